@@ -2,10 +2,17 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 	"workoutpal/src/internal/api"
 	"workoutpal/src/internal/config"
+	"workoutpal/src/internal/db/migrations"
+	"workoutpal/src/internal/dependency"
+	"workoutpal/src/internal/domain/service"
 )
 
 func main() {
@@ -16,11 +23,38 @@ func main() {
 		return
 	}
 
-	r := api.RegisterRoutes(cfg, db)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(db, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	appDep := dependency.NewAppDependencies(cfg, db)
+	go advanceEnrollments(appDep.ProgramService)
+
+	r := api.NewRouter(cfg, appDep)
 	log.Printf("Server starting on port %s", cfg.Port)
 	log.Fatal(http.ListenAndServe(":"+cfg.Port, r))
 }
 
+// enrollmentAdvanceInterval is how often program enrollments are moved on to
+// the weeks that have started
+const enrollmentAdvanceInterval = 15 * time.Minute
+
+// advanceEnrollments runs at start-up and then on every tick for as long as
+// the server does
+func advanceEnrollments(programs service.ProgramService) {
+	ticker := time.NewTicker(enrollmentAdvanceInterval)
+	defer ticker.Stop()
+	for {
+		if err := programs.AdvanceEnrollments(); err != nil {
+			log.Printf("advancing program enrollments: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
 func connectToDatabase(cfg *config.Config) (*sql.DB, error) {
 	db, err := sql.Open("postgres", cfg.DatabaseURL)
 	if err != nil {
@@ -31,3 +65,55 @@ func connectToDatabase(cfg *config.Config) (*sql.DB, error) {
 	}
 	return db, nil
 }
+
+// migrate handles `migrate up`, `migrate down [steps]` and `migrate status`
+func migrate(db *sql.DB, args []string) error {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			log.Printf("applied %03d_%s", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			log.Printf("database is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("steps must be a number: %w", err)
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			log.Printf("reverted %03d_%s", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%03d_%-40s %s\n", s.Version, s.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, expected up, down or status", command)
+	}
+}
@@ -1523,3 +1523,487 @@ values
     (6,'First_Share','Share a workout routine',now(),'💡','Knowledge Sharing');
 
 
+-- Logged workout sessions, started from a routine
+CREATE TABLE IF NOT EXISTS workout_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    routine_id INTEGER,
+    name VARCHAR,
+    notes TEXT,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (routine_id) REFERENCES workout_routine(id) ON DELETE SET NULL
+);
+
+-- Sets actually performed during a session
+CREATE TABLE IF NOT EXISTS workout_sets (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    set_number INTEGER NOT NULL,
+    weight NUMERIC NOT NULL DEFAULT 0,
+    reps INTEGER NOT NULL,
+    rpe NUMERIC,
+    rest_seconds INTEGER NOT NULL DEFAULT 0,
+    logged_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_id ON workout_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_workout_sets_session_id ON workout_sets(session_id);
+-- Only one session per user can be in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_sessions_one_active ON workout_sessions(user_id) WHERE finished_at IS NULL;
+
+
+-- Personal record history; a row is only written when a set beats the previous best
+CREATE TABLE IF NOT EXISTS personal_records (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    record_type VARCHAR NOT NULL CHECK (record_type IN ('max_weight', 'estimated_1rm', 'max_reps', 'max_volume')),
+    formula VARCHAR NOT NULL DEFAULT '',
+    value NUMERIC NOT NULL,
+    weight NUMERIC NOT NULL DEFAULT 0,
+    reps INTEGER NOT NULL DEFAULT 0,
+    session_id INTEGER,
+    set_id INTEGER,
+    achieved_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    FOREIGN KEY (set_id) REFERENCES workout_sets(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_records_user_exercise ON personal_records(user_id, exercise_id, record_type);
+
+-- Declarative rules for achievements; the service awards them once a user's
+-- progress for criteria_type reaches criteria_threshold.
+-- Achievements without a criteria_type can only be granted by an admin.
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS criteria_type VARCHAR;
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS criteria_threshold INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_achievements_criteria_type ON achievements(criteria_type);
+
+UPDATE achievements SET criteria_type = 'routines', criteria_threshold = 1 WHERE name = 'First_Routine';
+UPDATE achievements SET criteria_type = 'followers', criteria_threshold = 5 WHERE name = '5_Followers';
+UPDATE achievements SET criteria_type = 'following', criteria_threshold = 5 WHERE name = '5_Following';
+UPDATE achievements SET criteria_type = 'likes_given', criteria_threshold = 1 WHERE name = 'First_Like';
+UPDATE achievements SET criteria_type = 'posts', criteria_threshold = 1 WHERE name = 'First_Share';
+
+-- the seed inserted explicit ids, so move the sequence past them
+SELECT setval(pg_get_serial_sequence('achievements', 'id'), (SELECT COALESCE(MAX(id), 1) FROM achievements));
+
+INSERT INTO achievements (name, description, created_at, badge_icon, title, criteria_type, criteria_threshold)
+SELECT v.name, v.description, NOW(), v.badge_icon, v.title, v.criteria_type, v.criteria_threshold
+FROM (VALUES
+    ('10_Posts', 'Share 10 posts.', '📣', 'Regular Poster', 'posts', 10),
+    ('First_Schedule', 'Create a schedule for the first time.', '🗓️', 'Planner', 'schedules', 1),
+    ('10_Scheduled_Workouts', 'Finish 10 workouts from your schedules.', '⏰', 'Right On Time', 'scheduled_workouts', 10),
+    ('7_Day_Streak', 'Finish a workout 7 days in a row.', '🔥', 'On Fire', 'workout_streak', 7)
+) AS v(name, description, badge_icon, title, criteria_type, criteria_threshold)
+WHERE NOT EXISTS (SELECT 1 FROM achievements a WHERE a.name = v.name);
+
+-- Intensity and expertise were part of the API model but never stored
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS intensity VARCHAR;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS expertise VARCHAR;
+
+-- Keyset pagination over the default name ordering
+CREATE INDEX IF NOT EXISTS idx_exercises_name_id ON exercises ((LOWER(COALESCE(name, '')) COLLATE "C"), id);
+
+-- User-authored exercises live next to the built-in catalogue (owner_id IS NULL).
+-- They are private to their author unless is_public is set.
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS is_public BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS demo VARCHAR;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_count INTEGER;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_sets INTEGER;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_duration INTEGER;
+
+UPDATE exercises SET custom = FALSE WHERE custom IS NULL AND owner_id IS NULL;
+ALTER TABLE exercises ALTER COLUMN custom SET DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_exercises_owner_id ON exercises(owner_id) WHERE owner_id IS NOT NULL;
+
+-- A session is one signed-in device. Its refresh tokens form a family: each refresh
+-- rotates to a new token, and presenting a used one again revokes the whole session.
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id VARCHAR PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user_id ON auth_sessions(user_id);
+
+-- Only a sha256 of each token is stored
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    session_id VARCHAR NOT NULL REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens(session_id);
+
+-- Google sign-in: google_id is the token's subject, accounts created through
+-- google get provider 'google' and an empty password so password login never matches
+ALTER TABLE users ADD COLUMN IF NOT EXISTS google_id VARCHAR UNIQUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS provider VARCHAR NOT NULL DEFAULT 'local';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Roles: user, moderator and admin. The role is copied into the access token
+-- whenever a session is created or refreshed.
+UPDATE users SET role = 'user' WHERE role IS NULL OR role NOT IN ('user', 'moderator', 'admin');
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+
+-- Moderation: a suspended user can sign in again once suspended_until has passed,
+-- a banned user cannot until an admin reinstates them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+
+-- One row per admin action. Actor and target are plain ids, not foreign keys,
+-- so the trail outlives the users and content it mentions.
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id SERIAL PRIMARY KEY,
+    actor_id INTEGER NOT NULL,
+    action VARCHAR NOT NULL,
+    target_type VARCHAR NOT NULL,
+    target_id INTEGER NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target ON admin_audit_log(target_type, target_id);
+
+-- Anchors the first occurrence of each weekly schedule in calendar exports
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- One subscription URL per user. Only a sha256 of the secret in the URL is stored.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Time slots are wall clock times in the schedule's IANA time zone. Existing
+-- schedules were created without one and are read as UTC.
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS timezone VARCHAR NOT NULL DEFAULT 'UTC';
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS starts_on DATE;
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS ends_on DATE;
+
+-- Changes to single occurrences. occurs_on is the local date the occurrence was
+-- planned for; it is skipped when rescheduled_to is NULL and moved otherwise.
+CREATE TABLE IF NOT EXISTS schedule_exceptions (
+    id SERIAL PRIMARY KEY,
+    schedule_id INT NOT NULL REFERENCES schedule(id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    rescheduled_to TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (schedule_id, occurs_on)
+);
+
+-- How a planned occurrence went, when the user says so themselves. Occurrences
+-- without a mark are matched against finished workout sessions instead.
+CREATE TABLE IF NOT EXISTS schedule_occurrence_marks (
+    schedule_id INT NOT NULL REFERENCES schedule(id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('completed', 'missed', 'skipped')),
+    session_id INT REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (schedule_id, occurs_on)
+);
+
+-- The feed pages newest first by (created_at, id) and picks posts by author.
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts(user_id);
+CREATE INDEX IF NOT EXISTS idx_post_comments_post_id ON post_comments(post_id);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+-- Earlier versions of edited posts. written_at is when the version was posted
+-- or last edited, replaced_at when an edit replaced it.
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    title VARCHAR,
+    caption VARCHAR,
+    body TEXT,
+    status VARCHAR,
+    written_at TIMESTAMP,
+    replaced_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id ON post_revisions(post_id);
+
+-- A deleted comment keeps its row so its replies stay in the thread.
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Replies are paged per parent by (created_at, id).
+CREATE INDEX IF NOT EXISTS idx_post_comments_parent ON post_comments(parent_comment_id, created_at, id);
+
+-- A post can share one of its author's routines or finished sessions.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS routine_id INTEGER REFERENCES workout_routine(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS session_id INTEGER REFERENCES workout_sessions(id) ON DELETE SET NULL;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_one_workout;
+ALTER TABLE posts ADD CONSTRAINT posts_one_workout CHECK (routine_id IS NULL OR session_id IS NULL);
+
+-- Uploaded images. The bytes live in the blob store under media/<id>/original
+-- and media/<id>/thumbnail; ids are random so they can't be guessed.
+CREATE TABLE IF NOT EXISTS media (
+    id VARCHAR(32) PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    -- NULL when the image is small enough to be its own thumbnail
+    thumbnail_type VARCHAR,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_media_owner ON media(owner_id);
+
+CREATE TABLE IF NOT EXISTS post_media (
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    media_id VARCHAR(32) NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (post_id, media_id)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_media_id VARCHAR(32) REFERENCES media(id) ON DELETE SET NULL;
+
+-- A like is now one of several reactions; a user still reacts to a post once.
+ALTER TABLE post_likes ADD COLUMN IF NOT EXISTS reaction VARCHAR(16) NOT NULL DEFAULT 'like';
+ALTER TABLE post_likes DROP CONSTRAINT IF EXISTS post_likes_reaction;
+ALTER TABLE post_likes ADD CONSTRAINT post_likes_reaction CHECK (reaction IN ('like', 'fire', 'strong', 'clap'));
+CREATE INDEX IF NOT EXISTS idx_post_likes_post ON post_likes(post_id, created_at DESC, user_id DESC);
+
+-- Goals can be measured against logged data. Existing goals stay custom,
+-- free text goals whose status only changes by hand.
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS goal_type VARCHAR NOT NULL DEFAULT 'custom';
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE;
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS target_value NUMERIC;
+-- the body weight when a body weight goal was set, so progress has a baseline
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS start_value NUMERIC;
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_type;
+ALTER TABLE goals ADD CONSTRAINT goals_type CHECK (goal_type IN ('custom', 'body_weight', 'one_rep_max', 'workouts_per_week'));
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_measurable;
+ALTER TABLE goals ADD CONSTRAINT goals_measurable CHECK (
+    goal_type = 'custom'
+    OR (target_value > 0 AND (goal_type = 'one_rep_max') = (exercise_id IS NOT NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON goals(user_id);
+
+-- Body measurements over time, stored in SI units: kilograms for weight,
+-- centimetres for circumferences and percent for body fat. Values are
+-- converted to the user's preferred units when they are read.
+CREATE TABLE IF NOT EXISTS body_measurements (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric VARCHAR(16) NOT NULL,
+    value NUMERIC NOT NULL,
+    measured_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT body_measurements_metric CHECK (metric IN ('weight', 'body_fat', 'waist', 'chest', 'hips', 'neck', 'arm', 'thigh')),
+    CONSTRAINT body_measurements_value CHECK (value > 0 AND (metric <> 'body_fat' OR value < 100))
+);
+CREATE INDEX IF NOT EXISTS idx_body_measurements_user_metric ON body_measurements(user_id, metric, measured_at);
+
+-- seed each history with the weight on the profile
+INSERT INTO body_measurements (user_id, metric, value, measured_at)
+SELECT id, 'weight',
+    CASE WHEN LOWER(TRIM(weight_metric)) IN ('lb', 'lbs', 'pound', 'pounds') THEN weight * 0.45359237 ELSE weight END,
+    NOW()
+FROM users
+WHERE weight > 0
+  AND NOT EXISTS (SELECT 1 FROM body_measurements bm WHERE bm.user_id = users.id);
+
+-- Routines keep their description, an exercise order, and what to aim for
+-- on each exercise. Existing routines are ordered by exercise id.
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS position INTEGER;
+UPDATE exercises_in_routine e SET position = ordered.position
+FROM (
+    SELECT workout_routine_id, exercise_id,
+        ROW_NUMBER() OVER (PARTITION BY workout_routine_id ORDER BY exercise_id) - 1 AS position
+    FROM exercises_in_routine
+) ordered
+WHERE e.workout_routine_id = ordered.workout_routine_id AND e.exercise_id = ordered.exercise_id AND e.position IS NULL;
+ALTER TABLE exercises_in_routine ALTER COLUMN position SET DEFAULT 0;
+ALTER TABLE exercises_in_routine ALTER COLUMN position SET NOT NULL;
+
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_sets INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_reps_min INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_reps_max INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS rest_seconds INTEGER;
+-- exercises sharing a group within a routine are done back to back
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS superset_group INTEGER;
+ALTER TABLE exercises_in_routine DROP CONSTRAINT IF EXISTS exercises_in_routine_targets;
+ALTER TABLE exercises_in_routine ADD CONSTRAINT exercises_in_routine_targets CHECK (
+    (target_sets IS NULL OR target_sets > 0)
+    AND (target_reps_min IS NULL OR target_reps_min > 0)
+    AND (target_reps_max IS NULL OR target_reps_max >= COALESCE(target_reps_min, 1))
+    AND (rest_seconds IS NULL OR rest_seconds >= 0)
+    AND (superset_group IS NULL OR superset_group > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_exercises_in_routine_order ON exercises_in_routine(workout_routine_id, position);
+
+-- Routines are private unless their owner shares them with followers or with
+-- everyone. A fork remembers the routine and user it came from, and the
+-- source counts how many times it has been forked.
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'private';
+ALTER TABLE workout_routine DROP CONSTRAINT IF EXISTS workout_routine_visibility;
+ALTER TABLE workout_routine ADD CONSTRAINT workout_routine_visibility CHECK (visibility IN ('private', 'followers', 'public'));
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS forked_from_routine_id INTEGER REFERENCES workout_routine(id) ON DELETE SET NULL;
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS forked_from_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS fork_count INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_workout_routine_public ON workout_routine(fork_count DESC, id DESC) WHERE visibility = 'public';
+
+-- Training programs run for a number of weeks. Each week maps days of the
+-- week (0 = Sunday) to one of the author's routines, with a load given as a
+-- percentage of the estimated one rep max or as an RPE target.
+CREATE TABLE IF NOT EXISTS programs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    visibility VARCHAR(16) NOT NULL DEFAULT 'private',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT programs_visibility CHECK (visibility IN ('private', 'followers', 'public'))
+);
+CREATE INDEX IF NOT EXISTS idx_programs_user_id ON programs(user_id);
+
+CREATE TABLE IF NOT EXISTS program_weeks (
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL CHECK (week > 0),
+    name TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (program_id, week)
+);
+
+CREATE TABLE IF NOT EXISTS program_days (
+    program_id INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    routine_id INTEGER NOT NULL REFERENCES workout_routine(id) ON DELETE CASCADE,
+    load_type VARCHAR(16) NOT NULL,
+    load NUMERIC NOT NULL,
+    PRIMARY KEY (program_id, week, day_of_week),
+    FOREIGN KEY (program_id, week) REFERENCES program_weeks(program_id, week) ON DELETE CASCADE,
+    CONSTRAINT program_days_load CHECK (
+        (load_type = 'percent' AND load > 0 AND load <= 100)
+        OR (load_type = 'rpe' AND load >= 1 AND load <= 10)
+    )
+);
+
+-- current_week is the last week whose schedules have been generated; weeks
+-- start on started_on, so each one covers every day of the week once
+CREATE TABLE IF NOT EXISTS program_enrollments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    started_on DATE NOT NULL,
+    current_week INTEGER NOT NULL DEFAULT 1,
+    time_slot TIME NOT NULL,
+    routine_length_minutes INTEGER NOT NULL,
+    timezone VARCHAR NOT NULL DEFAULT 'UTC',
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT program_enrollments_status CHECK (status IN ('active', 'completed', 'cancelled'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_program_enrollments_active ON program_enrollments(user_id, program_id) WHERE status = 'active';
+
+-- the enrollee's own copy of each of the program's routines
+CREATE TABLE IF NOT EXISTS program_enrollment_routines (
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    program_routine_id INTEGER NOT NULL,
+    routine_id INTEGER NOT NULL REFERENCES workout_routine(id) ON DELETE CASCADE,
+    PRIMARY KEY (enrollment_id, program_routine_id)
+);
+
+-- schedules generated for an enrollment, one per program day
+CREATE TABLE IF NOT EXISTS program_enrollment_schedules (
+    schedule_id INTEGER PRIMARY KEY REFERENCES schedule(id) ON DELETE CASCADE,
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_program_enrollment_schedules_week ON program_enrollment_schedules(enrollment_id, week);
+
+-- Deleting a custom exercise hides it instead of removing the row, so the sets,
+-- records, routines and goals of other users that reference it survive
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Deleting a program cancels its active enrollments instead of removing them,
+-- so enrollees keep their history; program_id is cleared once it is gone
+ALTER TABLE program_enrollments ALTER COLUMN program_id DROP NOT NULL;
+ALTER TABLE program_enrollments DROP CONSTRAINT IF EXISTS program_enrollments_program_id_fkey;
+ALTER TABLE program_enrollments ADD CONSTRAINT program_enrollments_program_id_fkey
+    FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE SET NULL;
+
+-- A session keeps a single max_volume record per exercise, raised as sets are
+-- logged; earlier duplicates give way to the session's highest total
+DELETE FROM personal_records pr
+USING personal_records higher
+WHERE pr.record_type = 'max_volume'
+  AND higher.record_type = 'max_volume'
+  AND higher.user_id = pr.user_id
+  AND higher.exercise_id = pr.exercise_id
+  AND higher.session_id = pr.session_id
+  AND (higher.value, higher.id) > (pr.value, pr.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_personal_records_session_volume
+    ON personal_records(user_id, exercise_id, session_id, record_type)
+    WHERE record_type = 'max_volume';
+
+-- Migration tracking used by `server migrate`; this file already contains every
+-- migration below, so a database created from it starts up to date.
+-- Add a row here whenever a migration is appended to this file.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_migrations (version, name) VALUES
+    (1, 'init_schema'),
+    (2, 'unique_email_and_username'),
+    (3, 'add_age_column'),
+    (4, 'schedule'),
+    (5, 'modified_achievements'),
+    (6, 'add_privacy_fields'),
+    (7, 'add_follow_requests'),
+    (8, 'workout_sessions'),
+    (9, 'personal_records'),
+    (10, 'achievement_rules'),
+    (11, 'exercise_filters'),
+    (12, 'custom_exercises'),
+    (13, 'refresh_tokens'),
+    (14, 'google_accounts'),
+    (15, 'admin_roles'),
+    (16, 'calendar_feeds'),
+    (17, 'schedule_timezones'),
+    (18, 'schedule_adherence'),
+    (19, 'post_feed'),
+    (20, 'post_revisions'),
+    (21, 'comment_management'),
+    (22, 'post_workouts'),
+    (23, 'media'),
+    (24, 'post_reactions'),
+    (25, 'measurable_goals'),
+    (26, 'body_measurements'),
+    (27, 'routine_editing'),
+    (28, 'routine_sharing'),
+    (29, 'programs'),
+    (30, 'exercise_soft_delete'),
+    (31, 'program_enrollment_history'),
+    (32, 'personal_record_session_volume')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,402 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+
+	"workoutpal/src/internal/dependency"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/internal/policy"
+	"workoutpal/src/internal/service"
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util/constants"
+)
+
+const (
+	// reachable without a token
+	accessPublic = "public"
+	// any signed in user; the route only reads or writes data of the token's subject,
+	// or data that is already visible to everyone
+	accessSignedIn = "signed in"
+	// names another user's data in the path or body; strangers are refused
+	accessOwner = "owner"
+	// staff only; users without the moderator or admin role are refused
+	accessStaff = "staff"
+)
+
+// fixtures served by the read stubs in newAuthorizationDeps
+const (
+	fixtureOwnerID    = int64(1) // private profile that owns every fixture below
+	fixtureStrangerID = int64(2)
+	fixtureAdminID    = int64(3)
+	fixtureRoutineID  = int64(10)
+	fixturePostID     = int64(20)
+	fixtureScheduleID = int64(30)
+	fixtureRequestID  = int64(40)
+	fixtureExerciseID = int64(50)
+	fixtureSessionID  = int64(60)
+	fixtureCommentID  = int64(70)
+	fixtureProgramID  = int64(80)
+	fixtureEnrollID   = int64(90)
+)
+
+type routeRule struct {
+	method  string
+	pattern string
+	access  string
+	// owner and staff routes only: the request a stranger sends and the status they get back
+	path   string
+	body   string
+	status int
+}
+
+var routeRules = []routeRule{
+	{method: "GET", pattern: "/health", access: accessPublic},
+	{method: "POST", pattern: "/login", access: accessPublic},
+	{method: "POST", pattern: "/logout", access: accessPublic},
+	{method: "GET", pattern: "/me", access: accessSignedIn},
+	{method: "POST", pattern: "/auth/refresh", access: accessPublic},
+	{method: "POST", pattern: "/auth/google", access: accessPublic},
+	{method: "POST", pattern: "/auth/logout-all", access: accessSignedIn},
+
+	{method: "POST", pattern: "/users/", access: accessPublic},
+	{method: "GET", pattern: "/users/", access: accessSignedIn},
+	{method: "GET", pattern: "/users/{id}", access: accessSignedIn},
+	{method: "PATCH", pattern: "/users/{id}", access: accessOwner, path: "/users/1", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/users/{id}", access: accessOwner, path: "/users/1", status: http.StatusForbidden},
+	{method: "POST", pattern: "/users/{id}/avatar", access: accessOwner, path: "/users/1/avatar", status: http.StatusForbidden},
+	{method: "POST", pattern: "/users/{id}/goals", access: accessOwner, path: "/users/1/goals", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/goals", access: accessOwner, path: "/users/1/goals", status: http.StatusForbidden},
+	{method: "PATCH", pattern: "/users/{id}/goals/{goal_id}", access: accessOwner, path: "/users/1/goals/5", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/users/{id}/goals/{goal_id}", access: accessOwner, path: "/users/1/goals/5", status: http.StatusForbidden},
+	{method: "POST", pattern: "/users/{id}/follow", access: accessOwner, path: "/users/1/follow", status: http.StatusForbidden},
+	{method: "POST", pattern: "/users/{id}/unfollow", access: accessSignedIn},
+	{method: "GET", pattern: "/users/{id}/followers", access: accessSignedIn},
+	{method: "GET", pattern: "/users/{id}/following", access: accessSignedIn},
+	{method: "POST", pattern: "/users/{id}/follow-request", access: accessSignedIn},
+	{method: "DELETE", pattern: "/users/{id}/follow-request", access: accessSignedIn},
+	{method: "GET", pattern: "/users/{id}/follow-request/status", access: accessSignedIn},
+	{method: "POST", pattern: "/users/{id}/routines", access: accessOwner, path: "/users/1/routines", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/routines", access: accessOwner, path: "/users/1/routines", status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/users/{id}/routines/{routine_id}", access: accessOwner, path: "/users/1/routines/10", status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/records", access: accessOwner, path: "/users/1/records", status: http.StatusForbidden},
+	{method: "POST", pattern: "/users/{id}/measurements", access: accessOwner, path: "/users/1/measurements", body: `{"metric":"weight","value":80,"unit":"kg"}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/measurements", access: accessOwner, path: "/users/1/measurements", status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/measurements/trend", access: accessOwner, path: "/users/1/measurements/trend?metric=weight", status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/users/{id}/measurements/{measurement_id}", access: accessOwner, path: "/users/1/measurements/5", status: http.StatusForbidden},
+	{method: "GET", pattern: "/users/{id}/adherence", access: accessOwner, path: "/users/1/adherence", status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/follow-requests/", access: accessSignedIn},
+	{method: "POST", pattern: "/follow-requests/respond", access: accessOwner, path: "/follow-requests/respond", body: `{"requestID":40,"action":"accept"}`, status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/exercises/", access: accessSignedIn},
+	{method: "POST", pattern: "/exercises/", access: accessSignedIn},
+	{method: "GET", pattern: "/exercises/{id}", access: accessSignedIn},
+	{method: "PUT", pattern: "/exercises/{id}", access: accessOwner, path: "/exercises/50", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/exercises/{id}", access: accessOwner, path: "/exercises/50", status: http.StatusForbidden},
+	{method: "GET", pattern: "/exercises/{id}/records", access: accessOwner, path: "/exercises/50/records?userId=1", status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/routines/public", access: accessSignedIn},
+	{method: "GET", pattern: "/routines/{id}", access: accessOwner, path: "/routines/10", status: http.StatusForbidden},
+	{method: "POST", pattern: "/routines/{id}/fork", access: accessOwner, path: "/routines/10/fork", status: http.StatusForbidden},
+	{method: "PATCH", pattern: "/routines/{id}", access: accessOwner, path: "/routines/10", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "PUT", pattern: "/routines/{id}/exercises/order", access: accessOwner, path: "/routines/10/exercises/order", body: `{"exerciseIds":[]}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/routines/{id}", access: accessOwner, path: "/routines/10", status: http.StatusForbidden},
+	{method: "POST", pattern: "/routines/{id}/exercises", access: accessOwner, path: "/routines/10/exercises?exercise_id=50", status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/routines/{id}/exercises/{exercise_id}", access: accessOwner, path: "/routines/10/exercises/50", status: http.StatusForbidden},
+
+	{method: "POST", pattern: "/programs/", access: accessSignedIn},
+	{method: "GET", pattern: "/programs/enrollments", access: accessSignedIn},
+	{method: "DELETE", pattern: "/programs/enrollments/{id}", access: accessOwner, path: "/programs/enrollments/90", status: http.StatusForbidden},
+	{method: "GET", pattern: "/programs/{id}", access: accessOwner, path: "/programs/80", status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/programs/{id}", access: accessOwner, path: "/programs/80", status: http.StatusForbidden},
+	{method: "POST", pattern: "/programs/{id}/enroll", access: accessOwner, path: "/programs/80/enroll", body: `{"timeSlot":"07:00","routineLengthMinutes":60}`, status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/schedules/", access: accessSignedIn},
+	{method: "GET", pattern: "/schedules/of/{dayOfWeek}", access: accessSignedIn},
+	{method: "POST", pattern: "/schedules/", access: accessSignedIn},
+	{method: "GET", pattern: "/schedules/calendar.ics", access: accessSignedIn},
+	{method: "POST", pattern: "/schedules/calendar/feed", access: accessSignedIn},
+	{method: "DELETE", pattern: "/schedules/calendar/feed", access: accessSignedIn},
+	{method: "GET", pattern: "/schedules/occurrences", access: accessSignedIn},
+	{method: "POST", pattern: "/schedules/{id}/exceptions", access: accessOwner, path: "/schedules/30/exceptions", body: `{"date":"2025-03-03"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/schedules/{id}/exceptions/{date}", access: accessOwner, path: "/schedules/30/exceptions/2025-03-03", status: http.StatusForbidden},
+	{method: "PUT", pattern: "/schedules/{id}/occurrences/{date}", access: accessOwner, path: "/schedules/30/occurrences/2025-03-03", body: `{"status":"skipped"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/schedules/{id}/occurrences/{date}", access: accessOwner, path: "/schedules/30/occurrences/2025-03-03", status: http.StatusForbidden},
+	{method: "GET", pattern: "/calendar/{token}.ics", access: accessPublic},
+	{method: "GET", pattern: "/schedules/{id}", access: accessOwner, path: "/schedules/30", status: http.StatusForbidden},
+	// the update query is scoped to the token's subject
+	{method: "PUT", pattern: "/schedules/{id}", access: accessSignedIn},
+	{method: "DELETE", pattern: "/schedules/{id}", access: accessOwner, path: "/schedules/30", status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/posts/user/{id}", access: accessSignedIn},
+	{method: "GET", pattern: "/posts/", access: accessSignedIn},
+	{method: "POST", pattern: "/posts/", access: accessSignedIn},
+	{method: "PATCH", pattern: "/posts/{id}", access: accessOwner, path: "/posts/20", body: `{"title":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/posts/{id}", access: accessOwner, path: "/posts/20", status: http.StatusForbidden},
+	{method: "GET", pattern: "/posts/{id}/revisions", access: accessOwner, path: "/posts/20/revisions", status: http.StatusForbidden},
+	{method: "POST", pattern: "/posts/{id}/routine/copy", access: accessOwner, path: "/posts/20/routine/copy", status: http.StatusForbidden},
+	{method: "POST", pattern: "/posts/like", access: accessOwner, path: "/posts/like", body: `{"postId":20}`, status: http.StatusForbidden},
+	{method: "POST", pattern: "/posts/unlike", access: accessSignedIn},
+	{method: "GET", pattern: "/posts/{id}/reactions", access: accessOwner, path: "/posts/20/reactions", status: http.StatusForbidden},
+	{method: "POST", pattern: "/posts/comment", access: accessOwner, path: "/posts/comment", body: `{"postId":20,"comment":"x"}`, status: http.StatusForbidden},
+	{method: "POST", pattern: "/posts/comment/reply", access: accessOwner, path: "/posts/comment/reply", body: `{"postId":20,"commentId":70,"comment":"x"}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/posts/{id}/comments", access: accessOwner, path: "/posts/20/comments", status: http.StatusForbidden},
+	{method: "GET", pattern: "/posts/comments/{id}/replies", access: accessOwner, path: "/posts/comments/70/replies", status: http.StatusForbidden},
+	{method: "PATCH", pattern: "/posts/comments/{id}", access: accessOwner, path: "/posts/comments/70", body: `{"comment":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/posts/comments/{id}", access: accessOwner, path: "/posts/comments/70", status: http.StatusForbidden},
+
+	{method: "POST", pattern: "/media/", access: accessSignedIn},
+	{method: "GET", pattern: "/media/{id}", access: accessSignedIn},
+
+	{method: "GET", pattern: "/achievements/feed", access: accessSignedIn},
+	{method: "GET", pattern: "/achievements/", access: accessSignedIn},
+	{method: "POST", pattern: "/achievements/", access: accessOwner, path: "/achievements/", body: `{"userId":1,"achievementId":1}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/achievements/unlocked", access: accessSignedIn},
+	{method: "GET", pattern: "/achievements/unlocked/{id}", access: accessSignedIn},
+
+	{method: "GET", pattern: "/exercise-settings/", access: accessSignedIn},
+	{method: "POST", pattern: "/exercise-settings/", access: accessOwner, path: "/exercise-settings/", body: `{"exerciseId":50,"workoutRoutineId":10}`, status: http.StatusForbidden},
+	{method: "PUT", pattern: "/exercise-settings/", access: accessOwner, path: "/exercise-settings/", body: `{"exerciseId":50,"workoutRoutineId":10}`, status: http.StatusForbidden},
+
+	{method: "GET", pattern: "/sessions/", access: accessSignedIn},
+	{method: "POST", pattern: "/sessions/", access: accessOwner, path: "/sessions/", body: `{"routineId":10}`, status: http.StatusForbidden},
+	{method: "GET", pattern: "/sessions/active", access: accessSignedIn},
+	// other users' sessions are reported as missing
+	{method: "GET", pattern: "/sessions/{id}", access: accessOwner, path: "/sessions/60", status: http.StatusNotFound},
+	{method: "POST", pattern: "/sessions/{id}/sets", access: accessOwner, path: "/sessions/60/sets", body: `{"exerciseId":50,"reps":5,"weight":100}`, status: http.StatusNotFound},
+	{method: "POST", pattern: "/sessions/{id}/finish", access: accessOwner, path: "/sessions/60/finish", status: http.StatusNotFound},
+
+	{method: "POST", pattern: "/admin/posts/{id}/takedown", access: accessStaff, path: "/admin/posts/20/takedown", status: http.StatusForbidden},
+	{method: "POST", pattern: "/admin/users/{id}/suspend", access: accessStaff, path: "/admin/users/1/suspend", body: `{"until":"2999-01-01T00:00:00Z"}`, status: http.StatusForbidden},
+	{method: "POST", pattern: "/admin/users/{id}/ban", access: accessStaff, path: "/admin/users/1/ban", status: http.StatusForbidden},
+	{method: "POST", pattern: "/admin/users/{id}/reinstate", access: accessStaff, path: "/admin/users/1/reinstate", status: http.StatusForbidden},
+	{method: "PUT", pattern: "/admin/users/{id}/role", access: accessStaff, path: "/admin/users/1/role", body: `{"role":"admin"}`, status: http.StatusForbidden},
+	{method: "POST", pattern: "/admin/exercises", access: accessStaff, path: "/admin/exercises", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "PUT", pattern: "/admin/exercises/{id}", access: accessStaff, path: "/admin/exercises/50", body: `{"name":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/admin/exercises/{id}", access: accessStaff, path: "/admin/exercises/50", status: http.StatusForbidden},
+	{method: "POST", pattern: "/admin/achievements", access: accessStaff, path: "/admin/achievements", body: `{"title":"x"}`, status: http.StatusForbidden},
+	{method: "PUT", pattern: "/admin/achievements/{id}", access: accessStaff, path: "/admin/achievements/1", body: `{"title":"x"}`, status: http.StatusForbidden},
+	{method: "DELETE", pattern: "/admin/achievements/{id}", access: accessStaff, path: "/admin/achievements/1", status: http.StatusForbidden},
+	{method: "GET", pattern: "/admin/audit", access: accessStaff, path: "/admin/audit", status: http.StatusForbidden},
+}
+
+type authorizationRepos struct {
+	routines *mock_repository.MockRoutineRepository
+	posts    *mock_repository.MockPostRepository
+	audit    *mock_repository.MockAuditRepository
+}
+
+// newAuthorizationDeps wires the real services and access policy over mock
+// repositories. Only reads are stubbed, so any write reaching a repository
+// fails the test as an unexpected call.
+func newAuthorizationDeps(t *testing.T) (dependency.AppDependencies, authorizationRepos) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	users := mock_repository.NewMockUserRepository(ctrl)
+	relationships := mock_repository.NewMockRelationshipRepository(ctrl)
+	goals := mock_repository.NewMockGoalRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	routines := mock_repository.NewMockRoutineRepository(ctrl)
+	schedules := mock_repository.NewMockScheduleRepository(ctrl)
+	posts := mock_repository.NewMockPostRepository(ctrl)
+	achievements := mock_repository.NewMockAchievementRepository(ctrl)
+	exerciseSettings := mock_repository.NewMockExerciseSettingRepository(ctrl)
+	workoutSessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+	records := mock_repository.NewMockPersonalRecordRepository(ctrl)
+	sessions := mock_repository.NewMockSessionRepository(ctrl)
+	audit := mock_repository.NewMockAuditRepository(ctrl)
+	media := mock_repository.NewMockMediaRepository(ctrl)
+	measurements := mock_repository.NewMockBodyMeasurementRepository(ctrl)
+	programs := mock_repository.NewMockProgramRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).AnyTimes().DoAndReturn(func(work func(repository.TxRepositories) error) error {
+		return work(repository.TxRepositories{Exercises: exercises, Achievements: achievements, Users: users, Sessions: sessions, Posts: posts, Audit: audit})
+	})
+
+	sessions.EXPECT().IsSessionActive(gomock.Any()).Return(true, nil).AnyTimes()
+	users.EXPECT().ReadUserByID(fixtureOwnerID).Return(&model.User{ID: fixtureOwnerID, IsPrivate: true}, nil).AnyTimes()
+	users.EXPECT().ReadUserRole(fixtureOwnerID).Return(constants.ROLE_USER, nil).AnyTimes()
+	users.EXPECT().ReadUserRole(fixtureStrangerID).Return(constants.ROLE_USER, nil).AnyTimes()
+	users.EXPECT().ReadUserRole(fixtureAdminID).Return(constants.ROLE_ADMIN, nil).AnyTimes()
+	relationships.EXPECT().ReadUserFollowers(fixtureOwnerID).Return([]int64{}, nil).AnyTimes()
+	relationships.EXPECT().GetFollowRequestByID(fixtureRequestID).
+		Return(&model.FollowRequestModel{ID: fixtureRequestID, RequesterID: fixtureAdminID, RequestedID: fixtureOwnerID}, nil).AnyTimes()
+	routines.EXPECT().ReadRoutineWithExercises(fixtureRoutineID).
+		Return(&model.ExerciseRoutine{ID: fixtureRoutineID, UserID: fixtureOwnerID}, nil).AnyTimes()
+	posts.EXPECT().ReadPostOwnerID(fixturePostID).Return(fixtureOwnerID, nil).AnyTimes()
+	posts.EXPECT().ReadCommentRef(fixtureCommentID).
+		Return(&model.CommentRef{ID: fixtureCommentID, PostID: fixturePostID, AuthorID: fixtureOwnerID, PostAuthorID: fixtureOwnerID}, nil).AnyTimes()
+	schedules.EXPECT().ReadScheduleByID(fixtureScheduleID).
+		Return(&model.Schedule{ID: fixtureScheduleID, UserID: fixtureOwnerID}, nil).AnyTimes()
+	exercises.EXPECT().ReadExerciseByID(fixtureExerciseID).
+		Return(&model.Exercise{ID: fixtureExerciseID, OwnerID: fixtureOwnerID, IsPublic: true}, nil).AnyTimes()
+	workoutSessions.EXPECT().ReadSessionByID(fixtureSessionID).
+		Return(&model.WorkoutSession{ID: fixtureSessionID, UserID: fixtureOwnerID}, nil).AnyTimes()
+	programs.EXPECT().ReadProgramByID(fixtureProgramID).
+		Return(&model.Program{ID: fixtureProgramID, UserID: fixtureOwnerID}, nil).AnyTimes()
+	programs.EXPECT().ReadEnrollmentByID(fixtureEnrollID).
+		Return(&model.ProgramEnrollment{ID: fixtureEnrollID, UserID: fixtureOwnerID, ProgramID: fixtureProgramID}, nil).AnyTimes()
+
+	accessPolicy := policy.NewAccessPolicy(users, relationships)
+	achievementService := service.NewAchievementService(achievements, users)
+	goalService := service.NewGoalService(goals, accessPolicy)
+	personalRecordService := service.NewPersonalRecordService(records, accessPolicy)
+	routineService := service.NewRoutineService(routines, exercises, achievementService, accessPolicy)
+	scheduleService := service.NewScheduleService(schedules, routines, workoutSessions, achievementService, accessPolicy)
+
+	deps := dependency.AppDependencies{
+		UserService:            service.NewUserService(users, accessPolicy),
+		RelationshipService:    service.NewRelationshipService(relationships, users, achievementService, accessPolicy),
+		GoalService:            goalService,
+		ExerciseService:        service.NewExerciseService(exercises),
+		RoutineService:         routineService,
+		ScheduleService:        scheduleService,
+		AuthService:            service.NewAuthService(users, sessions, mock_service.NewMockIDTokenVerifier(ctrl)),
+		PostService:            service.NewPostService(posts, media, routineService, workoutSessions, achievementService, accessPolicy),
+		AchievementService:     achievementService,
+		ExerciseSettingService: service.NewExerciseSettingService(exerciseSettings, routines, accessPolicy),
+		WorkoutSessionService:  service.NewWorkoutSessionService(workoutSessions, routines, personalRecordService, achievementService, goalService),
+		PersonalRecordService:  personalRecordService,
+		AdminService:           service.NewAdminService(users, posts, audit, transactor),
+		MediaService:           service.NewMediaService(media, users, nil, accessPolicy),
+		BodyMeasurementService: service.NewBodyMeasurementService(measurements, users, accessPolicy, goalService),
+		ProgramService:         service.NewProgramService(programs, routines, records, routineService, scheduleService, accessPolicy),
+	}
+	return deps, authorizationRepos{routines: routines, posts: posts, audit: audit}
+}
+
+func bearerFor(t *testing.T, userID int64) map[string]string {
+	t.Helper()
+	return bearerWithRole(t, userID, constants.ROLE_USER)
+}
+
+func bearerWithRole(t *testing.T, userID int64, role string) map[string]string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  float64(userID),
+		"sid":  "session",
+		"role": role,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return map[string]string{"Authorization": "Bearer " + token, "Content-Type": "application/json"}
+}
+
+func newAuthorizationServer(t *testing.T) (*httptest.Server, authorizationRepos) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("JWT_SECRET", "")
+
+	deps, repos := newAuthorizationDeps(t)
+	r := chi.NewRouter()
+	Routes(r, deps, []byte("test-secret"))
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	return ts, repos
+}
+
+func TestRoutes_EveryRouteHasAnAccessRule(t *testing.T) {
+	r := chi.NewRouter()
+	Routes(r, dependency.AppDependencies{}, []byte("test-secret"))
+
+	rules := make(map[string]bool, len(routeRules))
+	for _, rule := range routeRules {
+		rules[rule.method+" "+rule.pattern] = true
+	}
+
+	registered := make(map[string]bool)
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + strings.ReplaceAll(route, "/*/", "/")
+		registered[key] = true
+		if !rules[key] {
+			t.Errorf("%s has no access rule", key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+	for key := range rules {
+		if !registered[key] {
+			t.Errorf("access rule for %s matches no route", key)
+		}
+	}
+}
+
+func TestRoutes_OwnerRoutesRefuseStrangers(t *testing.T) {
+	ts, _ := newAuthorizationServer(t)
+
+	for _, rule := range routeRules {
+		if rule.access != accessOwner && rule.access != accessStaff {
+			continue
+		}
+		t.Run(rule.method+" "+rule.pattern, func(t *testing.T) {
+			resp := do(ts, rule.method, rule.path, strings.NewReader(rule.body), bearerFor(t, fixtureStrangerID))
+			defer resp.Body.Close()
+
+			if resp.StatusCode != rule.status {
+				t.Fatalf("%s %s status = %d, want %d", rule.method, rule.path, resp.StatusCode, rule.status)
+			}
+		})
+	}
+}
+
+func TestRoutes_SignedInRoutesRequireAToken(t *testing.T) {
+	ts, _ := newAuthorizationServer(t)
+
+	for _, rule := range routeRules {
+		if rule.access == accessPublic {
+			continue
+		}
+		path := strings.NewReplacer("{id}", "1", "{routine_id}", "1", "{exercise_id}", "1", "{dayOfWeek}", "1", "{date}", "2025-03-03").Replace(rule.pattern)
+		resp := do(ts, rule.method, path, nil, nil)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s %s without a token status = %d, want 401", rule.method, path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRoutes_AdminMayDeleteAnotherUsersRoutine(t *testing.T) {
+	ts, repos := newAuthorizationServer(t)
+	repos.routines.EXPECT().DeleteRoutine(fixtureRoutineID).Return(nil)
+
+	resp := do(ts, http.MethodDelete, "/routines/10", nil, bearerFor(t, fixtureAdminID))
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		t.Fatalf("DELETE /routines/10 as admin status = %d, want success", resp.StatusCode)
+	}
+}
+
+func TestRoutes_ModeratorMayTakeDownPostsButNotBan(t *testing.T) {
+	ts, repos := newAuthorizationServer(t)
+	repos.posts.EXPECT().DeletePost(fixturePostID).Return(nil)
+	repos.audit.EXPECT().CreateAuditRecord(gomock.Any()).Return(&model.AuditRecord{ID: 1}, nil)
+	moderator := bearerWithRole(t, fixtureAdminID, constants.ROLE_MODERATOR)
+
+	resp := do(ts, http.MethodPost, "/admin/posts/20/takedown", nil, moderator)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		t.Fatalf("takedown as moderator status = %d, want success", resp.StatusCode)
+	}
+
+	resp = do(ts, http.MethodPost, "/admin/users/1/ban", strings.NewReader(`{}`), moderator)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("ban as moderator status = %d, want 403", resp.StatusCode)
+	}
+}
@@ -62,7 +62,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a user_achievement row (i.e., marks the achievement as earned for the user).",
+                "description": "Creates a user_achievement row (i.e., marks the achievement as earned for the user). Achievements with criteria are awarded automatically; this endpoint is for admins granting badges by hand.",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,7 +72,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Unlock an achievement for a user",
+                "summary": "Unlock an achievement for a user (admin only)",
                 "parameters": [
                     {
                         "description": "Unlock achievement payload",
@@ -103,6 +103,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -253,8 +259,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/google": {
+        "/admin/achievements": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds an achievement users can unlock. With a criteriaType it is awarded automatically once the user's count reaches threshold, without one it can only be granted by an admin. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,35 +274,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Authenticate with Google OAuth",
+                "summary": "Define an achievement",
                 "parameters": [
                     {
-                        "description": "Google ID token",
+                        "description": "Achievement definition",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.GoogleAuthRequest"
+                            "$ref": "#/definitions/model.AchievementDefinitionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Authentication successful",
+                    "201": {
+                        "description": "Achievement created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.AuthResponse"
+                            "$ref": "#/definitions/model.Achievement"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or token",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "401": {
-                        "description": "Authentication failed",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -298,13 +322,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/exercise-settings": {
-            "get": {
+        "/admin/achievements/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Replaces an achievement's text and unlock rule. Users who already earned it keep it. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -312,29 +337,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exercise Setting"
+                    "Admin"
                 ],
+                "summary": "Update an achievement definition",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Exercise ID",
-                        "name": "exercise_id",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Workout Routine ID",
-                        "name": "workout_routine_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Achievement definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementDefinitionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exercise Setting",
+                        "description": "Achievement updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.ExerciseSetting"
+                            "$ref": "#/definitions/model.Achievement"
                         }
                     },
                     "400": {
@@ -349,6 +377,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -357,41 +397,32 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Only achievements nobody has earned yet can be deleted. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Exercise Setting"
+                    "Admin"
                 ],
+                "summary": "Delete an achievement definition",
                 "parameters": [
                     {
-                        "description": "Update exercise setting payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateExerciseSettingRequest"
-                        }
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exercise Setting updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.ExerciseSetting"
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error",
+                        "description": "Achievement deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -402,55 +433,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Not an admin",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Exercise Setting"
-                ],
-                "parameters": [
-                    {
-                        "description": "New exercise setting payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateExerciseSettingRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Exercise Setting created successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.ExerciseSetting"
-                        }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Achievement not found",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Achievement has been earned",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -464,50 +460,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/exercises": {
+        "/admin/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists admin actions, newest first. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Exercises"
+                    "Admin"
                 ],
-                "summary": "List exercises",
+                "summary": "Read the admin audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Primary target muscle",
-                        "name": "target",
+                        "description": "Only actions on this kind of record: exercise, achievement, user or post",
+                        "name": "targetType",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Training intensity",
-                        "name": "intensity",
+                        "type": "integer",
+                        "description": "Only actions on the record with this id",
+                        "name": "targetId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Recommended expertise level",
-                        "name": "expertise",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 50 and at most 200",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exercises retrieved successfully",
+                        "description": "Audit records",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Exercise"
+                                "$ref": "#/definitions/model.AuditRecord"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
                             }
                         }
                     },
@@ -523,6 +529,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -530,14 +542,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/exercises": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a custom exercise with metadata such as targets, intensity, expertise, and media references.",
+                "description": "Creates a built-in exercise that every user can see. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,9 +559,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exercises"
+                    "Admin"
                 ],
-                "summary": "Create a new exercise",
+                "summary": "Add an exercise to the catalogue",
                 "parameters": [
                     {
                         "description": "New exercise payload",
@@ -578,6 +592,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -587,13 +607,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/exercises/{id}": {
-            "get": {
+        "/admin/exercises/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Replaces the fields of a built-in exercise; custom exercises can only be changed by their author. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,12 +622,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exercises"
+                    "Admin"
+                ],
+                "summary": "Update a catalogue exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exercise update payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateExerciseRequest"
+                        }
+                    }
                 ],
-                "summary": "Returns the exercise with the corresponding ID",
                 "responses": {
                     "200": {
-                        "description": "Exercises retrieved successfully",
+                        "description": "Exercise updated successfully",
                         "schema": {
                             "$ref": "#/definitions/model.Exercise"
                         }
@@ -623,6 +662,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No catalogue exercise with this id",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -630,25 +681,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/follow-requests": {
-            "get": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a built-in exercise, removing it from routines and logged sets. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
+                    "Admin"
+                ],
+                "summary": "Delete a catalogue exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all pending follow requests for the authenticated user",
                 "responses": {
                     "200": {
-                        "description": "Pending follow requests retrieved successfully",
+                        "description": "Exercise deleted successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.FollowRequestWithUser"
-                            }
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No catalogue exercise with this id",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "500": {
@@ -660,8 +739,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/follow-requests/respond": {
+        "/admin/posts/{id}/takedown": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes another user's post together with its likes and comments. Moderators and admins.",
                 "consumes": [
                     "application/json"
                 ],
@@ -669,112 +754,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
+                    "Admin"
                 ],
-                "summary": "Accept or reject a follow request",
+                "summary": "Take down a post",
                 "parameters": [
                     {
-                        "description": "Follow request response",
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.FollowRequestResponse"
+                            "$ref": "#/definitions/model.TakeDownPostRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Follow request processed successfully",
+                        "description": "Post taken down",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Authenticates a user and sets access_token as cookie",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Logs in a user",
-                "parameters": [
-                    {
-                        "description": "comment",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Not a moderator",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/logout": {
-            "post": {
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Logs out user by clearing access_token",
-                "responses": {
-                    "200": {
-                        "description": "successful logout",
+                    },
+                    "404": {
+                        "description": "Post not found",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/me": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Get current authenticated user",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
             }
         },
-        "/posts": {
-            "get": {
+        "/admin/users/{id}/ban": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Signs the user out everywhere and refuses new sessions until an admin reinstates them. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -782,25 +823,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Admin"
                 ],
-                "summary": "List posts",
+                "summary": "Ban a user",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "If true, only posts from followed users are returned",
-                        "name": "followings",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BanUserRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Posts retrieved successfully",
+                        "description": "User banned",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Post"
-                            }
+                            "$ref": "#/definitions/model.UserStanding"
                         }
                     },
                     "400": {
@@ -815,6 +863,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin, or the user is an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -822,49 +882,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/reinstate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lifts a suspension or ban. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Admin"
                 ],
-                "summary": "Create a new post",
+                "summary": "Reinstate a user",
                 "parameters": [
                     {
-                        "description": "New post payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreatePostRequest"
-                        }
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Post created successfully",
+                    "200": {
+                        "description": "User reinstated",
                         "schema": {
-                            "$ref": "#/definitions/model.Post"
+                            "$ref": "#/definitions/model.UserStanding"
                         }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -878,13 +942,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/comment": {
-            "post": {
+        "/admin/users/{id}/role": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Sets the role to user, moderator or admin and signs the user out so their next token carries it. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -892,25 +957,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Admin"
                 ],
-                "summary": "Comment on a post",
+                "summary": "Change a user's role",
                 "parameters": [
                     {
-                        "description": "Target post and comment text",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CommentOnPostRequest"
+                            "$ref": "#/definitions/model.UpdateUserRoleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Comment added successfully",
+                        "description": "Role changed",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.UserStanding"
                         }
                     },
                     "400": {
@@ -925,6 +997,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -934,13 +1018,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/comment/reply": {
+        "/admin/users/{id}/suspend": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Signs the user out everywhere and refuses new sessions until the given time. Moderators and admins; only admins can suspend a moderator.",
                 "consumes": [
                     "application/json"
                 ],
@@ -948,25 +1033,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Admin"
                 ],
-                "summary": "Comment on another comment",
+                "summary": "Suspend a user",
                 "parameters": [
                     {
-                        "description": "Target comment and reply text",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension end and reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CommentOnCommentRequest"
+                            "$ref": "#/definitions/model.SuspendUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reply added successfully",
+                        "description": "User suspended",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.UserStanding"
                         }
                     },
                     "400": {
@@ -981,6 +1073,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not a moderator, the user is an admin, or the user is a moderator and the caller isn't an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -990,13 +1094,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/like": {
+        "/auth/google": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+                "description": "Verifies a Google ID token, links or provisions the account and sets the same cookies as /login",
                 "consumes": [
                     "application/json"
                 ],
@@ -1004,55 +1104,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "auth"
                 ],
-                "summary": "Likes a post",
+                "summary": "Authenticate with Google",
                 "parameters": [
                     {
-                        "description": "Like post body",
+                        "description": "Google ID token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.LikePostRequest"
+                            "$ref": "#/definitions/model.GoogleAuthRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Authentication successful",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.AuthResponse"
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Invalid request or token",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Authentication failed",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Account suspended or banned",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Error"
                         }
                     }
                 }
             }
         },
-        "/posts/unlike": {
+        "/auth/logout-all": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Revokes all sessions of the current user, their access and refresh tokens stop working immediately",
+                "tags": [
+                    "auth"
                 ],
+                "summary": "Logs out every device",
+                "responses": {
+                    "200": {
+                        "description": "successful logout",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Trades the refresh_token cookie (or body) for a new access token and refresh token. Reusing a refresh token revokes its session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1060,17 +1173,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "auth"
                 ],
-                "summary": "Unlikes a post",
+                "summary": "Refreshes the access token",
                 "parameters": [
                     {
-                        "description": "Unlike post body",
+                        "description": "refresh token, when not sent as a cookie",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UnikePostRequest"
+                            "$ref": "#/definitions/model.RefreshRequest"
                         }
                     }
                 ],
@@ -1078,80 +1190,52 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error",
-                        "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Account suspended or banned",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Error"
                         }
                     }
                 }
             }
         },
-        "/posts/user/{id}": {
+        "/calendar/{token}.ics": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Serves the schedules of the user who created the subscription URL. The token in the path authenticates the request.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Posts"
+                    "schedules"
                 ],
-                "summary": "List posts for a specific user",
+                "summary": "Read a calendar subscription",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Target user ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Subscription token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Posts retrieved successfully",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Post"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error",
-                        "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "iCalendar file",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Unknown or revoked subscription",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1159,8 +1243,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{id}": {
-            "delete": {
+        "/exercise-settings": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1173,23 +1257,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Exercise Setting"
                 ],
-                "summary": "Delete a post",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Post ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workout Routine ID",
+                        "name": "workout_routine_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Post deleted successfully",
+                        "description": "Exercise Setting",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.ExerciseSetting"
                         }
                     },
                     "400": {
@@ -1204,12 +1294,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "Post not found",
-                        "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1217,79 +1301,119 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/routines/{id}": {
-            "get": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Exercise Setting"
                 ],
-                "summary": "Get routine with exercises",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Routine ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Update exercise setting payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateExerciseSettingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Routine with exercises retrieved successfully",
+                        "description": "Exercise Setting updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.ExerciseRoutine"
+                            "$ref": "#/definitions/model.ExerciseSetting"
                         }
                     },
                     "400": {
-                        "description": "Invalid routine ID",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "Routine not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Routine belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Exercise Setting"
                 ],
-                "summary": "Delete a routine",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Routine ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "New exercise setting payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateExerciseSettingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Routine deleted successfully",
+                    "201": {
+                        "description": "Exercise Setting created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.ExerciseSetting"
                         }
                     },
                     "400": {
-                        "description": "Invalid routine ID",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "Routine not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Routine belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1297,8 +1421,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/routines/{id}/exercises": {
-            "post": {
+        "/exercises": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Filters, searches and pages the exercise catalogue. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1306,101 +1436,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Exercises"
                 ],
-                "summary": "Add exercise to routine",
+                "summary": "List exercises",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Routine ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Target muscle, matched against any of the exercise targets",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Training intensity; exercises without one don't match",
+                        "name": "intensity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recommended expertise level; exercises without one don't match",
+                        "name": "expertise",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text searched for in the name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: name (default), -name, id or -id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Exercise ID",
-                        "name": "exercise_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Page size, default 50 and at most 200",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exercise added to routine successfully",
+                        "description": "Exercises retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Exercise"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/routines/{id}/exercises/{exercise_id}": {
-            "delete": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Routines"
-                ],
-                "summary": "Remove exercise from routine",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Routine ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Exercise ID",
-                        "name": "exercise_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Exercise removed from routine successfully",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
-            }
-        },
-        "/schedules": {
-            "get": {
-                "tags": [
-                    "schedules"
-                ],
-                "summary": "Read all schedules for the authenticated user",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Schedule"
-                            }
-                        }
-                    }
-                }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a custom exercise owned by the caller. It is private unless isPublic is set, in which case every user can find it in /exercises.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1408,83 +1533,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "Exercises"
                 ],
-                "summary": "Create a schedule",
+                "summary": "Create a new exercise",
                 "parameters": [
                     {
-                        "description": "Schedule create payload",
+                        "description": "New exercise payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateScheduleRequest"
+                            "$ref": "#/definitions/model.CreateExerciseRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Exercise created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Schedule"
+                            "$ref": "#/definitions/model.Exercise"
                         }
-                    }
-                }
-            }
-        },
-        "/schedules/{dayOfWeek}": {
-            "get": {
-                "tags": [
-                    "schedules"
-                ],
-                "summary": "Read schedules for the authenticated user on a specific day",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Day of week (0-6)",
-                        "name": "dayOfWeek",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Schedule"
-                            }
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
             }
         },
-        "/schedules/{id}": {
+        "/exercises/{id}": {
             "get": {
-                "tags": [
-                    "schedules"
-                ],
-                "summary": "Read schedule by ID",
-                "parameters": [
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Schedule"
-                        }
-                    }
-                }
-            },
-            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1492,77 +1589,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "Exercises"
                 ],
-                "summary": "Update a schedule",
+                "summary": "Returns the exercise with the corresponding ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
+                        "description": "Exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Schedule update payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateScheduleRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Exercises retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Schedule"
+                            "$ref": "#/definitions/model.Exercise"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "tags": [
-                    "schedules"
-                ],
-                "summary": "Delete a schedule",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
+                    },
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get all users",
-                "responses": {
-                    "200": {
-                        "description": "Users retrieved successfully",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exercise not found or private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "500": {
@@ -1573,12 +1634,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Replaces the fields of a custom exercise. Only its author can edit it; built-in exercises cannot be edited.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1586,25 +1648,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Exercises"
                 ],
-                "summary": "Create a new user",
+                "summary": "Update a custom exercise",
                 "parameters": [
                     {
-                        "description": "New user payload",
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exercise update payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateUserRequest"
+                            "$ref": "#/definitions/model.UpdateExerciseRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "200": {
+                        "description": "Exercise updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Exercise"
                         }
                     },
                     "400": {
@@ -1619,6 +1688,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not the author",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exercise not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1626,21 +1707,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hides a custom exercise from listings and from new routines. Routines, logged sets, records and goals that already use it keep it, and it stays readable by id flagged as deleted. Only its author can delete it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Exercises"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Delete a custom exercise",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1648,107 +1733,121 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully",
+                        "description": "Exercise deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user ID",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the author",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Exercise not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/exercises/{id}/records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Defaults to the caller's own records; pass userId to view another user's history, subject to their privacy settings.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Records"
                 ],
-                "summary": "Delete user by ID",
+                "summary": "Get the personal record history for an exercise",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Owner of the records (defaults to the caller)",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "epley",
+                        "description": "Estimated 1RM formula (epley or brzycki)",
+                        "name": "formula",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Record history retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PersonalRecord"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid query parameter",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Records are not visible to the caller",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/follow-requests": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Update user by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update user payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateUserRequest"
-                        }
-                    }
+                    "Relationships"
                 ],
+                "summary": "Get all pending follow requests for the authenticated user",
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "400": {
-                        "description": "Validation error",
+                        "description": "Pending follow requests retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FollowRequestWithUser"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1756,40 +1855,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/follow": {
+        "/follow-requests/respond": {
             "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Relationships"
                 ],
-                "summary": "Follow a user",
+                "summary": "Accept or reject a follow request",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID to follow",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Follower user ID",
-                        "name": "follower_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Follow request response",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FollowRequestResponse"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully followed user",
+                        "description": "Follow request processed successfully",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Request was sent to another user",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1797,79 +1900,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/follow-request": {
+        "/login": {
             "post": {
+                "description": "Authenticates a user and sets the access_token and refresh_token cookies",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
+                    "auth"
                 ],
-                "summary": "Send a follow request to a private profile",
+                "summary": "Logs in a user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID to request to follow",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Requester user ID",
-                        "name": "requester_id",
-                        "in": "query",
-                        "required": true
+                        "description": "comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Follow request sent successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user ID",
+                    "403": {
+                        "description": "Account suspended or banned",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Error"
                         }
                     }
                 }
-            },
-            "delete": {
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "The session is found from the refresh token, or from the access token for clients that only hold that one",
                 "tags": [
-                    "Relationships"
-                ],
-                "summary": "Cancel a pending follow request",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID whose request to cancel",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Requester user ID",
-                        "name": "requester_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logs out user by revoking the session and clearing its cookies",
                 "responses": {
                     "200": {
-                        "description": "Follow request cancelled successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user ID",
+                        "description": "successful logout",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1877,83 +1957,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/follow-request/status": {
+        "/me": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
-                ],
-                "summary": "Get the status of a follow request",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Requester user ID",
-                        "name": "requester_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Get current authenticated user",
                 "responses": {
                     "200": {
-                        "description": "Follow request status",
-                        "schema": {
-                            "$ref": "#/definitions/model.FollowRequestModel"
-                        }
-                    },
-                    "404": {
-                        "description": "No follow request found",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.User"
                         }
                     }
                 }
             }
         },
-        "/users/{id}/followers": {
-            "get": {
+        "/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores a JPEG, PNG or GIF of up to 5 MB, sent as a multipart upload in the file field, and makes a thumbnail of it. The type is read from the file itself. Attach the returned id to a post (mediaIds) or make it your avatar.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
+                    "Media"
                 ],
-                "summary": "List a user's followers",
+                "summary": "Upload an image",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Image",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Followers retrieved successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "$ref": "#/definitions/model.Media"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Not a supported image",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "413": {
+                        "description": "The image is too large",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -1961,42 +2025,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/following": {
+        "/media/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Serves an uploaded image, or with size=thumbnail a copy at most 320 pixels on its longer side. Images never change, so responses carry a long-lived Cache-Control and an ETag.",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
                 ],
                 "tags": [
-                    "Relationships"
+                    "Media"
                 ],
-                "summary": "List users that the target user is following",
+                "summary": "Read an image",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
+                        "type": "string",
+                        "description": "Media ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original (default) or thumbnail",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Following users retrieved successfully",
+                        "description": "The image",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "type": "file"
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Unknown size",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "No such image",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -2004,42 +2082,80 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/goals": {
+        "/posts": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pages through the feed, newest first or ranked by likes and comments weighed against age. The following scope (default) holds the caller's own posts and those of the users they follow; discover holds every post from a public profile. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Goals"
+                    "Posts"
                 ],
-                "summary": "Get all goals for a user",
+                "summary": "Read the home feed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "following (default) or discover",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "recent (default) or ranked",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20 and at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Goals retrieved successfully",
+                        "description": "Posts retrieved successfully",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Goal"
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -2047,6 +2163,12 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A post can share one of the caller's routines (routineId) or finished sessions (sessionId); the feed then shows a summary of it. Up to four of the caller's uploads from /media can be attached with mediaIds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2054,32 +2176,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Goals"
+                    "Posts"
                 ],
-                "summary": "Create a goal for user",
+                "summary": "Create a new post",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Goal payload",
+                        "description": "New post payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateGoalRequest"
+                            "$ref": "#/definitions/model.CreatePostRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Goal created successfully",
+                        "description": "Post created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Goal"
+                            "$ref": "#/definitions/model.Post"
                         }
                     },
                     "400": {
@@ -2088,8 +2203,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -2097,49 +2218,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/routines": {
-            "get": {
+        "/posts/comment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Posts"
                 ],
-                "summary": "Get all routines for a user",
+                "summary": "Comment on a post",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Target post and comment text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentOnPostRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Routines retrieved successfully",
+                        "description": "Comment added successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ExerciseRoutine"
-                            }
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/posts/comment/reply": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2147,32 +2294,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Posts"
                 ],
-                "summary": "Create a workout routine for user",
+                "summary": "Comment on another comment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Routine payload",
+                        "description": "Target comment and reply text",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateRoutineRequest"
+                            "$ref": "#/definitions/model.CommentOnCommentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Routine created successfully",
+                    "200": {
+                        "description": "Reply added successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.ExerciseRoutine"
+                            "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "400": {
@@ -2181,8 +2321,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
@@ -2190,680 +2342,5436 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/routines/{routine_id}": {
+        "/posts/comments/{id}": {
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open to the comment's author, the post's author and admins. A comment with replies stays in the thread, marked deleted and blanked.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Routines"
+                    "Posts"
                 ],
-                "summary": "Delete user's routine",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Routine ID",
-                        "name": "routine_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Routine deleted successfully",
+                        "description": "Comment deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your comment",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     },
                     "404": {
-                        "description": "Routine not found",
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/unfollow": {
-            "post": {
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only the comment's author can edit it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Relationships"
+                    "Posts"
                 ],
-                "summary": "Unfollow a user",
+                "summary": "Edit a comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID to unfollow",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Follower user ID",
-                        "name": "follower_id",
-                        "in": "query",
-                        "required": true
+                        "description": "New comment text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateCommentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully unfollowed user",
+                        "description": "Comment updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BasicResponse"
+                            "$ref": "#/definitions/model.Comment"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your comment",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.BasicResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Achievement": {
-            "type": "object",
-            "properties": {
-                "badgeIcon": {
-                    "type": "string"
-                },
+        },
+        "/posts/comments/{id}/replies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pages through the direct replies, oldest first, each with up to two levels of its own replies. Paged like /posts/{id}/comments; a comment's repliesCursor continues after the replies it came with.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "List the replies to a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page, or a comment's repliesCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20 and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replies retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Comment"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/like": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The reaction is one of like (the default), fire, strong or clap. Reacting again replaces the caller's earlier reaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "React to a post",
+                "parameters": [
+                    {
+                        "description": "Like post body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LikePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/unlike": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Take back a reaction to a post",
+                "parameters": [
+                    {
+                        "description": "Unlike post body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnikePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "List posts for a specific user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posts retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Delete a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your post",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the fields that are set. The version it replaces is kept and listed by /posts/{id}/revisions. Only the author can edit a post.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Edit a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your post",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pages through the top-level comments, oldest first. Each comes with up to two levels of replies, three per comment; replyCount and repliesCursor lead to the rest at /posts/comments/{id}/replies. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "List the comments on a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20 and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comments retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Comment"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/reactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pages through the reactions, newest first, optionally only those of one type. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "List who reacted to a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "fire",
+                            "strong",
+                            "clap"
+                        ],
+                        "type": "string",
+                        "description": "Only this reaction",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 50 and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactions retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Reaction"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Newest first. Only the author can read them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "List the earlier versions of a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revisions retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PostRevision"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your post",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/routine/copy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves the routine shared by the post, or followed by the session it shares, as a new routine of the caller. The owner's private custom exercises are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Copy the routine a post shares",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Routine copied successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "The post doesn't share a routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Author's profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Weeks run in the order they are sent. Each week maps days of the week (0 = Sunday) to one of the caller's routines, with a loadType of percent (of the estimated 1RM, above 0 to 100) or rpe (1 to 10). Visibility is private, followers or public, private by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "Create a training program",
+                "parameters": [
+                    {
+                        "description": "Program",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Program created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Program"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid weeks, days or loads",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "A routine is not the caller's",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/enrollments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Newest first. Reading changes nothing: a background job schedules each week shortly after it starts, skipping days that overlap another schedule, and completes enrollments past their last week. Active enrollments list the current week's days, with target weights for percentage loads on exercises the caller has an estimated 1RM for.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "List the caller's program enrollments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProgramEnrollment"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/enrollments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the current week's schedules from today on. Earlier ones and the copied routines are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "Leave a training program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Enrollment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Enrollment cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the caller's enrollment",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Enrollment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The enrollment has already ended",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Programs are shared like routines: private ones with their author, followers ones with followers and public ones with anyone who can see the author's profile.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "Read a training program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Program"
+                        }
+                    },
+                    "403": {
+                        "description": "The program is not shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Program not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels the active enrollments in the program; enrollees keep them in their list, without a programId. Schedules already generated for enrollees are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "Delete a training program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Program deleted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the caller's program",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Program not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/{id}/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copies the program's routines into the caller's account, without crediting them as forks, and schedules week 1, starting on startsOn (today by default), at the time slot in the time zone. Later weeks are scheduled by a background job shortly after they start.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Programs"
+                ],
+                "summary": "Enroll in a training program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "When to train",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.EnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProgramEnrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid start date, time slot, length or time zone",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The program is not shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Program not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enrolled, or week 1 overlaps another schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/public": {
+            "get": {
+                "description": "Lists routines shared with everyone by users with public profiles, with their exercises, owner and fork count. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Browse public routines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text searched for in the name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "newest (default) or popular, the most forked first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the X-Next-Cursor header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20 and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routines retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ExerciseRoutine"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Get routine with exercises",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routine with exercises retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid routine ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The routine isn't shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Routine not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Delete a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routine deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid routine ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Routine not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the name, description and visibility that are sent. exercises, when sent, replaces the routine's exercises in the order given, each with optional targets: targetSets, repsMin, repsMax, restSeconds and supersetGroup. Exercises in the same superset must be next to each other. Everything is applied together or not at all.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Update a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateRoutineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routine updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine, or an exercise is another user's private exercise",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{id}/exercises": {
+            "post": {
+                "description": "The exercise goes last, without targets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Add exercise to routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exercise added to routine successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine, or the exercise is another user's private exercise",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{id}/exercises/order": {
+            "put": {
+                "description": "Lists every exercise in the routine, each once, in the new order. Targets move with their exercise, and exercises in the same superset must stay next to each other.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Reorder the exercises in a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exercise IDs in the new order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReorderRoutineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routine reordered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "The list doesn't match the routine's exercises",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{id}/exercises/{exercise_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Remove exercise from routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "exercise_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exercise removed from routine successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{id}/fork": {
+            "post": {
+                "description": "Copies a routine the caller can see into their account as a private routine: its exercises, targets and the owner's exercise settings. The copy credits the original in forkedFrom and the original's forkCount goes up. The owner's private custom exercises are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Fork a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Routine forked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid routine ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The routine isn't shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Routine not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Read all schedules for the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Schedule"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Create a schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule create payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid day, time slot or length",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Routine is not yours",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps other schedules, listed in fields.conflictingScheduleIds",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/calendar.ics": {
+            "get": {
+                "description": "Returns the authenticated user's schedules as an RFC 5545 calendar with one weekly recurring event per schedule. Skipped occurrences are excluded from the series and rescheduled ones are sent as one-off overrides.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Export schedules as iCalendar",
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/calendar/feed": {
+            "post": {
+                "description": "Issues a secret URL that calendar apps can poll without signing in. Creating a new URL revokes the previous one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Create a calendar subscription URL",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CalendarFeed"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Revoke the calendar subscription URL",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/occurrences": {
+            "get": {
+                "description": "Lists every workout the authenticated user's schedules plan in [from, to), as UTC instants ordered by start. Skipped occurrences are left out and rescheduled ones appear at their new time. Bounds are RFC 3339 timestamps or dates; a date covers the whole UTC day. The range defaults to the next 7 days and can span at most 366 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Expand schedules into occurrences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range, e.g. 2025-03-01 or 2025-03-01T00:00:00Z",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive for timestamps and inclusive for dates",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ScheduleOccurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{dayOfWeek}": {
+            "get": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Read schedules for the authenticated user on a specific day",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Day of week (0-6)",
+                        "name": "dayOfWeek",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Schedule"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}": {
+            "get": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Read schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "403": {
+                        "description": "Profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Update a schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule update payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid day, time slot or length",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule or routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps other schedules, listed in fields.conflictingScheduleIds",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Delete a schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}/exceptions": {
+            "post": {
+                "description": "Skips the occurrence the schedule plans on the given local date, or moves it to rescheduledTo when set. Replaces an earlier change to the same occurrence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Skip or reschedule one occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Occurrence date and optional new start",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateScheduleExceptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleException"
+                        }
+                    },
+                    "400": {
+                        "description": "No occurrence on that date",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}/exceptions/{date}": {
+            "delete": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Restore a skipped or rescheduled occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Local date of the occurrence (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No change to that occurrence",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}/occurrences/{date}": {
+            "put": {
+                "description": "Sets the occurrence the schedule planned on the given local date to completed, missed or skipped, replacing the status matched from workouts. Only past occurrences can be completed or missed; sessionId names the workout that completed it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Mark how one occurrence went",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Local date of the occurrence (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status and optional workout session",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MarkOccurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OccurrenceMark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status, date or session",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The occurrence goes back to being matched with workouts.",
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Clear the status marked for one occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Local date of the occurrence (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your schedule",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The occurrence isn't marked",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "List workout sessions for the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "Sessions retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WorkoutSession"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Start a workout session from a routine",
+                "parameters": [
+                    {
+                        "description": "Routine to start",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartWorkoutSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Session started",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkoutSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Routine belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another session is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/active": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Get the in-progress workout session for the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "Active session",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkoutSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active session",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Get a workout session with its logged sets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkoutSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/finish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Finish a workout session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional closing notes",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.FinishWorkoutSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session finished",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkoutSession"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session already finished",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/sets": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Log a performed set in a workout session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Performed set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LogWorkoutSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Set logged",
+                        "schema": {
+                            "$ref": "#/definitions/model.WorkoutSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session already finished",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "New user payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update user payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/adherence": {
+            "get": {
+                "description": "Settles every occurrence the user's schedules planned on local dates in [from, to]. An occurrence is completed by a finished workout started within 6 hours of it, missed once that window passes without one, or whatever the user marked it as. Percentages count completed out of completed and missed occurrences per week (from Monday) and calendar month. The range defaults to the 12 weeks ending today and can span at most 366 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Read a user's schedule adherence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First local date, e.g. 2025-03-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last local date, inclusive",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Adherence"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/avatar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the image as a multipart upload in the file field, or pick one already uploaded to /media with {\"mediaId\": \"...\"}. Older clients may still send a base64 data URL as {\"avatar\": \"...\"}. Either way the image is stored as media and the avatar is served from /media/{mediaId}.",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set the user's avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "An uploaded image",
+                        "name": "avatar",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.SetAvatarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Avatar uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your avatar or not your upload",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User or media not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "The image is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/follow": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "Follow a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID to follow",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully followed user",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Profile is private, send a follow request",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/follow-request": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "Send a follow request to a private profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID to request to follow",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Follow request sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "Cancel a pending follow request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID whose request to cancel",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Follow request cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/follow-request/status": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "Get the status of a follow request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Requester user ID",
+                        "name": "requester_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Follow request status",
+                        "schema": {
+                            "$ref": "#/definitions/model.FollowRequestModel"
+                        }
+                    },
+                    "404": {
+                        "description": "No follow request found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/followers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "List a user's followers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followers retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/following": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "List users that the target user is following",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Following users retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/goals": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Goals"
+                ],
+                "summary": "Get all goals for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goals retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Goal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "A goal is custom free text unless it has a measurable type: body_weight (targetValue in kg, tracked against the latest logged weight measurement), one_rep_max (an estimated 1RM of targetValue on exerciseId) or workouts_per_week (targetValue workouts finished in a week, counted from Monday). Measurable goals come back with their current value and progress, and complete themselves once logged data (a finished workout, a new record or a weight) meets the target.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Goals"
+                ],
+                "summary": "Create a goal for user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Goal payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Goal created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Goal"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/goals/{goal_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Goals"
+                ],
+                "summary": "Delete a user's goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goal deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Goal not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the fields that are set. A measurable goal's type and exercise can't change, and custom goals have no target.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Goals"
+                ],
+                "summary": "Update a user's goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goal updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Goal"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Goal not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/measurements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists measurements oldest first, in the owner's profile units unless units asks for metric or imperial. Other users only see them when they follow the owner and the owner shows metrics to followers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurements"
+                ],
+                "summary": "List a user's body measurements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this metric",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date, e.g. 2025-03-01; defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date, inclusive; defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "metric or imperial",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BodyMeasurement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid metric, range or units",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Metrics are not shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Metric is one of weight, body_fat, waist, chest, hips, neck, arm or thigh. Weight is logged in kg or lb, body fat in % and the rest in cm or in; the unit defaults to the one on the profile. Values are stored in kg, cm and % and the response uses the unit the value was logged in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurements"
+                ],
+                "summary": "Log a body measurement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Measurement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateMeasurementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Measurement logged",
+                        "schema": {
+                            "$ref": "#/definitions/model.BodyMeasurement"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown metric, unit or value",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/measurements/trend": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Averages the metric per day, with a moving average over the last window days (default 7), and compares each week's average, from Monday, with the week before. Visibility follows the measurement list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurements"
+                ],
+                "summary": "Trend of one body metric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metric to trend",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Moving average window in days, 1 to 90",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date, e.g. 2025-03-01; defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date, inclusive; defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "metric or imperial",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MeasurementTrend"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid metric, window, range or units",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Metrics are not shared with the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/measurements/{measurement_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Measurements"
+                ],
+                "summary": "Delete a body measurement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Measurement ID",
+                        "name": "measurement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Measurement deleted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid measurement ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Measurement not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the current best per exercise and record type. Private profiles and hidden metrics are only visible to the owner, admins or permitted followers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Records"
+                ],
+                "summary": "Get a user's current personal records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "epley",
+                        "description": "Estimated 1RM formula (epley or brzycki)",
+                        "name": "formula",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Records retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PersonalRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid formula",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Records are not visible to the caller",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/routines": {
+            "get": {
+                "description": "Other users only get the routines shared with them: public ones, and followers' ones when they follow the owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Get all routines for a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routines retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ExerciseRoutine"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Profile is private",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "visibility is private (the default), followers or public.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Create a workout routine for user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Routine payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateRoutineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Routine created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExerciseRoutine"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your account",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/routines/{routine_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Routines"
+                ],
+                "summary": "Delete user's routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "routine_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Routine deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your routine",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Routine not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/unfollow": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Relationships"
+                ],
+                "summary": "Unfollow a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID to unfollow",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully unfollowed user",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.BasicResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constants.UserMessage": {
+            "type": "string",
+            "enum": [
+                "",
+                "An unexpected error occurred.",
+                "A database error occurred.",
+                "No entry found.",
+                "This record already exists.",
+                "This record already exists.",
+                "This record is linked to another and cannot be deleted.",
+                "A required field was left blank.",
+                "One or more values violate a database rule.",
+                "One or more values are too long.",
+                "Invalid data format.",
+                "A value is outside the allowed range.",
+                "The request took too long to complete. Please try again.",
+                "The system is temporarily unavailable. Please try again later.",
+                "The resource is currently locked or being modified.",
+                "Invalid username or password.",
+                "You do not have permission to perform this action."
+            ],
+            "x-enum-varnames": [
+                "DEFAULT",
+                "UNKNOWN",
+                "UNKNOWN_DB",
+                "NOT_FOUND",
+                "ALREADY_EXISTS",
+                "DUPLICATE",
+                "FOREIGN_KEY",
+                "MISSING_FIELD",
+                "CHECK_VIOLATION",
+                "TOO_LONG",
+                "INVALID_FORMAT",
+                "OUT_OF_RANGE",
+                "TIMEOUT",
+                "UNAVAILABLE",
+                "CONFLICT",
+                "AUTH",
+                "FORBIDDEN"
+            ]
+        },
+        "model.Achievement": {
+            "type": "object",
+            "properties": {
+                "badgeIcon": {
+                    "type": "string"
+                },
+                "criteriaType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementDefinitionRequest": {
+            "type": "object",
+            "properties": {
+                "badgeIcon": {
+                    "type": "string"
+                },
+                "criteriaType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Adherence": {
+            "type": "object",
+            "properties": {
+                "currentStreak": {
+                    "description": "consecutive completed occurrences; skipped ones don't break a streak",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "local dates (YYYY-MM-DD) the report covers, both inclusive",
+                    "type": "string"
+                },
+                "longestStreak": {
+                    "type": "integer"
+                },
+                "monthly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AdherencePeriod"
+                    }
+                },
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrackedOccurrence"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AdherencePeriod"
+                    }
+                }
+            }
+        },
+        "model.AdherencePeriod": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "missed": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "description": "completed share of the completed and missed occurrences, from 0 to 100;\nnil when none are settled yet",
+                    "type": "number"
+                },
+                "planned": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "start": {
+                    "description": "first local date of the week (a Monday) or month",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "targetId": {
+                    "type": "integer"
+                },
+                "targetType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "model.BanUserRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BasicResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BodyMeasurement": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "measuredAt": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Value is in Unit, the reader's preferred unit for the metric",
+                    "type": "number"
+                }
+            }
+        },
+        "model.CalendarFeed": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "description": "secret subscription URL; anyone holding it can read the user's schedules",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Comment": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "edited": {
+                    "type": "boolean"
+                },
+                "editedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parentId": {
+                    "type": "integer"
+                },
+                "postId": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Comment"
+                    }
+                },
+                "repliesCursor": {
+                    "type": "string"
+                },
+                "replyCount": {
+                    "description": "ReplyCount counts every direct reply; Replies holds the ones loaded with\nthe thread and RepliesCursor pages through the rest at /posts/comments/{id}/replies",
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentOnCommentRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "commentId": {
+                    "type": "integer"
+                },
+                "postId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CommentOnPostRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "postId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateAchievementRequest": {
+            "type": "object",
+            "properties": {
+                "achievementId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateExerciseRequest": {
+            "type": "object",
+            "properties": {
+                "demo": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expertise": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "intensity": {
+                    "type": "string"
+                },
+                "isPublic": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "recommendedCount": {
+                    "type": "integer"
+                },
+                "recommendedDuration": {
+                    "type": "integer"
+                },
+                "recommendedSets": {
+                    "type": "integer"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CreateExerciseSettingRequest": {
+            "type": "object",
+            "properties": {
+                "breakInterval": {
+                    "type": "integer"
+                },
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workoutRoutineId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateGoalRequest": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targetValue": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateMeasurementRequest": {
+            "type": "object",
+            "properties": {
+                "measuredAt": {
+                    "description": "MeasuredAt defaults to now",
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "Unit defaults to the profile's unit for the metric: kg or lb, cm or in",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.CreatePostRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "mediaIds": {
+                    "description": "MediaIDs attaches images the author uploaded to /media",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "postedBy": {
+                    "type": "integer"
+                },
+                "routineId": {
+                    "description": "a post can share one of the author's routines or finished sessions",
+                    "type": "integer"
+                },
+                "sessionId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateProgramRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProgramWeek"
+                    }
+                }
+            }
+        },
+        "model.CreateRoutineRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "exerciseIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "exercises": {
+                    "description": "Exercises gives the exercises with their targets; when set it is used\ninstead of ExerciseIDs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutineExercise"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "Visibility defaults to private",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateScheduleExceptionRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "rescheduledTo": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "endsOn": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "routineIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "routineLengthMinutes": {
+                    "type": "integer"
+                },
+                "startsOn": {
+                    "type": "string"
+                },
+                "timeSlot": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "heightMetric": {
+                    "type": "string"
+                },
+                "isPrivate": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "showMetricsToFollowers": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weightMetric": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EnrollRequest": {
+            "type": "object",
+            "properties": {
+                "routineLengthMinutes": {
+                    "type": "integer"
+                },
+                "startsOn": {
+                    "description": "local date week 1 starts on, today when empty",
+                    "type": "string"
+                },
+                "timeSlot": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EnrolledDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "load": {
+                    "type": "number"
+                },
+                "loadType": {
+                    "type": "string"
+                },
+                "routineId": {
+                    "type": "integer"
+                },
+                "scheduleId": {
+                    "type": "integer"
+                },
+                "targets": {
+                    "description": "weights for percentage loads, on exercises with an estimated 1RM",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LoadTarget"
+                    }
+                }
+            }
+        },
+        "model.Error": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "$ref": "#/definitions/constants.UserMessage"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Exercise": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "type": "boolean"
+                },
+                "deleted": {
+                    "description": "Deleted custom exercises stay readable by id for the history that references them",
+                    "type": "boolean"
+                },
+                "demo": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expertise": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "intensity": {
+                    "type": "string"
+                },
+                "isPublic": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "integer"
+                },
+                "recommendedCount": {
+                    "type": "integer"
+                },
+                "recommendedDuration": {
+                    "type": "integer"
+                },
+                "recommendedSets": {
+                    "type": "integer"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ExerciseRoutine": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entries": {
+                    "description": "Entries holds each exercise's targets, in the same order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutineExercise"
+                    }
+                },
+                "exerciseIds": {
+                    "description": "ExerciseIDs are in the order the exercises are done",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Exercise"
+                    }
+                },
+                "forkCount": {
+                    "type": "integer"
+                },
+                "forkedFrom": {
+                    "description": "ForkedFrom credits the routine this one was forked from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RoutineSource"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Username is the owner's, filled in when browsing public routines",
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "Visibility is private, followers or public",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExerciseSetting": {
+            "type": "object",
+            "properties": {
+                "breakInterval": {
+                    "type": "integer"
+                },
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "workoutRoutineId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FinishWorkoutSessionRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FollowRequestModel": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requestedID": {
+                    "type": "integer"
+                },
+                "requesterID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, accepted, rejected",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FollowRequestResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "accept, reject",
+                    "type": "string"
+                },
+                "requestID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FollowRequestWithUser": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requestedID": {
+                    "type": "integer"
+                },
+                "requesterID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "The user who sent the request",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.Goal": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentValue": {
+                    "description": "CurrentValue is read from the logged data: the latest weight in kg, the\nbest estimated 1RM, or the workouts finished this week",
+                    "type": "number"
+                },
+                "deadline": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
+                "exerciseId": {
+                    "description": "the rest is only set on measurable goals",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Progress runs from 0 to 100",
+                    "type": "number"
+                },
+                "startValue": {
+                    "description": "StartValue is the body weight in kg when a body weight goal was set",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "\"active\", \"completed\", \"paused\"",
+                    "type": "string"
+                },
+                "targetValue": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GoogleAuthRequest": {
+            "type": "object",
+            "properties": {
+                "idToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LikePostRequest": {
+            "type": "object",
+            "properties": {
+                "postId": {
+                    "type": "integer"
+                },
+                "reaction": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LoadTarget": {
+            "type": "object",
+            "properties": {
+                "estimatedOneRepMax": {
+                    "description": "the estimated 1RM the weight is a percentage of",
+                    "type": "number"
+                },
+                "exerciseId": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "model.AuthResponse": {
+        "model.LogWorkoutSetRequest": {
             "type": "object",
             "properties": {
-                "token": {
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "restSeconds": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "sessionId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/model.User"
+                "password": {
+                    "type": "string"
                 }
             }
         },
-        "model.BasicResponse": {
+        "model.MarkOccurrenceRequest": {
             "type": "object",
             "properties": {
-                "message": {
+                "sessionId": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "model.Comment": {
+        "model.MeasurementTrend": {
             "type": "object",
             "properties": {
-                "comment": {
+                "from": {
+                    "description": "local dates (YYYY-MM-DD) the trend covers, both inclusive",
                     "type": "string"
                 },
-                "date": {
+                "metric": {
                     "type": "string"
                 },
-                "id": {
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrendPoint"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "integer"
                 },
-                "replies": {
+                "weekly": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Comment"
+                        "$ref": "#/definitions/model.WeeklyChange"
                     }
                 },
-                "username": {
-                    "type": "string"
+                "window": {
+                    "type": "integer"
                 }
             }
         },
-        "model.CommentOnCommentRequest": {
+        "model.Media": {
             "type": "object",
             "properties": {
-                "comment": {
+                "contentType": {
                     "type": "string"
                 },
-                "commentId": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "height": {
                     "type": "integer"
                 },
-                "postId": {
+                "id": {
+                    "type": "string"
+                },
+                "ownerId": {
                     "type": "integer"
                 },
-                "userId": {
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnailUrl": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
                     "type": "integer"
                 }
             }
         },
-        "model.CommentOnPostRequest": {
+        "model.OccurrenceMark": {
             "type": "object",
             "properties": {
-                "comment": {
+                "date": {
                     "type": "string"
                 },
-                "postId": {
+                "scheduleId": {
                     "type": "integer"
                 },
-                "userId": {
+                "sessionId": {
+                    "description": "workout the occurrence was completed with, if the user named one",
                     "type": "integer"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "model.CreateAchievementRequest": {
+        "model.PersonalRecord": {
             "type": "object",
             "properties": {
-                "achievementId": {
+                "achievedAt": {
+                    "type": "string"
+                },
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "formula": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "sessionId": {
+                    "type": "integer"
+                },
+                "setId": {
                     "type": "integer"
                 },
+                "type": {
+                    "type": "string"
+                },
                 "userId": {
                     "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "model.CreateExerciseRequest": {
+        "model.Post": {
             "type": "object",
             "properties": {
-                "demo": {
+                "body": {
                     "type": "string"
                 },
-                "description": {
+                "caption": {
                     "type": "string"
                 },
-                "expertise": {
-                    "type": "string"
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Comment"
+                    }
                 },
-                "image": {
+                "commentsCursor": {
+                    "description": "CommentsCursor pages through the rest of the comments at /posts/{id}/comments",
                     "type": "string"
                 },
-                "intensity": {
+                "date": {
                     "type": "string"
                 },
-                "name": {
+                "edited": {
+                    "description": "Edited is set once the author has changed the post; EditedAt is the last change",
+                    "type": "boolean"
+                },
+                "editedAt": {
                     "type": "string"
                 },
-                "recommendedCount": {
+                "id": {
                     "type": "integer"
                 },
-                "recommendedDuration": {
-                    "type": "integer"
+                "isLiked": {
+                    "description": "IsLiked is set when the viewer reacted at all; MyReaction is how",
+                    "type": "boolean"
                 },
-                "recommendedSets": {
+                "likes": {
+                    "description": "Likes counts every reaction, whatever its type",
                     "type": "integer"
                 },
-                "targets": {
+                "media": {
+                    "description": "Media are the attached images, in the order they were attached",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.Media"
+                    }
+                },
+                "myReaction": {
+                    "type": "string"
+                },
+                "postedBy": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "description": "Reactions counts the reactions of each type the post has",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
                     }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "workout": {
+                    "description": "Workout is the routine or session the post shares, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.WorkoutSummary"
+                        }
+                    ]
                 }
             }
         },
-        "model.CreateExerciseSettingRequest": {
+        "model.PostRevision": {
             "type": "object",
             "properties": {
-                "breakInterval": {
-                    "type": "integer"
+                "body": {
+                    "type": "string"
                 },
-                "exerciseId": {
+                "caption": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "reps": {
+                "postId": {
                     "type": "integer"
                 },
-                "sets": {
+                "replacedAt": {
+                    "description": "when the next edit replaced it",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "writtenAt": {
+                    "description": "when this version was posted or last edited",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Program": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
+                "name": {
+                    "type": "string"
+                },
                 "userId": {
                     "type": "integer"
                 },
-                "weight": {
+                "visibility": {
+                    "type": "string"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProgramWeek"
+                    }
+                }
+            }
+        },
+        "model.ProgramDay": {
+            "type": "object",
+            "properties": {
+                "dayOfWeek": {
+                    "description": "0 (Sunday) to 6 (Saturday)",
+                    "type": "integer"
+                },
+                "load": {
                     "type": "number"
                 },
-                "workoutRoutineId": {
+                "loadType": {
+                    "type": "string"
+                },
+                "routineId": {
                     "type": "integer"
                 }
             }
         },
-        "model.CreateGoalRequest": {
+        "model.ProgramEnrollment": {
             "type": "object",
             "properties": {
-                "deadline": {
+                "currentWeek": {
+                    "type": "integer"
+                },
+                "days": {
+                    "description": "the current week, for active enrollments",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EnrolledDay"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "programId": {
+                    "type": "integer"
+                },
+                "programName": {
                     "type": "string"
                 },
-                "description": {
+                "routineLengthMinutes": {
+                    "type": "integer"
+                },
+                "startedOn": {
+                    "description": "local date (YYYY-MM-DD) of the first day of week 1",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timeSlot": {
+                    "type": "string"
+                },
+                "timezone": {
                     "type": "string"
                 },
+                "totalWeeks": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProgramWeek": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProgramDay"
+                    }
+                },
                 "name": {
                     "type": "string"
+                },
+                "number": {
+                    "description": "weeks are numbered from 1, in order",
+                    "type": "integer"
                 }
             }
         },
-        "model.CreatePostRequest": {
+        "model.Reaction": {
             "type": "object",
             "properties": {
-                "body": {
+                "avatar": {
                     "type": "string"
                 },
-                "caption": {
+                "createdAt": {
+                    "description": "when the user last reacted",
                     "type": "string"
                 },
-                "postedBy": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "status": {
+                "reaction": {
                     "type": "string"
                 },
-                "title": {
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.CreateRoutineRequest": {
+        "model.RefreshRequest": {
             "type": "object",
             "properties": {
-                "description": {
+                "refreshToken": {
                     "type": "string"
-                },
+                }
+            }
+        },
+        "model.ReorderRoutineRequest": {
+            "type": "object",
+            "properties": {
                 "exerciseIds": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
-                },
-                "name": {
-                    "type": "string"
                 }
             }
         },
-        "model.CreateScheduleRequest": {
+        "model.RoutineExercise": {
             "type": "object",
             "properties": {
-                "dayOfWeek": {
+                "exerciseId": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "position": {
+                    "description": "Position counts from 0 and follows the order the entries are sent in",
+                    "type": "integer"
                 },
-                "routineIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "repsMax": {
+                    "type": "integer"
                 },
-                "routineLengthMinutes": {
+                "repsMin": {
                     "type": "integer"
                 },
-                "timeSlot": {
-                    "type": "string"
+                "restSeconds": {
+                    "type": "integer"
                 },
-                "userId": {
+                "supersetGroup": {
+                    "description": "exercises sharing a superset group are done back to back, so they have\nto be next to each other",
+                    "type": "integer"
+                },
+                "targetSets": {
                     "type": "integer"
                 }
             }
         },
-        "model.CreateUserRequest": {
+        "model.RoutineSource": {
             "type": "object",
             "properties": {
-                "age": {
+                "routineId": {
                     "type": "integer"
                 },
-                "avatar": {
-                    "type": "string"
+                "userId": {
+                    "type": "integer"
                 },
-                "email": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "model.Schedule": {
+            "type": "object",
+            "properties": {
+                "dayOfWeek": {
+                    "type": "integer"
                 },
-                "height": {
-                    "type": "number"
-                },
-                "heightMetric": {
+                "endsOn": {
                     "type": "string"
                 },
-                "isPrivate": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "routineIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "showMetricsToFollowers": {
-                    "type": "boolean"
+                "routineLengthMinutes": {
+                    "type": "integer"
                 },
-                "username": {
+                "startsOn": {
+                    "description": "optional first and last local dates (YYYY-MM-DD) the schedule applies to",
                     "type": "string"
                 },
-                "weight": {
-                    "type": "number"
+                "timeSlot": {
+                    "type": "string"
                 },
-                "weightMetric": {
+                "timezone": {
+                    "description": "IANA time zone the time slot is read in, UTC when empty",
                     "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "model.Exercise": {
+        "model.ScheduleException": {
             "type": "object",
             "properties": {
-                "custom": {
-                    "type": "boolean"
-                },
-                "demo": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "expertise": {
+                "date": {
+                    "description": "local date (YYYY-MM-DD) the occurrence was planned for",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "intensity": {
-                    "type": "string"
-                },
-                "name": {
+                "rescheduledTo": {
+                    "description": "when the occurrence happens instead; nil skips it",
                     "type": "string"
                 },
-                "recommendedCount": {
-                    "type": "integer"
-                },
-                "recommendedDuration": {
-                    "type": "integer"
-                },
-                "recommendedSets": {
+                "scheduleId": {
                     "type": "integer"
-                },
-                "targets": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "model.ExerciseRoutine": {
+        "model.ScheduleOccurrence": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "date": {
+                    "description": "local date the occurrence was planned for, before any reschedule",
                     "type": "string"
                 },
-                "description": {
+                "endsAt": {
                     "type": "string"
                 },
-                "exerciseIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "exercises": {
+                "rescheduled": {
+                    "description": "true when a one-off reschedule moved it away from its usual slot",
+                    "type": "boolean"
+                },
+                "routineIds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Exercise"
+                        "type": "integer"
                     }
                 },
-                "id": {
+                "scheduleId": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "name": {
+                "startsAt": {
                     "type": "string"
-                },
-                "userId": {
-                    "type": "integer"
                 }
             }
         },
-        "model.ExerciseSetting": {
+        "model.SetAvatarRequest": {
             "type": "object",
             "properties": {
-                "breakInterval": {
-                    "type": "integer"
-                },
-                "exerciseId": {
-                    "type": "integer"
-                },
-                "reps": {
-                    "type": "integer"
-                },
-                "sets": {
-                    "type": "integer"
-                },
-                "userId": {
-                    "type": "integer"
-                },
-                "weight": {
-                    "type": "number"
-                },
-                "workoutRoutineId": {
-                    "type": "integer"
+                "mediaId": {
+                    "type": "string"
                 }
             }
         },
-        "model.FollowRequestModel": {
+        "model.StartWorkoutSessionRequest": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
                 },
-                "requestedID": {
+                "routineId": {
                     "type": "integer"
                 },
-                "requesterID": {
+                "userId": {
                     "type": "integer"
-                },
-                "status": {
-                    "description": "pending, accepted, rejected",
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "model.FollowRequestResponse": {
+        "model.SuspendUserRequest": {
             "type": "object",
             "properties": {
-                "action": {
-                    "description": "accept, reject",
+                "reason": {
                     "type": "string"
                 },
-                "requestID": {
-                    "type": "integer"
+                "until": {
+                    "type": "string"
                 }
             }
         },
-        "model.FollowRequestWithUser": {
+        "model.TakeDownPostRequest": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "requestedID": {
-                    "type": "integer"
-                },
-                "requesterID": {
-                    "type": "integer"
-                },
-                "status": {
+                "reason": {
                     "type": "string"
-                },
-                "user": {
-                    "description": "The user who sent the request",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    ]
                 }
             }
         },
-        "model.Goal": {
+        "model.TrackedOccurrence": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "date": {
+                    "description": "local date the occurrence was planned for, before any reschedule",
                     "type": "string"
                 },
-                "deadline": {
+                "endsAt": {
                     "type": "string"
                 },
-                "description": {
+                "manual": {
+                    "description": "true when the user set the status rather than it being matched from workouts",
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "rescheduled": {
+                    "description": "true when a one-off reschedule moved it away from its usual slot",
+                    "type": "boolean"
+                },
+                "routineIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scheduleId": {
                     "type": "integer"
                 },
-                "name": {
+                "sessionId": {
+                    "description": "workout that completed the occurrence",
+                    "type": "integer"
+                },
+                "startsAt": {
                     "type": "string"
                 },
                 "status": {
-                    "description": "\"active\", \"completed\", \"paused\"",
                     "type": "string"
-                },
-                "userId": {
-                    "type": "integer"
                 }
             }
         },
-        "model.GoogleAuthRequest": {
+        "model.TrendPoint": {
             "type": "object",
             "properties": {
-                "idToken": {
+                "date": {
                     "type": "string"
+                },
+                "movingAverage": {
+                    "description": "MovingAverage is the mean of the daily values over the window ending on Date",
+                    "type": "number"
+                },
+                "value": {
+                    "description": "Value is the mean of the day's measurements",
+                    "type": "number"
                 }
             }
         },
-        "model.LikePostRequest": {
+        "model.UnikePostRequest": {
             "type": "object",
             "properties": {
                 "postId": {
                     "type": "integer"
-                },
-                "userId": {
-                    "type": "integer"
                 }
             }
         },
-        "model.LoginRequest": {
+        "model.UpdateCommentRequest": {
             "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
+            "properties": {
+                "comment": {
                     "type": "string"
                 }
             }
         },
-        "model.Post": {
+        "model.UpdateExerciseRequest": {
             "type": "object",
             "properties": {
-                "body": {
+                "demo": {
                     "type": "string"
                 },
-                "caption": {
+                "description": {
                     "type": "string"
                 },
-                "comments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Comment"
-                    }
-                },
-                "date": {
+                "expertise": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "isLiked": {
-                    "type": "boolean"
-                },
-                "likes": {
-                    "type": "integer"
-                },
-                "postedBy": {
-                    "type": "string"
-                },
-                "status": {
+                "image": {
                     "type": "string"
                 },
-                "title": {
+                "intensity": {
                     "type": "string"
-                }
-            }
-        },
-        "model.Schedule": {
-            "type": "object",
-            "properties": {
-                "dayOfWeek": {
-                    "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "isPublic": {
+                    "type": "boolean"
                 },
                 "name": {
                     "type": "string"
                 },
-                "routineIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "routineLengthMinutes": {
+                "ownerId": {
                     "type": "integer"
                 },
-                "timeSlot": {
-                    "type": "string"
-                },
-                "userId": {
+                "recommendedCount": {
                     "type": "integer"
-                }
-            }
-        },
-        "model.UnikePostRequest": {
-            "type": "object",
-            "properties": {
-                "postId": {
+                },
+                "recommendedDuration": {
                     "type": "integer"
                 },
-                "userId": {
+                "recommendedSets": {
                     "type": "integer"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -2893,12 +7801,72 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateGoalRequest": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "targetValue": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.UpdatePostRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpdateRoutineRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RoutineExercise"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdateScheduleRequest": {
             "type": "object",
             "properties": {
                 "dayOfWeek": {
                     "type": "integer"
                 },
+                "endsOn": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -2914,9 +7882,15 @@ const docTemplate = `{
                 "routineLengthMinutes": {
                     "type": "integer"
                 },
+                "startsOn": {
+                    "type": "string"
+                },
                 "timeSlot": {
                     "type": "string"
                 },
+                "timezone": {
+                    "type": "string"
+                },
                 "userId": {
                     "type": "integer"
                 }
@@ -2966,6 +7940,14 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateUserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "model.User": {
             "type": "object",
             "properties": {
@@ -3026,6 +8008,9 @@ const docTemplate = `{
                 "provider": {
                     "type": "string"
                 },
+                "role": {
+                    "type": "string"
+                },
                 "routines": {
                     "type": "array",
                     "items": {
@@ -3071,6 +8056,168 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.UserStanding": {
+            "type": "object",
+            "properties": {
+                "bannedAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "suspendedUntil": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WeeklyChange": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "change": {
+                    "description": "Change is nil when nothing was logged the week before",
+                    "type": "number"
+                },
+                "start": {
+                    "description": "first date of the week, a Monday",
+                    "type": "string"
+                }
+            }
+        },
+        "model.WorkoutSession": {
+            "type": "object",
+            "properties": {
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "routineId": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WorkoutSet"
+                    }
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"active\", \"finished\"",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WorkoutSet": {
+            "type": "object",
+            "properties": {
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "loggedAt": {
+                    "type": "string"
+                },
+                "newRecords": {
+                    "description": "NewRecords lists the personal records this set broke, if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PersonalRecord"
+                    }
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "restSeconds": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "sessionId": {
+                    "type": "integer"
+                },
+                "setNumber": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.WorkoutSummary": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "type": "integer"
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WorkoutSummaryExercise"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PersonalRecord"
+                    }
+                },
+                "routineId": {
+                    "description": "RoutineID is the routine viewers can copy; a session carries the\nroutine it followed, if any",
+                    "type": "integer"
+                },
+                "sessionId": {
+                    "type": "integer"
+                },
+                "totalVolume": {
+                    "description": "the rest is only filled in for a session",
+                    "type": "number"
+                }
+            }
+        },
+        "model.WorkoutSummaryExercise": {
+            "type": "object",
+            "properties": {
+                "exerciseId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
         }
     }
 }`
@@ -8,6 +8,7 @@ import (
 	"workoutpal/src/internal/dependency"
 	"workoutpal/src/internal/handler"
 	middleware2 "workoutpal/src/internal/middleware"
+	"workoutpal/src/util/constants"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -16,6 +17,12 @@ import (
 )
 
 func RegisterRoutes(cfg *config.Config, db *sql.DB) http.Handler {
+	return NewRouter(cfg, dependency.NewAppDependencies(cfg, db))
+}
+
+// NewRouter serves dependencies the caller built, so background jobs can
+// share them
+func NewRouter(cfg *config.Config, appDep dependency.AppDependencies) http.Handler {
 	r := chi.NewRouter()
 
 	// --- Global middleware ---
@@ -26,7 +33,6 @@ func RegisterRoutes(cfg *config.Config, db *sql.DB) http.Handler {
 
 	// --- Real Routes ---
 	r.Route("/", func(r chi.Router) {
-		appDep := dependency.NewAppDependencies(db)
 		Routes(r, appDep, []byte(cfg.JWTSecret))
 	})
 
@@ -47,6 +53,7 @@ func RegisterRoutes(cfg *config.Config, db *sql.DB) http.Handler {
 		AllowCredentials: true,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{constants.NEXT_CURSOR_HEADER},
 	}).Handler(r)
 
 	return corsHandler
@@ -54,7 +61,7 @@ func RegisterRoutes(cfg *config.Config, db *sql.DB) http.Handler {
 
 func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http.Handler {
 	// --- Init Handlers ---
-	userHandler := handler.NewUserHandler(appDep.UserService, appDep.RelationshipService)
+	userHandler := handler.NewUserHandler(appDep.UserService, appDep.RelationshipService, appDep.MediaService)
 	goalHandler := handler.NewGoalHandler(appDep.GoalService)
 	relationshipHandler := handler.NewRelationshipHandler(appDep.RelationshipService)
 	routineHandler := handler.NewRoutineHandler(appDep.RoutineService)
@@ -64,10 +71,18 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 	postHandler := handler.NewPostHandler(appDep.PostService)
 	achievementHandler := handler.NewAchievementHandler(appDep.AchievementService)
 	exerciseSettingHandler := handler.NewExerciseSettingHandler(appDep.ExerciseSettingService)
+	workoutSessionHandler := handler.NewWorkoutSessionHandler(appDep.WorkoutSessionService)
+	personalRecordHandler := handler.NewPersonalRecordHandler(appDep.PersonalRecordService)
+	adminHandler := handler.NewAdminHandler(appDep.AdminService)
+	mediaHandler := handler.NewMediaHandler(appDep.MediaService)
+	bodyMeasurementHandler := handler.NewBodyMeasurementHandler(appDep.BodyMeasurementService)
+	programHandler := handler.NewProgramHandler(appDep.ProgramService)
 
 	// --- Init Middleware ---
 	var idMiddleware = middleware2.IdMiddleware()
-	var authMiddleware = middleware2.AuthMiddleware(secret)
+	var authMiddleware = middleware2.AuthMiddleware(secret, appDep.AuthService)
+	var moderatorMiddleware = middleware2.RequireRole(constants.ROLE_MODERATOR, constants.ROLE_ADMIN)
+	var adminMiddleware = middleware2.RequireRole(constants.ROLE_ADMIN)
 
 	// Health check
 	r.Get("/health", handler.HealthCheck)
@@ -76,6 +91,9 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 	r.Post("/login", authHandler.Login)
 	r.Post("/logout", authHandler.Logout)
 	r.With(authMiddleware).Get("/me", authHandler.Me)
+	r.Post("/auth/refresh", authHandler.Refresh)
+	r.Post("/auth/google", authHandler.GoogleAuth)
+	r.With(authMiddleware).Post("/auth/logout-all", authHandler.LogoutAll)
 
 	// --- Register Routes ---
 	r.Route("/users", func(r chi.Router) {
@@ -91,6 +109,8 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 			// User Goals
 			r.With(idMiddleware).Post("/{id}/goals", goalHandler.CreateUserGoal)
 			r.With(idMiddleware).Get("/{id}/goals", goalHandler.GetUserGoals)
+			r.With(idMiddleware).Patch("/{id}/goals/{goal_id}", goalHandler.UpdateUserGoal)
+			r.With(idMiddleware).Delete("/{id}/goals/{goal_id}", goalHandler.DeleteUserGoal)
 			// User Followers
 			r.With(idMiddleware).Post("/{id}/follow", relationshipHandler.FollowUser)
 			r.With(idMiddleware).Post("/{id}/unfollow", relationshipHandler.UnfollowUser)
@@ -104,6 +124,15 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 			r.With(idMiddleware).Post("/{id}/routines", routineHandler.CreateUserRoutine)
 			r.With(idMiddleware).Get("/{id}/routines", routineHandler.ReadUserRoutines)
 			r.With(idMiddleware).Delete("/{id}/routines/{routine_id}", routineHandler.DeleteUserRoutine)
+			// Personal Records
+			r.With(idMiddleware).Get("/{id}/records", personalRecordHandler.ReadUserRecords)
+			// Body measurements
+			r.With(idMiddleware).Post("/{id}/measurements", bodyMeasurementHandler.CreateMeasurement)
+			r.With(idMiddleware).Get("/{id}/measurements", bodyMeasurementHandler.ReadMeasurements)
+			r.With(idMiddleware).Get("/{id}/measurements/trend", bodyMeasurementHandler.ReadTrend)
+			r.With(idMiddleware).Delete("/{id}/measurements/{measurement_id}", bodyMeasurementHandler.DeleteMeasurement)
+			// Schedule adherence
+			r.With(idMiddleware).Get("/{id}/adherence", scheduleHandler.ReadUserAdherence)
 		})
 	})
 
@@ -116,39 +145,82 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 	// Exercises
 	r.With(authMiddleware).Route("/exercises", func(r chi.Router) {
 		r.Get("/", exerciseHandler.ReadExercises)
+		r.Post("/", exerciseHandler.CreateExercise)
 		r.With(idMiddleware).Get("/{id}", exerciseHandler.ReadExerciseByID)
+		r.With(idMiddleware).Put("/{id}", exerciseHandler.UpdateExercise)
+		r.With(idMiddleware).Delete("/{id}", exerciseHandler.DeleteExercise)
+		r.With(idMiddleware).Get("/{id}/records", personalRecordHandler.ReadExerciseRecords)
 	})
 
 	// Routines
 	r.With(authMiddleware).Route("/routines", func(r chi.Router) {
+		r.Get("/public", routineHandler.ReadPublicRoutines)
 		r.With(idMiddleware).Get("/{id}", routineHandler.ReadRoutineWithExercises)
+		r.With(idMiddleware).Patch("/{id}", routineHandler.UpdateRoutine)
 		r.With(idMiddleware).Delete("/{id}", routineHandler.DeleteRoutine)
 		r.With(idMiddleware).Post("/{id}/exercises", routineHandler.AddExerciseToRoutine)
+		r.With(idMiddleware).Put("/{id}/exercises/order", routineHandler.ReorderRoutineExercises)
+		r.With(idMiddleware).Post("/{id}/fork", routineHandler.ForkRoutine)
 		r.With(idMiddleware).Delete("/{id}/exercises/{exercise_id}", routineHandler.RemoveExerciseFromRoutine)
 	})
 
+	// Training programs
+	r.With(authMiddleware).Route("/programs", func(r chi.Router) {
+		r.Post("/", programHandler.CreateProgram)
+		r.Get("/enrollments", programHandler.ReadEnrollments)
+		r.With(idMiddleware).Delete("/enrollments/{id}", programHandler.CancelEnrollment)
+		r.With(idMiddleware).Get("/{id}", programHandler.ReadProgram)
+		r.With(idMiddleware).Delete("/{id}", programHandler.DeleteProgram)
+		r.With(idMiddleware).Post("/{id}/enroll", programHandler.Enroll)
+	})
+
 	// Schedules
 	r.With(authMiddleware).Route("/schedules", func(r chi.Router) {
 		r.Get("/", scheduleHandler.ReadUserSchedules)
 		r.Get("/of/{dayOfWeek}", scheduleHandler.ReadUserSchedulesByDay)
 		r.Post("/", scheduleHandler.CreateSchedule)
+		r.Get("/calendar.ics", scheduleHandler.ExportCalendar)
+		r.Post("/calendar/feed", scheduleHandler.CreateCalendarFeed)
+		r.Delete("/calendar/feed", scheduleHandler.RevokeCalendarFeed)
+		r.Get("/occurrences", scheduleHandler.ReadOccurrences)
 		r.With(idMiddleware).Get("/{id}", scheduleHandler.ReadScheduleByID)
 		r.With(idMiddleware).Put("/{id}", scheduleHandler.UpdateSchedule)
 		r.With(idMiddleware).Delete("/{id}", scheduleHandler.DeleteSchedule)
+		r.With(idMiddleware).Post("/{id}/exceptions", scheduleHandler.CreateScheduleException)
+		r.With(idMiddleware).Delete("/{id}/exceptions/{date}", scheduleHandler.DeleteScheduleException)
+		r.With(idMiddleware).Put("/{id}/occurrences/{date}", scheduleHandler.MarkOccurrence)
+		r.With(idMiddleware).Delete("/{id}/occurrences/{date}", scheduleHandler.DeleteOccurrenceMark)
 	})
 
+	// Calendar subscriptions authenticate with the secret token in the URL
+	r.Get("/calendar/{token}.ics", scheduleHandler.ReadCalendarFeed)
+
 	// Posts
 	r.With(authMiddleware).Route("/posts", func(r chi.Router) {
 		r.With(idMiddleware).Get("/user/{id}", postHandler.ReadPostsByUserID)
 		r.Get("/", postHandler.ReadPosts)
 		r.Post("/", postHandler.CreatePost)
+		r.With(idMiddleware).Patch("/{id}", postHandler.UpdatePost)
 		r.With(idMiddleware).Delete("/{id}", postHandler.DeletePost)
+		r.With(idMiddleware).Get("/{id}/revisions", postHandler.ReadPostRevisions)
+		r.With(idMiddleware).Post("/{id}/routine/copy", postHandler.CopyRoutine)
 
 		r.Post("/like", postHandler.LikePost)
 		r.Post("/unlike", postHandler.UnlikePost)
+		r.With(idMiddleware).Get("/{id}/reactions", postHandler.ReadReactions)
 
 		r.Post("/comment", postHandler.CommentOnPost)
 		r.Post("/comment/reply", postHandler.CommentOnComment)
+		r.With(idMiddleware).Get("/{id}/comments", postHandler.ReadComments)
+		r.With(idMiddleware).Get("/comments/{id}/replies", postHandler.ReadReplies)
+		r.With(idMiddleware).Patch("/comments/{id}", postHandler.UpdateComment)
+		r.With(idMiddleware).Delete("/comments/{id}", postHandler.DeleteComment)
+	})
+
+	// Media
+	r.With(authMiddleware).Route("/media", func(r chi.Router) {
+		r.Post("/", mediaHandler.UploadMedia)
+		r.Get("/{id}", mediaHandler.ReadMedia)
 	})
 
 	// Achievements
@@ -167,5 +239,38 @@ func Routes(r chi.Router, appDep dependency.AppDependencies, secret []byte) http
 		r.Put("/", exerciseSettingHandler.UpdateExerciseSetting)
 	})
 
+	// Workout Sessions
+	r.With(authMiddleware).Route("/sessions", func(r chi.Router) {
+		r.Get("/", workoutSessionHandler.ReadUserSessions)
+		r.Post("/", workoutSessionHandler.StartSession)
+		r.Get("/active", workoutSessionHandler.ReadActiveSession)
+		r.With(idMiddleware).Get("/{id}", workoutSessionHandler.ReadSessionByID)
+		r.With(idMiddleware).Post("/{id}/sets", workoutSessionHandler.LogSet)
+		r.With(idMiddleware).Post("/{id}/finish", workoutSessionHandler.FinishSession)
+	})
+
+	// Admin
+	r.With(authMiddleware).Route("/admin", func(r chi.Router) {
+		// Moderation
+		r.With(moderatorMiddleware, idMiddleware).Post("/posts/{id}/takedown", adminHandler.TakeDownPost)
+		r.With(moderatorMiddleware, idMiddleware).Post("/users/{id}/suspend", adminHandler.SuspendUser)
+
+		r.With(adminMiddleware).Group(func(r chi.Router) {
+			r.With(idMiddleware).Post("/users/{id}/ban", adminHandler.BanUser)
+			r.With(idMiddleware).Post("/users/{id}/reinstate", adminHandler.ReinstateUser)
+			r.With(idMiddleware).Put("/users/{id}/role", adminHandler.UpdateUserRole)
+			// Exercise catalogue
+			r.Post("/exercises", adminHandler.CreateExercise)
+			r.With(idMiddleware).Put("/exercises/{id}", adminHandler.UpdateExercise)
+			r.With(idMiddleware).Delete("/exercises/{id}", adminHandler.DeleteExercise)
+			// Achievement definitions
+			r.Post("/achievements", adminHandler.CreateAchievement)
+			r.With(idMiddleware).Put("/achievements/{id}", adminHandler.UpdateAchievement)
+			r.With(idMiddleware).Delete("/achievements/{id}", adminHandler.DeleteAchievement)
+			// Audit log
+			r.Get("/audit", adminHandler.ReadAuditLog)
+		})
+	})
+
 	return r
 }
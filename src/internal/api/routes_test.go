@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -106,6 +107,10 @@ func TestRoutes_Login_CallsAuthService_AndReturns200(t *testing.T) {
 		EXPECT().
 		Authenticate(gomock.Any(), gomock.AssignableToTypeOf(model.LoginRequest{})).
 		Return(&model.User{ID: 1, Email: reqBody.Email}, nil)
+	mockAuth.
+		EXPECT().
+		CreateSession(gomock.Any(), int64(1)).
+		Return(&model.AuthSession{ID: "s1", UserID: 1, RefreshToken: "refresh", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
 
 	buf, _ := json.Marshal(reqBody)
 	resp := do(ts, http.MethodPost, "/login", bytes.NewBuffer(buf), map[string]string{
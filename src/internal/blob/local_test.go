@@ -0,0 +1,61 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+
+	if err := store.Put(ctx, "media/abc/original", "image/png", []byte("first")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	// a second put replaces the blob
+	if err := store.Put(ctx, "media/abc/original", "image/png", []byte("second")); err != nil {
+		t.Fatalf("put again: %v", err)
+	}
+
+	body, err := store.Get(ctx, "media/abc/original")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if string(got) != "second" {
+		t.Fatalf("got %q, want second", got)
+	}
+
+	if err := store.Delete(ctx, "media/abc/original"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "media/abc/original"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist after delete, got %v", err)
+	}
+	if err := store.Delete(ctx, "media/abc/original"); err != nil {
+		t.Fatalf("deleting a missing blob should be a no-op, got %v", err)
+	}
+	// the directories the key made are gone, the store's own stays
+	if _, err := os.Stat(filepath.Join(dir, "media")); !os.IsNotExist(err) {
+		t.Fatalf("expected empty directories to be removed, got %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("store directory removed: %v", err)
+	}
+}
+
+func TestLocalStore_RejectsKeysOutsideTheStore(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	for _, key := range []string{"", ".", "../escape", "/etc/passwd", "media/../../escape"} {
+		if err := store.Put(context.Background(), key, "", []byte("x")); err == nil {
+			t.Errorf("key %q: expected an error", key)
+		}
+	}
+}
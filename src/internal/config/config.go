@@ -9,14 +9,27 @@ type Config struct {
 	GoogleClientID string
 	JWTSecret      string
 	Port           string
+	// media is kept under MediaDir until an S3 bucket is configured
+	MediaDir          string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
 }
 
 func Load() *Config {
 	return &Config{
-		DatabaseURL:    getEnv("DATABASE_URL", "host=127.0.0.1 port=5432 user=user password=password dbname=workoutpal sslmode=disable"),
-		GoogleClientID: getEnv("GOOGLE_CLIENT_ID", ""),
-		JWTSecret:      getEnv("JWT_SECRET", "your-secret-key"),
-		Port:           getEnv("PORT", "8080"),
+		DatabaseURL:       getEnv("DATABASE_URL", "host=127.0.0.1 port=5432 user=user password=password dbname=workoutpal sslmode=disable"),
+		GoogleClientID:    getEnv("GOOGLE_CLIENT_ID", ""),
+		JWTSecret:         getEnv("JWT_SECRET", "your-secret-key"),
+		Port:              getEnv("PORT", "8080"),
+		MediaDir:          getEnv("MEDIA_DIR", "media"),
+		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
 	}
 }
 
@@ -25,4 +38,4 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
@@ -39,6 +39,12 @@ func TestLoad(t *testing.T) {
 		t.Setenv("GOOGLE_CLIENT_ID", "")
 		t.Setenv("JWT_SECRET", "")
 		t.Setenv("PORT", "")
+		t.Setenv("MEDIA_DIR", "")
+		t.Setenv("S3_ENDPOINT", "")
+		t.Setenv("S3_REGION", "")
+		t.Setenv("S3_BUCKET", "")
+		t.Setenv("S3_ACCESS_KEY_ID", "")
+		t.Setenv("S3_SECRET_ACCESS_KEY", "")
 
 		cfg := Load()
 		if cfg == nil {
@@ -50,6 +56,8 @@ func TestLoad(t *testing.T) {
 			GoogleClientID: "",
 			JWTSecret:      "your-secret-key",
 			Port:           "8080",
+			MediaDir:       "media",
+			S3Region:       "us-east-1",
 		}
 		if !reflect.DeepEqual(cfg, want) {
 			t.Fatalf("defaults mismatch\n got:  %#v\n want: %#v", cfg, want)
@@ -61,6 +69,12 @@ func TestLoad(t *testing.T) {
 		t.Setenv("GOOGLE_CLIENT_ID", "abc.apps.googleusercontent.com")
 		t.Setenv("JWT_SECRET", "shh-its-a-secret")
 		t.Setenv("PORT", "9090")
+		t.Setenv("MEDIA_DIR", "/var/lib/workoutpal/media")
+		t.Setenv("S3_ENDPOINT", "http://minio:9000")
+		t.Setenv("S3_REGION", "eu-west-1")
+		t.Setenv("S3_BUCKET", "workoutpal-media")
+		t.Setenv("S3_ACCESS_KEY_ID", "access")
+		t.Setenv("S3_SECRET_ACCESS_KEY", "secret")
 
 		cfg := Load()
 		if cfg == nil {
@@ -68,10 +82,16 @@ func TestLoad(t *testing.T) {
 		}
 
 		want := &Config{
-			DatabaseURL:    "postgres://user:pass@db:5432/app?sslmode=disable",
-			GoogleClientID: "abc.apps.googleusercontent.com",
-			JWTSecret:      "shh-its-a-secret",
-			Port:           "9090",
+			DatabaseURL:       "postgres://user:pass@db:5432/app?sslmode=disable",
+			GoogleClientID:    "abc.apps.googleusercontent.com",
+			JWTSecret:         "shh-its-a-secret",
+			Port:              "9090",
+			MediaDir:          "/var/lib/workoutpal/media",
+			S3Endpoint:        "http://minio:9000",
+			S3Region:          "eu-west-1",
+			S3Bucket:          "workoutpal-media",
+			S3AccessKeyID:     "access",
+			S3SecretAccessKey: "secret",
 		}
 		if !reflect.DeepEqual(cfg, want) {
 			t.Fatalf("env override mismatch\n got:  %#v\n want: %#v", cfg, want)
@@ -7,4 +7,4 @@ import (
 func main() {
 	cfg := Load()
 	fmt.Printf("DatabaseURL: %s\n", cfg.DatabaseURL)
-}
\ No newline at end of file
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS user_exercise_settings;
+DROP TABLE IF EXISTS goals;
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS achievements;
+DROP TABLE IF EXISTS exercises_in_routine;
+DROP TABLE IF EXISTS workout_routine;
+DROP TABLE IF EXISTS exercises;
+DROP TABLE IF EXISTS post_comments;
+DROP TABLE IF EXISTS post_likes;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS follows;
+DROP TABLE IF EXISTS users;
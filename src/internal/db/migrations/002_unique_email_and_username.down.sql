@@ -0,0 +1,6 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+
+ALTER TABLE users
+ALTER COLUMN email TYPE VARCHAR,
+ALTER COLUMN username TYPE VARCHAR;
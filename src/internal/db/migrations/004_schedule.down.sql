@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS schedule_routine;
+DROP TABLE IF EXISTS schedule;
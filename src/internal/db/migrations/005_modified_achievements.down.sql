@@ -0,0 +1,2 @@
+ALTER TABLE achievements DROP COLUMN IF EXISTS badge_icon;
+ALTER TABLE achievements DROP COLUMN IF EXISTS title;
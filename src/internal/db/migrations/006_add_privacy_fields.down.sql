@@ -0,0 +1,3 @@
+ALTER TABLE users
+  DROP COLUMN IF EXISTS is_private,
+  DROP COLUMN IF EXISTS show_metrics_to_followers;
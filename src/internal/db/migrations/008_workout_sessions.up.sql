@@ -0,0 +1,32 @@
+-- Logged workout sessions, started from a routine
+CREATE TABLE IF NOT EXISTS workout_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    routine_id INTEGER,
+    name VARCHAR,
+    notes TEXT,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (routine_id) REFERENCES workout_routine(id) ON DELETE SET NULL
+);
+
+-- Sets actually performed during a session
+CREATE TABLE IF NOT EXISTS workout_sets (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    set_number INTEGER NOT NULL,
+    weight NUMERIC NOT NULL DEFAULT 0,
+    reps INTEGER NOT NULL,
+    rpe NUMERIC,
+    rest_seconds INTEGER NOT NULL DEFAULT 0,
+    logged_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_id ON workout_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_workout_sets_session_id ON workout_sets(session_id);
+-- Only one session per user can be in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workout_sessions_one_active ON workout_sessions(user_id) WHERE finished_at IS NULL;
@@ -0,0 +1,20 @@
+-- Personal record history; a row is only written when a set beats the previous best
+CREATE TABLE IF NOT EXISTS personal_records (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    record_type VARCHAR NOT NULL CHECK (record_type IN ('max_weight', 'estimated_1rm', 'max_reps', 'max_volume')),
+    formula VARCHAR NOT NULL DEFAULT '',
+    value NUMERIC NOT NULL,
+    weight NUMERIC NOT NULL DEFAULT 0,
+    reps INTEGER NOT NULL DEFAULT 0,
+    session_id INTEGER,
+    set_id INTEGER,
+    achieved_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    FOREIGN KEY (set_id) REFERENCES workout_sets(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_records_user_exercise ON personal_records(user_id, exercise_id, record_type);
@@ -0,0 +1,9 @@
+-- Remove the rule-only achievements, including any that were already earned
+DELETE FROM user_achievements WHERE achievement_id IN (
+    SELECT id FROM achievements WHERE name IN ('10_Posts', 'First_Schedule', '10_Scheduled_Workouts', '7_Day_Streak')
+);
+DELETE FROM achievements WHERE name IN ('10_Posts', 'First_Schedule', '10_Scheduled_Workouts', '7_Day_Streak');
+
+DROP INDEX IF EXISTS idx_achievements_criteria_type;
+ALTER TABLE achievements DROP COLUMN IF EXISTS criteria_threshold;
+ALTER TABLE achievements DROP COLUMN IF EXISTS criteria_type;
@@ -0,0 +1,26 @@
+-- Declarative rules for achievements; the service awards them once a user's
+-- progress for criteria_type reaches criteria_threshold.
+-- Achievements without a criteria_type can only be granted by an admin.
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS criteria_type VARCHAR;
+ALTER TABLE achievements ADD COLUMN IF NOT EXISTS criteria_threshold INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_achievements_criteria_type ON achievements(criteria_type);
+
+UPDATE achievements SET criteria_type = 'routines', criteria_threshold = 1 WHERE name = 'First_Routine';
+UPDATE achievements SET criteria_type = 'followers', criteria_threshold = 5 WHERE name = '5_Followers';
+UPDATE achievements SET criteria_type = 'following', criteria_threshold = 5 WHERE name = '5_Following';
+UPDATE achievements SET criteria_type = 'likes_given', criteria_threshold = 1 WHERE name = 'First_Like';
+UPDATE achievements SET criteria_type = 'posts', criteria_threshold = 1 WHERE name = 'First_Share';
+
+-- the seed inserted explicit ids, so move the sequence past them
+SELECT setval(pg_get_serial_sequence('achievements', 'id'), (SELECT COALESCE(MAX(id), 1) FROM achievements));
+
+INSERT INTO achievements (name, description, created_at, badge_icon, title, criteria_type, criteria_threshold)
+SELECT v.name, v.description, NOW(), v.badge_icon, v.title, v.criteria_type, v.criteria_threshold
+FROM (VALUES
+    ('10_Posts', 'Share 10 posts.', '📣', 'Regular Poster', 'posts', 10),
+    ('First_Schedule', 'Create a schedule for the first time.', '🗓️', 'Planner', 'schedules', 1),
+    ('10_Scheduled_Workouts', 'Finish 10 workouts from your schedules.', '⏰', 'Right On Time', 'scheduled_workouts', 10),
+    ('7_Day_Streak', 'Finish a workout 7 days in a row.', '🔥', 'On Fire', 'workout_streak', 7)
+) AS v(name, description, badge_icon, title, criteria_type, criteria_threshold)
+WHERE NOT EXISTS (SELECT 1 FROM achievements a WHERE a.name = v.name);
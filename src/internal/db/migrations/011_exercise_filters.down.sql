@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_exercises_name_id;
+ALTER TABLE exercises DROP COLUMN IF EXISTS expertise;
+ALTER TABLE exercises DROP COLUMN IF EXISTS intensity;
@@ -0,0 +1,6 @@
+-- Intensity and expertise were part of the API model but never stored
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS intensity VARCHAR;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS expertise VARCHAR;
+
+-- Keyset pagination over the default name ordering
+CREATE INDEX IF NOT EXISTS idx_exercises_name_id ON exercises ((LOWER(COALESCE(name, '')) COLLATE "C"), id);
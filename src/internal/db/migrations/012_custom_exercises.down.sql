@@ -0,0 +1,11 @@
+-- Custom exercises cannot outlive their owner column, otherwise they would become built-in
+DELETE FROM exercises WHERE owner_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_exercises_owner_id;
+ALTER TABLE exercises ALTER COLUMN custom DROP DEFAULT;
+ALTER TABLE exercises DROP COLUMN IF EXISTS recommended_duration;
+ALTER TABLE exercises DROP COLUMN IF EXISTS recommended_sets;
+ALTER TABLE exercises DROP COLUMN IF EXISTS recommended_count;
+ALTER TABLE exercises DROP COLUMN IF EXISTS demo;
+ALTER TABLE exercises DROP COLUMN IF EXISTS is_public;
+ALTER TABLE exercises DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,13 @@
+-- User-authored exercises live next to the built-in catalogue (owner_id IS NULL).
+-- They are private to their author unless is_public is set.
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS is_public BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS demo VARCHAR;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_count INTEGER;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_sets INTEGER;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS recommended_duration INTEGER;
+
+UPDATE exercises SET custom = FALSE WHERE custom IS NULL AND owner_id IS NULL;
+ALTER TABLE exercises ALTER COLUMN custom SET DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_exercises_owner_id ON exercises(owner_id) WHERE owner_id IS NOT NULL;
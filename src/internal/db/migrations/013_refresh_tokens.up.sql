@@ -0,0 +1,22 @@
+-- A session is one signed-in device. Its refresh tokens form a family: each refresh
+-- rotates to a new token, and presenting a used one again revokes the whole session.
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id VARCHAR PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user_id ON auth_sessions(user_id);
+
+-- Only a sha256 of each token is stored
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    session_id VARCHAR NOT NULL REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens(session_id);
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS is_verified;
+ALTER TABLE users DROP COLUMN IF EXISTS provider;
+ALTER TABLE users DROP COLUMN IF EXISTS google_id;
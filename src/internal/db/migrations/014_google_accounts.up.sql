@@ -0,0 +1,5 @@
+-- Google sign-in: google_id is the token's subject, accounts created through
+-- google get provider 'google' and an empty password so password login never matches
+ALTER TABLE users ADD COLUMN IF NOT EXISTS google_id VARCHAR UNIQUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS provider VARCHAR NOT NULL DEFAULT 'local';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT FALSE;
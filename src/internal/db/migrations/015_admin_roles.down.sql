@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS admin_audit_log;
+ALTER TABLE users DROP COLUMN IF EXISTS moderation_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS banned_at;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ALTER COLUMN role DROP NOT NULL;
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
@@ -0,0 +1,27 @@
+-- Roles: user, moderator and admin. The role is copied into the access token
+-- whenever a session is created or refreshed.
+UPDATE users SET role = 'user' WHERE role IS NULL OR role NOT IN ('user', 'moderator', 'admin');
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+
+-- Moderation: a suspended user can sign in again once suspended_until has passed,
+-- a banned user cannot until an admin reinstates them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+
+-- One row per admin action. Actor and target are plain ids, not foreign keys,
+-- so the trail outlives the users and content it mentions.
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id SERIAL PRIMARY KEY,
+    actor_id INTEGER NOT NULL,
+    action VARCHAR NOT NULL,
+    target_type VARCHAR NOT NULL,
+    target_id INTEGER NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target ON admin_audit_log(target_type, target_id);
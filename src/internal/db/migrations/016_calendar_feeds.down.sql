@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS calendar_feeds;
+ALTER TABLE schedule DROP COLUMN IF EXISTS created_at;
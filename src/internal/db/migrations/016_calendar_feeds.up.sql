@@ -0,0 +1,9 @@
+-- Anchors the first occurrence of each weekly schedule in calendar exports
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- One subscription URL per user. Only a sha256 of the secret in the URL is stored.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
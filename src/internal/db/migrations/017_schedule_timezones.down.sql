@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS schedule_exceptions;
+ALTER TABLE schedule DROP COLUMN IF EXISTS ends_on;
+ALTER TABLE schedule DROP COLUMN IF EXISTS starts_on;
+ALTER TABLE schedule DROP COLUMN IF EXISTS timezone;
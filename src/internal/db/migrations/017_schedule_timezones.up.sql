@@ -0,0 +1,16 @@
+-- Time slots are wall clock times in the schedule's IANA time zone. Existing
+-- schedules were created without one and are read as UTC.
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS timezone VARCHAR NOT NULL DEFAULT 'UTC';
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS starts_on DATE;
+ALTER TABLE schedule ADD COLUMN IF NOT EXISTS ends_on DATE;
+
+-- Changes to single occurrences. occurs_on is the local date the occurrence was
+-- planned for; it is skipped when rescheduled_to is NULL and moved otherwise.
+CREATE TABLE IF NOT EXISTS schedule_exceptions (
+    id SERIAL PRIMARY KEY,
+    schedule_id INT NOT NULL REFERENCES schedule(id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    rescheduled_to TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (schedule_id, occurs_on)
+);
@@ -0,0 +1,10 @@
+-- How a planned occurrence went, when the user says so themselves. Occurrences
+-- without a mark are matched against finished workout sessions instead.
+CREATE TABLE IF NOT EXISTS schedule_occurrence_marks (
+    schedule_id INT NOT NULL REFERENCES schedule(id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('completed', 'missed', 'skipped')),
+    session_id INT REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (schedule_id, occurs_on)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_post_comments_post_id;
+DROP INDEX IF EXISTS idx_posts_user_id;
+DROP INDEX IF EXISTS idx_posts_created_at_id;
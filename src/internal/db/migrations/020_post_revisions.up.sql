@@ -0,0 +1,15 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+-- Earlier versions of edited posts. written_at is when the version was posted
+-- or last edited, replaced_at when an edit replaced it.
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    title VARCHAR,
+    caption VARCHAR,
+    body TEXT,
+    status VARCHAR,
+    written_at TIMESTAMP,
+    replaced_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id ON post_revisions(post_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_post_comments_parent;
+ALTER TABLE post_comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE post_comments DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,6 @@
+-- A deleted comment keeps its row so its replies stay in the thread.
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+ALTER TABLE post_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Replies are paged per parent by (created_at, id).
+CREATE INDEX IF NOT EXISTS idx_post_comments_parent ON post_comments(parent_comment_id, created_at, id);
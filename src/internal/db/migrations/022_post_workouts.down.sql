@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_one_workout;
+ALTER TABLE posts DROP COLUMN IF EXISTS session_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS routine_id;
@@ -0,0 +1,5 @@
+-- A post can share one of its author's routines or finished sessions.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS routine_id INTEGER REFERENCES workout_routine(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS session_id INTEGER REFERENCES workout_sessions(id) ON DELETE SET NULL;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_one_workout;
+ALTER TABLE posts ADD CONSTRAINT posts_one_workout CHECK (routine_id IS NULL OR session_id IS NULL);
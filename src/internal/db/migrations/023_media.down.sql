@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_media_id;
+DROP TABLE IF EXISTS post_media;
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,23 @@
+-- Uploaded images. The bytes live in the blob store under media/<id>/original
+-- and media/<id>/thumbnail; ids are random so they can't be guessed.
+CREATE TABLE IF NOT EXISTS media (
+    id VARCHAR(32) PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    -- NULL when the image is small enough to be its own thumbnail
+    thumbnail_type VARCHAR,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_media_owner ON media(owner_id);
+
+CREATE TABLE IF NOT EXISTS post_media (
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    media_id VARCHAR(32) NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (post_id, media_id)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_media_id VARCHAR(32) REFERENCES media(id) ON DELETE SET NULL;
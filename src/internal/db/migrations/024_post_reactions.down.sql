@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_post_likes_post;
+ALTER TABLE post_likes DROP CONSTRAINT IF EXISTS post_likes_reaction;
+ALTER TABLE post_likes DROP COLUMN IF EXISTS reaction;
@@ -0,0 +1,5 @@
+-- A like is now one of several reactions; a user still reacts to a post once.
+ALTER TABLE post_likes ADD COLUMN IF NOT EXISTS reaction VARCHAR(16) NOT NULL DEFAULT 'like';
+ALTER TABLE post_likes DROP CONSTRAINT IF EXISTS post_likes_reaction;
+ALTER TABLE post_likes ADD CONSTRAINT post_likes_reaction CHECK (reaction IN ('like', 'fire', 'strong', 'clap'));
+CREATE INDEX IF NOT EXISTS idx_post_likes_post ON post_likes(post_id, created_at DESC, user_id DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_goals_user_id;
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_measurable;
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_type;
+ALTER TABLE goals DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE goals DROP COLUMN IF EXISTS start_value;
+ALTER TABLE goals DROP COLUMN IF EXISTS target_value;
+ALTER TABLE goals DROP COLUMN IF EXISTS exercise_id;
+ALTER TABLE goals DROP COLUMN IF EXISTS goal_type;
@@ -0,0 +1,16 @@
+-- Goals can be measured against logged data. Existing goals stay custom,
+-- free text goals whose status only changes by hand.
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS goal_type VARCHAR NOT NULL DEFAULT 'custom';
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE;
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS target_value NUMERIC;
+-- the body weight when a body weight goal was set, so progress has a baseline
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS start_value NUMERIC;
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_type;
+ALTER TABLE goals ADD CONSTRAINT goals_type CHECK (goal_type IN ('custom', 'body_weight', 'one_rep_max', 'workouts_per_week'));
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_measurable;
+ALTER TABLE goals ADD CONSTRAINT goals_measurable CHECK (
+    goal_type = 'custom'
+    OR (target_value > 0 AND (goal_type = 'one_rep_max') = (exercise_id IS NOT NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON goals(user_id);
@@ -0,0 +1,23 @@
+-- Body measurements over time, stored in SI units: kilograms for weight,
+-- centimetres for circumferences and percent for body fat. Values are
+-- converted to the user's preferred units when they are read.
+CREATE TABLE IF NOT EXISTS body_measurements (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric VARCHAR(16) NOT NULL,
+    value NUMERIC NOT NULL,
+    measured_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT body_measurements_metric CHECK (metric IN ('weight', 'body_fat', 'waist', 'chest', 'hips', 'neck', 'arm', 'thigh')),
+    CONSTRAINT body_measurements_value CHECK (value > 0 AND (metric <> 'body_fat' OR value < 100))
+);
+CREATE INDEX IF NOT EXISTS idx_body_measurements_user_metric ON body_measurements(user_id, metric, measured_at);
+
+-- seed each history with the weight on the profile
+INSERT INTO body_measurements (user_id, metric, value, measured_at)
+SELECT id, 'weight',
+    CASE WHEN LOWER(TRIM(weight_metric)) IN ('lb', 'lbs', 'pound', 'pounds') THEN weight * 0.45359237 ELSE weight END,
+    NOW()
+FROM users
+WHERE weight > 0
+  AND NOT EXISTS (SELECT 1 FROM body_measurements bm WHERE bm.user_id = users.id);
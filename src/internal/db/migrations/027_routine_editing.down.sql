@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_exercises_in_routine_order;
+ALTER TABLE exercises_in_routine DROP CONSTRAINT IF EXISTS exercises_in_routine_targets;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS superset_group;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS rest_seconds;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS target_reps_max;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS target_reps_min;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS target_sets;
+ALTER TABLE exercises_in_routine DROP COLUMN IF EXISTS position;
+ALTER TABLE workout_routine DROP COLUMN IF EXISTS description;
@@ -0,0 +1,30 @@
+-- Routines keep their description, an exercise order, and what to aim for
+-- on each exercise. Existing routines are ordered by exercise id.
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS position INTEGER;
+UPDATE exercises_in_routine e SET position = ordered.position
+FROM (
+    SELECT workout_routine_id, exercise_id,
+        ROW_NUMBER() OVER (PARTITION BY workout_routine_id ORDER BY exercise_id) - 1 AS position
+    FROM exercises_in_routine
+) ordered
+WHERE e.workout_routine_id = ordered.workout_routine_id AND e.exercise_id = ordered.exercise_id AND e.position IS NULL;
+ALTER TABLE exercises_in_routine ALTER COLUMN position SET DEFAULT 0;
+ALTER TABLE exercises_in_routine ALTER COLUMN position SET NOT NULL;
+
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_sets INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_reps_min INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS target_reps_max INTEGER;
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS rest_seconds INTEGER;
+-- exercises sharing a group within a routine are done back to back
+ALTER TABLE exercises_in_routine ADD COLUMN IF NOT EXISTS superset_group INTEGER;
+ALTER TABLE exercises_in_routine DROP CONSTRAINT IF EXISTS exercises_in_routine_targets;
+ALTER TABLE exercises_in_routine ADD CONSTRAINT exercises_in_routine_targets CHECK (
+    (target_sets IS NULL OR target_sets > 0)
+    AND (target_reps_min IS NULL OR target_reps_min > 0)
+    AND (target_reps_max IS NULL OR target_reps_max >= COALESCE(target_reps_min, 1))
+    AND (rest_seconds IS NULL OR rest_seconds >= 0)
+    AND (superset_group IS NULL OR superset_group > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_exercises_in_routine_order ON exercises_in_routine(workout_routine_id, position);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_workout_routine_public;
+ALTER TABLE workout_routine DROP COLUMN IF EXISTS fork_count;
+ALTER TABLE workout_routine DROP COLUMN IF EXISTS forked_from_user_id;
+ALTER TABLE workout_routine DROP COLUMN IF EXISTS forked_from_routine_id;
+ALTER TABLE workout_routine DROP CONSTRAINT IF EXISTS workout_routine_visibility;
+ALTER TABLE workout_routine DROP COLUMN IF EXISTS visibility;
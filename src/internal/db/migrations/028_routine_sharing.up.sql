@@ -0,0 +1,10 @@
+-- Routines are private unless their owner shares them with followers or with
+-- everyone. A fork remembers the routine and user it came from, and the
+-- source counts how many times it has been forked.
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'private';
+ALTER TABLE workout_routine DROP CONSTRAINT IF EXISTS workout_routine_visibility;
+ALTER TABLE workout_routine ADD CONSTRAINT workout_routine_visibility CHECK (visibility IN ('private', 'followers', 'public'));
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS forked_from_routine_id INTEGER REFERENCES workout_routine(id) ON DELETE SET NULL;
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS forked_from_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE workout_routine ADD COLUMN IF NOT EXISTS fork_count INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_workout_routine_public ON workout_routine(fork_count DESC, id DESC) WHERE visibility = 'public';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS program_enrollment_schedules;
+DROP TABLE IF EXISTS program_enrollment_routines;
+DROP TABLE IF EXISTS program_enrollments;
+DROP TABLE IF EXISTS program_days;
+DROP TABLE IF EXISTS program_weeks;
+DROP TABLE IF EXISTS programs;
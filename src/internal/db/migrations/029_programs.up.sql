@@ -0,0 +1,69 @@
+-- Training programs run for a number of weeks. Each week maps days of the
+-- week (0 = Sunday) to one of the author's routines, with a load given as a
+-- percentage of the estimated one rep max or as an RPE target.
+CREATE TABLE IF NOT EXISTS programs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    visibility VARCHAR(16) NOT NULL DEFAULT 'private',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT programs_visibility CHECK (visibility IN ('private', 'followers', 'public'))
+);
+CREATE INDEX IF NOT EXISTS idx_programs_user_id ON programs(user_id);
+
+CREATE TABLE IF NOT EXISTS program_weeks (
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL CHECK (week > 0),
+    name TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (program_id, week)
+);
+
+CREATE TABLE IF NOT EXISTS program_days (
+    program_id INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    routine_id INTEGER NOT NULL REFERENCES workout_routine(id) ON DELETE CASCADE,
+    load_type VARCHAR(16) NOT NULL,
+    load NUMERIC NOT NULL,
+    PRIMARY KEY (program_id, week, day_of_week),
+    FOREIGN KEY (program_id, week) REFERENCES program_weeks(program_id, week) ON DELETE CASCADE,
+    CONSTRAINT program_days_load CHECK (
+        (load_type = 'percent' AND load > 0 AND load <= 100)
+        OR (load_type = 'rpe' AND load >= 1 AND load <= 10)
+    )
+);
+
+-- current_week is the last week whose schedules have been generated; weeks
+-- start on started_on, so each one covers every day of the week once
+CREATE TABLE IF NOT EXISTS program_enrollments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    started_on DATE NOT NULL,
+    current_week INTEGER NOT NULL DEFAULT 1,
+    time_slot TIME NOT NULL,
+    routine_length_minutes INTEGER NOT NULL,
+    timezone VARCHAR NOT NULL DEFAULT 'UTC',
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT program_enrollments_status CHECK (status IN ('active', 'completed', 'cancelled'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_program_enrollments_active ON program_enrollments(user_id, program_id) WHERE status = 'active';
+
+-- the enrollee's own copy of each of the program's routines
+CREATE TABLE IF NOT EXISTS program_enrollment_routines (
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    program_routine_id INTEGER NOT NULL,
+    routine_id INTEGER NOT NULL REFERENCES workout_routine(id) ON DELETE CASCADE,
+    PRIMARY KEY (enrollment_id, program_routine_id)
+);
+
+-- schedules generated for an enrollment, one per program day
+CREATE TABLE IF NOT EXISTS program_enrollment_schedules (
+    schedule_id INTEGER PRIMARY KEY REFERENCES schedule(id) ON DELETE CASCADE,
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_program_enrollment_schedules_week ON program_enrollment_schedules(enrollment_id, week);
@@ -0,0 +1,3 @@
+-- Deleting a custom exercise hides it instead of removing the row, so the sets,
+-- records, routines and goals of other users that reference it survive
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
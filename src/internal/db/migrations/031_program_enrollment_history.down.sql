@@ -0,0 +1,5 @@
+DELETE FROM program_enrollments WHERE program_id IS NULL;
+ALTER TABLE program_enrollments DROP CONSTRAINT IF EXISTS program_enrollments_program_id_fkey;
+ALTER TABLE program_enrollments ADD CONSTRAINT program_enrollments_program_id_fkey
+    FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE;
+ALTER TABLE program_enrollments ALTER COLUMN program_id SET NOT NULL;
@@ -0,0 +1,6 @@
+-- Deleting a program cancels its active enrollments instead of removing them,
+-- so enrollees keep their history; program_id is cleared once it is gone
+ALTER TABLE program_enrollments ALTER COLUMN program_id DROP NOT NULL;
+ALTER TABLE program_enrollments DROP CONSTRAINT IF EXISTS program_enrollments_program_id_fkey;
+ALTER TABLE program_enrollments ADD CONSTRAINT program_enrollments_program_id_fkey
+    FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_personal_records_session_volume;
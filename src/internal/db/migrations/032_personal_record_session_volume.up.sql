@@ -0,0 +1,14 @@
+-- A session keeps a single max_volume record per exercise, raised as sets are
+-- logged; earlier duplicates give way to the session's highest total
+DELETE FROM personal_records pr
+USING personal_records higher
+WHERE pr.record_type = 'max_volume'
+  AND higher.record_type = 'max_volume'
+  AND higher.user_id = pr.user_id
+  AND higher.exercise_id = pr.exercise_id
+  AND higher.session_id = pr.session_id
+  AND (higher.value, higher.id) > (pr.value, pr.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_personal_records_session_volume
+    ON personal_records(user_id, exercise_id, session_id, record_type)
+    WHERE record_type = 'max_volume';
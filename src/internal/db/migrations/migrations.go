@@ -0,0 +1,261 @@
+// Package migrations applies the versioned SQL files in this directory.
+// Files are named NNN_name.up.sql and, optionally, NNN_name.down.sql; they are
+// embedded into the binary so the API can migrate its own database.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockKey identifies the postgres advisory lock held while migrating, so
+// replicas starting at the same time apply each migration exactly once
+const lockKey int64 = 4_810_220_317
+
+const createTrackingTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	HasDown   bool
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+// New returns a migrator for the migrations embedded in the binary
+func New(db *sql.DB) (*Migrator, error) {
+	return NewFromFS(db, files)
+}
+
+func NewFromFS(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads every NNN_name.up.sql / NNN_name.down.sql pair, ordered by version
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range entries {
+		base := path.Base(file)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", base)
+		}
+
+		stem := strings.TrimSuffix(base, "."+direction+".sql")
+		prefix, name, ok := strings.Cut(stem, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named NNN_name.%s.sql", base, direction)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no numeric version", base)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up migration", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies every pending migration in order and returns the ones it applied
+func (m *Migrator) Up() ([]*Migration, error) {
+	applied := make([]*Migration, 0)
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := apply(ctx, conn, migration, migration.Up,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first
+func (m *Migrator) Down(steps int) ([]*Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("down needs at least one step")
+	}
+
+	reverted := make([]*Migration, 0, steps)
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %03d_%s has no down migration", migration.Version, migration.Name)
+			}
+			if err := apply(ctx, conn, migration, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status() ([]*Status, error) {
+	var statuses []*Status
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses = make([]*Status, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			appliedAt, ok := done[migration.Version]
+			statuses = append(statuses, &Status{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+				HasDown:   migration.Down != "",
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock;
+// session level advisory locks belong to a connection, not to the pool
+func (m *Migrator) withLock(fn func(ctx context.Context, conn *sql.Conn) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if _, err := conn.ExecContext(ctx, createTrackingTable); err != nil {
+		return err
+	}
+	return fn(ctx, conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	return done, rows.Err()
+}
+
+// apply runs one migration and its bookkeeping in a single transaction
+func apply(ctx context.Context, conn *sql.Conn, migration *Migration, body string, track string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migration %03d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, track, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,212 @@
+package migrations
+
+import (
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"001_users.up.sql":   {Data: []byte("CREATE TABLE users (id SERIAL);")},
+		"001_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"002_posts.up.sql":   {Data: []byte("CREATE TABLE posts (id SERIAL);")},
+		"010_likes.up.sql":   {Data: []byte("CREATE TABLE likes (id SERIAL);")},
+		"010_likes.down.sql": {Data: []byte("DROP TABLE likes;")},
+	}
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(versions ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, v := range versions {
+		rows.AddRow(v, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	return rows
+}
+
+func TestLoad_OrdersAndPairsFiles(t *testing.T) {
+	got, err := Load(testFS())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("want 3 migrations, got %d", len(got))
+	}
+	if got[0].Version != 1 || got[1].Version != 2 || got[2].Version != 10 {
+		t.Fatalf("unexpected order: %d %d %d", got[0].Version, got[1].Version, got[2].Version)
+	}
+	if got[0].Down == "" || got[1].Down != "" || got[2].Name != "likes" {
+		t.Fatalf("unexpected migrations: %#v %#v %#v", got[0], got[1], got[2])
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"no direction", fstest.MapFS{"001_users.sql": {Data: []byte("x")}}},
+		{"no version", fstest.MapFS{"users.up.sql": {Data: []byte("x")}}},
+		{"non numeric version", fstest.MapFS{"abc_users.up.sql": {Data: []byte("x")}}},
+		{"down without up", fstest.MapFS{"001_users.down.sql": {Data: []byte("x")}}},
+		{"duplicate version", fstest.MapFS{
+			"001_users.up.sql": {Data: []byte("x")},
+			"001_posts.up.sql": {Data: []byte("x")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	got, err := Load(files)
+	if err != nil {
+		t.Fatalf("embedded migrations do not load: %v", err)
+	}
+	for i, m := range got {
+		if m.Version != i+1 {
+			t.Fatalf("migration versions must be contiguous, found %03d_%s at position %d", m.Version, m.Name, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %03d_%s has no down migration", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrator_Up_AppliesPendingInOrder(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	migrator, err := NewFromFS(db, testFS())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(appliedRows(1))
+	for _, m := range []struct {
+		version int
+		name    string
+		body    string
+	}{{2, "posts", "CREATE TABLE posts"}, {10, "likes", "CREATE TABLE likes"}} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(m.body)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+			WithArgs(m.version, m.name).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	expectUnlock(mock)
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(applied) != 2 || applied[0].Version != 2 || applied[1].Version != 10 {
+		t.Fatalf("unexpected applied migrations: %#v", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestMigrator_Up_StopsAtFailure(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	migrator, _ := NewFromFS(db, testFS())
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(appliedRows(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE posts")).WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := migrator.Up()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if len(applied) != 0 {
+		t.Fatalf("nothing should be applied, got %#v", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestMigrator_Down_RevertsNewestFirst(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	migrator, _ := NewFromFS(db, testFS())
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(appliedRows(1, 10))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE likes")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+		WithArgs(10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := migrator.Down(1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(reverted) != 1 || reverted[0].Version != 10 {
+		t.Fatalf("unexpected reverted migrations: %#v", reverted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestMigrator_Down_MissingDownFile(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	migrator, _ := NewFromFS(db, testFS())
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(appliedRows(1, 2))
+	expectUnlock(mock)
+
+	if _, err := migrator.Down(1); err == nil {
+		t.Fatalf("expected an error for 002_posts without a down file")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	migrator, _ := NewFromFS(db, testFS())
+
+	expectLock(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(appliedRows(1))
+	expectUnlock(mock)
+
+	got, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 3 || !got[0].Applied || got[1].Applied || got[2].Applied || got[1].HasDown {
+		t.Fatalf("unexpected status: %#v %#v %#v", got[0], got[1], got[2])
+	}
+}
@@ -2,8 +2,12 @@ package dependency
 
 import (
 	"database/sql"
+	"workoutpal/src/internal/blob"
+	"workoutpal/src/internal/config"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/oidc"
+	"workoutpal/src/internal/policy"
 	repository2 "workoutpal/src/internal/repository"
 	service2 "workoutpal/src/internal/service"
 )
@@ -24,9 +28,15 @@ type AppDependencies struct {
 	PostService            service.PostService
 	AchievementService     service.AchievementService
 	ExerciseSettingService service.ExerciseSettingService
+	WorkoutSessionService  service.WorkoutSessionService
+	PersonalRecordService  service.PersonalRecordService
+	AdminService           service.AdminService
+	MediaService           service.MediaService
+	BodyMeasurementService service.BodyMeasurementService
+	ProgramService         service.ProgramService
 }
 
-func NewAppDependencies(db *sql.DB) AppDependencies {
+func NewAppDependencies(cfg *config.Config, db *sql.DB) AppDependencies {
 	// --- Init Repositories ---
 	userRepository := repository2.NewUserRepository(db)
 	relationshipRepository := repository2.NewRelationshipRepository(db)
@@ -37,18 +47,50 @@ func NewAppDependencies(db *sql.DB) AppDependencies {
 	postRepository := repository2.NewPostRepository(db)
 	achievementRepository := repository2.NewAchievementRepository(db)
 	exerciseSettingRepository := repository2.NewExerciseSettingRepository(db)
+	workoutSessionRepository := repository2.NewWorkoutSessionRepository(db)
+	personalRecordRepository := repository2.NewPersonalRecordRepository(db)
+	sessionRepository := repository2.NewSessionRepository(db)
+	auditRepository := repository2.NewAuditRepository(db)
+	mediaRepository := repository2.NewMediaRepository(db)
+	bodyMeasurementRepository := repository2.NewBodyMeasurementRepository(db)
+	programRepository := repository2.NewProgramRepository(db)
+
+	// media stays on local disk until an S3 bucket is configured
+	var blobStore repository.BlobStore = blob.NewLocalStore(cfg.MediaDir)
+	if cfg.S3Bucket != "" {
+		blobStore = blob.NewS3Store(blob.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		}, nil)
+	}
 
 	// --- Init Services ---
-	userService := service2.NewUserService(userRepository)
-	relationshipService := service2.NewRelationshipService(relationshipRepository, userRepository)
-	goalService := service2.NewGoalService(goalRepository)
+	// google sign-in stays off until a client id is configured
+	var googleVerifier service.IDTokenVerifier
+	if cfg.GoogleClientID != "" {
+		googleVerifier = oidc.NewGoogleVerifier(cfg.GoogleClientID, oidc.NewJWKSKeySource(oidc.GoogleJWKSURL, nil))
+	}
+	accessPolicy := policy.NewAccessPolicy(userRepository, relationshipRepository)
+	// achievements first, the other services notify it about their events
+	achievementService := service2.NewAchievementService(achievementRepository, userRepository)
+	userService := service2.NewUserService(userRepository, accessPolicy)
+	relationshipService := service2.NewRelationshipService(relationshipRepository, userRepository, achievementService, accessPolicy)
+	goalService := service2.NewGoalService(goalRepository, accessPolicy)
 	exerciseService := service2.NewExerciseService(exerciseRepository)
-	routineService := service2.NewRoutineService(routineRepository)
-	authService := service2.NewAuthService(userRepository)
-	scheduleService := service2.NewScheduleService(scheduleRepository)
-	postService := service2.NewPostService(postRepository)
-	achievementService := service2.NewAchievementService(achievementRepository)
-	exerciseSettingService := service2.NewExerciseSettingService(exerciseSettingRepository)
+	routineService := service2.NewRoutineService(routineRepository, exerciseRepository, achievementService, accessPolicy)
+	authService := service2.NewAuthService(userRepository, sessionRepository, googleVerifier)
+	scheduleService := service2.NewScheduleService(scheduleRepository, routineRepository, workoutSessionRepository, achievementService, accessPolicy)
+	postService := service2.NewPostService(postRepository, mediaRepository, routineService, workoutSessionRepository, achievementService, accessPolicy)
+	exerciseSettingService := service2.NewExerciseSettingService(exerciseSettingRepository, routineRepository, accessPolicy)
+	personalRecordService := service2.NewPersonalRecordService(personalRecordRepository, accessPolicy)
+	workoutSessionService := service2.NewWorkoutSessionService(workoutSessionRepository, routineRepository, personalRecordService, achievementService, goalService)
+	mediaService := service2.NewMediaService(mediaRepository, userRepository, blobStore, accessPolicy)
+	bodyMeasurementService := service2.NewBodyMeasurementService(bodyMeasurementRepository, userRepository, accessPolicy, goalService)
+	programService := service2.NewProgramService(programRepository, routineRepository, personalRecordRepository, routineService, scheduleService, accessPolicy)
+	adminService := service2.NewAdminService(userRepository, postRepository, auditRepository, repository2.NewTransactor(db))
 
 	return AppDependencies{
 		UserRepository:         userRepository,
@@ -66,5 +108,11 @@ func NewAppDependencies(db *sql.DB) AppDependencies {
 		PostService:            postService,
 		AchievementService:     achievementService,
 		ExerciseSettingService: exerciseSettingService,
+		WorkoutSessionService:  workoutSessionService,
+		PersonalRecordService:  personalRecordService,
+		AdminService:           adminService,
+		MediaService:           mediaService,
+		BodyMeasurementService: bodyMeasurementService,
+		ProgramService:         programService,
 	}
 }
@@ -11,6 +11,14 @@ package domain
 //go:generate mockgen -destination=../../mock_internal/domain/service/mock_post_service.go      	-package=mock_service workoutpal/src/internal/domain/service PostService
 //go:generate mockgen -destination=../../mock_internal/domain/service/mock_achievement_service.go   -package=mock_service workoutpal/src/internal/domain/service AchievementService
 //go:generate mockgen -destination=../../mock_internal/domain/service/exercise_setting_service.go   -package=mock_service workoutpal/src/internal/domain/service ExerciseSettingService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_workout_session_service.go -package=mock_service workoutpal/src/internal/domain/service WorkoutSessionService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_personal_record_service.go -package=mock_service workoutpal/src/internal/domain/service PersonalRecordService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_id_token_verifier.go -package=mock_service workoutpal/src/internal/domain/service IDTokenVerifier
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_access_policy.go -package=mock_service workoutpal/src/internal/domain/service AccessPolicy
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_admin_service.go -package=mock_service workoutpal/src/internal/domain/service AdminService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_media_service.go -package=mock_service workoutpal/src/internal/domain/service MediaService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_body_measurement_service.go -package=mock_service workoutpal/src/internal/domain/service BodyMeasurementService
+//go:generate mockgen -destination=../../mock_internal/domain/service/mock_program_service.go -package=mock_service workoutpal/src/internal/domain/service ProgramService
 // Repositories
 //go:generate mockgen -destination=../../mock_internal/domain/repository/mock_exercise_repository.go     -package=mock_repository workoutpal/src/internal/domain/repository ExerciseRepository
 //go:generate mockgen -destination=../../mock_internal/domain/repository/mock_goal_repository.go         -package=mock_repository workoutpal/src/internal/domain/repository GoalRepository
@@ -21,3 +29,12 @@ package domain
 //go:generate mockgen -destination=../../mock_internal/domain/repository/mock_post_repository.go		 -package=mock_repository workoutpal/src/internal/domain/repository PostRepository
 //go:generate mockgen -destination=../../mock_internal/domain/repository/mock_achievement_repository.go	 -package=mock_repository workoutpal/src/internal/domain/repository AchievementRepository
 //go:generate mockgen -destination=../../mock_internal/domain/repository/exercise_setting_repository.go	 -package=mock_repository workoutpal/src/internal/domain/repository ExerciseSettingRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_workout_session_repository.go -package=mock_repository workoutpal/src/internal/domain/repository WorkoutSessionRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_personal_record_repository.go -package=mock_repository workoutpal/src/internal/domain/repository PersonalRecordRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_session_repository.go -package=mock_repository workoutpal/src/internal/domain/repository SessionRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_audit_repository.go -package=mock_repository workoutpal/src/internal/domain/repository AuditRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_media_repository.go -package=mock_repository workoutpal/src/internal/domain/repository MediaRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_blob_store.go -package=mock_repository workoutpal/src/internal/domain/repository BlobStore
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_body_measurement_repository.go -package=mock_repository workoutpal/src/internal/domain/repository BodyMeasurementRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_program_repository.go -package=mock_repository workoutpal/src/internal/domain/repository ProgramRepository
+//go:generate mockgen -destination=../../mock_internal/domain/repository/mock_transactor.go -package=mock_repository workoutpal/src/internal/domain/repository Transactor
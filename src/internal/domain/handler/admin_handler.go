@@ -0,0 +1,22 @@
+package handler
+
+import "net/http"
+
+type AdminHandler interface {
+	CreateExercise(w http.ResponseWriter, r *http.Request)
+	UpdateExercise(w http.ResponseWriter, r *http.Request)
+	DeleteExercise(w http.ResponseWriter, r *http.Request)
+
+	CreateAchievement(w http.ResponseWriter, r *http.Request)
+	UpdateAchievement(w http.ResponseWriter, r *http.Request)
+	DeleteAchievement(w http.ResponseWriter, r *http.Request)
+
+	SuspendUser(w http.ResponseWriter, r *http.Request)
+	BanUser(w http.ResponseWriter, r *http.Request)
+	ReinstateUser(w http.ResponseWriter, r *http.Request)
+	UpdateUserRole(w http.ResponseWriter, r *http.Request)
+
+	TakeDownPost(w http.ResponseWriter, r *http.Request)
+
+	ReadAuditLog(w http.ResponseWriter, r *http.Request)
+}
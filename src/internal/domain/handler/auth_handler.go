@@ -5,5 +5,8 @@ import "net/http"
 type AuthHandler interface {
 	Login(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	LogoutAll(w http.ResponseWriter, r *http.Request)
+	GoogleAuth(w http.ResponseWriter, r *http.Request)
 	Me(w http.ResponseWriter, r *http.Request)
 }
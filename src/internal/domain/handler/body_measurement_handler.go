@@ -0,0 +1,10 @@
+package handler
+
+import "net/http"
+
+type BodyMeasurementHandler interface {
+	CreateMeasurement(w http.ResponseWriter, r *http.Request)
+	ReadMeasurements(w http.ResponseWriter, r *http.Request)
+	ReadTrend(w http.ResponseWriter, r *http.Request)
+	DeleteMeasurement(w http.ResponseWriter, r *http.Request)
+}
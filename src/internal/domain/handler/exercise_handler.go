@@ -6,4 +6,6 @@ type ExerciseHandler interface {
 	ReadExerciseByID(w http.ResponseWriter, r *http.Request)
 	ReadExercises(w http.ResponseWriter, r *http.Request)
 	CreateExercise(w http.ResponseWriter, r *http.Request)
+	UpdateExercise(w http.ResponseWriter, r *http.Request)
+	DeleteExercise(w http.ResponseWriter, r *http.Request)
 }
@@ -5,4 +5,6 @@ import "net/http"
 type GoalHandler interface {
 	CreateUserGoal(w http.ResponseWriter, r *http.Request)
 	GetUserGoals(w http.ResponseWriter, r *http.Request)
-}
\ No newline at end of file
+	UpdateUserGoal(w http.ResponseWriter, r *http.Request)
+	DeleteUserGoal(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,8 @@
+package handler
+
+import "net/http"
+
+type MediaHandler interface {
+	UploadMedia(w http.ResponseWriter, r *http.Request)
+	ReadMedia(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,8 @@
+package handler
+
+import "net/http"
+
+type PersonalRecordHandler interface {
+	ReadUserRecords(w http.ResponseWriter, r *http.Request)
+	ReadExerciseRecords(w http.ResponseWriter, r *http.Request)
+}
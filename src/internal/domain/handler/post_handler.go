@@ -5,7 +5,15 @@ import "net/http"
 type PostHandler interface {
 	ReadPosts(w http.ResponseWriter, r *http.Request)
 	CreatePost(w http.ResponseWriter, r *http.Request)
+	UpdatePost(w http.ResponseWriter, r *http.Request)
+	ReadPostRevisions(w http.ResponseWriter, r *http.Request)
+	CopyRoutine(w http.ResponseWriter, r *http.Request)
+	ReadComments(w http.ResponseWriter, r *http.Request)
+	ReadReplies(w http.ResponseWriter, r *http.Request)
 	CommentOnPost(w http.ResponseWriter, r *http.Request)
+	UpdateComment(w http.ResponseWriter, r *http.Request)
+	DeleteComment(w http.ResponseWriter, r *http.Request)
 	LikePost(w http.ResponseWriter, r *http.Request)
 	UnlikePost(w http.ResponseWriter, r *http.Request)
+	ReadReactions(w http.ResponseWriter, r *http.Request)
 }
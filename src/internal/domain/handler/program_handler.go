@@ -0,0 +1,12 @@
+package handler
+
+import "net/http"
+
+type ProgramHandler interface {
+	CreateProgram(w http.ResponseWriter, r *http.Request)
+	ReadProgram(w http.ResponseWriter, r *http.Request)
+	DeleteProgram(w http.ResponseWriter, r *http.Request)
+	Enroll(w http.ResponseWriter, r *http.Request)
+	ReadEnrollments(w http.ResponseWriter, r *http.Request)
+	CancelEnrollment(w http.ResponseWriter, r *http.Request)
+}
@@ -9,4 +9,14 @@ type ScheduleHandler interface {
 	CreateSchedule(w http.ResponseWriter, r *http.Request)
 	UpdateSchedule(w http.ResponseWriter, r *http.Request)
 	DeleteSchedule(w http.ResponseWriter, r *http.Request)
+	ExportCalendar(w http.ResponseWriter, r *http.Request)
+	CreateCalendarFeed(w http.ResponseWriter, r *http.Request)
+	RevokeCalendarFeed(w http.ResponseWriter, r *http.Request)
+	ReadCalendarFeed(w http.ResponseWriter, r *http.Request)
+	ReadOccurrences(w http.ResponseWriter, r *http.Request)
+	CreateScheduleException(w http.ResponseWriter, r *http.Request)
+	DeleteScheduleException(w http.ResponseWriter, r *http.Request)
+	ReadUserAdherence(w http.ResponseWriter, r *http.Request)
+	MarkOccurrence(w http.ResponseWriter, r *http.Request)
+	DeleteOccurrenceMark(w http.ResponseWriter, r *http.Request)
 }
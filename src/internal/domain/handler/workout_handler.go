@@ -5,9 +5,13 @@ import "net/http"
 type RoutineHandler interface {
 	CreateUserRoutine(w http.ResponseWriter, r *http.Request)
 	ReadUserRoutines(w http.ResponseWriter, r *http.Request)
+	ReadPublicRoutines(w http.ResponseWriter, r *http.Request)
 	DeleteRoutine(w http.ResponseWriter, r *http.Request)
 	DeleteUserRoutine(w http.ResponseWriter, r *http.Request)
 	ReadRoutineWithExercises(w http.ResponseWriter, r *http.Request)
+	UpdateRoutine(w http.ResponseWriter, r *http.Request)
 	AddExerciseToRoutine(w http.ResponseWriter, r *http.Request)
 	RemoveExerciseFromRoutine(w http.ResponseWriter, r *http.Request)
+	ReorderRoutineExercises(w http.ResponseWriter, r *http.Request)
+	ForkRoutine(w http.ResponseWriter, r *http.Request)
 }
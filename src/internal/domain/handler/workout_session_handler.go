@@ -0,0 +1,12 @@
+package handler
+
+import "net/http"
+
+type WorkoutSessionHandler interface {
+	ReadUserSessions(w http.ResponseWriter, r *http.Request)
+	ReadActiveSession(w http.ResponseWriter, r *http.Request)
+	ReadSessionByID(w http.ResponseWriter, r *http.Request)
+	StartSession(w http.ResponseWriter, r *http.Request)
+	LogSet(w http.ResponseWriter, r *http.Request)
+	FinishSession(w http.ResponseWriter, r *http.Request)
+}
@@ -9,4 +9,12 @@ type AchievementRepository interface {
 	ReadUnlockedAchievementByAchievementID(id int64) (*model.UserAchievement, error)
 	ReadUnlockedAchievements(userID int64) ([]*model.UserAchievement, error)
 	CreateAchievement(a model.CreateAchievementRequest) (*model.UserAchievement, error)
+
+	ReadAchievementsByCriteria(criteriaTypes []string) ([]*model.Achievement, error)
+	ReadCriteriaProgress(userID int64, criteriaType string) (int64, error)
+	AwardAchievement(userID int64, achievementID int64) (*model.UserAchievement, error)
+
+	CreateAchievementDefinition(req model.AchievementDefinitionRequest) (*model.Achievement, error)
+	UpdateAchievementDefinition(req model.AchievementDefinitionRequest) (*model.Achievement, error)
+	DeleteAchievementDefinition(id int64) error
 }
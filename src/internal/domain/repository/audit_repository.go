@@ -0,0 +1,9 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type AuditRepository interface {
+	CreateAuditRecord(request model.CreateAuditRecordRequest) (*model.AuditRecord, error)
+	// ReadAuditRecords returns the newest records first
+	ReadAuditRecords(filter model.AuditFilter) ([]*model.AuditRecord, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore keeps file contents under string keys. Get reports a key that
+// isn't there with an error wrapping fs.ErrNotExist.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type BodyMeasurementRepository interface {
+	CreateMeasurement(measurement *model.BodyMeasurement) (*model.BodyMeasurement, error)
+	ReadMeasurements(filter model.MeasurementFilter) ([]*model.BodyMeasurement, error)
+	DeleteMeasurement(userID, measurementID int64) error
+}
@@ -6,5 +6,12 @@ import (
 
 type ExerciseRepository interface {
 	ReadExerciseByID(id int64) (*model.Exercise, error)
-	ReadAllExercises() ([]*model.Exercise, error)
+	ReadExercises(filter model.ExerciseFilter) ([]*model.Exercise, error)
+	CreateExercise(req model.CreateExerciseRequest) (*model.Exercise, error)
+	UpdateExercise(req model.UpdateExerciseRequest) (*model.Exercise, error)
+	DeleteExercise(id int64) error
+
+	CreateCatalogueExercise(req model.CreateExerciseRequest) (*model.Exercise, error)
+	UpdateCatalogueExercise(req model.UpdateExerciseRequest) (*model.Exercise, error)
+	DeleteCatalogueExercise(id int64) error
 }
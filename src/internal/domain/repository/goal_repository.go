@@ -6,5 +6,6 @@ type GoalRepository interface {
 	CreateGoal(userID int64, request model.CreateGoalRequest) (*model.Goal, error)
 	ReadUserGoals(userID int64) ([]*model.Goal, error)
 	UpdateGoal(request model.UpdateGoalRequest) (*model.Goal, error)
-	DeleteGoal(goalID int64) error
+	CompleteGoals(goalIDs []int64) error
+	DeleteGoal(userID, goalID int64) error
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type MediaRepository interface {
+	CreateMedia(media *model.Media) (*model.Media, error)
+	ReadMediaByID(id string) (*model.Media, error)
+	ReadMediaByIDs(ids []string) ([]*model.Media, error)
+}
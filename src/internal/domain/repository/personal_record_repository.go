@@ -0,0 +1,11 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type PersonalRecordRepository interface {
+	ReadBestRecords(userID int64) ([]*model.PersonalRecord, error)
+	ReadExerciseBestRecords(userID int64, exerciseID int64) ([]*model.PersonalRecord, error)
+	ReadExerciseRecordHistory(userID int64, exerciseID int64) ([]*model.PersonalRecord, error)
+	ReadSessionExerciseVolume(sessionID int64, exerciseID int64) (float64, error)
+	CreateRecords(records []*model.PersonalRecord) ([]*model.PersonalRecord, error)
+}
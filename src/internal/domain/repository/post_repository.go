@@ -4,17 +4,25 @@ import "workoutpal/src/internal/model"
 
 type PostRepository interface {
 	ReadPostsByUserID(targetUserID int64, userID int64) ([]*model.Post, error)
-	ReadPosts(userID int64) ([]*model.Post, error)
+	ReadPosts(filter model.PostFeedFilter) ([]*model.Post, error)
 	ReadPost(id int64, userID int64) (*model.Post, error)
+	ReadPostOwnerID(id int64) (int64, error)
 	CreatePost(req model.CreatePostRequest) (*model.Post, error)
 	UpdatePost(req model.UpdatePostRequest) (*model.Post, error)
+	ReadPostRevisions(postID int64) ([]*model.PostRevision, error)
+	ReadPostWorkouts(postIDs []int64) ([]*model.WorkoutSummary, error)
+	ReadPostMedia(postIDs []int64) ([]*model.Media, error)
 	DeletePost(id int64) error
 
 	LikePost(req model.LikePostRequest) (*model.Post, error)
 	UnlikePost(req model.UnikePostRequest) (*model.Post, error)
+	ReadPostReactions(postIDs []int64, viewerID int64) ([]*model.ReactionCount, error)
+	ReadReactions(filter model.ReactionFilter) ([]*model.Reaction, error)
 
-	ReadCommentsByPost(id int64) ([]*model.Comment, error)
-	ReadCommentsByComment(id int64) ([]*model.Comment, error)
+	ReadComments(filter model.CommentFilter) ([]*model.Comment, error)
+	ReadCommentRef(id int64) (*model.CommentRef, error)
 	CommentOnPost(req model.CommentOnPostRequest) error
 	CommentOnComment(req model.CommentOnCommentRequest) error
+	UpdateComment(req model.UpdateCommentRequest) (*model.Comment, error)
+	DeleteComment(id int64) error
 }
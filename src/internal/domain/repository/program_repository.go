@@ -0,0 +1,18 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type ProgramRepository interface {
+	CreateProgram(request model.CreateProgramRequest) (*model.Program, error)
+	ReadProgramByID(id int64) (*model.Program, error)
+	DeleteProgram(id int64) error
+	CreateEnrollment(enrollment *model.ProgramEnrollment) (*model.ProgramEnrollment, error)
+	ReadEnrollmentByID(id int64) (*model.ProgramEnrollment, error)
+	ReadUserEnrollments(userID int64) ([]*model.ProgramEnrollment, error)
+	ReadActiveEnrollments() ([]*model.ProgramEnrollment, error)
+	ClaimEnrollmentWeek(id int64, week int) (bool, error)
+	UpdateEnrollmentProgress(id int64, currentWeek int, status string) error
+	DeleteEnrollment(id int64) error
+	CreateEnrollmentSchedules(schedules []*model.EnrollmentSchedule) error
+	ReadEnrollmentSchedules(enrollmentID int64, week int) ([]*model.EnrollmentSchedule, error)
+}
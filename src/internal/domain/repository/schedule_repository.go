@@ -9,4 +9,14 @@ type ScheduleRepository interface {
 	CreateSchedule(request model.CreateScheduleRequest) (*model.Schedule, error)
 	UpdateSchedule(request model.UpdateScheduleRequest) (*model.Schedule, error)
 	DeleteSchedule(request model.DeleteScheduleRequest) error
+	ReadCalendarEntries(userID int64) ([]*model.CalendarEntry, error)
+	UpsertCalendarFeed(userID int64, tokenHash string) error
+	ReadCalendarFeedOwner(tokenHash string) (int64, error)
+	DeleteCalendarFeed(userID int64) error
+	CreateScheduleException(request model.CreateScheduleExceptionRequest) (*model.ScheduleException, error)
+	DeleteScheduleException(request model.DeleteScheduleExceptionRequest) error
+	ReadScheduleExceptions(filter model.ScheduleExceptionFilter) ([]*model.ScheduleException, error)
+	UpsertOccurrenceMark(request model.MarkOccurrenceRequest) (*model.OccurrenceMark, error)
+	DeleteOccurrenceMark(request model.DeleteOccurrenceMarkRequest) error
+	ReadOccurrenceMarks(filter model.OccurrenceMarkFilter) ([]*model.OccurrenceMark, error)
 }
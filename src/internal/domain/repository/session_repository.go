@@ -0,0 +1,12 @@
+package repository
+
+import "workoutpal/src/internal/model"
+
+type SessionRepository interface {
+	CreateSession(request model.CreateRefreshTokenRequest) error
+	ReadRefreshToken(tokenHash string) (*model.RefreshToken, error)
+	RotateRefreshToken(usedTokenID int64, request model.CreateRefreshTokenRequest) error
+	RevokeSession(sessionID string) error
+	RevokeUserSessions(userID int64) error
+	IsSessionActive(sessionID string) (bool, error)
+}
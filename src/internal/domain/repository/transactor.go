@@ -0,0 +1,17 @@
+package repository
+
+// Transactor runs work against repositories that share one database
+// transaction. It commits when work returns nil and rolls back otherwise.
+type Transactor interface {
+	WithinTransaction(work func(repos TxRepositories) error) error
+}
+
+// TxRepositories are the repositories that can take part in a transaction
+type TxRepositories struct {
+	Exercises    ExerciseRepository
+	Achievements AchievementRepository
+	Users        UserRepository
+	Sessions     SessionRepository
+	Posts        PostRepository
+	Audit        AuditRepository
+}
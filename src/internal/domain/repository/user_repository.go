@@ -6,7 +6,15 @@ type UserRepository interface {
 	ReadUsers() ([]*model.User, error)
 	ReadUserByID(id int64) (*model.User, error)
 	ReadUserByEmail(email string) (*model.User, error)
+	ReadUserByGoogleID(googleID string) (*model.User, error)
+	LinkGoogleAccount(email, googleID string) (*model.User, error)
+	CreateGoogleUser(request model.CreateGoogleUserRequest) (*model.User, error)
+	ReadUserRole(id int64) (string, error)
+	ReadUserStanding(id int64) (*model.UserStanding, error)
+	UpdateUserStanding(request model.UpdateUserStandingRequest) (*model.UserStanding, error)
+	UpdateUserRole(id int64, role string) (*model.UserStanding, error)
 	CreateUser(request model.CreateUserRequest) (*model.User, error)
 	UpdateUser(request model.UpdateUserRequest) (*model.User, error)
+	UpdateAvatarMedia(userID int64, mediaID string) error
 	DeleteUser(request model.DeleteUserRequest) error
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+	"workoutpal/src/internal/model"
+)
+
+type WorkoutSessionRepository interface {
+	ReadUserSessions(userID int64) ([]*model.WorkoutSession, error)
+	ReadSessionByID(id int64) (*model.WorkoutSession, error)
+	ReadActiveSession(userID int64) (*model.WorkoutSession, error)
+	CreateSession(request model.StartWorkoutSessionRequest) (*model.WorkoutSession, error)
+	FinishSession(request model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error)
+	ReadCompletedWorkouts(userID int64, from, to time.Time) ([]*model.CompletedWorkout, error)
+
+	CreateSet(request model.LogWorkoutSetRequest) (*model.WorkoutSet, error)
+}
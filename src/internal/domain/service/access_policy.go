@@ -0,0 +1,17 @@
+package service
+
+// AccessPolicy decides whether the acting user, the JWT subject, may touch data
+// owned by another user. Both return an error wrapping util.ErrForbidden on refusal.
+type AccessPolicy interface {
+	// CanModify allows the owner and admins
+	CanModify(actorID, ownerID int64) error
+	// CanView allows the owner, admins, and anyone when the owner's profile is
+	// public; private profiles are limited to followers
+	CanView(actorID, ownerID int64) error
+	// CanViewMetrics allows the owner, admins, and followers when the owner
+	// shares metrics with them
+	CanViewMetrics(actorID, ownerID int64) error
+	// CanViewAsFollower allows the owner, admins and followers, whether or not
+	// the profile is private
+	CanViewAsFollower(actorID, ownerID int64) error
+}
@@ -3,9 +3,17 @@ package service
 import "workoutpal/src/internal/model"
 
 type AchievementService interface {
+	AchievementEvaluator
+
 	ReadAchievementsFeed() ([]*model.UserAchievement, error)
 	ReadAllAchievements() ([]*model.Achievement, error)
 
 	ReadUnlockedAchievements(userID int64) ([]*model.UserAchievement, error)
-	CreateAchievement(req model.CreateAchievementRequest) (*model.UserAchievement, error)
+	CreateAchievement(adminID int64, req model.CreateAchievementRequest) (*model.UserAchievement, error)
+}
+
+// AchievementEvaluator is notified by other services after an action that can
+// unlock achievements, e.g. a new post or follow
+type AchievementEvaluator interface {
+	Evaluate(userID int64, criteriaTypes ...string) ([]*model.UserAchievement, error)
 }
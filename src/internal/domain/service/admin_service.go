@@ -0,0 +1,24 @@
+package service
+
+import "workoutpal/src/internal/model"
+
+// AdminService backs the /admin routes. Access is decided by the role guarding
+// each route; every change is written to the audit log under actorID.
+type AdminService interface {
+	CreateCatalogueExercise(actorID int64, req model.CreateExerciseRequest) (*model.Exercise, error)
+	UpdateCatalogueExercise(actorID int64, req model.UpdateExerciseRequest) (*model.Exercise, error)
+	DeleteCatalogueExercise(actorID int64, id int64) error
+
+	CreateAchievement(actorID int64, req model.AchievementDefinitionRequest) (*model.Achievement, error)
+	UpdateAchievement(actorID int64, req model.AchievementDefinitionRequest) (*model.Achievement, error)
+	DeleteAchievement(actorID int64, id int64) error
+
+	SuspendUser(actorID int64, req model.SuspendUserRequest) (*model.UserStanding, error)
+	BanUser(actorID int64, req model.BanUserRequest) (*model.UserStanding, error)
+	ReinstateUser(actorID int64, userID int64) (*model.UserStanding, error)
+	UpdateUserRole(actorID int64, req model.UpdateUserRoleRequest) (*model.UserStanding, error)
+
+	TakeDownPost(actorID int64, req model.TakeDownPostRequest) error
+
+	ReadAuditLog(req model.ReadAuditLogRequest) (*model.AuditLogPage, error)
+}
@@ -7,4 +7,16 @@ import (
 
 type AuthService interface {
 	Authenticate(ctx context.Context, request model.LoginRequest) (*model.User, error)
+	AuthenticateGoogle(ctx context.Context, idToken string) (*model.User, error)
+	CreateSession(ctx context.Context, userID int64) (*model.AuthSession, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*model.AuthSession, error)
+	EndSession(ctx context.Context, refreshToken string) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID int64) error
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// IDTokenVerifier checks a Google ID token and returns the identity it asserts
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, idToken string) (*model.GoogleIdentity, error)
 }
@@ -0,0 +1,10 @@
+package service
+
+import "workoutpal/src/internal/model"
+
+type BodyMeasurementService interface {
+	CreateMeasurement(actorID int64, request model.CreateMeasurementRequest) (*model.BodyMeasurement, error)
+	ReadMeasurements(actorID int64, request model.ReadMeasurementsRequest) ([]*model.BodyMeasurement, error)
+	ReadTrend(actorID int64, request model.ReadMeasurementsRequest) (*model.MeasurementTrend, error)
+	DeleteMeasurement(actorID, userID, measurementID int64) error
+}
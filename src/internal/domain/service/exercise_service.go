@@ -5,6 +5,9 @@ import (
 )
 
 type ExerciseService interface {
-	ReadExerciseByID(id int64) (*model.Exercise, error)
-	ReadAllExercises() ([]*model.Exercise, error)
+	ReadExerciseByID(viewerID, id int64) (*model.Exercise, error)
+	ReadExercises(req model.ReadExerciseRequest) (*model.ExercisePage, error)
+	CreateExercise(req model.CreateExerciseRequest) (*model.Exercise, error)
+	UpdateExercise(req model.UpdateExerciseRequest) (*model.Exercise, error)
+	DeleteExercise(req model.DeleteExerciseRequest) error
 }
@@ -3,6 +3,16 @@ package service
 import "workoutpal/src/internal/model"
 
 type GoalService interface {
-	CreateGoal(userID int64, request model.CreateGoalRequest) (*model.Goal, error)
-	ReadUserGoals(userID int64) ([]*model.Goal, error)
+	GoalSettler
+
+	CreateGoal(actorID, userID int64, request model.CreateGoalRequest) (*model.Goal, error)
+	ReadUserGoals(actorID, userID int64) ([]*model.Goal, error)
+	UpdateGoal(actorID, userID int64, request model.UpdateGoalRequest) (*model.Goal, error)
+	DeleteGoal(actorID, userID, goalID int64) error
+}
+
+// GoalSettler is notified by other services after logging data a measurable
+// goal tracks, e.g. a finished workout, a new record or a weight
+type GoalSettler interface {
+	SettleGoals(userID int64) error
 }
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"io"
+	"workoutpal/src/internal/model"
+)
+
+type MediaService interface {
+	UploadMedia(ctx context.Context, ownerID int64, file io.Reader) (*model.Media, error)
+	ReadMedia(ctx context.Context, id string, thumbnail bool) (*model.MediaContent, error)
+	SetAvatar(actorID, userID int64, mediaID string) (*model.Media, error)
+}
@@ -0,0 +1,9 @@
+package service
+
+import "workoutpal/src/internal/model"
+
+type PersonalRecordService interface {
+	RecordSet(userID int64, set *model.WorkoutSet) ([]*model.PersonalRecord, error)
+	ReadUserRecords(request model.ReadRecordsRequest) ([]*model.PersonalRecord, error)
+	ReadExerciseRecords(request model.ReadRecordsRequest) ([]*model.PersonalRecord, error)
+}
@@ -4,14 +4,20 @@ import "workoutpal/src/internal/model"
 
 type PostService interface {
 	ReadPostsByUserID(targetUserID int64, userID int64) ([]*model.Post, error)
-	ReadPosts(userID int64) ([]*model.Post, error)
+	ReadPosts(req model.ReadPostRequest) (*model.PostPage, error)
 	CreatePost(req model.CreatePostRequest) (*model.Post, error)
-	UpdatePost(req model.UpdatePostRequest) (*model.Post, error)
-	DeletePost(id int64) error
+	UpdatePost(actorID int64, req model.UpdatePostRequest) (*model.Post, error)
+	ReadPostRevisions(actorID, postID int64) ([]*model.PostRevision, error)
+	DeletePost(actorID, id int64) error
+	CopyRoutine(actorID, postID int64) (*model.ExerciseRoutine, error)
 
 	LikePost(req model.LikePostRequest) (*model.Post, error)
 	UnlikePost(req model.UnikePostRequest) (*model.Post, error)
+	ReadReactions(req model.ReadReactionsRequest) (*model.ReactionPage, error)
 
+	ReadComments(req model.ReadCommentsRequest) (*model.CommentPage, error)
 	CommentOnPost(req model.CommentOnPostRequest) error
 	CommentOnComment(req model.CommentOnCommentRequest) error
+	UpdateComment(actorID int64, req model.UpdateCommentRequest) (*model.Comment, error)
+	DeleteComment(actorID, id int64) error
 }
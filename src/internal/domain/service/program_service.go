@@ -0,0 +1,17 @@
+package service
+
+import "workoutpal/src/internal/model"
+
+type ProgramService interface {
+	CreateProgram(actorID int64, request model.CreateProgramRequest) (*model.Program, error)
+	ReadProgram(actorID, programID int64) (*model.Program, error)
+	DeleteProgram(actorID, programID int64) error
+	// Enroll copies the program's routines into the actor's account and
+	// schedules its first week
+	Enroll(actorID int64, request model.EnrollRequest) (*model.ProgramEnrollment, error)
+	ReadEnrollments(actorID int64) ([]*model.ProgramEnrollment, error)
+	CancelEnrollment(actorID, enrollmentID int64) error
+	// AdvanceEnrollments schedules the weeks that have started for every
+	// active enrollment, and completes the ones past their last week
+	AdvanceEnrollments() error
+}
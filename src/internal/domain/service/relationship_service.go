@@ -12,7 +12,7 @@ type RelationshipService interface {
 	SendFollowRequest(requesterID, requestedID int64) error
 	GetFollowRequest(requesterID, requestedID int64) (*model.FollowRequestModel, error)
 	GetPendingFollowRequests(userID int64) ([]*model.FollowRequestWithUser, error)
-	AcceptFollowRequest(requestID int64) error
-	RejectFollowRequest(requestID int64) error
+	AcceptFollowRequest(actorID, requestID int64) error
+	RejectFollowRequest(actorID, requestID int64) error
 	CancelFollowRequest(requesterID, requestedID int64) error
 }
@@ -3,10 +3,21 @@ package service
 import "workoutpal/src/internal/model"
 
 type RoutineService interface {
-	CreateRoutine(userID int64, request model.CreateRoutineRequest) (*model.ExerciseRoutine, error)
-	ReadUserRoutines(userID int64) ([]*model.ExerciseRoutine, error)
-	ReadRoutineWithExercises(routineID int64) (*model.ExerciseRoutine, error)
-	AddExerciseToRoutine(routineID, exerciseID int64) error
-	RemoveExerciseFromRoutine(routineID, exerciseID int64) error
-	DeleteRoutine(routineID int64) error
+	CreateRoutine(actorID, userID int64, request model.CreateRoutineRequest) (*model.ExerciseRoutine, error)
+	ReadUserRoutines(actorID, userID int64) ([]*model.ExerciseRoutine, error)
+	ReadPublicRoutines(request model.ReadPublicRoutinesRequest) (*model.RoutinePage, error)
+	ReadRoutineWithExercises(actorID, routineID int64) (*model.ExerciseRoutine, error)
+	UpdateRoutine(actorID int64, request model.UpdateRoutineRequest) (*model.ExerciseRoutine, error)
+	AddExerciseToRoutine(actorID, routineID, exerciseID int64) error
+	RemoveExerciseFromRoutine(actorID, routineID, exerciseID int64) error
+	ReorderRoutineExercises(actorID, routineID int64, exerciseIDs []int64) (*model.ExerciseRoutine, error)
+	DeleteRoutine(actorID, routineID int64) error
+	// ForkRoutine copies a routine the actor can see into their account
+	ForkRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error)
+	// CopyRoutine forks the routine a post shares; the post has already
+	// decided who may see it, so the routine's own visibility isn't checked
+	CopyRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error)
+	// CopyProgramRoutine copies a program's routine for an enrollee. It isn't
+	// a fork: the original is not credited and its fork count stays.
+	CopyProgramRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error)
 }
@@ -5,8 +5,18 @@ import "workoutpal/src/internal/model"
 type ScheduleService interface {
 	ReadUserSchedules(userId int64) ([]*model.Schedule, error)
 	ReadUserSchedulesByDay(userId int64, dayOfWeek int64) ([]*model.Schedule, error)
-	ReadScheduleByID(id int64) (*model.Schedule, error)
+	ReadScheduleByID(actorID, id int64) (*model.Schedule, error)
 	CreateSchedule(request model.CreateScheduleRequest) (*model.Schedule, error)
 	UpdateSchedule(request model.UpdateScheduleRequest) (*model.Schedule, error)
-	DeleteSchedule(request model.DeleteScheduleRequest) error
+	DeleteSchedule(actorID int64, request model.DeleteScheduleRequest) error
+	ExportCalendar(userID int64) ([]byte, error)
+	CreateCalendarFeed(userID int64) (string, error)
+	RevokeCalendarFeed(userID int64) error
+	ExportCalendarFeed(token string) ([]byte, error)
+	ReadOccurrences(request model.ReadOccurrencesRequest) ([]*model.ScheduleOccurrence, error)
+	CreateScheduleException(actorID int64, request model.CreateScheduleExceptionRequest) (*model.ScheduleException, error)
+	DeleteScheduleException(actorID int64, request model.DeleteScheduleExceptionRequest) error
+	ReadAdherence(actorID int64, request model.ReadAdherenceRequest) (*model.Adherence, error)
+	MarkOccurrence(actorID int64, request model.MarkOccurrenceRequest) (*model.OccurrenceMark, error)
+	DeleteOccurrenceMark(actorID int64, request model.DeleteOccurrenceMarkRequest) error
 }
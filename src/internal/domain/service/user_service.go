@@ -7,6 +7,6 @@ type UserService interface {
 	ReadUserByEmail(email string) (*model.User, error)
 	ReadUserByID(id int64) (*model.User, error)
 	CreateUser(request model.CreateUserRequest) (*model.User, error)
-	UpdateUser(request model.UpdateUserRequest) (*model.User, error)
-	DeleteUser(request model.DeleteUserRequest) error
+	UpdateUser(actorID int64, request model.UpdateUserRequest) (*model.User, error)
+	DeleteUser(actorID int64, request model.DeleteUserRequest) error
 }
@@ -0,0 +1,12 @@
+package service
+
+import "workoutpal/src/internal/model"
+
+type WorkoutSessionService interface {
+	ReadUserSessions(userID int64) ([]*model.WorkoutSession, error)
+	ReadSessionByID(id int64, userID int64) (*model.WorkoutSession, error)
+	ReadActiveSession(userID int64) (*model.WorkoutSession, error)
+	StartSession(request model.StartWorkoutSessionRequest) (*model.WorkoutSession, error)
+	LogSet(request model.LogWorkoutSetRequest) (*model.WorkoutSet, error)
+	FinishSession(request model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error)
+}
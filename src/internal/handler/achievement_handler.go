@@ -19,8 +19,8 @@ func NewAchievementHandler(svc service.AchievementService) *AchievementHandler {
 }
 
 // CreateAchievement godoc
-// @Summary Unlock an achievement for a user
-// @Description Creates a user_achievement row (i.e., marks the achievement as earned for the user).
+// @Summary Unlock an achievement for a user (admin only)
+// @Description Creates a user_achievement row (i.e., marks the achievement as earned for the user). Achievements with criteria are awarded automatically; this endpoint is for admins granting badges by hand.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -28,10 +28,13 @@ func NewAchievementHandler(svc service.AchievementService) *AchievementHandler {
 // @Success 201 {object} model.UserAchievement "UserAchievement created successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Caller is not an admin"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /achievements [post]
 func (h *AchievementHandler) CreateAchievement(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := r.Context().Value(constants.USER_ID_KEY).(int64)
+
 	var req model.CreateAchievementRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		responseErr := util.Error(err, r.URL.Path)
@@ -39,7 +42,7 @@ func (h *AchievementHandler) CreateAchievement(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	ach, err := h.svc.CreateAchievement(req)
+	ach, err := h.svc.CreateAchievement(adminID, req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
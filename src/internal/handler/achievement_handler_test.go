@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"workoutpal/src/internal/model"
 	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 )
 
@@ -40,10 +42,10 @@ func TestAchievementHandler_Create_Error(t *testing.T) {
 	h := &AchievementHandler{svc: svc}
 
 	req := model.CreateAchievementRequest{UserID: 1, AchievementID: 55}
-	svc.EXPECT().CreateAchievement(req).Return(nil, errors.New("fail"))
+	svc.EXPECT().CreateAchievement(int64(3), req).Return(nil, errors.New("fail"))
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/achievements", mustJSON(t, req))
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/achievements", mustJSON(t, req)), 3)
 	h.CreateAchievement(w, r)
 
 	if w.Code != http.StatusInternalServerError {
@@ -61,10 +63,10 @@ func TestAchievementHandler_Create_OK(t *testing.T) {
 	req := model.CreateAchievementRequest{UserID: 1, AchievementID: 55}
 	want := &model.UserAchievement{ID: 9, UserID: 1, Title: "First Workout", EarnedAt: "2025-01-01T00:00:00Z"}
 
-	svc.EXPECT().CreateAchievement(req).Return(want, nil)
+	svc.EXPECT().CreateAchievement(int64(3), req).Return(want, nil)
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/achievements", mustJSON(t, req))
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/achievements", mustJSON(t, req)), 3)
 	h.CreateAchievement(w, r)
 
 	if w.Code != http.StatusCreated {
@@ -77,6 +79,26 @@ func TestAchievementHandler_Create_OK(t *testing.T) {
 	}
 }
 
+func TestAchievementHandler_Create_NotAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAchievementService(ctrl)
+	h := &AchievementHandler{svc: svc}
+
+	req := model.CreateAchievementRequest{UserID: 1, AchievementID: 55}
+	svc.EXPECT().CreateAchievement(int64(1), req).
+		Return(nil, fmt.Errorf("%w: only admins can grant achievements", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/achievements", mustJSON(t, req)), 1)
+	h.CreateAchievement(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status=%d want=403", w.Code)
+	}
+}
+
 /* ---------- ReadAllAchievements (catalog) ---------- */
 
 func TestAchievementHandler_ReadAllAchievements_OK(t *testing.T) {
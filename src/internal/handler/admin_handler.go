@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/render"
+)
+
+type adminHandler struct {
+	adminService service.AdminService
+}
+
+func NewAdminHandler(as service.AdminService) handler.AdminHandler {
+	return &adminHandler{adminService: as}
+}
+
+// CreateExercise godoc
+// @Summary Add an exercise to the catalogue
+// @Description Creates a built-in exercise that every user can see. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body model.CreateExerciseRequest true "New exercise payload"
+// @Success 201 {object} model.Exercise "Exercise created successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/exercises [post]
+func (h *adminHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.CreateExerciseRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+
+	exercise, err := h.adminService.CreateCatalogueExercise(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, exercise)
+}
+
+// UpdateExercise godoc
+// @Summary Update a catalogue exercise
+// @Description Replaces the fields of a built-in exercise; custom exercises can only be changed by their author. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Param request body model.UpdateExerciseRequest true "Exercise update payload"
+// @Success 200 {object} model.Exercise "Exercise updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "No catalogue exercise with this id"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/exercises/{id} [put]
+func (h *adminHandler) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.UpdateExerciseRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.ID = id
+
+	exercise, err := h.adminService.UpdateCatalogueExercise(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, exercise)
+}
+
+// DeleteExercise godoc
+// @Summary Delete a catalogue exercise
+// @Description Deletes a built-in exercise, removing it from routines and logged sets. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Success 200 {object} model.BasicResponse "Exercise deleted successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "No catalogue exercise with this id"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/exercises/{id} [delete]
+func (h *adminHandler) DeleteExercise(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	if err := h.adminService.DeleteCatalogueExercise(actorID, id); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Exercise deleted successfully"})
+}
+
+// CreateAchievement godoc
+// @Summary Define an achievement
+// @Description Adds an achievement users can unlock. With a criteriaType it is awarded automatically once the user's count reaches threshold, without one it can only be granted by an admin. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body model.AchievementDefinitionRequest true "Achievement definition"
+// @Success 201 {object} model.Achievement "Achievement created successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/achievements [post]
+func (h *adminHandler) CreateAchievement(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.AchievementDefinitionRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+
+	achievement, err := h.adminService.CreateAchievement(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, achievement)
+}
+
+// UpdateAchievement godoc
+// @Summary Update an achievement definition
+// @Description Replaces an achievement's text and unlock rule. Users who already earned it keep it. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Achievement ID"
+// @Param request body model.AchievementDefinitionRequest true "Achievement definition"
+// @Success 200 {object} model.Achievement "Achievement updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "Achievement not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/achievements/{id} [put]
+func (h *adminHandler) UpdateAchievement(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.AchievementDefinitionRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.ID = id
+
+	achievement, err := h.adminService.UpdateAchievement(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, achievement)
+}
+
+// DeleteAchievement godoc
+// @Summary Delete an achievement definition
+// @Description Only achievements nobody has earned yet can be deleted. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Achievement ID"
+// @Success 200 {object} model.BasicResponse "Achievement deleted successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "Achievement not found"
+// @Failure 409 {object} model.BasicResponse "Achievement has been earned"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/achievements/{id} [delete]
+func (h *adminHandler) DeleteAchievement(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	if err := h.adminService.DeleteAchievement(actorID, id); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Achievement deleted successfully"})
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Description Signs the user out everywhere and refuses new sessions until the given time. Moderators and admins; only admins can suspend a moderator.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body model.SuspendUserRequest true "Suspension end and reason"
+// @Success 200 {object} model.UserStanding "User suspended"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not a moderator, the user is an admin, or the user is a moderator and the caller isn't an admin"
+// @Failure 404 {object} model.BasicResponse "User not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{id}/suspend [post]
+func (h *adminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.SuspendUserRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.UserID = id
+
+	standing, err := h.adminService.SuspendUser(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, standing)
+}
+
+// BanUser godoc
+// @Summary Ban a user
+// @Description Signs the user out everywhere and refuses new sessions until an admin reinstates them. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body model.BanUserRequest true "Reason"
+// @Success 200 {object} model.UserStanding "User banned"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin, or the user is an admin"
+// @Failure 404 {object} model.BasicResponse "User not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{id}/ban [post]
+func (h *adminHandler) BanUser(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.BanUserRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.UserID = id
+
+	standing, err := h.adminService.BanUser(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, standing)
+}
+
+// ReinstateUser godoc
+// @Summary Reinstate a user
+// @Description Lifts a suspension or ban. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} model.UserStanding "User reinstated"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "User not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{id}/reinstate [post]
+func (h *adminHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	standing, err := h.adminService.ReinstateUser(actorID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, standing)
+}
+
+// UpdateUserRole godoc
+// @Summary Change a user's role
+// @Description Sets the role to user, moderator or admin and signs the user out so their next token carries it. Admins only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body model.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} model.UserStanding "Role changed"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 404 {object} model.BasicResponse "User not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{id}/role [put]
+func (h *adminHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.UpdateUserRoleRequest
+	if !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.UserID = id
+
+	standing, err := h.adminService.UpdateUserRole(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, standing)
+}
+
+// TakeDownPost godoc
+// @Summary Take down a post
+// @Description Deletes another user's post together with its likes and comments. Moderators and admins.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body model.TakeDownPostRequest false "Reason"
+// @Success 200 {object} model.BasicResponse "Post taken down"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not a moderator"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/posts/{id}/takedown [post]
+func (h *adminHandler) TakeDownPost(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	// the reason is optional, so an empty body is fine
+	var req model.TakeDownPostRequest
+	if r.ContentLength != 0 && !decodeAdminRequest(w, r, &req) {
+		return
+	}
+	req.PostID = id
+
+	if err := h.adminService.TakeDownPost(actorID, req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Post taken down"})
+}
+
+// ReadAuditLog godoc
+// @Summary Read the admin audit log
+// @Description Lists admin actions, newest first. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param targetType query string false "Only actions on this kind of record: exercise, achievement, user or post"
+// @Param targetId query int false "Only actions on the record with this id"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 50 and at most 200"
+// @Success 200 {array} model.AuditRecord "Audit records"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not an admin"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/audit [get]
+func (h *adminHandler) ReadAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := model.ReadAuditLogRequest{
+		TargetType: query.Get("targetType"),
+		Cursor:     query.Get("cursor"),
+	}
+	if raw := query.Get("targetId"); raw != "" {
+		targetID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: targetId must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.TargetID = targetID
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := h.adminService.ReadAuditLog(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
+	render.JSON(w, r, page.Records)
+}
+
+// decodeAdminRequest reports a malformed body as a 400 and returns false
+func decodeAdminRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := render.DecodeJSON(r.Body, v); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+)
+
+func withAdminCtx(r *http.Request, actorID, id int64) *http.Request {
+	r = withUserCtx(r, actorID)
+	return r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+}
+
+func TestAdminHandler_CreateExercise_BadJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	h := &adminHandler{adminService: mock_service.NewMockAdminService(ctrl)}
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/admin/exercises", mustJSONString(t, "{")), 1)
+	h.CreateExercise(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestAdminHandler_CreateExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	req := model.CreateExerciseRequest{Name: "Lunge"}
+	svc.EXPECT().CreateCatalogueExercise(int64(1), req).Return(&model.Exercise{ID: 9, Name: "Lunge"}, nil)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/admin/exercises", mustJSON(t, req)), 1)
+	h.CreateExercise(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+}
+
+func TestAdminHandler_SuspendUser_UsesPathID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.EXPECT().SuspendUser(int64(2), model.SuspendUserRequest{UserID: 5, Until: until, Reason: "spam"}).
+		Return(&model.UserStanding{UserID: 5, SuspendedUntil: &until}, nil)
+
+	w := httptest.NewRecorder()
+	body := mustJSON(t, model.SuspendUserRequest{UserID: 99, Until: until, Reason: "spam"})
+	r := withAdminCtx(httptest.NewRequest(http.MethodPost, "/admin/users/5/suspend", body), 2, 5)
+	h.SuspendUser(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestAdminHandler_BanUser_AdminTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	svc.EXPECT().BanUser(int64(1), model.BanUserRequest{UserID: 3}).
+		Return(nil, fmt.Errorf("%w: admins cannot be moderated", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := withAdminCtx(httptest.NewRequest(http.MethodPost, "/admin/users/3/ban", mustJSONString(t, "{}")), 1, 3)
+	h.BanUser(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status=%d want=403", w.Code)
+	}
+}
+
+func TestAdminHandler_TakeDownPost_EmptyBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	svc.EXPECT().TakeDownPost(int64(2), model.TakeDownPostRequest{PostID: 20}).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := withAdminCtx(httptest.NewRequest(http.MethodPost, "/admin/posts/20/takedown", nil), 2, 20)
+	h.TakeDownPost(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestAdminHandler_ReadAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	svc.EXPECT().ReadAuditLog(model.ReadAuditLogRequest{TargetType: "user", TargetID: 5, Cursor: "10", Limit: 2}).
+		Return(&model.AuditLogPage{Records: []*model.AuditRecord{{ID: 9}, {ID: 8}}, NextCursor: "8"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/admin/audit?targetType=user&targetId=5&cursor=10&limit=2", nil)
+	h.ReadAuditLog(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+	if got := w.Header().Get(constants.NEXT_CURSOR_HEADER); got != "8" {
+		t.Fatalf("next cursor=%q want=8", got)
+	}
+}
+
+func TestAdminHandler_ReadAuditLog_BadLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	h := &adminHandler{adminService: mock_service.NewMockAdminService(ctrl)}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/admin/audit?limit=lots", nil)
+	h.ReadAuditLog(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestAdminHandler_DeleteAchievement_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockAdminService(ctrl)
+	h := &adminHandler{adminService: svc}
+
+	svc.EXPECT().DeleteAchievement(int64(1), int64(4)).Return(errors.New("fail"))
+
+	w := httptest.NewRecorder()
+	r := withAdminCtx(httptest.NewRequest(http.MethodDelete, "/admin/achievements/4", nil), 1, 4)
+	h.DeleteAchievement(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status=%d want=500", w.Code)
+	}
+}
@@ -12,12 +12,19 @@ import (
 	"workoutpal/src/internal/middleware"
 	"workoutpal/src/internal/model"
 	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 
 	"github.com/go-chi/render"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 )
 
+const (
+	accessTokenCookie  = "access_token"
+	refreshTokenCookie = "refresh_token"
+	accessTokenTTL     = time.Hour
+)
+
 type authHandler struct {
 	userService service.UserService
 	authService service.AuthService
@@ -34,12 +41,13 @@ func NewAuthHandler(us service.UserService, as service.AuthService, secret []byt
 
 // Login godoc
 // @Summary Logs in a user
-// @Description Authenticates a user and sets access_token as cookie
+// @Description Authenticates a user and sets the access_token and refresh_token cookies
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body model.LoginRequest true "comment"
 // @Success 200 {object} model.User
+// @Failure 403 {object} model.Error "Account suspended or banned"
 // @Router /login [post]
 func (h *authHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req model.LoginRequest
@@ -58,38 +66,70 @@ func (h *authHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims := jwt.MapClaims{
-		"sub":   user.ID,
-		"email": user.Email,
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+	session, err := h.authService.CreateSession(r.Context(), user.ID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
 	}
-	tokenWithClaims := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	token, err := tokenWithClaims.SignedString(h.secret)
+	if _, err := h.setSessionCookies(w, user, session); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, user)
+}
+
+// GoogleAuth godoc
+// @Summary Authenticate with Google
+// @Description Verifies a Google ID token, links or provisions the account and sets the same cookies as /login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.GoogleAuthRequest true "Google ID token"
+// @Success 200 {object} model.AuthResponse "Authentication successful"
+// @Failure 400 {object} model.BasicResponse "Invalid request or token"
+// @Failure 401 {object} model.Error "Authentication failed"
+// @Failure 403 {object} model.Error "Account suspended or banned"
+// @Router /auth/google [post]
+func (h *authHandler) GoogleAuth(w http.ResponseWriter, r *http.Request) {
+	var req model.GoogleAuthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, model.BasicResponse{Message: "Invalid request body"})
+		return
+	}
+
+	if req.IDToken == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, model.BasicResponse{Message: "ID token is required"})
+		return
+	}
+
+	user, err := h.authService.AuthenticateGoogle(r.Context(), req.IDToken)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
 		return
 	}
 
-	_ = godotenv.Overload()
-	isSecure := true
-	if v := os.Getenv("COOKIE_SECURE"); strings.ToLower(v) == "false" {
-		isSecure = false
+	session, err := h.authService.CreateSession(r.Context(), user.ID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "access_token",
-		Value:    token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   isSecure,
-		SameSite: http.SameSiteNoneMode,
-		MaxAge:   int(time.Hour.Seconds()),
-	})
+	token, err := h.setSessionCookies(w, user, session)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
 
-	render.JSON(w, r, user)
+	render.JSON(w, r, model.AuthResponse{Token: token, User: *user})
 }
 
 // Me godoc
@@ -119,66 +159,173 @@ func (h *authHandler) Me(w http.ResponseWriter, r *http.Request) {
 		util.ErrorResponse(w, r, responseErr)
 		return
 	}
+	user.Role, _ = claims["role"].(string)
+
+	render.JSON(w, r, user)
+}
+
+// Refresh godoc
+// @Summary Refreshes the access token
+// @Description Trades the refresh_token cookie (or body) for a new access token and refresh token. Reusing a refresh token revokes its session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.RefreshRequest false "refresh token, when not sent as a cookie"
+// @Success 200 {object} model.User
+// @Failure 401 {object} model.Error
+// @Failure 403 {object} model.Error "Account suspended or banned"
+// @Router /auth/refresh [post]
+func (h *authHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	session, err := h.authService.RefreshSession(r.Context(), refreshTokenFromRequest(r))
+	if err != nil {
+		if errors.Is(err, util.ErrUnauthorized) || errors.Is(err, util.ErrForbidden) {
+			clearSessionCookies(w)
+		}
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	user, err := h.userService.ReadUserByID(session.UserID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	if _, err := h.setSessionCookies(w, user, session); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
 
 	render.JSON(w, r, user)
 }
 
 // Logout godoc
-// @Summary Logs out user by clearing access_token
+// @Summary Logs out user by revoking the session and clearing its cookies
+// @Description The session is found from the refresh token, or from the access token for clients that only hold that one
 // @Tags auth
 // @Success 200 {object} model.BasicResponse "successful logout"
 // @Router /logout [post]
 func (h *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var err error
+	if refreshToken := refreshTokenFromRequest(r); refreshToken != "" {
+		err = h.authService.EndSession(r.Context(), refreshToken)
+	} else if sessionID := h.sessionIDFromAccessToken(r); sessionID != "" {
+		err = h.authService.RevokeSession(r.Context(), sessionID)
+	}
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	clearSessionCookies(w)
+	w.WriteHeader(http.StatusOK)
+	render.JSON(w, r, model.BasicResponse{Message: "success"})
+}
+
+// LogoutAll godoc
+// @Summary Logs out every device
+// @Description Revokes all sessions of the current user, their access and refresh tokens stop working immediately
+// @Tags auth
+// @Success 200 {object} model.BasicResponse "successful logout"
+// @Router /auth/logout-all [post]
+func (h *authHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	if err := h.authService.RevokeAllSessions(r.Context(), userID); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	clearSessionCookies(w)
+	render.JSON(w, r, model.BasicResponse{Message: "success"})
+}
+
+// setSessionCookies issues an access token bound to the session, so revoking the
+// session also invalidates it, stores both tokens as cookies and returns the access token.
+// The user is given the role the token carries so clients can tell which screens to show.
+func (h *authHandler) setSessionCookies(w http.ResponseWriter, user *model.User, session *model.AuthSession) (string, error) {
+	user.Role = session.Role
+	claims := jwt.MapClaims{
+		"sub":   user.ID,
+		"email": user.Email,
+		"sid":   session.ID,
+		"role":  session.Role,
+		"exp":   time.Now().Add(accessTokenTTL).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+	tokenWithClaims := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	token, err := tokenWithClaims.SignedString(h.secret)
+	if err != nil {
+		return "", err
+	}
+
+	setCookie(w, accessTokenCookie, token, int(accessTokenTTL.Seconds()))
+	setCookie(w, refreshTokenCookie, session.RefreshToken, int(time.Until(session.RefreshExpiresAt).Seconds()))
+	return token, nil
+}
+
+func clearSessionCookies(w http.ResponseWriter) {
+	setCookie(w, accessTokenCookie, "", -1)
+	setCookie(w, refreshTokenCookie, "", -1)
+}
+
+func setCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	_ = godotenv.Overload()
+	isSecure := true
+	if v := os.Getenv("COOKIE_SECURE"); strings.ToLower(v) == "false" {
+		isSecure = false
+	}
+
 	http.SetCookie(w, &http.Cookie{
-		Name:     "access_token",
-		Value:    "",
+		Name:     name,
+		Value:    value,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   true,
+		Secure:   isSecure,
 		SameSite: http.SameSiteNoneMode,
-		MaxAge:   -1,
+		MaxAge:   maxAge,
 	})
-	w.WriteHeader(http.StatusOK)
-	render.JSON(w, r, model.BasicResponse{Message: "success"})
 }
 
-// GoogleAuth godoc
-// @Summary Authenticate with Google OAuth
-// @Tags Authentication
-// @Accept json
-// @Produce json
-// @Param request body model.GoogleAuthRequest true "Google ID token"
-// @Success 200 {object} model.AuthResponse "Authentication successful"
-// @Failure 400 {object} model.BasicResponse "Invalid request or token"
-// @Failure 401 {object} model.BasicResponse "Authentication failed"
-// @Router /auth/google [post]
-func (a *authHandler) GoogleAuth(w http.ResponseWriter, r *http.Request) {
-	var req model.GoogleAuthRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, model.BasicResponse{Message: "Invalid request body"})
-		return
+// sessionIDFromAccessToken reads the sid claim of the access token in the
+// Authorization header or cookie. Logout is not behind the auth middleware, so
+// the signature is checked here; an expired token still names its session.
+func (h *authHandler) sessionIDFromAccessToken(r *http.Request) string {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		cookie, err := r.Cookie(accessTokenCookie)
+		if err != nil || cookie.Value == "" {
+			return ""
+		}
+		tokenString = cookie.Value
 	}
 
-	if req.IDToken == "" {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, model.BasicResponse{Message: "ID token is required"})
-		return
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return h.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+	if err != nil {
+		return ""
 	}
+	sessionID, _ := claims["sid"].(string)
+	return sessionID
+}
 
-	// TODO: Verify Google ID token and extract user info
-	// For now, return a placeholder response
-	response := model.AuthResponse{
-		Token: "jwt-token-placeholder",
-		User: model.User{
-			ID:         1,
-			Email:      "user@example.com",
-			Name:       "Google User",
-			Provider:   "google",
-			IsVerified: true,
-		},
+// refreshTokenFromRequest reads the refresh_token cookie, falling back to the
+// JSON body for clients that don't keep cookies
+func refreshTokenFromRequest(r *http.Request) string {
+	if cookie, err := r.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
 	}
-
-	render.Status(r, http.StatusOK)
-	render.JSON(w, r, response)
+	var req model.RefreshRequest
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	return req.RefreshToken
 }
@@ -4,16 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
 
 	"workoutpal/src/internal/model"
 	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 )
 
 func newAuthHandlerMocks(t *testing.T) (*mock_service.MockUserService, *mock_service.MockAuthService, *authHandler, *gomock.Controller) {
@@ -52,6 +55,8 @@ func TestAuthHandler_Login_OK(t *testing.T) {
 			}
 			return &model.User{ID: 123, Email: got.Email, Name: "Tester"}, nil
 		})
+	authSvc.EXPECT().CreateSession(gomock.Any(), int64(123)).
+		Return(&model.AuthSession{ID: "s1", UserID: 123, RefreshToken: "refresh", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/login", mustJSONBody(t, reqBody))
@@ -79,6 +84,17 @@ func TestAuthHandler_Login_OK(t *testing.T) {
 	if !tokenCookie.HttpOnly {
 		t.Fatalf("expected HttpOnly cookie")
 	}
+	if c := findCookie(resp.Cookies(), "refresh_token"); c == nil || c.Value != "refresh" || !c.HttpOnly {
+		t.Fatalf("expected HttpOnly refresh_token cookie, got %+v", c)
+	}
+
+	token, _, err := jwt.NewParser().ParseUnverified(tokenCookie.Value, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	if sid := token.Claims.(jwt.MapClaims)["sid"]; sid != "s1" {
+		t.Fatalf("expected the access token to carry the session id, got %v", sid)
+	}
 
 	var gotUser model.User
 	if err := json.NewDecoder(resp.Body).Decode(&gotUser); err != nil {
@@ -135,10 +151,13 @@ func TestAuthHandler_Me_Unauthorized_NoClaims(t *testing.T) {
 }
 
 func TestAuthHandler_Logout_ClearsCookie(t *testing.T) {
-	_, _, h, _ := newAuthHandlerMocks(t)
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().EndSession(gomock.Any(), "refresh").Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	r.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh"})
 
 	h.Logout(w, r)
 	resp := w.Result()
@@ -173,4 +192,205 @@ func TestAuthHandler_Logout_ClearsCookie(t *testing.T) {
 	}
 }
 
-var _ = jwt.MapClaims{}
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestAuthHandler_Refresh_OK(t *testing.T) {
+	userSvc, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().RefreshSession(gomock.Any(), "old").
+		Return(&model.AuthSession{ID: "s1", UserID: 123, RefreshToken: "new", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
+	userSvc.EXPECT().ReadUserByID(int64(123)).Return(&model.User{ID: 123, Email: "a@b.com"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	r.AddCookie(&http.Cookie{Name: "refresh_token", Value: "old"})
+
+	h.Refresh(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if c := findCookie(resp.Cookies(), "access_token"); c == nil || c.Value == "" {
+		t.Fatalf("expected a new access_token cookie")
+	}
+	if c := findCookie(resp.Cookies(), "refresh_token"); c == nil || c.Value != "new" {
+		t.Fatalf("expected the rotated refresh_token cookie, got %+v", c)
+	}
+}
+
+func TestAuthHandler_Refresh_TokenInBody(t *testing.T) {
+	userSvc, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().RefreshSession(gomock.Any(), "from-body").
+		Return(&model.AuthSession{ID: "s1", UserID: 123, RefreshToken: "new", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
+	userSvc.EXPECT().ReadUserByID(int64(123)).Return(&model.User{ID: 123}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/refresh", mustJSONBody(t, model.RefreshRequest{RefreshToken: "from-body"}))
+
+	h.Refresh(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
+
+func TestAuthHandler_Refresh_Unauthorized(t *testing.T) {
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().RefreshSession(gomock.Any(), "reused").
+		Return(nil, fmt.Errorf("%w: refresh token was already used, session revoked", util.ErrUnauthorized))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	r.AddCookie(&http.Cookie{Name: "refresh_token", Value: "reused"})
+
+	h.Refresh(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", resp.StatusCode)
+	}
+	if c := findCookie(resp.Cookies(), "refresh_token"); c == nil || c.MaxAge != -1 {
+		t.Fatalf("expected refresh_token cookie to be cleared, got %+v", c)
+	}
+}
+
+func TestAuthHandler_Logout_AccessTokenOnly(t *testing.T) {
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+	h.secret = []byte("secret")
+
+	// an expired token still names the session to revoke
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": 7, "sid": "s1", "exp": time.Now().Add(-time.Minute).Unix(),
+	}).SignedString(h.secret)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	authSvc.EXPECT().RevokeSession(gomock.Any(), "s1").Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	h.Logout(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if c := findCookie(resp.Cookies(), "access_token"); c == nil || c.MaxAge != -1 {
+		t.Fatalf("expected access_token cookie to be cleared, got %+v", c)
+	}
+}
+
+func TestAuthHandler_Logout_ForgedAccessToken(t *testing.T) {
+	_, _, h, _ := newAuthHandlerMocks(t)
+	h.secret = []byte("secret")
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": 7, "sid": "s1"}).SignedString([]byte("other"))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	r.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+
+	// no session is revoked; the cookies are still cleared
+	h.Logout(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
+
+func TestAuthHandler_LogoutAll_OK(t *testing.T) {
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().RevokeAllSessions(gomock.Any(), int64(123)).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/auth/logout-all", nil), 123)
+
+	h.LogoutAll(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if c := findCookie(resp.Cookies(), "access_token"); c == nil || c.MaxAge != -1 {
+		t.Fatalf("expected access_token cookie to be cleared, got %+v", c)
+	}
+}
+
+func TestAuthHandler_GoogleAuth_OK(t *testing.T) {
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().AuthenticateGoogle(gomock.Any(), "id-token").Return(&model.User{ID: 9, Email: "ada@example.com", Provider: "google"}, nil)
+	authSvc.EXPECT().CreateSession(gomock.Any(), int64(9)).
+		Return(&model.AuthSession{ID: "s1", UserID: 9, RefreshToken: "refresh", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/google", mustJSONBody(t, model.GoogleAuthRequest{IDToken: "id-token"}))
+
+	h.GoogleAuth(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	cookie := findCookie(resp.Cookies(), "access_token")
+	if cookie == nil || cookie.Value == "" {
+		t.Fatalf("expected access_token cookie to be set")
+	}
+	var got model.AuthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Token != cookie.Value || got.User.ID != 9 {
+		t.Fatalf("unexpected response: %+v", got)
+	}
+}
+
+func TestAuthHandler_GoogleAuth_MissingToken(t *testing.T) {
+	_, _, h, _ := newAuthHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/google", mustJSONBody(t, model.GoogleAuthRequest{}))
+
+	h.GoogleAuth(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestAuthHandler_GoogleAuth_InvalidToken(t *testing.T) {
+	_, authSvc, h, _ := newAuthHandlerMocks(t)
+
+	authSvc.EXPECT().AuthenticateGoogle(gomock.Any(), "forged").
+		Return(nil, fmt.Errorf("%w: invalid id token", util.ErrUnauthorized))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/auth/google", mustJSONBody(t, model.GoogleAuthRequest{IDToken: "forged"}))
+
+	h.GoogleAuth(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+	if findCookie(w.Result().Cookies(), "access_token") != nil {
+		t.Fatalf("no cookie should be set for a rejected token")
+	}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type bodyMeasurementHandler struct {
+	service service.BodyMeasurementService
+}
+
+func NewBodyMeasurementHandler(s service.BodyMeasurementService) handler.BodyMeasurementHandler {
+	return &bodyMeasurementHandler{service: s}
+}
+
+// CreateMeasurement godoc
+// @Summary Log a body measurement
+// @Description Metric is one of weight, body_fat, waist, chest, hips, neck, arm or thigh. Weight is logged in kg or lb, body fat in % and the rest in cm or in; the unit defaults to the one on the profile. Values are stored in kg, cm and % and the response uses the unit the value was logged in.
+// @Tags Measurements
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body model.CreateMeasurementRequest true "Measurement"
+// @Success 201 {object} model.BodyMeasurement "Measurement logged"
+// @Failure 400 {object} model.BasicResponse "Unknown metric, unit or value"
+// @Failure 403 {object} model.BasicResponse "Not your account"
+// @Security BearerAuth
+// @Router /users/{id}/measurements [post]
+func (h *bodyMeasurementHandler) CreateMeasurement(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.CreateMeasurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.UserID = id
+
+	measurement, err := h.service.CreateMeasurement(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, measurement)
+}
+
+// ReadMeasurements godoc
+// @Summary List a user's body measurements
+// @Description Lists measurements oldest first, in the owner's profile units unless units asks for metric or imperial. Other users only see them when they follow the owner and the owner shows metrics to followers.
+// @Tags Measurements
+// @Produce json
+// @Param id path int true "User ID"
+// @Param metric query string false "Only this metric"
+// @Param from query string false "First date, e.g. 2025-03-01; defaults to 90 days before to"
+// @Param to query string false "Last date, inclusive; defaults to today"
+// @Param units query string false "metric or imperial"
+// @Success 200 {array} model.BodyMeasurement
+// @Failure 400 {object} model.BasicResponse "Invalid metric, range or units"
+// @Failure 403 {object} model.BasicResponse "Metrics are not shared with the caller"
+// @Security BearerAuth
+// @Router /users/{id}/measurements [get]
+func (h *bodyMeasurementHandler) ReadMeasurements(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	query := r.URL.Query()
+
+	measurements, err := h.service.ReadMeasurements(actorID, model.ReadMeasurementsRequest{
+		UserID: id,
+		Metric: query.Get("metric"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Units:  query.Get("units"),
+	})
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, measurements)
+}
+
+// ReadTrend godoc
+// @Summary Trend of one body metric
+// @Description Averages the metric per day, with a moving average over the last window days (default 7), and compares each week's average, from Monday, with the week before. Visibility follows the measurement list.
+// @Tags Measurements
+// @Produce json
+// @Param id path int true "User ID"
+// @Param metric query string true "Metric to trend"
+// @Param window query int false "Moving average window in days, 1 to 90" default(7)
+// @Param from query string false "First date, e.g. 2025-03-01; defaults to 90 days before to"
+// @Param to query string false "Last date, inclusive; defaults to today"
+// @Param units query string false "metric or imperial"
+// @Success 200 {object} model.MeasurementTrend
+// @Failure 400 {object} model.BasicResponse "Invalid metric, window, range or units"
+// @Failure 403 {object} model.BasicResponse "Metrics are not shared with the caller"
+// @Security BearerAuth
+// @Router /users/{id}/measurements/trend [get]
+func (h *bodyMeasurementHandler) ReadTrend(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	query := r.URL.Query()
+
+	req := model.ReadMeasurementsRequest{
+		UserID: id,
+		Metric: query.Get("metric"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Units:  query.Get("units"),
+	}
+	if raw := query.Get("window"); raw != "" {
+		window, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: invalid window", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Window = window
+	}
+
+	trend, err := h.service.ReadTrend(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, trend)
+}
+
+// DeleteMeasurement godoc
+// @Summary Delete a body measurement
+// @Tags Measurements
+// @Produce json
+// @Param id path int true "User ID"
+// @Param measurement_id path int true "Measurement ID"
+// @Success 200 {object} model.BasicResponse "Measurement deleted"
+// @Failure 400 {object} model.BasicResponse "Invalid measurement ID"
+// @Failure 403 {object} model.BasicResponse "Not your account"
+// @Failure 404 {object} model.BasicResponse "Measurement not found"
+// @Security BearerAuth
+// @Router /users/{id}/measurements/{measurement_id} [delete]
+func (h *bodyMeasurementHandler) DeleteMeasurement(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	measurementID, err := strconv.ParseInt(chi.URLParam(r, "measurement_id"), 10, 64)
+	if err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid measurement ID", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	if err := h.service.DeleteMeasurement(actorID, id, measurementID); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Measurement deleted"})
+}
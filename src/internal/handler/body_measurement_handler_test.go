@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newBodyMeasurementHandlerMocks(t *testing.T) (*mock_service.MockBodyMeasurementService, *bodyMeasurementHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockBodyMeasurementService(ctrl)
+	return mockSvc, &bodyMeasurementHandler{service: mockSvc}
+}
+
+func TestBodyMeasurementHandler_CreateMeasurement_Created(t *testing.T) {
+	mockSvc, h := newBodyMeasurementHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		CreateMeasurement(int64(1), model.CreateMeasurementRequest{UserID: 1, Metric: "weight", Value: 180, Unit: "lb"}).
+		Return(&model.BodyMeasurement{ID: 5, UserID: 1, Metric: "weight", Value: 180, Unit: "lb"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/1/measurements", strings.NewReader(`{"metric":"weight","value":180,"unit":"lb"}`))
+	r = withIDCtx(withUserCtx(r, 1), 1)
+
+	h.CreateMeasurement(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+	var got model.BodyMeasurement
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != 5 || got.Unit != "lb" {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestBodyMeasurementHandler_CreateMeasurement_BadBody(t *testing.T) {
+	_, h := newBodyMeasurementHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/1/measurements", strings.NewReader(`{`))
+	r = withIDCtx(withUserCtx(r, 1), 1)
+
+	h.CreateMeasurement(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_ReadMeasurements_PassesQuery(t *testing.T) {
+	mockSvc, h := newBodyMeasurementHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		ReadMeasurements(int64(7), model.ReadMeasurementsRequest{UserID: 9, Metric: "waist", From: "2025-03-01", To: "2025-03-31", Units: "imperial"}).
+		Return([]*model.BodyMeasurement{{ID: 1, Metric: "waist", Value: 34, Unit: "in"}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/9/measurements?metric=waist&from=2025-03-01&to=2025-03-31&units=imperial", nil)
+	r = withIDCtx(withUserCtx(r, 7), 9)
+
+	h.ReadMeasurements(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_ReadMeasurements_Hidden(t *testing.T) {
+	mockSvc, h := newBodyMeasurementHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadMeasurements(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: metrics are not shared with you", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/9/measurements", nil)
+	r = withIDCtx(withUserCtx(r, 7), 9)
+
+	h.ReadMeasurements(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status=%d want=403", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_ReadTrend(t *testing.T) {
+	mockSvc, h := newBodyMeasurementHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		ReadTrend(int64(1), model.ReadMeasurementsRequest{UserID: 1, Metric: "weight", Window: 14}).
+		Return(&model.MeasurementTrend{UserID: 1, Metric: "weight", Unit: "kg", Window: 14}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1/measurements/trend?metric=weight&window=14", nil)
+	r = withIDCtx(withUserCtx(r, 1), 1)
+
+	h.ReadTrend(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_ReadTrend_BadWindow(t *testing.T) {
+	_, h := newBodyMeasurementHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1/measurements/trend?metric=weight&window=week", nil)
+	r = withIDCtx(withUserCtx(r, 1), 1)
+
+	h.ReadTrend(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_DeleteMeasurement(t *testing.T) {
+	mockSvc, h := newBodyMeasurementHandlerMocks(t)
+
+	mockSvc.EXPECT().DeleteMeasurement(int64(1), int64(1), int64(5)).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/users/1/measurements/5", nil)
+	r = setChiURLParam(withIDCtx(withUserCtx(r, 1), 1), "measurement_id", "5")
+
+	h.DeleteMeasurement(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestBodyMeasurementHandler_DeleteMeasurement_BadID(t *testing.T) {
+	_, h := newBodyMeasurementHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/users/1/measurements/x", nil)
+	r = setChiURLParam(withIDCtx(withUserCtx(r, 1), 1), "measurement_id", "x")
+
+	h.DeleteMeasurement(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
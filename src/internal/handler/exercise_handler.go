@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"workoutpal/src/internal/domain/handler"
 	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
 	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 
@@ -22,26 +25,55 @@ func NewExerciseHandler(es service.ExerciseService) handler.ExerciseHandler {
 
 // ReadExercises godoc
 // @Summary List exercises
+// @Description Filters, searches and pages the exercise catalogue. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.
 // @Tags Exercises
 // @Accept json
 // @Produce json
-// @Param target query string false "Primary target muscle"
-// @Param intensity query string false "Training intensity"
-// @Param expertise query string false "Recommended expertise level"
+// @Param target query string false "Target muscle, matched against any of the exercise targets"
+// @Param intensity query string false "Training intensity; exercises without one don't match"
+// @Param expertise query string false "Recommended expertise level; exercises without one don't match"
+// @Param search query string false "Text searched for in the name and description"
+// @Param sort query string false "Sort order: name (default), -name, id or -id"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 50 and at most 200"
 // @Success 200 {array} model.Exercise "Exercises retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /exercises [get]
 func (h *exerciseHandler) ReadExercises(w http.ResponseWriter, r *http.Request) {
-	exercises, err := h.exerciseService.ReadAllExercises()
+	query := r.URL.Query()
+	req := model.ReadExerciseRequest{
+		Target:    query.Get("target"),
+		Intensity: query.Get("intensity"),
+		Expertise: query.Get("expertise"),
+		Search:    query.Get("search"),
+		Sort:      query.Get("sort"),
+		Cursor:    query.Get("cursor"),
+		ViewerID:  r.Context().Value(constants.USER_ID_KEY).(int64),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := h.exerciseService.ReadExercises(req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
 		return
 	}
-	render.JSON(w, r, exercises)
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
+	render.JSON(w, r, page.Exercises)
 }
 
 // ReadExerciseByID godoc
@@ -49,15 +81,18 @@ func (h *exerciseHandler) ReadExercises(w http.ResponseWriter, r *http.Request)
 // @Tags Exercises
 // @Accept json
 // @Produce json
+// @Param id path int true "Exercise ID"
 // @Success 200 {object} model.Exercise "Exercises retrieved successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 404 {object} model.BasicResponse "Exercise not found or private"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /exercises/{id} [get]
 func (h *exerciseHandler) ReadExerciseByID(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	id := r.Context().Value(constants.ID_KEY).(int64)
-	exercise, err := h.exerciseService.ReadExerciseByID(id)
+	exercise, err := h.exerciseService.ReadExerciseByID(userID, id)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -68,7 +103,7 @@ func (h *exerciseHandler) ReadExerciseByID(w http.ResponseWriter, r *http.Reques
 
 // CreateExercise godoc
 // @Summary Create a new exercise
-// @Description Creates a custom exercise with metadata such as targets, intensity, expertise, and media references.
+// @Description Creates a custom exercise owned by the caller. It is private unless isPublic is set, in which case every user can find it in /exercises.
 // @Tags Exercises
 // @Accept json
 // @Produce json
@@ -80,6 +115,89 @@ func (h *exerciseHandler) ReadExerciseByID(w http.ResponseWriter, r *http.Reques
 // @Security BearerAuth
 // @Router /exercises [post]
 func (h *exerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
-	//TODO implement me
-	panic("implement me")
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.CreateExerciseRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.OwnerID = userID
+
+	exercise, err := h.exerciseService.CreateExercise(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, exercise)
+}
+
+// UpdateExercise godoc
+// @Summary Update a custom exercise
+// @Description Replaces the fields of a custom exercise. Only its author can edit it; built-in exercises cannot be edited.
+// @Tags Exercises
+// @Accept json
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Param request body model.UpdateExerciseRequest true "Exercise update payload"
+// @Success 200 {object} model.Exercise "Exercise updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not the author"
+// @Failure 404 {object} model.BasicResponse "Exercise not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /exercises/{id} [put]
+func (h *exerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.UpdateExerciseRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ID = id
+	req.OwnerID = userID
+
+	exercise, err := h.exerciseService.UpdateExercise(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, exercise)
+}
+
+// DeleteExercise godoc
+// @Summary Delete a custom exercise
+// @Description Hides a custom exercise from listings and from new routines. Routines, logged sets, records and goals that already use it keep it, and it stays readable by id flagged as deleted. Only its author can delete it.
+// @Tags Exercises
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Success 200 {object} model.BasicResponse "Exercise deleted successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not the author"
+// @Failure 404 {object} model.BasicResponse "Exercise not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /exercises/{id} [delete]
+func (h *exerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	err := h.exerciseService.DeleteExercise(model.DeleteExerciseRequest{ID: id, OwnerID: userID})
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Exercise deleted successfully"})
 }
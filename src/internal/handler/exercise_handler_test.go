@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 
 	mock_service "workoutpal/src/mock_internal/domain/service"
@@ -39,11 +41,12 @@ func TestExerciseHandler_ReadExercises_OK(t *testing.T) {
 	}
 
 	mockSvc.EXPECT().
-		ReadAllExercises().
-		Return(want, nil)
+		ReadExercises(model.ReadExerciseRequest{Target: "legs", Search: "squat", Sort: "-name", Cursor: "abc", Limit: 2, ViewerID: 5}).
+		Return(&model.ExercisePage{Exercises: want, NextCursor: "next"}, nil)
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+	r := httptest.NewRequest(http.MethodGet, "/exercises?target=legs&search=squat&sort=-name&cursor=abc&limit=2", nil)
+	r = withUserCtx(r, 5)
 
 	h.ReadExercises(w, r)
 
@@ -53,6 +56,9 @@ func TestExerciseHandler_ReadExercises_OK(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("status = %d, want 200", resp.StatusCode)
 	}
+	if cursor := resp.Header.Get(constants.NEXT_CURSOR_HEADER); cursor != "next" {
+		t.Fatalf("next cursor = %q, want next", cursor)
+	}
 
 	var got []model.Exercise
 	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
@@ -71,11 +77,12 @@ func TestExerciseHandler_ReadExercises_Error(t *testing.T) {
 	h := &exerciseHandler{exerciseService: mockSvc}
 
 	mockSvc.EXPECT().
-		ReadAllExercises().
+		ReadExercises(model.ReadExerciseRequest{ViewerID: 5}).
 		Return(nil, errors.New("boom"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+	r = withUserCtx(r, 5)
 
 	h.ReadExercises(w, r)
 
@@ -84,6 +91,24 @@ func TestExerciseHandler_ReadExercises_Error(t *testing.T) {
 	}
 }
 
+func TestExerciseHandler_ReadExercises_BadLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockExerciseService(ctrl)
+	h := &exerciseHandler{exerciseService: mockSvc}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/exercises?limit=lots", nil)
+	r = withUserCtx(r, 5)
+
+	h.ReadExercises(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
 func TestExerciseHandler_ReadExerciseByID_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -95,13 +120,13 @@ func TestExerciseHandler_ReadExerciseByID_OK(t *testing.T) {
 	want := &model.Exercise{ID: id, Name: "Deadlift"}
 
 	mockSvc.EXPECT().
-		ReadExerciseByID(id).
+		ReadExerciseByID(int64(5), id).
 		Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/exercises/42", nil)
 	ctx := context.WithValue(r.Context(), constants.ID_KEY, id)
-	r = r.WithContext(ctx)
+	r = withUserCtx(r.WithContext(ctx), 5)
 
 	h.ReadExerciseByID(w, r)
 
@@ -129,12 +154,12 @@ func TestExerciseHandler_ReadExerciseByID_Error(t *testing.T) {
 
 	const id int64 = 7
 	mockSvc.EXPECT().
-		ReadExerciseByID(id).
+		ReadExerciseByID(int64(5), id).
 		Return(&model.Exercise{}, errors.New("not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/exercises/7", nil)
-	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+	r = withUserCtx(r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id)), 5)
 
 	h.ReadExerciseByID(w, r)
 
@@ -142,3 +167,75 @@ func TestExerciseHandler_ReadExerciseByID_Error(t *testing.T) {
 		t.Fatalf("status = %d, want 500", w.Code)
 	}
 }
+
+func TestExerciseHandler_CreateExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockExerciseService(ctrl)
+	h := &exerciseHandler{exerciseService: mockSvc}
+
+	mockSvc.EXPECT().
+		CreateExercise(model.CreateExerciseRequest{OwnerID: 5, Name: "Calf raise", Targets: []string{"calves"}}).
+		Return(&model.Exercise{ID: 50, Name: "Calf raise", Custom: true, OwnerID: 5}, nil)
+
+	// ownerId in the body is ignored, the caller always owns what they create
+	body := mustJSON(t, model.CreateExerciseRequest{OwnerID: 99, Name: "Calf raise", Targets: []string{"calves"}})
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/exercises", body), 5)
+
+	h.CreateExercise(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", w.Code)
+	}
+	var got model.Exercise
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != 50 || got.OwnerID != 5 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestExerciseHandler_UpdateExercise_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockExerciseService(ctrl)
+	h := &exerciseHandler{exerciseService: mockSvc}
+
+	mockSvc.EXPECT().
+		UpdateExercise(model.UpdateExerciseRequest{ID: 50, OwnerID: 5, Name: "Calf raise"}).
+		Return(nil, fmt.Errorf("%w: only the author can change this exercise", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/exercises/50", mustJSON(t, model.UpdateExerciseRequest{Name: "Calf raise"}))
+	r = withUserCtx(r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(50))), 5)
+
+	h.UpdateExercise(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
+
+func TestExerciseHandler_DeleteExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockExerciseService(ctrl)
+	h := &exerciseHandler{exerciseService: mockSvc}
+
+	mockSvc.EXPECT().DeleteExercise(model.DeleteExerciseRequest{ID: 50, OwnerID: 5}).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/exercises/50", nil)
+	r = withUserCtx(r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(50))), 5)
+
+	h.DeleteExercise(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
@@ -84,6 +84,7 @@ func (h *ExerciseSettingHandler) ReadExerciseSetting(w http.ResponseWriter, r *h
 // @Success 201 {object} model.ExerciseSetting "Exercise Setting created successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Routine belongs to another user"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /exercise-settings [post]
@@ -118,6 +119,7 @@ func (h *ExerciseSettingHandler) CreateExerciseSetting(w http.ResponseWriter, r
 // @Success 200 {object} model.ExerciseSetting "Exercise Setting updated successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Routine belongs to another user"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /exercise-settings [put]
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"workoutpal/src/internal/domain/handler"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
 	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -25,6 +27,7 @@ func NewGoalHandler(gs service.GoalService) handler.GoalHandler {
 
 // CreateUserGoal godoc
 // @Summary Create a goal for user
+// @Description A goal is custom free text unless it has a measurable type: body_weight (targetValue in kg, tracked against the latest logged weight measurement), one_rep_max (an estimated 1RM of targetValue on exerciseId) or workouts_per_week (targetValue workouts finished in a week, counted from Monday). Measurable goals come back with their current value and progress, and complete themselves once logged data (a finished workout, a new record or a weight) meets the target.
 // @Tags Goals
 // @Accept json
 // @Produce json
@@ -32,9 +35,11 @@ func NewGoalHandler(gs service.GoalService) handler.GoalHandler {
 // @Param request body model.CreateGoalRequest true "Goal payload"
 // @Success 201 {object} model.Goal "Goal created successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Not your account"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id}/goals [post]
 func (g *goalHandler) CreateUserGoal(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -50,7 +55,7 @@ func (g *goalHandler) CreateUserGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	goal, err := g.goalService.CreateGoal(id, req)
+	goal, err := g.goalService.CreateGoal(actorID, id, req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -68,9 +73,11 @@ func (g *goalHandler) CreateUserGoal(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "User ID"
 // @Success 200 {array} model.Goal "Goals retrieved successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
+// @Failure 403 {object} model.BasicResponse "Profile is private"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id}/goals [get]
 func (g *goalHandler) GetUserGoals(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -79,7 +86,7 @@ func (g *goalHandler) GetUserGoals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	goals, err := g.goalService.ReadUserGoals(id)
+	goals, err := g.goalService.ReadUserGoals(actorID, id)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -88,3 +95,80 @@ func (g *goalHandler) GetUserGoals(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, goals)
 }
+
+// UpdateUserGoal godoc
+// @Summary Update a user's goal
+// @Description Changes the fields that are set. A measurable goal's type and exercise can't change, and custom goals have no target.
+// @Tags Goals
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param goal_id path int true "Goal ID"
+// @Param request body model.UpdateGoalRequest true "Fields to change"
+// @Success 200 {object} model.Goal "Goal updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Not your account"
+// @Failure 404 {object} model.BasicResponse "Goal not found"
+// @Security BearerAuth
+// @Router /users/{id}/goals/{goal_id} [patch]
+func (g *goalHandler) UpdateUserGoal(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	userID := r.Context().Value(constants.ID_KEY).(int64)
+	goalID, err := goalIDParam(r)
+	if err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
+		return
+	}
+
+	var req model.UpdateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
+		return
+	}
+	req.ID = goalID
+
+	goal, err := g.goalService.UpdateGoal(actorID, userID, req)
+	if err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
+		return
+	}
+
+	render.JSON(w, r, goal)
+}
+
+// DeleteUserGoal godoc
+// @Summary Delete a user's goal
+// @Tags Goals
+// @Produce json
+// @Param id path int true "User ID"
+// @Param goal_id path int true "Goal ID"
+// @Success 200 {object} model.BasicResponse "Goal deleted successfully"
+// @Failure 400 {object} model.BasicResponse "Invalid ID"
+// @Failure 403 {object} model.BasicResponse "Not your account"
+// @Failure 404 {object} model.BasicResponse "Goal not found"
+// @Security BearerAuth
+// @Router /users/{id}/goals/{goal_id} [delete]
+func (g *goalHandler) DeleteUserGoal(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	userID := r.Context().Value(constants.ID_KEY).(int64)
+	goalID, err := goalIDParam(r)
+	if err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
+		return
+	}
+
+	if err := g.goalService.DeleteGoal(actorID, userID, goalID); err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Goal deleted successfully"})
+}
+
+func goalIDParam(r *http.Request) (int64, error) {
+	goalID, err := strconv.ParseInt(chi.URLParam(r, "goal_id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid goal ID", util.ErrInvalidInput)
+	}
+	return goalID, nil
+}
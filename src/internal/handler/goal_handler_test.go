@@ -2,8 +2,10 @@ package handler
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -37,6 +39,7 @@ func TestGoalHandler_CreateUserGoal_BadID(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/abc/goals", bytes.NewBufferString(`{}`))
 	r = setChiURLParam(r, "id", "abc")
+	r = withUserCtx(r, 1)
 
 	h.CreateUserGoal(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -55,6 +58,7 @@ func TestGoalHandler_CreateUserGoal_BadJSON(t *testing.T) {
 	r := httptest.NewRequest(http.MethodPost, "/users/1/goals", bytes.NewBufferString("{"))
 	r.Header.Set("Content-Type", "application/json")
 	r = setChiURLParam(r, "id", "1")
+	r = withUserCtx(r, 1)
 
 	h.CreateUserGoal(w, r)
 	if w.Code != http.StatusBadRequest {
@@ -74,13 +78,14 @@ func TestGoalHandler_CreateUserGoal_ServiceError(t *testing.T) {
 
 	mockSvc.
 		EXPECT().
-		CreateGoal(userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
+		CreateGoal(userID, userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
 		Return(&model.Goal{}, errors.New("validation failed"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/1/goals", mustJSONBody(t, req))
 	r.Header.Set("Content-Type", "application/json")
 	r = setChiURLParam(r, "id", "1")
+	r = withUserCtx(r, 1)
 
 	h.CreateUserGoal(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -101,13 +106,14 @@ func TestGoalHandler_CreateUserGoal_OK(t *testing.T) {
 
 	mockSvc.
 		EXPECT().
-		CreateGoal(userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
+		CreateGoal(userID, userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
 		Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/2/goals", mustJSONBody(t, req))
 	r.Header.Set("Content-Type", "application/json")
 	r = setChiURLParam(r, "id", "2")
+	r = withUserCtx(r, 2)
 
 	h.CreateUserGoal(w, r)
 
@@ -133,6 +139,7 @@ func TestGoalHandler_GetUserGoals_BadID(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/users/xyz/goals", nil)
 	r = setChiURLParam(r, "id", "xyz")
+	r = withUserCtx(r, 1)
 
 	h.GetUserGoals(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -150,12 +157,13 @@ func TestGoalHandler_GetUserGoals_ServiceError(t *testing.T) {
 	const userID int64 = 3
 	mockSvc.
 		EXPECT().
-		ReadUserGoals(userID).
+		ReadUserGoals(userID, userID).
 		Return(nil, errors.New("user not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/users/3/goals", nil)
 	r = setChiURLParam(r, "id", "3")
+	r = withUserCtx(r, 3)
 
 	h.GetUserGoals(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -178,12 +186,13 @@ func TestGoalHandler_GetUserGoals_OK(t *testing.T) {
 
 	mockSvc.
 		EXPECT().
-		ReadUserGoals(userID).
+		ReadUserGoals(userID, userID).
 		Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/users/4/goals", nil)
 	r = setChiURLParam(r, "id", "4")
+	r = withUserCtx(r, 4)
 
 	h.GetUserGoals(w, r)
 
@@ -198,3 +207,71 @@ func TestGoalHandler_GetUserGoals_OK(t *testing.T) {
 		t.Fatalf("unexpected goals: %+v", got)
 	}
 }
+
+func TestGoalHandler_UpdateUserGoal_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockGoalService(ctrl)
+	h := &goalHandler{goalService: mockSvc}
+
+	status := "paused"
+	mockSvc.EXPECT().
+		UpdateGoal(int64(4), int64(4), model.UpdateGoalRequest{ID: 9, Status: &status}).
+		Return(&model.Goal{ID: 9, UserID: 4, Status: "paused"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/users/4/goals/9", bytes.NewBufferString(`{"status":"paused"}`))
+	r = setChiURLParam(r, "goal_id", "9")
+	r = withIDCtx(withUserCtx(r, 4), 4)
+
+	h.UpdateUserGoal(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body=%s", w.Code, w.Body.String())
+	}
+	var got model.Goal
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != 9 || got.Status != "paused" {
+		t.Fatalf("unexpected goal: %+v", got)
+	}
+}
+
+func TestGoalHandler_UpdateUserGoal_BadGoalID(t *testing.T) {
+	h := &goalHandler{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/users/4/goals/abc", bytes.NewBufferString(`{}`))
+	r = setChiURLParam(r, "goal_id", "abc")
+	r = withIDCtx(withUserCtx(r, 4), 4)
+
+	h.UpdateUserGoal(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestGoalHandler_DeleteUserGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockGoalService(ctrl)
+	h := &goalHandler{goalService: mockSvc}
+
+	mockSvc.EXPECT().DeleteGoal(int64(4), int64(4), int64(9)).Return(nil)
+	mockSvc.EXPECT().DeleteGoal(int64(4), int64(4), int64(10)).Return(fmt.Errorf("goal not found: %w", sql.ErrNoRows))
+
+	for goalID, want := range map[string]int{"9": http.StatusOK, "10": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/users/4/goals/"+goalID, nil)
+		r = setChiURLParam(r, "goal_id", goalID)
+		r = withIDCtx(withUserCtx(r, 4), 4)
+
+		h.DeleteUserGoal(w, r)
+		if w.Code != want {
+			t.Errorf("goal %s: status = %d, want %d", goalID, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// uploaded media never changes, so clients may keep it for a year; private
+// because it is served to signed-in users only
+const mediaCacheControl = "private, max-age=31536000, immutable"
+
+type mediaHandler struct {
+	service service.MediaService
+}
+
+func NewMediaHandler(s service.MediaService) handler.MediaHandler {
+	return &mediaHandler{service: s}
+}
+
+// UploadMedia godoc
+// @Summary Upload an image
+// @Description Stores a JPEG, PNG or GIF of up to 5 MB, sent as a multipart upload in the file field, and makes a thumbnail of it. The type is read from the file itself. Attach the returned id to a post (mediaIds) or make it your avatar.
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image"
+// @Success 201 {object} model.Media
+// @Failure 400 {object} model.BasicResponse "Not a supported image"
+// @Failure 413 {object} model.BasicResponse "The image is too large"
+// @Security BearerAuth
+// @Router /media [post]
+func (h *mediaHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	file, err := formFile(w, r)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	defer file.Close()
+
+	media, err := h.service.UploadMedia(r.Context(), userID, file)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, media)
+}
+
+// formFile opens the file field of a multipart upload, refusing bodies much
+// bigger than an image may be
+func formFile(w http.ResponseWriter, r *http.Request) (multipart.File, error) {
+	// leave room for the multipart framing around the file
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxMediaBytes+1<<20)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: images can be at most %d MB", util.ErrTooLarge, model.MaxMediaBytes>>20)
+		}
+		return nil, fmt.Errorf("%w: expected a multipart upload with the image in the file field", util.ErrInvalidInput)
+	}
+	return file, nil
+}
+
+// ReadMedia godoc
+// @Summary Read an image
+// @Description Serves an uploaded image, or with size=thumbnail a copy at most 320 pixels on its longer side. Images never change, so responses carry a long-lived Cache-Control and an ETag.
+// @Tags Media
+// @Produce image/jpeg,image/png,image/gif
+// @Param id path string true "Media ID"
+// @Param size query string false "original (default) or thumbnail"
+// @Success 200 {file} file "The image"
+// @Success 304 "Not modified"
+// @Failure 400 {object} model.BasicResponse "Unknown size"
+// @Failure 404 {object} model.BasicResponse "No such image"
+// @Security BearerAuth
+// @Router /media/{id} [get]
+func (h *mediaHandler) ReadMedia(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, constants.ID_KEY)
+
+	var thumbnail bool
+	switch size := r.URL.Query().Get("size"); size {
+	case "", "original":
+	case "thumbnail":
+		thumbnail = true
+	default:
+		responseErr := util.Error(fmt.Errorf("%w: size must be original or thumbnail", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	etag := `"` + id + `"`
+	if thumbnail {
+		etag = `"` + id + `-thumbnail"`
+	}
+	w.Header().Set("Cache-Control", mediaCacheControl)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	content, err := h.service.ReadMedia(r.Context(), id, thumbnail)
+	if err != nil {
+		w.Header().Del("Cache-Control")
+		w.Header().Del("ETag")
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	defer content.Body.Close()
+
+	w.Header().Set("Content-Type", content.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content.Body)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+
+	"github.com/golang/mock/gomock"
+)
+
+func multipartImage(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(field, "photo.png")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	part.Write(content)
+	mw.Close()
+	return &body, mw.FormDataContentType()
+}
+
+func TestMediaHandler_UploadMedia_Created(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockMediaService(ctrl)
+	h := &mediaHandler{service: svc}
+
+	svc.EXPECT().UploadMedia(gomock.Any(), int64(4), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, file io.Reader) (*model.Media, error) {
+			data, _ := io.ReadAll(file)
+			if string(data) != "image bytes" {
+				t.Errorf("service got %q", data)
+			}
+			return &model.Media{ID: "abc", URL: "/media/abc"}, nil
+		})
+
+	body, contentType := multipartImage(t, "file", []byte("image bytes"))
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/media", body), 4)
+	r.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+
+	h.UploadMedia(w, r)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"id":"abc"`) {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestMediaHandler_UploadMedia_Rejected(t *testing.T) {
+	h := &mediaHandler{}
+
+	wrongField, wrongType := multipartImage(t, "image", []byte("x"))
+	tooLarge, largeType := multipartImage(t, "file", make([]byte, model.MaxMediaBytes+2<<20))
+	cases := map[string]struct {
+		body        io.Reader
+		contentType string
+		want        int
+	}{
+		"not multipart": {strings.NewReader(`{"file":"x"}`), "application/json", http.StatusBadRequest},
+		"wrong field":   {wrongField, wrongType, http.StatusBadRequest},
+		"too large":     {tooLarge, largeType, http.StatusRequestEntityTooLarge},
+	}
+
+	for name, tc := range cases {
+		r := withUserCtx(httptest.NewRequest(http.MethodPost, "/media", tc.body), 4)
+		r.Header.Set("Content-Type", tc.contentType)
+		w := httptest.NewRecorder()
+
+		h.UploadMedia(w, r)
+		if w.Code != tc.want {
+			t.Errorf("%s: status=%d want=%d body=%s", name, w.Code, tc.want, w.Body.String())
+		}
+	}
+}
+
+func TestMediaHandler_ReadMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockMediaService(ctrl)
+	h := &mediaHandler{service: svc}
+
+	svc.EXPECT().ReadMedia(gomock.Any(), "abc", true).
+		Return(&model.MediaContent{ContentType: "image/png", Body: io.NopCloser(strings.NewReader("png"))}, nil)
+
+	r := setChiURLParam(httptest.NewRequest(http.MethodGet, "/media/abc?size=thumbnail", nil), "id", "abc")
+	w := httptest.NewRecorder()
+	h.ReadMedia(w, r)
+
+	if w.Code != http.StatusOK || w.Body.String() != "png" {
+		t.Fatalf("status=%d body=%q", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Fatalf("content type %q", got)
+	}
+	if got := w.Header().Get("Cache-Control"); got != mediaCacheControl {
+		t.Fatalf("cache control %q", got)
+	}
+	if got := w.Header().Get("ETag"); got != `"abc-thumbnail"` {
+		t.Fatalf("etag %q", got)
+	}
+}
+
+func TestMediaHandler_ReadMedia_NotModified(t *testing.T) {
+	// the service isn't asked, the client already has the file
+	h := &mediaHandler{}
+
+	r := setChiURLParam(httptest.NewRequest(http.MethodGet, "/media/abc", nil), "id", "abc")
+	r.Header.Set("If-None-Match", `"abc"`)
+	w := httptest.NewRecorder()
+	h.ReadMedia(w, r)
+
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("status=%d body=%q", w.Code, w.Body.String())
+	}
+}
+
+func TestMediaHandler_ReadMedia_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockMediaService(ctrl)
+	h := &mediaHandler{service: svc}
+	svc.EXPECT().ReadMedia(gomock.Any(), "missing", false).Return(nil, sql.ErrNoRows)
+
+	r := setChiURLParam(httptest.NewRequest(http.MethodGet, "/media/missing", nil), "id", "missing")
+	w := httptest.NewRecorder()
+	h.ReadMedia(w, r)
+	if w.Code != http.StatusNotFound || w.Header().Get("Cache-Control") != "" {
+		t.Fatalf("status=%d cache=%q, want an uncached 404", w.Code, w.Header().Get("Cache-Control"))
+	}
+
+	r = setChiURLParam(httptest.NewRequest(http.MethodGet, "/media/abc?size=huge", nil), "id", "abc")
+	w = httptest.NewRecorder()
+	h.ReadMedia(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/render"
+)
+
+type personalRecordHandler struct {
+	service service.PersonalRecordService
+}
+
+func NewPersonalRecordHandler(s service.PersonalRecordService) handler.PersonalRecordHandler {
+	return &personalRecordHandler{service: s}
+}
+
+// ReadUserRecords godoc
+// @Summary Get a user's current personal records
+// @Description Returns the current best per exercise and record type. Private profiles and hidden metrics are only visible to the owner, admins or permitted followers.
+// @Tags Records
+// @Produce json
+// @Param id path int true "User ID"
+// @Param formula query string false "Estimated 1RM formula (epley or brzycki)" default(epley)
+// @Success 200 {array} model.PersonalRecord "Records retrieved successfully"
+// @Failure 400 {object} model.BasicResponse "Invalid formula"
+// @Failure 403 {object} model.BasicResponse "Records are not visible to the caller"
+// @Security BearerAuth
+// @Router /users/{id}/records [get]
+func (h *personalRecordHandler) ReadUserRecords(w http.ResponseWriter, r *http.Request) {
+	viewerID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	records, err := h.service.ReadUserRecords(model.ReadRecordsRequest{
+		UserID:   id,
+		ViewerID: viewerID,
+		Formula:  r.URL.Query().Get("formula"),
+	})
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, records)
+}
+
+// ReadExerciseRecords godoc
+// @Summary Get the personal record history for an exercise
+// @Description Defaults to the caller's own records; pass userId to view another user's history, subject to their privacy settings.
+// @Tags Records
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Param userId query int false "Owner of the records (defaults to the caller)"
+// @Param formula query string false "Estimated 1RM formula (epley or brzycki)" default(epley)
+// @Success 200 {array} model.PersonalRecord "Record history retrieved successfully"
+// @Failure 400 {object} model.BasicResponse "Invalid query parameter"
+// @Failure 403 {object} model.BasicResponse "Records are not visible to the caller"
+// @Security BearerAuth
+// @Router /exercises/{id}/records [get]
+func (h *personalRecordHandler) ReadExerciseRecords(w http.ResponseWriter, r *http.Request) {
+	viewerID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	userID := viewerID
+	if raw := r.URL.Query().Get("userId"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: invalid userId", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		userID = parsed
+	}
+
+	records, err := h.service.ReadExerciseRecords(model.ReadRecordsRequest{
+		UserID:     userID,
+		ExerciseID: id,
+		ViewerID:   viewerID,
+		Formula:    r.URL.Query().Get("formula"),
+	})
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, records)
+}
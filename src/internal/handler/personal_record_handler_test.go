@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newPersonalRecordHandlerMocks(t *testing.T) (*mock_service.MockPersonalRecordService, *personalRecordHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockPersonalRecordService(ctrl)
+	return mockSvc, &personalRecordHandler{service: mockSvc}
+}
+
+func TestPersonalRecordHandler_ReadUserRecords_OK(t *testing.T) {
+	mockSvc, h := newPersonalRecordHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		ReadUserRecords(model.ReadRecordsRequest{UserID: 9, ViewerID: 7, Formula: "brzycki"}).
+		Return([]*model.PersonalRecord{{ID: 1, UserID: 9, Type: model.RecordTypeMaxWeight, Value: 100}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/9/records?formula=brzycki", nil)
+	r = withIDCtx(withUserCtx(r, 7), 9)
+
+	h.ReadUserRecords(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+	var got []model.PersonalRecord
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Value != 100 {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestPersonalRecordHandler_ReadUserRecords_Forbidden(t *testing.T) {
+	mockSvc, h := newPersonalRecordHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadUserRecords(gomock.Any()).
+		Return(nil, fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/9/records", nil)
+	r = withIDCtx(withUserCtx(r, 7), 9)
+
+	h.ReadUserRecords(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status=%d want=403", w.Code)
+	}
+}
+
+func TestPersonalRecordHandler_ReadExerciseRecords_DefaultsToCaller(t *testing.T) {
+	mockSvc, h := newPersonalRecordHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		ReadExerciseRecords(model.ReadRecordsRequest{UserID: 7, ExerciseID: 2, ViewerID: 7}).
+		Return([]*model.PersonalRecord{}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/exercises/2/records", nil)
+	r = withIDCtx(withUserCtx(r, 7), 2)
+
+	h.ReadExerciseRecords(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestPersonalRecordHandler_ReadExerciseRecords_OtherUser(t *testing.T) {
+	mockSvc, h := newPersonalRecordHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		ReadExerciseRecords(model.ReadRecordsRequest{UserID: 9, ExerciseID: 2, ViewerID: 7, Formula: "epley"}).
+		Return([]*model.PersonalRecord{}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/exercises/2/records?userId=9&formula=epley", nil)
+	r = withIDCtx(withUserCtx(r, 7), 2)
+
+	h.ReadExerciseRecords(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestPersonalRecordHandler_ReadExerciseRecords_BadUserID(t *testing.T) {
+	_, h := newPersonalRecordHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/exercises/2/records?userId=abc", nil)
+	r = withIDCtx(withUserCtx(r, 7), 2)
+
+	h.ReadExerciseRecords(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
 	"workoutpal/src/util"
@@ -19,6 +21,7 @@ func NewPostHandler(svc service.PostService) *PostHandler {
 
 // CreatePost godoc
 // @Summary Create a new post
+// @Description A post can share one of the caller's routines (routineId) or finished sessions (sessionId); the feed then shows a summary of it. Up to four of the caller's uploads from /media can be attached with mediaIds.
 // @Tags Posts
 // @Accept json
 // @Produce json
@@ -53,12 +56,17 @@ func (p *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 }
 
 // ReadPosts godoc
-// @Summary List posts
+// @Summary Read the home feed
+// @Description Pages through the feed, newest first or ranked by likes and comments weighed against age. The following scope (default) holds the caller's own posts and those of the users they follow; discover holds every post from a public profile. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.
 // @Tags Posts
 // @Accept json
 // @Produce json
-// @Param followings query bool false "If true, only posts from followed users are returned"
+// @Param scope query string false "following (default) or discover"
+// @Param sort query string false "recent (default) or ranked"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 20 and at most 100"
 // @Success 200 {array} model.Post "Posts retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
@@ -66,16 +74,36 @@ func (p *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 // @Router /posts [get]
 func (p *PostHandler) ReadPosts(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	query := r.URL.Query()
 
-	posts, err := p.svc.ReadPosts(userID)
+	req := model.ReadPostRequest{
+		ViewerID: userID,
+		Scope:    query.Get("scope"),
+		Sort:     query.Get("sort"),
+		Cursor:   query.Get("cursor"),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := p.svc.ReadPosts(req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
 		return
 	}
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
 
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(posts)
+	_ = json.NewEncoder(w).Encode(page.Posts)
 }
 
 // ReadPostsByUserID godoc
@@ -114,6 +142,7 @@ func (p *PostHandler) ReadPostsByUserID(w http.ResponseWriter, r *http.Request)
 // @Success 200 {object} model.BasicResponse "Comment added successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /posts/comment [post]
@@ -141,7 +170,8 @@ func (p *PostHandler) CommentOnPost(w http.ResponseWriter, r *http.Request) {
 }
 
 // LikePost godoc
-// @Summary Likes a post
+// @Summary React to a post
+// @Description The reaction is one of like (the default), fire, strong or clap. Reacting again replaces the caller's earlier reaction.
 // @Tags Posts
 // @Accept json
 // @Produce json
@@ -149,6 +179,7 @@ func (p *PostHandler) CommentOnPost(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} model.BasicResponse ""
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /posts/like [post]
@@ -176,7 +207,7 @@ func (p *PostHandler) LikePost(w http.ResponseWriter, r *http.Request) {
 }
 
 // UnlikePost godoc
-// @Summary Unlikes a post
+// @Summary Take back a reaction to a post
 // @Tags Posts
 // @Accept json
 // @Produce json
@@ -210,6 +241,56 @@ func (p *PostHandler) UnlikePost(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// ReadReactions godoc
+// @Summary List who reacted to a post
+// @Description Pages through the reactions, newest first, optionally only those of one type. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param type query string false "Only this reaction" Enums(like, fire, strong, clap)
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 50 and at most 100"
+// @Success 200 {array} model.Reaction "Reactions retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/reactions [get]
+func (p *PostHandler) ReadReactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := model.ReadReactionsRequest{
+		ViewerID: r.Context().Value(constants.USER_ID_KEY).(int64),
+		PostID:   r.Context().Value(constants.ID_KEY).(int64),
+		Type:     query.Get("type"),
+		Cursor:   query.Get("cursor"),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := p.svc.ReadReactions(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(page.Reactions)
+}
+
 // CommentOnComment godoc
 // @Summary Comment on another comment
 // @Tags Posts
@@ -219,6 +300,7 @@ func (p *PostHandler) UnlikePost(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} model.BasicResponse "Reply added successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /posts/comment/reply [post]
@@ -245,6 +327,241 @@ func (p *PostHandler) CommentOnComment(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// ReadComments godoc
+// @Summary List the comments on a post
+// @Description Pages through the top-level comments, oldest first. Each comes with up to two levels of replies, three per comment; replyCount and repliesCursor lead to the rest at /posts/comments/{id}/replies. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 20 and at most 100"
+// @Success 200 {array} model.Comment "Comments retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/comments [get]
+func (p *PostHandler) ReadComments(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	p.readCommentPage(w, r, model.ReadCommentsRequest{PostID: id})
+}
+
+// ReadReplies godoc
+// @Summary List the replies to a comment
+// @Description Pages through the direct replies, oldest first, each with up to two levels of its own replies. Paged like /posts/{id}/comments; a comment's repliesCursor continues after the replies it came with.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page, or a comment's repliesCursor"
+// @Param limit query int false "Page size, default 20 and at most 100"
+// @Success 200 {array} model.Comment "Replies retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
+// @Failure 404 {object} model.BasicResponse "Comment not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/comments/{id}/replies [get]
+func (p *PostHandler) ReadReplies(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	p.readCommentPage(w, r, model.ReadCommentsRequest{ParentID: id})
+}
+
+func (p *PostHandler) readCommentPage(w http.ResponseWriter, r *http.Request, req model.ReadCommentsRequest) {
+	query := r.URL.Query()
+	req.ViewerID = r.Context().Value(constants.USER_ID_KEY).(int64)
+	req.Cursor = query.Get("cursor")
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := p.svc.ReadComments(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(page.Comments)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Only the comment's author can edit it.
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Param request body model.UpdateCommentRequest true "New comment text"
+// @Success 200 {object} model.Comment "Comment updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not your comment"
+// @Failure 404 {object} model.BasicResponse "Comment not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/comments/{id} [patch]
+func (p *PostHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ID = id
+
+	comment, err := p.svc.UpdateComment(userID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Open to the comment's author, the post's author and admins. A comment with replies stays in the thread, marked deleted and blanked.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Comment ID"
+// @Success 200 {object} model.BasicResponse "Comment deleted successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not your comment"
+// @Failure 404 {object} model.BasicResponse "Comment not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/comments/{id} [delete]
+func (p *PostHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	if err := p.svc.DeleteComment(userID, id); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	resp := model.BasicResponse{Message: "Success"}
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// UpdatePost godoc
+// @Summary Edit a post
+// @Description Changes the fields that are set. The version it replaces is kept and listed by /posts/{id}/revisions. Only the author can edit a post.
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body model.UpdatePostRequest true "Fields to change"
+// @Success 200 {object} model.Post "Post updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not your post"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id} [patch]
+func (p *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.UpdatePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ID = id
+
+	post, err := p.svc.UpdatePost(userID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(post)
+}
+
+// ReadPostRevisions godoc
+// @Summary List the earlier versions of a post
+// @Description Newest first. Only the author can read them.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {array} model.PostRevision "Revisions retrieved successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not your post"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/revisions [get]
+func (p *PostHandler) ReadPostRevisions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	revisions, err := p.svc.ReadPostRevisions(userID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(revisions)
+}
+
+// CopyRoutine godoc
+// @Summary Copy the routine a post shares
+// @Description Saves the routine shared by the post, or followed by the session it shares, as a new routine of the caller. The owner's private custom exercises are left out.
+// @Tags Posts
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 201 {object} model.ExerciseRoutine "Routine copied successfully"
+// @Failure 400 {object} model.BasicResponse "The post doesn't share a routine"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Author's profile is private"
+// @Failure 404 {object} model.BasicResponse "Post not found"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/routine/copy [post]
+func (p *PostHandler) CopyRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	routine, err := p.svc.CopyRoutine(userID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(routine)
+}
+
 // DeletePost godoc
 // @Summary Delete a post
 // @Tags Posts
@@ -254,14 +571,16 @@ func (p *PostHandler) CommentOnComment(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} model.BasicResponse "Post deleted successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
 // @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 403 {object} model.BasicResponse "Not your post"
 // @Failure 404 {object} model.BasicResponse "Post not found"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /posts/{id} [delete]
 func (p *PostHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	id := r.Context().Value(constants.ID_KEY).(int64)
 
-	if err := p.svc.DeletePost(id); err != nil {
+	if err := p.svc.DeletePost(userID, id); err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
 		return
@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"workoutpal/src/internal/model"
 	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 )
 
@@ -103,7 +106,7 @@ func TestPostHandler_ReadPosts_ServiceError(t *testing.T) {
 
 	userID := int64(42)
 
-	svc.EXPECT().ReadPosts(userID).Return(nil, errors.New("read failed"))
+	svc.EXPECT().ReadPosts(model.ReadPostRequest{ViewerID: userID}).Return(nil, errors.New("read failed"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/posts", nil)
@@ -125,10 +128,11 @@ func TestPostHandler_ReadPosts_OK(t *testing.T) {
 	userID := int64(42)
 	want := []*model.Post{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}
 
-	svc.EXPECT().ReadPosts(userID).Return(want, nil)
+	svc.EXPECT().ReadPosts(model.ReadPostRequest{ViewerID: userID, Scope: "discover", Sort: "ranked", Cursor: "abc", Limit: 2}).
+		Return(&model.PostPage{Posts: want, NextCursor: "def"}, nil)
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	r := httptest.NewRequest(http.MethodGet, "/posts?scope=discover&sort=ranked&cursor=abc&limit=2", nil)
 	r = r.WithContext(context.WithValue(r.Context(), constants.USER_ID_KEY, userID))
 
 	h.ReadPosts(w, r)
@@ -137,6 +141,10 @@ func TestPostHandler_ReadPosts_OK(t *testing.T) {
 		t.Fatalf("status = %d, want 200", w.Code)
 	}
 
+	if w.Header().Get(constants.NEXT_CURSOR_HEADER) != "def" {
+		t.Fatalf("next cursor = %q, want def", w.Header().Get(constants.NEXT_CURSOR_HEADER))
+	}
+
 	var got []model.Post
 	_ = json.NewDecoder(w.Body).Decode(&got)
 	if len(got) != len(want) {
@@ -357,6 +365,26 @@ func TestPostHandler_LikePost_OK(t *testing.T) {
 	}
 }
 
+func TestPostHandler_LikePost_UserFromToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	// a userId in the body is ignored
+	svc.EXPECT().LikePost(model.LikePostRequest{PostID: 1, UserID: 42, Reaction: "fire"}).Return(&model.Post{ID: 1}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/posts/like", strings.NewReader(`{"postId":1,"userId":99,"reaction":"fire"}`))
+	r = withUserCtx(r, 42)
+
+	h.LikePost(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
+
 func TestPostHandler_UnlikePost_BadJSON(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -436,12 +464,14 @@ func TestPostHandler_DeletePost_OK(t *testing.T) {
 	svc := mock_service.NewMockPostService(ctrl)
 	h := &PostHandler{svc: svc}
 
+	userID := int64(7)
 	postID := int64(10)
-	svc.EXPECT().DeletePost(postID).Return(nil)
+	svc.EXPECT().DeletePost(userID, postID).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/posts/10", nil)
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, postID))
+	r = withUserCtx(r, userID)
 
 	h.DeletePost(w, r)
 
@@ -456,12 +486,14 @@ func TestPostHandler_DeletePost_Error(t *testing.T) {
 	svc := mock_service.NewMockPostService(ctrl)
 	h := &PostHandler{svc: svc}
 
+	userID := int64(7)
 	postID := int64(10)
-	svc.EXPECT().DeletePost(postID).Return(errors.New("not found"))
+	svc.EXPECT().DeletePost(userID, postID).Return(errors.New("not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/posts/10", nil)
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, postID))
+	r = withUserCtx(r, userID)
 
 	h.DeletePost(w, r)
 
@@ -470,6 +502,322 @@ func TestPostHandler_DeletePost_Error(t *testing.T) {
 	}
 }
 
+func TestPostHandler_DeletePost_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	userID := int64(7)
+	postID := int64(10)
+	svc.EXPECT().DeletePost(userID, postID).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/posts/10", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, postID))
+	r = withUserCtx(r, userID)
+
+	h.DeletePost(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
+
+func TestPostHandler_UpdatePost_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	userID := int64(7)
+	postID := int64(10)
+	svc.EXPECT().
+		UpdatePost(userID, gomock.AssignableToTypeOf(model.UpdatePostRequest{})).
+		DoAndReturn(func(_ int64, req model.UpdatePostRequest) (*model.Post, error) {
+			if req.ID != postID || req.Title == nil || *req.Title != "Leg day" || req.Body != nil {
+				t.Fatalf("unexpected request %+v", req)
+			}
+			return &model.Post{ID: postID, Title: "Leg day", Edited: true}, nil
+		})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/10", mustJSONString(t, `{"title":"Leg day"}`))
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, postID))
+	r = withUserCtx(r, userID)
+
+	h.UpdatePost(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var got model.Post
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.Edited || got.Title != "Leg day" {
+		t.Fatalf("unexpected post %+v", got)
+	}
+}
+
+func TestPostHandler_UpdatePost_BadJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/10", mustJSONString(t, "{"))
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.UpdatePost(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestPostHandler_UpdatePost_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().
+		UpdatePost(int64(7), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: only the author can edit this post", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/10", mustJSONString(t, `{"caption":"x"}`))
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.UpdatePost(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
+
+func TestPostHandler_ReadPostRevisions_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().ReadPostRevisions(int64(7), int64(10)).
+		Return([]*model.PostRevision{{ID: 1, PostID: 10, Title: "Before"}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/posts/10/revisions", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.ReadPostRevisions(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var got []model.PostRevision
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Before" {
+		t.Fatalf("unexpected revisions %+v", got)
+	}
+}
+
+func TestPostHandler_ReadComments_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().ReadComments(model.ReadCommentsRequest{ViewerID: 7, PostID: 10, Cursor: "abc", Limit: 5}).
+		Return(&model.CommentPage{Comments: []*model.Comment{{ID: 1, Replies: []*model.Comment{}}}, NextCursor: "next"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/posts/10/comments?cursor=abc&limit=5", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.ReadComments(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get(constants.NEXT_CURSOR_HEADER); got != "next" {
+		t.Fatalf("next cursor header = %q", got)
+	}
+	var got []model.Comment
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("unexpected comments %+v", got)
+	}
+}
+
+func TestPostHandler_ReadReactions_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().ReadReactions(model.ReadReactionsRequest{ViewerID: 7, PostID: 10, Type: "clap", Cursor: "abc", Limit: 5}).
+		Return(&model.ReactionPage{Reactions: []*model.Reaction{{UserID: 3, Username: "sam", Reaction: "clap"}}, NextCursor: "next"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/posts/10/reactions?type=clap&cursor=abc&limit=5", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.ReadReactions(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get(constants.NEXT_CURSOR_HEADER); got != "next" {
+		t.Fatalf("next cursor header = %q", got)
+	}
+	var got []model.Reaction
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Username != "sam" {
+		t.Fatalf("unexpected reactions %+v", got)
+	}
+}
+
+func TestPostHandler_ReadReplies_BadLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/posts/comments/3/replies?limit=many", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(3)))
+	r = withUserCtx(r, 7)
+
+	h.ReadReplies(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestPostHandler_ReadReplies_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().ReadComments(model.ReadCommentsRequest{ViewerID: 7, ParentID: 3}).
+		Return(&model.CommentPage{Comments: []*model.Comment{}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/posts/comments/3/replies", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(3)))
+	r = withUserCtx(r, 7)
+
+	h.ReadReplies(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if w.Header().Get(constants.NEXT_CURSOR_HEADER) != "" {
+		t.Fatalf("the last page has no cursor")
+	}
+}
+
+func TestPostHandler_UpdateComment_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().UpdateComment(int64(7), model.UpdateCommentRequest{ID: 3, Comment: "Fixed"}).
+		Return(&model.Comment{ID: 3, Comment: "Fixed", Edited: true}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/comments/3", mustJSONString(t, `{"comment":"Fixed"}`))
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(3)))
+	r = withUserCtx(r, 7)
+
+	h.UpdateComment(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
+
+func TestPostHandler_UpdateComment_BadJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/comments/3", mustJSONString(t, "{"))
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(3)))
+	r = withUserCtx(r, 7)
+
+	h.UpdateComment(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestPostHandler_DeleteComment_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().DeleteComment(int64(7), int64(3)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/posts/comments/3", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(3)))
+	r = withUserCtx(r, 7)
+
+	h.DeleteComment(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
+
+func TestPostHandler_CopyRoutine_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	svc := mock_service.NewMockPostService(ctrl)
+	h := &PostHandler{svc: svc}
+
+	svc.EXPECT().CopyRoutine(int64(7), int64(10)).Return(&model.ExerciseRoutine{ID: 12, UserID: 7, Name: "Legs"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/posts/10/routine/copy", nil)
+	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, int64(10)))
+	r = withUserCtx(r, 7)
+
+	h.CopyRoutine(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", w.Code)
+	}
+	var got model.ExerciseRoutine
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != 12 || got.UserID != 7 {
+		t.Fatalf("unexpected routine %+v", got)
+	}
+}
+
 func TestPostHandler_ReadPostsByUserID_ServiceError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
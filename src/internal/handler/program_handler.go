@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/render"
+)
+
+type programHandler struct {
+	service service.ProgramService
+}
+
+func NewProgramHandler(s service.ProgramService) handler.ProgramHandler {
+	return &programHandler{service: s}
+}
+
+// CreateProgram godoc
+// @Summary Create a training program
+// @Description Weeks run in the order they are sent. Each week maps days of the week (0 = Sunday) to one of the caller's routines, with a loadType of percent (of the estimated 1RM, above 0 to 100) or rpe (1 to 10). Visibility is private, followers or public, private by default.
+// @Tags Programs
+// @Accept json
+// @Produce json
+// @Param request body model.CreateProgramRequest true "Program"
+// @Success 201 {object} model.Program "Program created"
+// @Failure 400 {object} model.BasicResponse "Invalid weeks, days or loads"
+// @Failure 403 {object} model.BasicResponse "A routine is not the caller's"
+// @Security BearerAuth
+// @Router /programs [post]
+func (h *programHandler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.CreateProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	program, err := h.service.CreateProgram(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, program)
+}
+
+// ReadProgram godoc
+// @Summary Read a training program
+// @Description Programs are shared like routines: private ones with their author, followers ones with followers and public ones with anyone who can see the author's profile.
+// @Tags Programs
+// @Produce json
+// @Param id path int true "Program ID"
+// @Success 200 {object} model.Program
+// @Failure 403 {object} model.BasicResponse "The program is not shared with the caller"
+// @Failure 404 {object} model.BasicResponse "Program not found"
+// @Security BearerAuth
+// @Router /programs/{id} [get]
+func (h *programHandler) ReadProgram(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	program, err := h.service.ReadProgram(actorID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, program)
+}
+
+// DeleteProgram godoc
+// @Summary Delete a training program
+// @Description Cancels the active enrollments in the program; enrollees keep them in their list, without a programId. Schedules already generated for enrollees are kept.
+// @Tags Programs
+// @Produce json
+// @Param id path int true "Program ID"
+// @Success 200 {object} model.BasicResponse "Program deleted"
+// @Failure 403 {object} model.BasicResponse "Not the caller's program"
+// @Failure 404 {object} model.BasicResponse "Program not found"
+// @Security BearerAuth
+// @Router /programs/{id} [delete]
+func (h *programHandler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	if err := h.service.DeleteProgram(actorID, id); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Program deleted"})
+}
+
+// Enroll godoc
+// @Summary Enroll in a training program
+// @Description Copies the program's routines into the caller's account, without crediting them as forks, and schedules week 1, starting on startsOn (today by default), at the time slot in the time zone. Later weeks are scheduled by a background job shortly after they start.
+// @Tags Programs
+// @Accept json
+// @Produce json
+// @Param id path int true "Program ID"
+// @Param request body model.EnrollRequest true "When to train"
+// @Success 201 {object} model.ProgramEnrollment "Enrolled"
+// @Failure 400 {object} model.BasicResponse "Invalid start date, time slot, length or time zone"
+// @Failure 403 {object} model.BasicResponse "The program is not shared with the caller"
+// @Failure 404 {object} model.BasicResponse "Program not found"
+// @Failure 409 {object} model.BasicResponse "Already enrolled, or week 1 overlaps another schedule"
+// @Security BearerAuth
+// @Router /programs/{id}/enroll [post]
+func (h *programHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.EnrollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ProgramID = id
+
+	enrollment, err := h.service.Enroll(actorID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, enrollment)
+}
+
+// ReadEnrollments godoc
+// @Summary List the caller's program enrollments
+// @Description Newest first. Reading changes nothing: a background job schedules each week shortly after it starts, skipping days that overlap another schedule, and completes enrollments past their last week. Active enrollments list the current week's days, with target weights for percentage loads on exercises the caller has an estimated 1RM for.
+// @Tags Programs
+// @Produce json
+// @Success 200 {array} model.ProgramEnrollment
+// @Security BearerAuth
+// @Router /programs/enrollments [get]
+func (h *programHandler) ReadEnrollments(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	enrollments, err := h.service.ReadEnrollments(actorID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, enrollments)
+}
+
+// CancelEnrollment godoc
+// @Summary Leave a training program
+// @Description Removes the current week's schedules from today on. Earlier ones and the copied routines are kept.
+// @Tags Programs
+// @Produce json
+// @Param id path int true "Enrollment ID"
+// @Success 200 {object} model.BasicResponse "Enrollment cancelled"
+// @Failure 403 {object} model.BasicResponse "Not the caller's enrollment"
+// @Failure 404 {object} model.BasicResponse "Enrollment not found"
+// @Failure 409 {object} model.BasicResponse "The enrollment has already ended"
+// @Security BearerAuth
+// @Router /programs/enrollments/{id} [delete]
+func (h *programHandler) CancelEnrollment(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	if err := h.service.CancelEnrollment(actorID, id); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, model.BasicResponse{Message: "Enrollment cancelled"})
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newProgramHandlerMocks(t *testing.T) (*mock_service.MockProgramService, *programHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockProgramService(ctrl)
+	return mockSvc, &programHandler{service: mockSvc}
+}
+
+func TestProgramHandler_CreateProgram_Created(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		CreateProgram(int64(1), model.CreateProgramRequest{Name: "5/3/1", Weeks: []*model.ProgramWeek{
+			{Days: []*model.ProgramDay{{DayOfWeek: 1, RoutineID: 10, LoadType: "percent", Load: 65}}},
+		}}).
+		Return(&model.Program{ID: 7, UserID: 1, Name: "5/3/1"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/programs", strings.NewReader(`{"name":"5/3/1","weeks":[{"days":[{"dayOfWeek":1,"routineId":10,"loadType":"percent","load":65}]}]}`))
+	r = withUserCtx(r, 1)
+
+	h.CreateProgram(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+	var got model.Program
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != 7 {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestProgramHandler_CreateProgram_BadBody(t *testing.T) {
+	_, h := newProgramHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/programs", strings.NewReader(`{`))
+	r = withUserCtx(r, 1)
+
+	h.CreateProgram(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestProgramHandler_ReadProgram_Forbidden(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadProgram(int64(2), int64(7)).Return(nil, fmt.Errorf("%w: this program is private", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/programs/7", nil)
+	r = withIDCtx(withUserCtx(r, 2), 7)
+
+	h.ReadProgram(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status=%d want=403", w.Code)
+	}
+}
+
+func TestProgramHandler_Enroll_Created(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		Enroll(int64(2), model.EnrollRequest{ProgramID: 7, StartsOn: "2025-03-10", TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "Europe/Berlin"}).
+		Return(&model.ProgramEnrollment{ID: 90, ProgramID: 7, CurrentWeek: 1}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/programs/7/enroll", strings.NewReader(`{"startsOn":"2025-03-10","timeSlot":"07:00","routineLengthMinutes":60,"timezone":"Europe/Berlin"}`))
+	r = withIDCtx(withUserCtx(r, 2), 7)
+
+	h.Enroll(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+}
+
+func TestProgramHandler_Enroll_Conflict(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().Enroll(int64(2), gomock.Any()).Return(nil, fmt.Errorf("%w: you are already enrolled in this program", util.ErrConflict))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/programs/7/enroll", strings.NewReader(`{"timeSlot":"07:00","routineLengthMinutes":60}`))
+	r = withIDCtx(withUserCtx(r, 2), 7)
+
+	h.Enroll(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status=%d want=409", w.Code)
+	}
+}
+
+func TestProgramHandler_ReadEnrollments(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadEnrollments(int64(2)).Return([]*model.ProgramEnrollment{{ID: 90, Days: []*model.EnrolledDay{{Date: "2025-03-07"}}}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/programs/enrollments", nil)
+	r = withUserCtx(r, 2)
+
+	h.ReadEnrollments(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+	var got []*model.ProgramEnrollment
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || len(got[0].Days) != 1 {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestProgramHandler_CancelEnrollment(t *testing.T) {
+	mockSvc, h := newProgramHandlerMocks(t)
+
+	mockSvc.EXPECT().CancelEnrollment(int64(2), int64(90)).Return(nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/programs/enrollments/90", nil)
+	r = withIDCtx(withUserCtx(r, 2), 90)
+
+	h.CancelEnrollment(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
 	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -83,9 +84,9 @@ func (h *relationshipHandler) ReadFollowings(w http.ResponseWriter, r *http.Requ
 // @Tags Relationships
 // @Produce json
 // @Param id path int true "User ID to follow"
-// @Param follower_id query int true "Follower user ID"
 // @Success 200 {object} model.BasicResponse "Successfully followed user"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
+// @Failure 403 {object} model.BasicResponse "Profile is private, send a follow request"
 // @Router /users/{id}/follow [post]
 func (h *relationshipHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
 	followeeIDStr := chi.URLParam(r, "id")
@@ -96,13 +97,7 @@ func (h *relationshipHandler) FollowUser(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	followerIDStr := r.URL.Query().Get("follower_id")
-	followerID, err := strconv.ParseInt(followerIDStr, 10, 64)
-	if err != nil {
-		responseErr := util.Error(err, r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	followerID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	err = h.relationshipService.FollowUser(followerID, followeeID)
 	if err != nil {
@@ -119,7 +114,6 @@ func (h *relationshipHandler) FollowUser(w http.ResponseWriter, r *http.Request)
 // @Tags Relationships
 // @Produce json
 // @Param id path int true "User ID to unfollow"
-// @Param follower_id query int true "Follower user ID"
 // @Success 200 {object} model.BasicResponse "Successfully unfollowed user"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
 // @Router /users/{id}/unfollow [post]
@@ -132,13 +126,7 @@ func (h *relationshipHandler) UnfollowUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	followerIDStr := r.URL.Query().Get("follower_id")
-	followerID, err := strconv.ParseInt(followerIDStr, 10, 64)
-	if err != nil {
-		responseErr := util.Error(err, r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	followerID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	err = h.relationshipService.UnfollowUser(followerID, followeeID)
 	if err != nil {
@@ -155,7 +143,6 @@ func (h *relationshipHandler) UnfollowUser(w http.ResponseWriter, r *http.Reques
 // @Tags Relationships
 // @Produce json
 // @Param id path int true "User ID to request to follow"
-// @Param requester_id query int true "Requester user ID"
 // @Success 200 {object} model.BasicResponse "Follow request sent successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
 // @Router /users/{id}/follow-request [post]
@@ -168,13 +155,7 @@ func (h *relationshipHandler) SendFollowRequest(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	requesterIDStr := r.URL.Query().Get("requester_id")
-	requesterID, err := strconv.ParseInt(requesterIDStr, 10, 64)
-	if err != nil {
-		responseErr := util.Error(err, r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	requesterID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	err = h.relationshipService.SendFollowRequest(requesterID, requestedID)
 	if err != nil {
@@ -194,13 +175,7 @@ func (h *relationshipHandler) SendFollowRequest(w http.ResponseWriter, r *http.R
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Router /follow-requests [get]
 func (h *relationshipHandler) GetPendingFollowRequests(w http.ResponseWriter, r *http.Request) {
-	userIDStr := r.URL.Query().Get("user_id")
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
-	if err != nil {
-		responseErr := util.Error(err, r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	requests, err := h.relationshipService.GetPendingFollowRequests(userID)
 	if err != nil {
@@ -225,8 +200,10 @@ func (h *relationshipHandler) GetPendingFollowRequests(w http.ResponseWriter, r
 // @Param request body model.FollowRequestResponse true "Follow request response"
 // @Success 200 {object} model.BasicResponse "Follow request processed successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid request"
+// @Failure 403 {object} model.BasicResponse "Request was sent to another user"
 // @Router /follow-requests/respond [post]
 func (h *relationshipHandler) RespondToFollowRequest(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	var req model.FollowRequestResponse
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		responseErr := util.Error(err, r.URL.Path)
@@ -236,9 +213,9 @@ func (h *relationshipHandler) RespondToFollowRequest(w http.ResponseWriter, r *h
 
 	var err error
 	if req.Action == "accept" {
-		err = h.relationshipService.AcceptFollowRequest(req.RequestID)
+		err = h.relationshipService.AcceptFollowRequest(userID, req.RequestID)
 	} else if req.Action == "reject" {
-		err = h.relationshipService.RejectFollowRequest(req.RequestID)
+		err = h.relationshipService.RejectFollowRequest(userID, req.RequestID)
 	} else {
 		render.Status(r, http.StatusBadRequest)
 		render.JSON(w, r, model.BasicResponse{Message: "Invalid action. Use 'accept' or 'reject'"})
@@ -259,7 +236,6 @@ func (h *relationshipHandler) RespondToFollowRequest(w http.ResponseWriter, r *h
 // @Tags Relationships
 // @Produce json
 // @Param id path int true "User ID whose request to cancel"
-// @Param requester_id query int true "Requester user ID"
 // @Success 200 {object} model.BasicResponse "Follow request cancelled successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
 // @Router /users/{id}/follow-request [delete]
@@ -272,13 +248,7 @@ func (h *relationshipHandler) CancelFollowRequest(w http.ResponseWriter, r *http
 		return
 	}
 
-	requesterIDStr := r.URL.Query().Get("requester_id")
-	requesterID, err := strconv.ParseInt(requesterIDStr, 10, 64)
-	if err != nil {
-		responseErr := util.Error(err, r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	requesterID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	err = h.relationshipService.CancelFollowRequest(requesterID, requestedID)
 	if err != nil {
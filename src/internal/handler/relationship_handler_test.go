@@ -6,7 +6,6 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -176,7 +175,7 @@ func TestRelationshipHandler_FollowUser_BadFolloweeID(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/users/abc/follow?follower_id=1", nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/abc/follow", nil)
 	r = withChiURLParam(r, "id", "abc")
 
 	h.FollowUser(w, r)
@@ -185,7 +184,7 @@ func TestRelationshipHandler_FollowUser_BadFolloweeID(t *testing.T) {
 	}
 }
 
-func TestRelationshipHandler_FollowUser_BadFollowerID(t *testing.T) {
+func TestRelationshipHandler_FollowUser_IgnoresFollowerIDQuery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
@@ -193,12 +192,15 @@ func TestRelationshipHandler_FollowUser_BadFollowerID(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/users/2/follow?follower_id=bad", nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/2/follow?follower_id=99", nil)
 	r = withChiURLParam(r, "id", "2")
+	r = withUserCtx(r, 4)
+
+	mockSvc.EXPECT().FollowUser(int64(4), int64(2)).Return(nil)
 
 	h.FollowUser(w, r)
-	if w.Code != http.StatusInternalServerError {
-		t.Fatalf("status = %d, want 500", w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
 	}
 }
 
@@ -210,10 +212,9 @@ func TestRelationshipHandler_FollowUser_ServiceError(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	q := url.Values{}
-	q.Set("follower_id", "1")
-	r := httptest.NewRequest(http.MethodPost, "/users/2/follow?"+q.Encode(), nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/2/follow", nil)
 	r = withChiURLParam(r, "id", "2")
+	r = withUserCtx(r, 1)
 
 	mockSvc.EXPECT().FollowUser(int64(1), int64(2)).Return(errors.New("already following"))
 
@@ -231,10 +232,9 @@ func TestRelationshipHandler_FollowUser_OK(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	q := url.Values{}
-	q.Set("follower_id", "3")
-	r := httptest.NewRequest(http.MethodPost, "/users/5/follow?"+q.Encode(), nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/5/follow", nil)
 	r = withChiURLParam(r, "id", "5")
+	r = withUserCtx(r, 3)
 
 	mockSvc.EXPECT().FollowUser(int64(3), int64(5)).Return(nil)
 
@@ -259,7 +259,7 @@ func TestRelationshipHandler_UnfollowUser_BadFolloweeID(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/users/abc/unfollow?follower_id=1", nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/abc/unfollow", nil)
 	r = withChiURLParam(r, "id", "abc")
 
 	h.UnfollowUser(w, r)
@@ -268,7 +268,7 @@ func TestRelationshipHandler_UnfollowUser_BadFolloweeID(t *testing.T) {
 	}
 }
 
-func TestRelationshipHandler_UnfollowUser_BadFollowerID(t *testing.T) {
+func TestRelationshipHandler_UnfollowUser_IgnoresFollowerIDQuery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
@@ -276,12 +276,15 @@ func TestRelationshipHandler_UnfollowUser_BadFollowerID(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodPost, "/users/2/unfollow?follower_id=x", nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/2/unfollow?follower_id=99", nil)
 	r = withChiURLParam(r, "id", "2")
+	r = withUserCtx(r, 4)
+
+	mockSvc.EXPECT().UnfollowUser(int64(4), int64(2)).Return(nil)
 
 	h.UnfollowUser(w, r)
-	if w.Code != http.StatusInternalServerError {
-		t.Fatalf("status = %d, want 500", w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
 	}
 }
 
@@ -293,10 +296,9 @@ func TestRelationshipHandler_UnfollowUser_ServiceError(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	q := url.Values{}
-	q.Set("follower_id", "8")
-	r := httptest.NewRequest(http.MethodPost, "/users/9/unfollow?"+q.Encode(), nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/9/unfollow", nil)
 	r = withChiURLParam(r, "id", "9")
+	r = withUserCtx(r, 8)
 
 	mockSvc.EXPECT().UnfollowUser(int64(8), int64(9)).Return(errors.New("not following"))
 
@@ -314,10 +316,9 @@ func TestRelationshipHandler_UnfollowUser_OK(t *testing.T) {
 	h := &relationshipHandler{relationshipService: mockSvc}
 
 	w := httptest.NewRecorder()
-	q := url.Values{}
-	q.Set("follower_id", "10")
-	r := httptest.NewRequest(http.MethodPost, "/users/11/unfollow?"+q.Encode(), nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/11/unfollow", nil)
 	r = withChiURLParam(r, "id", "11")
+	r = withUserCtx(r, 10)
 
 	mockSvc.EXPECT().UnfollowUser(int64(10), int64(11)).Return(nil)
 
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"workoutpal/src/internal/domain/handler"
@@ -26,6 +27,7 @@ func NewRoutineHandler(rs service.RoutineService) handler.RoutineHandler {
 
 // CreateUserRoutine godoc
 // @Summary Create a workout routine for user
+// @Description visibility is private (the default), followers or public.
 // @Tags Routines
 // @Accept json
 // @Produce json
@@ -33,10 +35,12 @@ func NewRoutineHandler(rs service.RoutineService) handler.RoutineHandler {
 // @Param request body model.CreateRoutineRequest true "Routine payload"
 // @Success 201 {object} model.ExerciseRoutine "Routine created successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Not your account"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id}/routines [post]
 func (h *workoutHandler) CreateUserRoutine(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	var req model.CreateRoutineRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -45,7 +49,7 @@ func (h *workoutHandler) CreateUserRoutine(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	routine, err := h.routineService.CreateRoutine(id, req)
+	routine, err := h.routineService.CreateRoutine(actorID, id, req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -58,17 +62,20 @@ func (h *workoutHandler) CreateUserRoutine(w http.ResponseWriter, r *http.Reques
 
 // ReadUserRoutines godoc
 // @Summary Get all routines for a user
+// @Description Other users only get the routines shared with them: public ones, and followers' ones when they follow the owner.
 // @Tags Routines
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {array} model.ExerciseRoutine "Routines retrieved successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
+// @Failure 403 {object} model.BasicResponse "Profile is private"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id}/routines [get]
 func (h *workoutHandler) ReadUserRoutines(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
-	routines, err := h.routineService.ReadUserRoutines(id)
+	routines, err := h.routineService.ReadUserRoutines(actorID, id)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -78,6 +85,74 @@ func (h *workoutHandler) ReadUserRoutines(w http.ResponseWriter, r *http.Request
 	render.JSON(w, r, routines)
 }
 
+// ReadPublicRoutines godoc
+// @Summary Browse public routines
+// @Description Lists routines shared with everyone by users with public profiles, with their exercises, owner and fork count. The cursor for the next page is returned in the X-Next-Cursor header and is absent on the last page.
+// @Tags Routines
+// @Produce json
+// @Param search query string false "Text searched for in the name and description"
+// @Param sort query string false "newest (default) or popular, the most forked first"
+// @Param cursor query string false "Cursor from the X-Next-Cursor header of the previous page"
+// @Param limit query int false "Page size, default 20 and at most 100"
+// @Success 200 {array} model.ExerciseRoutine "Routines retrieved successfully"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Router /routines/public [get]
+func (h *workoutHandler) ReadPublicRoutines(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := model.ReadPublicRoutinesRequest{
+		Search: query.Get("search"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: limit must be a number", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.Limit = limit
+	}
+
+	page, err := h.routineService.ReadPublicRoutines(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	if page.NextCursor != "" {
+		w.Header().Set(constants.NEXT_CURSOR_HEADER, page.NextCursor)
+	}
+	render.JSON(w, r, page.Routines)
+}
+
+// ForkRoutine godoc
+// @Summary Fork a routine
+// @Description Copies a routine the caller can see into their account as a private routine: its exercises, targets and the owner's exercise settings. The copy credits the original in forkedFrom and the original's forkCount goes up. The owner's private custom exercises are left out.
+// @Tags Routines
+// @Produce json
+// @Param id path int true "Routine ID"
+// @Success 201 {object} model.ExerciseRoutine "Routine forked successfully"
+// @Failure 400 {object} model.BasicResponse "Invalid routine ID"
+// @Failure 403 {object} model.BasicResponse "The routine isn't shared with the caller"
+// @Failure 404 {object} model.BasicResponse "Routine not found"
+// @Router /routines/{id}/fork [post]
+func (h *workoutHandler) ForkRoutine(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	routine, err := h.routineService.ForkRoutine(actorID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, routine)
+}
+
 // DeleteRoutine godoc
 // @Summary Delete a routine
 // @Tags Routines
@@ -85,12 +160,14 @@ func (h *workoutHandler) ReadUserRoutines(w http.ResponseWriter, r *http.Request
 // @Param id path int true "Routine ID"
 // @Success 200 {object} model.BasicResponse "Routine deleted successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid routine ID"
+// @Failure 403 {object} model.BasicResponse "Not your routine"
 // @Failure 404 {object} model.BasicResponse "Routine not found"
 // @Router /routines/{id} [delete]
 func (h *workoutHandler) DeleteRoutine(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
-	err := h.routineService.DeleteRoutine(id)
+	err := h.routineService.DeleteRoutine(actorID, id)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -107,12 +184,48 @@ func (h *workoutHandler) DeleteRoutine(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "Routine ID"
 // @Success 200 {object} model.ExerciseRoutine "Routine with exercises retrieved successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid routine ID"
+// @Failure 403 {object} model.BasicResponse "The routine isn't shared with the caller"
 // @Failure 404 {object} model.BasicResponse "Routine not found"
 // @Router /routines/{id} [get]
 func (h *workoutHandler) ReadRoutineWithExercises(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
-	routine, err := h.routineService.ReadRoutineWithExercises(id)
+	routine, err := h.routineService.ReadRoutineWithExercises(actorID, id)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, routine)
+}
+
+// UpdateRoutine godoc
+// @Summary Update a routine
+// @Description Changes the name, description and visibility that are sent. exercises, when sent, replaces the routine's exercises in the order given, each with optional targets: targetSets, repsMin, repsMax, restSeconds and supersetGroup. Exercises in the same superset must be next to each other. Everything is applied together or not at all.
+// @Tags Routines
+// @Accept json
+// @Produce json
+// @Param id path int true "Routine ID"
+// @Param request body model.UpdateRoutineRequest true "Fields to change"
+// @Success 200 {object} model.ExerciseRoutine "Routine updated successfully"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Not your routine, or an exercise is another user's private exercise"
+// @Router /routines/{id} [patch]
+func (h *workoutHandler) UpdateRoutine(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.UpdateRoutineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ID = id
+
+	routine, err := h.routineService.UpdateRoutine(actorID, req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -124,6 +237,7 @@ func (h *workoutHandler) ReadRoutineWithExercises(w http.ResponseWriter, r *http
 
 // AddExerciseToRoutine godoc
 // @Summary Add exercise to routine
+// @Description The exercise goes last, without targets.
 // @Tags Routines
 // @Accept json
 // @Produce json
@@ -131,9 +245,11 @@ func (h *workoutHandler) ReadRoutineWithExercises(w http.ResponseWriter, r *http
 // @Param exercise_id query int true "Exercise ID"
 // @Success 200 {object} model.BasicResponse "Exercise added to routine successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid ID"
+// @Failure 403 {object} model.BasicResponse "Not your routine, or the exercise is another user's private exercise"
 // @Router /routines/{id}/exercises [post]
 func (h *workoutHandler) AddExerciseToRoutine(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	exerciseIDStr := r.URL.Query().Get("exercise_id")
 	exerciseID, err := strconv.ParseInt(exerciseIDStr, 10, 64)
@@ -143,7 +259,7 @@ func (h *workoutHandler) AddExerciseToRoutine(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.routineService.AddExerciseToRoutine(id, exerciseID)
+	err = h.routineService.AddExerciseToRoutine(actorID, id, exerciseID)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -161,9 +277,11 @@ func (h *workoutHandler) AddExerciseToRoutine(w http.ResponseWriter, r *http.Req
 // @Param exercise_id path int true "Exercise ID"
 // @Success 200 {object} model.BasicResponse "Exercise removed from routine successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid ID"
+// @Failure 403 {object} model.BasicResponse "Not your routine"
 // @Router /routines/{id}/exercises/{exercise_id} [delete]
 func (h *workoutHandler) RemoveExerciseFromRoutine(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	exerciseIDStr := chi.URLParam(r, "exercise_id")
 	exerciseID, err := strconv.ParseInt(exerciseIDStr, 10, 64)
@@ -173,7 +291,7 @@ func (h *workoutHandler) RemoveExerciseFromRoutine(w http.ResponseWriter, r *htt
 		return
 	}
 
-	err = h.routineService.RemoveExerciseFromRoutine(id, exerciseID)
+	err = h.routineService.RemoveExerciseFromRoutine(actorID, id, exerciseID)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -183,6 +301,39 @@ func (h *workoutHandler) RemoveExerciseFromRoutine(w http.ResponseWriter, r *htt
 	render.JSON(w, r, model.BasicResponse{Message: "Exercise removed from routine successfully"})
 }
 
+// ReorderRoutineExercises godoc
+// @Summary Reorder the exercises in a routine
+// @Description Lists every exercise in the routine, each once, in the new order. Targets move with their exercise, and exercises in the same superset must stay next to each other.
+// @Tags Routines
+// @Accept json
+// @Produce json
+// @Param id path int true "Routine ID"
+// @Param request body model.ReorderRoutineRequest true "Exercise IDs in the new order"
+// @Success 200 {object} model.ExerciseRoutine "Routine reordered successfully"
+// @Failure 400 {object} model.BasicResponse "The list doesn't match the routine's exercises"
+// @Failure 403 {object} model.BasicResponse "Not your routine"
+// @Router /routines/{id}/exercises/order [put]
+func (h *workoutHandler) ReorderRoutineExercises(w http.ResponseWriter, r *http.Request) {
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.ReorderRoutineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	routine, err := h.routineService.ReorderRoutineExercises(actorID, id, req.ExerciseIDs)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, routine)
+}
+
 // DeleteUserRoutine godoc
 // @Summary Delete user's routine
 // @Tags Routines
@@ -191,9 +342,11 @@ func (h *workoutHandler) RemoveExerciseFromRoutine(w http.ResponseWriter, r *htt
 // @Param routine_id path int true "Routine ID"
 // @Success 200 {object} model.BasicResponse "Routine deleted successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid ID"
+// @Failure 403 {object} model.BasicResponse "Not your routine"
 // @Failure 404 {object} model.BasicResponse "Routine not found"
 // @Router /users/{id}/routines/{routine_id} [delete]
 func (h *workoutHandler) DeleteUserRoutine(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	routineIDStr := chi.URLParam(r, "routine_id")
 	routineID, err := strconv.ParseInt(routineIDStr, 10, 64)
 	if err != nil {
@@ -202,7 +355,7 @@ func (h *workoutHandler) DeleteUserRoutine(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = h.routineService.DeleteRoutine(routineID)
+	err = h.routineService.DeleteRoutine(actorID, routineID)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -16,6 +17,8 @@ import (
 
 	"workoutpal/src/internal/model"
 	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 )
 
 func mustJSONString(t *testing.T, s string) *strings.Reader {
@@ -33,6 +36,7 @@ func TestRoutineHandler_CreateUserRoutine_BadJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/1/routines", mustJSONString(t, "{"))
 	r = withIDCtx(r, 1)
+	r = withUserCtx(r, 1)
 
 	h.CreateUserRoutine(w, r)
 	if w.Code != http.StatusBadRequest {
@@ -50,12 +54,13 @@ func TestRoutineHandler_CreateUserRoutine_ServiceError(t *testing.T) {
 	const userID int64 = 1
 	req := model.CreateRoutineRequest{Name: "Push Day"}
 	svc.EXPECT().
-		CreateRoutine(userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
+		CreateRoutine(int64(1), userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
 		Return((*model.ExerciseRoutine)(nil), errors.New("validation failed"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/1/routines", mustJSON(t, req))
 	r = withIDCtx(r, userID)
+	r = withUserCtx(r, 1)
 
 	h.CreateUserRoutine(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -75,12 +80,13 @@ func TestRoutineHandler_CreateUserRoutine_OK(t *testing.T) {
 	want := &model.ExerciseRoutine{ID: 10, UserID: userID, Name: req.Name}
 
 	svc.EXPECT().
-		CreateRoutine(userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
+		CreateRoutine(int64(1), userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
 		Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/users/2/routines", mustJSON(t, req))
 	r = withIDCtx(r, userID)
+	r = withUserCtx(r, 1)
 
 	h.CreateUserRoutine(w, r)
 	if w.Code != http.StatusCreated {
@@ -103,11 +109,12 @@ func TestRoutineHandler_ReadUserRoutines_NotFound(t *testing.T) {
 	h := &workoutHandler{routineService: svc}
 
 	const userID int64 = 3
-	svc.EXPECT().ReadUserRoutines(userID).Return(nil, errors.New("user not found"))
+	svc.EXPECT().ReadUserRoutines(int64(1), userID).Return(nil, errors.New("user not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/users/3/routines", nil)
 	r = withIDCtx(r, userID)
+	r = withUserCtx(r, 1)
 
 	h.ReadUserRoutines(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -127,11 +134,12 @@ func TestRoutineHandler_ReadUserRoutines_OK(t *testing.T) {
 		{ID: 1, UserID: userID, Name: "A"},
 		{ID: 2, UserID: userID, Name: "B"},
 	}
-	svc.EXPECT().ReadUserRoutines(userID).Return(want, nil)
+	svc.EXPECT().ReadUserRoutines(int64(1), userID).Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/users/4/routines", nil)
 	r = withIDCtx(r, userID)
+	r = withUserCtx(r, 1)
 
 	h.ReadUserRoutines(w, r)
 	if w.Code != http.StatusOK {
@@ -154,11 +162,12 @@ func TestRoutineHandler_DeleteRoutine_NotFound(t *testing.T) {
 	h := &workoutHandler{routineService: svc}
 
 	const routineID int64 = 5
-	svc.EXPECT().DeleteRoutine(routineID).Return(errors.New("not found"))
+	svc.EXPECT().DeleteRoutine(int64(1), routineID).Return(errors.New("not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/routines/5", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.DeleteRoutine(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -174,11 +183,12 @@ func TestRoutineHandler_DeleteRoutine_OK(t *testing.T) {
 	h := &workoutHandler{routineService: svc}
 
 	const routineID int64 = 6
-	svc.EXPECT().DeleteRoutine(routineID).Return(nil)
+	svc.EXPECT().DeleteRoutine(int64(1), routineID).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/routines/6", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.DeleteRoutine(w, r)
 	if w.Code != http.StatusOK {
@@ -199,11 +209,12 @@ func TestRoutineHandler_ReadRoutineWithExercises_NotFound(t *testing.T) {
 	h := &workoutHandler{routineService: svc}
 
 	const routineID int64 = 7
-	svc.EXPECT().ReadRoutineWithExercises(routineID).Return((*model.ExerciseRoutine)(nil), errors.New("not found"))
+	svc.EXPECT().ReadRoutineWithExercises(int64(1), routineID).Return((*model.ExerciseRoutine)(nil), errors.New("not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/routines/7", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.ReadRoutineWithExercises(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -220,11 +231,12 @@ func TestRoutineHandler_ReadRoutineWithExercises_OK(t *testing.T) {
 
 	const routineID int64 = 8
 	want := &model.ExerciseRoutine{ID: routineID, Name: "Pull Day"}
-	svc.EXPECT().ReadRoutineWithExercises(routineID).Return(want, nil)
+	svc.EXPECT().ReadRoutineWithExercises(int64(1), routineID).Return(want, nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/routines/8", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.ReadRoutineWithExercises(w, r)
 	if w.Code != http.StatusOK {
@@ -249,6 +261,7 @@ func TestRoutineHandler_AddExerciseToRoutine_BadExerciseID(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPost, "/routines/1/exercises?exercise_id=bad", nil)
 	r = withIDCtx(r, 1)
+	r = withUserCtx(r, 1)
 
 	h.AddExerciseToRoutine(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -266,13 +279,14 @@ func TestRoutineHandler_AddExerciseToRoutine_ServiceError(t *testing.T) {
 	const routineID int64 = 2
 	const exerciseID int64 = 9
 
-	svc.EXPECT().AddExerciseToRoutine(routineID, exerciseID).Return(errors.New("already added"))
+	svc.EXPECT().AddExerciseToRoutine(int64(1), routineID, exerciseID).Return(errors.New("already added"))
 
 	w := httptest.NewRecorder()
 	q := url.Values{}
 	q.Set("exercise_id", strconv.FormatInt(exerciseID, 10))
 	r := httptest.NewRequest(http.MethodPost, "/routines/2/exercises?"+q.Encode(), nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.AddExerciseToRoutine(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -290,13 +304,14 @@ func TestRoutineHandler_AddExerciseToRoutine_OK(t *testing.T) {
 	const routineID int64 = 3
 	const exerciseID int64 = 10
 
-	svc.EXPECT().AddExerciseToRoutine(routineID, exerciseID).Return(nil)
+	svc.EXPECT().AddExerciseToRoutine(int64(1), routineID, exerciseID).Return(nil)
 
 	w := httptest.NewRecorder()
 	q := url.Values{}
 	q.Set("exercise_id", strconv.FormatInt(exerciseID, 10))
 	r := httptest.NewRequest(http.MethodPost, "/routines/3/exercises?"+q.Encode(), nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 
 	h.AddExerciseToRoutine(w, r)
 	if w.Code != http.StatusOK {
@@ -319,6 +334,7 @@ func TestRoutineHandler_RemoveExerciseFromRoutine_BadExerciseID(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/routines/1/exercises/bad", nil)
 	r = withIDCtx(r, 1)
+	r = withUserCtx(r, 1)
 	r = withChiURLParam(r, "exercise_id", "bad")
 
 	h.RemoveExerciseFromRoutine(w, r)
@@ -337,11 +353,12 @@ func TestRoutineHandler_RemoveExerciseFromRoutine_ServiceError(t *testing.T) {
 	const routineID int64 = 4
 	const exerciseID int64 = 12
 
-	svc.EXPECT().RemoveExerciseFromRoutine(routineID, exerciseID).Return(errors.New("not in routine"))
+	svc.EXPECT().RemoveExerciseFromRoutine(int64(1), routineID, exerciseID).Return(errors.New("not in routine"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/routines/4/exercises/12", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 	r = withChiURLParam(r, "exercise_id", "12")
 
 	h.RemoveExerciseFromRoutine(w, r)
@@ -360,11 +377,12 @@ func TestRoutineHandler_RemoveExerciseFromRoutine_OK(t *testing.T) {
 	const routineID int64 = 5
 	const exerciseID int64 = 13
 
-	svc.EXPECT().RemoveExerciseFromRoutine(routineID, exerciseID).Return(nil)
+	svc.EXPECT().RemoveExerciseFromRoutine(int64(1), routineID, exerciseID).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/routines/5/exercises/13", nil)
 	r = withIDCtx(r, routineID)
+	r = withUserCtx(r, 1)
 	r = withChiURLParam(r, "exercise_id", "13")
 
 	h.RemoveExerciseFromRoutine(w, r)
@@ -386,7 +404,7 @@ func TestRoutineHandler_DeleteUserRoutine_UsesContextID(t *testing.T) {
 	h := &workoutHandler{routineService: svc}
 
 	const routineID int64 = 77
-	svc.EXPECT().DeleteRoutine(routineID).Return(nil)
+	svc.EXPECT().DeleteRoutine(int64(1), routineID).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/users/1/routines/77", nil)
@@ -394,9 +412,197 @@ func TestRoutineHandler_DeleteUserRoutine_UsesContextID(t *testing.T) {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("routine_id", "77")
 	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	r = withUserCtx(r, 1)
 
 	h.DeleteUserRoutine(w, r)
 	if w.Code != http.StatusOK {
 		t.Fatalf("status = %d, want 200", w.Code)
 	}
 }
+
+func TestRoutineHandler_UpdateRoutine_BadJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/routines/7", mustJSONString(t, "{"))
+	r = withIDCtx(r, 7)
+	r = withUserCtx(r, 1)
+
+	h.UpdateRoutine(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestRoutineHandler_UpdateRoutine_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().UpdateRoutine(int64(1), gomock.Any()).DoAndReturn(func(_ int64, req model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+		if req.ID != 7 || *req.Name != "Pull B" || req.Description != nil || len(*req.Exercises) != 1 || *(*req.Exercises)[0].RepsMax != 12 {
+			t.Fatalf("unexpected request %+v", req)
+		}
+		return &model.ExerciseRoutine{ID: 7, UserID: 1, Name: "Pull B", ExerciseIDs: []int64{11}}, nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/routines/7",
+		mustJSONString(t, `{"name":"Pull B","exercises":[{"exerciseId":11,"targetSets":3,"repsMin":8,"repsMax":12}]}`))
+	r = withIDCtx(r, 7)
+	r = withUserCtx(r, 1)
+
+	h.UpdateRoutine(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var got model.ExerciseRoutine
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Name != "Pull B" {
+		t.Fatalf("unexpected routine: %+v", got)
+	}
+}
+
+func TestRoutineHandler_ReorderRoutineExercises_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().ReorderRoutineExercises(int64(1), int64(7), []int64{13, 11, 12}).
+		Return(&model.ExerciseRoutine{ID: 7, UserID: 1, ExerciseIDs: []int64{13, 11, 12}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/routines/7/exercises/order", mustJSONString(t, `{"exerciseIds":[13,11,12]}`))
+	r = withIDCtx(r, 7)
+	r = withUserCtx(r, 1)
+
+	h.ReorderRoutineExercises(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
+
+func TestRoutineHandler_ReorderRoutineExercises_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().ReorderRoutineExercises(int64(1), int64(7), []int64{11}).
+		Return(nil, fmt.Errorf("%w: every exercise in the routine must be listed", util.ErrInvalidInput))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/routines/7/exercises/order", mustJSONString(t, `{"exerciseIds":[11]}`))
+	r = withIDCtx(r, 7)
+	r = withUserCtx(r, 1)
+
+	h.ReorderRoutineExercises(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestRoutineHandler_ReadPublicRoutines_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().ReadPublicRoutines(model.ReadPublicRoutinesRequest{Search: "legs", Sort: "popular", Cursor: "abc", Limit: 5}).
+		Return(&model.RoutinePage{Routines: []*model.ExerciseRoutine{{ID: 9, Username: "coach", ForkCount: 3}}, NextCursor: "def"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/routines/public?search=legs&sort=popular&cursor=abc&limit=5", nil)
+	r = withUserCtx(r, 1)
+
+	h.ReadPublicRoutines(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if w.Header().Get(constants.NEXT_CURSOR_HEADER) != "def" {
+		t.Fatalf("missing next cursor header")
+	}
+	var got []model.ExerciseRoutine
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Username != "coach" || got[0].ForkCount != 3 {
+		t.Fatalf("unexpected routines: %+v", got)
+	}
+}
+
+func TestRoutineHandler_ReadPublicRoutines_BadLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	h := &workoutHandler{routineService: mock_service.NewMockRoutineService(ctrl)}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/routines/public?limit=lots", nil)
+	r = withUserCtx(r, 1)
+
+	h.ReadPublicRoutines(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestRoutineHandler_ForkRoutine_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().ForkRoutine(int64(1), int64(9)).
+		Return(&model.ExerciseRoutine{ID: 12, UserID: 1, ForkedFrom: &model.RoutineSource{RoutineID: 9, UserID: 5}}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/routines/9/fork", nil)
+	r = withIDCtx(r, 9)
+	r = withUserCtx(r, 1)
+
+	h.ForkRoutine(w, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", w.Code)
+	}
+	var got model.ExerciseRoutine
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ForkedFrom == nil || got.ForkedFrom.RoutineID != 9 {
+		t.Fatalf("unexpected routine: %+v", got)
+	}
+}
+
+func TestRoutineHandler_ForkRoutine_NotShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := mock_service.NewMockRoutineService(ctrl)
+	h := &workoutHandler{routineService: svc}
+
+	svc.EXPECT().ForkRoutine(int64(1), int64(9)).Return(nil, fmt.Errorf("%w: this routine is private", util.ErrForbidden))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/routines/9/fork", nil)
+	r = withIDCtx(r, 9)
+	r = withUserCtx(r, 1)
+
+	h.ForkRoutine(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 
@@ -65,11 +67,13 @@ func (h *scheduleHandler) ReadUserSchedulesByDay(w http.ResponseWriter, r *http.
 // @Tags schedules
 // @Param id path int true "Schedule ID"
 // @Success 200 {object} model.Schedule
+// @Failure 403 {object} model.BasicResponse "Profile is private"
 // @Router /schedules/{id} [get]
 func (h *scheduleHandler) ReadScheduleByID(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	id := r.Context().Value(constants.ID_KEY).(int64)
 
-	schedule, err := h.service.ReadScheduleByID(id)
+	schedule, err := h.service.ReadScheduleByID(userID, id)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -86,6 +90,9 @@ func (h *scheduleHandler) ReadScheduleByID(w http.ResponseWriter, r *http.Reques
 // @Produce json
 // @Param request body model.CreateScheduleRequest true "Schedule create payload"
 // @Success 201 {object} model.Schedule
+// @Failure 400 {object} model.BasicResponse "Invalid day, time slot or length"
+// @Failure 403 {object} model.BasicResponse "Routine is not yours"
+// @Failure 409 {object} model.BasicResponse "Overlaps other schedules, listed in fields.conflictingScheduleIds"
 // @Router /schedules [post]
 func (h *scheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
@@ -113,6 +120,9 @@ func (h *scheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request)
 // @Param id path int true "Schedule ID"
 // @Param request body model.UpdateScheduleRequest true "Schedule update payload"
 // @Success 200 {object} model.Schedule
+// @Failure 400 {object} model.BasicResponse "Invalid day, time slot or length"
+// @Failure 403 {object} model.BasicResponse "Not your schedule or routine"
+// @Failure 409 {object} model.BasicResponse "Overlaps other schedules, listed in fields.conflictingScheduleIds"
 // @Router /schedules/{id} [put]
 func (h *scheduleHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
@@ -138,10 +148,325 @@ func (h *scheduleHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request)
 // @Tags schedules
 // @Param id path int true "Schedule ID"
 // @Success 204 {string} string "No Content"
+// @Failure 403 {object} model.BasicResponse "Not your schedule"
+// @Failure 404 {object} model.BasicResponse "Schedule not found"
 // @Router /schedules/{id} [delete]
 func (h *scheduleHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
 	id := r.Context().Value(constants.ID_KEY).(int64)
 
-	_ = h.service.DeleteSchedule(model.DeleteScheduleRequest{ID: id})
+	err := h.service.DeleteSchedule(userID, model.DeleteScheduleRequest{ID: id})
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
 	render.Status(r, http.StatusNoContent)
 }
+
+// ExportCalendar godoc
+// @Summary Export schedules as iCalendar
+// @Description Returns the authenticated user's schedules as an RFC 5545 calendar with one weekly recurring event per schedule. Skipped occurrences are excluded from the series and rescheduled ones are sent as one-off overrides.
+// @Tags schedules
+// @Produce text/calendar
+// @Success 200 {string} string "iCalendar file"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Router /schedules/calendar.ics [get]
+func (h *scheduleHandler) ExportCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	calendar, err := h.service.ExportCalendar(userID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="workoutpal.ics"`)
+	writeCalendar(w, calendar)
+}
+
+// CreateCalendarFeed godoc
+// @Summary Create a calendar subscription URL
+// @Description Issues a secret URL that calendar apps can poll without signing in. Creating a new URL revokes the previous one.
+// @Tags schedules
+// @Produce json
+// @Success 201 {object} model.CalendarFeed
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Router /schedules/calendar/feed [post]
+func (h *scheduleHandler) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	token, err := h.service.CreateCalendarFeed(userID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, model.CalendarFeed{URL: calendarFeedURL(r, token)})
+}
+
+// RevokeCalendarFeed godoc
+// @Summary Revoke the calendar subscription URL
+// @Tags schedules
+// @Success 204 {string} string "No Content"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Router /schedules/calendar/feed [delete]
+func (h *scheduleHandler) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	if err := h.service.RevokeCalendarFeed(userID); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReadCalendarFeed godoc
+// @Summary Read a calendar subscription
+// @Description Serves the schedules of the user who created the subscription URL. The token in the path authenticates the request.
+// @Tags schedules
+// @Produce text/calendar
+// @Param token path string true "Subscription token"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 404 {object} model.BasicResponse "Unknown or revoked subscription"
+// @Router /calendar/{token}.ics [get]
+func (h *scheduleHandler) ReadCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, constants.CALENDAR_TOKEN_KEY)
+
+	calendar, err := h.service.ExportCalendarFeed(token)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	writeCalendar(w, calendar)
+}
+
+func writeCalendar(w http.ResponseWriter, calendar []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(calendar)
+}
+
+// calendarFeedURL builds the absolute subscription URL from the request, so it
+// points at whichever host the client reached the API on
+func calendarFeedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + "/calendar/" + token + ".ics"
+}
+
+// ReadOccurrences godoc
+// @Summary Expand schedules into occurrences
+// @Description Lists every workout the authenticated user's schedules plan in [from, to), as UTC instants ordered by start. Skipped occurrences are left out and rescheduled ones appear at their new time. Bounds are RFC 3339 timestamps or dates; a date covers the whole UTC day. The range defaults to the next 7 days and can span at most 366 days.
+// @Tags schedules
+// @Produce json
+// @Param from query string false "Start of the range, e.g. 2025-03-01 or 2025-03-01T00:00:00Z"
+// @Param to query string false "End of the range, exclusive for timestamps and inclusive for dates"
+// @Success 200 {array} model.ScheduleOccurrence
+// @Failure 400 {object} model.BasicResponse "Invalid range"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Router /schedules/occurrences [get]
+func (h *scheduleHandler) ReadOccurrences(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	query := r.URL.Query()
+
+	req := model.ReadOccurrencesRequest{UserID: userID, From: time.Now().UTC().Truncate(24 * time.Hour)}
+	if raw := query.Get("from"); raw != "" {
+		from, _, err := parseRangeBound(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: from must be a date or an RFC 3339 timestamp", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		req.From = from
+	}
+	req.To = req.From.AddDate(0, 0, 7)
+	if raw := query.Get("to"); raw != "" {
+		to, isDate, err := parseRangeBound(raw)
+		if err != nil {
+			responseErr := util.Error(fmt.Errorf("%w: to must be a date or an RFC 3339 timestamp", util.ErrInvalidInput), r.URL.Path)
+			util.ErrorResponse(w, r, responseErr)
+			return
+		}
+		if isDate {
+			to = to.AddDate(0, 0, 1)
+		}
+		req.To = to
+	}
+
+	occurrences, err := h.service.ReadOccurrences(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, occurrences)
+}
+
+// parseRangeBound accepts an RFC 3339 timestamp or a date, which is read as
+// midnight UTC, and reports which of the two it was
+func parseRangeBound(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t, false, err
+}
+
+// CreateScheduleException godoc
+// @Summary Skip or reschedule one occurrence
+// @Description Skips the occurrence the schedule plans on the given local date, or moves it to rescheduledTo when set. Replaces an earlier change to the same occurrence.
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param request body model.CreateScheduleExceptionRequest true "Occurrence date and optional new start"
+// @Success 201 {object} model.ScheduleException
+// @Failure 400 {object} model.BasicResponse "No occurrence on that date"
+// @Failure 403 {object} model.BasicResponse "Not your schedule"
+// @Failure 404 {object} model.BasicResponse "Schedule not found"
+// @Router /schedules/{id}/exceptions [post]
+func (h *scheduleHandler) CreateScheduleException(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.CreateScheduleExceptionRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ScheduleID = id
+
+	exception, err := h.service.CreateScheduleException(userID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, exception)
+}
+
+// DeleteScheduleException godoc
+// @Summary Restore a skipped or rescheduled occurrence
+// @Tags schedules
+// @Param id path int true "Schedule ID"
+// @Param date path string true "Local date of the occurrence (YYYY-MM-DD)"
+// @Success 204 {string} string "No Content"
+// @Failure 403 {object} model.BasicResponse "Not your schedule"
+// @Failure 404 {object} model.BasicResponse "No change to that occurrence"
+// @Router /schedules/{id}/exceptions/{date} [delete]
+func (h *scheduleHandler) DeleteScheduleException(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	req := model.DeleteScheduleExceptionRequest{ScheduleID: id, Date: chi.URLParam(r, constants.DATE_KEY)}
+	if err := h.service.DeleteScheduleException(userID, req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReadUserAdherence godoc
+// @Summary Read a user's schedule adherence
+// @Description Settles every occurrence the user's schedules planned on local dates in [from, to]. An occurrence is completed by a finished workout started within 6 hours of it, missed once that window passes without one, or whatever the user marked it as. Percentages count completed out of completed and missed occurrences per week (from Monday) and calendar month. The range defaults to the 12 weeks ending today and can span at most 366 days.
+// @Tags schedules
+// @Produce json
+// @Param id path int true "User ID"
+// @Param from query string false "First local date, e.g. 2025-03-01"
+// @Param to query string false "Last local date, inclusive"
+// @Success 200 {object} model.Adherence
+// @Failure 400 {object} model.BasicResponse "Invalid range"
+// @Failure 403 {object} model.BasicResponse "Profile is private"
+// @Router /users/{id}/adherence [get]
+func (h *scheduleHandler) ReadUserAdherence(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+	query := r.URL.Query()
+
+	req := model.ReadAdherenceRequest{UserID: id, From: query.Get("from"), To: query.Get("to")}
+	adherence, err := h.service.ReadAdherence(userID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, adherence)
+}
+
+// MarkOccurrence godoc
+// @Summary Mark how one occurrence went
+// @Description Sets the occurrence the schedule planned on the given local date to completed, missed or skipped, replacing the status matched from workouts. Only past occurrences can be completed or missed; sessionId names the workout that completed it.
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param date path string true "Local date of the occurrence (YYYY-MM-DD)"
+// @Param request body model.MarkOccurrenceRequest true "Status and optional workout session"
+// @Success 200 {object} model.OccurrenceMark
+// @Failure 400 {object} model.BasicResponse "Invalid status, date or session"
+// @Failure 403 {object} model.BasicResponse "Not your schedule"
+// @Failure 404 {object} model.BasicResponse "Schedule not found"
+// @Router /schedules/{id}/occurrences/{date} [put]
+func (h *scheduleHandler) MarkOccurrence(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.MarkOccurrenceRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		responseErr := util.Error(fmt.Errorf("%w: invalid request body", util.ErrInvalidInput), r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.ScheduleID = id
+	req.Date = chi.URLParam(r, constants.DATE_KEY)
+
+	mark, err := h.service.MarkOccurrence(userID, req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, mark)
+}
+
+// DeleteOccurrenceMark godoc
+// @Summary Clear the status marked for one occurrence
+// @Description The occurrence goes back to being matched with workouts.
+// @Tags schedules
+// @Param id path int true "Schedule ID"
+// @Param date path string true "Local date of the occurrence (YYYY-MM-DD)"
+// @Success 204 {string} string "No Content"
+// @Failure 403 {object} model.BasicResponse "Not your schedule"
+// @Failure 404 {object} model.BasicResponse "The occurrence isn't marked"
+// @Router /schedules/{id}/occurrences/{date} [delete]
+func (h *scheduleHandler) DeleteOccurrenceMark(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	req := model.DeleteOccurrenceMarkRequest{ScheduleID: id, Date: chi.URLParam(r, constants.DATE_KEY)}
+	if err := h.service.DeleteOccurrenceMark(userID, req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,13 +3,17 @@ package handler
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 	"workoutpal/src/internal/model"
 	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 	"workoutpal/src/util/constants"
 
 	"github.com/go-chi/chi/v5"
@@ -179,7 +183,7 @@ func TestScheduleHandler_ReadScheduleByID_OK(t *testing.T) {
 	want := &model.Schedule{ID: id, Name: "Pull Day"}
 
 	mockSvc.EXPECT().
-		ReadScheduleByID(id).
+		ReadScheduleByID(int64(1), id).
 		Return(want, nil)
 
 	w := httptest.NewRecorder()
@@ -187,6 +191,7 @@ func TestScheduleHandler_ReadScheduleByID_OK(t *testing.T) {
 
 	// handler reads ID from constants.ID_KEY context value
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+	r = withUserCtx(r, 1)
 
 	h.ReadScheduleByID(w, r)
 
@@ -215,12 +220,13 @@ func TestScheduleHandler_ReadScheduleByID_Error(t *testing.T) {
 	id := int64(123)
 
 	mockSvc.EXPECT().
-		ReadScheduleByID(id).
+		ReadScheduleByID(int64(1), id).
 		Return(nil, errors.New("nope"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/schedules/123", nil)
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+	r = withUserCtx(r, 1)
 
 	h.ReadScheduleByID(w, r)
 
@@ -332,6 +338,39 @@ func TestScheduleHandler_CreateSchedule_Error(t *testing.T) {
 	}
 }
 
+func TestScheduleHandler_CreateSchedule_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	conflict := util.WithFields(fmt.Errorf("%w: the schedule overlaps 2 of your other schedules", util.ErrConflict),
+		map[string]any{"conflictingScheduleIds": []int64{3, 5}})
+	mockSvc.EXPECT().CreateSchedule(gomock.Any()).Return(nil, conflict)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/schedules", mustJSONBuf(t, model.CreateScheduleRequest{DayOfWeek: 1, TimeSlot: "07:00"}))
+	r = r.WithContext(context.WithValue(r.Context(), constants.USER_ID_KEY, int64(77)))
+
+	h.CreateSchedule(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	var got struct {
+		Fields struct {
+			ConflictingScheduleIDs []int64 `json:"conflictingScheduleIds"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Fields.ConflictingScheduleIDs) != 2 || got.Fields.ConflictingScheduleIDs[1] != 5 {
+		t.Fatalf("fields = %+v", got.Fields)
+	}
+}
+
 func TestScheduleHandler_UpdateSchedule_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -456,14 +495,14 @@ func TestScheduleHandler_DeleteSchedule_OK(t *testing.T) {
 	id := int64(123)
 
 	mockSvc.EXPECT().
-		DeleteSchedule(model.DeleteScheduleRequest{ID: id}).
+		DeleteSchedule(int64(1), model.DeleteScheduleRequest{ID: id}).
 		Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/schedules/123", nil)
 
-	// handler only pulls ID from context
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+	r = withUserCtx(r, 1)
 
 	h.DeleteSchedule(w, r)
 
@@ -475,7 +514,7 @@ func TestScheduleHandler_DeleteSchedule_OK(t *testing.T) {
 	}
 }
 
-func TestScheduleHandler_DeleteSchedule_ErrorStill204(t *testing.T) {
+func TestScheduleHandler_DeleteSchedule_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
@@ -485,20 +524,215 @@ func TestScheduleHandler_DeleteSchedule_ErrorStill204(t *testing.T) {
 	id := int64(999)
 
 	mockSvc.EXPECT().
-		DeleteSchedule(model.DeleteScheduleRequest{ID: id}).
+		DeleteSchedule(int64(1), model.DeleteScheduleRequest{ID: id}).
 		Return(errors.New("could not delete"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/schedules/999", nil)
 
 	r = r.WithContext(context.WithValue(r.Context(), constants.ID_KEY, id))
+	r = withUserCtx(r, 1)
 
 	h.DeleteSchedule(w, r)
 
 	resp := w.Result()
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", resp.StatusCode)
+	}
+}
+
+func TestScheduleHandler_ExportCalendar_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	mockSvc.EXPECT().ExportCalendar(int64(99)).Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/schedules/calendar.ics", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99)))
+	rr := httptest.NewRecorder()
+
+	h.ExportCalendar(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	if rr.Body.String() != "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n" {
+		t.Fatalf("unexpected body %q", rr.Body.String())
+	}
+}
+
+func TestScheduleHandler_CreateCalendarFeed_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	mockSvc.EXPECT().CreateCalendarFeed(int64(99)).Return("abc", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.example.com/schedules/calendar/feed", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99)))
+	rr := httptest.NewRecorder()
+
+	h.CreateCalendarFeed(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+	var got model.CalendarFeed
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.URL != "https://api.example.com/calendar/abc.ics" {
+		t.Fatalf("unexpected feed URL %q", got.URL)
+	}
+}
+
+func TestScheduleHandler_ReadCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+	r := chi.NewRouter()
+	r.Get("/calendar/{token}.ics", h.ReadCalendarFeed)
+
+	mockSvc.EXPECT().ExportCalendarFeed("abc").Return([]byte("BEGIN:VCALENDAR\r\n"), nil)
+	mockSvc.EXPECT().ExportCalendarFeed("gone").Return(nil, sql.ErrNoRows)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/calendar/abc.ics", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/calendar/gone.ics", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a revoked feed, got %d", rr.Code)
+	}
+}
+
+func TestScheduleHandler_ReadOccurrences_DateRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	// a date as the upper bound covers that whole day
+	mockSvc.EXPECT().ReadOccurrences(model.ReadOccurrencesRequest{
+		UserID: 99,
+		From:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+	}).Return([]*model.ScheduleOccurrence{{ScheduleID: 1}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/schedules/occurrences?from=2025-03-01&to=2025-03-07", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99)))
+	rr := httptest.NewRecorder()
+
+	h.ReadOccurrences(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestScheduleHandler_ReadOccurrences_BadBound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	h := &scheduleHandler{service: mock_service.NewMockScheduleService(ctrl)}
+
+	req := httptest.NewRequest(http.MethodGet, "/schedules/occurrences?from=yesterday", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99)))
+	rr := httptest.NewRecorder()
+
+	h.ReadOccurrences(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestScheduleHandler_DeleteScheduleException(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	mockSvc.EXPECT().DeleteScheduleException(int64(99), model.DeleteScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-03"}).Return(nil)
+
+	req := muxWithParam(httptest.NewRequest(http.MethodDelete, "/schedules/30/exceptions/2025-03-03", nil), constants.DATE_KEY, "2025-03-03")
+	ctx := context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99))
+	ctx = context.WithValue(ctx, constants.ID_KEY, int64(30))
+	rr := httptest.NewRecorder()
+
+	h.DeleteScheduleException(rr, req.WithContext(ctx))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rr.Code)
+	}
+}
+
+func TestScheduleHandler_ReadUserAdherence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	mockSvc.EXPECT().ReadAdherence(int64(99), model.ReadAdherenceRequest{UserID: 5, From: "2025-03-01", To: "2025-03-31"}).
+		Return(&model.Adherence{UserID: 5, CurrentStreak: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/5/adherence?from=2025-03-01&to=2025-03-31", nil)
+	ctx := context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99))
+	ctx = context.WithValue(ctx, constants.ID_KEY, int64(5))
+	rr := httptest.NewRecorder()
+
+	h.ReadUserAdherence(rr, req.WithContext(ctx))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var got model.Adherence
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.CurrentStreak != 3 {
+		t.Fatalf("unexpected adherence %+v", got)
+	}
+}
+
+func TestScheduleHandler_MarkOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockScheduleService(ctrl)
+	h := &scheduleHandler{service: mockSvc}
+
+	want := model.MarkOccurrenceRequest{ScheduleID: 30, Date: "2025-03-03", Status: "completed", SessionID: 9}
+	mockSvc.EXPECT().MarkOccurrence(int64(99), want).
+		Return(&model.OccurrenceMark{ScheduleID: 30, Date: "2025-03-03", Status: "completed", SessionID: 9}, nil)
+
+	body := bytes.NewBufferString(`{"status":"completed","sessionId":9}`)
+	req := muxWithParam(httptest.NewRequest(http.MethodPut, "/schedules/30/occurrences/2025-03-03", body), constants.DATE_KEY, "2025-03-03")
+	ctx := context.WithValue(req.Context(), constants.USER_ID_KEY, int64(99))
+	ctx = context.WithValue(ctx, constants.ID_KEY, int64(30))
+	rr := httptest.NewRecorder()
+
+	h.MarkOccurrence(rr, req.WithContext(ctx))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
 	}
 }
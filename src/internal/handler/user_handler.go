@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"workoutpal/src/internal/domain/handler"
@@ -17,12 +21,14 @@ import (
 type userHandler struct {
 	userService         service.UserService
 	relationshipService service.RelationshipService
+	mediaService        service.MediaService
 }
 
-func NewUserHandler(us service.UserService, rs service.RelationshipService) handler.UserHandler {
+func NewUserHandler(us service.UserService, rs service.RelationshipService, ms service.MediaService) handler.UserHandler {
 	return &userHandler{
 		userService:         us,
 		relationshipService: rs,
+		mediaService:        ms,
 	}
 }
 
@@ -179,10 +185,12 @@ func (u *userHandler) ReadUserByID(w http.ResponseWriter, r *http.Request) {
 // @Param request body model.UpdateUserRequest true "Update user payload"
 // @Success 200 {object} model.User "User updated successfully"
 // @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Not your account"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id} [patch]
 func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	var req model.UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -192,7 +200,7 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.ID = id
-	user, err := u.userService.UpdateUser(req)
+	user, err := u.userService.UpdateUser(actorID, req)
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -209,12 +217,14 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "User ID"
 // @Success 200 {object} model.BasicResponse "User deleted successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid user ID"
+// @Failure 403 {object} model.BasicResponse "Not your account"
 // @Failure 404 {object} model.BasicResponse "User not found"
 // @Router /users/{id} [delete]
 func (u *userHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	id := r.Context().Value(constants.ID_KEY).(int64)
+	actorID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
-	err := u.userService.DeleteUser(model.DeleteUserRequest{ID: id})
+	err := u.userService.DeleteUser(actorID, model.DeleteUserRequest{ID: id})
 	if err != nil {
 		responseErr := util.Error(err, r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
@@ -225,97 +235,101 @@ func (u *userHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 }
 
 // UploadAvatar godoc
-// @Summary Upload user avatar as base64
+// @Summary Set the user's avatar
+// @Description Send the image as a multipart upload in the file field, or pick one already uploaded to /media with {"mediaId": "..."}. Older clients may still send a base64 data URL as {"avatar": "..."}. Either way the image is stored as media and the avatar is served from /media/{mediaId}.
 // @Tags Users
-// @Accept json
+// @Accept multipart/form-data,json
 // @Produce json
 // @Param id path int true "User ID"
-// @Param avatar body map[string]string true "Avatar base64 data"
+// @Param file formData file false "Image"
+// @Param avatar body model.SetAvatarRequest false "An uploaded image"
 // @Success 200 {object} map[string]string "Avatar uploaded successfully"
 // @Failure 400 {object} model.BasicResponse "Invalid data or user ID"
-// @Failure 404 {object} model.BasicResponse "User not found"
+// @Failure 403 {object} model.BasicResponse "Not your avatar or not your upload"
+// @Failure 404 {object} model.BasicResponse "User or media not found"
+// @Failure 413 {object} model.BasicResponse "The image is too large"
 // @Failure 500 {object} model.BasicResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users/{id}/avatar [post]
 func (u *userHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(constants.ID_KEY).(int64)
-	viewerID, ok := r.Context().Value(constants.USER_ID_KEY).(int64)
-	
-	// Debug: Check if viewerID was properly extracted
-	if !ok {
-		responseErr := util.Error(errors.New("Authentication failed: no user ID in context"), r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	viewerID := r.Context().Value(constants.USER_ID_KEY).(int64)
 
 	// Only allow users to upload their own avatar
 	if viewerID != userID {
-		responseErr := util.Error(errors.New("You can only upload your own avatar"), r.URL.Path)
+		responseErr := util.Error(fmt.Errorf("%w: you can only upload your own avatar", util.ErrForbidden), r.URL.Path)
 		util.ErrorResponse(w, r, responseErr)
 		return
 	}
 
-	// Parse JSON request
-	var req map[string]string
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	mediaID, err := u.avatarMediaID(w, r, userID)
+	if err != nil {
 		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
 		return
 	}
 
-	avatarData, ok := req["avatar"]
-	if !ok || avatarData == "" {
-		responseErr := util.Error(errors.New("Avatar data is required"), r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
+	media, err := u.mediaService.SetAvatar(viewerID, userID, mediaID)
+	if err != nil {
+		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
 		return
 	}
 
-	// Validate base64 format (should start with data:image/)
-	if !strings.HasPrefix(avatarData, "data:image/") {
-		responseErr := util.Error(errors.New("Invalid image format. Must be base64 encoded image"), r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
-	}
+	render.JSON(w, r, map[string]string{
+		"message": "Avatar uploaded successfully",
+		"avatar":  media.URL,
+		"mediaId": media.ID,
+	})
+}
 
-	// Validate size (approximate - base64 is ~33% larger than original)
-	if len(avatarData) > 7000000 { // ~5MB original file
-		responseErr := util.Error(errors.New("Image size too large. Must be less than 5MB"), r.URL.Path)
-		util.ErrorResponse(w, r, responseErr)
-		return
+// avatarMediaID uploads the image the request carries, if it carries one, and
+// returns the id of the media to use as the avatar
+func (u *userHandler) avatarMediaID(w http.ResponseWriter, r *http.Request, userID int64) (string, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, err := formFile(w, r)
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		return u.uploadAvatar(r, userID, file)
 	}
 
-	// Get current user data to preserve other fields
-	currentUser, err := u.userService.ReadUserByID(userID)
-	if err != nil {
-		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
-		return
+	// base64 takes a third more room than the image it encodes
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxMediaBytes*4/3+1<<10)
+	var req struct {
+		model.SetAvatarRequest
+		Avatar string `json:"avatar"`
 	}
-
-	// Update user's avatar data in database
-	updateReq := model.UpdateUserRequest{
-		ID:     userID,
-		Avatar: avatarData,
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", fmt.Errorf("%w: images can be at most %d MB", util.ErrTooLarge, model.MaxMediaBytes>>20)
+		}
+		return "", fmt.Errorf("%w: invalid request body", util.ErrInvalidInput)
 	}
 
-	// Preserve current user data
-	updateReq.Username = currentUser.Username
-	updateReq.Name = currentUser.Name
-	updateReq.Email = currentUser.Email
-	updateReq.Age = currentUser.Age
-	updateReq.Height = currentUser.Height
-	updateReq.HeightMetric = currentUser.HeightMetric
-	updateReq.Weight = currentUser.Weight
-	updateReq.WeightMetric = currentUser.WeightMetric
-	updateReq.IsPrivate = currentUser.IsPrivate
-	updateReq.ShowMetricsToFollowers = currentUser.ShowMetricsToFollowers
+	switch {
+	case req.MediaID != "":
+		return req.MediaID, nil
+	case req.Avatar != "":
+		// data:image/<type>;base64,<data>
+		comma := strings.Index(req.Avatar, ",")
+		if !strings.HasPrefix(req.Avatar, "data:image/") || comma == -1 {
+			return "", fmt.Errorf("%w: avatar must be a base64 encoded image data URL", util.ErrInvalidInput)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(req.Avatar[comma+1:])
+		if err != nil {
+			return "", fmt.Errorf("%w: avatar must be a base64 encoded image data URL", util.ErrInvalidInput)
+		}
+		return u.uploadAvatar(r, userID, bytes.NewReader(decoded))
+	default:
+		return "", fmt.Errorf("%w: send an image or the mediaId of one", util.ErrInvalidInput)
+	}
+}
 
-	updatedUser, err := u.userService.UpdateUser(updateReq)
+func (u *userHandler) uploadAvatar(r *http.Request, userID int64, file io.Reader) (string, error) {
+	media, err := u.mediaService.UploadMedia(r.Context(), userID, file)
 	if err != nil {
-		util.ErrorResponse(w, r, util.Error(err, r.URL.Path))
-		return
+		return "", err
 	}
-
-	render.JSON(w, r, map[string]string{
-		"message": "Avatar uploaded successfully",
-		"avatar":  updatedUser.Avatar,
-	})
+	return media.ID, nil
 }
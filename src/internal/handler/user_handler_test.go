@@ -207,6 +207,7 @@ func TestUserHandler_UpdateUser_BadJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPatch, "/users/9", bytes.NewBufferString("{"))
 	r = withIDCtx(r, 9)
+	r = withUserCtx(r, 9)
 	r.Header.Set("Content-Type", "application/json")
 
 	h.UpdateUser(w, r)
@@ -225,12 +226,13 @@ func TestUserHandler_UpdateUser_ServiceError(t *testing.T) {
 	const id int64 = 9
 	req := model.UpdateUserRequest{Username: "newname"}
 	svc.EXPECT().
-		UpdateUser(gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
+		UpdateUser(id, gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
 		Return((*model.User)(nil), errors.New("bad update"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPatch, "/users/9", mustJSON(t, req))
 	r = withIDCtx(r, id)
+	r = withUserCtx(r, id)
 	r.Header.Set("Content-Type", "application/json")
 
 	h.UpdateUser(w, r)
@@ -251,8 +253,8 @@ func TestUserHandler_UpdateUser_OK(t *testing.T) {
 	want := &model.User{ID: id, Username: req.Username}
 
 	svc.EXPECT().
-		UpdateUser(gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
-		DoAndReturn(func(got model.UpdateUserRequest) (*model.User, error) {
+		UpdateUser(id, gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
+		DoAndReturn(func(_ int64, got model.UpdateUserRequest) (*model.User, error) {
 			if got.ID != id {
 				return nil, errors.New("missing id propagation")
 			}
@@ -262,6 +264,7 @@ func TestUserHandler_UpdateUser_OK(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodPatch, "/users/9", mustJSON(t, req))
 	r = withIDCtx(r, id)
+	r = withUserCtx(r, id)
 	r.Header.Set("Content-Type", "application/json")
 
 	h.UpdateUser(w, r)
@@ -285,11 +288,12 @@ func TestUserHandler_DeleteUser_NotFound(t *testing.T) {
 	h := &userHandler{userService: svc}
 
 	const id int64 = 13
-	svc.EXPECT().DeleteUser(model.DeleteUserRequest{ID: id}).Return(errors.New("not found"))
+	svc.EXPECT().DeleteUser(id, model.DeleteUserRequest{ID: id}).Return(errors.New("not found"))
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/users/13", nil)
 	r = withIDCtx(r, id)
+	r = withUserCtx(r, id)
 
 	h.DeleteUser(w, r)
 	if w.Code != http.StatusInternalServerError {
@@ -305,11 +309,12 @@ func TestUserHandler_DeleteUser_OK(t *testing.T) {
 	h := &userHandler{userService: svc}
 
 	const id int64 = 13
-	svc.EXPECT().DeleteUser(model.DeleteUserRequest{ID: id}).Return(nil)
+	svc.EXPECT().DeleteUser(id, model.DeleteUserRequest{ID: id}).Return(nil)
 
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodDelete, "/users/13", nil)
 	r = withIDCtx(r, id)
+	r = withUserCtx(r, id)
 
 	h.DeleteUser(w, r)
 	if w.Code != http.StatusOK {
@@ -323,3 +328,78 @@ func TestUserHandler_DeleteUser_OK(t *testing.T) {
 		t.Fatalf("message = %q, want %q", br.Message, "User deleted successfully")
 	}
 }
+
+func TestUserHandler_UploadAvatar_MediaID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ms := mock_service.NewMockMediaService(ctrl)
+	h := &userHandler{mediaService: ms}
+
+	ms.EXPECT().SetAvatar(int64(3), int64(3), "abc").Return(&model.Media{ID: "abc", URL: "/media/abc"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/3/avatar", bytes.NewBufferString(`{"mediaId":"abc"}`))
+	r = withUserCtx(withIDCtx(r, 3), 3)
+
+	h.UploadAvatar(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body=%s", w.Code, w.Body.String())
+	}
+	var body map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["avatar"] != "/media/abc" || body["mediaId"] != "abc" {
+		t.Fatalf("body = %v", body)
+	}
+}
+
+func TestUserHandler_UploadAvatar_Multipart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ms := mock_service.NewMockMediaService(ctrl)
+	h := &userHandler{mediaService: ms}
+
+	gomock.InOrder(
+		ms.EXPECT().UploadMedia(gomock.Any(), int64(3), gomock.Any()).Return(&model.Media{ID: "new"}, nil),
+		ms.EXPECT().SetAvatar(int64(3), int64(3), "new").Return(&model.Media{ID: "new", URL: "/media/new"}, nil),
+	)
+
+	body, contentType := multipartImage(t, "file", []byte("image bytes"))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users/3/avatar", body)
+	r.Header.Set("Content-Type", contentType)
+	r = withUserCtx(withIDCtx(r, 3), 3)
+
+	h.UploadAvatar(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestUserHandler_UploadAvatar_Rejected(t *testing.T) {
+	h := &userHandler{}
+
+	cases := []struct {
+		name   string
+		viewer int64
+		body   string
+		want   int
+	}{
+		{"someone else's avatar", 4, `{"mediaId":"abc"}`, http.StatusForbidden},
+		{"nothing to set", 3, `{}`, http.StatusBadRequest},
+		{"not a data URL", 3, `{"avatar":"https://example.com/me.png"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users/3/avatar", bytes.NewBufferString(tc.body))
+		r = withUserCtx(withIDCtx(r, 3), tc.viewer)
+
+		h.UploadAvatar(w, r)
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+}
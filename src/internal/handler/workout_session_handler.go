@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"workoutpal/src/internal/domain/handler"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	"github.com/go-chi/render"
+)
+
+type workoutSessionHandler struct {
+	service service.WorkoutSessionService
+}
+
+func NewWorkoutSessionHandler(s service.WorkoutSessionService) handler.WorkoutSessionHandler {
+	return &workoutSessionHandler{service: s}
+}
+
+// ReadUserSessions godoc
+// @Summary List workout sessions for the authenticated user
+// @Tags Sessions
+// @Produce json
+// @Success 200 {array} model.WorkoutSession "Sessions retrieved successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 500 {object} model.BasicResponse "Internal server error"
+// @Security BearerAuth
+// @Router /sessions [get]
+func (h *workoutSessionHandler) ReadUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	sessions, err := h.service.ReadUserSessions(userID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, sessions)
+}
+
+// ReadActiveSession godoc
+// @Summary Get the in-progress workout session for the authenticated user
+// @Tags Sessions
+// @Produce json
+// @Success 200 {object} model.WorkoutSession "Active session"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 404 {object} model.BasicResponse "No active session"
+// @Security BearerAuth
+// @Router /sessions/active [get]
+func (h *workoutSessionHandler) ReadActiveSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	session, err := h.service.ReadActiveSession(userID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, session)
+}
+
+// ReadSessionByID godoc
+// @Summary Get a workout session with its logged sets
+// @Tags Sessions
+// @Produce json
+// @Param id path int true "Session ID"
+// @Success 200 {object} model.WorkoutSession "Session retrieved successfully"
+// @Failure 401 {object} model.BasicResponse "Unauthorized"
+// @Failure 404 {object} model.BasicResponse "Session not found"
+// @Security BearerAuth
+// @Router /sessions/{id} [get]
+func (h *workoutSessionHandler) ReadSessionByID(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	session, err := h.service.ReadSessionByID(id, userID)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, session)
+}
+
+// StartSession godoc
+// @Summary Start a workout session from a routine
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param request body model.StartWorkoutSessionRequest true "Routine to start"
+// @Success 201 {object} model.WorkoutSession "Session started"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 403 {object} model.BasicResponse "Routine belongs to another user"
+// @Failure 409 {object} model.BasicResponse "Another session is in progress"
+// @Security BearerAuth
+// @Router /sessions [post]
+func (h *workoutSessionHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+
+	var req model.StartWorkoutSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.UserID = userID
+
+	session, err := h.service.StartSession(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, session)
+}
+
+// LogSet godoc
+// @Summary Log a performed set in a workout session
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Session ID"
+// @Param request body model.LogWorkoutSetRequest true "Performed set"
+// @Success 201 {object} model.WorkoutSet "Set logged"
+// @Failure 400 {object} model.BasicResponse "Validation error"
+// @Failure 404 {object} model.BasicResponse "Session not found"
+// @Failure 409 {object} model.BasicResponse "Session already finished"
+// @Security BearerAuth
+// @Router /sessions/{id}/sets [post]
+func (h *workoutSessionHandler) LogSet(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	var req model.LogWorkoutSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+	req.SessionID = id
+	req.UserID = userID
+
+	set, err := h.service.LogSet(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, set)
+}
+
+// FinishSession godoc
+// @Summary Finish a workout session
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Session ID"
+// @Param request body model.FinishWorkoutSessionRequest false "Optional closing notes"
+// @Success 200 {object} model.WorkoutSession "Session finished"
+// @Failure 404 {object} model.BasicResponse "Session not found"
+// @Failure 409 {object} model.BasicResponse "Session already finished"
+// @Security BearerAuth
+// @Router /sessions/{id}/finish [post]
+func (h *workoutSessionHandler) FinishSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(constants.USER_ID_KEY).(int64)
+	id := r.Context().Value(constants.ID_KEY).(int64)
+
+	// the body is optional, so an empty one is not an error
+	var req model.FinishWorkoutSessionRequest
+	_ = render.DecodeJSON(r.Body, &req)
+	req.ID = id
+	req.UserID = userID
+
+	session, err := h.service.FinishSession(req)
+	if err != nil {
+		responseErr := util.Error(err, r.URL.Path)
+		util.ErrorResponse(w, r, responseErr)
+		return
+	}
+
+	render.JSON(w, r, session)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"workoutpal/src/internal/model"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newWorkoutSessionHandlerMocks(t *testing.T) (*mock_service.MockWorkoutSessionService, *workoutSessionHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockSvc := mock_service.NewMockWorkoutSessionService(ctrl)
+	return mockSvc, &workoutSessionHandler{service: mockSvc}
+}
+
+func TestWorkoutSessionHandler_ReadUserSessions_OK(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadUserSessions(int64(7)).
+		Return([]*model.WorkoutSession{{ID: 1, UserID: 7, Status: "finished"}}, nil)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodGet, "/sessions", nil), 7)
+
+	h.ReadUserSessions(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+	var got []model.WorkoutSession
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestWorkoutSessionHandler_ReadActiveSession_None(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadActiveSession(int64(7)).Return(nil, sql.ErrNoRows)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodGet, "/sessions/active", nil), 7)
+
+	h.ReadActiveSession(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status=%d want=404", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_ReadSessionByID_OK(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().ReadSessionByID(int64(3), int64(7)).
+		Return(&model.WorkoutSession{ID: 3, UserID: 7, Status: "active"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/sessions/3", nil)
+	r = withIDCtx(withUserCtx(r, 7), 3)
+
+	h.ReadSessionByID(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_StartSession_BadJSON(t *testing.T) {
+	_, h := newWorkoutSessionHandlerMocks(t)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/sessions", bytes.NewBufferString("{bad")), 7)
+
+	h.StartSession(w, r)
+
+	if w.Code == http.StatusCreated {
+		t.Fatalf("expected error status, got %d", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_StartSession_OK(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	// the user id in the body is ignored in favour of the caller
+	body := model.StartWorkoutSessionRequest{UserID: 99, RoutineID: 3}
+	mockSvc.EXPECT().
+		StartSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3}).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, RoutineID: 3, Status: "active"}, nil)
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/sessions", mustJSON(t, body)), 7)
+
+	h.StartSession(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_StartSession_Conflict(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().StartSession(gomock.Any()).
+		Return(nil, fmt.Errorf("%w: session 9 is still in progress", util.ErrConflict))
+
+	w := httptest.NewRecorder()
+	r := withUserCtx(httptest.NewRequest(http.MethodPost, "/sessions", mustJSON(t, model.StartWorkoutSessionRequest{RoutineID: 3})), 7)
+
+	h.StartSession(w, r)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status=%d want=409", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_LogSet_OK(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	body := model.LogWorkoutSetRequest{ExerciseID: 2, Weight: 80, Reps: 5}
+	mockSvc.EXPECT().
+		LogSet(model.LogWorkoutSetRequest{SessionID: 3, UserID: 7, ExerciseID: 2, Weight: 80, Reps: 5}).
+		Return(&model.WorkoutSet{ID: 1, SessionID: 3, ExerciseID: 2, SetNumber: 1, Weight: 80, Reps: 5}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/sessions/3/sets", mustJSON(t, body))
+	r = withIDCtx(withUserCtx(r, 7), 3)
+
+	h.LogSet(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status=%d want=201", w.Code)
+	}
+	var got model.WorkoutSet
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.SetNumber != 1 {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+}
+
+func TestWorkoutSessionHandler_LogSet_Invalid(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().LogSet(gomock.Any()).
+		Return(nil, fmt.Errorf("%w: reps must be greater than 0", util.ErrInvalidInput))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/sessions/3/sets", mustJSON(t, model.LogWorkoutSetRequest{ExerciseID: 2}))
+	r = withIDCtx(withUserCtx(r, 7), 3)
+
+	h.LogSet(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status=%d want=400", w.Code)
+	}
+}
+
+func TestWorkoutSessionHandler_FinishSession_EmptyBody(t *testing.T) {
+	mockSvc, h := newWorkoutSessionHandlerMocks(t)
+
+	mockSvc.EXPECT().
+		FinishSession(model.FinishWorkoutSessionRequest{ID: 3, UserID: 7}).
+		Return(&model.WorkoutSession{ID: 3, UserID: 7, Status: "finished"}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/sessions/3/finish", nil)
+	r = withIDCtx(withUserCtx(r, 7), 3)
+
+	h.FinishSession(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d want=200", w.Code)
+	}
+}
@@ -20,7 +20,13 @@ func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
 	return claims, ok
 }
 
-func AuthMiddleware(secret []byte) func(http.Handler) http.Handler {
+// SessionChecker reports whether the session an access token was issued for is
+// still active, so logging out takes effect before the token expires
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+func AuthMiddleware(secret []byte, sessions SessionChecker) func(http.Handler) http.Handler {
 	if os.Getenv("APP_ENV") == "test" {
 		return func(next http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +82,23 @@ func AuthMiddleware(secret []byte) func(http.Handler) http.Handler {
 				return
 			}
 
+			if sessions != nil {
+				sessionID, _ := claims["sid"].(string)
+				active, err := sessions.IsSessionActive(r.Context(), sessionID)
+				if err != nil {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(map[string]string{"error": "could not verify session"})
+					return
+				}
+				if !active {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					json.NewEncoder(w).Encode(map[string]string{"error": "session has been revoked"})
+					return
+				}
+			}
+
 			userIDFloat, _ := claims["sub"].(float64)
 			userID := int64(userIDFloat)
 
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workoutpal/src/util/constants"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testSecret = []byte("test-secret")
+
+type stubSessions map[string]bool
+
+func (s stubSessions) IsSessionActive(_ context.Context, sessionID string) (bool, error) {
+	if sessionID == "broken" {
+		return false, errors.New("db down")
+	}
+	return s[sessionID], nil
+}
+
+func signedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func TestAuthMiddleware_SessionRevocation(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	sessions := stubSessions{"live": true, "revoked": false}
+
+	tests := []struct {
+		name string
+		sid  any
+		want int
+	}{
+		{"active session", "live", http.StatusOK},
+		{"revoked session", "revoked", http.StatusUnauthorized},
+		{"unknown session", "other", http.StatusUnauthorized},
+		{"token without session", nil, http.StatusUnauthorized},
+		{"checker failure", "broken", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{"sub": 7, "exp": time.Now().Add(time.Hour).Unix()}
+			if tt.sid != nil {
+				claims["sid"] = tt.sid
+			}
+
+			var gotUserID any
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID = r.Context().Value(constants.USER_ID_KEY)
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/me", nil)
+			r.Header.Set("Authorization", "Bearer "+signedToken(t, claims))
+			AuthMiddleware(testSecret, sessions)(next).ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && gotUserID != int64(7) {
+				t.Fatalf("expected user 7 in context, got %v", gotUserID)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_NoSessionChecker(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/me", nil)
+	r.AddCookie(&http.Cookie{Name: "access_token", Value: signedToken(t, jwt.MapClaims{"sub": 7, "exp": time.Now().Add(time.Hour).Unix()})})
+	AuthMiddleware(testSecret, nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workoutpal/src/util/constants"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestRequireRole(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	sessions := stubSessions{"live": true}
+
+	tests := []struct {
+		name string
+		role any
+		want int
+	}{
+		{"admin", constants.ROLE_ADMIN, http.StatusOK},
+		{"moderator", constants.ROLE_MODERATOR, http.StatusOK},
+		{"user", constants.ROLE_USER, http.StatusForbidden},
+		{"token without role", nil, http.StatusForbidden},
+		{"unknown role", "owner", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{"sub": 7, "sid": "live", "exp": time.Now().Add(time.Hour).Unix()}
+			if tt.role != nil {
+				claims["role"] = tt.role
+			}
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+			h := AuthMiddleware(testSecret, sessions)(RequireRole(constants.ROLE_MODERATOR, constants.ROLE_ADMIN)(next))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/admin/posts/1", nil)
+			r.Header.Set("Authorization", "Bearer "+signedToken(t, claims))
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if called != (tt.want == http.StatusOK) {
+				t.Fatalf("next called = %v", called)
+			}
+		})
+	}
+}
+
+func TestRequireRole_WithoutAuthMiddleware(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+
+	w := httptest.NewRecorder()
+	RequireRole(constants.ROLE_ADMIN)(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+}
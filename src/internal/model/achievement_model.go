@@ -1,10 +1,25 @@
 package model
 
+// Criteria an achievement can be unlocked by; the threshold is compared against
+// the user's current count for the criteria
+const (
+	CriteriaPosts             = "posts"
+	CriteriaLikesGiven        = "likes_given"
+	CriteriaFollowers         = "followers"
+	CriteriaFollowing         = "following"
+	CriteriaRoutines          = "routines"
+	CriteriaSchedules         = "schedules"
+	CriteriaScheduledWorkouts = "scheduled_workouts"
+	CriteriaWorkoutStreak     = "workout_streak"
+)
+
 type Achievement struct {
-	ID          int64  `json:"id"`
-	Title       string `json:"title"`
-	BadgeIcon   string `json:"badgeIcon"`
-	Description string `json:"description"`
+	ID           int64  `json:"id"`
+	Title        string `json:"title"`
+	BadgeIcon    string `json:"badgeIcon"`
+	Description  string `json:"description"`
+	CriteriaType string `json:"criteriaType,omitempty"`
+	Threshold    int64  `json:"threshold,omitempty"`
 }
 
 type UserAchievement struct {
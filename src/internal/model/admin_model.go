@@ -0,0 +1,119 @@
+package model
+
+import "time"
+
+// Actions recorded in the admin audit log
+const (
+	AuditCreateExercise    = "exercise.create"
+	AuditUpdateExercise    = "exercise.update"
+	AuditDeleteExercise    = "exercise.delete"
+	AuditCreateAchievement = "achievement.create"
+	AuditUpdateAchievement = "achievement.update"
+	AuditDeleteAchievement = "achievement.delete"
+	AuditSuspendUser       = "user.suspend"
+	AuditBanUser           = "user.ban"
+	AuditReinstateUser     = "user.reinstate"
+	AuditChangeRole        = "user.role"
+	AuditTakeDownPost      = "post.takedown"
+)
+
+// Kinds of record an audit entry can point at
+const (
+	AuditTargetExercise    = "exercise"
+	AuditTargetAchievement = "achievement"
+	AuditTargetUser        = "user"
+	AuditTargetPost        = "post"
+)
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+)
+
+// UserStanding is a user's role and moderation state
+type UserStanding struct {
+	UserID         int64      `json:"userId"`
+	Role           string     `json:"role"`
+	SuspendedUntil *time.Time `json:"suspendedUntil,omitempty"`
+	BannedAt       *time.Time `json:"bannedAt,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+}
+
+// UpdateUserStandingRequest replaces the moderation state; nil times clear it
+type UpdateUserStandingRequest struct {
+	UserID         int64
+	SuspendedUntil *time.Time
+	BannedAt       *time.Time
+	Reason         string
+}
+
+type SuspendUserRequest struct {
+	UserID int64     `json:"-"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+type BanUserRequest struct {
+	UserID int64  `json:"-"`
+	Reason string `json:"reason"`
+}
+
+type UpdateUserRoleRequest struct {
+	UserID int64  `json:"-"`
+	Role   string `json:"role"`
+}
+
+type TakeDownPostRequest struct {
+	PostID int64  `json:"-"`
+	Reason string `json:"reason"`
+}
+
+// AchievementDefinitionRequest creates or replaces an achievement. Without a
+// criteria type the achievement can only be granted by an admin.
+type AchievementDefinitionRequest struct {
+	ID           int64  `json:"-"`
+	Name         string `json:"name"`
+	Title        string `json:"title"`
+	BadgeIcon    string `json:"badgeIcon"`
+	Description  string `json:"description"`
+	CriteriaType string `json:"criteriaType"`
+	Threshold    int64  `json:"threshold"`
+}
+
+type AuditRecord struct {
+	ID         int64          `json:"id"`
+	ActorID    int64          `json:"actorId"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"targetType"`
+	TargetID   int64          `json:"targetId"`
+	Details    map[string]any `json:"details"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+type CreateAuditRecordRequest struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   int64
+	Details    map[string]any
+}
+
+type ReadAuditLogRequest struct {
+	TargetType string
+	TargetID   int64
+	Cursor     string
+	Limit      int
+}
+
+// AuditFilter is a validated ReadAuditLogRequest; BeforeID 0 starts at the newest record
+type AuditFilter struct {
+	TargetType string
+	TargetID   int64
+	BeforeID   int64
+	Limit      int
+}
+
+type AuditLogPage struct {
+	Records    []*AuditRecord `json:"records"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
@@ -1,6 +1,57 @@
 package model
 
+import "time"
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// AuthSession is one signed-in device. RefreshToken is the raw token and is only
+// known at the moment it is issued; the database keeps a hash of it. Role is
+// the user's role when the session was issued or last refreshed.
+type AuthSession struct {
+	ID               string
+	UserID           int64
+	Role             string
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// RefreshToken is a stored refresh token. Every token issued for a session
+// belongs to the same family, so reusing an old one revokes them all.
+type RefreshToken struct {
+	ID             int64
+	SessionID      string
+	UserID         int64
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	SessionRevoked bool
+}
+
+type CreateRefreshTokenRequest struct {
+	SessionID string
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// GoogleIdentity is what a verified Google ID token says about its user
+type GoogleIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+type CreateGoogleUserRequest struct {
+	Username string
+	Email    string
+	Name     string
+	GoogleID string
+}
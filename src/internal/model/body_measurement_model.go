@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+const (
+	MetricWeight  = "weight"
+	MetricBodyFat = "body_fat"
+	MetricWaist   = "waist"
+	MetricChest   = "chest"
+	MetricHips    = "hips"
+	MetricNeck    = "neck"
+	MetricArm     = "arm"
+	MetricThigh   = "thigh"
+
+	// measurements are stored in kilograms, centimetres and percent
+	UnitKilogram   = "kg"
+	UnitPound      = "lb"
+	UnitCentimeter = "cm"
+	UnitInch       = "in"
+	UnitPercent    = "%"
+
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+
+	DefaultTrendWindow = 7
+	MaxTrendWindow     = 90
+)
+
+// BodyMetrics lists every metric that can be logged
+var BodyMetrics = []string{MetricWeight, MetricBodyFat, MetricWaist, MetricChest, MetricHips, MetricNeck, MetricArm, MetricThigh}
+
+type BodyMeasurement struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"userId"`
+	Metric string `json:"metric"`
+	// Value is in Unit, the reader's preferred unit for the metric
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit"`
+	MeasuredAt time.Time `json:"measuredAt"`
+}
+
+type CreateMeasurementRequest struct {
+	UserID int64   `json:"-"`
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	// Unit defaults to the profile's unit for the metric: kg or lb, cm or in
+	Unit string `json:"unit,omitempty"`
+	// MeasuredAt defaults to now
+	MeasuredAt *time.Time `json:"measuredAt,omitempty"`
+}
+
+type ReadMeasurementsRequest struct {
+	UserID int64
+	// Metric is optional when listing and required for a trend
+	Metric string
+	// local dates (YYYY-MM-DD), both inclusive
+	From string
+	To   string
+	// Units is metric or imperial; empty follows the owner's profile
+	Units string
+	// Window is the moving average's length in days
+	Window int
+}
+
+// MeasurementFilter selects measurements in SI units taken in [From, Until)
+type MeasurementFilter struct {
+	UserID int64
+	Metric string
+	From   time.Time
+	Until  time.Time
+}
+
+// TrendPoint is one day with measurements
+type TrendPoint struct {
+	Date string `json:"date"`
+	// Value is the mean of the day's measurements
+	Value float64 `json:"value"`
+	// MovingAverage is the mean of the daily values over the window ending on Date
+	MovingAverage float64 `json:"movingAverage"`
+}
+
+// WeeklyChange compares a week's mean with the week before it
+type WeeklyChange struct {
+	// first date of the week, a Monday
+	Start   string  `json:"start"`
+	Average float64 `json:"average"`
+	// Change is nil when nothing was logged the week before
+	Change *float64 `json:"change"`
+}
+
+type MeasurementTrend struct {
+	UserID int64  `json:"userId"`
+	Metric string `json:"metric"`
+	Unit   string `json:"unit"`
+	// local dates (YYYY-MM-DD) the trend covers, both inclusive
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Window int             `json:"window"`
+	Points []*TrendPoint   `json:"points"`
+	Weekly []*WeeklyChange `json:"weekly"`
+}
@@ -8,4 +8,5 @@ type Error struct {
 	Detail   constants.UserMessage `json:"detail"`
 	Instance string                `json:"instance"`
 	Error    string                `json:"error"`
+	Fields   map[string]any        `json:"fields,omitempty"`
 }
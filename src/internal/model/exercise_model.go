@@ -13,15 +13,62 @@ type Exercise struct {
 	RecommendedSets     int      `json:"recommendedSets"`
 	RecommendedDuration int      `json:"recommendedDuration"`
 	Custom              bool     `json:"custom"`
+	OwnerID             int64    `json:"ownerId,omitempty"`
+	IsPublic            bool     `json:"isPublic"`
+	// Deleted custom exercises stay readable by id for the history that references them
+	Deleted bool `json:"deleted,omitempty"`
 }
 
+const (
+	ExerciseSortName     = "name"
+	ExerciseSortNameDesc = "-name"
+	ExerciseSortID       = "id"
+	ExerciseSortIDDesc   = "-id"
+
+	DefaultExercisePageSize = 50
+	MaxExercisePageSize     = 200
+)
+
+// ReadExerciseRequest filters on intensity and expertise leave out exercises
+// that have none set
 type ReadExerciseRequest struct {
 	Target    string `json:"target"`
 	Intensity string `json:"intensity"`
 	Expertise string `json:"expertise"`
+	Search    string `json:"search"`
+	Sort      string `json:"sort"`
+	Cursor    string `json:"cursor"`
+	Limit     int    `json:"limit"`
+	ViewerID  int64  `json:"viewerId"`
+}
+
+// ExerciseCursor marks the last exercise of a page; the next page starts after it
+type ExerciseCursor struct {
+	Sort string `json:"s"`
+	Name string `json:"n,omitempty"`
+	ID   int64  `json:"i"`
+}
+
+// ExerciseFilter is a validated ReadExerciseRequest with its cursor decoded
+type ExerciseFilter struct {
+	Target    string
+	Intensity string
+	Expertise string
+	Search    string
+	Sort      string
+	After     *ExerciseCursor
+	Limit     int
+	// ViewerID sees the built-in catalogue, their own custom exercises and shared ones
+	ViewerID int64
+}
+
+type ExercisePage struct {
+	Exercises  []*Exercise `json:"exercises"`
+	NextCursor string      `json:"nextCursor,omitempty"`
 }
 
 type CreateExerciseRequest struct {
+	OwnerID             int64    `json:"ownerId"`
 	Name                string   `json:"name"`
 	Description         string   `json:"description"`
 	Targets             []string `json:"targets"`
@@ -32,4 +79,26 @@ type CreateExerciseRequest struct {
 	RecommendedCount    int      `json:"recommendedCount"`
 	RecommendedSets     int      `json:"recommendedSets"`
 	RecommendedDuration int      `json:"recommendedDuration"`
+	IsPublic            bool     `json:"isPublic"`
+}
+
+type UpdateExerciseRequest struct {
+	ID                  int64    `json:"id"`
+	OwnerID             int64    `json:"ownerId"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description"`
+	Targets             []string `json:"targets"`
+	Intensity           string   `json:"intensity"`
+	Expertise           string   `json:"expertise"`
+	Image               string   `json:"image"`
+	Demo                string   `json:"demo"`
+	RecommendedCount    int      `json:"recommendedCount"`
+	RecommendedSets     int      `json:"recommendedSets"`
+	RecommendedDuration int      `json:"recommendedDuration"`
+	IsPublic            bool     `json:"isPublic"`
+}
+
+type DeleteExerciseRequest struct {
+	ID      int64 `json:"id"`
+	OwnerID int64 `json:"ownerId"`
 }
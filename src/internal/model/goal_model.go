@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+const (
+	// GoalTypeCustom is a free text goal; only its owner changes its status
+	GoalTypeCustom = "custom"
+	// GoalTypeBodyWeight reaches TargetValue, in kg, on the latest logged
+	// weight measurement
+	GoalTypeBodyWeight = "body_weight"
+	// GoalTypeOneRepMax reaches an estimated 1RM of TargetValue on ExerciseID
+	GoalTypeOneRepMax = "one_rep_max"
+	// GoalTypeWorkoutsPerWeek finishes TargetValue workouts in one week,
+	// counted from Monday
+	GoalTypeWorkoutsPerWeek = "workouts_per_week"
+
+	GoalStatusActive    = "active"
+	GoalStatusCompleted = "completed"
+	GoalStatusPaused    = "paused"
+)
+
+type Goal struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"userId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Deadline    string `json:"deadline"`
+	CreatedAt   string `json:"createdAt"`
+	Status      string `json:"status"` // "active", "completed", "paused"
+	Type        string `json:"type"`
+	// the rest is only set on measurable goals
+	ExerciseID  *int64   `json:"exerciseId,omitempty"`
+	TargetValue *float64 `json:"targetValue,omitempty"`
+	// StartValue is the body weight in kg when a body weight goal was set
+	StartValue *float64 `json:"startValue,omitempty"`
+	// CurrentValue is read from the logged data: the latest weight in kg, the
+	// best estimated 1RM, or the workouts finished this week
+	CurrentValue *float64 `json:"currentValue,omitempty"`
+	// Progress runs from 0 to 100
+	Progress    *float64   `json:"progress,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+type CreateGoalRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Deadline    string  `json:"deadline"`
+	Type        string  `json:"type,omitempty"`
+	ExerciseID  int64   `json:"exerciseId,omitempty"`
+	TargetValue float64 `json:"targetValue,omitempty"`
+}
+
+// UpdateGoalRequest changes the fields that are set and leaves the rest. A
+// goal's type and exercise are fixed once it is created.
+type UpdateGoalRequest struct {
+	ID          int64    `json:"-"`
+	UserID      int64    `json:"-"`
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Deadline    *string  `json:"deadline,omitempty"`
+	Status      *string  `json:"status,omitempty"`
+	TargetValue *float64 `json:"targetValue,omitempty"`
+}
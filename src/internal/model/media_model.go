@@ -0,0 +1,68 @@
+package model
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	// MaxMediaBytes is the largest upload accepted
+	MaxMediaBytes = 5 << 20
+	// MaxMediaPixels caps width*height, so a small file can't decode into a huge image
+	MaxMediaPixels = 24_000_000
+	// ThumbnailSize bounds the longer side of a thumbnail
+	ThumbnailSize = 320
+	// MaxPostMedia is how many images one post can attach
+	MaxPostMedia = 4
+)
+
+// Media is an uploaded image. It is served from URL, and ThumbnailURL serves
+// a smaller copy, or the original when it is already small.
+type Media struct {
+	ID           string    `json:"id"`
+	OwnerID      int64     `json:"ownerId"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	CreatedAt    time.Time `json:"createdAt"`
+	// ThumbnailType is empty when there is no separate thumbnail
+	ThumbnailType string `json:"-"`
+	// PostID is set when the media is read as a post's attachment
+	PostID int64 `json:"-"`
+}
+
+// MediaContent is a stored file being served; the caller closes Body
+type MediaContent struct {
+	Media       *Media
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// SetAvatarRequest picks an uploaded image as the user's avatar
+type SetAvatarRequest struct {
+	MediaID string `json:"mediaId"`
+}
+
+// MediaURL is where the media with the given id is served
+func MediaURL(id string) string {
+	return "/media/" + id
+}
+
+// SetURLs fills in the URLs the media is served from
+func (m *Media) SetURLs() {
+	m.URL = MediaURL(m.ID)
+	m.ThumbnailURL = m.URL + "?size=thumbnail"
+}
+
+// BlobKey is where the original file is stored
+func (m *Media) BlobKey() string {
+	return "media/" + m.ID + "/original"
+}
+
+// ThumbnailKey is where the thumbnail is stored, if there is one
+func (m *Media) ThumbnailKey() string {
+	return "media/" + m.ID + "/thumbnail"
+}
@@ -0,0 +1,32 @@
+package model
+
+const (
+	RecordTypeMaxWeight    = "max_weight"
+	RecordTypeEstimated1RM = "estimated_1rm"
+	RecordTypeMaxReps      = "max_reps"
+	RecordTypeMaxVolume    = "max_volume"
+
+	FormulaEpley   = "epley"
+	FormulaBrzycki = "brzycki"
+)
+
+type PersonalRecord struct {
+	ID         int64   `json:"id"`
+	UserID     int64   `json:"userId"`
+	ExerciseID int64   `json:"exerciseId"`
+	Type       string  `json:"type"`
+	Formula    string  `json:"formula,omitempty"`
+	Value      float64 `json:"value"`
+	Weight     float64 `json:"weight"`
+	Reps       int64   `json:"reps"`
+	SessionID  int64   `json:"sessionId"`
+	SetID      int64   `json:"setId"`
+	AchievedAt string  `json:"achievedAt"`
+}
+
+type ReadRecordsRequest struct {
+	UserID     int64  `json:"userId"`
+	ExerciseID int64  `json:"exerciseId"`
+	ViewerID   int64  `json:"viewerId"`
+	Formula    string `json:"formula"`
+}
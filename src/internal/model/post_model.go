@@ -1,28 +1,170 @@
 package model
 
+import "time"
+
 type Post struct {
-	ID       int64      `json:"id"`
-	Title    string     `json:"title"`
-	Caption  string     `json:"caption"`
-	Body     string     `json:"body"`
-	PostedBy string     `json:"postedBy"`
-	Status   string     `json:"status"`
-	Date     string     `json:"date"`
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Caption  string `json:"caption"`
+	Body     string `json:"body"`
+	PostedBy string `json:"postedBy"`
+	Status   string `json:"status"`
+	Date     string `json:"date"`
+	// Likes counts every reaction, whatever its type
 	Likes    int        `json:"likes"`
 	Comments []*Comment `json:"comments"`
-	IsLiked  bool       `json:"isLiked"`
+	// CommentsCursor pages through the rest of the comments at /posts/{id}/comments
+	CommentsCursor string `json:"commentsCursor,omitempty"`
+	// IsLiked is set when the viewer reacted at all; MyReaction is how
+	IsLiked    bool   `json:"isLiked"`
+	MyReaction string `json:"myReaction,omitempty"`
+	// Reactions counts the reactions of each type the post has
+	Reactions map[string]int `json:"reactions"`
+	// Workout is the routine or session the post shares, if any
+	Workout *WorkoutSummary `json:"workout,omitempty"`
+	// Media are the attached images, in the order they were attached
+	Media []*Media `json:"media,omitempty"`
+	// Edited is set once the author has changed the post; EditedAt is the last change
+	Edited   bool       `json:"edited"`
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	// where the post sits in a feed; the next page's cursor is built from them
+	CreatedAt time.Time `json:"-"`
+	Score     float64   `json:"-"`
 }
 
+// Comment is one comment of a thread. A deleted comment that still has
+// replies stays in place with Deleted set and its author and text blanked.
 type Comment struct {
 	ID       int64      `json:"id"`
+	PostID   int64      `json:"postId"`
+	ParentID int64      `json:"parentId,omitempty"`
 	Username string     `json:"username"`
 	Comment  string     `json:"comment"`
 	Date     string     `json:"date"`
-	Replies  []*Comment `json:"replies"`
+	Edited   bool       `json:"edited"`
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	Deleted  bool       `json:"deleted"`
+	// ReplyCount counts every direct reply; Replies holds the ones loaded with
+	// the thread and RepliesCursor pages through the rest at /posts/comments/{id}/replies
+	ReplyCount    int        `json:"replyCount"`
+	Replies       []*Comment `json:"replies"`
+	RepliesCursor string     `json:"repliesCursor,omitempty"`
+	// where the comment sits in its thread
+	CreatedAt time.Time `json:"-"`
+	Level     int       `json:"-"`
 }
 
+const (
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 100
+
+	// a page of comments comes with this many levels of replies below each
+	// comment, and at most ThreadReplies replies per comment
+	ThreadDepth   = 2
+	ThreadReplies = 3
+
+	// MaxCommentDepth caps how deep replies nest; top-level comments are at depth 0
+	MaxCommentDepth = 8
+)
+
+// ReadCommentsRequest reads the top-level comments of PostID, or the replies
+// to ParentID when it is set
+type ReadCommentsRequest struct {
+	ViewerID int64  `json:"viewerId"`
+	PostID   int64  `json:"postId"`
+	ParentID int64  `json:"parentId"`
+	Cursor   string `json:"cursor"`
+	Limit    int    `json:"limit"`
+}
+
+// CommentCursor marks the last comment of a page in one thread
+type CommentCursor struct {
+	PostID    int64     `json:"o"`
+	ParentID  int64     `json:"p"`
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"i"`
+}
+
+// CommentFilter loads a page of comments under each of its posts, or under
+// ParentID, together with Depth levels of their replies
+type CommentFilter struct {
+	PostIDs  []int64
+	ParentID int64
+	After    *CommentCursor
+	Limit    int
+	Depth    int
+	// Replies caps the replies loaded per comment
+	Replies int
+}
+
+type CommentPage struct {
+	Comments   []*Comment `json:"comments"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// CommentRef is what the service checks before touching a comment
+type CommentRef struct {
+	ID           int64
+	PostID       int64
+	AuthorID     int64
+	PostAuthorID int64
+	// Depth counts the comment's ancestors
+	Depth   int
+	Deleted bool
+}
+
+type UpdateCommentRequest struct {
+	ID      int64  `json:"-"`
+	Comment string `json:"comment"`
+}
+
+const (
+	// FeedScopeFollowing is the viewer's own posts and those of the users they follow
+	FeedScopeFollowing = "following"
+	// FeedScopeDiscover is every post from a public profile
+	FeedScopeDiscover = "discover"
+
+	FeedSortRecent = "recent"
+	// FeedSortRanked weighs likes and comments against the post's age
+	FeedSortRanked = "ranked"
+
+	DefaultFeedPageSize = 20
+	MaxFeedPageSize     = 100
+)
+
 type ReadPostRequest struct {
-	Followings bool `json:"followings"`
+	ViewerID int64  `json:"viewerId"`
+	Scope    string `json:"scope"`
+	Sort     string `json:"sort"`
+	Cursor   string `json:"cursor"`
+	Limit    int    `json:"limit"`
+}
+
+// PostFeedCursor marks the last post of a page; the next page starts after it
+type PostFeedCursor struct {
+	Scope string `json:"c"`
+	Sort  string `json:"s"`
+	// ranked feeds are scored as of the first page so later pages line up
+	AsOf      time.Time `json:"a,omitempty"`
+	CreatedAt time.Time `json:"t,omitempty"`
+	Score     float64   `json:"r,omitempty"`
+	ID        int64     `json:"i"`
+}
+
+// PostFeedFilter is a validated ReadPostRequest with its cursor decoded
+type PostFeedFilter struct {
+	ViewerID int64
+	Scope    string
+	Sort     string
+	// AsOf is when ranked scores are computed; posts created later are left out
+	AsOf  time.Time
+	After *PostFeedCursor
+	Limit int
+}
+
+type PostPage struct {
+	Posts      []*Post `json:"posts"`
+	NextCursor string  `json:"nextCursor,omitempty"`
 }
 
 type CreatePostRequest struct {
@@ -31,15 +173,58 @@ type CreatePostRequest struct {
 	Body     string `json:"body"`
 	PostedBy int64  `json:"postedBy"`
 	Status   string `json:"status"`
+	// a post can share one of the author's routines or finished sessions
+	RoutineID int64 `json:"routineId,omitempty"`
+	SessionID int64 `json:"sessionId,omitempty"`
+	// MediaIDs attaches images the author uploaded to /media
+	MediaIDs []string `json:"mediaIds,omitempty"`
+}
+
+// WorkoutSummary is what a post shows of the routine or session it shares
+type WorkoutSummary struct {
+	PostID int64 `json:"-"`
+	// RoutineID is the routine viewers can copy; a session carries the
+	// routine it followed, if any
+	RoutineID int64                     `json:"routineId,omitempty"`
+	SessionID int64                     `json:"sessionId,omitempty"`
+	Name      string                    `json:"name"`
+	Exercises []*WorkoutSummaryExercise `json:"exercises"`
+	// the rest is only filled in for a session
+	TotalVolume     float64           `json:"totalVolume"`
+	DurationSeconds int64             `json:"durationSeconds"`
+	Records         []*PersonalRecord `json:"records"`
 }
 
+// WorkoutSummaryExercise is an exercise of a shared routine, or one done in a
+// shared session with the sets logged for it
+type WorkoutSummaryExercise struct {
+	ExerciseID int64   `json:"exerciseId"`
+	Name       string  `json:"name"`
+	Sets       int     `json:"sets"`
+	Volume     float64 `json:"volume"`
+}
+
+// UpdatePostRequest changes the fields that are set and leaves the rest
 type UpdatePostRequest struct {
-	ID       int64  `json:"id"`
-	Title    string `json:"title"`
-	Caption  string `json:"caption"`
-	Body     string `json:"body"`
-	PostedBy int64  `json:"postedBy"`
-	Status   string `json:"status"`
+	ID      int64   `json:"-"`
+	Title   *string `json:"title,omitempty"`
+	Caption *string `json:"caption,omitempty"`
+	Body    *string `json:"body,omitempty"`
+	Status  *string `json:"status,omitempty"`
+}
+
+// PostRevision is a version of a post that an edit replaced
+type PostRevision struct {
+	ID      int64  `json:"id"`
+	PostID  int64  `json:"postId"`
+	Title   string `json:"title"`
+	Caption string `json:"caption"`
+	Body    string `json:"body"`
+	Status  string `json:"status"`
+	// when this version was posted or last edited
+	WrittenAt time.Time `json:"writtenAt"`
+	// when the next edit replaced it
+	ReplacedAt time.Time `json:"replacedAt"`
 }
 
 type DeletePostRequest struct {
@@ -59,12 +244,79 @@ type CommentOnCommentRequest struct {
 	Comment   string `json:"comment"`
 }
 
+// LikePostRequest reacts to a post, replacing the user's earlier reaction.
+// Reaction defaults to a like.
 type LikePostRequest struct {
-	PostID int64 `json:"postId"`
-	UserID int64 `json:"userId"`
+	PostID   int64  `json:"postId"`
+	UserID   int64  `json:"-"`
+	Reaction string `json:"reaction,omitempty"`
 }
 
 type UnikePostRequest struct {
 	PostID int64 `json:"postId"`
-	UserID int64 `json:"userId"`
+	UserID int64 `json:"-"`
+}
+
+const (
+	ReactionLike   = "like"
+	ReactionFire   = "fire"
+	ReactionStrong = "strong"
+	ReactionClap   = "clap"
+
+	DefaultReactionPageSize = 50
+	MaxReactionPageSize     = 100
+)
+
+// ReactionTypes are the reactions a post can get
+var ReactionTypes = []string{ReactionLike, ReactionFire, ReactionStrong, ReactionClap}
+
+// Reaction is one user's reaction to a post
+type Reaction struct {
+	UserID   int64  `json:"userId"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Avatar   string `json:"avatar,omitempty"`
+	Reaction string `json:"reaction"`
+	// when the user last reacted
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ReactionCount is how many reactions of one type a post has, and whether
+// the viewer's is one of them
+type ReactionCount struct {
+	PostID   int64
+	Reaction string
+	Count    int
+	Mine     bool
+}
+
+// ReadReactionsRequest lists who reacted to a post, newest first, optionally
+// only those who reacted with Type
+type ReadReactionsRequest struct {
+	ViewerID int64  `json:"viewerId"`
+	PostID   int64  `json:"postId"`
+	Type     string `json:"type"`
+	Cursor   string `json:"cursor"`
+	Limit    int    `json:"limit"`
+}
+
+// ReactionCursor marks the last reaction of a page
+type ReactionCursor struct {
+	PostID    int64     `json:"o"`
+	Type      string    `json:"y,omitempty"`
+	CreatedAt time.Time `json:"t"`
+	UserID    int64     `json:"u"`
+}
+
+// ReactionFilter is a validated ReadReactionsRequest with its cursor decoded
+type ReactionFilter struct {
+	PostID int64
+	Type   string
+	After  *ReactionCursor
+	Limit  int
+}
+
+type ReactionPage struct {
+	Reactions  []*Reaction `json:"reactions"`
+	NextCursor string      `json:"nextCursor,omitempty"`
 }
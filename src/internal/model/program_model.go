@@ -0,0 +1,111 @@
+package model
+
+import "time"
+
+const (
+	// loads of a program day
+	LoadTypePercent = "percent" // percentage of the estimated one rep max
+	LoadTypeRPE     = "rpe"     // rate of perceived exertion, 1 to 10
+
+	EnrollmentActive    = "active"
+	EnrollmentCompleted = "completed"
+	EnrollmentCancelled = "cancelled"
+
+	MaxProgramWeeks = 52
+)
+
+var LoadTypes = []string{LoadTypePercent, LoadTypeRPE}
+
+// Program is a multi-week plan built on its author's routines
+type Program struct {
+	ID          int64          `json:"id"`
+	UserID      int64          `json:"userId"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Visibility  string         `json:"visibility"`
+	Weeks       []*ProgramWeek `json:"weeks"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+type ProgramWeek struct {
+	// weeks are numbered from 1, in order
+	Number int           `json:"number"`
+	Name   string        `json:"name,omitempty"`
+	Days   []*ProgramDay `json:"days"`
+}
+
+type ProgramDay struct {
+	// 0 (Sunday) to 6 (Saturday)
+	DayOfWeek int64   `json:"dayOfWeek"`
+	RoutineID int64   `json:"routineId"`
+	LoadType  string  `json:"loadType"`
+	Load      float64 `json:"load"`
+}
+
+type CreateProgramRequest struct {
+	UserID      int64          `json:"-"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Visibility  string         `json:"visibility"`
+	Weeks       []*ProgramWeek `json:"weeks"`
+}
+
+// ProgramEnrollment is a user following a program. Its schedules are
+// generated a week at a time, as the weeks come. Deleting the program cancels
+// its active enrollments, which keep their rows with ProgramID 0 and no
+// ProgramName.
+type ProgramEnrollment struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"userId"`
+	ProgramID   int64  `json:"programId"`
+	ProgramName string `json:"programName"`
+	Status      string `json:"status"`
+	// local date (YYYY-MM-DD) of the first day of week 1
+	StartedOn            string `json:"startedOn"`
+	CurrentWeek          int    `json:"currentWeek"`
+	TotalWeeks           int    `json:"totalWeeks"`
+	TimeSlot             string `json:"timeSlot"`
+	RoutineLengthMinutes int64  `json:"routineLengthMinutes"`
+	Timezone             string `json:"timezone"`
+	// the current week, for active enrollments
+	Days []*EnrolledDay `json:"days,omitempty"`
+	// the program's routine ids mapped to the enrollee's copies
+	Routines map[int64]int64 `json:"-"`
+}
+
+// EnrolledDay is one program day of the current week, on the enrollee's routine
+type EnrolledDay struct {
+	Date       string  `json:"date"`
+	DayOfWeek  int64   `json:"dayOfWeek"`
+	RoutineID  int64   `json:"routineId"`
+	ScheduleID int64   `json:"scheduleId,omitempty"`
+	LoadType   string  `json:"loadType"`
+	Load       float64 `json:"load"`
+	// weights for percentage loads, on exercises with an estimated 1RM
+	Targets []*LoadTarget `json:"targets,omitempty"`
+}
+
+type LoadTarget struct {
+	ExerciseID int64 `json:"exerciseId"`
+	// the estimated 1RM the weight is a percentage of
+	EstimatedOneRepMax float64 `json:"estimatedOneRepMax"`
+	Weight             float64 `json:"weight"`
+}
+
+type EnrollRequest struct {
+	ProgramID int64 `json:"-"`
+	UserID    int64 `json:"-"`
+	// local date week 1 starts on, today when empty
+	StartsOn             string `json:"startsOn,omitempty"`
+	TimeSlot             string `json:"timeSlot"`
+	RoutineLengthMinutes int64  `json:"routineLengthMinutes"`
+	Timezone             string `json:"timezone"`
+}
+
+// EnrollmentSchedule is a schedule generated for one program day
+type EnrollmentSchedule struct {
+	EnrollmentID int64
+	ScheduleID   int64
+	Week         int
+	DayOfWeek    int64
+}
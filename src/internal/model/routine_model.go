@@ -0,0 +1,53 @@
+package model
+
+const (
+	RoutineVisibilityPrivate   = "private"
+	RoutineVisibilityFollowers = "followers"
+	RoutineVisibilityPublic    = "public"
+
+	RoutineSortNewest  = "newest"
+	RoutineSortPopular = "popular"
+
+	DefaultRoutinePageSize = 20
+	MaxRoutinePageSize     = 100
+)
+
+// RoutineVisibilities are who a routine can be shared with, narrowest first
+var RoutineVisibilities = []string{RoutineVisibilityPrivate, RoutineVisibilityFollowers, RoutineVisibilityPublic}
+
+// RoutineSource is the routine a fork was made from. RoutineID is 0 once the
+// source is deleted; the user stays credited until their account is.
+type RoutineSource struct {
+	RoutineID int64  `json:"routineId,omitempty"`
+	UserID    int64  `json:"userId,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// ReadPublicRoutinesRequest browses public routines, newest or most forked
+// first, optionally only those whose name or description matches Search
+type ReadPublicRoutinesRequest struct {
+	Search string `json:"search"`
+	Sort   string `json:"sort"`
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+// RoutineCursor marks the last routine of a page
+type RoutineCursor struct {
+	Sort      string `json:"s"`
+	ForkCount int    `json:"f,omitempty"`
+	ID        int64  `json:"i"`
+}
+
+// PublicRoutineFilter is a validated ReadPublicRoutinesRequest with its cursor decoded
+type PublicRoutineFilter struct {
+	Search string
+	Sort   string
+	After  *RoutineCursor
+	Limit  int
+}
+
+type RoutinePage struct {
+	Routines   []*ExerciseRoutine `json:"routines"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Schedule struct {
 	ID                   int64   `json:"id"`
 	Name                 string  `json:"name"`
@@ -8,6 +10,11 @@ type Schedule struct {
 	RoutineIDs           []int64 `json:"routineIds"`
 	TimeSlot             string  `json:"timeSlot"`
 	RoutineLengthMinutes int64   `json:"routineLengthMinutes"`
+	// IANA time zone the time slot is read in, UTC when empty
+	Timezone string `json:"timezone"`
+	// optional first and last local dates (YYYY-MM-DD) the schedule applies to
+	StartsOn string `json:"startsOn,omitempty"`
+	EndsOn   string `json:"endsOn,omitempty"`
 }
 
 type CreateScheduleRequest struct {
@@ -17,6 +24,9 @@ type CreateScheduleRequest struct {
 	RoutineIDs           []int64 `json:"routineIds"`
 	TimeSlot             string  `json:"timeSlot"`
 	RoutineLengthMinutes int64   `json:"routineLengthMinutes"`
+	Timezone             string  `json:"timezone"`
+	StartsOn             string  `json:"startsOn,omitempty"`
+	EndsOn               string  `json:"endsOn,omitempty"`
 }
 
 type UpdateScheduleRequest struct {
@@ -27,8 +37,165 @@ type UpdateScheduleRequest struct {
 	RoutineIDs           []int64 `json:"routineIds"`
 	TimeSlot             string  `json:"timeSlot"`
 	RoutineLengthMinutes int64   `json:"routineLengthMinutes"`
+	Timezone             string  `json:"timezone"`
+	StartsOn             string  `json:"startsOn,omitempty"`
+	EndsOn               string  `json:"endsOn,omitempty"`
 }
 
 type DeleteScheduleRequest struct {
 	ID int64 `json:"id"`
 }
+
+// CalendarEntry is a schedule as it is exported to a calendar
+type CalendarEntry struct {
+	Schedule
+	// names of the schedule's routines, in schedule order
+	RoutineNames []string
+	// the first weekly occurrence is the first matching day on or after this
+	CreatedAt time.Time
+	// skipped and rescheduled occurrences, by planned date
+	Exceptions []*ScheduleException
+}
+
+type CalendarFeed struct {
+	// secret subscription URL; anyone holding it can read the user's schedules
+	URL string `json:"url"`
+}
+
+// ScheduleException changes one planned occurrence of a schedule
+type ScheduleException struct {
+	ID         int64 `json:"id"`
+	ScheduleID int64 `json:"scheduleId"`
+	// local date (YYYY-MM-DD) the occurrence was planned for
+	Date string `json:"date"`
+	// when the occurrence happens instead; nil skips it
+	RescheduledTo *time.Time `json:"rescheduledTo,omitempty"`
+}
+
+type CreateScheduleExceptionRequest struct {
+	ScheduleID    int64      `json:"-"`
+	Date          string     `json:"date"`
+	RescheduledTo *time.Time `json:"rescheduledTo,omitempty"`
+}
+
+type DeleteScheduleExceptionRequest struct {
+	ScheduleID int64
+	Date       string
+}
+
+// ScheduleOccurrence is one concrete workout planned by a schedule
+type ScheduleOccurrence struct {
+	ScheduleID int64   `json:"scheduleId"`
+	Name       string  `json:"name"`
+	RoutineIDs []int64 `json:"routineIds"`
+	// local date the occurrence was planned for, before any reschedule
+	Date     string    `json:"date"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	// true when a one-off reschedule moved it away from its usual slot
+	Rescheduled bool `json:"rescheduled"`
+}
+
+type ReadOccurrencesRequest struct {
+	UserID int64
+	From   time.Time
+	To     time.Time
+}
+
+// ScheduleExceptionFilter selects the exceptions that can affect occurrences in
+// [From, To): those planned for a local date in [FromDate, ToDate], or moved into the range
+type ScheduleExceptionFilter struct {
+	UserID   int64
+	FromDate string
+	ToDate   string
+	From     time.Time
+	To       time.Time
+}
+
+// occurrence statuses; upcoming occurrences can still be completed and are
+// left out of adherence
+const (
+	OccurrenceCompleted = "completed"
+	OccurrenceMissed    = "missed"
+	OccurrenceSkipped   = "skipped"
+	OccurrenceUpcoming  = "upcoming"
+)
+
+// OccurrenceMark is a status the user set by hand for one occurrence
+type OccurrenceMark struct {
+	ScheduleID int64  `json:"scheduleId"`
+	Date       string `json:"date"`
+	Status     string `json:"status"`
+	// workout the occurrence was completed with, if the user named one
+	SessionID int64 `json:"sessionId,omitempty"`
+}
+
+type MarkOccurrenceRequest struct {
+	ScheduleID int64  `json:"-"`
+	Date       string `json:"-"`
+	Status     string `json:"status"`
+	SessionID  int64  `json:"sessionId,omitempty"`
+}
+
+type DeleteOccurrenceMarkRequest struct {
+	ScheduleID int64
+	Date       string
+}
+
+// OccurrenceMarkFilter selects a user's marks for local dates in [FromDate, ToDate]
+type OccurrenceMarkFilter struct {
+	UserID   int64
+	FromDate string
+	ToDate   string
+}
+
+// CompletedWorkout is a finished workout session as adherence matches it
+type CompletedWorkout struct {
+	SessionID  int64
+	RoutineID  int64
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// TrackedOccurrence is an occurrence with how it went
+type TrackedOccurrence struct {
+	ScheduleOccurrence
+	Status string `json:"status"`
+	// workout that completed the occurrence
+	SessionID int64 `json:"sessionId,omitempty"`
+	// true when the user set the status rather than it being matched from workouts
+	Manual bool `json:"manual"`
+}
+
+// AdherencePeriod sums up the occurrences planned for one week or month
+type AdherencePeriod struct {
+	// first local date of the week (a Monday) or month
+	Start     string `json:"start"`
+	Planned   int    `json:"planned"`
+	Completed int    `json:"completed"`
+	Missed    int    `json:"missed"`
+	Skipped   int    `json:"skipped"`
+	// completed share of the completed and missed occurrences, from 0 to 100;
+	// nil when none are settled yet
+	Percent *float64 `json:"percent"`
+}
+
+type Adherence struct {
+	UserID int64 `json:"userId"`
+	// local dates (YYYY-MM-DD) the report covers, both inclusive
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Weekly  []*AdherencePeriod `json:"weekly"`
+	Monthly []*AdherencePeriod `json:"monthly"`
+	// consecutive completed occurrences; skipped ones don't break a streak
+	CurrentStreak int                  `json:"currentStreak"`
+	LongestStreak int                  `json:"longestStreak"`
+	Occurrences   []*TrackedOccurrence `json:"occurrences"`
+}
+
+type ReadAdherenceRequest struct {
+	UserID int64
+	// local dates (YYYY-MM-DD), both inclusive
+	From string
+	To   string
+}
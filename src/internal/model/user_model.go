@@ -15,6 +15,7 @@ type User struct {
 	GoogleID     string            `json:"googleId,omitempty"`
 	Provider     string            `json:"provider,omitempty"`
 	IsVerified   bool              `json:"isVerified"`
+	Role         string            `json:"role,omitempty"`
 	IsPrivate    bool              `json:"isPrivate"`
 	ShowMetricsToFollowers bool    `json:"showMetricsToFollowers"`
 	Posts        []Post            `json:"posts,omitempty"`
@@ -24,25 +25,40 @@ type User struct {
 	Routines     []ExerciseRoutine `json:"routines,omitempty"`
 }
 
-type Goal struct {
-	ID          int64  `json:"id"`
-	UserID      int64  `json:"userId"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Deadline    string `json:"deadline"`
-	CreatedAt   string `json:"createdAt"`
-	Status      string `json:"status"` // "active", "completed", "paused"
-}
-
 type ExerciseRoutine struct {
 	ID          int64      `json:"id"`
 	UserID      int64      `json:"userId"`
 	Name        string     `json:"name"`
 	Description string     `json:"description"`
 	Exercises   []Exercise `json:"exercises"`
-	ExerciseIDs []int64    `json:"exerciseIds"`
-	CreatedAt   string     `json:"createdAt"`
-	IsActive    bool       `json:"isActive"`
+	// ExerciseIDs are in the order the exercises are done
+	ExerciseIDs []int64 `json:"exerciseIds"`
+	// Entries holds each exercise's targets, in the same order
+	Entries   []RoutineExercise `json:"entries"`
+	CreatedAt string            `json:"createdAt"`
+	IsActive  bool              `json:"isActive"`
+	// Visibility is private, followers or public
+	Visibility string `json:"visibility"`
+	// Username is the owner's, filled in when browsing public routines
+	Username string `json:"username,omitempty"`
+	// ForkedFrom credits the routine this one was forked from
+	ForkedFrom *RoutineSource `json:"forkedFrom,omitempty"`
+	ForkCount  int            `json:"forkCount"`
+}
+
+// RoutineExercise is an exercise's place in a routine and what to aim for on
+// it; targets that aren't set are left to the lifter
+type RoutineExercise struct {
+	ExerciseID int64 `json:"exerciseId"`
+	// Position counts from 0 and follows the order the entries are sent in
+	Position    int  `json:"position"`
+	TargetSets  *int `json:"targetSets,omitempty"`
+	RepsMin     *int `json:"repsMin,omitempty"`
+	RepsMax     *int `json:"repsMax,omitempty"`
+	RestSeconds *int `json:"restSeconds,omitempty"`
+	// exercises sharing a superset group are done back to back, so they have
+	// to be next to each other
+	SupersetGroup *int `json:"supersetGroup,omitempty"`
 }
 
 type CreateUserRequest struct {
@@ -80,23 +96,31 @@ type DeleteUserRequest struct {
 	ID int64 `json:"id"`
 }
 
-type CreateGoalRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Deadline    string `json:"deadline"`
+type CreateRoutineRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	ExerciseIDs []int64 `json:"exerciseIds"`
+	// Exercises gives the exercises with their targets; when set it is used
+	// instead of ExerciseIDs
+	Exercises []RoutineExercise `json:"exercises,omitempty"`
+	// Visibility defaults to private
+	Visibility string `json:"visibility,omitempty"`
+	// Source is set on forks; the source's exercise settings are copied too
+	Source *RoutineSource `json:"-"`
 }
 
-type UpdateGoalRequest struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Deadline    string `json:"deadline"`
-	Status      string `json:"status"`
+// UpdateRoutineRequest changes the fields that are set. Exercises replaces the
+// routine's exercises, in the order given, along with their targets.
+type UpdateRoutineRequest struct {
+	ID          int64              `json:"-"`
+	Name        *string            `json:"name,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Exercises   *[]RoutineExercise `json:"exercises,omitempty"`
+	Visibility  *string            `json:"visibility,omitempty"`
 }
 
-type CreateRoutineRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
+// ReorderRoutineRequest lists every exercise in the routine in its new order
+type ReorderRoutineRequest struct {
 	ExerciseIDs []int64 `json:"exerciseIds"`
 }
 
@@ -1,32 +1,50 @@
 package model
 
-type Workout struct {
-	ID        int64                `json:"id"`
-	Name      string               `json:"name"`
-	Frequency string               `json:"frequency"`
-	NextRound string               `json:"nextRound"`
-	Exercises []RegisteredExercise `json:"exercises"`
+type WorkoutSession struct {
+	ID         int64         `json:"id"`
+	UserID     int64         `json:"userId"`
+	RoutineID  int64         `json:"routineId"`
+	Name       string        `json:"name"`
+	Notes      string        `json:"notes"`
+	StartedAt  string        `json:"startedAt"`
+	FinishedAt string        `json:"finishedAt,omitempty"`
+	Status     string        `json:"status"` // "active", "finished"
+	Sets       []*WorkoutSet `json:"sets"`
 }
 
-type RegisteredExercise struct {
-	StartTime string   `json:"startTime"`
-	EndTime   string   `json:"endTime"`
-	Count     int      `json:"count"`
-	Sets      int      `json:"sets"`
-	Duration  int      `json:"duration"`
-	Exercise  Exercise `json:"exercise"`
+type WorkoutSet struct {
+	ID          int64   `json:"id"`
+	SessionID   int64   `json:"sessionId"`
+	ExerciseID  int64   `json:"exerciseId"`
+	SetNumber   int64   `json:"setNumber"`
+	Weight      float64 `json:"weight"`
+	Reps        int64   `json:"reps"`
+	RPE         float64 `json:"rpe"`
+	RestSeconds int64   `json:"restSeconds"`
+	LoggedAt    string  `json:"loggedAt"`
+	// NewRecords lists the personal records this set broke, if any
+	NewRecords []*PersonalRecord `json:"newRecords,omitempty"`
 }
 
-type CreateWorkoutRequest struct {
-	Name      string               `json:"name"`
-	Frequency string               `json:"frequency"`
-	NextRound string               `json:"nextRound"`
-	Exercises []RegisteredExercise `json:"exercises"`
+type StartWorkoutSessionRequest struct {
+	UserID    int64  `json:"userId"`
+	RoutineID int64  `json:"routineId"`
+	Name      string `json:"name"`
+	Notes     string `json:"notes"`
 }
 
-type UpdateWorkoutRequest struct {
-	Name      string               `json:"name"`
-	Frequency string               `json:"frequency"`
-	NextRound string               `json:"nextRound"`
-	Exercises []RegisteredExercise `json:"exercises"`
+type LogWorkoutSetRequest struct {
+	SessionID   int64   `json:"sessionId"`
+	UserID      int64   `json:"userId"`
+	ExerciseID  int64   `json:"exerciseId"`
+	Weight      float64 `json:"weight"`
+	Reps        int64   `json:"reps"`
+	RPE         float64 `json:"rpe"`
+	RestSeconds int64   `json:"restSeconds"`
+}
+
+type FinishWorkoutSessionRequest struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"userId"`
+	Notes  string `json:"notes"`
 }
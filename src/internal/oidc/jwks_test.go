@@ -0,0 +1,124 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type jwksServer struct {
+	*httptest.Server
+	keys  map[string]*rsa.PublicKey
+	hits  atomic.Int32
+	cache string
+}
+
+func newJWKSServer(t *testing.T, keys map[string]*rsa.PublicKey) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: keys, cache: "public, max-age=600"}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.hits.Add(1)
+		set := map[string]any{"keys": []map[string]string{}}
+		for kid, key := range s.keys {
+			set["keys"] = append(set["keys"].([]map[string]string), map[string]string{
+				"kid": kid, "kty": "RSA", "alg": "RS256", "use": "sig",
+				"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		w.Header().Set("Cache-Control", s.cache)
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestJWKSKeySource_CachesKeys(t *testing.T) {
+	key := newTestKey(t)
+	srv := newJWKSServer(t, map[string]*rsa.PublicKey{"k1": &key.PublicKey})
+	now := testNow
+	source := NewJWKSKeySource(srv.URL, srv.Client())
+	source.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		got, err := source.Key(context.Background(), "k1")
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if got.N.Cmp(key.N) != 0 || got.E != key.E {
+			t.Fatalf("fetched key does not match")
+		}
+	}
+	if hits := srv.hits.Load(); hits != 1 {
+		t.Fatalf("expected a single fetch, got %d", hits)
+	}
+
+	// max-age=600 has passed
+	now = now.Add(11 * time.Minute)
+	if _, err := source.Key(context.Background(), "k1"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if hits := srv.hits.Load(); hits != 2 {
+		t.Fatalf("expected a refetch after max-age, got %d fetches", hits)
+	}
+}
+
+func TestJWKSKeySource_UnknownKidRefetches(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	srv := newJWKSServer(t, map[string]*rsa.PublicKey{"old": &oldKey.PublicKey})
+	now := testNow
+	source := NewJWKSKeySource(srv.URL, srv.Client())
+	source.now = func() time.Time { return now }
+
+	if _, err := source.Key(context.Background(), "old"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// the provider rotates keys before the cache expires
+	srv.keys = map[string]*rsa.PublicKey{"old": &oldKey.PublicKey, "new": &newKey.PublicKey}
+
+	// refetches for unknown kids are rate limited
+	if _, err := source.Key(context.Background(), "new"); err == nil {
+		t.Fatalf("expected unknown kid right after a fetch")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := source.Key(context.Background(), "new"); err != nil {
+		t.Fatalf("expected the rotated key to be fetched: %v", err)
+	}
+	if hits := srv.hits.Load(); hits != 2 {
+		t.Fatalf("expected 2 fetches, got %d", hits)
+	}
+}
+
+func TestJWKSKeySource_EndpointDown(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	source := NewJWKSKeySource(srv.URL, srv.Client())
+	if _, err := source.Key(context.Background(), "k1"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"public, max-age=19845, must-revalidate": 19845 * time.Second,
+		"max-age=0":                              defaultJWKSTTL,
+		"no-cache":                               defaultJWKSTTL,
+		"":                                       defaultJWKSTTL,
+	}
+	for header, want := range tests {
+		if got := maxAge(header); got != want {
+			t.Errorf("maxAge(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// googleIssuers are the two spellings Google uses for the iss claim
+var googleIssuers = []string{"accounts.google.com", "https://accounts.google.com"}
+
+type Verifier struct {
+	clientID string
+	issuers  []string
+	keys     KeySource
+	now      func() time.Time
+}
+
+// NewGoogleVerifier accepts Google ID tokens issued to clientID, checking their
+// signature against keys (normally NewJWKSKeySource(GoogleJWKSURL, nil))
+func NewGoogleVerifier(clientID string, keys KeySource) service.IDTokenVerifier {
+	return &Verifier{clientID: clientID, issuers: googleIssuers, keys: keys, now: time.Now}
+}
+
+type googleClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+func (v *Verifier) Verify(ctx context.Context, idToken string) (*model.GoogleIdentity, error) {
+	var claims googleClaims
+	_, err := jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("token has no kid header")
+		}
+		return v.keys.Key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(v.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if !v.trustedIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("invalid id token: untrusted issuer %q", claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id token: missing subject")
+	}
+
+	return &model.GoogleIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+func (v *Verifier) trustedIssuer(iss string) bool {
+	for _, trusted := range v.issuers {
+		if iss == trusted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "client.apps.googleusercontent.com"
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func googleToken(t *testing.T, key *rsa.PrivateKey, kid string, edit func(jwt.MapClaims)) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testClientID,
+		"sub":            "1234567890",
+		"email":          "ada@example.com",
+		"email_verified": true,
+		"name":           "Ada Lovelace",
+		"iat":            testNow.Add(-time.Minute).Unix(),
+		"exp":            testNow.Add(time.Hour).Unix(),
+	}
+	if edit != nil {
+		edit(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed
+}
+
+func newTestVerifier(keys KeySource) *Verifier {
+	v := NewGoogleVerifier(testClientID, keys).(*Verifier)
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestVerifier_Verify_OK(t *testing.T) {
+	key := newTestKey(t)
+	v := newTestVerifier(StaticKeys{"k1": &key.PublicKey})
+
+	got, err := v.Verify(context.Background(), googleToken(t, key, "k1", nil))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Subject != "1234567890" || got.Email != "ada@example.com" || !got.EmailVerified || got.Name != "Ada Lovelace" {
+		t.Fatalf("unexpected identity: %+v", got)
+	}
+}
+
+func TestVerifier_Verify_EmailVerifiedAsString(t *testing.T) {
+	key := newTestKey(t)
+	v := newTestVerifier(StaticKeys{"k1": &key.PublicKey})
+
+	got, err := v.Verify(context.Background(), googleToken(t, key, "k1", func(c jwt.MapClaims) {
+		c["iss"] = "accounts.google.com"
+		c["email_verified"] = "true"
+	}))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !got.EmailVerified {
+		t.Fatalf("expected email to be verified")
+	}
+}
+
+func TestVerifier_Verify_Rejected(t *testing.T) {
+	key := newTestKey(t)
+	other := newTestKey(t)
+	v := newTestVerifier(StaticKeys{"k1": &key.PublicKey})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong audience", googleToken(t, key, "k1", func(c jwt.MapClaims) { c["aud"] = "someone-else" })},
+		{"untrusted issuer", googleToken(t, key, "k1", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" })},
+		{"expired", googleToken(t, key, "k1", func(c jwt.MapClaims) { c["exp"] = testNow.Add(-time.Minute).Unix() })},
+		{"no expiry", googleToken(t, key, "k1", func(c jwt.MapClaims) { delete(c, "exp") })},
+		{"issued in the future", googleToken(t, key, "k1", func(c jwt.MapClaims) { c["iat"] = testNow.Add(time.Hour).Unix() })},
+		{"missing subject", googleToken(t, key, "k1", func(c jwt.MapClaims) { delete(c, "sub") })},
+		{"unknown key", googleToken(t, key, "k2", nil)},
+		{"wrong key", googleToken(t, other, "k1", nil)},
+		{"hmac signed", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"aud": testClientID, "iss": "accounts.google.com", "sub": "1", "exp": testNow.Add(time.Hour).Unix()})
+			token.Header["kid"] = "k1"
+			s, _ := token.SignedString([]byte("secret"))
+			return s
+		}()},
+		{"garbage", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Verify(context.Background(), tt.token); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Package policy holds the resource-level authorization rules services check
+// before acting on another user's data.
+package policy
+
+import (
+	"fmt"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+)
+
+type policy struct {
+	userRepository         repository.UserRepository
+	relationshipRepository repository.RelationshipRepository
+}
+
+func NewAccessPolicy(ur repository.UserRepository, rr repository.RelationshipRepository) service.AccessPolicy {
+	return &policy{userRepository: ur, relationshipRepository: rr}
+}
+
+func (p *policy) CanModify(actorID, ownerID int64) error {
+	if actorID != 0 && actorID == ownerID {
+		return nil
+	}
+	isAdmin, err := p.isAdmin(actorID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return fmt.Errorf("%w: this belongs to another user", util.ErrForbidden)
+	}
+	return nil
+}
+
+func (p *policy) CanView(actorID, ownerID int64) error {
+	if actorID != 0 && actorID == ownerID {
+		return nil
+	}
+
+	owner, err := p.userRepository.ReadUserByID(ownerID)
+	if err != nil {
+		return err
+	}
+	if !owner.IsPrivate {
+		return nil
+	}
+
+	isAdmin, err := p.isAdmin(actorID)
+	if err != nil || isAdmin {
+		return err
+	}
+	followers, err := p.relationshipRepository.ReadUserFollowers(ownerID)
+	if err != nil {
+		return err
+	}
+	for _, id := range followers {
+		if id == actorID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: this profile is private", util.ErrForbidden)
+}
+
+func (p *policy) CanViewMetrics(actorID, ownerID int64) error {
+	if actorID != 0 && actorID == ownerID {
+		return nil
+	}
+
+	owner, err := p.userRepository.ReadUserByID(ownerID)
+	if err != nil {
+		return err
+	}
+	isAdmin, err := p.isAdmin(actorID)
+	if err != nil || isAdmin {
+		return err
+	}
+	followers, err := p.relationshipRepository.ReadUserFollowers(ownerID)
+	if err != nil {
+		return err
+	}
+	isFollower := false
+	for _, id := range followers {
+		if id == actorID {
+			isFollower = true
+			break
+		}
+	}
+
+	if owner.IsPrivate && !isFollower {
+		return fmt.Errorf("%w: this profile is private", util.ErrForbidden)
+	}
+	if !owner.ShowMetricsToFollowers || !isFollower {
+		return fmt.Errorf("%w: metrics are not shared with you", util.ErrForbidden)
+	}
+	return nil
+}
+
+func (p *policy) CanViewAsFollower(actorID, ownerID int64) error {
+	if actorID != 0 && actorID == ownerID {
+		return nil
+	}
+
+	isAdmin, err := p.isAdmin(actorID)
+	if err != nil || isAdmin {
+		return err
+	}
+	followers, err := p.relationshipRepository.ReadUserFollowers(ownerID)
+	if err != nil {
+		return err
+	}
+	for _, id := range followers {
+		if id == actorID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: this is only shared with followers", util.ErrForbidden)
+}
+
+func (p *policy) isAdmin(actorID int64) (bool, error) {
+	if actorID == 0 {
+		return false, nil
+	}
+	role, err := p.userRepository.ReadUserRole(actorID)
+	if err != nil {
+		return false, err
+	}
+	return role == constants.ROLE_ADMIN, nil
+}
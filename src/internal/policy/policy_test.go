@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newPolicyMocks(t *testing.T) (*mock_repository.MockUserRepository, *mock_repository.MockRelationshipRepository, *policy) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	users := mock_repository.NewMockUserRepository(ctrl)
+	relationships := mock_repository.NewMockRelationshipRepository(ctrl)
+	return users, relationships, &policy{userRepository: users, relationshipRepository: relationships}
+}
+
+func TestPolicy_CanModify(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   int64
+		ownerID   int64
+		role      string // empty skips the role lookup
+		forbidden bool
+	}{
+		{name: "owner", actorID: 1, ownerID: 1},
+		{name: "admin", actorID: 2, ownerID: 1, role: constants.ROLE_ADMIN},
+		{name: "other user", actorID: 2, ownerID: 1, role: constants.ROLE_USER, forbidden: true},
+		{name: "no subject", actorID: 0, ownerID: 0, forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, _, p := newPolicyMocks(t)
+			if tt.role != "" {
+				users.EXPECT().ReadUserRole(tt.actorID).Return(tt.role, nil)
+			}
+
+			err := p.CanModify(tt.actorID, tt.ownerID)
+			if tt.forbidden != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("CanModify(%d, %d) = %v, forbidden want %v", tt.actorID, tt.ownerID, err, tt.forbidden)
+			}
+			if !tt.forbidden && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicy_CanModify_RoleLookupError(t *testing.T) {
+	users, _, p := newPolicyMocks(t)
+	users.EXPECT().ReadUserRole(int64(2)).Return("", errors.New("db down"))
+
+	if err := p.CanModify(2, 1); err == nil || err.Error() != "db down" {
+		t.Fatalf("expected db down, got %v", err)
+	}
+}
+
+func TestPolicy_CanView_Owner(t *testing.T) {
+	_, _, p := newPolicyMocks(t)
+
+	if err := p.CanView(1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPolicy_CanView_PublicProfile(t *testing.T) {
+	users, _, p := newPolicyMocks(t)
+	users.EXPECT().ReadUserByID(int64(1)).Return(&model.User{ID: 1}, nil)
+
+	if err := p.CanView(2, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPolicy_CanView_PrivateProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      string
+		followers []int64
+		forbidden bool
+	}{
+		{name: "follower", role: constants.ROLE_USER, followers: []int64{3, 2}},
+		{name: "admin", role: constants.ROLE_ADMIN},
+		{name: "stranger", role: constants.ROLE_USER, followers: []int64{3}, forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, relationships, p := newPolicyMocks(t)
+			users.EXPECT().ReadUserByID(int64(1)).Return(&model.User{ID: 1, IsPrivate: true}, nil)
+			users.EXPECT().ReadUserRole(int64(2)).Return(tt.role, nil)
+			if tt.role != constants.ROLE_ADMIN {
+				relationships.EXPECT().ReadUserFollowers(int64(1)).Return(tt.followers, nil)
+			}
+
+			err := p.CanView(2, 1)
+			if tt.forbidden != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("CanView = %v, forbidden want %v", err, tt.forbidden)
+			}
+			if !tt.forbidden && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicy_CanViewMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		owner     model.User
+		role      string
+		followers []int64
+		forbidden bool
+	}{
+		{name: "follower of a sharing profile", owner: model.User{ID: 1, ShowMetricsToFollowers: true}, role: constants.ROLE_USER, followers: []int64{2}},
+		{name: "admin", owner: model.User{ID: 1, IsPrivate: true}, role: constants.ROLE_ADMIN},
+		{name: "follower of a profile that hides metrics", owner: model.User{ID: 1}, role: constants.ROLE_USER, followers: []int64{2}, forbidden: true},
+		{name: "stranger on a public sharing profile", owner: model.User{ID: 1, ShowMetricsToFollowers: true}, role: constants.ROLE_USER, followers: []int64{3}, forbidden: true},
+		{name: "stranger on a private profile", owner: model.User{ID: 1, IsPrivate: true, ShowMetricsToFollowers: true}, role: constants.ROLE_USER, forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, relationships, p := newPolicyMocks(t)
+			owner := tt.owner
+			users.EXPECT().ReadUserByID(int64(1)).Return(&owner, nil)
+			users.EXPECT().ReadUserRole(int64(2)).Return(tt.role, nil)
+			if tt.role != constants.ROLE_ADMIN {
+				relationships.EXPECT().ReadUserFollowers(int64(1)).Return(tt.followers, nil)
+			}
+
+			err := p.CanViewMetrics(2, 1)
+			if tt.forbidden != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("CanViewMetrics = %v, forbidden want %v", err, tt.forbidden)
+			}
+			if !tt.forbidden && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolicy_CanViewMetrics_Owner(t *testing.T) {
+	_, _, p := newPolicyMocks(t)
+
+	if err := p.CanViewMetrics(1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPolicy_CanViewAsFollower(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   int64
+		role      string // empty skips the role lookup
+		followers []int64
+		forbidden bool
+	}{
+		{name: "owner", actorID: 1},
+		{name: "follower", actorID: 2, role: constants.ROLE_USER, followers: []int64{2}},
+		{name: "admin", actorID: 2, role: constants.ROLE_ADMIN},
+		{name: "stranger", actorID: 2, role: constants.ROLE_USER, followers: []int64{3}, forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, relationships, p := newPolicyMocks(t)
+			if tt.role != "" {
+				users.EXPECT().ReadUserRole(tt.actorID).Return(tt.role, nil)
+			}
+			if tt.role == constants.ROLE_USER {
+				relationships.EXPECT().ReadUserFollowers(int64(1)).Return(tt.followers, nil)
+			}
+
+			err := p.CanViewAsFollower(tt.actorID, 1)
+			if tt.forbidden != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("CanViewAsFollower = %v, forbidden want %v", err, tt.forbidden)
+			}
+			if !tt.forbidden && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -2,12 +2,15 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	domainrepo "workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type achievementRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewAchievementRepository(db *sql.DB) domainrepo.AchievementRepository {
@@ -112,3 +115,146 @@ func (r *achievementRepository) ReadUnlockedAchievementByAchievementID(id int64)
 
 	return &a, nil
 }
+
+func (r *achievementRepository) ReadAchievementsByCriteria(criteriaTypes []string) ([]*model.Achievement, error) {
+	rows, err := r.db.Query(`
+    SELECT a.id, a.title, a.badge_icon, a.description, a.criteria_type, a.criteria_threshold
+    FROM achievements a
+    WHERE a.criteria_type = ANY($1)
+    ORDER BY a.criteria_threshold ASC, a.id ASC`, pq.Array(criteriaTypes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*model.Achievement = make([]*model.Achievement, 0)
+	for rows.Next() {
+		var a model.Achievement
+		if err := rows.Scan(&a.ID, &a.Title, &a.BadgeIcon, &a.Description, &a.CriteriaType, &a.Threshold); err != nil {
+			return nil, err
+		}
+		result = append(result, &a)
+	}
+	return result, rows.Err()
+}
+
+// criteriaProgressQueries count what a user has done so far for each criteria type
+var criteriaProgressQueries = map[string]string{
+	model.CriteriaPosts:      `SELECT COUNT(*) FROM posts WHERE user_id = $1`,
+	model.CriteriaLikesGiven: `SELECT COUNT(*) FROM post_likes WHERE user_id = $1`,
+	model.CriteriaFollowers:  `SELECT COUNT(*) FROM follows WHERE followed_user_id = $1`,
+	model.CriteriaFollowing:  `SELECT COUNT(*) FROM follows WHERE following_user_id = $1`,
+	model.CriteriaRoutines:   `SELECT COUNT(*) FROM workout_routine WHERE user_id = $1`,
+	model.CriteriaSchedules:  `SELECT COUNT(*) FROM schedule WHERE user_id = $1`,
+	// finished sessions of a routine the user has put on one of their schedules
+	model.CriteriaScheduledWorkouts: `
+    SELECT COUNT(*)
+    FROM workout_sessions ws
+    WHERE ws.user_id = $1
+      AND ws.finished_at IS NOT NULL
+      AND EXISTS (
+        SELECT 1
+        FROM schedule s
+        JOIN schedule_routine sr ON sr.schedule_id = s.id
+        WHERE s.user_id = ws.user_id
+          AND sr.routine_id = ws.routine_id
+      )`,
+	// longest run of consecutive days with at least one finished session
+	model.CriteriaWorkoutStreak: `
+    WITH days AS (
+      SELECT DISTINCT finished_at::date AS day
+      FROM workout_sessions
+      WHERE user_id = $1
+        AND finished_at IS NOT NULL
+    ), runs AS (
+      SELECT day - (ROW_NUMBER() OVER (ORDER BY day))::int AS run
+      FROM days
+    )
+    SELECT COALESCE(MAX(length), 0)
+    FROM (SELECT COUNT(*) AS length FROM runs GROUP BY run) r`,
+}
+
+func (r *achievementRepository) ReadCriteriaProgress(userID int64, criteriaType string) (int64, error) {
+	q, ok := criteriaProgressQueries[criteriaType]
+	if !ok {
+		return 0, fmt.Errorf("unknown achievement criteria %q", criteriaType)
+	}
+
+	var count int64
+	if err := r.db.QueryRow(q, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AwardAchievement returns nil when the user already has the achievement
+func (r *achievementRepository) AwardAchievement(userID int64, achievementID int64) (*model.UserAchievement, error) {
+	row := r.db.QueryRow(`
+    WITH awarded AS (
+      INSERT INTO user_achievements (user_id, achievement_id, earned_at)
+      VALUES ($1, $2, now())
+      ON CONFLICT (user_id, achievement_id) DO NOTHING
+      RETURNING user_id, achievement_id, earned_at
+    )
+    SELECT a.id, aw.user_id, a.title, a.badge_icon, a.description, aw.earned_at
+    FROM awarded aw
+    JOIN achievements a ON a.id = aw.achievement_id`, userID, achievementID)
+
+	var a model.UserAchievement
+	if err := row.Scan(&a.ID, &a.UserID, &a.Title, &a.BadgeIcon, &a.Description, &a.EarnedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+const achievementDefinitionColumns = "id, title, badge_icon, description, COALESCE(criteria_type, ''), criteria_threshold"
+
+func scanAchievementDefinition(row *sql.Row) (*model.Achievement, error) {
+	var a model.Achievement
+	var title, badgeIcon, description sql.NullString
+	if err := row.Scan(&a.ID, &title, &badgeIcon, &description, &a.CriteriaType, &a.Threshold); err != nil {
+		return nil, err
+	}
+	a.Title = title.String
+	a.BadgeIcon = badgeIcon.String
+	a.Description = description.String
+	return &a, nil
+}
+
+func (r *achievementRepository) CreateAchievementDefinition(req model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	row := r.db.QueryRow(`
+    INSERT INTO achievements (name, title, badge_icon, description, criteria_type, criteria_threshold, created_at)
+    VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, now())
+    RETURNING `+achievementDefinitionColumns,
+		req.Name, req.Title, req.BadgeIcon, req.Description, req.CriteriaType, req.Threshold)
+	return scanAchievementDefinition(row)
+}
+
+func (r *achievementRepository) UpdateAchievementDefinition(req model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	row := r.db.QueryRow(`
+    UPDATE achievements
+    SET name = $2, title = $3, badge_icon = $4, description = $5, criteria_type = NULLIF($6, ''), criteria_threshold = $7
+    WHERE id = $1
+    RETURNING `+achievementDefinitionColumns,
+		req.ID, req.Name, req.Title, req.BadgeIcon, req.Description, req.CriteriaType, req.Threshold)
+	return scanAchievementDefinition(row)
+}
+
+// DeleteAchievementDefinition fails with a foreign key violation once anyone has earned the achievement
+func (r *achievementRepository) DeleteAchievementDefinition(id int64) error {
+	res, err := r.db.Exec(`DELETE FROM achievements WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -297,3 +297,132 @@ func Test_CreateAchievement_InsertError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+// -------------------- Rules engine --------------------
+
+func Test_ReadAchievementsByCriteria_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE a.criteria_type = ANY($1)")).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "badge_icon", "description", "criteria_type", "criteria_threshold"}).
+			AddRow(4, "Hi, Five!", "👏", "Follow 5 users.", "following", 5))
+
+	got, err := repo.ReadAchievementsByCriteria([]string{"following"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].CriteriaType != "following" || got[0].Threshold != 5 {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func Test_ReadCriteriaProgress_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM follows WHERE following_user_id = $1")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	got, err := repo.ReadCriteriaProgress(2, model.CriteriaFollowing)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != 5 {
+		t.Fatalf("got %d, want 5", got)
+	}
+}
+
+func Test_ReadCriteriaProgress_EveryCriteriaHasAQuery(t *testing.T) {
+	for _, c := range []string{
+		model.CriteriaPosts, model.CriteriaLikesGiven, model.CriteriaFollowers, model.CriteriaFollowing,
+		model.CriteriaRoutines, model.CriteriaSchedules, model.CriteriaScheduledWorkouts, model.CriteriaWorkoutStreak,
+	} {
+		if _, ok := criteriaProgressQueries[c]; !ok {
+			t.Errorf("no progress query for %q", c)
+		}
+	}
+}
+
+func Test_ReadCriteriaProgress_Unknown(t *testing.T) {
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	if _, err := repo.ReadCriteriaProgress(2, "marathons"); err == nil {
+		t.Fatalf("expected error for unknown criteria")
+	}
+}
+
+func Test_AwardAchievement_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (user_id, achievement_id) DO NOTHING")).
+		WithArgs(int64(2), int64(4)).
+		WillReturnRows(sqlmock.NewRows(colsUnlocked()).
+			AddRow(4, 2, "Hi, Five!", "👏", "Follow 5 users.", "2025-01-01T00:00:00Z"))
+
+	got, err := repo.AwardAchievement(2, 4)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got == nil || got.ID != 4 || got.UserID != 2 {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func Test_AwardAchievement_AlreadyOwned(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (user_id, achievement_id) DO NOTHING")).
+		WithArgs(int64(2), int64(4)).
+		WillReturnRows(sqlmock.NewRows(colsUnlocked()))
+
+	got, err := repo.AwardAchievement(2, 4)
+	if err != nil || got != nil {
+		t.Fatalf("expected nil, nil; got %#v, %v", got, err)
+	}
+}
+
+// -------------------- Achievement definitions --------------------
+
+func Test_CreateAchievementDefinition_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO achievements (name, title, badge_icon, description, criteria_type, criteria_threshold, created_at)")).
+		WithArgs("Beta_Tester", "Beta Tester", "", "", "", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "badge_icon", "description", "criteria_type", "criteria_threshold"}).
+			AddRow(int64(12), "Beta Tester", nil, nil, "", 1))
+
+	a, err := repo.CreateAchievementDefinition(model.AchievementDefinitionRequest{Name: "Beta_Tester", Title: "Beta Tester", Threshold: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if a.ID != 12 || a.Title != "Beta Tester" || a.CriteriaType != "" {
+		t.Fatalf("unexpected achievement: %#v", a)
+	}
+}
+
+func Test_DeleteAchievementDefinition_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAchievementRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM achievements WHERE id = $1")).
+		WithArgs(int64(99)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteAchievementDefinition(99); !errors.Is(err, sql2.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
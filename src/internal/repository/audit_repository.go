@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type auditRepository struct {
+	db querier
+}
+
+func NewAuditRepository(db *sql.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const auditColumns = "id, actor_id, action, target_type, target_id, details, created_at"
+
+func scanAuditRecord(row Scanner) (*model.AuditRecord, error) {
+	var record model.AuditRecord
+	var details []byte
+	if err := row.Scan(&record.ID, &record.ActorID, &record.Action, &record.TargetType, &record.TargetID, &details, &record.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(details, &record.Details); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (a *auditRepository) CreateAuditRecord(request model.CreateAuditRecordRequest) (*model.AuditRecord, error) {
+	details := request.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+
+	row := a.db.QueryRow(`
+		INSERT INTO admin_audit_log (actor_id, action, target_type, target_id, details)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+auditColumns,
+		request.ActorID, request.Action, request.TargetType, request.TargetID, encoded)
+	return scanAuditRecord(row)
+}
+
+func (a *auditRepository) ReadAuditRecords(filter model.AuditFilter) ([]*model.AuditRecord, error) {
+	conditions := make([]string, 0, 3)
+	args := make([]interface{}, 0, 4)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.TargetType != "" {
+		conditions = append(conditions, "target_type = "+arg(filter.TargetType))
+	}
+	if filter.TargetID != 0 {
+		conditions = append(conditions, "target_id = "+arg(filter.TargetID))
+	}
+	if filter.BeforeID != 0 {
+		conditions = append(conditions, "id < "+arg(filter.BeforeID))
+	}
+
+	q := "SELECT " + auditColumns + " FROM admin_audit_log"
+	if len(conditions) > 0 {
+		q += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	q += " ORDER BY id DESC LIMIT " + arg(filter.Limit)
+
+	rows, err := a.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*model.AuditRecord, 0)
+	for rows.Next() {
+		record, err := scanAuditRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func auditCols() []string {
+	return []string{"id", "actor_id", "action", "target_type", "target_id", "details", "created_at"}
+}
+
+func TestAuditRepository_CreateAuditRecord(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAuditRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO admin_audit_log (actor_id, action, target_type, target_id, details)")).
+		WithArgs(int64(1), model.AuditBanUser, model.AuditTargetUser, int64(5), []byte(`{"reason":"spam"}`)).
+		WillReturnRows(sqlmock.NewRows(auditCols()).
+			AddRow(int64(3), int64(1), model.AuditBanUser, model.AuditTargetUser, int64(5), []byte(`{"reason":"spam"}`), now))
+
+	record, err := repo.CreateAuditRecord(model.CreateAuditRecordRequest{
+		ActorID: 1, Action: model.AuditBanUser, TargetType: model.AuditTargetUser, TargetID: 5,
+		Details: map[string]any{"reason": "spam"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if record.ID != 3 || record.Details["reason"] != "spam" {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+}
+
+func TestAuditRepository_CreateAuditRecord_NoDetails(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAuditRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO admin_audit_log")).
+		WithArgs(int64(1), model.AuditDeleteExercise, model.AuditTargetExercise, int64(9), []byte(`{}`)).
+		WillReturnRows(sqlmock.NewRows(auditCols()).
+			AddRow(int64(1), int64(1), model.AuditDeleteExercise, model.AuditTargetExercise, int64(9), []byte(`{}`), time.Now()))
+
+	if _, err := repo.CreateAuditRecord(model.CreateAuditRecordRequest{
+		ActorID: 1, Action: model.AuditDeleteExercise, TargetType: model.AuditTargetExercise, TargetID: 9,
+	}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestAuditRepository_ReadAuditRecords_Filters(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAuditRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id, actor_id, action, target_type, target_id, details, created_at FROM admin_audit_log "+
+			"WHERE target_type = $1 AND target_id = $2 AND id < $3 ORDER BY id DESC LIMIT $4",
+	)).
+		WithArgs(model.AuditTargetPost, int64(20), int64(10), 3).
+		WillReturnRows(sqlmock.NewRows(auditCols()).
+			AddRow(int64(9), int64(2), model.AuditTakeDownPost, model.AuditTargetPost, int64(20), []byte(`{}`), time.Now()))
+
+	records, err := repo.ReadAuditRecords(model.AuditFilter{TargetType: model.AuditTargetPost, TargetID: 20, BeforeID: 10, Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != 9 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
+
+func TestAuditRepository_ReadAuditRecords_Unfiltered(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewAuditRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id, actor_id, action, target_type, target_id, details, created_at FROM admin_audit_log ORDER BY id DESC LIMIT $1",
+	)).
+		WithArgs(51).
+		WillReturnRows(sqlmock.NewRows(auditCols()))
+
+	records, err := repo.ReadAuditRecords(model.AuditFilter{Limit: 51})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no records, got %d", len(records))
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type bodyMeasurementRepository struct {
+	db *sql.DB
+}
+
+func NewBodyMeasurementRepository(db *sql.DB) repository.BodyMeasurementRepository {
+	return &bodyMeasurementRepository{db: db}
+}
+
+// CreateMeasurement stores a value already converted to SI units
+func (b *bodyMeasurementRepository) CreateMeasurement(measurement *model.BodyMeasurement) (*model.BodyMeasurement, error) {
+	created := *measurement
+	err := b.db.QueryRow(`
+		INSERT INTO body_measurements (user_id, metric, value, measured_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		measurement.UserID, measurement.Metric, measurement.Value, measurement.MeasuredAt.UTC()).Scan(&created.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ReadMeasurements returns the matching measurements oldest first, in SI units
+func (b *bodyMeasurementRepository) ReadMeasurements(filter model.MeasurementFilter) ([]*model.BodyMeasurement, error) {
+	rows, err := b.db.Query(`
+		SELECT id, user_id, metric, value, measured_at
+		FROM body_measurements
+		WHERE user_id = $1 AND ($2 = '' OR metric = $2) AND measured_at >= $3 AND measured_at < $4
+		ORDER BY measured_at, id`,
+		filter.UserID, filter.Metric, filter.From.UTC(), filter.Until.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	measurements := []*model.BodyMeasurement{}
+	for rows.Next() {
+		var m model.BodyMeasurement
+		var measuredAt time.Time
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Metric, &m.Value, &measuredAt); err != nil {
+			return nil, err
+		}
+		m.MeasuredAt = measuredAt.UTC()
+		measurements = append(measurements, &m)
+	}
+	return measurements, rows.Err()
+}
+
+func (b *bodyMeasurementRepository) DeleteMeasurement(userID, measurementID int64) error {
+	result, err := b.db.Exec("DELETE FROM body_measurements WHERE id = $1 AND user_id = $2", measurementID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("measurement not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestBodyMeasurementRepository_CreateMeasurement(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewBodyMeasurementRepository(db)
+
+	measuredAt := time.Date(2025, 3, 12, 7, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO body_measurements (user_id, metric, value, measured_at)")).
+		WithArgs(int64(1), model.MetricWeight, 81.5, measuredAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	got, err := repo.CreateMeasurement(&model.BodyMeasurement{UserID: 1, Metric: model.MetricWeight, Value: 81.5, Unit: model.UnitKilogram, MeasuredAt: measuredAt})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 5 || got.Value != 81.5 || got.Unit != model.UnitKilogram {
+		t.Fatalf("unexpected measurement: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestBodyMeasurementRepository_ReadMeasurements(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewBodyMeasurementRepository(db)
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT id, user_id, metric, value, measured_at\s+FROM body_measurements\s+WHERE user_id = \$1 AND \(\$2 = '' OR metric = \$2\) .+ ORDER BY measured_at, id`).
+		WithArgs(int64(1), "", from, until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "metric", "value", "measured_at"}).
+			AddRow(1, 1, "weight", 81.5, from.Add(time.Hour)).
+			AddRow(2, 1, "waist", 90.0, from.Add(2*time.Hour)))
+
+	got, err := repo.ReadMeasurements(model.MeasurementFilter{UserID: 1, From: from, Until: until})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Metric != "weight" || got[1].Value != 90 || !got[1].MeasuredAt.Equal(from.Add(2*time.Hour)) {
+		t.Fatalf("unexpected measurements: %#v", got)
+	}
+}
+
+func TestBodyMeasurementRepository_ReadMeasurements_Empty(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewBodyMeasurementRepository(db)
+
+	mock.ExpectQuery("FROM body_measurements").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "metric", "value", "measured_at"}))
+
+	got, err := repo.ReadMeasurements(model.MeasurementFilter{UserID: 1, Metric: model.MetricWaist})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("want an empty list, got %#v", got)
+	}
+}
+
+func TestBodyMeasurementRepository_DeleteMeasurement(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewBodyMeasurementRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM body_measurements WHERE id = $1 AND user_id = $2")).
+		WithArgs(int64(5), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM body_measurements").
+		WithArgs(int64(6), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteMeasurement(1, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeleteMeasurement(1, 6); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("want sql.ErrNoRows, got %v", err)
+	}
+}
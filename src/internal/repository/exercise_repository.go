@@ -3,77 +3,246 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type exerciseRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewExerciseRepository(db *sql.DB) repository.ExerciseRepository {
 	return &exerciseRepository{db: db}
 }
-func (e *exerciseRepository) ReadExerciseByID(id int64) (*model.Exercise, error) {
+
+const exerciseColumns = "id, name, description, targets, image, intensity, expertise, demo, " +
+	"recommended_count, recommended_sets, recommended_duration, custom, owner_id, is_public, deleted_at IS NOT NULL"
+
+// exerciseNameKey is what name sorting and name cursors compare; byte order keeps
+// it identical to the in-memory repository
+const exerciseNameKey = `LOWER(COALESCE(name, '')) COLLATE "C"`
+
+type exerciseSort struct {
+	byName bool
+	desc   bool
+}
+
+var exerciseSorts = map[string]exerciseSort{
+	model.ExerciseSortName:     {byName: true},
+	model.ExerciseSortNameDesc: {byName: true, desc: true},
+	model.ExerciseSortID:       {},
+	model.ExerciseSortIDDesc:   {desc: true},
+}
+
+func scanExerciseRow(row Scanner) (*model.Exercise, error) {
 	var exercise model.Exercise
 	var targetsStr string
-	var image, demo sql.NullString
+	var image, intensity, expertise, demo sql.NullString
+	var count, sets, duration, ownerID sql.NullInt64
+	var custom, isPublic sql.NullBool
+
+	if err := row.Scan(&exercise.ID, &exercise.Name, &exercise.Description, &targetsStr, &image, &intensity, &expertise, &demo,
+		&count, &sets, &duration, &custom, &ownerID, &isPublic, &exercise.Deleted); err != nil {
+		return nil, err
+	}
+	exercise.Targets = parseTargets(targetsStr)
+	exercise.Image = image.String
+	exercise.Intensity = intensity.String
+	exercise.Expertise = expertise.String
+	exercise.Demo = demo.String
+	exercise.RecommendedCount = int(count.Int64)
+	exercise.RecommendedSets = int(sets.Int64)
+	exercise.RecommendedDuration = int(duration.Int64)
+	exercise.Custom = custom.Bool
+	exercise.OwnerID = ownerID.Int64
+	exercise.IsPublic = isPublic.Bool
+	return &exercise, nil
+}
+
+// parseTargets reads the targets array, accepting both the postgres array literal
+// ({abs,"hip flexors"}) and a plain comma separated list
+func parseTargets(s string) []string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if s == "" {
+		return nil
+	}
+	targets := strings.Split(s, ",")
+	for i, t := range targets {
+		targets[i] = strings.Trim(t, `"`)
+	}
+	return targets
+}
 
-	err := e.db.QueryRow(
-		"SELECT id, name, description, targets, image FROM exercises WHERE id = $1",
-		id,
-	).Scan(&exercise.ID, &exercise.Name, &exercise.Description, &targetsStr, &image)
+// escapeLike makes a search term match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (e *exerciseRepository) ReadExerciseByID(id int64) (*model.Exercise, error) {
+	row := e.db.QueryRow("SELECT "+exerciseColumns+" FROM exercises WHERE id = $1", id)
+	exercise, err := scanExerciseRow(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("exercise not found")
 		}
 		return nil, err
 	}
+	return exercise, nil
+}
 
-	if targetsStr != "" {
-		exercise.Targets = strings.Split(targetsStr, ",")
+func (e *exerciseRepository) ReadExercises(filter model.ExerciseFilter) ([]*model.Exercise, error) {
+	conditions := make([]string, 0, 6)
+	args := make([]interface{}, 0, 8)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
 	}
-	if image.Valid {
-		exercise.Image = image.String
+
+	conditions = append(conditions, "deleted_at IS NULL", "(owner_id IS NULL OR is_public OR owner_id = "+arg(filter.ViewerID)+")")
+
+	if filter.Target != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM unnest(targets) AS t(target) WHERE LOWER(t.target) = LOWER("+arg(filter.Target)+"))")
 	}
-	if demo.Valid {
-		exercise.Demo = demo.String
+	if filter.Intensity != "" {
+		conditions = append(conditions, "LOWER(intensity) = LOWER("+arg(filter.Intensity)+")")
+	}
+	if filter.Expertise != "" {
+		conditions = append(conditions, "LOWER(expertise) = LOWER("+arg(filter.Expertise)+")")
+	}
+	if filter.Search != "" {
+		pattern := arg("%" + escapeLike(filter.Search) + "%")
+		conditions = append(conditions, "(name ILIKE "+pattern+" OR description ILIKE "+pattern+")")
+	}
+
+	sort, ok := exerciseSorts[filter.Sort]
+	if !ok {
+		sort = exerciseSorts[model.ExerciseSortName]
+	}
+	op, dir := ">", "ASC"
+	if sort.desc {
+		op, dir = "<", "DESC"
+	}
+	if filter.After != nil {
+		if sort.byName {
+			conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s COLLATE \"C\", %s)", exerciseNameKey, op, arg(filter.After.Name), arg(filter.After.ID)))
+		} else {
+			conditions = append(conditions, "id "+op+" "+arg(filter.After.ID))
+		}
 	}
-	return &exercise, nil
-}
 
-func (e *exerciseRepository) ReadAllExercises() ([]*model.Exercise, error) {
-	rows, err := e.db.Query("SELECT id, name, description, targets, image FROM exercises")
+	q := "SELECT " + exerciseColumns + " FROM exercises WHERE " + strings.Join(conditions, " AND ")
+	if sort.byName {
+		q += " ORDER BY " + exerciseNameKey + " " + dir + ", id " + dir
+	} else {
+		q += " ORDER BY id " + dir
+	}
+	if filter.Limit > 0 {
+		q += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := e.db.Query(q, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var exercises []*model.Exercise
+	exercises := make([]*model.Exercise, 0)
 	for rows.Next() {
-		var exercise model.Exercise
-		var targetsStr string
-		var image, demo sql.NullString
-
-		if err := rows.Scan(&exercise.ID, &exercise.Name, &exercise.Description, &targetsStr, &image); err != nil {
+		exercise, err := scanExerciseRow(rows)
+		if err != nil {
 			return nil, err
 		}
-		if targetsStr != "" {
-			exercise.Targets = strings.Split(targetsStr, ",")
-		}
-		if image.Valid {
-			exercise.Image = image.String
-		}
-		if demo.Valid {
-			exercise.Demo = demo.String
-		}
-		exercises = append(exercises, &exercise)
+		exercises = append(exercises, exercise)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return exercises, nil
 }
+
+func (e *exerciseRepository) CreateExercise(req model.CreateExerciseRequest) (*model.Exercise, error) {
+	q := `
+	INSERT INTO exercises (name, description, targets, image, intensity, expertise, demo,
+		recommended_count, recommended_sets, recommended_duration, custom, owner_id, is_public)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, TRUE, $11, $12)
+	RETURNING ` + exerciseColumns
+
+	row := e.db.QueryRow(q, req.Name, req.Description, pq.Array(req.Targets), req.Image, req.Intensity, req.Expertise, req.Demo,
+		req.RecommendedCount, req.RecommendedSets, req.RecommendedDuration, req.OwnerID, req.IsPublic)
+	return scanExerciseRow(row)
+}
+
+func (e *exerciseRepository) UpdateExercise(req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	q := `
+	UPDATE exercises
+	SET name = $2, description = $3, targets = $4, image = $5, intensity = $6, expertise = $7, demo = $8,
+		recommended_count = $9, recommended_sets = $10, recommended_duration = $11, is_public = $12
+	WHERE id = $1 AND owner_id = $13 AND deleted_at IS NULL
+	RETURNING ` + exerciseColumns
+
+	row := e.db.QueryRow(q, req.ID, req.Name, req.Description, pq.Array(req.Targets), req.Image, req.Intensity, req.Expertise, req.Demo,
+		req.RecommendedCount, req.RecommendedSets, req.RecommendedDuration, req.IsPublic, req.OwnerID)
+	return scanExerciseRow(row)
+}
+
+// CreateCatalogueExercise adds a built-in exercise, one without an owner
+func (e *exerciseRepository) CreateCatalogueExercise(req model.CreateExerciseRequest) (*model.Exercise, error) {
+	q := `
+	INSERT INTO exercises (name, description, targets, image, intensity, expertise, demo,
+		recommended_count, recommended_sets, recommended_duration, custom, owner_id, is_public)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, FALSE, NULL, TRUE)
+	RETURNING ` + exerciseColumns
+
+	row := e.db.QueryRow(q, req.Name, req.Description, pq.Array(req.Targets), req.Image, req.Intensity, req.Expertise, req.Demo,
+		req.RecommendedCount, req.RecommendedSets, req.RecommendedDuration)
+	return scanExerciseRow(row)
+}
+
+func (e *exerciseRepository) UpdateCatalogueExercise(req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	q := `
+	UPDATE exercises
+	SET name = $2, description = $3, targets = $4, image = $5, intensity = $6, expertise = $7, demo = $8,
+		recommended_count = $9, recommended_sets = $10, recommended_duration = $11
+	WHERE id = $1 AND owner_id IS NULL
+	RETURNING ` + exerciseColumns
+
+	row := e.db.QueryRow(q, req.ID, req.Name, req.Description, pq.Array(req.Targets), req.Image, req.Intensity, req.Expertise, req.Demo,
+		req.RecommendedCount, req.RecommendedSets, req.RecommendedDuration)
+	return scanExerciseRow(row)
+}
+
+func (e *exerciseRepository) DeleteCatalogueExercise(id int64) error {
+	res, err := e.db.Exec("DELETE FROM exercises WHERE id = $1 AND owner_id IS NULL", id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteExercise only marks a custom exercise deleted: removing the row would
+// cascade to the sets, records, routines and goals of everyone who used it
+func (e *exerciseRepository) DeleteExercise(id int64) error {
+	res, err := e.db.Exec("UPDATE exercises SET deleted_at = NOW() WHERE id = $1 AND owner_id IS NOT NULL AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -4,20 +4,29 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"workoutpal/src/internal/model"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
 
+const exerciseSelect = "SELECT id, name, description, targets, image, intensity, expertise, demo, " +
+	"recommended_count, recommended_sets, recommended_duration, custom, owner_id, is_public, deleted_at IS NOT NULL FROM exercises"
+
+func exerciseCols() []string {
+	return []string{"id", "name", "description", "targets", "image", "intensity", "expertise", "demo",
+		"recommended_count", "recommended_sets", "recommended_duration", "custom", "owner_id", "is_public", "deleted"}
+}
+
 func TestExerciseRepository_ReadExerciseByID_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewExerciseRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "targets", "image"}).
-		AddRow(42, "Deadlift", "posterior chain", "back,glutes,hamstrings", "img.png")
+	rows := sqlmock.NewRows(exerciseCols()).
+		AddRow(42, "Deadlift", "posterior chain", "{back,glutes,hamstrings}", "img.png", "high", "advanced", nil, 10, 3, 60, false, nil, false, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises WHERE id = $1",
+		exerciseSelect + " WHERE id = $1",
 	)).
 		WithArgs(int64(42)).
 		WillReturnRows(rows)
@@ -29,7 +38,8 @@ func TestExerciseRepository_ReadExerciseByID_OK(t *testing.T) {
 	if got == nil || got.ID != 42 || got.Name != "Deadlift" {
 		t.Fatalf("unexpected exercise: %#v", got)
 	}
-	if len(got.Targets) != 3 || got.Targets[0] != "back" || got.Image != "img.png" {
+	if len(got.Targets) != 3 || got.Targets[0] != "back" || got.Targets[2] != "hamstrings" || got.Image != "img.png" ||
+		got.Intensity != "high" || got.RecommendedSets != 3 || got.Custom {
 		t.Fatalf("unexpected fields: %#v", got)
 	}
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -43,7 +53,7 @@ func TestExerciseRepository_ReadExerciseByID_NotFound(t *testing.T) {
 	repo := NewExerciseRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises WHERE id = $1",
+		exerciseSelect + " WHERE id = $1",
 	)).
 		WithArgs(int64(7)).
 		WillReturnError(sql.ErrNoRows)
@@ -66,7 +76,7 @@ func TestExerciseRepository_ReadExerciseByID_DBError(t *testing.T) {
 	repo := NewExerciseRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises WHERE id = $1",
+		exerciseSelect + " WHERE id = $1",
 	)).
 		WithArgs(int64(9)).
 		WillReturnError(assertErr)
@@ -88,15 +98,15 @@ func TestExerciseRepository_ReadAllExercises_OK_WithNulls(t *testing.T) {
 	defer db.Close()
 	repo := NewExerciseRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "targets", "image"}).
-		AddRow(1, "Push-ups", "desc1", "chest,shoulders,triceps", "img1.png").
-		AddRow(2, "Pull-ups", "desc2", "back,biceps", nil)
+	rows := sqlmock.NewRows(exerciseCols()).
+		AddRow(1, "Push-ups", "desc1", "chest,shoulders,triceps", "img1.png", nil, nil, nil, nil, nil, nil, nil, nil, nil, false).
+		AddRow(2, "Pull-ups", "desc2", `{back,"upper biceps"}`, nil, nil, nil, nil, nil, nil, nil, true, 8, true, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises",
+		exerciseSelect + " WHERE deleted_at IS NULL AND (owner_id IS NULL OR is_public OR owner_id = $1) ORDER BY",
 	)).WillReturnRows(rows)
 
-	got, err := repo.ReadAllExercises()
+	got, err := repo.ReadExercises(model.ExerciseFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -106,7 +116,8 @@ func TestExerciseRepository_ReadAllExercises_OK_WithNulls(t *testing.T) {
 	if got[0].ID != 1 || got[0].Name != "Push-ups" || len(got[0].Targets) != 3 || got[0].Image != "img1.png" {
 		t.Fatalf("bad row1: %#v", got[0])
 	}
-	if got[1].ID != 2 || got[1].Name != "Pull-ups" || len(got[1].Targets) != 2 || got[1].Image != "" {
+	if got[1].ID != 2 || got[1].Name != "Pull-ups" || len(got[1].Targets) != 2 || got[1].Targets[1] != "upper biceps" || got[1].Image != "" ||
+		!got[1].Custom || got[1].OwnerID != 8 || !got[1].IsPublic {
 		t.Fatalf("bad row2: %#v", got[1])
 	}
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -119,14 +130,14 @@ func TestExerciseRepository_ReadAllExercises_ScanError(t *testing.T) {
 	defer db.Close()
 	repo := NewExerciseRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "targets", "image"}).
-		AddRow("bad", "X", "Y", "a,b", nil)
+	rows := sqlmock.NewRows(exerciseCols()).
+		AddRow("bad", "X", "Y", "a,b", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises",
+		exerciseSelect + " WHERE deleted_at IS NULL AND (owner_id IS NULL OR is_public OR owner_id = $1) ORDER BY",
 	)).WillReturnRows(rows)
 
-	got, err := repo.ReadAllExercises()
+	got, err := repo.ReadExercises(model.ExerciseFilter{})
 	if got != nil {
 		t.Fatalf("expected nil slice, got %#v", got)
 	}
@@ -142,16 +153,16 @@ func TestExerciseRepository_ReadAllExercises_RowsErr(t *testing.T) {
 	defer db.Close()
 	repo := NewExerciseRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "targets", "image"}).
-		AddRow(1, "A", "d", "x,y", nil).
-		AddRow(2, "B", "e", "p,q", nil).
+	rows := sqlmock.NewRows(exerciseCols()).
+		AddRow(1, "A", "d", "x,y", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false).
+		AddRow(2, "B", "e", "p,q", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, false).
 		RowError(1, assertErr)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, description, targets, image FROM exercises",
+		exerciseSelect + " WHERE deleted_at IS NULL AND (owner_id IS NULL OR is_public OR owner_id = $1) ORDER BY",
 	)).WillReturnRows(rows)
 
-	got, err := repo.ReadAllExercises()
+	got, err := repo.ReadExercises(model.ExerciseFilter{})
 	if got != nil {
 		t.Fatalf("expected nil slice, got %#v", got)
 	}
@@ -163,6 +174,162 @@ func TestExerciseRepository_ReadAllExercises_RowsErr(t *testing.T) {
 	}
 }
 
+func TestExerciseRepository_ReadExercises_Filters(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		exerciseSelect+
+			" WHERE deleted_at IS NULL AND (owner_id IS NULL OR is_public OR owner_id = $1) "+
+			"AND EXISTS (SELECT 1 FROM unnest(targets) AS t(target) WHERE LOWER(t.target) = LOWER($2)) "+
+			"AND LOWER(intensity) = LOWER($3) "+
+			"AND LOWER(expertise) = LOWER($4) "+
+			"AND (name ILIKE $5 OR description ILIKE $5) "+
+			`AND (LOWER(COALESCE(name, '')) COLLATE "C", id) < ($6 COLLATE "C", $7) `+
+			`ORDER BY LOWER(COALESCE(name, '')) COLLATE "C" DESC, id DESC LIMIT $8`,
+	)).
+		WithArgs(int64(8), "abs", "high", "beginner", `%100\%%`, "sit-up", int64(12), 21).
+		WillReturnRows(sqlmock.NewRows(exerciseCols()).
+			AddRow(3, "Crunch", "100% effort", "{abs}", nil, "high", "beginner", nil, nil, nil, nil, nil, nil, nil, false))
+
+	got, err := repo.ReadExercises(model.ExerciseFilter{
+		Target:    "abs",
+		Intensity: "high",
+		Expertise: "beginner",
+		Search:    "100%",
+		Sort:      model.ExerciseSortNameDesc,
+		After:     &model.ExerciseCursor{Sort: model.ExerciseSortNameDesc, Name: "sit-up", ID: 12},
+		Limit:     21,
+		ViewerID:  8,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 3 || got[0].Targets[0] != "abs" {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExerciseRepository_ReadExercises_IDCursor(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		exerciseSelect+" WHERE deleted_at IS NULL AND (owner_id IS NULL OR is_public OR owner_id = $1) AND id > $2 ORDER BY id ASC LIMIT $3",
+	)).
+		WithArgs(int64(0), int64(40), 3).
+		WillReturnRows(sqlmock.NewRows(exerciseCols()))
+
+	got, err := repo.ReadExercises(model.ExerciseFilter{
+		Sort:  model.ExerciseSortID,
+		After: &model.ExerciseCursor{Sort: model.ExerciseSortID, ID: 40},
+		Limit: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no rows, got %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExerciseRepository_CreateExercise_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO exercises")).
+		WithArgs("Calf raise", "", sqlmock.AnyArg(), "", "", "", "", 0, 0, 0, int64(8), false).
+		WillReturnRows(sqlmock.NewRows(exerciseCols()).
+			AddRow(50, "Calf raise", "", "{calves}", "", "", "", "", 0, 0, 0, true, 8, false, false))
+
+	got, err := repo.CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: "Calf raise", Targets: []string{"calves"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 50 || !got.Custom || got.OwnerID != 8 || got.IsPublic {
+		t.Fatalf("unexpected exercise: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExerciseRepository_UpdateExercise_NotOwned(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE id = $1 AND owner_id = $13")).
+		WillReturnRows(sqlmock.NewRows(exerciseCols()))
+
+	_, err := repo.UpdateExercise(model.UpdateExerciseRequest{ID: 50, OwnerID: 9, Name: "Calf raise"})
+	if err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestExerciseRepository_DeleteExercise(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	const q = "UPDATE exercises SET deleted_at = NOW() WHERE id = $1 AND owner_id IS NOT NULL AND deleted_at IS NULL"
+	mock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(int64(50)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteExercise(50); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeleteExercise(1); err != sql.ErrNoRows {
+		t.Fatalf("built-in exercises must not be deleted, got %v", err)
+	}
+}
+
+func TestExerciseRepository_CreateCatalogueExercise(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, FALSE, NULL, TRUE)")).
+		WithArgs("Lunge", "", `{"legs"}`, "", "", "", "", 0, 0, 0).
+		WillReturnRows(sqlmock.NewRows(exerciseCols()).
+			AddRow(9, "Lunge", "", "{legs}", "", "", "", nil, 0, 0, 0, false, nil, true, false))
+
+	ex, err := repo.CreateCatalogueExercise(model.CreateExerciseRequest{Name: "Lunge", Targets: []string{"legs"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ex.ID != 9 || ex.OwnerID != 0 || !ex.IsPublic {
+		t.Fatalf("unexpected exercise: %+v", ex)
+	}
+}
+
+func TestExerciseRepository_DeleteCatalogueExercise(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewExerciseRepository(db)
+
+	const q = "DELETE FROM exercises WHERE id = $1 AND owner_id IS NULL"
+	mock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(q)).WithArgs(int64(50)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteCatalogueExercise(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeleteCatalogueExercise(50); err != sql.ErrNoRows {
+		t.Fatalf("custom exercises must not be deleted from the catalogue, got %v", err)
+	}
+}
+
 var assertErr = &testErr{"boom"}
 
 type testErr struct{ s string }
@@ -3,8 +3,11 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type goalRepository struct {
@@ -15,23 +18,69 @@ func NewGoalRepository(db *sql.DB) repository.GoalRepository {
 	return &goalRepository{db: db}
 }
 
-func (g *goalRepository) CreateGoal(userID int64, request model.CreateGoalRequest) (*model.Goal, error) {
-	var goal model.Goal
-	err := g.db.QueryRow(`
-		INSERT INTO goals (user_id, name, description, deadline, status) 
-		VALUES ($1, $2, $3, $4, 'active') 
-		RETURNING id, user_id, name, description, deadline, created_at, status`,
-		userID, request.Name, request.Description, request.Deadline).Scan(
-		&goal.ID, &goal.UserID, &goal.Name, &goal.Description, &goal.Deadline, &goal.CreatedAt, &goal.Status)
+// latestWeight is the user's most recently logged weight measurement, in kg
+const latestWeight = `(SELECT m.value FROM body_measurements m
+			WHERE m.user_id = %s AND m.metric = 'weight' ORDER BY m.measured_at DESC, m.id DESC LIMIT 1)`
 
-	if err != nil {
+// goalColumns reads a goal aliased g along with its current value: the
+// latest logged weight in kg, the best estimated 1RM on the exercise, or the
+// workouts finished since Monday
+var goalColumns = `g.id, g.user_id, g.name, g.description, g.deadline, g.created_at, g.status,
+		g.goal_type, g.exercise_id, g.target_value, g.start_value, g.completed_at,
+		CASE g.goal_type
+			WHEN 'body_weight' THEN ` + fmt.Sprintf(latestWeight, "g.user_id") + `
+			WHEN 'one_rep_max' THEN (SELECT MAX(pr.value) FROM personal_records pr
+				WHERE pr.user_id = g.user_id AND pr.exercise_id = g.exercise_id AND pr.record_type = 'estimated_1rm')
+			WHEN 'workouts_per_week' THEN (SELECT COUNT(*) FROM workout_sessions ws
+				WHERE ws.user_id = g.user_id AND ws.finished_at >= date_trunc('week', NOW() AT TIME ZONE 'UTC'))
+		END`
+
+func scanGoal(row Scanner) (*model.Goal, error) {
+	var goal model.Goal
+	var deadline sql.NullString
+	var exerciseID sql.NullInt64
+	var target, start, current sql.NullFloat64
+	var completedAt sql.NullTime
+	if err := row.Scan(
+		&goal.ID, &goal.UserID, &goal.Name, &goal.Description, &deadline, &goal.CreatedAt, &goal.Status,
+		&goal.Type, &exerciseID, &target, &start, &completedAt, &current,
+	); err != nil {
 		return nil, err
 	}
+	goal.Deadline = deadline.String
+	if exerciseID.Valid {
+		goal.ExerciseID = &exerciseID.Int64
+	}
+	goal.TargetValue = nullFloat(target)
+	goal.StartValue = nullFloat(start)
+	goal.CurrentValue = nullFloat(current)
+	if completedAt.Valid {
+		goal.CompletedAt = &completedAt.Time
+	}
 	return &goal, nil
 }
 
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+// CreateGoal records a body weight goal's starting weight from the latest
+// logged weight, in kg
+func (g *goalRepository) CreateGoal(userID int64, request model.CreateGoalRequest) (*model.Goal, error) {
+	row := g.db.QueryRow(`
+		INSERT INTO goals AS g (user_id, name, description, deadline, status, goal_type, exercise_id, target_value, start_value)
+		VALUES ($1, $2, $3, NULLIF($4, '')::timestamp, 'active', $5, NULLIF($6, 0), NULLIF($7, 0),
+			CASE WHEN $5 = 'body_weight' THEN `+fmt.Sprintf(latestWeight, "$1")+` END)
+		RETURNING `+goalColumns,
+		userID, request.Name, request.Description, request.Deadline, request.Type, request.ExerciseID, request.TargetValue)
+	return scanGoal(row)
+}
+
 func (g *goalRepository) ReadUserGoals(userID int64) ([]*model.Goal, error) {
-	rows, err := g.db.Query("SELECT id, user_id, name, description, deadline, created_at, status FROM goals WHERE user_id = $1", userID)
+	rows, err := g.db.Query(`SELECT `+goalColumns+` FROM goals g WHERE g.user_id = $1 ORDER BY g.id`, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -39,34 +88,51 @@ func (g *goalRepository) ReadUserGoals(userID int64) ([]*model.Goal, error) {
 
 	var goals []*model.Goal
 	for rows.Next() {
-		var goal model.Goal
-		err := rows.Scan(&goal.ID, &goal.UserID, &goal.Name, &goal.Description, &goal.Deadline, &goal.CreatedAt, &goal.Status)
+		goal, err := scanGoal(rows)
 		if err != nil {
 			return nil, err
 		}
-		goals = append(goals, &goal)
+		goals = append(goals, goal)
 	}
-	return goals, nil
+	return goals, rows.Err()
 }
 
+// UpdateGoal only touches the user's own goal, and leaves custom goals
+// without a target. Marking a goal completed stamps completed_at; any other
+// status clears it.
 func (g *goalRepository) UpdateGoal(request model.UpdateGoalRequest) (*model.Goal, error) {
-	var goal model.Goal
-	err := g.db.QueryRow(`
-		UPDATE goals SET name=$2, description=$3, deadline=$4, status=$5
-		WHERE id=$1 RETURNING id, user_id, name, description, deadline, created_at, status`,
-		request.ID, request.Name, request.Description, request.Deadline, request.Status).Scan(
-		&goal.ID, &goal.UserID, &goal.Name, &goal.Description, &goal.Deadline, &goal.CreatedAt, &goal.Status)
+	row := g.db.QueryRow(`
+		UPDATE goals g SET
+			name = COALESCE($3, name),
+			description = COALESCE($4, description),
+			deadline = CASE WHEN $5::varchar IS NULL THEN deadline ELSE NULLIF($5, '')::timestamp END,
+			status = COALESCE($6, status),
+			target_value = CASE WHEN goal_type = 'custom' THEN target_value ELSE COALESCE($7, target_value) END,
+			completed_at = CASE WHEN COALESCE($6, status) = 'completed' THEN COALESCE(completed_at, NOW()) END
+		WHERE g.id = $1 AND g.user_id = $2
+		RETURNING `+goalColumns,
+		request.ID, request.UserID, request.Name, request.Description, request.Deadline, request.Status, request.TargetValue)
+	goal, err := scanGoal(row)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("goal not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("goal not found: %w", sql.ErrNoRows)
 		}
 		return nil, err
 	}
-	return &goal, nil
+	return goal, nil
+}
+
+// CompleteGoals marks active goals completed; goals in another status are left
+func (g *goalRepository) CompleteGoals(goalIDs []int64) error {
+	_, err := g.db.Exec(`
+		UPDATE goals SET status = 'completed', completed_at = NOW()
+		WHERE id = ANY($1) AND status = 'active'`,
+		pq.Array(goalIDs))
+	return err
 }
 
-func (g *goalRepository) DeleteGoal(goalID int64) error {
-	result, err := g.db.Exec("DELETE FROM goals WHERE id = $1", goalID)
+func (g *goalRepository) DeleteGoal(userID, goalID int64) error {
+	result, err := g.db.Exec("DELETE FROM goals WHERE id = $1 AND user_id = $2", goalID, userID)
 	if err != nil {
 		return err
 	}
@@ -75,7 +141,7 @@ func (g *goalRepository) DeleteGoal(goalID int64) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("goal not found")
+		return fmt.Errorf("goal not found: %w", sql.ErrNoRows)
 	}
 	return nil
 }
@@ -3,13 +3,19 @@ package repository
 import (
 	"database/sql"
 	"errors"
-	"regexp"
 	"testing"
+	"time"
 	"workoutpal/src/internal/model"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 )
 
+var goalRowColumns = []string{
+	"id", "user_id", "name", "description", "deadline", "created_at", "status",
+	"goal_type", "exercise_id", "target_value", "start_value", "completed_at", "current_value",
+}
+
 func TestGoalRepository_CreateGoal_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -19,23 +25,68 @@ func TestGoalRepository_CreateGoal_OK(t *testing.T) {
 		Name:        "New Goal",
 		Description: "Test goal",
 		Deadline:    "2025-01-01",
+		Type:        model.GoalTypeCustom,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "deadline", "created_at", "status"}).
-		AddRow(1, 10, req.Name, req.Description, req.Deadline, "2025-01-01T00:00:00Z", "active")
+	rows := sqlmock.NewRows(goalRowColumns).
+		AddRow(1, 10, req.Name, req.Description, req.Deadline, "2025-01-01T00:00:00Z", "active", "custom", nil, nil, nil, nil, nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO goals (user_id, name, description, deadline, status) 
-		VALUES ($1, $2, $3, $4, 'active') 
-		RETURNING id, user_id, name, description, deadline, created_at, status`)).
-		WithArgs(int64(10), req.Name, req.Description, req.Deadline).
+	mock.ExpectQuery(`INSERT INTO goals AS g \(user_id, name, description, deadline, status, goal_type, exercise_id, target_value, start_value\)`).
+		WithArgs(int64(10), req.Name, req.Description, req.Deadline, req.Type, int64(0), float64(0)).
 		WillReturnRows(rows)
 
 	got, err := repo.CreateGoal(10, req)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if got.ID != 1 || got.UserID != 10 || got.Status != "active" {
+	if got.ID != 1 || got.UserID != 10 || got.Status != "active" || got.Type != "custom" {
+		t.Fatalf("unexpected model: %#v", got)
+	}
+	if got.TargetValue != nil || got.CurrentValue != nil || got.ExerciseID != nil {
+		t.Fatalf("a custom goal has no measurements: %#v", got)
+	}
+}
+
+func TestGoalRepository_CreateGoal_Measurable(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewGoalRepository(db)
+
+	req := model.CreateGoalRequest{Name: "Bench 100", Type: model.GoalTypeOneRepMax, ExerciseID: 7, TargetValue: 100}
+
+	mock.ExpectQuery(`INSERT INTO goals AS g .+ RETURNING g.id, .+ CASE g.goal_type .+ FROM personal_records pr`).
+		WithArgs(int64(10), req.Name, req.Description, "", req.Type, req.ExerciseID, req.TargetValue).
+		WillReturnRows(sqlmock.NewRows(goalRowColumns).
+			AddRow(2, 10, req.Name, "", nil, "ts", "active", req.Type, 7, 100.0, nil, nil, 92.5))
+
+	got, err := repo.CreateGoal(10, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Deadline != "" || *got.ExerciseID != 7 || *got.TargetValue != 100 || *got.CurrentValue != 92.5 {
+		t.Fatalf("unexpected model: %#v", got)
+	}
+}
+
+func TestGoalRepository_CreateGoal_BodyWeight(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewGoalRepository(db)
+
+	req := model.CreateGoalRequest{Name: "Cut", Type: model.GoalTypeBodyWeight, TargetValue: 75}
+
+	// both the start and the current weight are the latest logged measurement in kg
+	mock.ExpectQuery(`INSERT INTO goals AS g .+ CASE WHEN \$5 = 'body_weight' THEN \(SELECT m.value FROM body_measurements m WHERE m.user_id = \$1 AND m.metric = 'weight' ORDER BY m.measured_at DESC, m.id DESC LIMIT 1\) END\) `+
+		`RETURNING .+ WHEN 'body_weight' THEN \(SELECT m.value FROM body_measurements m WHERE m.user_id = g.user_id AND m.metric = 'weight'`).
+		WithArgs(int64(10), req.Name, req.Description, "", req.Type, int64(0), req.TargetValue).
+		WillReturnRows(sqlmock.NewRows(goalRowColumns).
+			AddRow(3, 10, req.Name, "", nil, "ts", "active", req.Type, nil, 75.0, 80.0, nil, 80.0))
+
+	got, err := repo.CreateGoal(10, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got.StartValue != 80 || *got.CurrentValue != 80 || *got.TargetValue != 75 {
 		t.Fatalf("unexpected model: %#v", got)
 	}
 }
@@ -59,11 +110,12 @@ func TestGoalRepository_ReadUserGoals_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewGoalRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "deadline", "created_at", "status"}).
-		AddRow(1, 10, "Goal 1", "Desc 1", "2025-01-01", "ts1", "active").
-		AddRow(2, 10, "Goal 2", "Desc 2", "2026-01-01", "ts2", "paused")
+	completedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(goalRowColumns).
+		AddRow(1, 10, "Goal 1", "Desc 1", "2025-01-01", "ts1", "active", "body_weight", nil, 75.0, 80.0, nil, 78.0).
+		AddRow(2, 10, "Goal 2", "Desc 2", "2026-01-01", "ts2", "completed", "workouts_per_week", nil, 3.0, nil, completedAt, 1.0)
 
-	mock.ExpectQuery("SELECT id, user_id, name, description, deadline, created_at, status FROM goals WHERE user_id = \\$1").
+	mock.ExpectQuery(`SELECT g.id, g.user_id, .+ FROM goals g WHERE g.user_id = \$1 ORDER BY g.id`).
 		WithArgs(int64(10)).
 		WillReturnRows(rows)
 
@@ -71,6 +123,9 @@ func TestGoalRepository_ReadUserGoals_OK(t *testing.T) {
 	if err != nil || len(got) != 2 {
 		t.Fatalf("unexpected result: %+v err=%v", got, err)
 	}
+	if *got[0].StartValue != 80 || *got[0].CurrentValue != 78 || got[1].CompletedAt == nil || !got[1].CompletedAt.Equal(completedAt) {
+		t.Fatalf("unexpected goals: %+v %+v", got[0], got[1])
+	}
 }
 
 func TestGoalRepository_ReadUserGoals_ScanError(t *testing.T) {
@@ -78,10 +133,10 @@ func TestGoalRepository_ReadUserGoals_ScanError(t *testing.T) {
 	defer db.Close()
 	repo := NewGoalRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "deadline", "created_at", "status"}).
-		AddRow("BAD", 10, "Goal", "Desc", "2025", "ts", "active")
+	rows := sqlmock.NewRows(goalRowColumns).
+		AddRow("BAD", 10, "Goal", "Desc", "2025", "ts", "active", "custom", nil, nil, nil, nil, nil)
 
-	mock.ExpectQuery("SELECT id, user_id").
+	mock.ExpectQuery("SELECT g.id, g.user_id").
 		WithArgs(int64(10)).
 		WillReturnRows(rows)
 
@@ -96,23 +151,18 @@ func TestGoalRepository_UpdateGoal_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewGoalRepository(db)
 
-	req := model.UpdateGoalRequest{
-		ID:          1,
-		Name:        "Updated",
-		Description: "New Desc",
-		Deadline:    "2026",
-		Status:      "paused",
-	}
+	name, status := "Updated", "paused"
+	req := model.UpdateGoalRequest{ID: 1, UserID: 10, Name: &name, Status: &status}
 
-	row := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "deadline", "created_at", "status"}).
-		AddRow(1, 10, "Updated", "New Desc", "2026", "ts", "paused")
+	row := sqlmock.NewRows(goalRowColumns).
+		AddRow(1, 10, "Updated", "Desc", "2026", "ts", "paused", "custom", nil, nil, nil, nil, nil)
 
-	mock.ExpectQuery("UPDATE goals").
-		WithArgs(req.ID, req.Name, req.Description, req.Deadline, req.Status).
+	mock.ExpectQuery(`UPDATE goals g SET .+ WHERE g.id = \$1 AND g.user_id = \$2 RETURNING`).
+		WithArgs(req.ID, req.UserID, req.Name, req.Description, req.Deadline, req.Status, req.TargetValue).
 		WillReturnRows(row)
 
 	got, err := repo.UpdateGoal(req)
-	if err != nil || got.Status != "paused" {
+	if err != nil || got.Status != "paused" || got.Name != "Updated" {
 		t.Fatalf("unexpected: %+v err=%v", got, err)
 	}
 }
@@ -126,8 +176,22 @@ func TestGoalRepository_UpdateGoal_NotFound(t *testing.T) {
 		WillReturnError(sql.ErrNoRows)
 
 	_, err := repo.UpdateGoal(model.UpdateGoalRequest{ID: 99})
-	if err == nil || err.Error() != "goal not found" {
-		t.Fatalf("expected 'goal not found', got %v", err)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestGoalRepository_CompleteGoals(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewGoalRepository(db)
+
+	mock.ExpectExec(`UPDATE goals SET status = 'completed', completed_at = NOW\(\) WHERE id = ANY\(\$1\) AND status = 'active'`).
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := repo.CompleteGoals([]int64{1, 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
@@ -136,11 +200,11 @@ func TestGoalRepository_DeleteGoal_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewGoalRepository(db)
 
-	mock.ExpectExec("DELETE FROM goals").
-		WithArgs(int64(1)).
+	mock.ExpectExec("DELETE FROM goals WHERE id = \\$1 AND user_id = \\$2").
+		WithArgs(int64(1), int64(10)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	if err := repo.DeleteGoal(1); err != nil {
+	if err := repo.DeleteGoal(10, 1); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -151,11 +215,11 @@ func TestGoalRepository_DeleteGoal_NotFound(t *testing.T) {
 	repo := NewGoalRepository(db)
 
 	mock.ExpectExec("DELETE FROM goals").
-		WithArgs(int64(2)).
+		WithArgs(int64(2), int64(10)).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
-	err := repo.DeleteGoal(2)
-	if err == nil || err.Error() != "goal not found" {
+	err := repo.DeleteGoal(10, 2)
+	if !errors.Is(err, sql.ErrNoRows) {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
@@ -1,27 +1,37 @@
 package in_memory
 
 import (
+	"database/sql"
 	"errors"
+	"sort"
+	"strings"
+	"sync"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
 )
 
 type inMemoryExerciseRepository struct {
-	data map[int64]*model.Exercise
+	data   map[int64]*model.Exercise
+	nextID int64
+	mutex  sync.RWMutex
 }
 
 func NewInMemoryExerciseRepository() repository.ExerciseRepository {
 	return &inMemoryExerciseRepository{
 		data: map[int64]*model.Exercise{
-			1: {ID: 1, Name: "Push-ups", Targets: []string{"chest", "shoulders", "triceps"}, Intensity: "medium", Expertise: "beginner"},
-			2: {ID: 2, Name: "Pull-ups", Targets: []string{"back", "biceps"}, Intensity: "high", Expertise: "intermediate"},
-			3: {ID: 3, Name: "Squats", Targets: []string{"legs", "glutes"}, Intensity: "medium", Expertise: "beginner"},
-			4: {ID: 4, Name: "Deadlifts", Targets: []string{"back", "legs", "glutes"}, Intensity: "high", Expertise: "advanced"},
+			1: {ID: 1, Name: "Push-ups", Description: "Lower your chest to the floor and press back up.", Targets: []string{"chest", "shoulders", "triceps"}, Intensity: "medium", Expertise: "beginner"},
+			2: {ID: 2, Name: "Pull-ups", Description: "Hang from a bar and pull your chin over it.", Targets: []string{"back", "biceps"}, Intensity: "high", Expertise: "intermediate"},
+			3: {ID: 3, Name: "Squats", Description: "Sit your hips back and down, then stand up.", Targets: []string{"legs", "glutes"}, Intensity: "medium", Expertise: "beginner"},
+			4: {ID: 4, Name: "Deadlifts", Description: "Lift the bar from the floor with a flat back.", Targets: []string{"back", "legs", "glutes"}, Intensity: "high", Expertise: "advanced"},
 		},
+		nextID: 5,
 	}
 }
 
 func (e *inMemoryExerciseRepository) ReadExerciseByID(id int64) (*model.Exercise, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
 	if ex, ok := e.data[id]; ok {
 		// return a copy to avoid external mutation of our map values
 		cp := *ex
@@ -30,11 +40,198 @@ func (e *inMemoryExerciseRepository) ReadExerciseByID(id int64) (*model.Exercise
 	return nil, errors.New("exercise not found")
 }
 
-func (e *inMemoryExerciseRepository) ReadAllExercises() ([]*model.Exercise, error) {
+// ReadExercises mirrors the filtering, ordering and keyset paging of the postgres repository
+func (e *inMemoryExerciseRepository) ReadExercises(filter model.ExerciseFilter) ([]*model.Exercise, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	byName := filter.Sort == "" || filter.Sort == model.ExerciseSortName || filter.Sort == model.ExerciseSortNameDesc
+	desc := strings.HasPrefix(filter.Sort, "-")
+
+	// less reports whether a comes before b in ascending order
+	less := func(aName string, aID int64, bName string, bID int64) bool {
+		if byName && aName != bName {
+			return aName < bName
+		}
+		return aID < bID
+	}
+
 	out := make([]*model.Exercise, 0, len(e.data))
 	for _, ex := range e.data {
+		if !matchesExerciseFilter(ex, filter) {
+			continue
+		}
+		if filter.After != nil {
+			name := strings.ToLower(ex.Name)
+			after := less(filter.After.Name, filter.After.ID, name, ex.ID)
+			if desc {
+				after = less(name, ex.ID, filter.After.Name, filter.After.ID)
+			}
+			if !after {
+				continue
+			}
+		}
 		cp := *ex
 		out = append(out, &cp)
 	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if desc {
+			return less(strings.ToLower(out[j].Name), out[j].ID, strings.ToLower(out[i].Name), out[i].ID)
+		}
+		return less(strings.ToLower(out[i].Name), out[i].ID, strings.ToLower(out[j].Name), out[j].ID)
+	})
+
+	if filter.Limit > 0 && len(out) > filter.Limit {
+		out = out[:filter.Limit]
+	}
 	return out, nil
 }
+
+func (e *inMemoryExerciseRepository) CreateExercise(req model.CreateExerciseRequest) (*model.Exercise, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ex := &model.Exercise{
+		ID:                  e.nextID,
+		Name:                req.Name,
+		Description:         req.Description,
+		Targets:             req.Targets,
+		Intensity:           req.Intensity,
+		Expertise:           req.Expertise,
+		Image:               req.Image,
+		Demo:                req.Demo,
+		RecommendedCount:    req.RecommendedCount,
+		RecommendedSets:     req.RecommendedSets,
+		RecommendedDuration: req.RecommendedDuration,
+		Custom:              true,
+		OwnerID:             req.OwnerID,
+		IsPublic:            req.IsPublic,
+	}
+	e.data[ex.ID] = ex
+	e.nextID++
+
+	cp := *ex
+	return &cp, nil
+}
+
+func (e *inMemoryExerciseRepository) UpdateExercise(req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ex, ok := e.data[req.ID]
+	if !ok || ex.OwnerID == 0 || ex.OwnerID != req.OwnerID || ex.Deleted {
+		return nil, sql.ErrNoRows
+	}
+	ex.Name = req.Name
+	ex.Description = req.Description
+	ex.Targets = req.Targets
+	ex.Intensity = req.Intensity
+	ex.Expertise = req.Expertise
+	ex.Image = req.Image
+	ex.Demo = req.Demo
+	ex.RecommendedCount = req.RecommendedCount
+	ex.RecommendedSets = req.RecommendedSets
+	ex.RecommendedDuration = req.RecommendedDuration
+	ex.IsPublic = req.IsPublic
+
+	cp := *ex
+	return &cp, nil
+}
+
+func (e *inMemoryExerciseRepository) DeleteExercise(id int64) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ex, ok := e.data[id]
+	if !ok || ex.OwnerID == 0 || ex.Deleted {
+		return sql.ErrNoRows
+	}
+	ex.Deleted = true
+	return nil
+}
+
+func (e *inMemoryExerciseRepository) CreateCatalogueExercise(req model.CreateExerciseRequest) (*model.Exercise, error) {
+	req.OwnerID = 0
+	ex, err := e.CreateExercise(req)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	stored := e.data[ex.ID]
+	stored.Custom = false
+	stored.IsPublic = true
+	cp := *stored
+	return &cp, nil
+}
+
+func (e *inMemoryExerciseRepository) UpdateCatalogueExercise(req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ex, ok := e.data[req.ID]
+	if !ok || ex.OwnerID != 0 {
+		return nil, sql.ErrNoRows
+	}
+	ex.Name = req.Name
+	ex.Description = req.Description
+	ex.Targets = req.Targets
+	ex.Intensity = req.Intensity
+	ex.Expertise = req.Expertise
+	ex.Image = req.Image
+	ex.Demo = req.Demo
+	ex.RecommendedCount = req.RecommendedCount
+	ex.RecommendedSets = req.RecommendedSets
+	ex.RecommendedDuration = req.RecommendedDuration
+
+	cp := *ex
+	return &cp, nil
+}
+
+func (e *inMemoryExerciseRepository) DeleteCatalogueExercise(id int64) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	ex, ok := e.data[id]
+	if !ok || ex.OwnerID != 0 {
+		return sql.ErrNoRows
+	}
+	delete(e.data, id)
+	return nil
+}
+
+func matchesExerciseFilter(ex *model.Exercise, filter model.ExerciseFilter) bool {
+	if ex.Deleted {
+		return false
+	}
+	if ex.OwnerID != 0 && !ex.IsPublic && ex.OwnerID != filter.ViewerID {
+		return false
+	}
+	if filter.Target != "" {
+		found := false
+		for _, t := range ex.Targets {
+			if strings.EqualFold(t, filter.Target) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Intensity != "" && !strings.EqualFold(ex.Intensity, filter.Intensity) {
+		return false
+	}
+	if filter.Expertise != "" && !strings.EqualFold(ex.Expertise, filter.Expertise) {
+		return false
+	}
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(ex.Name), search) && !strings.Contains(strings.ToLower(ex.Description), search) {
+			return false
+		}
+	}
+	return true
+}
@@ -5,10 +5,12 @@ import (
 	"sync"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util/constants"
 )
 
 type inMemoryUserRepository struct {
 	users         map[int64]*model.User
+	standings     map[int64]*model.UserStanding
 	goals         map[int64]*model.Goal
 	routines      map[int64]*model.ExerciseRoutine
 	nextID        int64
@@ -20,6 +22,7 @@ type inMemoryUserRepository struct {
 func NewInMemoryUserRepository() repository.UserRepository {
 	return &inMemoryUserRepository{
 		users:         make(map[int64]*model.User),
+		standings:     make(map[int64]*model.UserStanding),
 		goals:         make(map[int64]*model.Goal),
 		routines:      make(map[int64]*model.ExerciseRoutine),
 		nextID:        1,
@@ -33,6 +36,56 @@ func (u *inMemoryUserRepository) ReadUserByEmail(email string) (*model.User, err
 	panic("implement me")
 }
 
+func (u *inMemoryUserRepository) ReadUserByGoogleID(googleID string) (*model.User, error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	for _, user := range u.users {
+		if user.GoogleID == googleID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *inMemoryUserRepository) LinkGoogleAccount(email, googleID string) (*model.User, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	for _, user := range u.users {
+		if user.Email == email && user.GoogleID == "" {
+			user.GoogleID = googleID
+			user.IsVerified = true
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *inMemoryUserRepository) CreateGoogleUser(request model.CreateGoogleUserRequest) (*model.User, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	for _, user := range u.users {
+		if user.Username == request.Username || user.Email == request.Email || user.GoogleID == request.GoogleID {
+			return nil, errors.New("user already exists")
+		}
+	}
+
+	user := &model.User{
+		ID:         u.nextID,
+		Username:   request.Username,
+		Email:      request.Email,
+		Name:       request.Name,
+		GoogleID:   request.GoogleID,
+		Provider:   "google",
+		IsVerified: true,
+	}
+	u.users[user.ID] = user
+	u.nextID++
+	return user, nil
+}
+
 func (u *inMemoryUserRepository) ReadUsers() ([]*model.User, error) {
 	u.mutex.RLock()
 	defer u.mutex.RUnlock()
@@ -55,6 +108,60 @@ func (u *inMemoryUserRepository) ReadUserByID(id int64) (*model.User, error) {
 	return user, nil
 }
 
+// ReadUserRole reports in-memory users as regular users until their role is changed
+func (u *inMemoryUserRepository) ReadUserRole(id int64) (string, error) {
+	standing, err := u.ReadUserStanding(id)
+	if err != nil {
+		return "", err
+	}
+	return standing.Role, nil
+}
+
+func (u *inMemoryUserRepository) ReadUserStanding(id int64) (*model.UserStanding, error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	if _, exists := u.users[id]; !exists {
+		return nil, errors.New("user not found")
+	}
+	if standing, ok := u.standings[id]; ok {
+		cp := *standing
+		return &cp, nil
+	}
+	return &model.UserStanding{UserID: id, Role: constants.ROLE_USER}, nil
+}
+
+func (u *inMemoryUserRepository) UpdateUserStanding(request model.UpdateUserStandingRequest) (*model.UserStanding, error) {
+	return u.updateStanding(request.UserID, func(standing *model.UserStanding) {
+		standing.SuspendedUntil = request.SuspendedUntil
+		standing.BannedAt = request.BannedAt
+		standing.Reason = request.Reason
+	})
+}
+
+func (u *inMemoryUserRepository) UpdateUserRole(id int64, role string) (*model.UserStanding, error) {
+	return u.updateStanding(id, func(standing *model.UserStanding) {
+		standing.Role = role
+	})
+}
+
+func (u *inMemoryUserRepository) updateStanding(id int64, update func(*model.UserStanding)) (*model.UserStanding, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if _, exists := u.users[id]; !exists {
+		return nil, errors.New("user not found")
+	}
+	standing, ok := u.standings[id]
+	if !ok {
+		standing = &model.UserStanding{UserID: id, Role: constants.ROLE_USER}
+		u.standings[id] = standing
+	}
+	update(standing)
+	cp := *standing
+	return &cp, nil
+}
+
 func (u *inMemoryUserRepository) CreateUser(request model.CreateUserRequest) (*model.User, error) {
 	u.mutex.Lock()
 	defer u.mutex.Unlock()
@@ -113,6 +220,19 @@ func (u *inMemoryUserRepository) UpdateUser(request model.UpdateUserRequest) (*m
 	return user, nil
 }
 
+func (u *inMemoryUserRepository) UpdateAvatarMedia(userID int64, mediaID string) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	user, exists := u.users[userID]
+	if !exists {
+		return errors.New("user not found")
+	}
+
+	user.Avatar = model.MediaURL(mediaID)
+	return nil
+}
+
 func (u *inMemoryUserRepository) DeleteUser(request model.DeleteUserRequest) error {
 	u.mutex.Lock()
 	defer u.mutex.Unlock()
@@ -141,6 +261,7 @@ func (u *inMemoryUserRepository) CreateGoal(userID int64, request model.CreateGo
 		Deadline:    request.Deadline,
 		CreatedAt:   "2024-01-01",
 		Status:      "active",
+		Type:        request.Type,
 	}
 
 	u.goals[u.nextGoalID] = goal
@@ -170,23 +291,31 @@ func (u *inMemoryUserRepository) UpdateGoal(request model.UpdateGoalRequest) (*m
 	defer u.mutex.Unlock()
 
 	goal, exists := u.goals[request.ID]
-	if !exists {
+	if !exists || goal.UserID != request.UserID {
 		return nil, errors.New("goal not found")
 	}
 
-	goal.Name = request.Name
-	goal.Description = request.Description
-	goal.Deadline = request.Deadline
-	goal.Status = request.Status
+	if request.Name != nil {
+		goal.Name = *request.Name
+	}
+	if request.Description != nil {
+		goal.Description = *request.Description
+	}
+	if request.Deadline != nil {
+		goal.Deadline = *request.Deadline
+	}
+	if request.Status != nil {
+		goal.Status = *request.Status
+	}
 
 	return goal, nil
 }
 
-func (u *inMemoryUserRepository) DeleteGoal(goalID int64) error {
+func (u *inMemoryUserRepository) DeleteGoal(userID, goalID int64) error {
 	u.mutex.Lock()
 	defer u.mutex.Unlock()
 
-	if _, exists := u.goals[goalID]; !exists {
+	if goal, exists := u.goals[goalID]; !exists || goal.UserID != userID {
 		return errors.New("goal not found")
 	}
 
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
+)
+
+type mediaRepository struct {
+	db *sql.DB
+}
+
+func NewMediaRepository(db *sql.DB) repository.MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+const mediaColumns = "id, owner_id, content_type, size_bytes, width, height, COALESCE(thumbnail_type, ''), created_at"
+
+func scanMedia(row Scanner) (*model.Media, error) {
+	var media model.Media
+	if err := row.Scan(&media.ID, &media.OwnerID, &media.ContentType, &media.Size, &media.Width, &media.Height, &media.ThumbnailType, &media.CreatedAt); err != nil {
+		return nil, err
+	}
+	media.SetURLs()
+	return &media, nil
+}
+
+func (m *mediaRepository) CreateMedia(media *model.Media) (*model.Media, error) {
+	row := m.db.QueryRow(`
+		INSERT INTO media (id, owner_id, content_type, size_bytes, width, height, thumbnail_type)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+		RETURNING `+mediaColumns,
+		media.ID, media.OwnerID, media.ContentType, media.Size, media.Width, media.Height, media.ThumbnailType)
+	return scanMedia(row)
+}
+
+// ReadMediaByID returns sql.ErrNoRows when there is no such media
+func (m *mediaRepository) ReadMediaByID(id string) (*model.Media, error) {
+	return scanMedia(m.db.QueryRow(`SELECT `+mediaColumns+` FROM media WHERE id = $1`, id))
+}
+
+// ReadMediaByIDs leaves out ids that don't exist
+func (m *mediaRepository) ReadMediaByIDs(ids []string) ([]*model.Media, error) {
+	rows, err := m.db.Query(`SELECT `+mediaColumns+` FROM media WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*model.Media, 0, len(ids))
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, media)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+var mediaRowColumns = []string{"id", "owner_id", "content_type", "size_bytes", "width", "height", "thumbnail_type", "created_at"}
+
+func TestMediaRepository_CreateMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewMediaRepository(db)
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO media (id, owner_id, content_type, size_bytes, width, height, thumbnail_type)")).
+		WithArgs("abc", int64(4), "image/png", int64(100), 10, 20, "").
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns).AddRow("abc", int64(4), "image/png", int64(100), 10, 20, "", created))
+
+	got, err := repo.CreateMedia(&model.Media{ID: "abc", OwnerID: 4, ContentType: "image/png", Size: 100, Width: 10, Height: 20})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != "abc" || got.URL != "/media/abc" || !got.CreatedAt.Equal(created) {
+		t.Fatalf("unexpected media %+v", got)
+	}
+}
+
+func TestMediaRepository_ReadMediaByID_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewMediaRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media WHERE id = $1")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns))
+
+	if _, err := repo.ReadMediaByID("missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestMediaRepository_ReadMediaByIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewMediaRepository(db)
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media WHERE id = ANY($1)")).
+		WithArgs(pq.Array([]string{"a", "b"})).
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns).AddRow("a", int64(4), "image/jpeg", int64(900), 800, 600, "image/jpeg", created))
+
+	got, err := repo.ReadMediaByIDs([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].ThumbnailType != "image/jpeg" {
+		t.Fatalf("unexpected media %+v", got)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type personalRecordRepository struct {
+	db *sql.DB
+}
+
+func NewPersonalRecordRepository(db *sql.DB) repository.PersonalRecordRepository {
+	return &personalRecordRepository{db: db}
+}
+
+func scanPersonalRecordRow(row Scanner) (*model.PersonalRecord, error) {
+	var rec model.PersonalRecord
+	var sessionID, setID sql.NullInt64
+	if err := row.Scan(
+		&rec.ID,
+		&rec.UserID,
+		&rec.ExerciseID,
+		&rec.Type,
+		&rec.Formula,
+		&rec.Value,
+		&rec.Weight,
+		&rec.Reps,
+		&sessionID,
+		&setID,
+		&rec.AchievedAt,
+	); err != nil {
+		return nil, err
+	}
+	rec.SessionID = sessionID.Int64
+	rec.SetID = setID.Int64
+	return &rec, nil
+}
+
+func (p *personalRecordRepository) queryRecords(ctx context.Context, q string, args ...any) ([]*model.PersonalRecord, error) {
+	rows, err := p.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records = make([]*model.PersonalRecord, 0)
+	for rows.Next() {
+		rec, err := scanPersonalRecordRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// current bests are the top row per exercise, record type and formula;
+// rep records are additionally kept per weight
+const selectBestRecords = `
+		SELECT DISTINCT ON (exercise_id, record_type, formula, CASE WHEN record_type = 'max_reps' THEN weight ELSE 0 END)
+		       id, user_id, exercise_id, record_type, formula, value, weight, reps, session_id, set_id, achieved_at
+		FROM personal_records
+		WHERE user_id = $1
+	`
+
+func (p *personalRecordRepository) ReadBestRecords(userID int64) ([]*model.PersonalRecord, error) {
+	ctx := context.Background()
+
+	const q = selectBestRecords + `
+		ORDER BY exercise_id, record_type, formula, CASE WHEN record_type = 'max_reps' THEN weight ELSE 0 END, value DESC, achieved_at DESC;
+	`
+
+	return p.queryRecords(ctx, q, userID)
+}
+
+func (p *personalRecordRepository) ReadExerciseBestRecords(userID int64, exerciseID int64) ([]*model.PersonalRecord, error) {
+	ctx := context.Background()
+
+	const q = selectBestRecords + `
+		  AND exercise_id = $2
+		ORDER BY exercise_id, record_type, formula, CASE WHEN record_type = 'max_reps' THEN weight ELSE 0 END, value DESC, achieved_at DESC;
+	`
+
+	return p.queryRecords(ctx, q, userID, exerciseID)
+}
+
+func (p *personalRecordRepository) ReadExerciseRecordHistory(userID int64, exerciseID int64) ([]*model.PersonalRecord, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT id, user_id, exercise_id, record_type, formula, value, weight, reps, session_id, set_id, achieved_at
+		FROM personal_records
+		WHERE user_id = $1
+		  AND exercise_id = $2
+		ORDER BY achieved_at DESC, id DESC;
+	`
+
+	return p.queryRecords(ctx, q, userID, exerciseID)
+}
+
+func (p *personalRecordRepository) ReadSessionExerciseVolume(sessionID int64, exerciseID int64) (float64, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT COALESCE(SUM(weight * reps), 0)
+		FROM workout_sets
+		WHERE session_id = $1
+		  AND exercise_id = $2;
+	`
+
+	var volume float64
+	if err := p.db.QueryRowContext(ctx, q, sessionID, exerciseID).Scan(&volume); err != nil {
+		return 0, err
+	}
+	return volume, nil
+}
+
+// CreateRecords raises the session's max_volume record for an exercise in
+// place, since its running total grows with every set
+func (p *personalRecordRepository) CreateRecords(records []*model.PersonalRecord) ([]*model.PersonalRecord, error) {
+	ctx := context.Background()
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	const q = `
+		INSERT INTO personal_records (user_id, exercise_id, record_type, formula, value, weight, reps, session_id, set_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), NULLIF($9, 0))
+		ON CONFLICT (user_id, exercise_id, session_id, record_type) WHERE record_type = 'max_volume'
+		DO UPDATE SET value = EXCLUDED.value,
+		              weight = EXCLUDED.weight,
+		              reps = EXCLUDED.reps,
+		              set_id = EXCLUDED.set_id,
+		              achieved_at = NOW()
+		RETURNING id, user_id, exercise_id, record_type, formula, value, weight, reps, session_id, set_id, achieved_at;
+	`
+
+	var created = make([]*model.PersonalRecord, 0, len(records))
+	for _, rec := range records {
+		row, err := scanPersonalRecordRow(tx.QueryRowContext(ctx, q,
+			rec.UserID,
+			rec.ExerciseID,
+			rec.Type,
+			rec.Formula,
+			rec.Value,
+			rec.Weight,
+			rec.Reps,
+			rec.SessionID,
+			rec.SetID,
+		))
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, row)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var personalRecordCols = []string{"id", "user_id", "exercise_id", "record_type", "formula", "value", "weight", "reps", "session_id", "set_id", "achieved_at"}
+
+func TestPersonalRecordRepository_ReadBestRecords_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(exercise_id, record_type, formula`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(personalRecordCols).
+			AddRow(1, 7, 2, "max_weight", "", 100.0, 100.0, 5, 5, 11, "2025-01-02T10:00:00Z").
+			AddRow(2, 7, 2, "estimated_1rm", "epley", 116.67, 100.0, 5, nil, nil, "2025-01-02T10:00:00Z"))
+
+	got, err := repo.ReadBestRecords(7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	if got[0].SessionID != 5 || got[0].SetID != 11 || got[0].Value != 100 {
+		t.Fatalf("bad first record: %#v", got[0])
+	}
+	if got[1].SessionID != 0 || got[1].SetID != 0 || got[1].Formula != "epley" {
+		t.Fatalf("bad second record: %#v", got[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPersonalRecordRepository_ReadExerciseBestRecords_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("AND exercise_id = $2")).
+		WithArgs(int64(7), int64(2)).
+		WillReturnRows(sqlmock.NewRows(personalRecordCols))
+
+	got, err := repo.ReadExerciseBestRecords(7, 2)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("expected empty slice, got %#v", got)
+	}
+}
+
+func TestPersonalRecordRepository_ReadExerciseRecordHistory_Error(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY achieved_at DESC, id DESC")).
+		WithArgs(int64(7), int64(2)).
+		WillReturnError(errors.New("boom"))
+
+	if _, err := repo.ReadExerciseRecordHistory(7, 2); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestPersonalRecordRepository_ReadSessionExerciseVolume_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(weight * reps), 0)")).
+		WithArgs(int64(5), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(1450.5))
+
+	got, err := repo.ReadSessionExerciseVolume(5, 2)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != 1450.5 {
+		t.Fatalf("got %v, want 1450.5", got)
+	}
+}
+
+func TestPersonalRecordRepository_CreateRecords_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	in := []*model.PersonalRecord{
+		{UserID: 7, ExerciseID: 2, Type: "max_weight", Value: 100, Weight: 100, Reps: 5, SessionID: 5, SetID: 11},
+		{UserID: 7, ExerciseID: 2, Type: "estimated_1rm", Formula: "epley", Value: 116.67, Weight: 100, Reps: 5, SessionID: 5, SetID: 11},
+	}
+
+	mock.ExpectBegin()
+	for i, rec := range in {
+		mock.ExpectQuery("INSERT INTO personal_records").
+			WithArgs(rec.UserID, rec.ExerciseID, rec.Type, rec.Formula, rec.Value, rec.Weight, rec.Reps, rec.SessionID, rec.SetID).
+			WillReturnRows(sqlmock.NewRows(personalRecordCols).
+				AddRow(i+1, rec.UserID, rec.ExerciseID, rec.Type, rec.Formula, rec.Value, rec.Weight, rec.Reps, rec.SessionID, rec.SetID, "2025-01-02T10:00:00Z"))
+	}
+	mock.ExpectCommit()
+
+	got, err := repo.CreateRecords(in)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 || got[1].AchievedAt == "" {
+		t.Fatalf("unexpected records: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPersonalRecordRepository_CreateRecords_RaisesSessionVolume(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	rec := &model.PersonalRecord{UserID: 7, ExerciseID: 2, Type: "max_volume", Value: 1500, Weight: 100, Reps: 5, SessionID: 5, SetID: 12}
+
+	// the session's earlier max_volume row 3 is updated rather than joined by another
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO personal_records .+ ON CONFLICT \(user_id, exercise_id, session_id, record_type\) WHERE record_type = 'max_volume' `+
+		`DO UPDATE SET value = EXCLUDED.value, .+ set_id = EXCLUDED.set_id`).
+		WithArgs(rec.UserID, rec.ExerciseID, rec.Type, rec.Formula, rec.Value, rec.Weight, rec.Reps, rec.SessionID, rec.SetID).
+		WillReturnRows(sqlmock.NewRows(personalRecordCols).
+			AddRow(3, rec.UserID, rec.ExerciseID, rec.Type, "", rec.Value, rec.Weight, rec.Reps, rec.SessionID, rec.SetID, "2025-01-02T10:05:00Z"))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateRecords([]*model.PersonalRecord{rec})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 3 || got[0].Value != 1500 || got[0].SetID != 12 {
+		t.Fatalf("unexpected records: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPersonalRecordRepository_CreateRecords_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPersonalRecordRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO personal_records").WillReturnError(errors.New("check violation"))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateRecords([]*model.PersonalRecord{{UserID: 7, ExerciseID: 2, Type: "bogus"}})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
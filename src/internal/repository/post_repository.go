@@ -2,12 +2,17 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type PostRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewPostRepository(db *sql.DB) repository.PostRepository {
@@ -50,7 +55,8 @@ func (p *PostRepository) ReadPostsByUserID(targetUserID int64, userID int64) ([]
         p.created_at,
         u.username,
         COUNT(DISTINCT pl_all.user_id) AS likes,
-        pl_user.post_id IS NOT NULL AS is_liked
+        pl_user.post_id IS NOT NULL AS is_liked,
+        p.edited_at
     FROM posts p 
     LEFT JOIN post_likes pl_all ON p.id = pl_all.post_id
     LEFT JOIN post_likes pl_user ON p.id = pl_user.post_id AND pl_user.user_id = $1
@@ -68,6 +74,7 @@ func (p *PostRepository) ReadPostsByUserID(targetUserID int64, userID int64) ([]
 	var result = make([]*model.Post, 0)
 	for rows.Next() {
 		var post model.Post
+		var editedAt sql.NullTime
 		if err := rows.Scan(
 			&post.ID,
 			&post.Title,
@@ -78,9 +85,11 @@ func (p *PostRepository) ReadPostsByUserID(targetUserID int64, userID int64) ([]
 			&post.PostedBy,
 			&post.Likes,
 			&post.IsLiked,
+			&editedAt,
 		); err != nil {
 			return nil, err
 		}
+		setEdited(&post, editedAt)
 		result = append(result, &post)
 	}
 
@@ -91,25 +100,70 @@ func (p *PostRepository) ReadPostsByUserID(targetUserID int64, userID int64) ([]
 	return result, nil
 }
 
-func (p *PostRepository) ReadPosts(userID int64) ([]*model.Post, error) {
-	rows, err := p.db.Query(`
-    SELECT 
-        p.id,
-        p.title,
-        p.body,
-        p.caption,
-        p.status,
-        p.created_at,
-        u.username,
-        COUNT(DISTINCT pl_all.user_id) AS likes,
-        pl_user.post_id IS NOT NULL AS is_liked
-    FROM posts p 
-    LEFT JOIN post_likes pl_all ON p.id = pl_all.post_id
-    LEFT JOIN post_likes pl_user ON p.id = pl_user.post_id AND pl_user.user_id = $1
-    JOIN users u ON u.id = p.user_id
-    GROUP BY p.id, u.username, pl_user.post_id`,
-		userID,
-	)
+// ReadPosts pages through a feed. Banned users' posts are left out, and
+// private profiles only reach their followers through the following scope.
+func (p *PostRepository) ReadPosts(filter model.PostFeedFilter) ([]*model.Post, error) {
+	args := make([]interface{}, 0, 6)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	viewer := arg(filter.ViewerID)
+	conditions := []string{"u.banned_at IS NULL"}
+	if filter.Scope == model.FeedScopeDiscover {
+		conditions = append(conditions, "u.is_private = FALSE")
+	} else {
+		conditions = append(conditions, "(p.user_id = "+viewer+" OR p.user_id IN (SELECT followed_user_id FROM follows WHERE following_user_id = "+viewer+"))")
+	}
+
+	score, order := "0::float8", "created_at DESC, id DESC"
+	if filter.Sort == model.FeedSortRanked {
+		// Each like counts once and each comment twice, decaying with the post's
+		// age in hours. Scores only use asOf, so they don't move between pages.
+		asOf := arg(filter.AsOf.UTC()) + "::timestamp"
+		conditions = append(conditions, "p.created_at <= "+asOf)
+		score = "(likes + 2 * comments + 1) / POWER(EXTRACT(EPOCH FROM (" + asOf + " - created_at)) / 3600 + 2, 1.5)"
+		order = "score DESC, id DESC"
+	}
+
+	after := ""
+	if filter.After != nil {
+		if filter.Sort == model.FeedSortRanked {
+			after = " WHERE (score, id) < (" + arg(filter.After.Score) + "::float8, " + arg(filter.After.ID) + ")"
+		} else {
+			after = " WHERE (created_at, id) < (" + arg(filter.After.CreatedAt.UTC()) + "::timestamp, " + arg(filter.After.ID) + ")"
+		}
+	}
+
+	q := `
+    WITH feed AS (
+        SELECT
+            p.id,
+            p.title,
+            p.body,
+            p.caption,
+            p.status,
+            p.created_at,
+            u.username,
+            (SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = p.id) AS likes,
+            EXISTS (SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = ` + viewer + `) AS is_liked,
+            (SELECT COUNT(*) FROM post_comments pc WHERE pc.post_id = p.id AND pc.deleted_at IS NULL) AS comments,
+            p.edited_at
+        FROM posts p
+        JOIN users u ON u.id = p.user_id
+        WHERE ` + strings.Join(conditions, " AND ") + `
+    ), scored AS (
+        SELECT feed.*, ` + score + ` AS score FROM feed
+    )
+    SELECT id, title, body, caption, status, created_at, username, likes, is_liked, edited_at, score
+    FROM scored` + after + `
+    ORDER BY ` + order
+	if filter.Limit > 0 {
+		q += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := p.db.Query(q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,19 +172,24 @@ func (p *PostRepository) ReadPosts(userID int64) ([]*model.Post, error) {
 	var result []*model.Post = make([]*model.Post, 0)
 	for rows.Next() {
 		var post model.Post
+		var editedAt sql.NullTime
 		if err := rows.Scan(
 			&post.ID,
 			&post.Title,
 			&post.Body,
 			&post.Caption,
 			&post.Status,
-			&post.Date,
+			&post.CreatedAt,
 			&post.PostedBy,
 			&post.Likes,
 			&post.IsLiked,
+			&editedAt,
+			&post.Score,
 		); err != nil {
 			return nil, err
 		}
+		post.Date = post.CreatedAt.Format(time.RFC3339Nano)
+		setEdited(&post, editedAt)
 		result = append(result, &post)
 	}
 
@@ -152,7 +211,8 @@ func (p *PostRepository) ReadPost(id int64, userID int64) (*model.Post, error) {
         p.created_at,
         u.username,
         COUNT(DISTINCT pl_all.user_id) AS likes,
-        pl_user.post_id IS NOT NULL AS is_liked
+        pl_user.post_id IS NOT NULL AS is_liked,
+        p.edited_at
     FROM posts p 
     LEFT JOIN post_likes pl_all ON p.id = pl_all.post_id
     LEFT JOIN post_likes pl_user 
@@ -165,6 +225,7 @@ func (p *PostRepository) ReadPost(id int64, userID int64) (*model.Post, error) {
 	)
 
 	var post model.Post
+	var editedAt sql.NullTime
 	err := row.Scan(
 		&post.ID,
 		&post.Title,
@@ -175,21 +236,37 @@ func (p *PostRepository) ReadPost(id int64, userID int64) (*model.Post, error) {
 		&post.PostedBy,
 		&post.Likes,
 		&post.IsLiked,
+		&editedAt,
 	)
 
 	if err != nil {
 		return nil, err
 	}
+	setEdited(&post, editedAt)
 	return &post, nil
 }
 
+// ReadPostOwnerID returns sql.ErrNoRows when the post does not exist
+func (p *PostRepository) ReadPostOwnerID(id int64) (int64, error) {
+	var ownerID int64
+	err := p.db.QueryRow(`SELECT user_id FROM posts WHERE id = $1`, id).Scan(&ownerID)
+	return ownerID, err
+}
+
 func (p *PostRepository) CreatePost(req model.CreatePostRequest) (*model.Post, error) {
 	var id int64
 	row := p.db.QueryRow(`
-		INSERT INTO posts (title, body, caption, status, user_id)
-		VALUES ($1,$2,$3,$4,$5)
-		RETURNING id`,
-		req.Title, req.Body, req.Caption, req.Status, req.PostedBy)
+		WITH post AS (
+			INSERT INTO posts (title, body, caption, status, user_id, routine_id, session_id)
+			VALUES ($1,$2,$3,$4,$5,NULLIF($6, 0),NULLIF($7, 0))
+			RETURNING id
+		), attached AS (
+			INSERT INTO post_media (post_id, media_id, position)
+			SELECT post.id, m.id, m.position
+			FROM post, unnest($8::varchar[]) WITH ORDINALITY AS m(id, position)
+		)
+		SELECT id FROM post`,
+		req.Title, req.Body, req.Caption, req.Status, req.PostedBy, req.RoutineID, req.SessionID, pq.Array(req.MediaIDs))
 
 	if err := row.Scan(&id); err != nil {
 		return nil, err
@@ -198,11 +275,172 @@ func (p *PostRepository) CreatePost(req model.CreatePostRequest) (*model.Post, e
 	return p.ReadPost(id, req.PostedBy)
 }
 
+// ReadPostMedia lists the images attached to the given posts, each post's in
+// the order they were attached
+func (p *PostRepository) ReadPostMedia(postIDs []int64) ([]*model.Media, error) {
+	rows, err := p.db.Query(`
+		SELECT pm.post_id, `+mediaColumns+`
+		FROM post_media pm
+		JOIN media ON media.id = pm.media_id
+		WHERE pm.post_id = ANY($1)
+		ORDER BY pm.post_id, pm.position`, pq.Array(postIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*model.Media, 0)
+	for rows.Next() {
+		var media model.Media
+		if err := rows.Scan(&media.PostID, &media.ID, &media.OwnerID, &media.ContentType, &media.Size, &media.Width, &media.Height, &media.ThumbnailType, &media.CreatedAt); err != nil {
+			return nil, err
+		}
+		media.SetURLs()
+		result = append(result, &media)
+	}
+	return result, rows.Err()
+}
+
+// ReadPostWorkouts summarizes what the given posts share, leaving out posts
+// that share nothing. Exercises are listed in the order they were first logged,
+// or in the routine's order.
+func (p *PostRepository) ReadPostWorkouts(postIDs []int64) ([]*model.WorkoutSummary, error) {
+	rows, err := p.db.Query(`
+    SELECT
+        p.id,
+        COALESCE(p.routine_id, ws.routine_id, 0),
+        COALESCE(p.session_id, 0),
+        COALESCE(NULLIF(ws.name, ''), wr.name, ''),
+        COALESCE(EXTRACT(EPOCH FROM (ws.finished_at - ws.started_at))::bigint, 0)
+    FROM posts p
+    LEFT JOIN workout_sessions ws ON ws.id = p.session_id
+    LEFT JOIN workout_routine wr ON wr.id = COALESCE(p.routine_id, ws.routine_id)
+    WHERE p.id = ANY($1)
+      AND (p.routine_id IS NOT NULL OR p.session_id IS NOT NULL)
+    ORDER BY p.id`, pq.Array(postIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result = make([]*model.WorkoutSummary, 0)
+	byPost := make(map[int64]*model.WorkoutSummary)
+	bySession := make(map[int64]*model.WorkoutSummary)
+	sessionIDs := make([]int64, 0)
+	for rows.Next() {
+		summary := model.WorkoutSummary{Exercises: make([]*model.WorkoutSummaryExercise, 0), Records: make([]*model.PersonalRecord, 0)}
+		if err := rows.Scan(&summary.PostID, &summary.RoutineID, &summary.SessionID, &summary.Name, &summary.DurationSeconds); err != nil {
+			return nil, err
+		}
+		result = append(result, &summary)
+		byPost[summary.PostID] = &summary
+		if summary.SessionID != 0 {
+			bySession[summary.SessionID] = &summary
+			sessionIDs = append(sessionIDs, summary.SessionID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	if err := p.readPostWorkoutExercises(postIDs, byPost); err != nil {
+		return nil, err
+	}
+	if len(sessionIDs) > 0 {
+		if err := p.readPostWorkoutRecords(sessionIDs, bySession); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *PostRepository) readPostWorkoutExercises(postIDs []int64, byPost map[int64]*model.WorkoutSummary) error {
+	rows, err := p.db.Query(`
+    SELECT p.id, e.id, COALESCE(e.name, ''), COUNT(s.id), COALESCE(SUM(s.weight * s.reps), 0), MIN(s.id) AS position
+    FROM posts p
+    JOIN workout_sets s ON s.session_id = p.session_id
+    JOIN exercises e ON e.id = s.exercise_id
+    WHERE p.id = ANY($1)
+    GROUP BY p.id, e.id, e.name
+    UNION ALL
+    SELECT p.id, e.id, COALESCE(e.name, ''), 0, 0, er.position
+    FROM posts p
+    JOIN exercises_in_routine er ON er.workout_routine_id = p.routine_id
+    JOIN exercises e ON e.id = er.exercise_id
+    WHERE p.id = ANY($1)
+    ORDER BY 1, 6`, pq.Array(postIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, position int64
+		var exercise model.WorkoutSummaryExercise
+		if err := rows.Scan(&postID, &exercise.ExerciseID, &exercise.Name, &exercise.Sets, &exercise.Volume, &position); err != nil {
+			return err
+		}
+		if summary := byPost[postID]; summary != nil {
+			summary.Exercises = append(summary.Exercises, &exercise)
+			summary.TotalVolume += exercise.Volume
+		}
+	}
+	return rows.Err()
+}
+
+// readPostWorkoutRecords attaches the personal records each session set
+func (p *PostRepository) readPostWorkoutRecords(sessionIDs []int64, bySession map[int64]*model.WorkoutSummary) error {
+	rows, err := p.db.Query(`
+    SELECT id, user_id, exercise_id, record_type, formula, value, weight, reps, session_id, set_id, achieved_at
+    FROM personal_records
+    WHERE session_id = ANY($1)
+    ORDER BY achieved_at, id`, pq.Array(sessionIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := scanPersonalRecordRow(rows)
+		if err != nil {
+			return err
+		}
+		if summary := bySession[record.SessionID]; summary != nil {
+			summary.Records = append(summary.Records, record)
+		}
+	}
+	return rows.Err()
+}
+
+func setEdited(post *model.Post, editedAt sql.NullTime) {
+	if editedAt.Valid {
+		post.Edited = true
+		post.EditedAt = &editedAt.Time
+	}
+}
+
+// UpdatePost keeps the version it replaces as a revision. Nothing is stored
+// when the request leaves the post as it was.
 func (p *PostRepository) UpdatePost(req model.UpdatePostRequest) (*model.Post, error) {
 	_, err := p.db.Exec(`
-		UPDATE posts 
-		SET title=$1, body=$2, caption=$3, status=$4 
-		WHERE id=$5`,
+		WITH previous AS (
+			SELECT id, title, caption, body, status, COALESCE(edited_at, created_at) AS written_at
+			FROM posts
+			WHERE id = $5
+			  AND (title, body, caption, status) IS DISTINCT FROM
+			      (COALESCE($1, title), COALESCE($2, body), COALESCE($3, caption), COALESCE($4, status))
+			FOR UPDATE
+		), revision AS (
+			INSERT INTO post_revisions (post_id, title, caption, body, status, written_at)
+			SELECT id, title, caption, body, status, written_at FROM previous
+		)
+		UPDATE posts
+		SET title = COALESCE($1, title), body = COALESCE($2, body), caption = COALESCE($3, caption),
+		    status = COALESCE($4, status), edited_at = NOW()
+		WHERE id IN (SELECT id FROM previous)`,
 		req.Title, req.Body, req.Caption, req.Status, req.ID)
 	if err != nil {
 		return nil, err
@@ -211,59 +449,124 @@ func (p *PostRepository) UpdatePost(req model.UpdatePostRequest) (*model.Post, e
 	return p.ReadPost(req.ID, 0)
 }
 
-func (p *PostRepository) DeletePost(id int64) error {
-	_, err := p.db.Exec(`DELETE FROM posts WHERE id = $1`, id)
-	return err
-}
-
-func (p *PostRepository) ReadCommentsByPost(postID int64) ([]*model.Comment, error) {
+// ReadPostRevisions lists the replaced versions of a post, newest first
+func (p *PostRepository) ReadPostRevisions(postID int64) ([]*model.PostRevision, error) {
 	rows, err := p.db.Query(`
-		SELECT pc.id, pc.body, pc.created_at, u.username 
-		FROM post_comments pc 
-		JOIN users u ON u.id = pc.user_id 
-		WHERE pc.post_id = $1 AND pc.parent_comment_id IS NULL
-		ORDER BY pc.created_at ASC`, postID)
+		SELECT id, post_id, COALESCE(title, ''), COALESCE(caption, ''), COALESCE(body, ''), COALESCE(status, ''),
+		       COALESCE(written_at, replaced_at), replaced_at
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY replaced_at DESC, id DESC`, postID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result = make([]*model.Comment, 0)
+	revisions := make([]*model.PostRevision, 0)
 	for rows.Next() {
-		var c model.Comment
-		err := rows.Scan(&c.ID, &c.Comment, &c.Date, &c.Username)
-		if err != nil {
+		var r model.PostRevision
+		if err := rows.Scan(&r.ID, &r.PostID, &r.Title, &r.Caption, &r.Body, &r.Status, &r.WrittenAt, &r.ReplacedAt); err != nil {
 			return nil, err
 		}
-		result = append(result, &c)
+		revisions = append(revisions, &r)
 	}
-
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return revisions, nil
 }
 
-func (p *PostRepository) ReadCommentsByComment(commentID int64) ([]*model.Comment, error) {
-	rows, err := p.db.Query(`
-		SELECT pc.id, pc.body, pc.created_at, u.username 
-		FROM post_comments pc 
-		JOIN users u ON u.id = pc.user_id 
-		WHERE pc.parent_comment_id = $1
-		ORDER BY pc.created_at ASC`, commentID)
+func (p *PostRepository) DeletePost(id int64) error {
+	_, err := p.db.Exec(`DELETE FROM posts WHERE id = $1`, id)
+	return err
+}
+
+// ReadComments loads the comments a filter asks for and their replies in one
+// query. Deleted comments are left out unless something replied to them.
+// Comments come back ordered by level, then oldest first.
+func (p *PostRepository) ReadComments(filter model.CommentFilter) ([]*model.Comment, error) {
+	args := make([]interface{}, 0, 7)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	posts := arg(pq.Array(filter.PostIDs))
+	anchor := "v.parent_comment_id IS NULL"
+	if filter.ParentID != 0 {
+		anchor = "v.parent_comment_id = " + arg(filter.ParentID)
+	}
+	if filter.After != nil {
+		anchor += " AND (v.created_at, v.id) > (" + arg(filter.After.CreatedAt.UTC()) + "::timestamp, " + arg(filter.After.ID) + ")"
+	}
+
+	q := `
+    WITH RECURSIVE visible AS (
+        SELECT pc.id, pc.post_id, pc.parent_comment_id, pc.created_at,
+            ROW_NUMBER() OVER (PARTITION BY pc.parent_comment_id ORDER BY pc.created_at, pc.id) AS position
+        FROM post_comments pc
+        WHERE pc.post_id = ANY(` + posts + `)
+          AND (pc.deleted_at IS NULL OR EXISTS (SELECT 1 FROM post_comments r WHERE r.parent_comment_id = pc.id))
+    ), page AS (
+        SELECT v.id, ROW_NUMBER() OVER (PARTITION BY v.post_id ORDER BY v.created_at, v.id) AS position
+        FROM visible v
+        WHERE ` + anchor + `
+    ), thread AS (
+        SELECT id, 0 AS level FROM page WHERE position <= ` + arg(filter.Limit) + `
+        UNION ALL
+        SELECT v.id, t.level + 1
+        FROM visible v
+        JOIN thread t ON v.parent_comment_id = t.id
+        WHERE t.level < ` + arg(filter.Depth) + ` AND v.position <= ` + arg(filter.Replies) + `
+    )
+    SELECT
+        pc.id,
+        pc.post_id,
+        COALESCE(pc.parent_comment_id, 0),
+        t.level,
+        u.username,
+        pc.body,
+        pc.created_at,
+        pc.edited_at,
+        pc.deleted_at IS NOT NULL,
+        (SELECT COUNT(*) FROM visible r WHERE r.parent_comment_id = pc.id) AS replies
+    FROM thread t
+    JOIN post_comments pc ON pc.id = t.id
+    JOIN users u ON u.id = pc.user_id
+    ORDER BY t.level, pc.created_at, pc.id`
+
+	rows, err := p.db.Query(q, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []*model.Comment
+	var result = make([]*model.Comment, 0)
 	for rows.Next() {
 		var c model.Comment
-		err := rows.Scan(&c.ID, &c.Comment, &c.Date, &c.Username)
-		if err != nil {
+		var editedAt sql.NullTime
+		if err := rows.Scan(
+			&c.ID,
+			&c.PostID,
+			&c.ParentID,
+			&c.Level,
+			&c.Username,
+			&c.Comment,
+			&c.CreatedAt,
+			&editedAt,
+			&c.Deleted,
+			&c.ReplyCount,
+		); err != nil {
 			return nil, err
 		}
+		c.Date = c.CreatedAt.Format(time.RFC3339Nano)
+		if c.Deleted {
+			c.Username, c.Comment = "", ""
+		} else if editedAt.Valid {
+			c.Edited = true
+			c.EditedAt = &editedAt.Time
+		}
 		result = append(result, &c)
 	}
 
@@ -274,6 +577,71 @@ func (p *PostRepository) ReadCommentsByComment(commentID int64) ([]*model.Commen
 	return result, nil
 }
 
+// ReadCommentRef returns sql.ErrNoRows when the comment does not exist
+func (p *PostRepository) ReadCommentRef(id int64) (*model.CommentRef, error) {
+	row := p.db.QueryRow(`
+    WITH RECURSIVE ancestors AS (
+        SELECT parent_comment_id FROM post_comments WHERE id = $1
+        UNION ALL
+        SELECT pc.parent_comment_id
+        FROM post_comments pc
+        JOIN ancestors a ON pc.id = a.parent_comment_id
+    )
+    SELECT pc.id, pc.post_id, pc.user_id, p.user_id, pc.deleted_at IS NOT NULL,
+        (SELECT COUNT(*) FROM ancestors WHERE parent_comment_id IS NOT NULL)
+    FROM post_comments pc
+    JOIN posts p ON p.id = pc.post_id
+    WHERE pc.id = $1`, id)
+
+	var ref model.CommentRef
+	if err := row.Scan(&ref.ID, &ref.PostID, &ref.AuthorID, &ref.PostAuthorID, &ref.Deleted, &ref.Depth); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// UpdateComment returns sql.ErrNoRows when the comment is gone or deleted
+func (p *PostRepository) UpdateComment(req model.UpdateCommentRequest) (*model.Comment, error) {
+	row := p.db.QueryRow(`
+    UPDATE post_comments pc
+    SET body = $1, edited_at = NOW()
+    FROM users u
+    WHERE pc.id = $2 AND pc.deleted_at IS NULL AND u.id = pc.user_id
+    RETURNING pc.id, pc.post_id, COALESCE(pc.parent_comment_id, 0), u.username, pc.body, pc.created_at, pc.edited_at,
+        (SELECT COUNT(*) FROM post_comments r
+         WHERE r.parent_comment_id = pc.id
+           AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM post_comments rr WHERE rr.parent_comment_id = r.id)))`,
+		req.Comment, req.ID)
+
+	var c model.Comment
+	var editedAt time.Time
+	if err := row.Scan(&c.ID, &c.PostID, &c.ParentID, &c.Username, &c.Comment, &c.CreatedAt, &editedAt, &c.ReplyCount); err != nil {
+		return nil, err
+	}
+	c.Date = c.CreatedAt.Format(time.RFC3339Nano)
+	c.Edited = true
+	c.EditedAt = &editedAt
+	c.Replies = make([]*model.Comment, 0)
+	return &c, nil
+}
+
+// DeleteComment only marks the comment deleted so its replies keep their
+// place. It returns sql.ErrNoRows when the comment is gone or already deleted.
+func (p *PostRepository) DeleteComment(id int64) error {
+	res, err := p.db.Exec(`UPDATE post_comments SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 func (p *PostRepository) CommentOnPost(req model.CommentOnPostRequest) error {
 	_, err := p.db.Exec(`
 		INSERT INTO post_comments (body, user_id, post_id)
@@ -291,7 +659,11 @@ func (p *PostRepository) CommentOnComment(req model.CommentOnCommentRequest) err
 }
 
 func (p *PostRepository) LikePost(req model.LikePostRequest) (*model.Post, error) {
-	_, err := p.db.Exec(`INSERT INTO post_likes(user_id, post_id,created_at) VALUES ($1,$2,now())`, req.UserID, req.PostID)
+	_, err := p.db.Exec(`
+		INSERT INTO post_likes (user_id, post_id, reaction, created_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = EXCLUDED.reaction, created_at = EXCLUDED.created_at`,
+		req.UserID, req.PostID, req.Reaction)
 	if err != nil {
 		return nil, err
 	}
@@ -307,3 +679,73 @@ func (p *PostRepository) UnlikePost(req model.UnikePostRequest) (*model.Post, er
 
 	return p.ReadPost(req.PostID, req.UserID)
 }
+
+// ReadPostReactions counts each post's reactions by type
+func (p *PostRepository) ReadPostReactions(postIDs []int64, viewerID int64) ([]*model.ReactionCount, error) {
+	rows, err := p.db.Query(`
+		SELECT post_id, reaction, COUNT(*), BOOL_OR(user_id = $2)
+		FROM post_likes
+		WHERE post_id = ANY($1)
+		GROUP BY post_id, reaction`,
+		pq.Array(postIDs), viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*model.ReactionCount, 0)
+	for rows.Next() {
+		var count model.ReactionCount
+		if err := rows.Scan(&count.PostID, &count.Reaction, &count.Count, &count.Mine); err != nil {
+			return nil, err
+		}
+		result = append(result, &count)
+	}
+	return result, rows.Err()
+}
+
+// ReadReactions pages through who reacted to a post, newest first
+func (p *PostRepository) ReadReactions(filter model.ReactionFilter) ([]*model.Reaction, error) {
+	args := []interface{}{filter.PostID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"pl.post_id = $1"}
+	if filter.Type != "" {
+		conditions = append(conditions, "pl.reaction = "+arg(filter.Type))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(pl.created_at, pl.user_id) < ("+arg(filter.After.CreatedAt.UTC())+"::timestamp, "+arg(filter.After.UserID)+")")
+	}
+
+	q := `
+		SELECT u.id, u.username, COALESCE(u.name, ''), u.avatar_data, u.avatar_media_id, pl.reaction, pl.created_at
+		FROM post_likes pl
+		JOIN users u ON u.id = pl.user_id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY pl.created_at DESC, pl.user_id DESC`
+	if filter.Limit > 0 {
+		q += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := p.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*model.Reaction, 0)
+	for rows.Next() {
+		var reaction model.Reaction
+		var avatarData ByteaData
+		var avatarMediaID sql.NullString
+		if err := rows.Scan(&reaction.UserID, &reaction.Username, &reaction.Name, &avatarData, &avatarMediaID, &reaction.Reaction, &reaction.CreatedAt); err != nil {
+			return nil, err
+		}
+		reaction.Avatar = avatarURL(avatarMediaID, avatarData)
+		result = append(result, &reaction)
+	}
+	return result, rows.Err()
+}
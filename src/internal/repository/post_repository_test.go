@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"workoutpal/src/internal/model"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 )
 
 func TestPostRepository_ReadPostsByUserID_OK(t *testing.T) {
@@ -25,10 +28,10 @@ func TestPostRepository_ReadPostsByUserID_OK(t *testing.T) {
 		WillReturnRows(privacyRow)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).
-		AddRow(1, "A", "B", "C", "active", "now", "user1", 5, true).
-		AddRow(2, "X", "Y", "Z", "inactive", "now", "user2", 0, false)
+		AddRow(1, "A", "B", "C", "active", "now", "user1", 5, true, nil).
+		AddRow(2, "X", "Y", "Z", "inactive", "now", "user2", 0, false, nil)
 
 	// Then expect the main posts query
 	mock.ExpectQuery("SELECT p.id").
@@ -120,9 +123,9 @@ func TestPostRepository_ReadPostsByUserID_PrivateProfile_Following(t *testing.T)
 
 	// Expect posts query since user is following
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).
-		AddRow(1, "A", "B", "C", "active", "now", "user1", 5, true)
+		AddRow(1, "A", "B", "C", "active", "now", "user1", 5, true, nil)
 
 	mock.ExpectQuery("SELECT p.id").
 		WithArgs(userID, targetUserID).
@@ -148,21 +151,19 @@ func TestPostRepository_CreatePost_OK(t *testing.T) {
 		Caption:  "C",
 		Status:   "active",
 		PostedBy: 1,
+		MediaIDs: []string{"img1", "img2"},
 	}
 
 	insertRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1))
 
-	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO posts (title, body, caption, status, user_id)
-		VALUES ($1,$2,$3,$4,$5)
-		RETURNING id`)).
-		WithArgs(req.Title, req.Body, req.Caption, req.Status, req.PostedBy).
+	mock.ExpectQuery(`INSERT INTO posts .+ INSERT INTO post_media`).
+		WithArgs(req.Title, req.Body, req.Caption, req.Status, req.PostedBy, req.RoutineID, req.SessionID, pq.Array(req.MediaIDs)).
 		WillReturnRows(insertRows)
 
 	selectRows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).AddRow(
-		int64(1), "T", "B", "C", "active", "now", "user1", 0, false,
+		int64(1), "T", "B", "C", "active", "now", "user1", 0, false, nil,
 	)
 
 	mock.ExpectQuery("SELECT p.id").
@@ -194,24 +195,25 @@ func TestPostRepository_CreatePost_Error(t *testing.T) {
 	}
 }
 
+var postFeedCols = []string{"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at", "score"}
+
 func TestPostRepository_ReadPosts_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewPostRepository(db)
 
 	userID := int64(42)
+	created := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
 
-	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
-	}).
-		AddRow(1, "A", "B", "C", "active", "now", "user1", 5, true).
-		AddRow(2, "X", "Y", "Z", "inactive", "now", "user2", 0, false)
+	rows := sqlmock.NewRows(postFeedCols).
+		AddRow(1, "A", "B", "C", "active", created, "user1", 5, true, nil, 0).
+		AddRow(2, "X", "Y", "Z", "inactive", created, "user2", 0, false, nil, 0)
 
-	mock.ExpectQuery("SELECT p.id").
-		WithArgs(userID).
+	mock.ExpectQuery(regexp.QuoteMeta("following_user_id = $1")).
+		WithArgs(userID, 21).
 		WillReturnRows(rows)
 
-	got, err := repo.ReadPosts(userID)
+	got, err := repo.ReadPosts(model.PostFeedFilter{ViewerID: userID, Scope: model.FeedScopeFollowing, Sort: model.FeedSortRecent, Limit: 21})
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -224,6 +226,38 @@ func TestPostRepository_ReadPosts_OK(t *testing.T) {
 	if got[1].IsLiked {
 		t.Fatalf("expected second post to be not liked, got IsLiked=%v", got[1].IsLiked)
 	}
+	if got[0].Date != "2025-03-03T07:00:00Z" || !got[0].CreatedAt.Equal(created) {
+		t.Fatalf("unexpected date %q", got[0].Date)
+	}
+}
+
+func TestPostRepository_ReadPosts_DiscoverRankedAfterCursor(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	asOf := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`u\.is_private = FALSE AND p\.created_at <= \$2::timestamp(.|\n)*WHERE \(score, id\) < \(\$3::float8, \$4\)(.|\n)*ORDER BY score DESC, id DESC LIMIT \$5`).
+		WithArgs(int64(42), asOf, 0.25, int64(7), 11).
+		WillReturnRows(sqlmock.NewRows(postFeedCols).AddRow(6, "A", "B", "C", "active", asOf, "user1", 1, false, nil, 0.2))
+
+	got, err := repo.ReadPosts(model.PostFeedFilter{
+		ViewerID: 42,
+		Scope:    model.FeedScopeDiscover,
+		Sort:     model.FeedSortRanked,
+		AsOf:     asOf,
+		After:    &model.PostFeedCursor{Score: 0.25, ID: 7},
+		Limit:    11,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Score != 0.2 {
+		t.Fatalf("unexpected posts %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
 }
 
 func TestPostRepository_ReadPosts_Error(t *testing.T) {
@@ -233,11 +267,11 @@ func TestPostRepository_ReadPosts_Error(t *testing.T) {
 
 	userID := int64(42)
 
-	mock.ExpectQuery("SELECT p.id").
+	mock.ExpectQuery("SELECT").
 		WithArgs(userID).
 		WillReturnError(errors.New("fail"))
 
-	_, err := repo.ReadPosts(userID)
+	_, err := repo.ReadPosts(model.PostFeedFilter{ViewerID: userID})
 	if err == nil || err.Error() != "fail" {
 		t.Fatalf("expected fail, got %v", err)
 	}
@@ -252,9 +286,9 @@ func TestPostRepository_ReadPost_OK(t *testing.T) {
 	userID := int64(42)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).
-		AddRow(postID, "T", "B", "C", "active", "now", "user", 3, true)
+		AddRow(postID, "T", "B", "C", "active", "now", "user", 3, true, nil)
 
 	mock.ExpectQuery("SELECT p.id").
 		WithArgs(userID, postID).
@@ -295,25 +329,18 @@ func TestPostRepository_UpdatePost_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	req := model.UpdatePostRequest{
-		ID:      1,
-		Title:   "T",
-		Body:    "B",
-		Caption: "C",
-		Status:  "S",
-	}
+	title, status := "T", "S"
+	req := model.UpdatePostRequest{ID: 1, Title: &title, Status: &status}
+	edited := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
 
-	mock.ExpectExec(regexp.QuoteMeta(`
-		UPDATE posts 
-		SET title=$1, body=$2, caption=$3, status=$4 
-		WHERE id=$5`)).
-		WithArgs(req.Title, req.Body, req.Caption, req.Status, req.ID).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO post_revisions (post_id, title, caption, body, status, written_at)")).
+		WithArgs(req.Title, nil, nil, req.Status, req.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	selectRows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).AddRow(
-		int64(1), "T", "B", "C", "S", "now", "user1", 0, false,
+		int64(1), "T", "B", "C", "S", "now", "user1", 0, false, edited,
 	)
 
 	mock.ExpectQuery("SELECT p.id").
@@ -327,6 +354,9 @@ func TestPostRepository_UpdatePost_OK(t *testing.T) {
 	if got.ID != 1 || got.Title != "T" {
 		t.Fatalf("unexpected post: %#v", got)
 	}
+	if !got.Edited || got.EditedAt == nil || !got.EditedAt.Equal(edited) {
+		t.Fatalf("expected the post to be marked edited, got %#v", got)
+	}
 }
 
 func TestPostRepository_UpdatePost_Error(t *testing.T) {
@@ -346,6 +376,95 @@ func TestPostRepository_UpdatePost_Error(t *testing.T) {
 	}
 }
 
+func TestPostRepository_ReadPostRevisions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	written := time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC)
+	replaced := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM post_revisions")).
+		WithArgs(int64(20)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "title", "caption", "body", "status", "written_at", "replaced_at"}).
+			AddRow(int64(2), int64(20), "Second", "", "b", "active", replaced.Add(-time.Hour), replaced).
+			AddRow(int64(1), int64(20), "First", "", "b", "active", written, replaced.Add(-time.Hour)))
+
+	got, err := repo.ReadPostRevisions(20)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0].Title != "Second" || !got[1].WrittenAt.Equal(written) {
+		t.Fatalf("unexpected revisions %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostRepository_ReadPostWorkouts(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN workout_sessions ws ON ws.id = p.session_id")).
+		WithArgs(pq.Array([]int64{1, 2, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "routine_id", "session_id", "name", "duration"}).
+			AddRow(int64(1), int64(9), int64(7), "Leg day", int64(3600)).
+			AddRow(int64(2), int64(9), int64(0), "Legs", int64(0)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, e.id, COALESCE(e.name, ''), 0, 0, er.position")).
+		WithArgs(pq.Array([]int64{1, 2, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "exercise_id", "name", "sets", "volume", "position"}).
+			AddRow(int64(1), int64(5), "Squat", 3, 1500.0, int64(40)).
+			AddRow(int64(1), int64(6), "Lunge", 2, 400.0, int64(43)).
+			AddRow(int64(2), int64(5), "Squat", 0, 0.0, int64(0)))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM personal_records")).
+		WithArgs(pq.Array([]int64{7})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "exercise_id", "record_type", "formula", "value", "weight", "reps", "session_id", "set_id", "achieved_at"}).
+			AddRow(int64(30), int64(4), int64(5), "max_weight", "", 120.0, 120.0, int64(5), int64(7), int64(41), "2025-03-03T08:00:00Z"))
+
+	got, err := repo.ReadPostWorkouts([]int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("post 3 shares nothing, got %d summaries", len(got))
+	}
+	session, routine := got[0], got[1]
+	if session.TotalVolume != 1900 || len(session.Exercises) != 2 || len(session.Records) != 1 || session.DurationSeconds != 3600 {
+		t.Fatalf("unexpected session summary %+v", session)
+	}
+	if routine.RoutineID != 9 || len(routine.Exercises) != 1 || len(routine.Records) != 0 {
+		t.Fatalf("unexpected routine summary %+v", routine)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostRepository_ReadPostMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM post_media pm")).
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "id", "owner_id", "content_type", "size_bytes", "width", "height", "thumbnail_type", "created_at"}).
+			AddRow(int64(1), "img1", int64(4), "image/jpeg", int64(2048), 1200, 800, "image/jpeg", created).
+			AddRow(int64(1), "img2", int64(4), "image/png", int64(512), 64, 64, "", created))
+
+	got, err := repo.ReadPostMedia([]int64{1, 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0].PostID != 1 || got[0].ID != "img1" || got[1].ThumbnailType != "" {
+		t.Fatalf("unexpected media %+v", got)
+	}
+	if got[0].URL != "/media/img1" || got[0].ThumbnailURL != "/media/img1?size=thumbnail" {
+		t.Fatalf("urls = %q, %q", got[0].URL, got[0].ThumbnailURL)
+	}
+}
+
 func TestPostRepository_DeletePost_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -374,68 +493,160 @@ func TestPostRepository_DeletePost_Error(t *testing.T) {
 	}
 }
 
-func TestPostRepository_ReadCommentsByPost_OK(t *testing.T) {
+func TestPostRepository_ReadPostOwnerID_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "body", "created_at", "username"}).
-		AddRow(1, "C", "now", "user")
-	mock.ExpectQuery("SELECT pc.id").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id FROM posts WHERE id = $1")).
 		WithArgs(int64(1)).
-		WillReturnRows(rows)
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(int64(4)))
 
-	got, err := repo.ReadCommentsByPost(1)
-	if err != nil || len(got) != 1 {
-		t.Fatalf("unexpected: %#v err=%v", got, err)
+	got, err := repo.ReadPostOwnerID(1)
+	if err != nil || got != 4 {
+		t.Fatalf("got %d, err=%v; want 4", got, err)
 	}
 }
 
-func TestPostRepository_ReadCommentsByPost_Error(t *testing.T) {
+func TestPostRepository_ReadPostOwnerID_NotFound(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	mock.ExpectQuery("SELECT pc.id").
-		WillReturnError(errors.New("fail"))
+	mock.ExpectQuery("SELECT user_id FROM posts").
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
 
-	_, err := repo.ReadCommentsByPost(1)
-	if err == nil || err.Error() != "fail" {
-		t.Fatalf("expected fail, got %v", err)
+	if _, err := repo.ReadPostOwnerID(1); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
 	}
 }
 
-func TestPostRepository_ReadCommentsByComment_OK(t *testing.T) {
+var commentColumns = []string{"id", "post_id", "parent_id", "level", "username", "body", "created_at", "edited_at", "deleted", "replies"}
+
+func TestPostRepository_ReadComments_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "body", "created_at", "username"}).
-		AddRow(1, "C", "now", "user")
-	mock.ExpectQuery("SELECT pc.id").
-		WithArgs(int64(1)).
+	created := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	edited := created.Add(time.Hour)
+	rows := sqlmock.NewRows(commentColumns).
+		AddRow(int64(1), int64(20), int64(0), 0, "ann", "First", created, edited, false, 1).
+		AddRow(int64(2), int64(20), int64(1), 1, "bob", "gone", created.Add(time.Minute), nil, true, 1)
+	mock.ExpectQuery(regexp.QuoteMeta("WITH RECURSIVE visible AS")).
+		WithArgs(pq.Array([]int64{20, 21}), 21, 2, 3).
 		WillReturnRows(rows)
 
-	got, err := repo.ReadCommentsByComment(1)
-	if err != nil || len(got) != 1 {
+	got, err := repo.ReadComments(model.CommentFilter{PostIDs: []int64{20, 21}, Limit: 21, Depth: 2, Replies: 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d comments, want 2", len(got))
+	}
+	first, deleted := got[0], got[1]
+	if first.Comment != "First" || !first.Edited || first.EditedAt == nil || first.ReplyCount != 1 || first.Date != created.Format(time.RFC3339Nano) {
+		t.Fatalf("unexpected comment %+v", first)
+	}
+	if !deleted.Deleted || deleted.Username != "" || deleted.Comment != "" || deleted.ParentID != 1 || deleted.Level != 1 {
+		t.Fatalf("a deleted comment must be blanked, got %+v", deleted)
+	}
+}
+
+func TestPostRepository_ReadComments_RepliesAfterCursor(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	after := &model.CommentCursor{PostID: 20, ParentID: 5, CreatedAt: time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC), ID: 9}
+	mock.ExpectQuery(regexp.QuoteMeta("v.parent_comment_id = $2 AND (v.created_at, v.id) > ($3::timestamp, $4)")).
+		WithArgs(pq.Array([]int64{20}), int64(5), after.CreatedAt, int64(9), 11, 2, 3).
+		WillReturnRows(sqlmock.NewRows(commentColumns))
+
+	got, err := repo.ReadComments(model.CommentFilter{PostIDs: []int64{20}, ParentID: 5, After: after, Limit: 11, Depth: 2, Replies: 3})
+	if err != nil || len(got) != 0 {
 		t.Fatalf("unexpected: %#v err=%v", got, err)
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
 }
 
-func TestPostRepository_ReadCommentsByComment_Error(t *testing.T) {
+func TestPostRepository_ReadComments_Error(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	mock.ExpectQuery("SELECT pc.id").
+	mock.ExpectQuery("WITH RECURSIVE").
 		WillReturnError(errors.New("fail"))
 
-	_, err := repo.ReadCommentsByComment(1)
+	_, err := repo.ReadComments(model.CommentFilter{PostIDs: []int64{1}})
 	if err == nil || err.Error() != "fail" {
 		t.Fatalf("expected fail, got %v", err)
 	}
 }
 
+func TestPostRepository_ReadCommentRef(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WITH RECURSIVE ancestors AS")).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "author_id", "post_author_id", "deleted", "depth"}).
+			AddRow(int64(9), int64(20), int64(4), int64(3), false, 2))
+
+	got, err := repo.ReadCommentRef(9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := model.CommentRef{ID: 9, PostID: 20, AuthorID: 4, PostAuthorID: 3, Depth: 2}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+}
+
+func TestPostRepository_UpdateComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	created := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE post_comments pc")).
+		WithArgs("Fixed", int64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "parent_id", "username", "body", "created_at", "edited_at", "replies"}).
+			AddRow(int64(9), int64(20), int64(0), "ann", "Fixed", created, created.Add(time.Hour), 0))
+
+	got, err := repo.UpdateComment(model.UpdateCommentRequest{ID: 9, Comment: "Fixed"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Comment != "Fixed" || !got.Edited || !got.EditedAt.Equal(created.Add(time.Hour)) {
+		t.Fatalf("unexpected comment %+v", got)
+	}
+}
+
+func TestPostRepository_DeleteComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE post_comments SET deleted_at = NOW()")).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE post_comments SET deleted_at = NOW()")).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteComment(9); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repo.DeleteComment(9); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("deleting twice: expected sql.ErrNoRows, got %v", err)
+	}
+}
+
 func TestPostRepository_CommentOnPost_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -503,16 +714,16 @@ func TestPostRepository_LikePost_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	req := model.LikePostRequest{UserID: 2, PostID: 1}
+	req := model.LikePostRequest{UserID: 2, PostID: 1, Reaction: model.ReactionFire}
 
-	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO post_likes(user_id, post_id,created_at) VALUES ($1,$2,now())`)).
-		WithArgs(req.UserID, req.PostID).
+	mock.ExpectExec(`INSERT INTO post_likes \(user_id, post_id, reaction, created_at\) .+ ON CONFLICT \(user_id, post_id\) DO UPDATE SET reaction = EXCLUDED.reaction`).
+		WithArgs(req.UserID, req.PostID, req.Reaction).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).
-		AddRow(1, "T", "B", "C", "active", "now", "user", 1, true)
+		AddRow(1, "T", "B", "C", "active", "now", "user", 1, true, nil)
 
 	mock.ExpectQuery("SELECT p.id").
 		WithArgs(req.UserID, req.PostID).
@@ -535,10 +746,10 @@ func TestPostRepository_LikePost_ErrorOnInsert(t *testing.T) {
 	defer db.Close()
 	repo := NewPostRepository(db)
 
-	req := model.LikePostRequest{UserID: 2, PostID: 1}
+	req := model.LikePostRequest{UserID: 2, PostID: 1, Reaction: model.ReactionLike}
 
 	mock.ExpectExec("INSERT INTO post_likes").
-		WithArgs(req.UserID, req.PostID).
+		WithArgs(req.UserID, req.PostID, req.Reaction).
 		WillReturnError(errors.New("like fail"))
 
 	_, err := repo.LikePost(req)
@@ -559,9 +770,9 @@ func TestPostRepository_UnlikePost_OK(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked",
+		"id", "title", "body", "caption", "status", "created_at", "username", "likes", "is_liked", "edited_at",
 	}).
-		AddRow(1, "T", "B", "C", "active", "now", "user", 0, false)
+		AddRow(1, "T", "B", "C", "active", "now", "user", 0, false, nil)
 
 	mock.ExpectQuery("SELECT p.id").
 		WithArgs(req.UserID, req.PostID).
@@ -595,3 +806,53 @@ func TestPostRepository_UnlikePost_ErrorOnDelete(t *testing.T) {
 		t.Fatalf("expected unlike fail, got %v", err)
 	}
 }
+
+func TestPostRepository_ReadPostReactions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	mock.ExpectQuery(`SELECT post_id, reaction, COUNT\(\*\), BOOL_OR\(user_id = \$2\) FROM post_likes WHERE post_id = ANY\(\$1\) GROUP BY post_id, reaction`).
+		WithArgs(pq.Array([]int64{1, 2}), int64(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "reaction", "count", "mine"}).
+			AddRow(1, "like", 3, false).
+			AddRow(1, "fire", 1, true))
+
+	got, err := repo.ReadPostReactions([]int64{1, 2}, 9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[1].Reaction != "fire" || got[1].Count != 1 || !got[1].Mine {
+		t.Fatalf("unexpected counts: %+v", got)
+	}
+}
+
+func TestPostRepository_ReadReactions_Page(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewPostRepository(db)
+
+	after := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM post_likes pl JOIN users u ON u.id = pl.user_id `+
+		`WHERE pl.post_id = \$1 AND pl.reaction = \$2 AND \(pl.created_at, pl.user_id\) < \(\$3::timestamp, \$4\) `+
+		`ORDER BY pl.created_at DESC, pl.user_id DESC LIMIT \$5`).
+		WithArgs(int64(1), "clap", after, int64(7), 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "avatar_data", "avatar_media_id", "reaction", "created_at"}).
+			AddRow(5, "sam", "Sam", nil, "abc", "clap", after.Add(-time.Minute)))
+
+	got, err := repo.ReadReactions(model.ReactionFilter{
+		PostID: 1,
+		Type:   "clap",
+		After:  &model.ReactionCursor{PostID: 1, Type: "clap", CreatedAt: after, UserID: 7},
+		Limit:  11,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Username != "sam" || got[0].Avatar != "/media/abc" {
+		t.Fatalf("unexpected reactions: %+v", got[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
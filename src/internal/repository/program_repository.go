@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type programRepository struct {
+	db *sql.DB
+}
+
+func NewProgramRepository(db *sql.DB) repository.ProgramRepository {
+	return &programRepository{db: db}
+}
+
+// CreateProgram stores the program with its weeks and days in one transaction.
+// Weeks are numbered by their position in the request.
+func (p *programRepository) CreateProgram(request model.CreateProgramRequest) (*model.Program, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	program := model.Program{
+		UserID:      request.UserID,
+		Name:        request.Name,
+		Description: request.Description,
+		Visibility:  request.Visibility,
+		Weeks:       make([]*model.ProgramWeek, 0, len(request.Weeks)),
+	}
+	err = tx.QueryRow(`
+		INSERT INTO programs (user_id, name, description, visibility)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		request.UserID, request.Name, request.Description, request.Visibility,
+	).Scan(&program.ID, &program.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, week := range request.Weeks {
+		number := i + 1
+		if _, err := tx.Exec("INSERT INTO program_weeks (program_id, week, name) VALUES ($1, $2, $3)", program.ID, number, week.Name); err != nil {
+			return nil, err
+		}
+		days := make([]*model.ProgramDay, 0, len(week.Days))
+		for _, day := range week.Days {
+			if _, err := tx.Exec(`
+				INSERT INTO program_days (program_id, week, day_of_week, routine_id, load_type, load)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+				program.ID, number, day.DayOfWeek, day.RoutineID, day.LoadType, day.Load,
+			); err != nil {
+				return nil, err
+			}
+			days = append(days, day)
+		}
+		program.Weeks = append(program.Weeks, &model.ProgramWeek{Number: number, Name: week.Name, Days: days})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+// ReadProgramByID reads the program with its weeks in order, and each week's
+// days from Sunday
+func (p *programRepository) ReadProgramByID(id int64) (*model.Program, error) {
+	var program model.Program
+	err := p.db.QueryRow(
+		"SELECT id, user_id, name, description, visibility, created_at FROM programs WHERE id = $1", id,
+	).Scan(&program.ID, &program.UserID, &program.Name, &program.Description, &program.Visibility, &program.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("program not found: %w", sql.ErrNoRows)
+		}
+		return nil, err
+	}
+
+	weekRows, err := p.db.Query("SELECT week, name FROM program_weeks WHERE program_id = $1 ORDER BY week", id)
+	if err != nil {
+		return nil, err
+	}
+	defer weekRows.Close()
+
+	program.Weeks = []*model.ProgramWeek{}
+	byNumber := map[int]*model.ProgramWeek{}
+	for weekRows.Next() {
+		week := &model.ProgramWeek{Days: []*model.ProgramDay{}}
+		if err := weekRows.Scan(&week.Number, &week.Name); err != nil {
+			return nil, err
+		}
+		byNumber[week.Number] = week
+		program.Weeks = append(program.Weeks, week)
+	}
+	if err := weekRows.Err(); err != nil {
+		return nil, err
+	}
+
+	dayRows, err := p.db.Query(`
+		SELECT week, day_of_week, routine_id, load_type, load
+		FROM program_days
+		WHERE program_id = $1
+		ORDER BY week, day_of_week`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer dayRows.Close()
+
+	for dayRows.Next() {
+		var number int
+		var day model.ProgramDay
+		if err := dayRows.Scan(&number, &day.DayOfWeek, &day.RoutineID, &day.LoadType, &day.Load); err != nil {
+			return nil, err
+		}
+		if week := byNumber[number]; week != nil {
+			week.Days = append(week.Days, &day)
+		}
+	}
+	return &program, dayRows.Err()
+}
+
+// DeleteProgram cancels the program's active enrollments and keeps them, with
+// their program cleared by the foreign key
+func (p *programRepository) DeleteProgram(id int64) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(
+		"UPDATE program_enrollments SET status = 'cancelled' WHERE program_id = $1 AND status = 'active'", id,
+	); err != nil {
+		return err
+	}
+	result, err := tx.Exec("DELETE FROM programs WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("program not found: %w", sql.ErrNoRows)
+	}
+	return tx.Commit()
+}
+
+// CreateEnrollment stores the enrollment with the enrollee's copies of the
+// program's routines
+func (p *programRepository) CreateEnrollment(enrollment *model.ProgramEnrollment) (*model.ProgramEnrollment, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	created := *enrollment
+	err = tx.QueryRow(`
+		INSERT INTO program_enrollments (user_id, program_id, started_on, current_week, time_slot, routine_length_minutes, timezone, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		enrollment.UserID, enrollment.ProgramID, enrollment.StartedOn, enrollment.CurrentWeek,
+		enrollment.TimeSlot, enrollment.RoutineLengthMinutes, enrollment.Timezone, enrollment.Status,
+	).Scan(&created.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, programRoutineID := range slices.Sorted(maps.Keys(enrollment.Routines)) {
+		if _, err := tx.Exec(
+			"INSERT INTO program_enrollment_routines (enrollment_id, program_routine_id, routine_id) VALUES ($1, $2, $3)",
+			created.ID, programRoutineID, enrollment.Routines[programRoutineID],
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// enrollmentColumns are read by scanEnrollment, with programs joined as p.
+// The program is left joined since enrollments outlive a deleted program.
+const enrollmentColumns = `e.id, e.user_id, e.program_id, COALESCE(p.name, ''), e.status, e.started_on, e.current_week,
+	(SELECT COUNT(*) FROM program_weeks w WHERE w.program_id = e.program_id),
+	e.time_slot, e.routine_length_minutes, e.timezone`
+
+func scanEnrollment(row Scanner) (*model.ProgramEnrollment, error) {
+	var enrollment model.ProgramEnrollment
+	var programID sql.NullInt64
+	var startedOn time.Time
+	if err := row.Scan(&enrollment.ID, &enrollment.UserID, &programID, &enrollment.ProgramName,
+		&enrollment.Status, &startedOn, &enrollment.CurrentWeek, &enrollment.TotalWeeks,
+		&enrollment.TimeSlot, &enrollment.RoutineLengthMinutes, &enrollment.Timezone); err != nil {
+		return nil, err
+	}
+	enrollment.ProgramID = programID.Int64
+	enrollment.StartedOn = startedOn.Format(time.DateOnly)
+	return &enrollment, nil
+}
+
+func (p *programRepository) ReadEnrollmentByID(id int64) (*model.ProgramEnrollment, error) {
+	enrollment, err := scanEnrollment(p.db.QueryRow(
+		"SELECT "+enrollmentColumns+" FROM program_enrollments e LEFT JOIN programs p ON p.id = e.program_id WHERE e.id = $1", id,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("enrollment not found: %w", sql.ErrNoRows)
+		}
+		return nil, err
+	}
+	if err := p.attachEnrollmentRoutines([]*model.ProgramEnrollment{enrollment}); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+// ReadUserEnrollments lists the user's enrollments, newest first
+func (p *programRepository) ReadUserEnrollments(userID int64) ([]*model.ProgramEnrollment, error) {
+	rows, err := p.db.Query(
+		"SELECT "+enrollmentColumns+" FROM program_enrollments e LEFT JOIN programs p ON p.id = e.program_id WHERE e.user_id = $1 ORDER BY e.id DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enrollments := []*model.ProgramEnrollment{}
+	for rows.Next() {
+		enrollment, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return enrollments, p.attachEnrollmentRoutines(enrollments)
+}
+
+// ReadActiveEnrollments lists every user's active enrollments whose first
+// week has been scheduled; enrolling schedules it
+func (p *programRepository) ReadActiveEnrollments() ([]*model.ProgramEnrollment, error) {
+	rows, err := p.db.Query(
+		"SELECT " + enrollmentColumns + " FROM program_enrollments e LEFT JOIN programs p ON p.id = e.program_id WHERE e.status = 'active' AND e.current_week >= 1 ORDER BY e.id",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enrollments := []*model.ProgramEnrollment{}
+	for rows.Next() {
+		enrollment, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return enrollments, p.attachEnrollmentRoutines(enrollments)
+}
+
+func (p *programRepository) attachEnrollmentRoutines(enrollments []*model.ProgramEnrollment) error {
+	for _, enrollment := range enrollments {
+		rows, err := p.db.Query(
+			"SELECT program_routine_id, routine_id FROM program_enrollment_routines WHERE enrollment_id = $1", enrollment.ID,
+		)
+		if err != nil {
+			return err
+		}
+		enrollment.Routines = map[int64]int64{}
+		for rows.Next() {
+			var programRoutineID, routineID int64
+			if err := rows.Scan(&programRoutineID, &routineID); err != nil {
+				rows.Close()
+				return err
+			}
+			enrollment.Routines[programRoutineID] = routineID
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimEnrollmentWeek moves an active enrollment on to the week after its
+// current one, and reports false when it has already moved or ended
+func (p *programRepository) ClaimEnrollmentWeek(id int64, week int) (bool, error) {
+	result, err := p.db.Exec(
+		"UPDATE program_enrollments SET current_week = $2 WHERE id = $1 AND current_week = $2 - 1 AND status = 'active'", id, week,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// UpdateEnrollmentProgress leaves enrollments that have already ended
+func (p *programRepository) UpdateEnrollmentProgress(id int64, currentWeek int, status string) error {
+	_, err := p.db.Exec(
+		"UPDATE program_enrollments SET current_week = $2, status = $3 WHERE id = $1 AND status = 'active'", id, currentWeek, status,
+	)
+	return err
+}
+
+func (p *programRepository) DeleteEnrollment(id int64) error {
+	_, err := p.db.Exec("DELETE FROM program_enrollments WHERE id = $1", id)
+	return err
+}
+
+func (p *programRepository) CreateEnrollmentSchedules(schedules []*model.EnrollmentSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, schedule := range schedules {
+		if _, err := tx.Exec(
+			"INSERT INTO program_enrollment_schedules (schedule_id, enrollment_id, week, day_of_week) VALUES ($1, $2, $3, $4)",
+			schedule.ScheduleID, schedule.EnrollmentID, schedule.Week, schedule.DayOfWeek,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (p *programRepository) ReadEnrollmentSchedules(enrollmentID int64, week int) ([]*model.EnrollmentSchedule, error) {
+	rows, err := p.db.Query(`
+		SELECT schedule_id, enrollment_id, week, day_of_week
+		FROM program_enrollment_schedules
+		WHERE enrollment_id = $1 AND week = $2
+		ORDER BY day_of_week`, enrollmentID, week)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []*model.EnrollmentSchedule{}
+	for rows.Next() {
+		var schedule model.EnrollmentSchedule
+		if err := rows.Scan(&schedule.ScheduleID, &schedule.EnrollmentID, &schedule.Week, &schedule.DayOfWeek); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, &schedule)
+	}
+	return schedules, rows.Err()
+}
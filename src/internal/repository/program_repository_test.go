@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestProgramRepository_CreateProgram(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO programs (user_id, name, description, visibility)")).
+		WithArgs(int64(1), "5/3/1", "", model.RoutineVisibilityPublic).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_weeks (program_id, week, name)")).
+		WithArgs(int64(7), 1, "5s").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_days (program_id, week, day_of_week, routine_id, load_type, load)")).
+		WithArgs(int64(7), 1, int64(1), int64(10), model.LoadTypePercent, 65.0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_weeks (program_id, week, name)")).
+		WithArgs(int64(7), 2, "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateProgram(model.CreateProgramRequest{
+		UserID:     1,
+		Name:       "5/3/1",
+		Visibility: model.RoutineVisibilityPublic,
+		Weeks: []*model.ProgramWeek{
+			{Number: 5, Name: "5s", Days: []*model.ProgramDay{{DayOfWeek: 1, RoutineID: 10, LoadType: model.LoadTypePercent, Load: 65}}},
+			{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 7 || !got.CreatedAt.Equal(createdAt) || len(got.Weeks) != 2 || got.Weeks[0].Number != 1 || got.Weeks[1].Number != 2 || len(got.Weeks[0].Days) != 1 {
+		t.Fatalf("unexpected program: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_CreateProgram_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO programs")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_weeks")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_days")).WillReturnError(errors.New("fk violation"))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateProgram(model.CreateProgramRequest{
+		UserID: 1,
+		Name:   "x",
+		Weeks:  []*model.ProgramWeek{{Days: []*model.ProgramDay{{DayOfWeek: 1, RoutineID: 10, LoadType: model.LoadTypeRPE, Load: 8}}}},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_ReadProgramByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, name, description, visibility, created_at FROM programs WHERE id = $1")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "description", "visibility", "created_at"}).
+			AddRow(7, 1, "5/3/1", "", "public", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT week, name FROM program_weeks WHERE program_id = $1 ORDER BY week")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"week", "name"}).AddRow(1, "5s").AddRow(2, "deload"))
+	mock.ExpectQuery(`SELECT week, day_of_week, routine_id, load_type, load\s+FROM program_days`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"week", "day_of_week", "routine_id", "load_type", "load"}).
+			AddRow(1, 1, 10, "percent", 65.0).
+			AddRow(1, 4, 11, "rpe", 8.0))
+
+	got, err := repo.ReadProgramByID(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Weeks) != 2 || len(got.Weeks[0].Days) != 2 || len(got.Weeks[1].Days) != 0 || got.Weeks[0].Days[1].LoadType != model.LoadTypeRPE {
+		t.Fatalf("unexpected program: %#v", got)
+	}
+}
+
+func TestProgramRepository_ReadProgramByID_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM programs WHERE id = $1")).WithArgs(int64(7)).WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.ReadProgramByID(7); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("want sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestProgramRepository_CreateEnrollment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO program_enrollments")).
+		WithArgs(int64(2), int64(7), "2025-03-03", 0, "07:00", int64(60), "UTC", model.EnrollmentActive).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(90))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_enrollment_routines")).
+		WithArgs(int64(90), int64(10), int64(100)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_enrollment_routines")).
+		WithArgs(int64(90), int64(11), int64(101)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateEnrollment(&model.ProgramEnrollment{
+		UserID:               2,
+		ProgramID:            7,
+		Status:               model.EnrollmentActive,
+		StartedOn:            "2025-03-03",
+		TimeSlot:             "07:00",
+		RoutineLengthMinutes: 60,
+		Timezone:             "UTC",
+		Routines:             map[int64]int64{11: 101, 10: 100},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 90 || got.Routines[10] != 100 {
+		t.Fatalf("unexpected enrollment: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_ReadEnrollmentByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectQuery(`SELECT e\.id, .+ FROM program_enrollments e LEFT JOIN programs p ON p\.id = e\.program_id WHERE e\.id = \$1`).
+		WithArgs(int64(90)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "program_id", "name", "status", "started_on", "current_week", "weeks", "time_slot", "routine_length_minutes", "timezone"}).
+			AddRow(90, 2, 7, "5/3/1", "active", time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), 1, 4, "07:00:00", 60, "Europe/Berlin"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT program_routine_id, routine_id FROM program_enrollment_routines WHERE enrollment_id = $1")).
+		WithArgs(int64(90)).
+		WillReturnRows(sqlmock.NewRows([]string{"program_routine_id", "routine_id"}).AddRow(10, 100))
+
+	got, err := repo.ReadEnrollmentByID(90)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.StartedOn != "2025-03-03" || got.TotalWeeks != 4 || got.ProgramName != "5/3/1" || got.Routines[10] != 100 {
+		t.Fatalf("unexpected enrollment: %#v", got)
+	}
+}
+
+func TestProgramRepository_ReadEnrollmentByID_DeletedProgram(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectQuery(`SELECT e\.id, e\.user_id, e\.program_id, COALESCE\(p\.name, ''\), .+ FROM program_enrollments e LEFT JOIN programs p`).
+		WithArgs(int64(90)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "program_id", "name", "status", "started_on", "current_week", "weeks", "time_slot", "routine_length_minutes", "timezone"}).
+			AddRow(90, 2, nil, "", "cancelled", time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), 2, 0, "07:00:00", 60, "UTC"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT program_routine_id, routine_id FROM program_enrollment_routines WHERE enrollment_id = $1")).
+		WithArgs(int64(90)).
+		WillReturnRows(sqlmock.NewRows([]string{"program_routine_id", "routine_id"}))
+
+	got, err := repo.ReadEnrollmentByID(90)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ProgramID != 0 || got.Status != model.EnrollmentCancelled || got.CurrentWeek != 2 {
+		t.Fatalf("unexpected enrollment: %#v", got)
+	}
+}
+
+func TestProgramRepository_DeleteProgram_CancelsEnrollments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE program_enrollments SET status = 'cancelled' WHERE program_id = $1 AND status = 'active'")).
+		WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM programs WHERE id = $1")).
+		WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.DeleteProgram(7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_DeleteProgram_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE program_enrollments").WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM programs").WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := repo.DeleteProgram(7); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_ReadActiveEnrollments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectQuery(`SELECT e\.id, .+ FROM program_enrollments e LEFT JOIN programs p ON p\.id = e\.program_id WHERE e\.status = 'active' AND e\.current_week >= 1 ORDER BY e\.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "program_id", "name", "status", "started_on", "current_week", "weeks", "time_slot", "routine_length_minutes", "timezone"}).
+			AddRow(90, 2, 7, "5/3/1", "active", time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), 1, 4, "07:00:00", 60, "UTC"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT program_routine_id, routine_id FROM program_enrollment_routines WHERE enrollment_id = $1")).
+		WithArgs(int64(90)).
+		WillReturnRows(sqlmock.NewRows([]string{"program_routine_id", "routine_id"}).AddRow(10, 100))
+
+	got, err := repo.ReadActiveEnrollments()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 90 || got[0].Routines[10] != 100 {
+		t.Fatalf("unexpected enrollments: %#v", got)
+	}
+}
+
+func TestProgramRepository_ClaimEnrollmentWeek(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	claim := regexp.QuoteMeta("UPDATE program_enrollments SET current_week = $2 WHERE id = $1 AND current_week = $2 - 1 AND status = 'active'")
+	mock.ExpectExec(claim).WithArgs(int64(90), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	// a second claim of the same week finds it already taken
+	mock.ExpectExec(claim).WithArgs(int64(90), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if claimed, err := repo.ClaimEnrollmentWeek(90, 2); err != nil || !claimed {
+		t.Fatalf("want the week claimed, got %v, %v", claimed, err)
+	}
+	if claimed, err := repo.ClaimEnrollmentWeek(90, 2); err != nil || claimed {
+		t.Fatalf("want the week already taken, got %v, %v", claimed, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestProgramRepository_EnrollmentSchedules(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewProgramRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO program_enrollment_schedules (schedule_id, enrollment_id, week, day_of_week)")).
+		WithArgs(int64(30), int64(90), 2, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT schedule_id, enrollment_id, week, day_of_week\s+FROM program_enrollment_schedules`).
+		WithArgs(int64(90), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"schedule_id", "enrollment_id", "week", "day_of_week"}).AddRow(30, 90, 2, 1))
+
+	if err := repo.CreateEnrollmentSchedules([]*model.EnrollmentSchedule{{EnrollmentID: 90, ScheduleID: 30, Week: 2, DayOfWeek: 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := repo.ReadEnrollmentSchedules(90, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ScheduleID != 30 {
+		t.Fatalf("unexpected schedules: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
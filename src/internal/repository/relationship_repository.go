@@ -158,7 +158,7 @@ func (r *relationshipRepository) GetFollowRequestByID(requestID int64) (*model.F
 func (r *relationshipRepository) GetPendingFollowRequests(userID int64) ([]*model.FollowRequestWithUser, error) {
 	rows, err := r.db.Query(`
 		SELECT fr.id, fr.requester_id, fr.requested_id, fr.status, fr.created_at,
-		       u.id, u.username, u.name, u.email, u.avatar_data
+		       u.id, u.username, u.name, u.email, u.avatar_data, u.avatar_media_id
 		FROM follow_requests fr
 		JOIN users u ON fr.requester_id = u.id
 		WHERE fr.requested_id = $1 AND fr.status = 'pending'
@@ -174,13 +174,16 @@ func (r *relationshipRepository) GetPendingFollowRequests(userID int64) ([]*mode
 	for rows.Next() {
 		var req model.FollowRequestWithUser
 		var user model.User
+		var avatarData ByteaData
+		var avatarMediaID sql.NullString
 		err := rows.Scan(
 			&req.ID, &req.RequesterID, &req.RequestedID, &req.Status, &req.CreatedAt,
-			&user.ID, &user.Username, &user.Name, &user.Email, &user.Avatar,
+			&user.ID, &user.Username, &user.Name, &user.Email, &avatarData, &avatarMediaID,
 		)
 		if err != nil {
 			return nil, err
 		}
+		user.Avatar = avatarURL(avatarMediaID, avatarData)
 		req.User = &user
 		requests = append(requests, &req)
 	}
@@ -3,8 +3,12 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type routineRepository struct {
@@ -15,6 +19,31 @@ func NewRoutineRepository(db *sql.DB) repository.RoutineRepository {
 	return &routineRepository{db: db}
 }
 
+// routineColumns are read by scanRoutine; src is the user a fork came from
+const routineColumns = `wr.id, wr.name, wr.description, wr.user_id, wr.visibility, wr.fork_count,
+	COALESCE(wr.forked_from_routine_id, 0), COALESCE(wr.forked_from_user_id, 0), COALESCE(src.username, '')`
+
+const routineTables = `workout_routine wr LEFT JOIN users src ON src.id = wr.forked_from_user_id`
+
+func scanRoutine(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*model.ExerciseRoutine, error) {
+	var routine model.ExerciseRoutine
+	var source model.RoutineSource
+	dest := []interface{}{&routine.ID, &routine.Name, &routine.Description, &routine.UserID, &routine.Visibility, &routine.ForkCount,
+		&source.RoutineID, &source.UserID, &source.Username}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if source.RoutineID != 0 || source.UserID != 0 {
+		routine.ForkedFrom = &source
+	}
+	routine.IsActive = true
+	return &routine, nil
+}
+
+// CreateRoutine stores request.Exercises in order; the service fills it in
+// from ExerciseIDs when only those were sent. A fork also gets the source
+// owner's settings for the exercises it keeps, and bumps the source's fork
+// count, in the same transaction.
 func (r *routineRepository) CreateRoutine(userID int64, request model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -25,30 +54,43 @@ func (r *routineRepository) CreateRoutine(userID int64, request model.CreateRout
 		_ = tx.Rollback()
 	}()
 
+	var sourceRoutineID, sourceUserID interface{}
+	if request.Source != nil {
+		sourceRoutineID, sourceUserID = request.Source.RoutineID, request.Source.UserID
+	}
+
 	var routine model.ExerciseRoutine
-	// Keep this minimal & consistent with model fields we know exist.
-	// We’ll store frequency implicitly (if you add it to your model later, extend RETURNING).
 	err = tx.QueryRow(`
-		INSERT INTO workout_routine (name, user_id)
-		VALUES ($1, $2)
-		RETURNING id, name, user_id`,
-		request.Name, userID,
-	).Scan(&routine.ID, &routine.Name, &routine.UserID)
+		INSERT INTO workout_routine (name, description, user_id, visibility, forked_from_routine_id, forked_from_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, name, description, user_id, visibility`,
+		request.Name, request.Description, userID, request.Visibility, sourceRoutineID, sourceUserID,
+	).Scan(&routine.ID, &routine.Name, &routine.Description, &routine.UserID, &routine.Visibility)
 	if err != nil {
 		return nil, err
 	}
 
-	// Insert exercises into exercises_in_routine
-	for _, exerciseID := range request.ExerciseIDs {
-		if _, err := tx.Exec(
-			"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id) VALUES ($1, $2)",
-			routine.ID, exerciseID,
+	if err := insertRoutineExercises(tx, routine.ID, request.Exercises); err != nil {
+		return nil, err
+	}
+	setRoutineEntries(&routine, request.Exercises)
+	routine.IsActive = true
+
+	if request.Source != nil {
+		if _, err := tx.Exec(`
+			INSERT INTO user_exercise_settings (user_id, exercise_id, workout_routine_id, weight, reps, sets, break_interval)
+			SELECT $1, exercise_id, $2, weight, reps, sets, break_interval
+			FROM user_exercise_settings
+			WHERE user_id = $3 AND workout_routine_id = $4 AND exercise_id = ANY($5)`,
+			userID, routine.ID, request.Source.UserID, request.Source.RoutineID, pq.Array(routine.ExerciseIDs),
 		); err != nil {
 			return nil, err
 		}
+		if _, err := tx.Exec("UPDATE workout_routine SET fork_count = fork_count + 1 WHERE id = $1", request.Source.RoutineID); err != nil {
+			return nil, err
+		}
+		routine.ForkedFrom = request.Source
 	}
-	routine.ExerciseIDs = append(routine.ExerciseIDs, request.ExerciseIDs...)
-	routine.IsActive = true
 
 	if err := tx.Commit(); err != nil {
 		return nil, err
@@ -58,7 +100,7 @@ func (r *routineRepository) CreateRoutine(userID int64, request model.CreateRout
 
 func (r *routineRepository) ReadUserRoutines(userID int64) ([]*model.ExerciseRoutine, error) {
 	rows, err := r.db.Query(
-		"SELECT id, name, user_id FROM workout_routine WHERE user_id = $1",
+		"SELECT "+routineColumns+" FROM "+routineTables+" WHERE wr.user_id = $1 ORDER BY wr.id",
 		userID,
 	)
 	if err != nil {
@@ -68,42 +110,70 @@ func (r *routineRepository) ReadUserRoutines(userID int64) ([]*model.ExerciseRou
 
 	var routines []*model.ExerciseRoutine
 	for rows.Next() {
-		var routine model.ExerciseRoutine
-		if err := rows.Scan(&routine.ID, &routine.Name, &routine.UserID); err != nil {
-			return nil, err
-		}
-
-		exRows, err := r.db.Query(
-			"SELECT exercise_id FROM exercises_in_routine WHERE workout_routine_id = $1",
-			routine.ID,
-		)
+		routine, err := scanRoutine(rows)
 		if err != nil {
 			return nil, err
 		}
+		routines = append(routines, routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return routines, r.attachRoutineExercises(routines)
+}
+
+// ReadPublicRoutines lists public routines of public profiles, newest or most
+// forked first, with their owner's username
+func (r *routineRepository) ReadPublicRoutines(filter model.PublicRoutineFilter) ([]*model.ExerciseRoutine, error) {
+	args := make([]interface{}, 0, 4)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-		var eids []int64
-		for exRows.Next() {
-			var eid int64
-			if err := exRows.Scan(&eid); err != nil {
-				exRows.Close()
-				return nil, err
-			}
-			eids = append(eids, eid)
+	conditions := []string{"wr.visibility = '" + model.RoutineVisibilityPublic + "'", "NOT owner.is_private"}
+	if filter.Search != "" {
+		pattern := arg("%" + escapeLike(filter.Search) + "%")
+		conditions = append(conditions, "(wr.name ILIKE "+pattern+" OR wr.description ILIKE "+pattern+")")
+	}
+	order := "wr.id DESC"
+	if filter.Sort == model.RoutineSortPopular {
+		order = "wr.fork_count DESC, wr.id DESC"
+	}
+	if filter.After != nil {
+		if filter.Sort == model.RoutineSortPopular {
+			conditions = append(conditions, "(wr.fork_count, wr.id) < ("+arg(filter.After.ForkCount)+", "+arg(filter.After.ID)+")")
+		} else {
+			conditions = append(conditions, "wr.id < "+arg(filter.After.ID))
 		}
-		if err := exRows.Err(); err != nil {
-			exRows.Close()
+	}
+
+	q := "SELECT " + routineColumns + ", owner.username FROM " + routineTables + " JOIN users owner ON owner.id = wr.user_id WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY " + order
+	if filter.Limit > 0 {
+		q += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := r.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	routines := make([]*model.ExerciseRoutine, 0)
+	for rows.Next() {
+		var username string
+		routine, err := scanRoutine(rows, &username)
+		if err != nil {
 			return nil, err
 		}
-		exRows.Close()
-
-		routine.ExerciseIDs = eids
-		routine.IsActive = true
-		routines = append(routines, &routine)
+		routine.Username = username
+		routines = append(routines, routine)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return routines, nil
+	return routines, r.attachRoutineExercises(routines)
 }
 
 func (r *routineRepository) DeleteRoutine(routineID int64) error {
@@ -122,48 +192,69 @@ func (r *routineRepository) DeleteRoutine(routineID int64) error {
 }
 
 func (r *routineRepository) ReadRoutineWithExercises(routineID int64) (*model.ExerciseRoutine, error) {
-	var routine model.ExerciseRoutine
-	err := r.db.QueryRow(
-		"SELECT id, name, user_id FROM workout_routine WHERE id = $1",
+	routine, err := scanRoutine(r.db.QueryRow(
+		"SELECT "+routineColumns+" FROM "+routineTables+" WHERE wr.id = $1",
 		routineID,
-	).Scan(&routine.ID, &routine.Name, &routine.UserID)
+	))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("routine not found")
 		}
 		return nil, err
 	}
+	if err := r.attachRoutineExercises([]*model.ExerciseRoutine{routine}); err != nil {
+		return nil, err
+	}
+	return routine, nil
+}
 
-	exRows, err := r.db.Query(
-		"SELECT exercise_id FROM exercises_in_routine WHERE workout_routine_id = $1",
-		routine.ID,
-	)
+// UpdateRoutine applies the name, description, visibility and exercise list in one
+// transaction, so readers never see a routine half updated
+func (r *routineRepository) UpdateRoutine(request model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.Exec(`
+		UPDATE workout_routine
+		SET name = COALESCE($2, name), description = COALESCE($3, description), visibility = COALESCE($4, visibility)
+		WHERE id = $1`,
+		request.ID, request.Name, request.Description, request.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return nil, err
 	}
-	var eids []int64
-	for exRows.Next() {
-		var eid int64
-		if err := exRows.Scan(&eid); err != nil {
-			exRows.Close()
+	if affected == 0 {
+		return nil, errors.New("routine not found")
+	}
+
+	if request.Exercises != nil {
+		if _, err := tx.Exec("DELETE FROM exercises_in_routine WHERE workout_routine_id = $1", request.ID); err != nil {
+			return nil, err
+		}
+		if err := insertRoutineExercises(tx, request.ID, *request.Exercises); err != nil {
 			return nil, err
 		}
-		eids = append(eids, eid)
 	}
-	if err := exRows.Err(); err != nil {
-		exRows.Close()
+
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
-	exRows.Close()
-
-	routine.ExerciseIDs = eids
-	routine.IsActive = true
-	return &routine, nil
+	return r.ReadRoutineWithExercises(request.ID)
 }
 
+// AddExerciseToRoutine puts the exercise last, without targets
 func (r *routineRepository) AddExerciseToRoutine(routineID, exerciseID int64) error {
-	_, err := r.db.Exec(
-		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id) VALUES ($1, $2)",
+	_, err := r.db.Exec(`
+		INSERT INTO exercises_in_routine (workout_routine_id, exercise_id, position)
+		SELECT $1, $2, COALESCE(MAX(position) + 1, 0) FROM exercises_in_routine WHERE workout_routine_id = $1`,
 		routineID, exerciseID,
 	)
 	return err
@@ -186,3 +277,99 @@ func (r *routineRepository) RemoveExerciseFromRoutine(routineID, exerciseID int6
 	}
 	return nil
 }
+
+// ReorderRoutineExercises moves each listed exercise to its index in the list,
+// keeping its targets, in a single statement
+func (r *routineRepository) ReorderRoutineExercises(routineID int64, exerciseIDs []int64) error {
+	_, err := r.db.Exec(`
+		UPDATE exercises_in_routine e SET position = o.position - 1
+		FROM unnest($2::int[]) WITH ORDINALITY AS o(exercise_id, position)
+		WHERE e.workout_routine_id = $1 AND e.exercise_id = o.exercise_id`,
+		routineID, pq.Array(exerciseIDs),
+	)
+	return err
+}
+
+func insertRoutineExercises(tx *sql.Tx, routineID int64, entries []model.RoutineExercise) error {
+	for i, entry := range entries {
+		if _, err := tx.Exec(`
+			INSERT INTO exercises_in_routine (workout_routine_id, exercise_id, position, target_sets, target_reps_min, target_reps_max, rest_seconds, superset_group)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			routineID, entry.ExerciseID, i, entry.TargetSets, entry.RepsMin, entry.RepsMax, entry.RestSeconds, entry.SupersetGroup,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachRoutineExercises fills in the exercises of every routine in one query
+func (r *routineRepository) attachRoutineExercises(routines []*model.ExerciseRoutine) error {
+	if len(routines) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(routines))
+	for i, routine := range routines {
+		ids[i] = routine.ID
+	}
+	entries, err := r.readRoutineExercises(ids)
+	if err != nil {
+		return err
+	}
+	for _, routine := range routines {
+		setRoutineEntries(routine, entries[routine.ID])
+	}
+	return nil
+}
+
+// readRoutineExercises loads the exercises of every listed routine in order
+func (r *routineRepository) readRoutineExercises(routineIDs []int64) (map[int64][]model.RoutineExercise, error) {
+	rows, err := r.db.Query(`
+		SELECT workout_routine_id, exercise_id, target_sets, target_reps_min, target_reps_max, rest_seconds, superset_group
+		FROM exercises_in_routine
+		WHERE workout_routine_id = ANY($1)
+		ORDER BY workout_routine_id, position, exercise_id`,
+		pq.Array(routineIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[int64][]model.RoutineExercise, len(routineIDs))
+	for rows.Next() {
+		var routineID int64
+		var entry model.RoutineExercise
+		var sets, repsMin, repsMax, rest, superset sql.NullInt64
+		if err := rows.Scan(&routineID, &entry.ExerciseID, &sets, &repsMin, &repsMax, &rest, &superset); err != nil {
+			return nil, err
+		}
+		entry.TargetSets = nullInt(sets)
+		entry.RepsMin = nullInt(repsMin)
+		entry.RepsMax = nullInt(repsMax)
+		entry.RestSeconds = nullInt(rest)
+		entry.SupersetGroup = nullInt(superset)
+		entries[routineID] = append(entries[routineID], entry)
+	}
+	return entries, rows.Err()
+}
+
+// setRoutineEntries numbers the entries by their order and mirrors them in
+// ExerciseIDs
+func setRoutineEntries(routine *model.ExerciseRoutine, entries []model.RoutineExercise) {
+	routine.Entries = make([]model.RoutineExercise, len(entries))
+	routine.ExerciseIDs = make([]int64, len(entries))
+	for i, entry := range entries {
+		entry.Position = i
+		routine.Entries[i] = entry
+		routine.ExerciseIDs[i] = entry.ExerciseID
+	}
+}
+
+func nullInt(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
@@ -9,33 +9,41 @@ import (
 	"workoutpal/src/internal/model"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 )
 
+var routineColumnNames = []string{"id", "name", "description", "user_id", "visibility", "fork_count", "forked_from_routine_id", "forked_from_user_id", "source_username"}
+
+var routineExerciseColumns = []string{"workout_routine_id", "exercise_id", "target_sets", "target_reps_min", "target_reps_max", "rest_seconds", "superset_group"}
+
 func TestRoutineRepository_CreateRoutine_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
+	sets, repsMin, repsMax := 3, 8, 12
 	req := model.CreateRoutineRequest{
 		Name:        "Push",
+		Description: "Chest and triceps",
 		ExerciseIDs: []int64{1, 2},
+		Exercises:   []model.RoutineExercise{{ExerciseID: 2, TargetSets: &sets, RepsMin: &repsMin, RepsMax: &repsMax}, {ExerciseID: 1}},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO workout_routine (name, user_id)
-		VALUES ($1, $2)
-		RETURNING id, name, user_id`)).
-		WithArgs(req.Name, int64(10)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
-			AddRow(100, req.Name, 10))
+		INSERT INTO workout_routine (name, description, user_id, visibility, forked_from_routine_id, forked_from_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, name, description, user_id, visibility`)).
+		WithArgs(req.Name, req.Description, int64(10), "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "visibility"}).
+			AddRow(100, req.Name, req.Description, 10, "private"))
 
 	mock.ExpectExec(regexp.QuoteMeta(
-		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id) VALUES ($1, $2)",
-	)).WithArgs(int64(100), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id, position, target_sets, target_reps_min, target_reps_max, rest_seconds, superset_group)",
+	)).WithArgs(int64(100), int64(2), 0, &sets, &repsMin, &repsMax, nil, nil).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectExec(regexp.QuoteMeta(
-		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id) VALUES ($1, $2)",
-	)).WithArgs(int64(100), int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id, position, target_sets, target_reps_min, target_reps_max, rest_seconds, superset_group)",
+	)).WithArgs(int64(100), int64(1), 1, nil, nil, nil, nil, nil).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	mock.ExpectCommit()
 
@@ -43,9 +51,12 @@ func TestRoutineRepository_CreateRoutine_OK(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if got.ID != 100 || got.UserID != 10 || got.Name != "Push" || len(got.ExerciseIDs) != 2 || !got.IsActive {
+	if got.ID != 100 || got.UserID != 10 || got.Name != "Push" || got.Description != req.Description || !got.IsActive {
 		t.Fatalf("unexpected routine: %#v", got)
 	}
+	if len(got.ExerciseIDs) != 2 || got.ExerciseIDs[0] != 2 || got.Entries[1].Position != 1 || *got.Entries[0].RepsMax != 12 {
+		t.Fatalf("want the exercises in the order sent, got %#v", got)
+	}
 }
 
 func TestRoutineRepository_CreateRoutine_InsertExerciseFails_RollsBack(t *testing.T) {
@@ -53,15 +64,15 @@ func TestRoutineRepository_CreateRoutine_InsertExerciseFails_RollsBack(t *testin
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
-	req := model.CreateRoutineRequest{Name: "A", ExerciseIDs: []int64{1}}
+	req := model.CreateRoutineRequest{Name: "A", Visibility: "private", Exercises: []model.RoutineExercise{{ExerciseID: 1}}}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO workout_routine").
-		WithArgs("A", int64(5)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
-			AddRow(77, "A", 5))
+		WithArgs("A", "", int64(5), "private", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "visibility"}).
+			AddRow(77, "A", "", 5, "private"))
 	mock.ExpectExec("INSERT INTO exercises_in_routine").
-		WithArgs(int64(77), int64(1)).
+		WithArgs(int64(77), int64(1), 0, nil, nil, nil, nil, nil).
 		WillReturnError(errors.New("fk violation"))
 	mock.ExpectRollback()
 
@@ -77,29 +88,35 @@ func TestRoutineRepository_ReadUserRoutines_OK(t *testing.T) {
 	repo := NewRoutineRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, user_id FROM workout_routine WHERE user_id = $1",
+		"FROM workout_routine wr LEFT JOIN users src ON src.id = wr.forked_from_user_id WHERE wr.user_id = $1 ORDER BY wr.id",
 	)).WithArgs(int64(10)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
-			AddRow(1, "A", 10).
-			AddRow(2, "B", 10))
-
-	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT exercise_id FROM exercises_in_routine WHERE workout_routine_id = $1",
-	)).WithArgs(int64(1)).
-		WillReturnRows(sqlmock.NewRows([]string{"exercise_id"}).AddRow(int64(5)).AddRow(int64(6)))
+		WillReturnRows(sqlmock.NewRows(routineColumnNames).
+			AddRow(1, "A", "", 10, "private", 0, 0, 0, "").
+			AddRow(2, "B", "Legs day", 10, "public", 3, 40, 8, "coach"))
 
-	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT exercise_id FROM exercises_in_routine WHERE workout_routine_id = $1",
-	)).WithArgs(int64(2)).
-		WillReturnRows(sqlmock.NewRows([]string{"exercise_id"}))
+	// one query loads the exercises of every routine
+	mock.ExpectQuery(`SELECT workout_routine_id, exercise_id, .+ FROM exercises_in_routine\s+WHERE workout_routine_id = ANY\(\$1\)\s+ORDER BY workout_routine_id, position, exercise_id`).
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnRows(sqlmock.NewRows(routineExerciseColumns).
+			AddRow(1, 6, 3, 8, 12, 90, nil).
+			AddRow(1, 5, nil, nil, nil, nil, nil))
 
 	got, err := repo.ReadUserRoutines(10)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != 2 || got[0].ID != 1 || len(got[0].ExerciseIDs) != 2 || !got[1].IsActive {
+	if len(got) != 2 || got[0].ID != 1 || len(got[0].ExerciseIDs) != 2 || !got[1].IsActive || got[1].Description != "Legs day" {
 		t.Fatalf("unexpected routines: %#v", got)
 	}
+	if got[0].ExerciseIDs[0] != 6 || *got[0].Entries[0].RestSeconds != 90 || got[0].Entries[1].Position != 1 || got[0].Entries[1].TargetSets != nil {
+		t.Fatalf("unexpected entries: %#v", got[0].Entries)
+	}
+	if got[0].ForkedFrom != nil || got[1].ForkCount != 3 || *got[1].ForkedFrom != (model.RoutineSource{RoutineID: 40, UserID: 8, Username: "coach"}) {
+		t.Fatalf("unexpected sharing: %#v", got)
+	}
+	if len(got[1].ExerciseIDs) != 0 {
+		t.Fatalf("routine B has no exercises: %#v", got[1])
+	}
 }
 
 func TestRoutineRepository_ReadUserRoutines_OuterQueryError(t *testing.T) {
@@ -107,7 +124,7 @@ func TestRoutineRepository_ReadUserRoutines_OuterQueryError(t *testing.T) {
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
-	mock.ExpectQuery("SELECT id, name, user_id FROM workout_routine").
+	mock.ExpectQuery("FROM workout_routine wr").
 		WithArgs(int64(9)).
 		WillReturnError(errors.New("db down"))
 
@@ -122,13 +139,13 @@ func TestRoutineRepository_ReadUserRoutines_InnerQueryError(t *testing.T) {
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
-	mock.ExpectQuery("SELECT id, name, user_id FROM workout_routine").
+	mock.ExpectQuery("FROM workout_routine wr").
 		WithArgs(int64(9)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
-			AddRow(1, "A", 9))
+		WillReturnRows(sqlmock.NewRows(routineColumnNames).
+			AddRow(1, "A", "", 9, "private", 0, 0, 0, ""))
 
-	mock.ExpectQuery("SELECT exercise_id FROM exercises_in_routine").
-		WithArgs(int64(1)).
+	mock.ExpectQuery("FROM exercises_in_routine").
+		WithArgs(pq.Array([]int64{1})).
 		WillReturnError(errors.New("join failed"))
 
 	_, err := repo.ReadUserRoutines(9)
@@ -143,18 +160,19 @@ func TestRoutineRepository_ReadRoutineWithExercises_OK(t *testing.T) {
 	repo := NewRoutineRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, name, user_id FROM workout_routine WHERE id = $1",
+		"FROM workout_routine wr LEFT JOIN users src ON src.id = wr.forked_from_user_id WHERE wr.id = $1",
 	)).WithArgs(int64(7)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
-			AddRow(7, "Pull", 2))
+		WillReturnRows(sqlmock.NewRows(routineColumnNames).
+			AddRow(7, "Pull", "Back", 2, "followers", 0, 0, 0, ""))
 
-	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT exercise_id FROM exercises_in_routine WHERE workout_routine_id = $1",
-	)).WithArgs(int64(7)).
-		WillReturnRows(sqlmock.NewRows([]string{"exercise_id"}).AddRow(int64(11)).AddRow(int64(12)))
+	mock.ExpectQuery("FROM exercises_in_routine").
+		WithArgs(pq.Array([]int64{7})).
+		WillReturnRows(sqlmock.NewRows(routineExerciseColumns).
+			AddRow(7, 11, nil, nil, nil, nil, 1).
+			AddRow(7, 12, nil, nil, nil, nil, 1))
 
 	got, err := repo.ReadRoutineWithExercises(7)
-	if err != nil || got.ID != 7 || len(got.ExerciseIDs) != 2 {
+	if err != nil || got.ID != 7 || got.Description != "Back" || got.Visibility != "followers" || len(got.ExerciseIDs) != 2 || *got.Entries[1].SupersetGroup != 1 {
 		t.Fatalf("unexpected: %#v err=%v", got, err)
 	}
 }
@@ -164,7 +182,7 @@ func TestRoutineRepository_ReadRoutineWithExercises_NotFound(t *testing.T) {
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
-	mock.ExpectQuery("SELECT id, name, user_id FROM workout_routine WHERE id = \\$1").
+	mock.ExpectQuery("FROM workout_routine wr .+ WHERE wr.id = \\$1").
 		WithArgs(int64(99)).
 		WillReturnError(sql.ErrNoRows)
 
@@ -207,9 +225,8 @@ func TestRoutineRepository_AddExerciseToRoutine_OK(t *testing.T) {
 	defer db.Close()
 	repo := NewRoutineRepository(db)
 
-	mock.ExpectExec(regexp.QuoteMeta(
-		"INSERT INTO exercises_in_routine (workout_routine_id, exercise_id) VALUES ($1, $2)",
-	)).WithArgs(int64(5), int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO exercises_in_routine \(workout_routine_id, exercise_id, position\)\s+SELECT \$1, \$2, COALESCE\(MAX\(position\) \+ 1, 0\)`).
+		WithArgs(int64(5), int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	if err := repo.AddExerciseToRoutine(5, 9); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -257,3 +274,212 @@ func TestRoutineRepository_RemoveExerciseFromRoutine_NotFound(t *testing.T) {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
+
+func TestRoutineRepository_UpdateRoutine_ReplacesExercisesInOneTransaction(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	name := "Pull B"
+	rest := 60
+	exercises := []model.RoutineExercise{{ExerciseID: 12, RestSeconds: &rest}, {ExerciseID: 11}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SET name = COALESCE($2, name), description = COALESCE($3, description), visibility = COALESCE($4, visibility)")).
+		WithArgs(int64(7), &name, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM exercises_in_routine WHERE workout_routine_id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO exercises_in_routine").
+		WithArgs(int64(7), int64(12), 0, nil, nil, nil, &rest, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO exercises_in_routine").
+		WithArgs(int64(7), int64(11), 1, nil, nil, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("FROM workout_routine wr .+ WHERE wr.id").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(routineColumnNames).AddRow(7, name, "", 2, "private", 0, 0, 0, ""))
+	mock.ExpectQuery("FROM exercises_in_routine").
+		WithArgs(pq.Array([]int64{7})).
+		WillReturnRows(sqlmock.NewRows(routineExerciseColumns).
+			AddRow(7, 12, nil, nil, nil, 60, nil).
+			AddRow(7, 11, nil, nil, nil, nil, nil))
+
+	got, err := repo.UpdateRoutine(model.UpdateRoutineRequest{ID: 7, Name: &name, Exercises: &exercises})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != name || got.ExerciseIDs[0] != 12 || *got.Entries[0].RestSeconds != 60 {
+		t.Fatalf("unexpected routine: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRoutineRepository_UpdateRoutine_InsertFails_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	exercises := []model.RoutineExercise{{ExerciseID: 99}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE workout_routine").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM exercises_in_routine").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO exercises_in_routine").WillReturnError(errors.New("fk violation"))
+	mock.ExpectRollback()
+
+	if _, err := repo.UpdateRoutine(model.UpdateRoutineRequest{ID: 7, Exercises: &exercises}); err == nil || err.Error() != "fk violation" {
+		t.Fatalf("expected fk violation, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRoutineRepository_UpdateRoutine_KeepsExercisesWhenNotSent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	description := "Heavy day"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE workout_routine").
+		WithArgs(int64(7), nil, &description, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("FROM workout_routine wr").
+		WillReturnRows(sqlmock.NewRows(routineColumnNames).AddRow(7, "Pull", description, 2, "private", 0, 0, 0, ""))
+	mock.ExpectQuery("FROM exercises_in_routine").
+		WillReturnRows(sqlmock.NewRows(routineExerciseColumns))
+
+	got, err := repo.UpdateRoutine(model.UpdateRoutineRequest{ID: 7, Description: &description})
+	if err != nil || got.Description != description {
+		t.Fatalf("unexpected: %#v err=%v", got, err)
+	}
+}
+
+func TestRoutineRepository_ReorderRoutineExercises(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	mock.ExpectExec(`UPDATE exercises_in_routine e SET position = o.position - 1\s+FROM unnest\(\$2::int\[\]\) WITH ORDINALITY`).
+		WithArgs(int64(7), pq.Array([]int64{12, 11})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := repo.ReorderRoutineExercises(7, []int64{12, 11}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRoutineRepository_CreateRoutine_Fork_CopiesSettingsAndCounts(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	source := &model.RoutineSource{RoutineID: 40, UserID: 8}
+	req := model.CreateRoutineRequest{Name: "Legs", Visibility: "private", Source: source,
+		Exercises: []model.RoutineExercise{{ExerciseID: 1}, {ExerciseID: 3}}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO workout_routine").
+		WithArgs("Legs", "", int64(5), "private", int64(40), int64(8)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "visibility"}).
+			AddRow(77, "Legs", "", 5, "private"))
+	mock.ExpectExec("INSERT INTO exercises_in_routine").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO exercises_in_routine").WillReturnResult(sqlmock.NewResult(0, 1))
+	// only the settings of the exercises the fork keeps are copied
+	mock.ExpectExec(`INSERT INTO user_exercise_settings .+\s+SELECT \$1, exercise_id, \$2, .+\s+FROM user_exercise_settings\s+WHERE user_id = \$3 AND workout_routine_id = \$4 AND exercise_id = ANY\(\$5\)`).
+		WithArgs(int64(5), int64(77), int64(8), int64(40), pq.Array([]int64{1, 3})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE workout_routine SET fork_count = fork_count + 1 WHERE id = $1")).
+		WithArgs(int64(40)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.CreateRoutine(5, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ForkedFrom == nil || got.ForkedFrom.RoutineID != 40 || got.ForkCount != 0 {
+		t.Fatalf("unexpected fork: %#v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRoutineRepository_CreateRoutine_Fork_CountFails_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	req := model.CreateRoutineRequest{Name: "Legs", Visibility: "private", Source: &model.RoutineSource{RoutineID: 40, UserID: 8}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO workout_routine").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "visibility"}).
+			AddRow(77, "Legs", "", 5, "private"))
+	mock.ExpectExec("INSERT INTO user_exercise_settings").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE workout_routine SET fork_count").WillReturnError(errors.New("db down"))
+	mock.ExpectRollback()
+
+	if _, err := repo.CreateRoutine(5, req); err == nil || err.Error() != "db down" {
+		t.Fatalf("expected db down, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRoutineRepository_ReadPublicRoutines_SearchNewest(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	mock.ExpectQuery(`SELECT .+, owner.username FROM workout_routine wr LEFT JOIN users src .+ JOIN users owner ON owner.id = wr.user_id `+
+		`WHERE wr.visibility = 'public' AND NOT owner.is_private AND \(wr.name ILIKE \$1 OR wr.description ILIKE \$1\) `+
+		`ORDER BY wr.id DESC LIMIT \$2`).
+		WithArgs(`%5\_x5%`, 21).
+		WillReturnRows(sqlmock.NewRows(append(routineColumnNames, "username")).
+			AddRow(9, "5_x5 Strength", "", 3, "public", 12, 0, 0, "", "coach"))
+	mock.ExpectQuery("FROM exercises_in_routine").
+		WithArgs(pq.Array([]int64{9})).
+		WillReturnRows(sqlmock.NewRows(routineExerciseColumns).AddRow(9, 1, 5, 5, 5, 180, nil))
+
+	got, err := repo.ReadPublicRoutines(model.PublicRoutineFilter{Search: "5_x5", Sort: model.RoutineSortNewest, Limit: 21})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Username != "coach" || got[0].ForkCount != 12 || got[0].ExerciseIDs[0] != 1 {
+		t.Fatalf("unexpected routines: %#v", got)
+	}
+}
+
+func TestRoutineRepository_ReadPublicRoutines_PopularAfterCursor(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewRoutineRepository(db)
+
+	mock.ExpectQuery(`WHERE wr.visibility = 'public' AND NOT owner.is_private AND \(wr.fork_count, wr.id\) < \(\$1, \$2\) `+
+		`ORDER BY wr.fork_count DESC, wr.id DESC LIMIT \$3`).
+		WithArgs(12, int64(9), 3).
+		WillReturnRows(sqlmock.NewRows(append(routineColumnNames, "username")))
+
+	got, err := repo.ReadPublicRoutines(model.PublicRoutineFilter{
+		Sort:  model.RoutineSortPopular,
+		After: &model.RoutineCursor{Sort: model.RoutineSortPopular, ForkCount: 12, ID: 9},
+		Limit: 3,
+	})
+	if err != nil || len(got) != 0 {
+		t.Fatalf("unexpected: %#v err=%v", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/model"
+
+	"github.com/lib/pq"
 )
 
 type scheduleRepository struct {
@@ -75,6 +79,7 @@ func (s *scheduleRepository) replaceScheduleRoutines(ctx context.Context, tx *sq
 
 func scanScheduleRow(row Scanner) (*model.Schedule, error) {
 	var sch model.Schedule
+	var startsOn, endsOn sql.NullTime
 	if err := row.Scan(
 		&sch.ID,
 		&sch.Name,
@@ -82,12 +87,25 @@ func scanScheduleRow(row Scanner) (*model.Schedule, error) {
 		&sch.DayOfWeek,
 		&sch.TimeSlot,
 		&sch.RoutineLengthMinutes,
+		&sch.Timezone,
+		&startsOn,
+		&endsOn,
 	); err != nil {
 		return nil, err
 	}
+	sch.StartsOn = formatDate(startsOn)
+	sch.EndsOn = formatDate(endsOn)
 	return &sch, nil
 }
 
+// formatDate renders a DATE column as YYYY-MM-DD, or "" when it is NULL
+func formatDate(date sql.NullTime) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.Time.Format(time.DateOnly)
+}
+
 type Scanner interface {
 	Scan(dest ...any) error
 }
@@ -96,7 +114,7 @@ func (s *scheduleRepository) ReadUserSchedules(userId int64) ([]*model.Schedule,
 	ctx := context.Background()
 
 	const q = `
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		ORDER BY day_of_week ASC, time_slot ASC;
@@ -133,7 +151,7 @@ func (s *scheduleRepository) ReadUserSchedulesByDay(userId int64, dayOfWeek int6
 	ctx := context.Background()
 
 	const q = `
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		  AND day_of_week = $2
@@ -171,7 +189,7 @@ func (s *scheduleRepository) ReadScheduleByID(id int64) (*model.Schedule, error)
 	ctx := context.Background()
 
 	const q = `
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE id = $1;
 	`
@@ -201,9 +219,9 @@ func (s *scheduleRepository) CreateSchedule(request model.CreateScheduleRequest)
 	}()
 
 	const insertSchedule = `
-		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::date, NULLIF($8, '')::date)
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`
 
 	row := tx.QueryRowContext(ctx, insertSchedule,
@@ -212,6 +230,9 @@ func (s *scheduleRepository) CreateSchedule(request model.CreateScheduleRequest)
 		request.DayOfWeek,
 		request.TimeSlot,
 		request.RoutineLengthMinutes,
+		request.Timezone,
+		request.StartsOn,
+		request.EndsOn,
 	)
 
 	base, err := scanScheduleRow(row)
@@ -246,10 +267,13 @@ func (s *scheduleRepository) UpdateSchedule(request model.UpdateScheduleRequest)
 		SET name = $1,
 		    day_of_week = $2,
 		    time_slot = $3,
-		    routine_length_minutes = $4
+		    routine_length_minutes = $4,
+		    timezone = $7,
+		    starts_on = NULLIF($8, '')::date,
+		    ends_on = NULLIF($9, '')::date
 		WHERE id = $5
 		  AND user_id = $6
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`
 
 	row := tx.QueryRowContext(ctx, updateSchedule,
@@ -259,12 +283,15 @@ func (s *scheduleRepository) UpdateSchedule(request model.UpdateScheduleRequest)
 		request.RoutineLengthMinutes,
 		request.ID,
 		request.UserID,
+		request.Timezone,
+		request.StartsOn,
+		request.EndsOn,
 	)
 
 	base, err := scanScheduleRow(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, fmt.Errorf("schedule not found: %w", sql.ErrNoRows)
 		}
 		return nil, err
 	}
@@ -301,3 +328,260 @@ func (s *scheduleRepository) DeleteSchedule(request model.DeleteScheduleRequest)
 	}
 	return nil
 }
+
+// ReadCalendarEntries reads the user's schedules together with their routine
+// names and exceptions
+func (s *scheduleRepository) ReadCalendarEntries(userID int64) ([]*model.CalendarEntry, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT s.id, s.name, s.user_id, s.day_of_week, s.time_slot, s.routine_length_minutes,
+		       s.timezone, s.starts_on, s.ends_on, s.created_at,
+		       COALESCE(array_agg(wr.name ORDER BY sr.position) FILTER (WHERE wr.id IS NOT NULL), '{}')
+		FROM schedule s
+		LEFT JOIN schedule_routine sr ON sr.schedule_id = s.id
+		LEFT JOIN workout_routine wr ON wr.id = sr.routine_id
+		WHERE s.user_id = $1
+		GROUP BY s.id
+		ORDER BY s.day_of_week ASC, s.time_slot ASC;
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*model.CalendarEntry, 0)
+	for rows.Next() {
+		var entry model.CalendarEntry
+		var startsOn, endsOn sql.NullTime
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Name,
+			&entry.UserID,
+			&entry.DayOfWeek,
+			&entry.TimeSlot,
+			&entry.RoutineLengthMinutes,
+			&entry.Timezone,
+			&startsOn,
+			&endsOn,
+			&entry.CreatedAt,
+			pq.Array(&entry.RoutineNames),
+		); err != nil {
+			return nil, err
+		}
+		entry.StartsOn = formatDate(startsOn)
+		entry.EndsOn = formatDate(endsOn)
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, s.attachCalendarExceptions(userID, entries)
+}
+
+func (s *scheduleRepository) attachCalendarExceptions(userID int64, entries []*model.CalendarEntry) error {
+	const q = `
+		SELECT e.id, e.schedule_id, e.occurs_on, e.rescheduled_to
+		FROM schedule_exceptions e
+		JOIN schedule s ON s.id = e.schedule_id
+		WHERE s.user_id = $1
+		ORDER BY e.schedule_id ASC, e.occurs_on ASC;
+	`
+
+	rows, err := s.db.QueryContext(context.Background(), q, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	bySchedule := make(map[int64]*model.CalendarEntry, len(entries))
+	for _, entry := range entries {
+		bySchedule[entry.ID] = entry
+	}
+	for rows.Next() {
+		exception, err := scanScheduleException(rows)
+		if err != nil {
+			return err
+		}
+		if entry := bySchedule[exception.ScheduleID]; entry != nil {
+			entry.Exceptions = append(entry.Exceptions, exception)
+		}
+	}
+	return rows.Err()
+}
+
+// UpsertCalendarFeed replaces the user's feed token, invalidating the previous URL
+func (s *scheduleRepository) UpsertCalendarFeed(userID int64, tokenHash string) error {
+	const q = `
+		INSERT INTO calendar_feeds (user_id, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = NOW();
+	`
+
+	_, err := s.db.ExecContext(context.Background(), q, userID, tokenHash)
+	return err
+}
+
+// ReadCalendarFeedOwner returns sql.ErrNoRows for unknown or revoked tokens
+func (s *scheduleRepository) ReadCalendarFeedOwner(tokenHash string) (int64, error) {
+	var userID int64
+	err := s.db.QueryRowContext(context.Background(),
+		`SELECT user_id FROM calendar_feeds WHERE token_hash = $1`, tokenHash).Scan(&userID)
+	return userID, err
+}
+
+func (s *scheduleRepository) DeleteCalendarFeed(userID int64) error {
+	_, err := s.db.ExecContext(context.Background(), `DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	return err
+}
+
+func scanScheduleException(row Scanner) (*model.ScheduleException, error) {
+	var exception model.ScheduleException
+	var occursOn time.Time
+	var rescheduledTo sql.NullTime
+	if err := row.Scan(&exception.ID, &exception.ScheduleID, &occursOn, &rescheduledTo); err != nil {
+		return nil, err
+	}
+	exception.Date = occursOn.Format(time.DateOnly)
+	if rescheduledTo.Valid {
+		exception.RescheduledTo = &rescheduledTo.Time
+	}
+	return &exception, nil
+}
+
+// CreateScheduleException skips or moves one occurrence, replacing an earlier
+// exception for the same date
+func (s *scheduleRepository) CreateScheduleException(request model.CreateScheduleExceptionRequest) (*model.ScheduleException, error) {
+	const q = `
+		INSERT INTO schedule_exceptions (schedule_id, occurs_on, rescheduled_to)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (schedule_id, occurs_on) DO UPDATE SET rescheduled_to = EXCLUDED.rescheduled_to
+		RETURNING id, schedule_id, occurs_on, rescheduled_to;
+	`
+
+	row := s.db.QueryRowContext(context.Background(), q, request.ScheduleID, request.Date, request.RescheduledTo)
+	return scanScheduleException(row)
+}
+
+func (s *scheduleRepository) DeleteScheduleException(request model.DeleteScheduleExceptionRequest) error {
+	res, err := s.db.ExecContext(context.Background(),
+		`DELETE FROM schedule_exceptions WHERE schedule_id = $1 AND occurs_on = $2`, request.ScheduleID, request.Date)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (s *scheduleRepository) ReadScheduleExceptions(filter model.ScheduleExceptionFilter) ([]*model.ScheduleException, error) {
+	const q = `
+		SELECT e.id, e.schedule_id, e.occurs_on, e.rescheduled_to
+		FROM schedule_exceptions e
+		JOIN schedule s ON s.id = e.schedule_id
+		WHERE s.user_id = $1
+		  AND (e.occurs_on BETWEEN $2 AND $3 OR (e.rescheduled_to >= $4 AND e.rescheduled_to < $5))
+		ORDER BY e.schedule_id ASC, e.occurs_on ASC;
+	`
+
+	rows, err := s.db.QueryContext(context.Background(), q, filter.UserID, filter.FromDate, filter.ToDate, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exceptions := make([]*model.ScheduleException, 0)
+	for rows.Next() {
+		exception, err := scanScheduleException(rows)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, exception)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exceptions, nil
+}
+
+func scanOccurrenceMark(row Scanner) (*model.OccurrenceMark, error) {
+	var mark model.OccurrenceMark
+	var occursOn time.Time
+	var sessionID sql.NullInt64
+	if err := row.Scan(&mark.ScheduleID, &occursOn, &mark.Status, &sessionID); err != nil {
+		return nil, err
+	}
+	mark.Date = occursOn.Format(time.DateOnly)
+	mark.SessionID = sessionID.Int64
+	return &mark, nil
+}
+
+// UpsertOccurrenceMark replaces any earlier mark for the same occurrence
+func (s *scheduleRepository) UpsertOccurrenceMark(request model.MarkOccurrenceRequest) (*model.OccurrenceMark, error) {
+	const q = `
+		INSERT INTO schedule_occurrence_marks (schedule_id, occurs_on, status, session_id)
+		VALUES ($1, $2, $3, NULLIF($4, 0))
+		ON CONFLICT (schedule_id, occurs_on) DO UPDATE
+		SET status = EXCLUDED.status, session_id = EXCLUDED.session_id, updated_at = NOW()
+		RETURNING schedule_id, occurs_on, status, session_id;
+	`
+
+	row := s.db.QueryRowContext(context.Background(), q, request.ScheduleID, request.Date, request.Status, request.SessionID)
+	return scanOccurrenceMark(row)
+}
+
+func (s *scheduleRepository) DeleteOccurrenceMark(request model.DeleteOccurrenceMarkRequest) error {
+	res, err := s.db.ExecContext(context.Background(),
+		`DELETE FROM schedule_occurrence_marks WHERE schedule_id = $1 AND occurs_on = $2`, request.ScheduleID, request.Date)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (s *scheduleRepository) ReadOccurrenceMarks(filter model.OccurrenceMarkFilter) ([]*model.OccurrenceMark, error) {
+	const q = `
+		SELECT m.schedule_id, m.occurs_on, m.status, m.session_id
+		FROM schedule_occurrence_marks m
+		JOIN schedule s ON s.id = m.schedule_id
+		WHERE s.user_id = $1
+		  AND m.occurs_on BETWEEN $2 AND $3
+		ORDER BY m.occurs_on ASC, m.schedule_id ASC;
+	`
+
+	rows, err := s.db.QueryContext(context.Background(), q, filter.UserID, filter.FromDate, filter.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	marks := make([]*model.OccurrenceMark, 0)
+	for rows.Next() {
+		mark, err := scanOccurrenceMark(rows)
+		if err != nil {
+			return nil, err
+		}
+		marks = append(marks, mark)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return marks, nil
+}
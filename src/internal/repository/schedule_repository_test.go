@@ -2,8 +2,10 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
+	"time"
 	"workoutpal/src/internal/model"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -17,12 +19,12 @@ func TestScheduleRepository_ReadUserSchedules_OK(t *testing.T) {
 	userID := int64(10)
 
 	rowsSchedules := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow(1, "Morning Lift", userID, 1, "07:30", 90).
-		AddRow(2, "Cardio", userID, 3, "18:00", 45)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow(1, "Morning Lift", userID, 1, "07:30", 90, "UTC", nil, nil).
+		AddRow(2, "Cardio", userID, 3, "18:00", 45, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		ORDER BY day_of_week ASC, time_slot ASC;
@@ -91,7 +93,7 @@ func TestScheduleRepository_ReadUserSchedules_QueryError(t *testing.T) {
 	userID := int64(10)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		ORDER BY day_of_week ASC, time_slot ASC;
@@ -119,11 +121,11 @@ func TestScheduleRepository_ReadUserSchedules_ScanError(t *testing.T) {
 	userID := int64(10)
 
 	rowsSchedules := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow("bad", "X", userID, 1, "07:30", 90)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow("bad", "X", userID, 1, "07:30", 90, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		ORDER BY day_of_week ASC, time_slot ASC;
@@ -152,11 +154,11 @@ func TestScheduleRepository_ReadUserSchedulesByDay_OK(t *testing.T) {
 	day := int64(3)
 
 	rowsSchedules := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow(5, "Evening Cardio", userID, day, "19:00", 60)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow(5, "Evening Cardio", userID, day, "19:00", 60, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE user_id = $1
 		  AND day_of_week = $2
@@ -202,11 +204,11 @@ func TestScheduleRepository_ReadScheduleByID_OK(t *testing.T) {
 	repo := NewScheduleRepository(db)
 
 	rowsSchedule := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow(8, "Leg Day", 99, 2, "06:00", 75)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow(8, "Leg Day", 99, 2, "06:00", 75, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE id = $1;
 	`)).
@@ -247,7 +249,7 @@ func TestScheduleRepository_ReadScheduleByID_NotFound(t *testing.T) {
 	repo := NewScheduleRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE id = $1;
 	`)).
@@ -272,7 +274,7 @@ func TestScheduleRepository_ReadScheduleByID_DBError(t *testing.T) {
 	repo := NewScheduleRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes
+		SELECT id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on
 		FROM schedule
 		WHERE id = $1;
 	`)).
@@ -308,15 +310,15 @@ func TestScheduleRepository_CreateSchedule_OK(t *testing.T) {
 	mock.ExpectBegin()
 
 	rowInsert := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow(900, req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow(900, req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::date, NULLIF($8, '')::date)
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`)).
-		WithArgs(req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes).
+		WithArgs(req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.Timezone, req.StartsOn, req.EndsOn).
 		WillReturnRows(rowInsert)
 
 	mock.ExpectExec(regexp.QuoteMeta(`
@@ -388,11 +390,11 @@ func TestScheduleRepository_CreateSchedule_InsertError(t *testing.T) {
 	mock.ExpectBegin()
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		INSERT INTO schedule (name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::date, NULLIF($8, '')::date)
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`)).
-		WithArgs(req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes).
+		WithArgs(req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.Timezone, req.StartsOn, req.EndsOn).
 		WillReturnError(assertErr)
 
 	mock.ExpectRollback()
@@ -427,20 +429,23 @@ func TestScheduleRepository_UpdateSchedule_OK(t *testing.T) {
 	mock.ExpectBegin()
 
 	rowUpdate := sqlmock.NewRows([]string{
-		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes",
-	}).AddRow(req.ID, req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes)
+		"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on",
+	}).AddRow(req.ID, req.Name, req.UserID, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, "UTC", nil, nil)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
 		UPDATE schedule
 		SET name = $1,
 		    day_of_week = $2,
 		    time_slot = $3,
-		    routine_length_minutes = $4
+		    routine_length_minutes = $4,
+		    timezone = $7,
+		    starts_on = NULLIF($8, '')::date,
+		    ends_on = NULLIF($9, '')::date
 		WHERE id = $5
 		  AND user_id = $6
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`)).
-		WithArgs(req.Name, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.ID, req.UserID).
+		WithArgs(req.Name, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.ID, req.UserID, req.Timezone, req.StartsOn, req.EndsOn).
 		WillReturnRows(rowUpdate)
 
 	mock.ExpectExec(regexp.QuoteMeta(
@@ -515,12 +520,15 @@ func TestScheduleRepository_UpdateSchedule_NotFound(t *testing.T) {
 		SET name = $1,
 		    day_of_week = $2,
 		    time_slot = $3,
-		    routine_length_minutes = $4
+		    routine_length_minutes = $4,
+		    timezone = $7,
+		    starts_on = NULLIF($8, '')::date,
+		    ends_on = NULLIF($9, '')::date
 		WHERE id = $5
 		  AND user_id = $6
-		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes;
+		RETURNING id, name, user_id, day_of_week, time_slot, routine_length_minutes, timezone, starts_on, ends_on;
 	`)).
-		WithArgs(req.Name, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.ID, req.UserID).
+		WithArgs(req.Name, req.DayOfWeek, req.TimeSlot, req.RoutineLengthMinutes, req.ID, req.UserID, req.Timezone, req.StartsOn, req.EndsOn).
 		WillReturnError(sql.ErrNoRows)
 
 	mock.ExpectRollback()
@@ -529,8 +537,8 @@ func TestScheduleRepository_UpdateSchedule_NotFound(t *testing.T) {
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
-	if err != nil {
-		t.Fatalf("expected nil err, got %v", err)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
 	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unmet expectations: %v", err)
@@ -581,3 +589,166 @@ func TestScheduleRepository_DeleteSchedule_NotFound(t *testing.T) {
 		t.Fatalf("unmet expectations: %v", err)
 	}
 }
+
+func TestScheduleRepository_ReadCalendarEntries_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewScheduleRepository(db)
+
+	created := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM schedule s")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id", "day_of_week", "time_slot", "routine_length_minutes", "timezone", "starts_on", "ends_on", "created_at", "routines"}).
+			AddRow(int64(1), "Legs", int64(5), int64(1), "07:30:00", int64(45), "Europe/Berlin", created, nil, created, "{Squats,\"Leg press\"}").
+			AddRow(int64(2), "Rest", int64(5), int64(0), "09:00:00", int64(0), "UTC", nil, nil, created, "{}"))
+	movedTo := time.Date(2025, 3, 11, 6, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM schedule_exceptions e")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "schedule_id", "occurs_on", "rescheduled_to"}).
+			AddRow(int64(30), int64(1), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), movedTo).
+			AddRow(int64(31), int64(1), time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), nil))
+
+	entries, err := repo.ReadCalendarEntries(5)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if names := entries[0].RoutineNames; len(names) != 2 || names[1] != "Leg press" {
+		t.Fatalf("unexpected routine names %#v", names)
+	}
+	if entries[0].Timezone != "Europe/Berlin" || entries[0].StartsOn != "2025-03-05" || entries[0].EndsOn != "" {
+		t.Fatalf("unexpected window %#v", entries[0])
+	}
+	if len(entries[1].RoutineNames) != 0 || !entries[1].CreatedAt.Equal(created) || len(entries[1].Exceptions) != 0 {
+		t.Fatalf("unexpected entry %#v", entries[1])
+	}
+	if exceptions := entries[0].Exceptions; len(exceptions) != 2 || exceptions[0].Date != "2025-03-10" || !exceptions[0].RescheduledTo.Equal(movedTo) || exceptions[1].RescheduledTo != nil {
+		t.Fatalf("unexpected exceptions %#v", exceptions)
+	}
+}
+
+func TestScheduleRepository_CalendarFeed(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewScheduleRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash")).
+		WithArgs(int64(5), "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id FROM calendar_feeds WHERE token_hash = $1")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(int64(5)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id FROM calendar_feeds WHERE token_hash = $1")).
+		WithArgs("other").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM calendar_feeds WHERE user_id = $1")).
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpsertCalendarFeed(5, "hash"); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if owner, err := repo.ReadCalendarFeedOwner("hash"); err != nil || owner != 5 {
+		t.Fatalf("expected owner 5, got %d, %v", owner, err)
+	}
+	if _, err := repo.ReadCalendarFeedOwner("other"); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := repo.DeleteCalendarFeed(5); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestScheduleRepository_ScheduleExceptions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewScheduleRepository(db)
+
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	moved := time.Date(2025, 3, 4, 18, 0, 0, 0, time.UTC)
+	cols := []string{"id", "schedule_id", "occurs_on", "rescheduled_to"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("ON CONFLICT (schedule_id, occurs_on) DO UPDATE SET rescheduled_to = EXCLUDED.rescheduled_to")).
+		WithArgs(int64(30), "2025-03-03", &moved).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(1), int64(30), day, moved))
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM schedule_exceptions e")).
+		WithArgs(int64(5), "2025-02-28", "2025-03-09", from, to).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(1), int64(30), day, nil))
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schedule_exceptions WHERE schedule_id = $1 AND occurs_on = $2")).
+		WithArgs(int64(30), "2025-03-10").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	created, err := repo.CreateScheduleException(model.CreateScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-03", RescheduledTo: &moved})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Date != "2025-03-03" || created.RescheduledTo == nil || !created.RescheduledTo.Equal(moved) {
+		t.Fatalf("unexpected exception %+v", created)
+	}
+
+	list, err := repo.ReadScheduleExceptions(model.ScheduleExceptionFilter{UserID: 5, FromDate: "2025-02-28", ToDate: "2025-03-09", From: from, To: to})
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(list) != 1 || list[0].RescheduledTo != nil {
+		t.Fatalf("expected one skipped occurrence, got %+v", list)
+	}
+
+	if err := repo.DeleteScheduleException(model.DeleteScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-10"}); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestScheduleRepository_OccurrenceMarks(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewScheduleRepository(db)
+
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	cols := []string{"schedule_id", "occurs_on", "status", "session_id"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO schedule_occurrence_marks")).
+		WithArgs(int64(30), "2025-03-03", "completed", int64(9)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(30), day, "completed", int64(9)))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM schedule_occurrence_marks m")).
+		WithArgs(int64(5), "2025-03-01", "2025-03-31").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(30), day, "skipped", nil))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schedule_occurrence_marks WHERE schedule_id = $1 AND occurs_on = $2")).
+		WithArgs(int64(30), "2025-03-10").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mark, err := repo.UpsertOccurrenceMark(model.MarkOccurrenceRequest{ScheduleID: 30, Date: "2025-03-03", Status: "completed", SessionID: 9})
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if mark.Date != "2025-03-03" || mark.SessionID != 9 {
+		t.Fatalf("unexpected mark %+v", mark)
+	}
+
+	marks, err := repo.ReadOccurrenceMarks(model.OccurrenceMarkFilter{UserID: 5, FromDate: "2025-03-01", ToDate: "2025-03-31"})
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(marks) != 1 || marks[0].Status != "skipped" || marks[0].SessionID != 0 {
+		t.Fatalf("unexpected marks %+v", marks)
+	}
+
+	if err := repo.DeleteOccurrenceMark(model.DeleteOccurrenceMarkRequest{ScheduleID: 30, Date: "2025-03-10"}); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
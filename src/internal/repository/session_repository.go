@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type sessionRepository struct {
+	db querier
+}
+
+func NewSessionRepository(db *sql.DB) repository.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// CreateSession starts a session together with its first refresh token
+func (s *sessionRepository) CreateSession(req model.CreateRefreshTokenRequest) error {
+	return inTransaction(s.db, func(tx querier) error {
+		if _, err := tx.Exec(`INSERT INTO auth_sessions (id, user_id) VALUES ($1, $2)`, req.SessionID, req.UserID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO refresh_tokens (session_id, token_hash, expires_at) VALUES ($1, $2, $3)`,
+			req.SessionID, req.TokenHash, req.ExpiresAt)
+		return err
+	})
+}
+
+func (s *sessionRepository) ReadRefreshToken(tokenHash string) (*model.RefreshToken, error) {
+	q := `
+		SELECT rt.id, rt.session_id, s.user_id, rt.expires_at, rt.used_at, s.revoked_at IS NOT NULL
+		FROM refresh_tokens rt
+		JOIN auth_sessions s ON s.id = rt.session_id
+		WHERE rt.token_hash = $1
+	`
+	var token model.RefreshToken
+	var usedAt sql.NullTime
+	err := s.db.QueryRow(q, tokenHash).Scan(&token.ID, &token.SessionID, &token.UserID, &token.ExpiresAt, &usedAt, &token.SessionRevoked)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks the presented token as used and issues its successor.
+// It returns sql.ErrNoRows when the token was already used, e.g. by a concurrent refresh.
+func (s *sessionRepository) RotateRefreshToken(usedTokenID int64, req model.CreateRefreshTokenRequest) error {
+	return inTransaction(s.db, func(tx querier) error {
+		res, err := tx.Exec(`UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`, usedTokenID)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		_, err = tx.Exec(`INSERT INTO refresh_tokens (session_id, token_hash, expires_at) VALUES ($1, $2, $3)`,
+			req.SessionID, req.TokenHash, req.ExpiresAt)
+		return err
+	})
+}
+
+func (s *sessionRepository) RevokeSession(sessionID string) error {
+	_, err := s.db.Exec(`UPDATE auth_sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, sessionID)
+	return err
+}
+
+func (s *sessionRepository) RevokeUserSessions(userID int64) error {
+	_, err := s.db.Exec(`UPDATE auth_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	return err
+}
+
+// IsSessionActive reports false for unknown sessions as well as revoked ones
+func (s *sessionRepository) IsSessionActive(sessionID string) (bool, error) {
+	var active bool
+	err := s.db.QueryRow(`SELECT revoked_at IS NULL FROM auth_sessions WHERE id = $1`, sessionID).Scan(&active)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return active, nil
+}
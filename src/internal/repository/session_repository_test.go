@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const insertRefreshToken = `INSERT INTO refresh_tokens (session_id, token_hash, expires_at) VALUES ($1, $2, $3)`
+
+func TestSessionRepository_CreateSession_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	expires := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO auth_sessions (id, user_id) VALUES ($1, $2)`)).
+		WithArgs("s1", int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertRefreshToken)).
+		WithArgs("s1", "hash", expires).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateSession(model.CreateRefreshTokenRequest{SessionID: "s1", UserID: 7, TokenHash: "hash", ExpiresAt: expires})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_ReadRefreshToken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	cols := []string{"id", "session_id", "user_id", "expires_at", "used_at", "revoked"}
+	expires := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	used := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refresh_tokens rt")).WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(3, "s1", 7, expires, used, false))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refresh_tokens rt")).WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.ReadRefreshToken("hash")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 3 || got.SessionID != "s1" || got.UserID != 7 || got.UsedAt == nil || !got.UsedAt.Equal(used) || got.SessionRevoked {
+		t.Fatalf("unexpected token: %+v", got)
+	}
+
+	got, err = repo.ReadRefreshToken("missing")
+	if err != nil || got != nil {
+		t.Fatalf("expected nil, nil for a missing token, got %+v, %v", got, err)
+	}
+}
+
+func TestSessionRepository_RotateRefreshToken_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	expires := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`)).
+		WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertRefreshToken)).
+		WithArgs("s1", "next", expires).WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectCommit()
+
+	err := repo.RotateRefreshToken(3, model.CreateRefreshTokenRequest{SessionID: "s1", UserID: 7, TokenHash: "next", ExpiresAt: expires})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_RotateRefreshToken_AlreadyUsed(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE refresh_tokens SET used_at = NOW()`)).
+		WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.RotateRefreshToken(3, model.CreateRefreshTokenRequest{SessionID: "s1", TokenHash: "next"})
+	if err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_Revoke(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE auth_sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`)).
+		WithArgs("s1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE auth_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`)).
+		WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := repo.RevokeSession("s1"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repo.RevokeUserSessions(7); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_IsSessionActive(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewSessionRepository(db)
+
+	q := regexp.QuoteMeta(`SELECT revoked_at IS NULL FROM auth_sessions WHERE id = $1`)
+	mock.ExpectQuery(q).WithArgs("live").WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+	mock.ExpectQuery(q).WithArgs("revoked").WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+	mock.ExpectQuery(q).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+	for _, tt := range []struct {
+		id   string
+		want bool
+	}{{"live", true}, {"revoked", false}, {"unknown", false}} {
+		got, err := repo.IsSessionActive(tt.id)
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", tt.id, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: active = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"database/sql"
+	"workoutpal/src/internal/domain/repository"
+)
+
+// querier is what *sql.DB and *sql.Tx have in common, so a repository can
+// run on its own or inside a transaction started by the Transactor
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// inTransaction runs work in a transaction of its own, or in the one q
+// already belongs to
+func inTransaction(q querier, work func(tx querier) error) error {
+	db, ok := q.(*sql.DB)
+	if !ok {
+		return work(q)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := work(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) repository.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(work func(repos repository.TxRepositories) error) error {
+	return inTransaction(t.db, func(tx querier) error {
+		return work(repository.TxRepositories{
+			Exercises:    &exerciseRepository{db: tx},
+			Achievements: &achievementRepository{db: tx},
+			Users:        &userRepository{db: tx},
+			Sessions:     &sessionRepository{db: tx},
+			Posts:        &PostRepository{db: tx},
+			Audit:        &auditRepository{db: tx},
+		})
+	})
+}
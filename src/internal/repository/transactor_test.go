@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func takeDownPost(repos repository.TxRepositories) error {
+	if err := repos.Posts.DeletePost(20); err != nil {
+		return err
+	}
+	_, err := repos.Audit.CreateAuditRecord(model.CreateAuditRecordRequest{
+		ActorID: 1, Action: model.AuditTakeDownPost, TargetType: model.AuditTargetPost, TargetID: 20,
+	})
+	return err
+}
+
+func TestTransactor_CommitsActionWithItsAudit(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM posts WHERE id = $1")).WithArgs(int64(20)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO admin_audit_log")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id", "action", "target_type", "target_id", "details", "created_at"}).
+			AddRow(int64(1), int64(1), model.AuditTakeDownPost, model.AuditTargetPost, int64(20), []byte(`{}`), time.Now()))
+	mock.ExpectCommit()
+
+	if err := NewTransactor(db).WithinTransaction(takeDownPost); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransactor_AuditFailureRollsBackTheAction(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM posts WHERE id = $1")).WithArgs(int64(20)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO admin_audit_log")).WillReturnError(errors.New("db down"))
+	mock.ExpectRollback()
+
+	if err := NewTransactor(db).WithinTransaction(takeDownPost); err == nil {
+		t.Fatal("expected the audit failure")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -17,7 +17,7 @@ import (
 )
 
 type userRepository struct {
-	db *sql.DB
+	db querier
 }
 
 // Custom type for handling BYTEA data in PostgreSQL
@@ -79,6 +79,15 @@ func binaryToDataURL(binaryData []byte) string {
 	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(binaryData)
 }
 
+// avatarURL points at the uploaded avatar, falling back to one stored in the
+// row before avatars became media
+func avatarURL(mediaID sql.NullString, data ByteaData) string {
+	if mediaID.Valid {
+		return model.MediaURL(mediaID.String)
+	}
+	return binaryToDataURL([]byte(data))
+}
+
 func NewUserRepository(db *sql.DB) repository.UserRepository {
 	return &userRepository{db: db}
 }
@@ -86,8 +95,9 @@ func NewUserRepository(db *sql.DB) repository.UserRepository {
 func (u *userRepository) ReadUserByEmail(email string) (*model.User, error) {
 	var user model.User
 	var avatarData ByteaData
-	err := u.db.QueryRow("SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users WHERE email = $1", email).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Password, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &user.IsPrivate, &user.ShowMetricsToFollowers)
+	var avatarMediaID sql.NullString
+	err := u.db.QueryRow("SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users WHERE email = $1", email).Scan(
+		&user.ID, &user.Username, &user.Email, &user.Password, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &avatarMediaID, &user.IsPrivate, &user.ShowMetricsToFollowers)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("user not found")
@@ -95,12 +105,12 @@ func (u *userRepository) ReadUserByEmail(email string) (*model.User, error) {
 		return nil, err
 	}
 	// Convert binary data back to data URL for frontend compatibility
-	user.Avatar = binaryToDataURL([]byte(avatarData))
+	user.Avatar = avatarURL(avatarMediaID, avatarData)
 	return &user, nil
 }
 
 func (u *userRepository) ReadUsers() ([]*model.User, error) {
-	rows, err := u.db.Query("SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users")
+	rows, err := u.db.Query("SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users")
 	if err != nil {
 		return nil, err
 	}
@@ -110,12 +120,13 @@ func (u *userRepository) ReadUsers() ([]*model.User, error) {
 	for rows.Next() {
 		var user model.User
 		var avatarData ByteaData
-		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &user.IsPrivate, &user.ShowMetricsToFollowers)
+		var avatarMediaID sql.NullString
+		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &avatarMediaID, &user.IsPrivate, &user.ShowMetricsToFollowers)
 		if err != nil {
 			return nil, err
 		}
 		// Convert binary data back to data URL for frontend compatibility
-		user.Avatar = binaryToDataURL([]byte(avatarData))
+		user.Avatar = avatarURL(avatarMediaID, avatarData)
 		users = append(users, &user)
 	}
 	return users, nil
@@ -124,8 +135,9 @@ func (u *userRepository) ReadUsers() ([]*model.User, error) {
 func (u *userRepository) ReadUserByID(id int64) (*model.User, error) {
 	var user model.User
 	var avatarData ByteaData
-	err := u.db.QueryRow("SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users WHERE id = $1", id).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &user.IsPrivate, &user.ShowMetricsToFollowers)
+	var avatarMediaID sql.NullString
+	err := u.db.QueryRow("SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users WHERE id = $1", id).Scan(
+		&user.ID, &user.Username, &user.Email, &user.Name, &user.Age, &user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &avatarMediaID, &user.IsPrivate, &user.ShowMetricsToFollowers)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
@@ -133,7 +145,7 @@ func (u *userRepository) ReadUserByID(id int64) (*model.User, error) {
 		return nil, err
 	}
 	// Convert binary data back to data URL for frontend compatibility
-	user.Avatar = binaryToDataURL([]byte(avatarData))
+	user.Avatar = avatarURL(avatarMediaID, avatarData)
 	return &user, nil
 }
 
@@ -158,15 +170,16 @@ func (u *userRepository) CreateUser(request model.CreateUserRequest) (*model.Use
 	}
 	
 	var avatarData ByteaData
+	var avatarMediaID sql.NullString
 	avatarParam := ByteaData(avatarBinary)
 	err := u.db.QueryRow(`
 		INSERT INTO users (username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers) 
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::bytea, COALESCE($11, FALSE), COALESCE($12, FALSE)) 
-		RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers`,
+		RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers`,
 				request.Username, request.Email, request.Password, request.Name, request.Age,
 				request.Height, request.HeightMetric, request.Weight, request.WeightMetric, avatarParam, request.IsPrivate, request.ShowMetricsToFollowers).Scan(
 				&user.ID, &user.Username, &user.Email, &user.Name, &user.Age,
-				&user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &user.IsPrivate, &user.ShowMetricsToFollowers)
+				&user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &avatarMediaID, &user.IsPrivate, &user.ShowMetricsToFollowers)
 
 	if err != nil {
 		var pqErr *pq.Error
@@ -179,7 +192,7 @@ func (u *userRepository) CreateUser(request model.CreateUserRequest) (*model.Use
 	}
 	
 	// Convert binary data back to data URL for response
-	user.Avatar = binaryToDataURL([]byte(avatarData))
+	user.Avatar = avatarURL(avatarMediaID, avatarData)
 	return &user, nil
 }
 
@@ -204,17 +217,18 @@ func (u *userRepository) UpdateUser(request model.UpdateUserRequest) (*model.Use
 	}
 	
 	var avatarData ByteaData
+	var avatarMediaID sql.NullString
 	
 	// Use ByteaData for proper PostgreSQL BYTEA handling
 	avatarParam := ByteaData(avatarBinary)
 	
 	err := u.db.QueryRow(`
-		UPDATE users SET username=$2, email=$3, name=$4, age=$5, height=$6, height_metric=$7, weight=$8, weight_metric=$9, avatar_data=$10::bytea, is_private=$11, show_metrics_to_followers=$12
-		WHERE id=$1 RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers`,
+		UPDATE users SET username=$2, email=$3, name=$4, age=$5, height=$6, height_metric=$7, weight=$8, weight_metric=$9, avatar_data=$10::bytea, avatar_media_id=CASE WHEN $10::bytea IS NULL THEN avatar_media_id END, is_private=$11, show_metrics_to_followers=$12
+		WHERE id=$1 RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers`,
 				request.ID, request.Username, request.Email, request.Name, request.Age,
 				request.Height, request.HeightMetric, request.Weight, request.WeightMetric, avatarParam, request.IsPrivate, request.ShowMetricsToFollowers).Scan(
 				&user.ID, &user.Username, &user.Email, &user.Name, &user.Age,
-				&user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &user.IsPrivate, &user.ShowMetricsToFollowers)
+				&user.Height, &user.HeightMetric, &user.Weight, &user.WeightMetric, &avatarData, &avatarMediaID, &user.IsPrivate, &user.ShowMetricsToFollowers)
 	if err != nil {
 		log.Printf("UpdateUser: SQL query error: %v", err)
 		if errors.Is(err, sql.ErrNoRows) {
@@ -224,10 +238,77 @@ func (u *userRepository) UpdateUser(request model.UpdateUserRequest) (*model.Use
 	}
 	
 	// Convert binary data back to data URL for response
-	user.Avatar = binaryToDataURL([]byte(avatarData))
+	user.Avatar = avatarURL(avatarMediaID, avatarData)
 	return &user, nil
 }
 
+func (u *userRepository) ReadUserRole(id int64) (string, error) {
+	var role sql.NullString
+	err := u.db.QueryRow("SELECT role FROM users WHERE id = $1", id).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.New("user not found")
+		}
+		return "", err
+	}
+	return role.String, nil
+}
+
+const userStandingColumns = "id, COALESCE(role, 'user'), suspended_until, banned_at, COALESCE(moderation_reason, '')"
+
+func scanUserStanding(row Scanner) (*model.UserStanding, error) {
+	var standing model.UserStanding
+	var suspendedUntil, bannedAt sql.NullTime
+	if err := row.Scan(&standing.UserID, &standing.Role, &suspendedUntil, &bannedAt, &standing.Reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+		}
+		return nil, err
+	}
+	if suspendedUntil.Valid {
+		standing.SuspendedUntil = &suspendedUntil.Time
+	}
+	if bannedAt.Valid {
+		standing.BannedAt = &bannedAt.Time
+	}
+	return &standing, nil
+}
+
+func (u *userRepository) ReadUserStanding(id int64) (*model.UserStanding, error) {
+	row := u.db.QueryRow("SELECT "+userStandingColumns+" FROM users WHERE id = $1", id)
+	return scanUserStanding(row)
+}
+
+func (u *userRepository) UpdateUserStanding(request model.UpdateUserStandingRequest) (*model.UserStanding, error) {
+	row := u.db.QueryRow(`
+		UPDATE users SET suspended_until = $2, banned_at = $3, moderation_reason = NULLIF($4, '')
+		WHERE id = $1
+		RETURNING `+userStandingColumns,
+		request.UserID, request.SuspendedUntil, request.BannedAt, request.Reason)
+	return scanUserStanding(row)
+}
+
+func (u *userRepository) UpdateUserRole(id int64, role string) (*model.UserStanding, error) {
+	row := u.db.QueryRow("UPDATE users SET role = $2 WHERE id = $1 RETURNING "+userStandingColumns, id, role)
+	return scanUserStanding(row)
+}
+
+// UpdateAvatarMedia drops any avatar stored in the row the old way
+func (u *userRepository) UpdateAvatarMedia(userID int64, mediaID string) error {
+	result, err := u.db.Exec("UPDATE users SET avatar_media_id = $2, avatar_data = NULL WHERE id = $1", userID, mediaID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 func (u *userRepository) DeleteUser(request model.DeleteUserRequest) error {
 	result, err := u.db.Exec("DELETE FROM users WHERE id = $1", request.ID)
 	if err != nil {
@@ -242,3 +323,57 @@ func (u *userRepository) DeleteUser(request model.DeleteUserRequest) error {
 	}
 	return nil
 }
+
+const googleUserColumns = "id, username, email, name, google_id, provider, is_verified, is_private, show_metrics_to_followers"
+
+func scanGoogleUserRow(row Scanner) (*model.User, error) {
+	var user model.User
+	var name, googleID, provider sql.NullString
+	if err := row.Scan(&user.ID, &user.Username, &user.Email, &name, &googleID, &provider, &user.IsVerified, &user.IsPrivate, &user.ShowMetricsToFollowers); err != nil {
+		return nil, err
+	}
+	user.Name = name.String
+	user.GoogleID = googleID.String
+	user.Provider = provider.String
+	return &user, nil
+}
+
+// ReadUserByGoogleID returns nil, nil when no account is linked to the google id
+func (u *userRepository) ReadUserByGoogleID(googleID string) (*model.User, error) {
+	user, err := scanGoogleUserRow(u.db.QueryRow("SELECT "+googleUserColumns+" FROM users WHERE google_id = $1", googleID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// LinkGoogleAccount attaches a google id to the account registered with email.
+// It returns nil, nil when there is no such account or it is linked to another google id.
+func (u *userRepository) LinkGoogleAccount(email, googleID string) (*model.User, error) {
+	row := u.db.QueryRow(`
+		UPDATE users SET google_id = $2, is_verified = TRUE
+		WHERE email = $1 AND google_id IS NULL
+		RETURNING `+googleUserColumns, email, googleID)
+	user, err := scanGoogleUserRow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// CreateGoogleUser provisions an account without a password, it can only sign in through google
+func (u *userRepository) CreateGoogleUser(request model.CreateGoogleUserRequest) (*model.User, error) {
+	row := u.db.QueryRow(`
+		INSERT INTO users (username, email, password, name, google_id, provider, is_verified)
+		VALUES ($1, $2, '', $3, $4, 'google', TRUE)
+		RETURNING `+googleUserColumns, request.Username, request.Email, request.Name, request.GoogleID)
+	user, err := scanGoogleUserRow(row)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, errors.New("user already exists")
+		}
+		return nil, err
+	}
+	return user, nil
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/lib/pq"
@@ -19,14 +20,14 @@ func TestUserRepository_ReadUserByEmail_OK(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"id", "username", "email", "password", "name", "age",
-		"height", "height_metric", "weight", "weight_metric", "avatar_data", "is_private", "show_metrics_to_followers",
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
 	}).AddRow(
 		1, "max", "a@b.com", "hashed", "Max", 25,
-		180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, false, false, // Sample JPEG binary data
+		180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, nil, false, false, // Sample JPEG binary data
 	)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users WHERE email = $1",
+		"SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users WHERE email = $1",
 	)).WithArgs("a@b.com").WillReturnRows(rows)
 
 	got, err := repo.ReadUserByEmail("a@b.com")
@@ -44,7 +45,7 @@ func TestUserRepository_ReadUserByEmail_NotFound(t *testing.T) {
 	repo := NewUserRepository(db)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users WHERE email = $1",
+		"SELECT id, username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users WHERE email = $1",
 	)).WithArgs("x@y.com").WillReturnError(sql.ErrNoRows)
 
 	got, err := repo.ReadUserByEmail("x@y.com")
@@ -78,12 +79,12 @@ func TestUserRepository_ReadUsers_OK_IncludingNullAvatar(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"id", "username", "email", "name", "age",
-		"height", "height_metric", "weight", "weight_metric", "avatar_data", "is_private", "show_metrics_to_followers",
-	}).AddRow(1, "max", "a@b.com", "Max", 25, 180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, false, false).
-		AddRow(2, "sam", "c@d.com", "Sam", 30, 175, "cm", 70.0, "kg", nil, false, false)
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
+	}).AddRow(1, "max", "a@b.com", "Max", 25, 180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, nil, false, false).
+		AddRow(2, "sam", "c@d.com", "Sam", 30, 175, "cm", 70.0, "kg", nil, nil, false, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users",
+		"SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users",
 	)).WillReturnRows(rows)
 
 	got, err := repo.ReadUsers()
@@ -135,11 +136,11 @@ func TestUserRepository_ReadUserByID_OK(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"id", "username", "email", "name", "age",
-		"height", "height_metric", "weight", "weight_metric", "avatar_data", "is_private", "show_metrics_to_followers",
-	}).AddRow(7, "max", "a@b.com", "Max", 25, 180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, false, false)
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
+	}).AddRow(7, "max", "a@b.com", "Max", 25, 180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, nil, false, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers FROM users WHERE id = $1",
+		"SELECT id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers FROM users WHERE id = $1",
 	)).WithArgs(int64(7)).WillReturnRows(rows)
 
 	got, err := repo.ReadUserByID(7)
@@ -148,6 +149,43 @@ func TestUserRepository_ReadUserByID_OK(t *testing.T) {
 	}
 }
 
+func TestUserRepository_ReadUserByID_UploadedAvatarWins(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "username", "email", "name", "age",
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
+	}).AddRow(7, "max", "a@b.com", "Max", 25, 180, "cm", 75.0, "kg", []byte{255, 216, 255, 224}, "abc123", false, false)
+	mock.ExpectQuery("SELECT id, username, email, name").WithArgs(int64(7)).WillReturnRows(rows)
+
+	got, err := repo.ReadUserByID(7)
+	if err != nil || got.Avatar != "/media/abc123" {
+		t.Fatalf("avatar = %q, err=%v; want /media/abc123", got.Avatar, err)
+	}
+}
+
+func TestUserRepository_UpdateAvatarMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	query := regexp.QuoteMeta("UPDATE users SET avatar_media_id = $2, avatar_data = NULL WHERE id = $1")
+	mock.ExpectExec(query).WithArgs(int64(7), "abc123").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(int64(99), "abc123").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.UpdateAvatarMedia(7, "abc123"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repo.UpdateAvatarMedia(99, "abc123"); err == nil || err.Error() != "user not found" {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
 func TestUserRepository_ReadUserByID_NotFound(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -178,6 +216,57 @@ func TestUserRepository_ReadUserByID_DBError(t *testing.T) {
 	}
 }
 
+func TestUserRepository_ReadUserRole_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT role FROM users WHERE id = $1")).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("admin"))
+
+	role, err := repo.ReadUserRole(3)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if role != "admin" {
+		t.Fatalf("role = %q, want admin", role)
+	}
+}
+
+func TestUserRepository_ReadUserRole_NullRole(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT role FROM users WHERE id = $1")).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(nil))
+
+	role, err := repo.ReadUserRole(3)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if role != "" {
+		t.Fatalf("role = %q, want empty", role)
+	}
+}
+
+func TestUserRepository_ReadUserRole_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT role FROM users WHERE id = $1")).
+		WithArgs(int64(99)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.ReadUserRole(99)
+	if err == nil || err.Error() != "user not found" {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+}
+
 func TestUserRepository_CreateUser_OK(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -198,14 +287,14 @@ func TestUserRepository_CreateUser_OK(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"id", "username", "email", "name", "age",
-		"height", "height_metric", "weight", "weight_metric", "avatar_data", "is_private", "show_metrics_to_followers",
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
 	}).AddRow(1, req.Username, req.Email, req.Name, req.Age,
-		req.Height, req.HeightMetric, req.Weight, req.WeightMetric, []byte{226, 152, 160, 226}, false, false)
+		req.Height, req.HeightMetric, req.Weight, req.WeightMetric, []byte{226, 152, 160, 226}, nil, false, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
 			INSERT INTO users (username, email, password, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers) 
 			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::bytea, COALESCE($11, FALSE), COALESCE($12, FALSE)) 
-			RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers`)).
+			RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers`)).
 	WithArgs(req.Username, req.Email, req.Password, req.Name, req.Age, req.Height, req.HeightMetric, req.Weight, req.WeightMetric, []byte{226, 152, 160, 226}, req.IsPrivate, req.ShowMetricsToFollowers).
 		WillReturnRows(rows)
 
@@ -265,13 +354,13 @@ func TestUserRepository_UpdateUser_OK(t *testing.T) {
 	
 	rows := sqlmock.NewRows([]string{
 		"id", "username", "email", "name", "age",
-		"height", "height_metric", "weight", "weight_metric", "avatar_data", "is_private", "show_metrics_to_followers",
+		"height", "height_metric", "weight", "weight_metric", "avatar_data", "avatar_media_id", "is_private", "show_metrics_to_followers",
 	}).AddRow(req.ID, req.Username, req.Email, req.Name, req.Age,
-		req.Height, req.HeightMetric, req.Weight, req.WeightMetric, expectedBinary, false, false)
+		req.Height, req.HeightMetric, req.Weight, req.WeightMetric, expectedBinary, nil, false, false)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-			UPDATE users SET username=$2, email=$3, name=$4, age=$5, height=$6, height_metric=$7, weight=$8, weight_metric=$9, avatar_data=$10::bytea, is_private=$11, show_metrics_to_followers=$12
-			WHERE id=$1 RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, is_private, show_metrics_to_followers`)).
+			UPDATE users SET username=$2, email=$3, name=$4, age=$5, height=$6, height_metric=$7, weight=$8, weight_metric=$9, avatar_data=$10::bytea, avatar_media_id=CASE WHEN $10::bytea IS NULL THEN avatar_media_id END, is_private=$11, show_metrics_to_followers=$12
+			WHERE id=$1 RETURNING id, username, email, name, age, height, height_metric, weight, weight_metric, avatar_data, avatar_media_id, is_private, show_metrics_to_followers`)).
 	WithArgs(req.ID, req.Username, req.Email, req.Name, req.Age, req.Height, req.HeightMetric, req.Weight, req.WeightMetric, expectedBinary, req.IsPrivate, req.ShowMetricsToFollowers).
 		WillReturnRows(rows)
 
@@ -357,3 +446,146 @@ func TestUserRepository_DeleteUser_DBError(t *testing.T) {
 		t.Fatalf("expected delete fail, got %v", err)
 	}
 }
+
+var googleUserCols = []string{"id", "username", "email", "name", "google_id", "provider", "is_verified", "is_private", "show_metrics_to_followers"}
+
+func TestUserRepository_ReadUserByGoogleID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	q := regexp.QuoteMeta("SELECT " + googleUserColumns + " FROM users WHERE google_id = $1")
+	mock.ExpectQuery(q).WithArgs("g-1").
+		WillReturnRows(sqlmock.NewRows(googleUserCols).AddRow(4, "ada", "ada@example.com", "Ada", "g-1", "local", true, false, false))
+	mock.ExpectQuery(q).WithArgs("g-2").WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.ReadUserByGoogleID("g-1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 4 || got.GoogleID != "g-1" || got.Provider != "local" || !got.IsVerified {
+		t.Fatalf("unexpected user: %#v", got)
+	}
+
+	got, err = repo.ReadUserByGoogleID("g-2")
+	if err != nil || got != nil {
+		t.Fatalf("expected nil, nil for an unlinked google id, got %#v, %v", got, err)
+	}
+}
+
+func TestUserRepository_LinkGoogleAccount(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	q := regexp.QuoteMeta("UPDATE users SET google_id = $2, is_verified = TRUE")
+	mock.ExpectQuery(q).WithArgs("ada@example.com", "g-1").
+		WillReturnRows(sqlmock.NewRows(googleUserCols).AddRow(4, "ada", "ada@example.com", "Ada", "g-1", "local", true, false, false))
+	mock.ExpectQuery(q).WithArgs("nobody@example.com", "g-2").WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.LinkGoogleAccount("ada@example.com", "g-1")
+	if err != nil || got == nil || got.GoogleID != "g-1" {
+		t.Fatalf("unexpected result: %#v, %v", got, err)
+	}
+
+	got, err = repo.LinkGoogleAccount("nobody@example.com", "g-2")
+	if err != nil || got != nil {
+		t.Fatalf("expected nil, nil without a linkable account, got %#v, %v", got, err)
+	}
+}
+
+func TestUserRepository_CreateGoogleUser(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	q := regexp.QuoteMeta("INSERT INTO users (username, email, password, name, google_id, provider, is_verified)")
+	req := model.CreateGoogleUserRequest{Username: "ada", Email: "ada@example.com", Name: "Ada", GoogleID: "g-1"}
+	mock.ExpectQuery(q).WithArgs("ada", "ada@example.com", "Ada", "g-1").
+		WillReturnRows(sqlmock.NewRows(googleUserCols).AddRow(9, "ada", "ada@example.com", "Ada", "g-1", "google", true, false, false))
+	mock.ExpectQuery(q).WithArgs("ada", "ada@example.com", "Ada", "g-1").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	got, err := repo.CreateGoogleUser(req)
+	if err != nil || got.ID != 9 || got.Provider != "google" {
+		t.Fatalf("unexpected result: %#v, %v", got, err)
+	}
+
+	if _, err := repo.CreateGoogleUser(req); err == nil || err.Error() != "user already exists" {
+		t.Fatalf("expected 'user already exists', got %v", err)
+	}
+}
+
+func standingCols() []string {
+	return []string{"id", "role", "suspended_until", "banned_at", "moderation_reason"}
+}
+
+func TestUserRepository_ReadUserStanding(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, COALESCE(role, 'user'), suspended_until, banned_at, COALESCE(moderation_reason, '') FROM users WHERE id = $1")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows(standingCols()).AddRow(int64(5), "user", until, nil, "spam"))
+
+	standing, err := repo.ReadUserStanding(5)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if standing.SuspendedUntil == nil || !standing.SuspendedUntil.Equal(until) || standing.BannedAt != nil || standing.Reason != "spam" {
+		t.Fatalf("unexpected standing: %+v", standing)
+	}
+}
+
+func TestUserRepository_ReadUserStanding_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE id = $1")).
+		WithArgs(int64(9)).
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.ReadUserStanding(9); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestUserRepository_UpdateUserStanding(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	banned := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET suspended_until = $2, banned_at = $3, moderation_reason = NULLIF($4, '')")).
+		WithArgs(int64(5), nil, &banned, "abuse").
+		WillReturnRows(sqlmock.NewRows(standingCols()).AddRow(int64(5), "user", nil, banned, "abuse"))
+
+	standing, err := repo.UpdateUserStanding(model.UpdateUserStandingRequest{UserID: 5, BannedAt: &banned, Reason: "abuse"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if standing.BannedAt == nil || standing.SuspendedUntil != nil {
+		t.Fatalf("unexpected standing: %+v", standing)
+	}
+}
+
+func TestUserRepository_UpdateUserRole(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET role = $2 WHERE id = $1")).
+		WithArgs(int64(5), "moderator").
+		WillReturnRows(sqlmock.NewRows(standingCols()).AddRow(int64(5), "moderator", nil, nil, ""))
+
+	standing, err := repo.UpdateUserRole(5, "moderator")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if standing.Role != "moderator" {
+		t.Fatalf("unexpected standing: %+v", standing)
+	}
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+)
+
+type workoutSessionRepository struct {
+	db *sql.DB
+}
+
+func NewWorkoutSessionRepository(db *sql.DB) repository.WorkoutSessionRepository {
+	return &workoutSessionRepository{db: db}
+}
+
+func scanWorkoutSessionRow(row Scanner) (*model.WorkoutSession, error) {
+	var ses model.WorkoutSession
+	var routineID sql.NullInt64
+	var name, notes, finishedAt sql.NullString
+	if err := row.Scan(
+		&ses.ID,
+		&ses.UserID,
+		&routineID,
+		&name,
+		&notes,
+		&ses.StartedAt,
+		&finishedAt,
+	); err != nil {
+		return nil, err
+	}
+	ses.RoutineID = routineID.Int64
+	ses.Name = name.String
+	ses.Notes = notes.String
+	ses.FinishedAt = finishedAt.String
+	ses.Status = "active"
+	if finishedAt.Valid {
+		ses.Status = "finished"
+	}
+	return &ses, nil
+}
+
+func scanWorkoutSetRow(row Scanner) (*model.WorkoutSet, error) {
+	var set model.WorkoutSet
+	var rpe sql.NullFloat64
+	if err := row.Scan(
+		&set.ID,
+		&set.SessionID,
+		&set.ExerciseID,
+		&set.SetNumber,
+		&set.Weight,
+		&set.Reps,
+		&rpe,
+		&set.RestSeconds,
+		&set.LoggedAt,
+	); err != nil {
+		return nil, err
+	}
+	set.RPE = rpe.Float64
+	return &set, nil
+}
+
+func (s *workoutSessionRepository) getSetsForSession(ctx context.Context, sessionID int64) ([]*model.WorkoutSet, error) {
+	const q = `
+		SELECT id, session_id, exercise_id, set_number, weight, reps, rpe, rest_seconds, logged_at
+		FROM workout_sets
+		WHERE session_id = $1
+		ORDER BY logged_at ASC, id ASC;
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sets = make([]*model.WorkoutSet, 0)
+	for rows.Next() {
+		set, err := scanWorkoutSetRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+func (s *workoutSessionRepository) hydrateSession(ctx context.Context, base *model.WorkoutSession) (*model.WorkoutSession, error) {
+	sets, err := s.getSetsForSession(ctx, base.ID)
+	if err != nil {
+		return nil, err
+	}
+	base.Sets = sets
+	return base, nil
+}
+
+func (s *workoutSessionRepository) ReadUserSessions(userID int64) ([]*model.WorkoutSession, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT id, user_id, routine_id, name, notes, started_at, finished_at
+		FROM workout_sessions
+		WHERE user_id = $1
+		ORDER BY started_at DESC;
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions = make([]*model.WorkoutSession, 0)
+	for rows.Next() {
+		base, err := scanWorkoutSessionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		full, err := s.hydrateSession(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, full)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *workoutSessionRepository) ReadSessionByID(id int64) (*model.WorkoutSession, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT id, user_id, routine_id, name, notes, started_at, finished_at
+		FROM workout_sessions
+		WHERE id = $1;
+	`
+
+	base, err := scanWorkoutSessionRow(s.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.hydrateSession(ctx, base)
+}
+
+func (s *workoutSessionRepository) ReadActiveSession(userID int64) (*model.WorkoutSession, error) {
+	ctx := context.Background()
+
+	const q = `
+		SELECT id, user_id, routine_id, name, notes, started_at, finished_at
+		FROM workout_sessions
+		WHERE user_id = $1
+		  AND finished_at IS NULL
+		ORDER BY started_at DESC
+		LIMIT 1;
+	`
+
+	base, err := scanWorkoutSessionRow(s.db.QueryRowContext(ctx, q, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.hydrateSession(ctx, base)
+}
+
+func (s *workoutSessionRepository) CreateSession(request model.StartWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	ctx := context.Background()
+
+	const q = `
+		INSERT INTO workout_sessions (user_id, routine_id, name, notes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, routine_id, name, notes, started_at, finished_at;
+	`
+
+	session, err := scanWorkoutSessionRow(s.db.QueryRowContext(ctx, q,
+		request.UserID,
+		request.RoutineID,
+		request.Name,
+		request.Notes,
+	))
+	if err != nil {
+		return nil, err
+	}
+	session.Sets = make([]*model.WorkoutSet, 0)
+	return session, nil
+}
+
+func (s *workoutSessionRepository) FinishSession(request model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	ctx := context.Background()
+
+	const q = `
+		UPDATE workout_sessions
+		SET finished_at = NOW(),
+		    notes = COALESCE(NULLIF($1, ''), notes)
+		WHERE id = $2
+		  AND user_id = $3
+		  AND finished_at IS NULL
+		RETURNING id, user_id, routine_id, name, notes, started_at, finished_at;
+	`
+
+	base, err := scanWorkoutSessionRow(s.db.QueryRowContext(ctx, q,
+		request.Notes,
+		request.ID,
+		request.UserID,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.hydrateSession(ctx, base)
+}
+
+// ReadCompletedWorkouts lists the finished sessions started in [from, to),
+// oldest first. Session times are stored in UTC.
+func (s *workoutSessionRepository) ReadCompletedWorkouts(userID int64, from, to time.Time) ([]*model.CompletedWorkout, error) {
+	const q = `
+		SELECT id, COALESCE(routine_id, 0), started_at, finished_at
+		FROM workout_sessions
+		WHERE user_id = $1
+		  AND finished_at IS NOT NULL
+		  AND started_at >= $2
+		  AND started_at < $3
+		ORDER BY started_at ASC, id ASC;
+	`
+
+	rows, err := s.db.QueryContext(context.Background(), q, userID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workouts := make([]*model.CompletedWorkout, 0)
+	for rows.Next() {
+		var workout model.CompletedWorkout
+		if err := rows.Scan(&workout.SessionID, &workout.RoutineID, &workout.StartedAt, &workout.FinishedAt); err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, &workout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return workouts, nil
+}
+
+func (s *workoutSessionRepository) CreateSet(request model.LogWorkoutSetRequest) (*model.WorkoutSet, error) {
+	ctx := context.Background()
+
+	// set_number counts per exercise within the session, starting at 1
+	const q = `
+		INSERT INTO workout_sets (session_id, exercise_id, set_number, weight, reps, rpe, rest_seconds)
+		VALUES (
+			$1, $2,
+			(SELECT COALESCE(MAX(set_number), 0) + 1 FROM workout_sets WHERE session_id = $1 AND exercise_id = $2),
+			$3, $4, NULLIF($5::numeric, 0), $6
+		)
+		RETURNING id, session_id, exercise_id, set_number, weight, reps, rpe, rest_seconds, logged_at;
+	`
+
+	return scanWorkoutSetRow(s.db.QueryRowContext(ctx, q,
+		request.SessionID,
+		request.ExerciseID,
+		request.Weight,
+		request.Reps,
+		request.RPE,
+		request.RestSeconds,
+	))
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var workoutSessionCols = []string{"id", "user_id", "routine_id", "name", "notes", "started_at", "finished_at"}
+var workoutSetCols = []string{"id", "session_id", "exercise_id", "set_number", "weight", "reps", "rpe", "rest_seconds", "logged_at"}
+
+const selectSetsForSession = `
+		SELECT id, session_id, exercise_id, set_number, weight, reps, rpe, rest_seconds, logged_at
+		FROM workout_sets
+		WHERE session_id = $1
+		ORDER BY logged_at ASC, id ASC;
+	`
+
+func TestWorkoutSessionRepository_ReadUserSessions_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT id, user_id, routine_id, name, notes, started_at, finished_at
+		FROM workout_sessions
+		WHERE user_id = $1
+		ORDER BY started_at DESC;
+	`)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(workoutSessionCols).
+			AddRow(2, 7, 3, "Push", nil, "2025-01-02T10:00:00Z", nil).
+			AddRow(1, 7, nil, "Legs", "felt good", "2025-01-01T10:00:00Z", "2025-01-01T11:00:00Z"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectSetsForSession)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(workoutSetCols).
+			AddRow(10, 2, 5, 1, 60.5, 8, 7.5, 90, "2025-01-02T10:05:00Z"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectSetsForSession)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(workoutSetCols))
+
+	got, err := repo.ReadUserSessions(7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(got))
+	}
+	if got[0].Status != "active" || got[0].RoutineID != 3 || len(got[0].Sets) != 1 {
+		t.Fatalf("bad first session: %#v", got[0])
+	}
+	if set := got[0].Sets[0]; set.Weight != 60.5 || set.Reps != 8 || set.RPE != 7.5 || set.RestSeconds != 90 {
+		t.Fatalf("bad set: %#v", set)
+	}
+	if got[1].Status != "finished" || got[1].RoutineID != 0 || got[1].Notes != "felt good" || len(got[1].Sets) != 0 {
+		t.Fatalf("bad second session: %#v", got[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestWorkoutSessionRepository_ReadSessionByID_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	mock.ExpectQuery("FROM workout_sessions").
+		WithArgs(int64(99)).
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.ReadSessionByID(99)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected nil, got %#v", got)
+	}
+}
+
+func TestWorkoutSessionRepository_ReadActiveSession_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT id, user_id, routine_id, name, notes, started_at, finished_at
+		FROM workout_sessions
+		WHERE user_id = $1
+		  AND finished_at IS NULL
+		ORDER BY started_at DESC
+		LIMIT 1;
+	`)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(workoutSessionCols).
+			AddRow(4, 7, 3, "Push", nil, "2025-01-02T10:00:00Z", nil))
+	mock.ExpectQuery(regexp.QuoteMeta(selectSetsForSession)).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows(workoutSetCols))
+
+	got, err := repo.ReadActiveSession(7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got == nil || got.ID != 4 || got.Status != "active" {
+		t.Fatalf("unexpected session: %#v", got)
+	}
+}
+
+func TestWorkoutSessionRepository_CreateSession_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	req := model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3, Name: "Push"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		INSERT INTO workout_sessions (user_id, routine_id, name, notes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, routine_id, name, notes, started_at, finished_at;
+	`)).
+		WithArgs(req.UserID, req.RoutineID, req.Name, req.Notes).
+		WillReturnRows(sqlmock.NewRows(workoutSessionCols).
+			AddRow(5, 7, 3, "Push", "", "2025-01-02T10:00:00Z", nil))
+
+	got, err := repo.CreateSession(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 5 || got.Status != "active" || got.Sets == nil {
+		t.Fatalf("unexpected session: %#v", got)
+	}
+}
+
+func TestWorkoutSessionRepository_FinishSession_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	req := model.FinishWorkoutSessionRequest{ID: 5, UserID: 7, Notes: "done"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		UPDATE workout_sessions
+		SET finished_at = NOW(),
+		    notes = COALESCE(NULLIF($1, ''), notes)
+		WHERE id = $2
+		  AND user_id = $3
+		  AND finished_at IS NULL
+		RETURNING id, user_id, routine_id, name, notes, started_at, finished_at;
+	`)).
+		WithArgs(req.Notes, req.ID, req.UserID).
+		WillReturnRows(sqlmock.NewRows(workoutSessionCols).
+			AddRow(5, 7, 3, "Push", "done", "2025-01-02T10:00:00Z", "2025-01-02T11:00:00Z"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectSetsForSession)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows(workoutSetCols))
+
+	got, err := repo.FinishSession(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Status != "finished" || got.FinishedAt == "" || got.Notes != "done" {
+		t.Fatalf("unexpected session: %#v", got)
+	}
+}
+
+func TestWorkoutSessionRepository_FinishSession_NoRows(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	mock.ExpectQuery("UPDATE workout_sessions").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := repo.FinishSession(model.FinishWorkoutSessionRequest{ID: 5, UserID: 7})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestWorkoutSessionRepository_CreateSet_OK(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	req := model.LogWorkoutSetRequest{SessionID: 5, ExerciseID: 2, Weight: 100, Reps: 5, RestSeconds: 120}
+
+	mock.ExpectQuery("INSERT INTO workout_sets").
+		WithArgs(req.SessionID, req.ExerciseID, req.Weight, req.Reps, req.RPE, req.RestSeconds).
+		WillReturnRows(sqlmock.NewRows(workoutSetCols).
+			AddRow(11, 5, 2, 3, 100, 5, nil, 120, "2025-01-02T10:10:00Z"))
+
+	got, err := repo.CreateSet(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 11 || got.SetNumber != 3 || got.RPE != 0 || got.Weight != 100 {
+		t.Fatalf("unexpected set: %#v", got)
+	}
+}
+
+func TestWorkoutSessionRepository_CreateSet_Error(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	mock.ExpectQuery("INSERT INTO workout_sets").
+		WillReturnError(errors.New("fk violation"))
+
+	_, err := repo.CreateSet(model.LogWorkoutSetRequest{SessionID: 5, ExerciseID: 999, Reps: 5})
+	if err == nil || err.Error() != "fk violation" {
+		t.Fatalf("expected fk violation, got %v", err)
+	}
+}
+
+func TestWorkoutSessionRepository_ReadCompletedWorkouts(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := NewWorkoutSessionRepository(db)
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	from := time.Date(2025, 3, 1, 2, 0, 0, 0, loc)
+	to := time.Date(2025, 3, 8, 2, 0, 0, 0, loc)
+	started := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("AND finished_at IS NOT NULL")).
+		WithArgs(int64(5), from.UTC(), to.UTC()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "routine_id", "started_at", "finished_at"}).
+			AddRow(int64(9), int64(0), started, started.Add(time.Hour)))
+
+	workouts, err := repo.ReadCompletedWorkouts(5, from, to)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(workouts) != 1 || workouts[0].SessionID != 9 || !workouts[0].StartedAt.Equal(started) {
+		t.Fatalf("unexpected workouts %+v", workouts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
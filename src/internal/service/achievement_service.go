@@ -1,17 +1,22 @@
 package service
 
 import (
+	"fmt"
+	"log"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 )
 
 type AchievementService struct {
-	repo repository.AchievementRepository
+	repo           repository.AchievementRepository
+	userRepository repository.UserRepository
 }
 
-func NewAchievementService(repo repository.AchievementRepository) service.AchievementService {
-	return &AchievementService{repo: repo}
+func NewAchievementService(repo repository.AchievementRepository, userRepository repository.UserRepository) service.AchievementService {
+	return &AchievementService{repo: repo, userRepository: userRepository}
 }
 
 func (s *AchievementService) ReadAchievementsFeed() ([]*model.UserAchievement, error) {
@@ -26,6 +31,80 @@ func (s *AchievementService) ReadUnlockedAchievements(userID int64) ([]*model.Us
 	return s.repo.ReadUnlockedAchievements(userID)
 }
 
-func (s *AchievementService) CreateAchievement(req model.CreateAchievementRequest) (*model.UserAchievement, error) {
+// CreateAchievement grants an achievement by hand; everything else is awarded by Evaluate
+func (s *AchievementService) CreateAchievement(adminID int64, req model.CreateAchievementRequest) (*model.UserAchievement, error) {
+	role, err := s.userRepository.ReadUserRole(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if role != constants.ROLE_ADMIN {
+		return nil, fmt.Errorf("%w: only admins can grant achievements", util.ErrForbidden)
+	}
 	return s.repo.CreateAchievement(req)
 }
+
+// Evaluate checks the rule based achievements for the given criteria and
+// awards the ones the user now qualifies for
+func (s *AchievementService) Evaluate(userID int64, criteriaTypes ...string) ([]*model.UserAchievement, error) {
+	awarded := make([]*model.UserAchievement, 0)
+	if len(criteriaTypes) == 0 {
+		return awarded, nil
+	}
+
+	candidates, err := s.repo.ReadAchievementsByCriteria(criteriaTypes)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return awarded, nil
+	}
+
+	unlocked, err := s.repo.ReadUnlockedAchievements(userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int64]bool, len(unlocked))
+	for _, u := range unlocked {
+		owned[u.ID] = true
+	}
+
+	progress := make(map[string]int64)
+	for _, a := range candidates {
+		if owned[a.ID] {
+			continue
+		}
+
+		count, ok := progress[a.CriteriaType]
+		if !ok {
+			count, err = s.repo.ReadCriteriaProgress(userID, a.CriteriaType)
+			if err != nil {
+				return nil, err
+			}
+			progress[a.CriteriaType] = count
+		}
+		if count < a.Threshold {
+			continue
+		}
+
+		ua, err := s.repo.AwardAchievement(userID, a.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ua != nil {
+			awarded = append(awarded, ua)
+		}
+	}
+
+	return awarded, nil
+}
+
+// evaluateAchievements runs the rules after a domain event; failures are logged
+// so they never fail the action that triggered them
+func evaluateAchievements(evaluator service.AchievementEvaluator, userID int64, criteriaTypes ...string) {
+	if evaluator == nil {
+		return
+	}
+	if _, err := evaluator.Evaluate(userID, criteriaTypes...); err != nil {
+		log.Printf("achievements for user %d: %v", userID, err)
+	}
+}
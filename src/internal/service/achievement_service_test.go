@@ -6,6 +6,7 @@ import (
 
 	"workoutpal/src/internal/model"
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	"workoutpal/src/util"
 
 	"github.com/golang/mock/gomock"
 )
@@ -15,7 +16,7 @@ func TestAchievementService_ReadAllAchievements_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	svc := NewAchievementService(repo, nil)
 
 	want := []*model.Achievement{
 		{ID: 1, Title: "First Workout"},
@@ -40,7 +41,7 @@ func TestAchievementService_ReadAllAchievements_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	svc := NewAchievementService(repo, nil)
 
 	repo.EXPECT().
 		ReadAllAchievements().
@@ -57,7 +58,7 @@ func TestAchievementService_ReadUnlockedAchievements_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	svc := NewAchievementService(repo, nil)
 
 	want := []*model.UserAchievement{
 		{ID: 10, UserID: 1, Title: "First Workout", EarnedAt: "2025-01-01T00:00:00Z"},
@@ -82,7 +83,7 @@ func TestAchievementService_ReadUnlockedAchievements_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	svc := NewAchievementService(repo, nil)
 
 	repo.EXPECT().
 		ReadUnlockedAchievements(int64(1)).
@@ -99,16 +100,18 @@ func TestAchievementService_CreateAchievement_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	users := mock_repository.NewMockUserRepository(ctrl)
+	svc := NewAchievementService(repo, users)
 
 	req := model.CreateAchievementRequest{UserID: 1, AchievementID: 55}
 	want := &model.UserAchievement{ID: 99, UserID: 1, Title: "First Workout", EarnedAt: "2025-01-01T00:00:00Z"}
 
+	users.EXPECT().ReadUserRole(int64(3)).Return("admin", nil)
 	repo.EXPECT().
 		CreateAchievement(req).
 		Return(want, nil)
 
-	got, err := svc.CreateAchievement(req)
+	got, err := svc.CreateAchievement(3, req)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -122,16 +125,100 @@ func TestAchievementService_CreateAchievement_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockAchievementRepository(ctrl)
-	svc := NewAchievementService(repo)
+	users := mock_repository.NewMockUserRepository(ctrl)
+	svc := NewAchievementService(repo, users)
 
 	req := model.CreateAchievementRequest{UserID: 1, AchievementID: 55}
 
+	users.EXPECT().ReadUserRole(int64(3)).Return("admin", nil)
 	repo.EXPECT().
 		CreateAchievement(req).
 		Return(nil, errors.New("fail"))
 
-	got, err := svc.CreateAchievement(req)
+	got, err := svc.CreateAchievement(3, req)
 	if got != nil || err == nil {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestAchievementService_CreateAchievement_NotAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockAchievementRepository(ctrl)
+	users := mock_repository.NewMockUserRepository(ctrl)
+	svc := NewAchievementService(repo, users)
+
+	users.EXPECT().ReadUserRole(int64(1)).Return("user", nil)
+
+	_, err := svc.CreateAchievement(1, model.CreateAchievementRequest{UserID: 1, AchievementID: 55})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAchievementService_Evaluate_AwardsReachedThresholds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockAchievementRepository(ctrl)
+	svc := NewAchievementService(repo, nil)
+
+	criteria := []string{model.CriteriaFollowing}
+	repo.EXPECT().ReadAchievementsByCriteria(criteria).Return([]*model.Achievement{
+		{ID: 4, CriteriaType: model.CriteriaFollowing, Threshold: 5},
+		{ID: 7, CriteriaType: model.CriteriaFollowing, Threshold: 1},
+		{ID: 8, CriteriaType: model.CriteriaFollowing, Threshold: 50},
+	}, nil)
+	repo.EXPECT().ReadUnlockedAchievements(int64(2)).
+		Return([]*model.UserAchievement{{ID: 7, UserID: 2}}, nil)
+	// progress is read once per criteria type
+	repo.EXPECT().ReadCriteriaProgress(int64(2), model.CriteriaFollowing).Return(int64(5), nil).Times(1)
+	repo.EXPECT().AwardAchievement(int64(2), int64(4)).
+		Return(&model.UserAchievement{ID: 4, UserID: 2, Title: "Hi, Five!"}, nil)
+
+	got, err := svc.Evaluate(2, criteria...)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 4 {
+		t.Fatalf("unexpected awards: %#v", got)
+	}
+}
+
+func TestAchievementService_Evaluate_AlreadyAwardedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockAchievementRepository(ctrl)
+	svc := NewAchievementService(repo, nil)
+
+	repo.EXPECT().ReadAchievementsByCriteria([]string{model.CriteriaPosts}).
+		Return([]*model.Achievement{{ID: 6, CriteriaType: model.CriteriaPosts, Threshold: 1}}, nil)
+	repo.EXPECT().ReadUnlockedAchievements(int64(2)).Return([]*model.UserAchievement{}, nil)
+	repo.EXPECT().ReadCriteriaProgress(int64(2), model.CriteriaPosts).Return(int64(1), nil)
+	repo.EXPECT().AwardAchievement(int64(2), int64(6)).Return(nil, nil)
+
+	got, err := svc.Evaluate(2, model.CriteriaPosts)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected nothing awarded, got %#v", got)
+	}
+}
+
+func TestAchievementService_Evaluate_NoRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockAchievementRepository(ctrl)
+	svc := NewAchievementService(repo, nil)
+
+	repo.EXPECT().ReadAchievementsByCriteria([]string{model.CriteriaSchedules}).Return([]*model.Achievement{}, nil)
+
+	got, err := svc.Evaluate(2, model.CriteriaSchedules)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("unexpected result: %#v, %v", got, err)
+	}
+}
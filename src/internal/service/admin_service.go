@@ -0,0 +1,373 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+)
+
+var userRoles = []string{constants.ROLE_USER, constants.ROLE_MODERATOR, constants.ROLE_ADMIN}
+
+var achievementCriteria = []string{
+	model.CriteriaPosts,
+	model.CriteriaLikesGiven,
+	model.CriteriaFollowers,
+	model.CriteriaFollowing,
+	model.CriteriaRoutines,
+	model.CriteriaSchedules,
+	model.CriteriaScheduledWorkouts,
+	model.CriteriaWorkoutStreak,
+}
+
+var auditTargets = []string{
+	model.AuditTargetExercise,
+	model.AuditTargetAchievement,
+	model.AuditTargetUser,
+	model.AuditTargetPost,
+}
+
+// adminService writes every action together with its audit record through the
+// transactor, so the log holds exactly the actions that took effect
+type adminService struct {
+	userRepository  repository.UserRepository
+	postRepository  repository.PostRepository
+	auditRepository repository.AuditRepository
+	transactor      repository.Transactor
+	now             func() time.Time
+}
+
+func NewAdminService(userRepository repository.UserRepository, postRepository repository.PostRepository, auditRepository repository.AuditRepository, transactor repository.Transactor) service.AdminService {
+	return &adminService{
+		userRepository:  userRepository,
+		postRepository:  postRepository,
+		auditRepository: auditRepository,
+		transactor:      transactor,
+		now:             time.Now,
+	}
+}
+
+func (s *adminService) CreateCatalogueExercise(actorID int64, req model.CreateExerciseRequest) (*model.Exercise, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", util.ErrInvalidInput)
+	}
+	var exercise *model.Exercise
+	err := s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if exercise, err = repos.Exercises.CreateCatalogueExercise(req); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditCreateExercise, model.AuditTargetExercise, exercise.ID, map[string]any{"name": exercise.Name})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exercise, nil
+}
+
+func (s *adminService) UpdateCatalogueExercise(actorID int64, req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", util.ErrInvalidInput)
+	}
+	var exercise *model.Exercise
+	err := s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if exercise, err = repos.Exercises.UpdateCatalogueExercise(req); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditUpdateExercise, model.AuditTargetExercise, exercise.ID, map[string]any{"name": exercise.Name})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exercise, nil
+}
+
+func (s *adminService) DeleteCatalogueExercise(actorID int64, id int64) error {
+	return s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		if err := repos.Exercises.DeleteCatalogueExercise(id); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditDeleteExercise, model.AuditTargetExercise, id, nil)
+	})
+}
+
+func (s *adminService) CreateAchievement(actorID int64, req model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	req, err := normalizeAchievementDefinition(req)
+	if err != nil {
+		return nil, err
+	}
+	var achievement *model.Achievement
+	err = s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if achievement, err = repos.Achievements.CreateAchievementDefinition(req); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditCreateAchievement, model.AuditTargetAchievement, achievement.ID, achievementDetails(req))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return achievement, nil
+}
+
+func (s *adminService) UpdateAchievement(actorID int64, req model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	req, err := normalizeAchievementDefinition(req)
+	if err != nil {
+		return nil, err
+	}
+	var achievement *model.Achievement
+	err = s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if achievement, err = repos.Achievements.UpdateAchievementDefinition(req); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditUpdateAchievement, model.AuditTargetAchievement, achievement.ID, achievementDetails(req))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return achievement, nil
+}
+
+func (s *adminService) DeleteAchievement(actorID int64, id int64) error {
+	return s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		if err := repos.Achievements.DeleteAchievementDefinition(id); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditDeleteAchievement, model.AuditTargetAchievement, id, nil)
+	})
+}
+
+func (s *adminService) SuspendUser(actorID int64, req model.SuspendUserRequest) (*model.UserStanding, error) {
+	if !req.Until.After(s.now()) {
+		return nil, fmt.Errorf("%w: until must be in the future", util.ErrInvalidInput)
+	}
+	standing, err := s.readModeratableUser(actorID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	until := req.Until.UTC()
+	err = s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		standing, err = repos.Users.UpdateUserStanding(model.UpdateUserStandingRequest{
+			UserID:         req.UserID,
+			SuspendedUntil: &until,
+			BannedAt:       standing.BannedAt,
+			Reason:         req.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		if err := repos.Sessions.RevokeUserSessions(req.UserID); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditSuspendUser, model.AuditTargetUser, req.UserID,
+			map[string]any{"until": until.Format(time.RFC3339), "reason": req.Reason})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standing, nil
+}
+
+func (s *adminService) BanUser(actorID int64, req model.BanUserRequest) (*model.UserStanding, error) {
+	if _, err := s.readModeratableUser(actorID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	bannedAt := s.now().UTC()
+	var standing *model.UserStanding
+	err := s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		standing, err = repos.Users.UpdateUserStanding(model.UpdateUserStandingRequest{
+			UserID:   req.UserID,
+			BannedAt: &bannedAt,
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		if err := repos.Sessions.RevokeUserSessions(req.UserID); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditBanUser, model.AuditTargetUser, req.UserID, map[string]any{"reason": req.Reason})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standing, nil
+}
+
+// ReinstateUser lifts both suspensions and bans
+func (s *adminService) ReinstateUser(actorID int64, userID int64) (*model.UserStanding, error) {
+	var standing *model.UserStanding
+	err := s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if standing, err = repos.Users.UpdateUserStanding(model.UpdateUserStandingRequest{UserID: userID}); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditReinstateUser, model.AuditTargetUser, userID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standing, nil
+}
+
+// UpdateUserRole signs the user out everywhere, so no token keeps carrying the old role
+func (s *adminService) UpdateUserRole(actorID int64, req model.UpdateUserRoleRequest) (*model.UserStanding, error) {
+	if !slices.Contains(userRoles, req.Role) {
+		return nil, fmt.Errorf("%w: role must be one of %s", util.ErrInvalidInput, strings.Join(userRoles, ", "))
+	}
+	// keeps the last admin from demoting themselves
+	if actorID == req.UserID {
+		return nil, fmt.Errorf("%w: you cannot change your own role", util.ErrInvalidInput)
+	}
+
+	previous, err := s.userRepository.ReadUserStanding(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var standing *model.UserStanding
+	err = s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		var err error
+		if standing, err = repos.Users.UpdateUserRole(req.UserID, req.Role); err != nil {
+			return err
+		}
+		if err := repos.Sessions.RevokeUserSessions(req.UserID); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditChangeRole, model.AuditTargetUser, req.UserID,
+			map[string]any{"from": previous.Role, "to": req.Role})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standing, nil
+}
+
+func (s *adminService) TakeDownPost(actorID int64, req model.TakeDownPostRequest) error {
+	ownerID, err := s.postRepository.ReadPostOwnerID(req.PostID)
+	if err != nil {
+		return err
+	}
+	return s.transactor.WithinTransaction(func(repos repository.TxRepositories) error {
+		if err := repos.Posts.DeletePost(req.PostID); err != nil {
+			return err
+		}
+		return audit(repos, actorID, model.AuditTakeDownPost, model.AuditTargetPost, req.PostID,
+			map[string]any{"ownerId": ownerID, "reason": req.Reason})
+	})
+}
+
+func (s *adminService) ReadAuditLog(req model.ReadAuditLogRequest) (*model.AuditLogPage, error) {
+	filter := model.AuditFilter{TargetType: req.TargetType, TargetID: req.TargetID, Limit: req.Limit}
+	if filter.TargetType != "" && !slices.Contains(auditTargets, filter.TargetType) {
+		return nil, fmt.Errorf("%w: targetType must be one of %s", util.ErrInvalidInput, strings.Join(auditTargets, ", "))
+	}
+	switch {
+	case filter.Limit < 0:
+		return nil, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case filter.Limit == 0:
+		filter.Limit = model.DefaultAuditPageSize
+	case filter.Limit > model.MaxAuditPageSize:
+		filter.Limit = model.MaxAuditPageSize
+	}
+	if req.Cursor != "" {
+		beforeID, err := strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return nil, fmt.Errorf("%w: invalid cursor", util.ErrInvalidInput)
+		}
+		filter.BeforeID = beforeID
+	}
+
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	records, err := s.auditRepository.ReadAuditRecords(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.AuditLogPage{Records: records}
+	if len(records) > limit {
+		page.Records = records[:limit]
+		page.NextCursor = strconv.FormatInt(page.Records[limit-1].ID, 10)
+	}
+	return page, nil
+}
+
+// readModeratableUser refuses to suspend or ban the actor themselves or an
+// admin, and leaves moderators to admins
+func (s *adminService) readModeratableUser(actorID, userID int64) (*model.UserStanding, error) {
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: you cannot moderate yourself", util.ErrInvalidInput)
+	}
+	standing, err := s.userRepository.ReadUserStanding(userID)
+	if err != nil {
+		return nil, err
+	}
+	switch standing.Role {
+	case constants.ROLE_ADMIN:
+		return nil, fmt.Errorf("%w: admins cannot be suspended or banned", util.ErrForbidden)
+	case constants.ROLE_MODERATOR:
+		role, err := s.userRepository.ReadUserRole(actorID)
+		if err != nil {
+			return nil, err
+		}
+		if role != constants.ROLE_ADMIN {
+			return nil, fmt.Errorf("%w: only admins can suspend or ban a moderator", util.ErrForbidden)
+		}
+	}
+	return standing, nil
+}
+
+// audit records an action in the transaction that applies it, so a failed
+// write undoes the action too
+func audit(repos repository.TxRepositories, actorID int64, action, targetType string, targetID int64, details map[string]any) error {
+	_, err := repos.Audit.CreateAuditRecord(model.CreateAuditRecordRequest{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	})
+	return err
+}
+
+// normalizeAchievementDefinition trims the text fields, names the achievement
+// after its title when no name is given and checks its unlock rule
+func normalizeAchievementDefinition(req model.AchievementDefinitionRequest) (model.AchievementDefinitionRequest, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Name = strings.TrimSpace(req.Name)
+	req.CriteriaType = strings.TrimSpace(req.CriteriaType)
+	if req.Title == "" {
+		return req, fmt.Errorf("%w: title is required", util.ErrInvalidInput)
+	}
+	if req.Name == "" {
+		req.Name = strings.ReplaceAll(req.Title, " ", "_")
+	}
+	if req.CriteriaType != "" && !slices.Contains(achievementCriteria, req.CriteriaType) {
+		return req, fmt.Errorf("%w: criteriaType must be one of %s", util.ErrInvalidInput, strings.Join(achievementCriteria, ", "))
+	}
+	switch {
+	case req.Threshold < 0:
+		return req, fmt.Errorf("%w: threshold must be positive", util.ErrInvalidInput)
+	case req.Threshold == 0:
+		req.Threshold = 1
+	}
+	return req, nil
+}
+
+func achievementDetails(req model.AchievementDefinitionRequest) map[string]any {
+	return map[string]any{"title": req.Title, "criteriaType": req.CriteriaType, "threshold": req.Threshold}
+}
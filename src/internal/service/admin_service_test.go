@@ -0,0 +1,295 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
+
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+
+	"github.com/golang/mock/gomock"
+)
+
+type adminServiceMocks struct {
+	exercises    *mock_repository.MockExerciseRepository
+	achievements *mock_repository.MockAchievementRepository
+	users        *mock_repository.MockUserRepository
+	sessions     *mock_repository.MockSessionRepository
+	posts        *mock_repository.MockPostRepository
+	audit        *mock_repository.MockAuditRepository
+}
+
+var adminNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newAdminServiceMocks(t *testing.T) (adminServiceMocks, *adminService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := adminServiceMocks{
+		exercises:    mock_repository.NewMockExerciseRepository(ctrl),
+		achievements: mock_repository.NewMockAchievementRepository(ctrl),
+		users:        mock_repository.NewMockUserRepository(ctrl),
+		sessions:     mock_repository.NewMockSessionRepository(ctrl),
+		posts:        mock_repository.NewMockPostRepository(ctrl),
+		audit:        mock_repository.NewMockAuditRepository(ctrl),
+	}
+	// the transaction hands the action the same mocks; rolling back is the transactor's job
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).AnyTimes().DoAndReturn(func(work func(repository.TxRepositories) error) error {
+		return work(repository.TxRepositories{
+			Exercises:    m.exercises,
+			Achievements: m.achievements,
+			Users:        m.users,
+			Sessions:     m.sessions,
+			Posts:        m.posts,
+			Audit:        m.audit,
+		})
+	})
+	svc := NewAdminService(m.users, m.posts, m.audit, transactor).(*adminService)
+	svc.now = func() time.Time { return adminNow }
+	return m, svc
+}
+
+// expectAudit captures the audit record written for the action under test
+func (m adminServiceMocks) expectAudit(got *model.CreateAuditRecordRequest) {
+	m.audit.EXPECT().CreateAuditRecord(gomock.Any()).DoAndReturn(func(req model.CreateAuditRecordRequest) (*model.AuditRecord, error) {
+		*got = req
+		return &model.AuditRecord{ID: 1}, nil
+	})
+}
+
+func TestAdminService_CreateCatalogueExercise(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.exercises.EXPECT().CreateCatalogueExercise(model.CreateExerciseRequest{Name: "Lunge"}).
+		Return(&model.Exercise{ID: 9, Name: "Lunge"}, nil)
+	var audit model.CreateAuditRecordRequest
+	m.expectAudit(&audit)
+
+	got, err := svc.CreateCatalogueExercise(1, model.CreateExerciseRequest{Name: "  Lunge "})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 9 {
+		t.Fatalf("unexpected exercise: %+v", got)
+	}
+	if audit.ActorID != 1 || audit.Action != model.AuditCreateExercise || audit.TargetType != model.AuditTargetExercise || audit.TargetID != 9 {
+		t.Fatalf("unexpected audit record: %+v", audit)
+	}
+}
+
+func TestAdminService_CreateCatalogueExercise_NameRequired(t *testing.T) {
+	_, svc := newAdminServiceMocks(t)
+
+	if _, err := svc.CreateCatalogueExercise(1, model.CreateExerciseRequest{Name: " "}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestAdminService_DeleteCatalogueExercise_AuditFailure(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.exercises.EXPECT().DeleteCatalogueExercise(int64(9)).Return(nil)
+	m.audit.EXPECT().CreateAuditRecord(gomock.Any()).Return(nil, errors.New("db down"))
+
+	if err := svc.DeleteCatalogueExercise(1, 9); err == nil {
+		t.Fatal("expected the failed audit write to be reported")
+	}
+}
+
+func TestNormalizeAchievementDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     model.AchievementDefinitionRequest
+		want    model.AchievementDefinitionRequest
+		invalid bool
+	}{
+		{
+			name: "manual achievement",
+			req:  model.AchievementDefinitionRequest{Title: " Beta Tester "},
+			want: model.AchievementDefinitionRequest{Name: "Beta_Tester", Title: "Beta Tester", Threshold: 1},
+		},
+		{
+			name: "rule",
+			req:  model.AchievementDefinitionRequest{Name: "20_Posts", Title: "Prolific", CriteriaType: model.CriteriaPosts, Threshold: 20},
+			want: model.AchievementDefinitionRequest{Name: "20_Posts", Title: "Prolific", CriteriaType: model.CriteriaPosts, Threshold: 20},
+		},
+		{name: "no title", req: model.AchievementDefinitionRequest{Name: "x"}, invalid: true},
+		{name: "unknown criteria", req: model.AchievementDefinitionRequest{Title: "x", CriteriaType: "steps"}, invalid: true},
+		{name: "negative threshold", req: model.AchievementDefinitionRequest{Title: "x", Threshold: -1}, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeAchievementDefinition(tt.req)
+			if tt.invalid {
+				if !errors.Is(err, util.ErrInvalidInput) {
+					t.Fatalf("expected ErrInvalidInput, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminService_SuspendUser(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	until := adminNow.Add(24 * time.Hour)
+	m.users.EXPECT().ReadUserStanding(int64(5)).Return(&model.UserStanding{UserID: 5, Role: constants.ROLE_USER}, nil)
+	m.users.EXPECT().UpdateUserStanding(model.UpdateUserStandingRequest{UserID: 5, SuspendedUntil: &until, Reason: "spam"}).
+		Return(&model.UserStanding{UserID: 5, SuspendedUntil: &until, Reason: "spam"}, nil)
+	m.sessions.EXPECT().RevokeUserSessions(int64(5)).Return(nil)
+	var audit model.CreateAuditRecordRequest
+	m.expectAudit(&audit)
+
+	got, err := svc.SuspendUser(2, model.SuspendUserRequest{UserID: 5, Until: until, Reason: "spam"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.SuspendedUntil == nil || !got.SuspendedUntil.Equal(until) {
+		t.Fatalf("unexpected standing: %+v", got)
+	}
+	if audit.Action != model.AuditSuspendUser || audit.TargetID != 5 || audit.Details["reason"] != "spam" {
+		t.Fatalf("unexpected audit record: %+v", audit)
+	}
+}
+
+func TestAdminService_SuspendUser_Refused(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   int64
+		until     time.Time
+		role      string // empty skips the standing lookup
+		actorRole string // empty skips the actor's role lookup
+		want      error
+	}{
+		{name: "past date", actorID: 2, until: adminNow, want: util.ErrInvalidInput},
+		{name: "self", actorID: 5, until: adminNow.Add(time.Hour), want: util.ErrInvalidInput},
+		{name: "admin", actorID: 2, until: adminNow.Add(time.Hour), role: constants.ROLE_ADMIN, want: util.ErrForbidden},
+		{name: "moderator by a moderator", actorID: 2, until: adminNow.Add(time.Hour), role: constants.ROLE_MODERATOR, actorRole: constants.ROLE_MODERATOR, want: util.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := newAdminServiceMocks(t)
+			if tt.role != "" {
+				m.users.EXPECT().ReadUserStanding(int64(5)).Return(&model.UserStanding{UserID: 5, Role: tt.role}, nil)
+			}
+			if tt.actorRole != "" {
+				m.users.EXPECT().ReadUserRole(tt.actorID).Return(tt.actorRole, nil)
+			}
+
+			_, err := svc.SuspendUser(tt.actorID, model.SuspendUserRequest{UserID: 5, Until: tt.until})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAdminService_BanUser(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.users.EXPECT().ReadUserStanding(int64(5)).Return(&model.UserStanding{UserID: 5, Role: constants.ROLE_MODERATOR}, nil)
+	m.users.EXPECT().ReadUserRole(int64(1)).Return(constants.ROLE_ADMIN, nil)
+	m.users.EXPECT().UpdateUserStanding(gomock.Any()).DoAndReturn(func(req model.UpdateUserStandingRequest) (*model.UserStanding, error) {
+		if req.BannedAt == nil || !req.BannedAt.Equal(adminNow) || req.SuspendedUntil != nil {
+			t.Fatalf("unexpected standing update: %+v", req)
+		}
+		return &model.UserStanding{UserID: 5, BannedAt: req.BannedAt}, nil
+	})
+	m.sessions.EXPECT().RevokeUserSessions(int64(5)).Return(nil)
+	var audit model.CreateAuditRecordRequest
+	m.expectAudit(&audit)
+
+	if _, err := svc.BanUser(1, model.BanUserRequest{UserID: 5, Reason: "abuse"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if audit.Action != model.AuditBanUser || audit.TargetID != 5 {
+		t.Fatalf("unexpected audit record: %+v", audit)
+	}
+}
+
+func TestAdminService_UpdateUserRole(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.users.EXPECT().ReadUserStanding(int64(5)).Return(&model.UserStanding{UserID: 5, Role: constants.ROLE_USER}, nil)
+	m.users.EXPECT().UpdateUserRole(int64(5), constants.ROLE_MODERATOR).
+		Return(&model.UserStanding{UserID: 5, Role: constants.ROLE_MODERATOR}, nil)
+	m.sessions.EXPECT().RevokeUserSessions(int64(5)).Return(nil)
+	var audit model.CreateAuditRecordRequest
+	m.expectAudit(&audit)
+
+	got, err := svc.UpdateUserRole(1, model.UpdateUserRoleRequest{UserID: 5, Role: constants.ROLE_MODERATOR})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Role != constants.ROLE_MODERATOR {
+		t.Fatalf("unexpected standing: %+v", got)
+	}
+	if audit.Details["from"] != constants.ROLE_USER || audit.Details["to"] != constants.ROLE_MODERATOR {
+		t.Fatalf("unexpected audit details: %+v", audit.Details)
+	}
+}
+
+func TestAdminService_UpdateUserRole_Invalid(t *testing.T) {
+	_, svc := newAdminServiceMocks(t)
+
+	if _, err := svc.UpdateUserRole(1, model.UpdateUserRoleRequest{UserID: 5, Role: "owner"}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("unknown role: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := svc.UpdateUserRole(1, model.UpdateUserRoleRequest{UserID: 1, Role: constants.ROLE_USER}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("own role: expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestAdminService_TakeDownPost(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.posts.EXPECT().ReadPostOwnerID(int64(20)).Return(int64(5), nil)
+	m.posts.EXPECT().DeletePost(int64(20)).Return(nil)
+	var audit model.CreateAuditRecordRequest
+	m.expectAudit(&audit)
+
+	if err := svc.TakeDownPost(2, model.TakeDownPostRequest{PostID: 20, Reason: "spam"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if audit.Action != model.AuditTakeDownPost || audit.TargetID != 20 || audit.Details["ownerId"] != int64(5) {
+		t.Fatalf("unexpected audit record: %+v", audit)
+	}
+}
+
+func TestAdminService_ReadAuditLog_Pages(t *testing.T) {
+	m, svc := newAdminServiceMocks(t)
+	m.audit.EXPECT().ReadAuditRecords(model.AuditFilter{TargetType: model.AuditTargetUser, BeforeID: 10, Limit: 3}).
+		Return([]*model.AuditRecord{{ID: 9}, {ID: 8}, {ID: 7}}, nil)
+
+	page, err := svc.ReadAuditLog(model.ReadAuditLogRequest{TargetType: model.AuditTargetUser, Cursor: "10", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Records) != 2 || page.NextCursor != "8" {
+		t.Fatalf("unexpected page: %d records, cursor %q", len(page.Records), page.NextCursor)
+	}
+}
+
+func TestAdminService_ReadAuditLog_Invalid(t *testing.T) {
+	tests := []model.ReadAuditLogRequest{
+		{Cursor: "abc"},
+		{Cursor: "-4"},
+		{Limit: -1},
+		{TargetType: "routine"},
+	}
+
+	for _, req := range tests {
+		_, svc := newAdminServiceMocks(t)
+		if _, err := svc.ReadAuditLog(req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Fatalf("%+v: expected ErrInvalidInput, got %v", req, err)
+		}
+	}
+}
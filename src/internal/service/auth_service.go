@@ -2,20 +2,41 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"strings"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// RefreshTokenTTL is how long a device stays signed in without refreshing
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// usernameAttempts bounds how many generated usernames are tried when provisioning
+const usernameAttempts = 5
+
 type authService struct {
-	userRepository repository.UserRepository
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+	googleVerifier    service.IDTokenVerifier
+	now               func() time.Time
 }
 
-func NewAuthService(ur repository.UserRepository) service.AuthService {
-	return &authService{userRepository: ur}
+// NewAuthService takes a nil googleVerifier when google sign-in is not configured
+func NewAuthService(ur repository.UserRepository, sr repository.SessionRepository, googleVerifier service.IDTokenVerifier) service.AuthService {
+	return &authService{userRepository: ur, sessionRepository: sr, googleVerifier: googleVerifier, now: time.Now}
 }
 
 func (a *authService) Authenticate(ctx context.Context, request model.LoginRequest) (*model.User, error) {
@@ -31,3 +52,234 @@ func (a *authService) Authenticate(ctx context.Context, request model.LoginReque
 
 	return user, nil
 }
+
+// AuthenticateGoogle signs in with a Google ID token. The account is found by its
+// google id, linked by verified email on the first google login, or else provisioned.
+func (a *authService) AuthenticateGoogle(ctx context.Context, idToken string) (*model.User, error) {
+	if a.googleVerifier == nil {
+		return nil, errors.New("google sign-in is not configured")
+	}
+	identity, err := a.googleVerifier.Verify(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", util.ErrUnauthorized, err)
+	}
+
+	user, err := a.userRepository.ReadUserByGoogleID(identity.Subject)
+	if err != nil || user != nil {
+		return user, err
+	}
+
+	// linking by an unverified email would let anyone claim that address's account
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, fmt.Errorf("%w: google account has no verified email", util.ErrUnauthorized)
+	}
+	email := strings.ToLower(identity.Email)
+
+	user, err = a.userRepository.LinkGoogleAccount(email, identity.Subject)
+	if err != nil || user != nil {
+		return user, err
+	}
+	return a.provisionGoogleUser(identity, email)
+}
+
+func (a *authService) provisionGoogleUser(identity *model.GoogleIdentity, email string) (*model.User, error) {
+	base := usernameFromEmail(email)
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = base
+	}
+
+	username := base
+	for attempt := 0; attempt < usernameAttempts; attempt++ {
+		user, err := a.userRepository.CreateGoogleUser(model.CreateGoogleUserRequest{
+			Username: username,
+			Email:    email,
+			Name:     name,
+			GoogleID: identity.Subject,
+		})
+		if err == nil {
+			return user, nil
+		}
+		if err.Error() != "user already exists" {
+			return nil, err
+		}
+		username = fmt.Sprintf("%s%04d", base, mathrand.IntN(10000))
+	}
+	// the username was never the problem if every attempt collided, the email is
+	// registered to an account linked with another google id
+	return nil, fmt.Errorf("%w: an account already exists for %s", util.ErrConflict, email)
+}
+
+// usernameFromEmail turns the local part of an email into a username
+func usernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	var b strings.Builder
+	for _, r := range strings.ToLower(local) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	if len(username) < 3 {
+		username = "user" + username
+	}
+	if len(username) > 40 {
+		username = username[:40]
+	}
+	return username
+}
+
+func (a *authService) CreateSession(ctx context.Context, userID int64) (*model.AuthSession, error) {
+	role, err := a.readActiveRole(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.AuthSession{
+		ID:               randomToken(16),
+		UserID:           userID,
+		Role:             role,
+		RefreshToken:     randomToken(32),
+		RefreshExpiresAt: a.now().Add(RefreshTokenTTL),
+	}
+	err = a.sessionRepository.CreateSession(model.CreateRefreshTokenRequest{
+		SessionID: session.ID,
+		UserID:    userID,
+		TokenHash: hashToken(session.RefreshToken),
+		ExpiresAt: session.RefreshExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// RefreshSession trades a refresh token for its successor. A token can only be used
+// once; presenting it again means it was stolen, so the whole session is revoked.
+func (a *authService) RefreshSession(ctx context.Context, refreshToken string) (*model.AuthSession, error) {
+	token, err := a.readRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if token.SessionRevoked {
+		return nil, fmt.Errorf("%w: session has been revoked", util.ErrUnauthorized)
+	}
+	if token.UsedAt != nil {
+		return nil, a.revokeReusedSession(token.SessionID)
+	}
+	if !a.now().Before(token.ExpiresAt) {
+		return nil, fmt.Errorf("%w: refresh token has expired", util.ErrUnauthorized)
+	}
+	role, err := a.readActiveRole(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.AuthSession{
+		ID:               token.SessionID,
+		UserID:           token.UserID,
+		Role:             role,
+		RefreshToken:     randomToken(32),
+		RefreshExpiresAt: a.now().Add(RefreshTokenTTL),
+	}
+	err = a.sessionRepository.RotateRefreshToken(token.ID, model.CreateRefreshTokenRequest{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		TokenHash: hashToken(session.RefreshToken),
+		ExpiresAt: session.RefreshExpiresAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// another request rotated this token first
+		return nil, a.revokeReusedSession(token.SessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// EndSession revokes the session a refresh token belongs to; unknown tokens are ignored
+func (a *authService) EndSession(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	token, err := a.sessionRepository.ReadRefreshToken(hashToken(refreshToken))
+	if err != nil || token == nil {
+		return err
+	}
+	return a.sessionRepository.RevokeSession(token.SessionID)
+}
+
+// RevokeSession ends the session an access token names, for clients that
+// log out without their refresh token
+func (a *authService) RevokeSession(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return a.sessionRepository.RevokeSession(sessionID)
+}
+
+func (a *authService) RevokeAllSessions(ctx context.Context, userID int64) error {
+	return a.sessionRepository.RevokeUserSessions(userID)
+}
+
+func (a *authService) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+	return a.sessionRepository.IsSessionActive(sessionID)
+}
+
+// readActiveRole returns the role a new access token carries, refusing banned and
+// currently suspended users
+func (a *authService) readActiveRole(userID int64) (string, error) {
+	standing, err := a.userRepository.ReadUserStanding(userID)
+	if err != nil {
+		return "", err
+	}
+	if standing.BannedAt != nil {
+		return "", fmt.Errorf("%w: this account has been banned", util.ErrForbidden)
+	}
+	if standing.SuspendedUntil != nil && a.now().Before(*standing.SuspendedUntil) {
+		return "", fmt.Errorf("%w: this account is suspended until %s", util.ErrForbidden, standing.SuspendedUntil.Format(time.RFC3339))
+	}
+	if standing.Role == "" {
+		return constants.ROLE_USER, nil
+	}
+	return standing.Role, nil
+}
+
+func (a *authService) readRefreshToken(refreshToken string) (*model.RefreshToken, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("%w: refresh token is required", util.ErrUnauthorized)
+	}
+	token, err := a.sessionRepository.ReadRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, fmt.Errorf("%w: refresh token is invalid", util.ErrUnauthorized)
+	}
+	return token, nil
+}
+
+func (a *authService) revokeReusedSession(sessionID string) error {
+	if err := a.sessionRepository.RevokeSession(sessionID); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: refresh token was already used, session revoked", util.ErrUnauthorized)
+}
+
+// randomToken returns n random bytes, url-safe encoded
+func randomToken(n int) string {
+	b := make([]byte, n)
+	rand.Read(b) // never fails since go 1.24
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// refresh tokens carry 256 bits of entropy, so a fast hash is enough to keep them
+// useless if the table leaks
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
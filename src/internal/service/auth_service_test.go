@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+	"workoutpal/src/util/constants"
 
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
 
 	"github.com/golang/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
@@ -17,7 +22,7 @@ func TestAuthService_Authenticate_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockUserRepository(ctrl)
-	auth := NewAuthService(mockRepo)
+	auth := NewAuthService(mockRepo, nil, nil)
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 	user := &model.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}
@@ -43,7 +48,7 @@ func TestAuthService_Authenticate_InvalidEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockUserRepository(ctrl)
-	auth := NewAuthService(mockRepo)
+	auth := NewAuthService(mockRepo, nil, nil)
 
 	mockRepo.
 		EXPECT().
@@ -63,7 +68,7 @@ func TestAuthService_Authenticate_InvalidPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockUserRepository(ctrl)
-	auth := NewAuthService(mockRepo)
+	auth := NewAuthService(mockRepo, nil, nil)
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpass"), bcrypt.DefaultCost)
 	user := &model.User{ID: 2, Email: "john@example.com", Password: string(hashedPassword)}
@@ -80,3 +85,347 @@ func TestAuthService_Authenticate_InvalidPassword(t *testing.T) {
 		t.Fatalf("expected 'invalid password', got %v", err)
 	}
 }
+
+// newAuthSessionMocks lets user 7 hold a session as a regular user; tests about
+// other users or roles set up their own standing
+func newAuthSessionMocks(t *testing.T) (*mock_repository.MockUserRepository, *mock_repository.MockSessionRepository, *authService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	users := mock_repository.NewMockUserRepository(ctrl)
+	sessions := mock_repository.NewMockSessionRepository(ctrl)
+	svc := NewAuthService(users, sessions, nil).(*authService)
+	svc.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return users, sessions, svc
+}
+
+func expectStanding(users *mock_repository.MockUserRepository, standing *model.UserStanding) {
+	users.EXPECT().ReadUserStanding(standing.UserID).Return(standing, nil)
+}
+
+func TestAuthService_CreateSession_StoresHashedToken(t *testing.T) {
+	users, sessions, svc := newAuthSessionMocks(t)
+	expectStanding(users, &model.UserStanding{UserID: 7, Role: constants.ROLE_USER})
+
+	var stored model.CreateRefreshTokenRequest
+	sessions.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(req model.CreateRefreshTokenRequest) error {
+		stored = req
+		return nil
+	})
+
+	got, err := svc.CreateSession(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID == "" || got.RefreshToken == "" || got.UserID != 7 || got.Role != constants.ROLE_USER {
+		t.Fatalf("unexpected session: %+v", got)
+	}
+	if stored.TokenHash == got.RefreshToken || stored.TokenHash != hashToken(got.RefreshToken) {
+		t.Fatalf("refresh token must be stored hashed, got %q", stored.TokenHash)
+	}
+	if stored.SessionID != got.ID || !stored.ExpiresAt.Equal(svc.now().Add(RefreshTokenTTL)) {
+		t.Fatalf("unexpected stored token: %+v", stored)
+	}
+}
+
+func TestAuthService_RefreshSession_Rotates(t *testing.T) {
+	users, sessions, svc := newAuthSessionMocks(t)
+	expectStanding(users, &model.UserStanding{UserID: 7, Role: constants.ROLE_USER})
+
+	sessions.EXPECT().ReadRefreshToken(hashToken("old")).
+		Return(&model.RefreshToken{ID: 3, SessionID: "s1", UserID: 7, ExpiresAt: svc.now().Add(time.Hour)}, nil)
+	var rotated model.CreateRefreshTokenRequest
+	sessions.EXPECT().RotateRefreshToken(int64(3), gomock.Any()).DoAndReturn(func(_ int64, req model.CreateRefreshTokenRequest) error {
+		rotated = req
+		return nil
+	})
+
+	got, err := svc.RefreshSession(context.Background(), "old")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != "s1" || got.UserID != 7 || got.RefreshToken == "old" {
+		t.Fatalf("unexpected session: %+v", got)
+	}
+	if rotated.SessionID != "s1" || rotated.TokenHash != hashToken(got.RefreshToken) {
+		t.Fatalf("unexpected rotated token: %+v", rotated)
+	}
+}
+
+func TestAuthService_RefreshSession_ReuseRevokesSession(t *testing.T) {
+	_, sessions, svc := newAuthSessionMocks(t)
+
+	usedAt := svc.now().Add(-time.Minute)
+	sessions.EXPECT().ReadRefreshToken(hashToken("stolen")).
+		Return(&model.RefreshToken{ID: 3, SessionID: "s1", UserID: 7, ExpiresAt: svc.now().Add(time.Hour), UsedAt: &usedAt}, nil)
+	sessions.EXPECT().RevokeSession("s1").Return(nil)
+
+	_, err := svc.RefreshSession(context.Background(), "stolen")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthService_RefreshSession_ConcurrentRotationRevokesSession(t *testing.T) {
+	users, sessions, svc := newAuthSessionMocks(t)
+	expectStanding(users, &model.UserStanding{UserID: 7, Role: constants.ROLE_USER})
+
+	sessions.EXPECT().ReadRefreshToken(gomock.Any()).
+		Return(&model.RefreshToken{ID: 3, SessionID: "s1", UserID: 7, ExpiresAt: svc.now().Add(time.Hour)}, nil)
+	sessions.EXPECT().RotateRefreshToken(int64(3), gomock.Any()).Return(sql.ErrNoRows)
+	sessions.EXPECT().RevokeSession("s1").Return(nil)
+
+	_, err := svc.RefreshSession(context.Background(), "old")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthService_RefreshSession_Rejected(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		token *model.RefreshToken
+	}{
+		{"unknown token", nil},
+		{"revoked session", &model.RefreshToken{ID: 3, SessionID: "s1", ExpiresAt: now.Add(time.Hour), SessionRevoked: true}},
+		{"expired", &model.RefreshToken{ID: 3, SessionID: "s1", ExpiresAt: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, sessions, svc := newAuthSessionMocks(t)
+			sessions.EXPECT().ReadRefreshToken(gomock.Any()).Return(tt.token, nil)
+
+			_, err := svc.RefreshSession(context.Background(), "token")
+			if !errors.Is(err, util.ErrUnauthorized) {
+				t.Fatalf("expected ErrUnauthorized, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthService_RefreshSession_MissingToken(t *testing.T) {
+	_, _, svc := newAuthSessionMocks(t)
+
+	_, err := svc.RefreshSession(context.Background(), "")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthService_CreateSession_CarriesRole(t *testing.T) {
+	users, sessions, svc := newAuthSessionMocks(t)
+	expectStanding(users, &model.UserStanding{UserID: 7, Role: constants.ROLE_MODERATOR})
+	sessions.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	got, err := svc.CreateSession(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Role != constants.ROLE_MODERATOR {
+		t.Fatalf("role = %q, want moderator", got.Role)
+	}
+}
+
+func TestAuthService_Sessions_RefusedWhileModerated(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+	tests := []struct {
+		name     string
+		standing *model.UserStanding
+		refused  bool
+	}{
+		{"banned", &model.UserStanding{UserID: 7, BannedAt: &earlier}, true},
+		{"suspended", &model.UserStanding{UserID: 7, SuspendedUntil: &later}, true},
+		{"suspension over", &model.UserStanding{UserID: 7, SuspendedUntil: &earlier}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" login", func(t *testing.T) {
+			users, sessions, svc := newAuthSessionMocks(t)
+			expectStanding(users, tt.standing)
+			if !tt.refused {
+				sessions.EXPECT().CreateSession(gomock.Any()).Return(nil)
+			}
+
+			got, err := svc.CreateSession(context.Background(), 7)
+			if tt.refused != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("CreateSession err = %v, refused want %v", err, tt.refused)
+			}
+			// a role missing from the database counts as a regular user
+			if !tt.refused && got.Role != constants.ROLE_USER {
+				t.Fatalf("role = %q, want user", got.Role)
+			}
+		})
+		t.Run(tt.name+" refresh", func(t *testing.T) {
+			users, sessions, svc := newAuthSessionMocks(t)
+			sessions.EXPECT().ReadRefreshToken(gomock.Any()).
+				Return(&model.RefreshToken{ID: 3, SessionID: "s1", UserID: 7, ExpiresAt: now.Add(time.Hour)}, nil)
+			expectStanding(users, tt.standing)
+			if !tt.refused {
+				sessions.EXPECT().RotateRefreshToken(int64(3), gomock.Any()).Return(nil)
+			}
+
+			_, err := svc.RefreshSession(context.Background(), "token")
+			if tt.refused != errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("RefreshSession err = %v, refused want %v", err, tt.refused)
+			}
+		})
+	}
+}
+
+func TestAuthService_EndSession(t *testing.T) {
+	_, sessions, svc := newAuthSessionMocks(t)
+
+	sessions.EXPECT().ReadRefreshToken(hashToken("tok")).Return(&model.RefreshToken{ID: 3, SessionID: "s1"}, nil)
+	sessions.EXPECT().RevokeSession("s1").Return(nil)
+
+	if err := svc.EndSession(context.Background(), "tok"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := svc.EndSession(context.Background(), ""); err != nil {
+		t.Fatalf("unexpected err without a token: %v", err)
+	}
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	_, sessions, svc := newAuthSessionMocks(t)
+
+	sessions.EXPECT().RevokeSession("s1").Return(nil)
+
+	if err := svc.RevokeSession(context.Background(), "s1"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := svc.RevokeSession(context.Background(), ""); err != nil {
+		t.Fatalf("unexpected err without a session: %v", err)
+	}
+}
+
+func TestAuthService_RevokeAllSessions(t *testing.T) {
+	_, sessions, svc := newAuthSessionMocks(t)
+
+	sessions.EXPECT().RevokeUserSessions(int64(7)).Return(nil)
+
+	if err := svc.RevokeAllSessions(context.Background(), 7); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func newGoogleAuthMocks(t *testing.T) (*mock_repository.MockUserRepository, *mock_service.MockIDTokenVerifier, *authService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	users := mock_repository.NewMockUserRepository(ctrl)
+	verifier := mock_service.NewMockIDTokenVerifier(ctrl)
+	svc := NewAuthService(users, nil, verifier).(*authService)
+	return users, verifier, svc
+}
+
+var adaIdentity = &model.GoogleIdentity{Subject: "g-1", Email: "Ada.L@Example.com", EmailVerified: true, Name: "Ada"}
+
+func TestAuthService_AuthenticateGoogle_KnownGoogleID(t *testing.T) {
+	users, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "id-token").Return(adaIdentity, nil)
+	users.EXPECT().ReadUserByGoogleID("g-1").Return(&model.User{ID: 4, GoogleID: "g-1"}, nil)
+
+	got, err := svc.AuthenticateGoogle(context.Background(), "id-token")
+	if err != nil || got.ID != 4 {
+		t.Fatalf("unexpected result: %+v, %v", got, err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_LinksByEmail(t *testing.T) {
+	users, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "id-token").Return(adaIdentity, nil)
+	users.EXPECT().ReadUserByGoogleID("g-1").Return(nil, nil)
+	users.EXPECT().LinkGoogleAccount("ada.l@example.com", "g-1").Return(&model.User{ID: 4, GoogleID: "g-1"}, nil)
+
+	got, err := svc.AuthenticateGoogle(context.Background(), "id-token")
+	if err != nil || got.ID != 4 {
+		t.Fatalf("unexpected result: %+v, %v", got, err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_Provisions(t *testing.T) {
+	users, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "id-token").Return(adaIdentity, nil)
+	users.EXPECT().ReadUserByGoogleID("g-1").Return(nil, nil)
+	users.EXPECT().LinkGoogleAccount("ada.l@example.com", "g-1").Return(nil, nil)
+	users.EXPECT().CreateGoogleUser(model.CreateGoogleUserRequest{Username: "ada.l", Email: "ada.l@example.com", Name: "Ada", GoogleID: "g-1"}).
+		Return(nil, errors.New("user already exists"))
+	users.EXPECT().CreateGoogleUser(gomock.Any()).DoAndReturn(func(req model.CreateGoogleUserRequest) (*model.User, error) {
+		if len(req.Username) != len("ada.l")+4 || req.Username[:5] != "ada.l" {
+			t.Fatalf("expected a suffixed username, got %q", req.Username)
+		}
+		return &model.User{ID: 9, Username: req.Username, Provider: "google"}, nil
+	})
+
+	got, err := svc.AuthenticateGoogle(context.Background(), "id-token")
+	if err != nil || got.ID != 9 {
+		t.Fatalf("unexpected result: %+v, %v", got, err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_EmailTakenByOtherGoogleAccount(t *testing.T) {
+	users, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "id-token").Return(adaIdentity, nil)
+	users.EXPECT().ReadUserByGoogleID("g-1").Return(nil, nil)
+	users.EXPECT().LinkGoogleAccount("ada.l@example.com", "g-1").Return(nil, nil)
+	users.EXPECT().CreateGoogleUser(gomock.Any()).Return(nil, errors.New("user already exists")).Times(usernameAttempts)
+
+	_, err := svc.AuthenticateGoogle(context.Background(), "id-token")
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_UnverifiedEmail(t *testing.T) {
+	users, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "id-token").
+		Return(&model.GoogleIdentity{Subject: "g-1", Email: "ada@example.com"}, nil)
+	users.EXPECT().ReadUserByGoogleID("g-1").Return(nil, nil)
+
+	_, err := svc.AuthenticateGoogle(context.Background(), "id-token")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_InvalidToken(t *testing.T) {
+	_, verifier, svc := newGoogleAuthMocks(t)
+
+	verifier.EXPECT().Verify(gomock.Any(), "forged").Return(nil, errors.New("invalid id token: bad signature"))
+
+	_, err := svc.AuthenticateGoogle(context.Background(), "forged")
+	if !errors.Is(err, util.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestAuthService_AuthenticateGoogle_NotConfigured(t *testing.T) {
+	svc := NewAuthService(nil, nil, nil)
+
+	if _, err := svc.AuthenticateGoogle(context.Background(), "id-token"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestUsernameFromEmail(t *testing.T) {
+	tests := map[string]string{
+		"ada.l@example.com":     "ada.l",
+		"Jo+fit@example.com":    "jofit",
+		"x@example.com":         "userx",
+		"émile_r-9@example.com": "mile_r-9",
+	}
+	for email, want := range tests {
+		if got := usernameFromEmail(email); got != want {
+			t.Errorf("usernameFromEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
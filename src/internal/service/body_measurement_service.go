@@ -0,0 +1,209 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+// length of the history when no range is asked for
+const defaultMeasurementDays = 90
+
+type bodyMeasurementService struct {
+	measurementRepository repository.BodyMeasurementRepository
+	userRepository        repository.UserRepository
+	policy                service.AccessPolicy
+	goals                 service.GoalSettler
+	now                   func() time.Time
+}
+
+func NewBodyMeasurementService(measurementRepository repository.BodyMeasurementRepository, userRepository repository.UserRepository, policy service.AccessPolicy, goals service.GoalSettler) service.BodyMeasurementService {
+	return &bodyMeasurementService{
+		measurementRepository: measurementRepository,
+		userRepository:        userRepository,
+		policy:                policy,
+		goals:                 goals,
+		now:                   time.Now,
+	}
+}
+
+// CreateMeasurement stores the value in SI units and answers in the unit it
+// was logged in
+func (s *bodyMeasurementService) CreateMeasurement(actorID int64, request model.CreateMeasurementRequest) (*model.BodyMeasurement, error) {
+	if err := s.policy.CanModify(actorID, request.UserID); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(model.BodyMetrics, request.Metric) {
+		return nil, fmt.Errorf("%w: unknown metric %q", util.ErrInvalidInput, request.Metric)
+	}
+	if request.Value <= 0 {
+		return nil, fmt.Errorf("%w: value must be positive", util.ErrInvalidInput)
+	}
+	now := s.now().UTC()
+	measuredAt := now
+	if request.MeasuredAt != nil {
+		if request.MeasuredAt.After(now) {
+			return nil, fmt.Errorf("%w: measuredAt is in the future", util.ErrInvalidInput)
+		}
+		measuredAt = request.MeasuredAt.UTC()
+	}
+
+	var unit string
+	if request.Unit == "" {
+		units, err := s.displayUnits(request.UserID, "")
+		if err != nil {
+			return nil, err
+		}
+		unit = units.unitFor(request.Metric)
+	} else {
+		parsed, ok := parseUnit(request.Unit)
+		if !ok || !slices.Contains(metricUnits(request.Metric), parsed) {
+			return nil, fmt.Errorf("%w: %s is measured in %v", util.ErrInvalidInput, request.Metric, metricUnits(request.Metric))
+		}
+		unit = parsed
+	}
+
+	value := toSI(request.Value, unit)
+	if request.Metric == model.MetricBodyFat && value >= 100 {
+		return nil, fmt.Errorf("%w: body fat must be under 100%%", util.ErrInvalidInput)
+	}
+
+	created, err := s.measurementRepository.CreateMeasurement(&model.BodyMeasurement{
+		UserID:     request.UserID,
+		Metric:     request.Metric,
+		Value:      value,
+		Unit:       siUnit(request.Metric),
+		MeasuredAt: measuredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created.Metric == model.MetricWeight {
+		notifyGoals(s.goals, request.UserID)
+	}
+	convertMeasurement(created, unit)
+	return created, nil
+}
+
+func (s *bodyMeasurementService) ReadMeasurements(actorID int64, request model.ReadMeasurementsRequest) ([]*model.BodyMeasurement, error) {
+	if request.Metric != "" && !slices.Contains(model.BodyMetrics, request.Metric) {
+		return nil, fmt.Errorf("%w: unknown metric %q", util.ErrInvalidInput, request.Metric)
+	}
+	from, to, err := dateRange(request.From, request.To, defaultMeasurementDays, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CanViewMetrics(actorID, request.UserID); err != nil {
+		return nil, err
+	}
+	units, err := s.displayUnits(request.UserID, request.Units)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := s.measurementRepository.ReadMeasurements(model.MeasurementFilter{
+		UserID: request.UserID,
+		Metric: request.Metric,
+		From:   from,
+		Until:  to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range measurements {
+		convertMeasurement(m, units.unitFor(m.Metric))
+	}
+	return measurements, nil
+}
+
+// ReadTrend also reads the days before the range, so the first points'
+// moving average and the first week's change are complete
+func (s *bodyMeasurementService) ReadTrend(actorID int64, request model.ReadMeasurementsRequest) (*model.MeasurementTrend, error) {
+	if !slices.Contains(model.BodyMetrics, request.Metric) {
+		return nil, fmt.Errorf("%w: metric must be one of %v", util.ErrInvalidInput, model.BodyMetrics)
+	}
+	window := request.Window
+	if window == 0 {
+		window = model.DefaultTrendWindow
+	}
+	if window < 1 || window > model.MaxTrendWindow {
+		return nil, fmt.Errorf("%w: window must be between 1 and %d days", util.ErrInvalidInput, model.MaxTrendWindow)
+	}
+	from, to, err := dateRange(request.From, request.To, defaultMeasurementDays, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CanViewMetrics(actorID, request.UserID); err != nil {
+		return nil, err
+	}
+	units, err := s.displayUnits(request.UserID, request.Units)
+	if err != nil {
+		return nil, err
+	}
+
+	readFrom := from.AddDate(0, 0, 1-window)
+	if previousWeek := weekStart(from).AddDate(0, 0, -7); previousWeek.Before(readFrom) {
+		readFrom = previousWeek
+	}
+	measurements, err := s.measurementRepository.ReadMeasurements(model.MeasurementFilter{
+		UserID: request.UserID,
+		Metric: request.Metric,
+		From:   readFrom,
+		Until:  to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unit := units.unitFor(request.Metric)
+	for _, m := range measurements {
+		convertMeasurement(m, unit)
+	}
+	points, weekly := measurementTrend(measurements, from, to, window)
+	return &model.MeasurementTrend{
+		UserID: request.UserID,
+		Metric: request.Metric,
+		Unit:   unit,
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Window: window,
+		Points: points,
+		Weekly: weekly,
+	}, nil
+}
+
+func (s *bodyMeasurementService) DeleteMeasurement(actorID, userID, measurementID int64) error {
+	if err := s.policy.CanModify(actorID, userID); err != nil {
+		return err
+	}
+	if err := s.measurementRepository.DeleteMeasurement(userID, measurementID); err != nil {
+		return err
+	}
+	// the deleted value may have been the latest weight a goal tracks
+	notifyGoals(s.goals, userID)
+	return nil
+}
+
+// displayUnits picks the requested unit system, or else the units on the
+// owner's profile
+func (s *bodyMeasurementService) displayUnits(userID int64, system string) (displayUnits, error) {
+	switch system {
+	case model.UnitsMetric:
+		return displayUnits{mass: model.UnitKilogram, length: model.UnitCentimeter}, nil
+	case model.UnitsImperial:
+		return displayUnits{mass: model.UnitPound, length: model.UnitInch}, nil
+	case "":
+	default:
+		return displayUnits{}, fmt.Errorf("%w: units must be %q or %q", util.ErrInvalidInput, model.UnitsMetric, model.UnitsImperial)
+	}
+
+	owner, err := s.userRepository.ReadUserByID(userID)
+	if err != nil {
+		return displayUnits{}, err
+	}
+	return profileUnits(owner), nil
+}
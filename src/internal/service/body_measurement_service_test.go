@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+
+	"github.com/golang/mock/gomock"
+)
+
+var measurementNow = time.Date(2025, 3, 20, 15, 0, 0, 0, time.UTC) // a Thursday
+
+func newBodyMeasurementMocks(t *testing.T) (*mock_repository.MockBodyMeasurementRepository, *mock_repository.MockUserRepository, *mock_service.MockAccessPolicy, *bodyMeasurementService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	measurements := mock_repository.NewMockBodyMeasurementRepository(ctrl)
+	users := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewBodyMeasurementService(measurements, users, policy, nil).(*bodyMeasurementService)
+	svc.now = func() time.Time { return measurementNow }
+	return measurements, users, policy, svc
+}
+
+func TestBodyMeasurementService_CreateMeasurement_StoresSI(t *testing.T) {
+	measurements, _, policy, svc := newBodyMeasurementMocks(t)
+	goals := mock_service.NewMockGoalService(gomock.NewController(t))
+	svc.goals = goals
+
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+	measurements.EXPECT().CreateMeasurement(gomock.Any()).DoAndReturn(func(m *model.BodyMeasurement) (*model.BodyMeasurement, error) {
+		if m.Metric != model.MetricWeight || m.Unit != model.UnitKilogram || roundMeasurement(m.Value) != 81.65 {
+			t.Fatalf("want 180 lb stored as 81.65 kg, got %#v", m)
+		}
+		if !m.MeasuredAt.Equal(measurementNow) {
+			t.Fatalf("measuredAt = %v, want now", m.MeasuredAt)
+		}
+		created := *m
+		created.ID = 5
+		return &created, nil
+	})
+	// a new weight can meet a body_weight goal
+	goals.EXPECT().SettleGoals(int64(1)).Return(nil)
+
+	got, err := svc.CreateMeasurement(1, model.CreateMeasurementRequest{UserID: 1, Metric: model.MetricWeight, Value: 180, Unit: "lbs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 5 || got.Value != 180 || got.Unit != model.UnitPound {
+		t.Fatalf("want the value back in lb, got %#v", got)
+	}
+}
+
+func TestBodyMeasurementService_CreateMeasurement_DefaultsToProfileUnit(t *testing.T) {
+	measurements, users, policy, svc := newBodyMeasurementMocks(t)
+
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+	users.EXPECT().ReadUserByID(int64(1)).Return(&model.User{ID: 1, WeightMetric: "kg", HeightMetric: "ft"}, nil)
+	measurements.EXPECT().CreateMeasurement(gomock.Any()).DoAndReturn(func(m *model.BodyMeasurement) (*model.BodyMeasurement, error) {
+		if m.Unit != model.UnitCentimeter || roundMeasurement(m.Value) != 81.28 {
+			t.Fatalf("want 32 in stored as 81.28 cm, got %#v", m)
+		}
+		return m, nil
+	})
+
+	got, err := svc.CreateMeasurement(1, model.CreateMeasurementRequest{UserID: 1, Metric: model.MetricWaist, Value: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Value != 32 || got.Unit != model.UnitInch {
+		t.Fatalf("unexpected measurement: %#v", got)
+	}
+}
+
+func TestBodyMeasurementService_CreateMeasurement_Invalid(t *testing.T) {
+	future := measurementNow.Add(time.Hour)
+	tests := map[string]model.CreateMeasurementRequest{
+		"unknown metric":   {Metric: "shoe_size", Value: 44},
+		"zero value":       {Metric: model.MetricWeight, Value: 0, Unit: "kg"},
+		"wrong unit":       {Metric: model.MetricWeight, Value: 80, Unit: "cm"},
+		"unknown unit":     {Metric: model.MetricWaist, Value: 80, Unit: "furlong"},
+		"body fat too big": {Metric: model.MetricBodyFat, Value: 100, Unit: "%"},
+		"future":           {Metric: model.MetricWeight, Value: 80, Unit: "kg", MeasuredAt: &future},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, policy, svc := newBodyMeasurementMocks(t)
+			policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+
+			req.UserID = 1
+			if _, err := svc.CreateMeasurement(1, req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("want ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBodyMeasurementService_CreateMeasurement_Forbidden(t *testing.T) {
+	_, _, policy, svc := newBodyMeasurementMocks(t)
+	policy.EXPECT().CanModify(int64(2), int64(1)).Return(fmt.Errorf("%w: not yours", util.ErrForbidden))
+
+	_, err := svc.CreateMeasurement(2, model.CreateMeasurementRequest{UserID: 1, Metric: model.MetricWeight, Value: 80})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("want ErrForbidden, got %v", err)
+	}
+}
+
+func TestBodyMeasurementService_ReadMeasurements_ConvertsToRequestedUnits(t *testing.T) {
+	measurements, _, policy, svc := newBodyMeasurementMocks(t)
+
+	policy.EXPECT().CanViewMetrics(int64(2), int64(1)).Return(nil)
+	measurements.EXPECT().ReadMeasurements(model.MeasurementFilter{
+		UserID: 1,
+		From:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+	}).Return([]*model.BodyMeasurement{
+		{ID: 1, UserID: 1, Metric: model.MetricWeight, Value: 100, Unit: model.UnitKilogram},
+		{ID: 2, UserID: 1, Metric: model.MetricWaist, Value: 91.44, Unit: model.UnitCentimeter},
+		{ID: 3, UserID: 1, Metric: model.MetricBodyFat, Value: 18.5, Unit: model.UnitPercent},
+	}, nil)
+
+	got, err := svc.ReadMeasurements(2, model.ReadMeasurementsRequest{UserID: 1, From: "2025-03-01", To: "2025-03-10", Units: model.UnitsImperial})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct {
+		value float64
+		unit  string
+	}{{220.46, model.UnitPound}, {36, model.UnitInch}, {18.5, model.UnitPercent}}
+	for i, w := range want {
+		if got[i].Value != w.value || got[i].Unit != w.unit {
+			t.Fatalf("measurement %d = %v %s, want %v %s", i, got[i].Value, got[i].Unit, w.value, w.unit)
+		}
+	}
+}
+
+func TestBodyMeasurementService_ReadMeasurements_Hidden(t *testing.T) {
+	_, _, policy, svc := newBodyMeasurementMocks(t)
+	policy.EXPECT().CanViewMetrics(int64(2), int64(1)).Return(fmt.Errorf("%w: metrics are not shared with you", util.ErrForbidden))
+
+	if _, err := svc.ReadMeasurements(2, model.ReadMeasurementsRequest{UserID: 1}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("want ErrForbidden, got %v", err)
+	}
+}
+
+func TestBodyMeasurementService_ReadMeasurements_InvalidUnits(t *testing.T) {
+	_, _, policy, svc := newBodyMeasurementMocks(t)
+	policy.EXPECT().CanViewMetrics(int64(1), int64(1)).Return(nil)
+
+	if _, err := svc.ReadMeasurements(1, model.ReadMeasurementsRequest{UserID: 1, Units: "nautical"}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestBodyMeasurementService_ReadTrend(t *testing.T) {
+	measurements, users, policy, svc := newBodyMeasurementMocks(t)
+
+	at := func(day int, value float64) *model.BodyMeasurement {
+		return &model.BodyMeasurement{Metric: model.MetricWeight, Value: value, MeasuredAt: time.Date(2025, 3, day, 7, 0, 0, 0, time.UTC)}
+	}
+	policy.EXPECT().CanViewMetrics(int64(1), int64(1)).Return(nil)
+	users.EXPECT().ReadUserByID(int64(1)).Return(&model.User{ID: 1, WeightMetric: "kg"}, nil)
+	// the range starts on Wednesday the 12th, so the window reaches back to the
+	// 10th and the previous week to Monday the 3rd
+	measurements.EXPECT().ReadMeasurements(model.MeasurementFilter{
+		UserID: 1,
+		Metric: model.MetricWeight,
+		From:   time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC),
+	}).Return([]*model.BodyMeasurement{
+		at(4, 82), at(11, 81), at(12, 80), at(12, 81), at(14, 79), at(18, 78),
+	}, nil)
+
+	got, err := svc.ReadTrend(1, model.ReadMeasurementsRequest{UserID: 1, Metric: model.MetricWeight, From: "2025-03-12", To: "2025-03-20", Window: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Unit != model.UnitKilogram || got.Window != 3 || got.From != "2025-03-12" || got.To != "2025-03-20" {
+		t.Fatalf("unexpected trend: %#v", got)
+	}
+
+	wantPoints := []model.TrendPoint{
+		{Date: "2025-03-12", Value: 80.5, MovingAverage: 80.75},
+		{Date: "2025-03-14", Value: 79, MovingAverage: 79.75},
+		{Date: "2025-03-18", Value: 78, MovingAverage: 78},
+	}
+	if len(got.Points) != len(wantPoints) {
+		t.Fatalf("points = %d, want %d", len(got.Points), len(wantPoints))
+	}
+	for i, want := range wantPoints {
+		if *got.Points[i] != want {
+			t.Fatalf("point %d = %#v, want %#v", i, *got.Points[i], want)
+		}
+	}
+
+	// week of the 10th: (81 + 80.5 + 79) / 3 = 80.17, 1.83 below the week of the 3rd
+	if len(got.Weekly) != 2 {
+		t.Fatalf("weekly = %d, want 2", len(got.Weekly))
+	}
+	if w := got.Weekly[0]; w.Start != "2025-03-10" || w.Average != 80.17 || w.Change == nil || *w.Change != -1.83 {
+		t.Fatalf("unexpected first week: %#v", w)
+	}
+	if w := got.Weekly[1]; w.Start != "2025-03-17" || w.Average != 78 || w.Change == nil || *w.Change != -2.17 {
+		t.Fatalf("unexpected second week: %#v", w)
+	}
+}
+
+func TestBodyMeasurementService_ReadTrend_Invalid(t *testing.T) {
+	tests := map[string]model.ReadMeasurementsRequest{
+		"no metric":       {UserID: 1},
+		"window too big":  {UserID: 1, Metric: model.MetricWeight, Window: model.MaxTrendWindow + 1},
+		"negative window": {UserID: 1, Metric: model.MetricWeight, Window: -1},
+		"bad range":       {UserID: 1, Metric: model.MetricWeight, From: "2025-03-10", To: "2025-03-01"},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, svc := newBodyMeasurementMocks(t)
+			if _, err := svc.ReadTrend(1, req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("want ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBodyMeasurementService_DeleteMeasurement(t *testing.T) {
+	measurements, _, policy, svc := newBodyMeasurementMocks(t)
+	goals := mock_service.NewMockGoalService(gomock.NewController(t))
+	svc.goals = goals
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+	measurements.EXPECT().DeleteMeasurement(int64(1), int64(5)).Return(nil)
+	goals.EXPECT().SettleGoals(int64(1)).Return(nil)
+
+	if err := svc.DeleteMeasurement(1, 1, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProfileUnits(t *testing.T) {
+	tests := []struct {
+		weight, height string
+		want           displayUnits
+	}{
+		{"kg", "cm", displayUnits{mass: model.UnitKilogram, length: model.UnitCentimeter}},
+		{"LBS", "ft/in", displayUnits{mass: model.UnitPound, length: model.UnitInch}},
+		{"pounds", "inches", displayUnits{mass: model.UnitPound, length: model.UnitInch}},
+		{"", "stone", displayUnits{mass: model.UnitKilogram, length: model.UnitCentimeter}},
+	}
+	for _, tt := range tests {
+		if got := profileUnits(&model.User{WeightMetric: tt.weight, HeightMetric: tt.height}); got != tt.want {
+			t.Errorf("profileUnits(%q, %q) = %+v, want %+v", tt.weight, tt.height, got, tt.want)
+		}
+	}
+}
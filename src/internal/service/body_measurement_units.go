@@ -0,0 +1,188 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"time"
+	"workoutpal/src/internal/model"
+)
+
+const (
+	kilogramsPerPound  = 0.45359237
+	centimetresPerInch = 2.54
+)
+
+// displayUnits are the units a reader sees measurements in
+type displayUnits struct {
+	mass   string
+	length string
+}
+
+func (u displayUnits) unitFor(metric string) string {
+	switch metric {
+	case model.MetricWeight:
+		return u.mass
+	case model.MetricBodyFat:
+		return model.UnitPercent
+	default:
+		return u.length
+	}
+}
+
+// profileUnits reads the free-form units on a profile, falling back to
+// kilograms and centimetres for anything it doesn't recognise
+func profileUnits(user *model.User) displayUnits {
+	units := displayUnits{mass: model.UnitKilogram, length: model.UnitCentimeter}
+	if unit, _ := parseUnit(user.WeightMetric); unit == model.UnitPound {
+		units.mass = model.UnitPound
+	}
+	switch strings.ToLower(strings.TrimSpace(user.HeightMetric)) {
+	case "ft", "feet", "ft/in":
+		units.length = model.UnitInch
+	default:
+		if unit, _ := parseUnit(user.HeightMetric); unit == model.UnitInch {
+			units.length = model.UnitInch
+		}
+	}
+	return units
+}
+
+// parseUnit accepts the usual spellings of each unit
+func parseUnit(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "kg", "kgs", "kilogram", "kilograms":
+		return model.UnitKilogram, true
+	case "lb", "lbs", "pound", "pounds":
+		return model.UnitPound, true
+	case "cm", "centimeter", "centimeters", "centimetre", "centimetres":
+		return model.UnitCentimeter, true
+	case "in", "inch", "inches":
+		return model.UnitInch, true
+	case "%", "percent":
+		return model.UnitPercent, true
+	default:
+		return "", false
+	}
+}
+
+// metricUnits lists the units a metric can be logged in, SI first
+func metricUnits(metric string) []string {
+	switch metric {
+	case model.MetricWeight:
+		return []string{model.UnitKilogram, model.UnitPound}
+	case model.MetricBodyFat:
+		return []string{model.UnitPercent}
+	default:
+		return []string{model.UnitCentimeter, model.UnitInch}
+	}
+}
+
+func siUnit(metric string) string {
+	return metricUnits(metric)[0]
+}
+
+func toSI(value float64, unit string) float64 {
+	switch unit {
+	case model.UnitPound:
+		return value * kilogramsPerPound
+	case model.UnitInch:
+		return value * centimetresPerInch
+	default:
+		return value
+	}
+}
+
+func fromSI(value float64, unit string) float64 {
+	switch unit {
+	case model.UnitPound:
+		return value / kilogramsPerPound
+	case model.UnitInch:
+		return value / centimetresPerInch
+	default:
+		return value
+	}
+}
+
+// convertMeasurement turns a measurement read in SI units into unit
+func convertMeasurement(m *model.BodyMeasurement, unit string) {
+	m.Value = roundMeasurement(fromSI(m.Value, unit))
+	m.Unit = unit
+}
+
+func roundMeasurement(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+type measurementDay struct {
+	date  time.Time
+	sum   float64
+	count int
+}
+
+func (d *measurementDay) mean() float64 {
+	return d.sum / float64(d.count)
+}
+
+// measurementTrend averages the measurements, oldest first, per UTC day. Each
+// day in [from, to] gets the mean of the daily values over the window ending
+// on it, and each week starting on or after the Monday of from is compared
+// with the week before. Days without measurements are left out rather than
+// filled in.
+func measurementTrend(measurements []*model.BodyMeasurement, from, to time.Time, window int) ([]*model.TrendPoint, []*model.WeeklyChange) {
+	var days []*measurementDay
+	for _, m := range measurements {
+		date := m.MeasuredAt.UTC().Truncate(24 * time.Hour)
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, &measurementDay{date: date})
+		}
+		days[len(days)-1].sum += m.Value
+		days[len(days)-1].count++
+	}
+
+	points := []*model.TrendPoint{}
+	for i, day := range days {
+		if day.date.Before(from) || day.date.After(to) {
+			continue
+		}
+		windowStart := day.date.AddDate(0, 0, 1-window)
+		sum, count := 0.0, 0
+		for j := i; j >= 0 && !days[j].date.Before(windowStart); j-- {
+			sum += days[j].mean()
+			count++
+		}
+		points = append(points, &model.TrendPoint{
+			Date:          day.date.Format(time.DateOnly),
+			Value:         roundMeasurement(day.mean()),
+			MovingAverage: roundMeasurement(sum / float64(count)),
+		})
+	}
+
+	var weeks []*measurementDay
+	byStart := map[time.Time]*measurementDay{}
+	for _, day := range days {
+		start := weekStart(day.date)
+		week := byStart[start]
+		if week == nil {
+			week = &measurementDay{date: start}
+			byStart[start] = week
+			weeks = append(weeks, week)
+		}
+		week.sum += day.mean()
+		week.count++
+	}
+
+	weekly := []*model.WeeklyChange{}
+	first := weekStart(from)
+	for _, week := range weeks {
+		if week.date.Before(first) {
+			continue
+		}
+		change := &model.WeeklyChange{Start: week.date.Format(time.DateOnly), Average: roundMeasurement(week.mean())}
+		if previous := byStart[week.date.AddDate(0, 0, -7)]; previous != nil {
+			delta := roundMeasurement(week.mean() - previous.mean())
+			change.Change = &delta
+		}
+		weekly = append(weekly, change)
+	}
+	return points, weekly
+}
@@ -1,9 +1,15 @@
 package service
 
 import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 )
 
 type exerciseService struct {
@@ -16,10 +22,144 @@ func NewExerciseService(er repository.ExerciseRepository) service.ExerciseServic
 	}
 }
 
-func (e *exerciseService) ReadAllExercises() ([]*model.Exercise, error) {
-	return e.exerciseRepository.ReadAllExercises()
+func (e *exerciseService) ReadExercises(req model.ReadExerciseRequest) (*model.ExercisePage, error) {
+	filter, err := newExerciseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// ask for one extra row to know whether there is another page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	exercises, err := e.exerciseRepository.ReadExercises(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ExercisePage{Exercises: exercises}
+	if len(exercises) > limit {
+		page.Exercises = exercises[:limit]
+		last := page.Exercises[limit-1]
+		page.NextCursor = encodeExerciseCursor(model.ExerciseCursor{
+			Sort: filter.Sort,
+			Name: strings.ToLower(last.Name),
+			ID:   last.ID,
+		})
+	}
+	return page, nil
+}
+
+func (e *exerciseService) ReadExerciseByID(viewerID, id int64) (*model.Exercise, error) {
+	exercise, err := e.exerciseRepository.ReadExerciseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// private exercises are reported as missing rather than forbidden so their ids don't leak
+	if !exerciseVisibleTo(exercise, viewerID) {
+		return nil, fmt.Errorf("exercise not found: %w", sql.ErrNoRows)
+	}
+	return exercise, nil
+}
+
+func (e *exerciseService) CreateExercise(req model.CreateExerciseRequest) (*model.Exercise, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", util.ErrInvalidInput)
+	}
+	return e.exerciseRepository.CreateExercise(req)
+}
+
+func (e *exerciseService) UpdateExercise(req model.UpdateExerciseRequest) (*model.Exercise, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", util.ErrInvalidInput)
+	}
+	if err := e.checkOwner(req.OwnerID, req.ID); err != nil {
+		return nil, err
+	}
+	return e.exerciseRepository.UpdateExercise(req)
 }
 
-func (e *exerciseService) ReadExerciseByID(id int64) (*model.Exercise, error) {
-	return e.exerciseRepository.ReadExerciseByID(id)
+func (e *exerciseService) DeleteExercise(req model.DeleteExerciseRequest) error {
+	if err := e.checkOwner(req.OwnerID, req.ID); err != nil {
+		return err
+	}
+	return e.exerciseRepository.DeleteExercise(req.ID)
+}
+
+// checkOwner only lets a user change the custom exercises they authored
+func (e *exerciseService) checkOwner(userID, exerciseID int64) error {
+	exercise, err := e.ReadExerciseByID(userID, exerciseID)
+	if err != nil {
+		return err
+	}
+	if exercise.OwnerID != userID {
+		return fmt.Errorf("%w: only the author can change this exercise", util.ErrForbidden)
+	}
+	if exercise.Deleted {
+		return fmt.Errorf("exercise not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// exerciseVisibleTo reports whether the user may see and use the exercise:
+// the built-in catalogue, shared custom exercises and their own
+func exerciseVisibleTo(exercise *model.Exercise, userID int64) bool {
+	return exercise.OwnerID == 0 || exercise.IsPublic || exercise.OwnerID == userID
+}
+
+func newExerciseFilter(req model.ReadExerciseRequest) (model.ExerciseFilter, error) {
+	filter := model.ExerciseFilter{
+		Target:    strings.TrimSpace(req.Target),
+		Intensity: strings.TrimSpace(req.Intensity),
+		Expertise: strings.TrimSpace(req.Expertise),
+		Search:    strings.TrimSpace(req.Search),
+		Sort:      req.Sort,
+		Limit:     req.Limit,
+		ViewerID:  req.ViewerID,
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = model.ExerciseSortName
+	case model.ExerciseSortName, model.ExerciseSortNameDesc, model.ExerciseSortID, model.ExerciseSortIDDesc:
+	default:
+		return filter, fmt.Errorf("%w: sort must be one of name, -name, id, -id", util.ErrInvalidInput)
+	}
+
+	switch {
+	case filter.Limit < 0:
+		return filter, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case filter.Limit == 0:
+		filter.Limit = model.DefaultExercisePageSize
+	case filter.Limit > model.MaxExercisePageSize:
+		filter.Limit = model.MaxExercisePageSize
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeExerciseCursor(req.Cursor)
+		if err != nil || cursor.Sort != filter.Sort {
+			return filter, fmt.Errorf("%w: cursor is invalid for this sort order", util.ErrInvalidInput)
+		}
+		filter.After = cursor
+	}
+	return filter, nil
+}
+
+// cursors are opaque to clients, they only hand back what the previous page returned
+func encodeExerciseCursor(c model.ExerciseCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeExerciseCursor(s string) (*model.ExerciseCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c model.ExerciseCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
@@ -1,16 +1,20 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/internal/repository/in_memory"
+	"workoutpal/src/util"
 
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
 
 	"github.com/golang/mock/gomock"
 )
 
-func TestExerciseService_ReadAllExercises_OK(t *testing.T) {
+func TestExerciseService_ReadExercises_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
@@ -22,32 +26,130 @@ func TestExerciseService_ReadAllExercises_OK(t *testing.T) {
 		{ID: 2, Name: "Bench Press"},
 	}
 
-	repo.EXPECT().ReadAllExercises().Return(want, nil)
+	repo.EXPECT().
+		ReadExercises(model.ExerciseFilter{Target: "legs", Sort: model.ExerciseSortName, Limit: model.DefaultExercisePageSize + 1}).
+		Return(want, nil)
 
-	got, err := svc.ReadAllExercises()
+	got, err := svc.ReadExercises(model.ReadExerciseRequest{Target: " legs "})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != len(want) || got[0].ID != want[0].ID || got[1].Name != want[1].Name {
+	if len(got.Exercises) != len(want) || got.Exercises[0].ID != want[0].ID || got.NextCursor != "" {
 		t.Fatalf("unexpected result: %#v", got)
 	}
 }
 
-func TestExerciseService_ReadAllExercises_Error(t *testing.T) {
+func TestExerciseService_ReadExercises_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockExerciseRepository(ctrl)
 	svc := NewExerciseService(repo)
 
-	repo.EXPECT().ReadAllExercises().Return(nil, errors.New("db down"))
+	repo.EXPECT().ReadExercises(gomock.Any()).Return(nil, errors.New("db down"))
 
-	_, err := svc.ReadAllExercises()
+	_, err := svc.ReadExercises(model.ReadExerciseRequest{})
 	if err == nil || err.Error() != "db down" {
 		t.Fatalf("expected db down error, got %v", err)
 	}
 }
 
+func TestExerciseService_ReadExercises_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  model.ReadExerciseRequest
+	}{
+		{"unknown sort", model.ReadExerciseRequest{Sort: "popularity"}},
+		{"negative limit", model.ReadExerciseRequest{Limit: -1}},
+		{"garbage cursor", model.ReadExerciseRequest{Cursor: "not a cursor"}},
+		{"cursor from another sort", model.ReadExerciseRequest{
+			Sort:   model.ExerciseSortID,
+			Cursor: encodeExerciseCursor(model.ExerciseCursor{Sort: model.ExerciseSortName, Name: "squats", ID: 3}),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			svc := NewExerciseService(mock_repository.NewMockExerciseRepository(ctrl))
+
+			if _, err := svc.ReadExercises(tt.req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExerciseService_ReadExercises_ClampsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	repo.EXPECT().
+		ReadExercises(model.ExerciseFilter{Sort: model.ExerciseSortID, Limit: model.MaxExercisePageSize + 1}).
+		Return([]*model.Exercise{}, nil)
+
+	if _, err := svc.ReadExercises(model.ReadExerciseRequest{Sort: model.ExerciseSortID, Limit: 10000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// walks every page against the in-memory repository, which shares the postgres semantics
+func TestExerciseService_ReadExercises_Pagination(t *testing.T) {
+	tests := []struct {
+		name string
+		req  model.ReadExerciseRequest
+		want []int64
+	}{
+		{"name ascending", model.ReadExerciseRequest{}, []int64{6, 4, 2, 1, 3}},
+		{"name descending", model.ReadExerciseRequest{Sort: model.ExerciseSortNameDesc}, []int64{3, 1, 2, 4, 6}},
+		{"id descending", model.ReadExerciseRequest{Sort: model.ExerciseSortIDDesc}, []int64{6, 4, 3, 2, 1}},
+		{"target", model.ReadExerciseRequest{Target: "Glutes"}, []int64{4, 3}},
+		{"intensity and expertise", model.ReadExerciseRequest{Intensity: "medium", Expertise: "beginner"}, []int64{1, 3}},
+		{"exercises without an intensity don't match", model.ReadExerciseRequest{ViewerID: 8, Intensity: "HIGH"}, []int64{4, 2}},
+		{"search description", model.ReadExerciseRequest{Search: "BAR"}, []int64{4, 2}},
+		{"no match", model.ReadExerciseRequest{Target: "hamstrings"}, nil},
+		{"own and shared custom exercises", model.ReadExerciseRequest{ViewerID: 8, Target: "calves"}, []int64{6, 5}},
+		{"other users' private exercises are hidden", model.ReadExerciseRequest{ViewerID: 9, Target: "calves"}, []int64{6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := in_memory.NewInMemoryExerciseRepository()
+			_, _ = repo.CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: "Single-leg calf raise", Targets: []string{"calves"}})
+			_, _ = repo.CreateExercise(model.CreateExerciseRequest{OwnerID: 7, Name: "Calf raise", Targets: []string{"calves"}, IsPublic: true})
+			svc := NewExerciseService(repo)
+
+			var got []int64
+			req := tt.req
+			req.Limit = 1
+			for pages := 0; ; pages++ {
+				if pages > 10 {
+					t.Fatalf("pagination does not terminate")
+				}
+				page, err := svc.ReadExercises(req)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, ex := range page.Exercises {
+					got = append(got, ex.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				req.Cursor = page.NextCursor
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExerciseService_ReadExerciseByID_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -58,7 +160,7 @@ func TestExerciseService_ReadExerciseByID_OK(t *testing.T) {
 	want := &model.Exercise{ID: 42, Name: "Deadlift"}
 	repo.EXPECT().ReadExerciseByID(int64(42)).Return(want, nil)
 
-	got, err := svc.ReadExerciseByID(42)
+	got, err := svc.ReadExerciseByID(1, 42)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -76,7 +178,7 @@ func TestExerciseService_ReadExerciseByID_Error(t *testing.T) {
 
 	repo.EXPECT().ReadExerciseByID(int64(7)).Return((*model.Exercise)(nil), errors.New("not found"))
 
-	got, err := svc.ReadExerciseByID(7)
+	got, err := svc.ReadExerciseByID(1, 7)
 	if got != nil {
 		t.Fatalf("expected nil exercise, got %#v", got)
 	}
@@ -84,3 +186,150 @@ func TestExerciseService_ReadExerciseByID_Error(t *testing.T) {
 		t.Fatalf("expected not found error, got %v", err)
 	}
 }
+
+func TestExerciseService_ReadExerciseByID_PrivateIsHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	repo.EXPECT().ReadExerciseByID(int64(50)).Return(&model.Exercise{ID: 50, Custom: true, OwnerID: 8}, nil)
+
+	if _, err := svc.ReadExerciseByID(9, 50); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestExerciseService_CreateExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	repo.EXPECT().
+		CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: "Calf raise"}).
+		Return(&model.Exercise{ID: 50, Name: "Calf raise", Custom: true, OwnerID: 8}, nil)
+
+	got, err := svc.CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: "  Calf raise "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 50 || !got.Custom {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func TestExerciseService_CreateExercise_NameRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := NewExerciseService(mock_repository.NewMockExerciseRepository(ctrl))
+
+	if _, err := svc.CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: " "}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestExerciseService_UpdateExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	req := model.UpdateExerciseRequest{ID: 50, OwnerID: 8, Name: "Calf raise", IsPublic: true}
+	repo.EXPECT().ReadExerciseByID(int64(50)).Return(&model.Exercise{ID: 50, Custom: true, OwnerID: 8}, nil)
+	repo.EXPECT().UpdateExercise(req).Return(&model.Exercise{ID: 50, Name: "Calf raise", IsPublic: true}, nil)
+
+	got, err := svc.UpdateExercise(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.IsPublic {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func TestExerciseService_UpdateExercise_NotOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *model.Exercise
+	}{
+		{"built-in", &model.Exercise{ID: 50}},
+		{"someone else's shared exercise", &model.Exercise{ID: 50, Custom: true, OwnerID: 7, IsPublic: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mock_repository.NewMockExerciseRepository(ctrl)
+			svc := NewExerciseService(repo)
+
+			repo.EXPECT().ReadExerciseByID(int64(50)).Return(tt.existing, nil)
+
+			_, err := svc.UpdateExercise(model.UpdateExerciseRequest{ID: 50, OwnerID: 8, Name: "Mine now"})
+			if !errors.Is(err, util.ErrForbidden) {
+				t.Fatalf("expected ErrForbidden, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExerciseService_DeleteExercise_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	repo.EXPECT().ReadExerciseByID(int64(50)).Return(&model.Exercise{ID: 50, Custom: true, OwnerID: 8}, nil)
+	repo.EXPECT().DeleteExercise(int64(50)).Return(nil)
+
+	if err := svc.DeleteExercise(model.DeleteExerciseRequest{ID: 50, OwnerID: 8}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExerciseService_DeleteExercise_PrivateOfOtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseRepository(ctrl)
+	svc := NewExerciseService(repo)
+
+	repo.EXPECT().ReadExerciseByID(int64(50)).Return(&model.Exercise{ID: 50, Custom: true, OwnerID: 7}, nil)
+
+	if err := svc.DeleteExercise(model.DeleteExerciseRequest{ID: 50, OwnerID: 8}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// a deleted exercise leaves the listings but stays readable for the history that uses it
+func TestExerciseService_DeleteExercise_KeepsHistory(t *testing.T) {
+	repo := in_memory.NewInMemoryExerciseRepository()
+	svc := NewExerciseService(repo)
+	created, _ := repo.CreateExercise(model.CreateExerciseRequest{OwnerID: 8, Name: "Calf raise", Targets: []string{"calves"}, IsPublic: true})
+
+	if err := svc.DeleteExercise(model.DeleteExerciseRequest{ID: created.ID, OwnerID: 8}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	page, err := svc.ReadExercises(model.ReadExerciseRequest{ViewerID: 9, Target: "calves"})
+	if err != nil || len(page.Exercises) != 0 {
+		t.Fatalf("deleted exercise still listed: %#v, %v", page, err)
+	}
+	got, err := svc.ReadExerciseByID(9, created.ID)
+	if err != nil || !got.Deleted {
+		t.Fatalf("want the exercise flagged deleted, got %#v, %v", got, err)
+	}
+	if err := svc.DeleteExercise(model.DeleteExerciseRequest{ID: created.ID, OwnerID: 8}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("want a second delete to find nothing, got %v", err)
+	}
+	if _, err := svc.UpdateExercise(model.UpdateExerciseRequest{ID: created.ID, OwnerID: 8, Name: "Calf raise"}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("want a deleted exercise left unchanged, got %v", err)
+	}
+}
@@ -8,10 +8,12 @@ import (
 
 type exerciseSettingService struct {
 	exerciseSettingRepository repository.ExerciseSettingRepository
+	routineRepository         repository.RoutineRepository
+	policy                    service.AccessPolicy
 }
 
-func NewExerciseSettingService(exerciseSettingRepository repository.ExerciseSettingRepository) service.ExerciseSettingService {
-	return &exerciseSettingService{exerciseSettingRepository: exerciseSettingRepository}
+func NewExerciseSettingService(exerciseSettingRepository repository.ExerciseSettingRepository, routineRepository repository.RoutineRepository, policy service.AccessPolicy) service.ExerciseSettingService {
+	return &exerciseSettingService{exerciseSettingRepository: exerciseSettingRepository, routineRepository: routineRepository, policy: policy}
 }
 
 func (s *exerciseSettingService) ReadExerciseSetting(req model.ReadExerciseSettingRequest) (*model.ExerciseSetting, error) {
@@ -19,9 +21,24 @@ func (s *exerciseSettingService) ReadExerciseSetting(req model.ReadExerciseSetti
 }
 
 func (s *exerciseSettingService) CreateExerciseSetting(req model.CreateExerciseSettingRequest) (*model.ExerciseSetting, error) {
+	if err := s.checkRoutineOwner(req.UserID, req.WorkoutRoutineID); err != nil {
+		return nil, err
+	}
 	return s.exerciseSettingRepository.CreateExerciseSetting(req)
 }
 
 func (s *exerciseSettingService) UpdateExerciseSetting(req model.UpdateExerciseSettingRequest) (*model.ExerciseSetting, error) {
+	if err := s.checkRoutineOwner(req.UserID, req.WorkoutRoutineID); err != nil {
+		return nil, err
+	}
 	return s.exerciseSettingRepository.UpdateExerciseSetting(req)
 }
+
+// checkRoutineOwner stops settings being attached to someone else's routine
+func (s *exerciseSettingService) checkRoutineOwner(userID, routineID int64) error {
+	routine, err := s.routineRepository.ReadRoutineWithExercises(routineID)
+	if err != nil {
+		return err
+	}
+	return s.policy.CanModify(userID, routine.UserID)
+}
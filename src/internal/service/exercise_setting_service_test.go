@@ -2,20 +2,39 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"workoutpal/src/internal/model"
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 
 	"github.com/golang/mock/gomock"
 )
 
+func newExerciseSettingServiceMocks(t *testing.T) (*mock_repository.MockExerciseSettingRepository, *mock_repository.MockRoutineRepository, *mock_service.MockAccessPolicy, *exerciseSettingService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
+	routines := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	return repo, routines, policy, &exerciseSettingService{exerciseSettingRepository: repo, routineRepository: routines, policy: policy}
+}
+
+// expectOwnRoutine lets user 1 use routine 3, the routine these tests work on
+func expectOwnRoutine(routines *mock_repository.MockRoutineRepository, policy *mock_service.MockAccessPolicy) {
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).Return(&model.ExerciseRoutine{ID: 3, UserID: 1}, nil)
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+}
+
 func TestExerciseSettingService_ReadExerciseSetting_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	svc := NewExerciseSettingService(repo, nil, nil)
 
 	req := model.ReadExerciseSettingRequest{
 		UserID:           1,
@@ -50,7 +69,7 @@ func TestExerciseSettingService_ReadExerciseSetting_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	svc := NewExerciseSettingService(repo, nil, nil)
 
 	req := model.ReadExerciseSettingRequest{
 		UserID:           1,
@@ -72,11 +91,8 @@ func TestExerciseSettingService_ReadExerciseSetting_Error(t *testing.T) {
 }
 
 func TestExerciseSettingService_CreateExerciseSetting_OK(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	repo, routines, policy, svc := newExerciseSettingServiceMocks(t)
+	expectOwnRoutine(routines, policy)
 
 	req := model.CreateExerciseSettingRequest{
 		UserID:           1,
@@ -111,11 +127,8 @@ func TestExerciseSettingService_CreateExerciseSetting_OK(t *testing.T) {
 }
 
 func TestExerciseSettingService_CreateExerciseSetting_Error(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	repo, routines, policy, svc := newExerciseSettingServiceMocks(t)
+	expectOwnRoutine(routines, policy)
 
 	req := model.CreateExerciseSettingRequest{
 		UserID:           1,
@@ -137,11 +150,8 @@ func TestExerciseSettingService_CreateExerciseSetting_Error(t *testing.T) {
 }
 
 func TestExerciseSettingService_UpdateExerciseSetting_OK(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	repo, routines, policy, svc := newExerciseSettingServiceMocks(t)
+	expectOwnRoutine(routines, policy)
 
 	req := model.UpdateExerciseSettingRequest{
 		UserID:           1,
@@ -176,11 +186,8 @@ func TestExerciseSettingService_UpdateExerciseSetting_OK(t *testing.T) {
 }
 
 func TestExerciseSettingService_UpdateExerciseSetting_Error(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	repo := mock_repository.NewMockExerciseSettingRepository(ctrl)
-	svc := NewExerciseSettingService(repo)
+	repo, routines, policy, svc := newExerciseSettingServiceMocks(t)
+	expectOwnRoutine(routines, policy)
 
 	req := model.UpdateExerciseSettingRequest{
 		UserID:           1,
@@ -200,3 +207,17 @@ func TestExerciseSettingService_UpdateExerciseSetting_Error(t *testing.T) {
 		t.Fatalf("expected update fail, got %v", err)
 	}
 }
+
+func TestExerciseSettingService_CreateExerciseSetting_OtherUsersRoutine(t *testing.T) {
+	_, routines, policy, svc := newExerciseSettingServiceMocks(t)
+
+	req := model.CreateExerciseSettingRequest{UserID: 1, ExerciseID: 2, WorkoutRoutineID: 3}
+
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).Return(&model.ExerciseRoutine{ID: 3, UserID: 9}, nil)
+	policy.EXPECT().CanModify(int64(1), int64(9)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	_, err := svc.CreateExerciseSetting(req)
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
@@ -1,23 +1,215 @@
 package service
 
 import (
+	"fmt"
+	"log"
+	"math"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 )
 
 type goalService struct {
 	goalRepository repository.GoalRepository
+	policy         service.AccessPolicy
+	now            func() time.Time
 }
 
-func NewGoalService(goalRepository repository.GoalRepository) service.GoalService {
-	return &goalService{goalRepository: goalRepository}
+func NewGoalService(goalRepository repository.GoalRepository, policy service.AccessPolicy) service.GoalService {
+	return &goalService{goalRepository: goalRepository, policy: policy, now: time.Now}
 }
 
-func (u *goalService) CreateGoal(userID int64, request model.CreateGoalRequest) (*model.Goal, error) {
-	return u.goalRepository.CreateGoal(userID, request)
+func (u *goalService) CreateGoal(actorID, userID int64, request model.CreateGoalRequest) (*model.Goal, error) {
+	if err := u.policy.CanModify(actorID, userID); err != nil {
+		return nil, err
+	}
+	if err := checkNewGoal(&request); err != nil {
+		return nil, err
+	}
+
+	goal, err := u.goalRepository.CreateGoal(userID, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.settleGoals([]*model.Goal{goal}); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (u *goalService) ReadUserGoals(actorID, userID int64) ([]*model.Goal, error) {
+	if err := u.policy.CanView(actorID, userID); err != nil {
+		return nil, err
+	}
+	goals, err := u.goalRepository.ReadUserGoals(userID)
+	if err != nil {
+		return nil, err
+	}
+	// completion is settled when the data changes, reading only reports it
+	measureGoals(goals)
+	return goals, nil
+}
+
+func (u *goalService) UpdateGoal(actorID, userID int64, request model.UpdateGoalRequest) (*model.Goal, error) {
+	if err := u.policy.CanModify(actorID, userID); err != nil {
+		return nil, err
+	}
+	if request.Name == nil && request.Description == nil && request.Deadline == nil && request.Status == nil && request.TargetValue == nil {
+		return nil, fmt.Errorf("%w: nothing to update", util.ErrInvalidInput)
+	}
+	if request.Status != nil {
+		switch *request.Status {
+		case model.GoalStatusActive, model.GoalStatusCompleted, model.GoalStatusPaused:
+		default:
+			return nil, fmt.Errorf("%w: status must be active, completed or paused", util.ErrInvalidInput)
+		}
+	}
+	if request.TargetValue != nil && *request.TargetValue <= 0 {
+		return nil, fmt.Errorf("%w: targetValue must be positive", util.ErrInvalidInput)
+	}
+
+	request.UserID = userID
+	goal, err := u.goalRepository.UpdateGoal(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.settleGoals([]*model.Goal{goal}); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (u *goalService) DeleteGoal(actorID, userID, goalID int64) error {
+	if err := u.policy.CanModify(actorID, userID); err != nil {
+		return err
+	}
+	return u.goalRepository.DeleteGoal(userID, goalID)
+}
+
+// SettleGoals completes the user's active goals whose target the logged data
+// now meets
+func (u *goalService) SettleGoals(userID int64) error {
+	goals, err := u.goalRepository.ReadUserGoals(userID)
+	if err != nil {
+		return err
+	}
+	return u.settleGoals(goals)
+}
+
+// settleGoals works out each measurable goal's progress and completes the
+// active ones whose target has been met
+func (u *goalService) settleGoals(goals []*model.Goal) error {
+	met := measureGoals(goals)
+	if len(met) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(met))
+	for _, goal := range met {
+		ids = append(ids, goal.ID)
+	}
+	if err := u.goalRepository.CompleteGoals(ids); err != nil {
+		return err
+	}
+	completedAt := u.now().UTC()
+	for _, goal := range met {
+		goal.Status = model.GoalStatusCompleted
+		goal.CompletedAt = &completedAt
+	}
+	return nil
+}
+
+// measureGoals fills in each measurable goal's progress and returns the active
+// ones whose target has been met
+func measureGoals(goals []*model.Goal) []*model.Goal {
+	var met []*model.Goal
+	for _, goal := range goals {
+		progress, reached, ok := goalProgress(goal)
+		if !ok {
+			continue
+		}
+		goal.Progress = &progress
+		if reached && goal.Status == model.GoalStatusActive {
+			met = append(met, goal)
+		}
+	}
+	return met
+}
+
+// notifyGoals settles the user's goals after an action; the action already
+// happened, so a failure is only logged
+func notifyGoals(settler service.GoalSettler, userID int64) {
+	if settler == nil {
+		return
+	}
+	if err := settler.SettleGoals(userID); err != nil {
+		log.Printf("goals for user %d: %v", userID, err)
+	}
 }
 
-func (u *goalService) ReadUserGoals(userID int64) ([]*model.Goal, error) {
-	return u.goalRepository.ReadUserGoals(userID)
+func checkNewGoal(request *model.CreateGoalRequest) error {
+	switch request.Type {
+	case "", model.GoalTypeCustom:
+		request.Type = model.GoalTypeCustom
+		request.ExerciseID = 0
+		request.TargetValue = 0
+		return nil
+	case model.GoalTypeBodyWeight, model.GoalTypeWorkoutsPerWeek:
+		if request.ExerciseID != 0 {
+			return fmt.Errorf("%w: only one_rep_max goals take an exerciseId", util.ErrInvalidInput)
+		}
+	case model.GoalTypeOneRepMax:
+		if request.ExerciseID <= 0 {
+			return fmt.Errorf("%w: one_rep_max goals need an exerciseId", util.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: type must be custom, body_weight, one_rep_max or workouts_per_week", util.ErrInvalidInput)
+	}
+
+	if request.TargetValue <= 0 {
+		return fmt.Errorf("%w: targetValue must be positive", util.ErrInvalidInput)
+	}
+	if request.Type == model.GoalTypeWorkoutsPerWeek && request.TargetValue != math.Trunc(request.TargetValue) {
+		return fmt.Errorf("%w: targetValue must be a whole number of workouts", util.ErrInvalidInput)
+	}
+	return nil
+}
+
+// goalProgress returns how far along a measurable goal is, from 0 to 100,
+// and whether its target is met. ok is false for custom goals.
+func goalProgress(goal *model.Goal) (progress float64, met bool, ok bool) {
+	if goal.Type == model.GoalTypeCustom || goal.TargetValue == nil {
+		return 0, false, false
+	}
+	if goal.Status == model.GoalStatusCompleted {
+		return 100, true, true
+	}
+	if goal.CurrentValue == nil {
+		return 0, false, true
+	}
+
+	target, current := *goal.TargetValue, *goal.CurrentValue
+	var fraction float64
+	switch goal.Type {
+	case model.GoalTypeBodyWeight:
+		// losing or gaining, measured from the weight when the goal was set
+		start := current
+		if goal.StartValue != nil {
+			start = *goal.StartValue
+		}
+		if start == target {
+			if current == target {
+				fraction = 1
+			}
+		} else {
+			fraction = (start - current) / (start - target)
+		}
+	default:
+		fraction = current / target
+	}
+
+	fraction = math.Max(0, math.Min(1, fraction))
+	return math.Round(fraction*1000) / 10, fraction == 1, true
 }
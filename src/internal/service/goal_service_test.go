@@ -2,10 +2,15 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 	"workoutpal/src/internal/model"
 
+	"workoutpal/src/util"
+
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
 
 	"github.com/golang/mock/gomock"
 )
@@ -15,7 +20,8 @@ func TestGoalService_CreateGoal_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockGoalRepository(ctrl)
-	svc := NewGoalService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
 
 	const userID int64 = 1
 	req := model.CreateGoalRequest{
@@ -32,11 +38,12 @@ func TestGoalService_CreateGoal_OK(t *testing.T) {
 		CreatedAt:   "2025-10-15T00:00:00Z",
 	}
 
+	policy.EXPECT().CanModify(userID, userID).Return(nil)
 	repo.EXPECT().
 		CreateGoal(userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
 		Return(want, nil)
 
-	got, err := svc.CreateGoal(userID, req)
+	got, err := svc.CreateGoal(userID, userID, req)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -52,15 +59,17 @@ func TestGoalService_CreateGoal_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockGoalRepository(ctrl)
-	svc := NewGoalService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
 
 	const userID int64 = 2
 	req := model.CreateGoalRequest{Name: "Bench 225"}
+	policy.EXPECT().CanModify(userID, userID).Return(nil)
 	repo.EXPECT().
 		CreateGoal(userID, gomock.AssignableToTypeOf(model.CreateGoalRequest{})).
 		Return((*model.Goal)(nil), errors.New("validation failed"))
 
-	got, err := svc.CreateGoal(userID, req)
+	got, err := svc.CreateGoal(userID, userID, req)
 	if got != nil {
 		t.Fatalf("expected nil goal, got %#v", got)
 	}
@@ -74,16 +83,18 @@ func TestGoalService_ReadUserGoals_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockGoalRepository(ctrl)
-	svc := NewGoalService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
 
 	const userID int64 = 3
 	want := []*model.Goal{
 		{ID: 1, UserID: userID, Name: "Lose 5lb", Status: "active"},
 		{ID: 2, UserID: userID, Name: "Deadlift 180kg", Status: "paused"},
 	}
+	policy.EXPECT().CanView(userID, userID).Return(nil)
 	repo.EXPECT().ReadUserGoals(userID).Return(want, nil)
 
-	got, err := svc.ReadUserGoals(userID)
+	got, err := svc.ReadUserGoals(userID, userID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -91,3 +102,190 @@ func TestGoalService_ReadUserGoals_OK(t *testing.T) {
 		t.Fatalf("unexpected goals: %#v", got)
 	}
 }
+
+func TestGoalService_CreateGoal_ForAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
+
+	policy.EXPECT().CanModify(int64(2), int64(5)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	_, err := svc.CreateGoal(2, 5, model.CreateGoalRequest{Name: "Bench 225"})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func goalFloat(v float64) *float64 { return &v }
+
+func TestGoalProgress(t *testing.T) {
+	cases := []struct {
+		name     string
+		goal     model.Goal
+		progress float64
+		met      bool
+		ok       bool
+	}{
+		{"custom", model.Goal{Type: model.GoalTypeCustom}, 0, false, false},
+		{"losing weight", model.Goal{Type: model.GoalTypeBodyWeight, TargetValue: goalFloat(75), StartValue: goalFloat(80), CurrentValue: goalFloat(78)}, 40, false, true},
+		{"gaining weight", model.Goal{Type: model.GoalTypeBodyWeight, TargetValue: goalFloat(70), StartValue: goalFloat(60), CurrentValue: goalFloat(72)}, 100, true, true},
+		{"moving away", model.Goal{Type: model.GoalTypeBodyWeight, TargetValue: goalFloat(75), StartValue: goalFloat(80), CurrentValue: goalFloat(82)}, 0, false, true},
+		{"no weight logged", model.Goal{Type: model.GoalTypeBodyWeight, TargetValue: goalFloat(75)}, 0, false, true},
+		{"1RM short", model.Goal{Type: model.GoalTypeOneRepMax, TargetValue: goalFloat(120), CurrentValue: goalFloat(90)}, 75, false, true},
+		{"workouts done", model.Goal{Type: model.GoalTypeWorkoutsPerWeek, TargetValue: goalFloat(3), CurrentValue: goalFloat(3)}, 100, true, true},
+		{"workouts so far", model.Goal{Type: model.GoalTypeWorkoutsPerWeek, TargetValue: goalFloat(3), CurrentValue: goalFloat(1)}, 33.3, false, true},
+		// a completed goal stays complete even if the data slips back
+		{"completed", model.Goal{Type: model.GoalTypeWorkoutsPerWeek, Status: model.GoalStatusCompleted, TargetValue: goalFloat(3), CurrentValue: goalFloat(0)}, 100, true, true},
+	}
+	for _, tc := range cases {
+		progress, met, ok := goalProgress(&tc.goal)
+		if progress != tc.progress || met != tc.met || ok != tc.ok {
+			t.Errorf("%s: got (%v, %v, %v), want (%v, %v, %v)", tc.name, progress, met, ok, tc.progress, tc.met, tc.ok)
+		}
+	}
+}
+
+func settleFixtureGoals() []*model.Goal {
+	return []*model.Goal{
+		{ID: 1, Status: "active", Type: model.GoalTypeOneRepMax, TargetValue: goalFloat(100), CurrentValue: goalFloat(102.5)},
+		{ID: 2, Status: "active", Type: model.GoalTypeWorkoutsPerWeek, TargetValue: goalFloat(4), CurrentValue: goalFloat(2)},
+		// paused goals keep their status
+		{ID: 3, Status: "paused", Type: model.GoalTypeWorkoutsPerWeek, TargetValue: goalFloat(1), CurrentValue: goalFloat(1)},
+		{ID: 4, Status: "active", Type: model.GoalTypeCustom},
+	}
+}
+
+func TestGoalService_SettleGoals_CompletesMetGoals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	svc := &goalService{goalRepository: repo, now: func() time.Time { return time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC) }}
+
+	repo.EXPECT().ReadUserGoals(int64(3)).Return(settleFixtureGoals(), nil)
+	repo.EXPECT().CompleteGoals([]int64{1}).Return(nil)
+
+	if err := svc.SettleGoals(3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGoalService_ReadUserGoals_OnlyReportsProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
+
+	policy.EXPECT().CanView(int64(3), int64(3)).Return(nil)
+	// no CompleteGoals: reading never writes
+	repo.EXPECT().ReadUserGoals(int64(3)).Return(settleFixtureGoals(), nil)
+
+	got, err := svc.ReadUserGoals(3, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].Status != "active" || got[0].CompletedAt != nil || *got[0].Progress != 100 {
+		t.Fatalf("expected goal 1 at its target but not completed, got %+v", got[0])
+	}
+	if got[1].Status != "active" || *got[1].Progress != 50 {
+		t.Fatalf("expected goal 2 half way, got %+v", got[1])
+	}
+	if got[2].Status != "paused" || got[3].Progress != nil {
+		t.Fatalf("unexpected goals %+v %+v", got[2], got[3])
+	}
+}
+
+func TestGoalService_CreateGoal_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil).AnyTimes()
+	svc := NewGoalService(mock_repository.NewMockGoalRepository(ctrl), policy)
+
+	for _, req := range []model.CreateGoalRequest{
+		{Type: "marathon"},
+		{Type: model.GoalTypeBodyWeight},
+		{Type: model.GoalTypeBodyWeight, TargetValue: -70},
+		{Type: model.GoalTypeOneRepMax, TargetValue: 100},
+		{Type: model.GoalTypeWorkoutsPerWeek, TargetValue: 3, ExerciseID: 4},
+		{Type: model.GoalTypeWorkoutsPerWeek, TargetValue: 2.5},
+	} {
+		if _, err := svc.CreateGoal(1, 1, req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", req, err)
+		}
+	}
+}
+
+func TestGoalService_CreateGoal_DefaultsToCustom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
+
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
+	// a custom goal drops any target it was sent
+	repo.EXPECT().CreateGoal(int64(1), model.CreateGoalRequest{Name: "Stretch daily", Type: model.GoalTypeCustom}).
+		Return(&model.Goal{ID: 5, Type: model.GoalTypeCustom, Status: "active"}, nil)
+
+	if _, err := svc.CreateGoal(1, 1, model.CreateGoalRequest{Name: "Stretch daily", TargetValue: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGoalService_UpdateGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
+	policy.EXPECT().CanModify(int64(3), int64(3)).Return(nil).AnyTimes()
+
+	if _, err := svc.UpdateGoal(3, 3, model.UpdateGoalRequest{ID: 1}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for an empty update, got %v", err)
+	}
+	done := "done"
+	if _, err := svc.UpdateGoal(3, 3, model.UpdateGoalRequest{ID: 1, Status: &done}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for an unknown status, got %v", err)
+	}
+
+	target := 85.0
+	repo.EXPECT().UpdateGoal(model.UpdateGoalRequest{ID: 1, UserID: 3, TargetValue: &target}).
+		Return(&model.Goal{ID: 1, Status: "active", Type: model.GoalTypeBodyWeight, TargetValue: &target, StartValue: goalFloat(80), CurrentValue: goalFloat(81)}, nil)
+
+	got, err := svc.UpdateGoal(3, 3, model.UpdateGoalRequest{ID: 1, TargetValue: &target})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got.Progress != 20 {
+		t.Fatalf("expected 20%% progress, got %v", *got.Progress)
+	}
+}
+
+func TestGoalService_DeleteGoal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockGoalRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewGoalService(repo, policy)
+
+	policy.EXPECT().CanModify(int64(2), int64(5)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+	if err := svc.DeleteGoal(2, 5, 1); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+
+	policy.EXPECT().CanModify(int64(5), int64(5)).Return(nil)
+	repo.EXPECT().DeleteGoal(int64(5), int64(1)).Return(nil)
+	if err := svc.DeleteGoal(5, 5, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
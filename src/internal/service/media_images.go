@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+// the formats the standard library can decode, so every upload gets checked
+// and thumbnailed; the content type comes from the bytes, not the client
+var mediaFormats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// processedImage is an upload that checked out, with the thumbnail to store
+// alongside it when the image is bigger than one
+type processedImage struct {
+	contentType   string
+	width         int
+	height        int
+	thumbnail     []byte
+	thumbnailType string
+}
+
+func processImage(data []byte) (*processedImage, error) {
+	contentType := http.DetectContentType(data)
+	format, ok := mediaFormats[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and GIF images can be uploaded", util.ErrInvalidInput)
+	}
+
+	// read the size first so a small file can't make us decode a huge image
+	config, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decodedFormat != format {
+		return nil, fmt.Errorf("%w: the file is not a valid image", util.ErrInvalidInput)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("%w: the image is empty", util.ErrInvalidInput)
+	}
+	if config.Width*config.Height > model.MaxMediaPixels {
+		return nil, fmt.Errorf("%w: the image can have at most %d pixels", util.ErrTooLarge, model.MaxMediaPixels)
+	}
+
+	// decode it all, so a file that is cut short or corrupt isn't stored;
+	// a GIF's thumbnail is its first frame
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: the file is not a valid image", util.ErrInvalidInput)
+	}
+
+	processed := &processedImage{contentType: contentType, width: config.Width, height: config.Height}
+	if config.Width <= model.ThumbnailSize && config.Height <= model.ThumbnailSize {
+		return processed, nil
+	}
+	thumbnail := scaleDown(img, model.ThumbnailSize)
+
+	// photos stay JPEG, anything that may be transparent becomes PNG
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		processed.thumbnailType = "image/jpeg"
+		err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85})
+	} else {
+		processed.thumbnailType = "image/png"
+		err = png.Encode(&buf, thumbnail)
+	}
+	if err != nil {
+		return nil, err
+	}
+	processed.thumbnail = buf.Bytes()
+	return processed, nil
+}
+
+// scaleDown shrinks img to fit in a size x size box, keeping its aspect ratio.
+// Each thumbnail pixel averages the source pixels it covers; RGBA is
+// premultiplied, so transparent pixels don't bleed their color into the average.
+func scaleDown(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := size, size
+	if srcW > srcH {
+		dstH = max(1, srcH*size/srcW)
+	} else {
+		dstW = max(1, srcW*size/srcH)
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
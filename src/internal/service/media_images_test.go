@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"workoutpal/src/util"
+)
+
+func encodedImage(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buf, img)
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("encode %s: %v", format, err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessImage(t *testing.T) {
+	cases := []struct {
+		format        string
+		width, height int
+		contentType   string
+		thumbnailType string
+		thumbW        int
+		thumbH        int
+	}{
+		{"png", 640, 320, "image/png", "image/png", 320, 160},
+		{"jpeg", 400, 800, "image/jpeg", "image/jpeg", 160, 320},
+		{"gif", 500, 500, "image/gif", "image/png", 320, 320},
+		// small enough to be its own thumbnail
+		{"jpeg", 100, 60, "image/jpeg", "", 0, 0},
+	}
+	for _, tc := range cases {
+		got, err := processImage(encodedImage(t, tc.format, tc.width, tc.height))
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", tc.format, err)
+		}
+		if got.contentType != tc.contentType || got.width != tc.width || got.height != tc.height || got.thumbnailType != tc.thumbnailType {
+			t.Fatalf("%s: got %s %dx%d thumbnail %q", tc.format, got.contentType, got.width, got.height, got.thumbnailType)
+		}
+		if tc.thumbnailType == "" {
+			if got.thumbnail != nil {
+				t.Fatalf("%s: expected no thumbnail", tc.format)
+			}
+			continue
+		}
+		thumb, _, err := image.DecodeConfig(bytes.NewReader(got.thumbnail))
+		if err != nil || thumb.Width != tc.thumbW || thumb.Height != tc.thumbH {
+			t.Fatalf("%s: thumbnail %dx%d err=%v, want %dx%d", tc.format, thumb.Width, thumb.Height, err, tc.thumbW, tc.thumbH)
+		}
+	}
+}
+
+func TestProcessImage_Rejects(t *testing.T) {
+	valid := encodedImage(t, "png", 10, 10)
+	// a GIF header claiming 6000x6000 pixels and nothing else
+	bomb := []byte{'G', 'I', 'F', '8', '9', 'a', 0x70, 0x17, 0x70, 0x17, 0, 0, 0, 0x3b}
+
+	cases := map[string]struct {
+		data    []byte
+		wantErr error
+	}{
+		"text":      {[]byte("just some text, not an image"), util.ErrInvalidInput},
+		"truncated": {valid[:len(valid)/2], util.ErrInvalidInput},
+		"svg":       {[]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), util.ErrInvalidInput},
+		"too big":   {bomb, util.ErrTooLarge},
+	}
+	for name, tc := range cases {
+		if _, err := processImage(tc.data); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: expected %v, got %v", name, tc.wantErr, err)
+		}
+	}
+}
+
+func TestScaleDown_AveragesCoveredPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			if x%2 == 1 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	got := scaleDown(src, 2)
+
+	if got.Bounds().Dx() != 2 || got.Bounds().Dy() != 1 {
+		t.Fatalf("size = %v, want 2x1", got.Bounds())
+	}
+	for x := 0; x < 2; x++ {
+		if c := got.RGBAAt(x, 0); c.R != 128 || c.A != 255 {
+			t.Fatalf("pixel %d = %+v, want mid gray", x, c)
+		}
+	}
+}
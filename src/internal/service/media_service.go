@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+type MediaService struct {
+	repo   repository.MediaRepository
+	users  repository.UserRepository
+	blobs  repository.BlobStore
+	policy service.AccessPolicy
+}
+
+func NewMediaService(repo repository.MediaRepository, users repository.UserRepository, blobs repository.BlobStore, policy service.AccessPolicy) service.MediaService {
+	return &MediaService{repo: repo, users: users, blobs: blobs, policy: policy}
+}
+
+// UploadMedia checks an image and stores it, along with a thumbnail when it
+// is bigger than one
+func (s *MediaService) UploadMedia(ctx context.Context, ownerID int64, file io.Reader) (*model.Media, error) {
+	data, err := io.ReadAll(io.LimitReader(file, model.MaxMediaBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", util.ErrInvalidInput)
+	}
+	if len(data) > model.MaxMediaBytes {
+		return nil, fmt.Errorf("%w: images can be at most %d MB", util.ErrTooLarge, model.MaxMediaBytes>>20)
+	}
+
+	processed, err := processImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 128 random bits; knowing the id is what lets someone fetch the image
+	media := &model.Media{
+		ID:            randomToken(16),
+		OwnerID:       ownerID,
+		ContentType:   processed.contentType,
+		Size:          int64(len(data)),
+		Width:         processed.width,
+		Height:        processed.height,
+		ThumbnailType: processed.thumbnailType,
+	}
+	if err := s.blobs.Put(ctx, media.BlobKey(), media.ContentType, data); err != nil {
+		return nil, err
+	}
+	if processed.thumbnail != nil {
+		if err := s.blobs.Put(ctx, media.ThumbnailKey(), media.ThumbnailType, processed.thumbnail); err != nil {
+			s.removeBlobs(ctx, media)
+			return nil, err
+		}
+	}
+
+	created, err := s.repo.CreateMedia(media)
+	if err != nil {
+		s.removeBlobs(ctx, media)
+		return nil, err
+	}
+	return created, nil
+}
+
+// removeBlobs is best effort; a blob left behind only costs space
+func (s *MediaService) removeBlobs(ctx context.Context, media *model.Media) {
+	_ = s.blobs.Delete(ctx, media.BlobKey())
+	if media.ThumbnailType != "" {
+		_ = s.blobs.Delete(ctx, media.ThumbnailKey())
+	}
+}
+
+// ReadMedia opens an image or its thumbnail. An image too small to need a
+// thumbnail is its own.
+func (s *MediaService) ReadMedia(ctx context.Context, id string, thumbnail bool) (*model.MediaContent, error) {
+	media, err := s.repo.ReadMediaByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	key, contentType := media.BlobKey(), media.ContentType
+	if thumbnail && media.ThumbnailType != "" {
+		key, contentType = media.ThumbnailKey(), media.ThumbnailType
+	}
+	body, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("media %s has no stored file: %w", id, sql.ErrNoRows)
+		}
+		return nil, err
+	}
+	return &model.MediaContent{Media: media, ContentType: contentType, Body: body}, nil
+}
+
+// SetAvatar makes one of the user's own uploads their avatar
+func (s *MediaService) SetAvatar(actorID, userID int64, mediaID string) (*model.Media, error) {
+	if err := s.policy.CanModify(actorID, userID); err != nil {
+		return nil, err
+	}
+	if mediaID == "" {
+		return nil, fmt.Errorf("%w: mediaId is required", util.ErrInvalidInput)
+	}
+	media, err := s.repo.ReadMediaByID(mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.OwnerID != userID {
+		return nil, fmt.Errorf("%w: the avatar must be one of the user's own uploads", util.ErrForbidden)
+	}
+
+	if err := s.users.UpdateAvatarMedia(userID, media.ID); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
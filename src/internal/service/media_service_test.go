@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"workoutpal/src/internal/model"
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+type mediaMocks struct {
+	repo   *mock_repository.MockMediaRepository
+	users  *mock_repository.MockUserRepository
+	blobs  *mock_repository.MockBlobStore
+	policy *mock_service.MockAccessPolicy
+}
+
+func newMediaService(t *testing.T) (*mediaMocks, *MediaService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	m := &mediaMocks{
+		repo:   mock_repository.NewMockMediaRepository(ctrl),
+		users:  mock_repository.NewMockUserRepository(ctrl),
+		blobs:  mock_repository.NewMockBlobStore(ctrl),
+		policy: mock_service.NewMockAccessPolicy(ctrl),
+	}
+	return m, &MediaService{repo: m.repo, users: m.users, blobs: m.blobs, policy: m.policy}
+}
+
+func TestMediaService_UploadMedia_StoresImageAndThumbnail(t *testing.T) {
+	m, svc := newMediaService(t)
+	data := encodedImage(t, "jpeg", 640, 480)
+
+	var stored *model.Media
+	m.blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/jpeg", data).Return(nil)
+	m.blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/jpeg", gomock.Any()).Return(nil)
+	m.repo.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(media *model.Media) (*model.Media, error) {
+		stored = media
+		return media, nil
+	})
+
+	got, err := svc.UploadMedia(context.Background(), 4, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got != stored || got.OwnerID != 4 || got.Width != 640 || got.Height != 480 || got.Size != int64(len(data)) || got.ThumbnailType != "image/jpeg" {
+		t.Fatalf("unexpected media %+v", got)
+	}
+	if len(got.ID) < 20 {
+		t.Fatalf("media ids must be hard to guess, got %q", got.ID)
+	}
+}
+
+func TestMediaService_UploadMedia_TooLarge(t *testing.T) {
+	_, svc := newMediaService(t)
+
+	_, err := svc.UploadMedia(context.Background(), 4, io.LimitReader(zeroReader{}, model.MaxMediaBytes+1))
+	if !errors.Is(err, util.ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestMediaService_UploadMedia_RemovesBlobsWhenTheRowFails(t *testing.T) {
+	m, svc := newMediaService(t)
+	data := encodedImage(t, "png", 32, 32)
+
+	var key string
+	m.blobs.EXPECT().Put(gomock.Any(), gomock.Any(), "image/png", data).DoAndReturn(func(_ context.Context, k, _ string, _ []byte) error {
+		key = k
+		return nil
+	})
+	m.repo.EXPECT().CreateMedia(gomock.Any()).Return(nil, errors.New("db down"))
+	m.blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string) error {
+		if k != key {
+			t.Errorf("deleted %q, want %q", k, key)
+		}
+		return nil
+	})
+
+	if _, err := svc.UploadMedia(context.Background(), 4, bytes.NewReader(data)); err == nil || err.Error() != "db down" {
+		t.Fatalf("expected db down, got %v", err)
+	}
+}
+
+func TestMediaService_ReadMedia(t *testing.T) {
+	cases := []struct {
+		name      string
+		media     *model.Media
+		thumbnail bool
+		wantKey   string
+		wantType  string
+	}{
+		{"original", &model.Media{ID: "big", ContentType: "image/gif", ThumbnailType: "image/png"}, false, "media/big/original", "image/gif"},
+		{"thumbnail", &model.Media{ID: "big", ContentType: "image/gif", ThumbnailType: "image/png"}, true, "media/big/thumbnail", "image/png"},
+		{"small image", &model.Media{ID: "small", ContentType: "image/jpeg"}, true, "media/small/original", "image/jpeg"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, svc := newMediaService(t)
+			m.repo.EXPECT().ReadMediaByID(tc.media.ID).Return(tc.media, nil)
+			m.blobs.EXPECT().Get(gomock.Any(), tc.wantKey).Return(io.NopCloser(strings.NewReader("bytes")), nil)
+
+			got, err := svc.ReadMedia(context.Background(), tc.media.ID, tc.thumbnail)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if got.ContentType != tc.wantType {
+				t.Fatalf("content type %q, want %q", got.ContentType, tc.wantType)
+			}
+		})
+	}
+}
+
+func TestMediaService_ReadMedia_MissingBlob(t *testing.T) {
+	m, svc := newMediaService(t)
+	m.repo.EXPECT().ReadMediaByID("gone").Return(&model.Media{ID: "gone", ContentType: "image/png"}, nil)
+	m.blobs.EXPECT().Get(gomock.Any(), "media/gone/original").Return(nil, fmt.Errorf("open: %w", fs.ErrNotExist))
+
+	if _, err := svc.ReadMedia(context.Background(), "gone", false); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestMediaService_SetAvatar(t *testing.T) {
+	m, svc := newMediaService(t)
+	m.policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil).Times(2)
+	m.repo.EXPECT().ReadMediaByID("mine").Return(&model.Media{ID: "mine", OwnerID: 4}, nil)
+	m.repo.EXPECT().ReadMediaByID("theirs").Return(&model.Media{ID: "theirs", OwnerID: 5}, nil)
+	m.users.EXPECT().UpdateAvatarMedia(int64(4), "mine").Return(nil)
+
+	if _, err := svc.SetAvatar(4, 4, "mine"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, err := svc.SetAvatar(4, 4, "theirs"); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for someone else's upload, got %v", err)
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
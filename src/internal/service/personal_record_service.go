@@ -0,0 +1,183 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+type personalRecordService struct {
+	recordRepository repository.PersonalRecordRepository
+	policy           service.AccessPolicy
+}
+
+func NewPersonalRecordService(recordRepository repository.PersonalRecordRepository, policy service.AccessPolicy) service.PersonalRecordService {
+	return &personalRecordService{
+		recordRepository: recordRepository,
+		policy:           policy,
+	}
+}
+
+// RecordSet compares a freshly logged set against the user's current bests
+// for that exercise and stores every record it beats
+func (s *personalRecordService) RecordSet(userID int64, set *model.WorkoutSet) ([]*model.PersonalRecord, error) {
+	if set == nil || set.Reps <= 0 {
+		return []*model.PersonalRecord{}, nil
+	}
+
+	bests, err := s.recordRepository.ReadExerciseBestRecords(userID, set.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := s.recordRepository.ReadSessionExerciseVolume(set.SessionID, set.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var broken []*model.PersonalRecord
+	for _, candidate := range candidateRecords(set, volume) {
+		candidate.UserID = userID
+		if beatsBest(candidate, bests) {
+			broken = append(broken, candidate)
+		}
+	}
+	if len(broken) == 0 {
+		return []*model.PersonalRecord{}, nil
+	}
+
+	return s.recordRepository.CreateRecords(broken)
+}
+
+func (s *personalRecordService) ReadUserRecords(request model.ReadRecordsRequest) ([]*model.PersonalRecord, error) {
+	formula, err := normalizeFormula(request.Formula)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CanViewMetrics(request.ViewerID, request.UserID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.recordRepository.ReadBestRecords(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return filterFormula(records, formula), nil
+}
+
+func (s *personalRecordService) ReadExerciseRecords(request model.ReadRecordsRequest) ([]*model.PersonalRecord, error) {
+	formula, err := normalizeFormula(request.Formula)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CanViewMetrics(request.ViewerID, request.UserID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.recordRepository.ReadExerciseRecordHistory(request.UserID, request.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	return filterFormula(records, formula), nil
+}
+
+func candidateRecords(set *model.WorkoutSet, sessionVolume float64) []*model.PersonalRecord {
+	base := func(recordType string, formula string, value float64) *model.PersonalRecord {
+		return &model.PersonalRecord{
+			ExerciseID: set.ExerciseID,
+			Type:       recordType,
+			Formula:    formula,
+			Value:      roundRecord(value),
+			Weight:     set.Weight,
+			Reps:       set.Reps,
+			SessionID:  set.SessionID,
+			SetID:      set.ID,
+		}
+	}
+
+	// rep records are tracked per weight, so bodyweight sets count too
+	candidates := []*model.PersonalRecord{
+		base(model.RecordTypeMaxReps, "", float64(set.Reps)),
+	}
+	if set.Weight <= 0 {
+		return candidates
+	}
+
+	candidates = append(candidates, base(model.RecordTypeMaxWeight, "", set.Weight))
+	for _, formula := range []string{model.FormulaEpley, model.FormulaBrzycki} {
+		if oneRM, ok := estimateOneRepMax(set.Weight, set.Reps, formula); ok {
+			candidates = append(candidates, base(model.RecordTypeEstimated1RM, formula, oneRM))
+		}
+	}
+	if sessionVolume > 0 {
+		candidates = append(candidates, base(model.RecordTypeMaxVolume, "", sessionVolume))
+	}
+	return candidates
+}
+
+func beatsBest(candidate *model.PersonalRecord, bests []*model.PersonalRecord) bool {
+	for _, best := range bests {
+		if best.Type != candidate.Type || best.Formula != candidate.Formula {
+			continue
+		}
+		if candidate.Type == model.RecordTypeMaxReps && best.Weight != candidate.Weight {
+			continue
+		}
+		if candidate.Value <= best.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// estimateOneRepMax returns false when the formula is not defined for the rep count
+func estimateOneRepMax(weight float64, reps int64, formula string) (float64, bool) {
+	if weight <= 0 || reps <= 0 {
+		return 0, false
+	}
+	if reps == 1 {
+		return weight, true
+	}
+
+	switch formula {
+	case model.FormulaEpley:
+		return weight * (1 + float64(reps)/30), true
+	case model.FormulaBrzycki:
+		if reps >= 37 {
+			return 0, false
+		}
+		return weight * 36 / (37 - float64(reps)), true
+	default:
+		return 0, false
+	}
+}
+
+func normalizeFormula(formula string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(formula)) {
+	case "", model.FormulaEpley:
+		return model.FormulaEpley, nil
+	case model.FormulaBrzycki:
+		return model.FormulaBrzycki, nil
+	default:
+		return "", fmt.Errorf("%w: formula must be %q or %q", util.ErrInvalidInput, model.FormulaEpley, model.FormulaBrzycki)
+	}
+}
+
+// filterFormula keeps estimated 1RM records for the requested formula only
+func filterFormula(records []*model.PersonalRecord, formula string) []*model.PersonalRecord {
+	var filtered = make([]*model.PersonalRecord, 0, len(records))
+	for _, rec := range records {
+		if rec.Type == model.RecordTypeEstimated1RM && rec.Formula != formula {
+			continue
+		}
+		filtered = append(filtered, rec)
+	}
+	return filtered
+}
+
+func roundRecord(v float64) float64 {
+	return math.Round(v*100) / 100
+}
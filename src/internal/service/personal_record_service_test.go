@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"workoutpal/src/internal/model"
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newPersonalRecordServiceMocks(t *testing.T) (*mock_repository.MockPersonalRecordRepository, *mock_service.MockAccessPolicy, *personalRecordService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	records := mock_repository.NewMockPersonalRecordRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPersonalRecordService(records, policy).(*personalRecordService)
+	return records, policy, svc
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		name    string
+		weight  float64
+		reps    int64
+		formula string
+		want    float64
+		ok      bool
+	}{
+		{"epley single is the weight", 100, 1, model.FormulaEpley, 100, true},
+		{"epley five reps", 100, 5, model.FormulaEpley, 116.67, true},
+		{"brzycki five reps", 100, 5, model.FormulaBrzycki, 112.5, true},
+		{"brzycki ten reps", 100, 10, model.FormulaBrzycki, 133.33, true},
+		{"brzycki undefined past 36 reps", 100, 37, model.FormulaBrzycki, 0, false},
+		{"no weight", 0, 5, model.FormulaEpley, 0, false},
+		{"unknown formula", 100, 5, "lombardi", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := estimateOneRepMax(tt.weight, tt.reps, tt.formula)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if roundRecord(got) != tt.want {
+				t.Fatalf("got %v, want %v", roundRecord(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestPersonalRecordService_RecordSet_FirstSetSetsEveryRecord(t *testing.T) {
+	records, _, svc := newPersonalRecordServiceMocks(t)
+
+	set := &model.WorkoutSet{ID: 11, SessionID: 5, ExerciseID: 2, Weight: 100, Reps: 5}
+	records.EXPECT().ReadExerciseBestRecords(int64(7), int64(2)).Return([]*model.PersonalRecord{}, nil)
+	records.EXPECT().ReadSessionExerciseVolume(int64(5), int64(2)).Return(500.0, nil)
+	records.EXPECT().CreateRecords(gomock.Any()).
+		DoAndReturn(func(in []*model.PersonalRecord) ([]*model.PersonalRecord, error) {
+			return in, nil
+		})
+
+	got, err := svc.RecordSet(7, set)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	byKey := map[string]float64{}
+	for _, rec := range got {
+		if rec.UserID != 7 || rec.SetID != 11 || rec.SessionID != 5 {
+			t.Fatalf("record not tied to the set: %#v", rec)
+		}
+		byKey[rec.Type+"/"+rec.Formula] = rec.Value
+	}
+	want := map[string]float64{
+		"max_reps/":             5,
+		"max_weight/":           100,
+		"estimated_1rm/epley":   116.67,
+		"estimated_1rm/brzycki": 112.5,
+		"max_volume/":           500,
+	}
+	if len(byKey) != len(want) {
+		t.Fatalf("got %v, want %v", byKey, want)
+	}
+	for k, v := range want {
+		if byKey[k] != v {
+			t.Fatalf("%s = %v, want %v", k, byKey[k], v)
+		}
+	}
+}
+
+func TestPersonalRecordService_RecordSet_OnlyStoresBeatenRecords(t *testing.T) {
+	records, _, svc := newPersonalRecordServiceMocks(t)
+
+	set := &model.WorkoutSet{ID: 12, SessionID: 5, ExerciseID: 2, Weight: 80, Reps: 12}
+	bests := []*model.PersonalRecord{
+		{Type: model.RecordTypeMaxWeight, Value: 100, Weight: 100, Reps: 5},
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaEpley, Value: 116.67},
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaBrzycki, Value: 112.5},
+		// rep records at other weights do not count against this one
+		{Type: model.RecordTypeMaxReps, Value: 20, Weight: 60},
+		{Type: model.RecordTypeMaxVolume, Value: 2000},
+	}
+	records.EXPECT().ReadExerciseBestRecords(int64(7), int64(2)).Return(bests, nil)
+	records.EXPECT().ReadSessionExerciseVolume(int64(5), int64(2)).Return(960.0, nil)
+	records.EXPECT().CreateRecords(gomock.Any()).
+		DoAndReturn(func(in []*model.PersonalRecord) ([]*model.PersonalRecord, error) {
+			return in, nil
+		})
+
+	got, err := svc.RecordSet(7, set)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// 80x12 -> epley 112, brzycki 115.2; only brzycki and the new rep weight beat the bests
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %#v", got)
+	}
+	for _, rec := range got {
+		switch {
+		case rec.Type == model.RecordTypeMaxReps && rec.Weight == 80 && rec.Value == 12:
+		case rec.Type == model.RecordTypeEstimated1RM && rec.Formula == model.FormulaBrzycki && rec.Value == 115.2:
+		default:
+			t.Fatalf("unexpected record: %#v", rec)
+		}
+	}
+}
+
+func TestPersonalRecordService_RecordSet_NothingBeaten(t *testing.T) {
+	records, _, svc := newPersonalRecordServiceMocks(t)
+
+	set := &model.WorkoutSet{ID: 13, SessionID: 5, ExerciseID: 2, Reps: 10}
+	records.EXPECT().ReadExerciseBestRecords(int64(7), int64(2)).
+		Return([]*model.PersonalRecord{{Type: model.RecordTypeMaxReps, Value: 15, Weight: 0}}, nil)
+	records.EXPECT().ReadSessionExerciseVolume(int64(5), int64(2)).Return(0.0, nil)
+
+	got, err := svc.RecordSet(7, set)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no records, got %#v", got)
+	}
+}
+
+func TestPersonalRecordService_ReadUserRecords_OwnerFiltersFormula(t *testing.T) {
+	records, policy, svc := newPersonalRecordServiceMocks(t)
+
+	policy.EXPECT().CanViewMetrics(int64(7), int64(7)).Return(nil)
+	records.EXPECT().ReadBestRecords(int64(7)).Return([]*model.PersonalRecord{
+		{Type: model.RecordTypeMaxWeight, Value: 100},
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaEpley, Value: 116.67},
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaBrzycki, Value: 112.5},
+	}, nil)
+
+	got, err := svc.ReadUserRecords(model.ReadRecordsRequest{UserID: 7, ViewerID: 7, Formula: "Brzycki"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[1].Formula != model.FormulaBrzycki {
+		t.Fatalf("unexpected records: %#v", got)
+	}
+}
+
+func TestPersonalRecordService_ReadUserRecords_InvalidFormula(t *testing.T) {
+	_, _, svc := newPersonalRecordServiceMocks(t)
+
+	_, err := svc.ReadUserRecords(model.ReadRecordsRequest{UserID: 7, ViewerID: 7, Formula: "lombardi"})
+	if !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPersonalRecordService_ReadUserRecords_Forbidden(t *testing.T) {
+	_, policy, svc := newPersonalRecordServiceMocks(t)
+
+	// the metrics rules themselves are covered by the policy tests
+	policy.EXPECT().CanViewMetrics(int64(8), int64(7)).Return(fmt.Errorf("%w: metrics are not shared with you", util.ErrForbidden))
+
+	_, err := svc.ReadUserRecords(model.ReadRecordsRequest{UserID: 7, ViewerID: 8})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestPersonalRecordService_ReadExerciseRecords_OK(t *testing.T) {
+	records, policy, svc := newPersonalRecordServiceMocks(t)
+
+	policy.EXPECT().CanViewMetrics(int64(7), int64(7)).Return(nil)
+	records.EXPECT().ReadExerciseRecordHistory(int64(7), int64(2)).Return([]*model.PersonalRecord{
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaEpley, Value: 120},
+		{Type: model.RecordTypeEstimated1RM, Formula: model.FormulaBrzycki, Value: 115},
+		{Type: model.RecordTypeMaxWeight, Value: 100},
+	}, nil)
+
+	got, err := svc.ReadExerciseRecords(model.ReadRecordsRequest{UserID: 7, ExerciseID: 2, ViewerID: 7})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0].Formula != model.FormulaEpley {
+		t.Fatalf("unexpected records: %#v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+// buildThreads hangs each comment under its parent and returns the top level,
+// the comments at level 0, in order. comments must be ordered by level.
+func buildThreads(comments []*model.Comment) []*model.Comment {
+	top := make([]*model.Comment, 0)
+	byID := make(map[int64]*model.Comment, len(comments))
+	for _, c := range comments {
+		c.Replies = make([]*model.Comment, 0)
+		byID[c.ID] = c
+		if c.Level == 0 {
+			top = append(top, c)
+			continue
+		}
+		if parent := byID[c.ParentID]; parent != nil {
+			parent.Replies = append(parent.Replies, c)
+		}
+	}
+
+	// replies cut off by the per-comment cap carry on from the last one loaded;
+	// comments on the last level loaded have none, so theirs start from the top
+	for _, c := range comments {
+		if n := len(c.Replies); n > 0 && c.ReplyCount > n {
+			last := c.Replies[n-1]
+			c.RepliesCursor = encodeCommentCursor(model.CommentCursor{PostID: c.PostID, ParentID: c.ID, CreatedAt: last.CreatedAt, ID: last.ID})
+		}
+	}
+	return top
+}
+
+// pageComments cuts one thread's top level down to limit, returning the
+// cursor for the rest when there is more
+func pageComments(top []*model.Comment, limit int, postID, parentID int64) *model.CommentPage {
+	page := &model.CommentPage{Comments: top}
+	if len(top) > limit {
+		page.Comments = top[:limit]
+		last := page.Comments[limit-1]
+		page.NextCursor = encodeCommentCursor(model.CommentCursor{PostID: postID, ParentID: parentID, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page
+}
+
+func commentPageSize(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case limit == 0:
+		return model.DefaultCommentPageSize, nil
+	case limit > model.MaxCommentPageSize:
+		return model.MaxCommentPageSize, nil
+	}
+	return limit, nil
+}
+
+// comment cursors are opaque to clients, like feed cursors
+func encodeCommentCursor(c model.CommentCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCommentCursor(s string) (*model.CommentCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c model.CommentCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"workoutpal/src/internal/model"
+)
+
+func TestBuildThreads(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2025, 3, 3, 7, minutes, 0, 0, time.UTC)
+	}
+	comments := []*model.Comment{
+		{ID: 1, PostID: 20, CreatedAt: at(0), ReplyCount: 4},
+		{ID: 2, PostID: 20, CreatedAt: at(1), ReplyCount: 0},
+		{ID: 3, PostID: 20, ParentID: 1, Level: 1, CreatedAt: at(2), ReplyCount: 2},
+		{ID: 4, PostID: 20, ParentID: 1, Level: 1, CreatedAt: at(3)},
+		{ID: 5, PostID: 20, ParentID: 1, Level: 1, CreatedAt: at(4)},
+		{ID: 6, PostID: 20, ParentID: 3, Level: 2, CreatedAt: at(5)},
+		{ID: 7, PostID: 20, ParentID: 3, Level: 2, CreatedAt: at(6), ReplyCount: 1},
+	}
+
+	top := buildThreads(comments)
+
+	if len(top) != 2 || top[0].ID != 1 || top[1].ID != 2 {
+		t.Fatalf("unexpected top level %+v", top)
+	}
+	first := top[0]
+	if len(first.Replies) != 3 || first.Replies[0].ID != 3 || len(first.Replies[0].Replies) != 2 {
+		t.Fatalf("unexpected thread under comment 1: %+v", first.Replies)
+	}
+	if top[1].Replies == nil {
+		t.Fatalf("replies must encode as an empty list")
+	}
+
+	// comment 1 has a fourth reply that was cut off, so it continues after comment 5
+	cursor, err := decodeCommentCursor(first.RepliesCursor)
+	if err != nil {
+		t.Fatalf("comment 1 should have a replies cursor: %v", err)
+	}
+	if *cursor != (model.CommentCursor{PostID: 20, ParentID: 1, CreatedAt: at(4), ID: 5}) {
+		t.Fatalf("unexpected cursor %+v", cursor)
+	}
+	// every reply of comment 3 was loaded
+	if first.Replies[0].RepliesCursor != "" {
+		t.Fatalf("comment 3 has all its replies, got cursor %q", first.Replies[0].RepliesCursor)
+	}
+	// comment 7's reply lies below the levels loaded; it is fetched from the start
+	if deepest := first.Replies[0].Replies[1]; deepest.RepliesCursor != "" || len(deepest.Replies) != 0 {
+		t.Fatalf("unexpected deepest comment %+v", deepest)
+	}
+}
+
+func TestPageComments(t *testing.T) {
+	created := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	top := []*model.Comment{{ID: 1, CreatedAt: created}, {ID: 2, CreatedAt: created}, {ID: 3, CreatedAt: created}}
+
+	page := pageComments(top, 2, 20, 0)
+	if len(page.Comments) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected 2 comments and a cursor, got %+v", page)
+	}
+	if page := pageComments(top, 3, 20, 0); page.NextCursor != "" {
+		t.Fatalf("a full last page has no cursor, got %q", page.NextCursor)
+	}
+}
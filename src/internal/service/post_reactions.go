@@ -0,0 +1,44 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+func isReactionType(reaction string) bool {
+	return slices.Contains(model.ReactionTypes, reaction)
+}
+
+func reactionPageSize(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case limit == 0:
+		return model.DefaultReactionPageSize, nil
+	case limit > model.MaxReactionPageSize:
+		return model.MaxReactionPageSize, nil
+	}
+	return limit, nil
+}
+
+// reaction cursors are opaque to clients, like comment cursors
+func encodeReactionCursor(c model.ReactionCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeReactionCursor(s string) (*model.ReactionCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c model.ReactionCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -1,17 +1,29 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 )
 
 type PostService struct {
-	repo repository.PostRepository
+	repo         repository.PostRepository
+	media        repository.MediaRepository
+	routines     service.RoutineService
+	sessions     repository.WorkoutSessionRepository
+	achievements service.AchievementEvaluator
+	policy       service.AccessPolicy
+	now          func() time.Time
 }
 
-func NewPostService(repo repository.PostRepository) service.PostService {
-	return &PostService{repo: repo}
+func NewPostService(repo repository.PostRepository, media repository.MediaRepository, routines service.RoutineService, sessions repository.WorkoutSessionRepository, achievements service.AchievementEvaluator, policy service.AccessPolicy) service.PostService {
+	return &PostService{repo: repo, media: media, routines: routines, sessions: sessions, achievements: achievements, policy: policy, now: time.Now}
 }
 
 func (s *PostService) ReadPostsByUserID(targetUserID int64, userID int64) ([]*model.Post, error) {
@@ -20,58 +32,226 @@ func (s *PostService) ReadPostsByUserID(targetUserID int64, userID int64) ([]*mo
 		return nil, err
 	}
 
-	for _, post := range posts {
-		comments, err := s.repo.ReadCommentsByPost(post.ID)
-		if err != nil {
-			return nil, err
-		}
-		for _, c := range comments {
-			replies, err := s.repo.ReadCommentsByComment(c.ID)
-			if err != nil {
-				return nil, err
-			}
-			c.Replies = replies
-		}
-		post.Comments = comments
+	if err := s.attachComments(posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachWorkouts(posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachMedia(posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(posts, userID); err != nil {
+		return nil, err
 	}
 
 	return posts, nil
 }
 
-func (s *PostService) ReadPosts(userID int64) ([]*model.Post, error) {
-	posts, err := s.repo.ReadPosts(userID)
+func (s *PostService) ReadPosts(req model.ReadPostRequest) (*model.PostPage, error) {
+	filter, err := newPostFeedFilter(req, s.now())
 	if err != nil {
 		return nil, err
 	}
 
-	for _, post := range posts {
-		comments, err := s.repo.ReadCommentsByPost(post.ID)
-		if err != nil {
-			return nil, err
-		}
-		for _, c := range comments {
-			replies, err := s.repo.ReadCommentsByComment(c.ID)
-			if err != nil {
-				return nil, err
-			}
-			c.Replies = replies
+	// ask for one extra row to know whether there is another page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	posts, err := s.repo.ReadPosts(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.PostPage{Posts: posts}
+	if len(posts) > limit {
+		page.Posts = posts[:limit]
+		last := page.Posts[limit-1]
+		cursor := model.PostFeedCursor{Scope: filter.Scope, Sort: filter.Sort, ID: last.ID}
+		if filter.Sort == model.FeedSortRanked {
+			cursor.AsOf = filter.AsOf
+			cursor.Score = last.Score
+		} else {
+			cursor.CreatedAt = last.CreatedAt
 		}
-		post.Comments = comments
+		page.NextCursor = encodePostFeedCursor(cursor)
 	}
 
-	return posts, nil
+	if err := s.attachComments(page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachWorkouts(page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachMedia(page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(page.Posts, filter.ViewerID); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
 func (s *PostService) CreatePost(req model.CreatePostRequest) (*model.Post, error) {
+	if err := s.checkWorkout(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkMedia(req); err != nil {
+		return nil, err
+	}
 	post, err := s.repo.CreatePost(req)
 	if err != nil {
 		return nil, err
 	}
+	post.Reactions = make(map[string]int)
+	if req.RoutineID != 0 || req.SessionID != 0 {
+		if err := s.attachWorkouts([]*model.Post{post}); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.MediaIDs) > 0 {
+		if err := s.attachMedia([]*model.Post{post}); err != nil {
+			return nil, err
+		}
+	}
 
+	evaluateAchievements(s.achievements, req.PostedBy, model.CriteriaPosts)
 	return post, nil
 }
 
-func (s *PostService) UpdatePost(req model.UpdatePostRequest) (*model.Post, error) {
+// checkWorkout lets a post share only its author's own routines and finished sessions
+func (s *PostService) checkWorkout(req model.CreatePostRequest) error {
+	switch {
+	case req.RoutineID != 0 && req.SessionID != 0:
+		return fmt.Errorf("%w: a post can share a routine or a session, not both", util.ErrInvalidInput)
+	case req.RoutineID != 0:
+		routine, err := s.routines.ReadRoutineWithExercises(req.PostedBy, req.RoutineID)
+		if err != nil {
+			return err
+		}
+		if routine.UserID != req.PostedBy {
+			return fmt.Errorf("%w: you can only share your own routines", util.ErrForbidden)
+		}
+	case req.SessionID != 0:
+		session, err := s.sessions.ReadSessionByID(req.SessionID)
+		if err != nil {
+			return err
+		}
+		if session.UserID != req.PostedBy {
+			return fmt.Errorf("%w: you can only share your own workouts", util.ErrForbidden)
+		}
+		if session.Status != "finished" {
+			return fmt.Errorf("%w: finish the workout before sharing it", util.ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// checkMedia lets a post attach only images its author uploaded, each once
+func (s *PostService) checkMedia(req model.CreatePostRequest) error {
+	if len(req.MediaIDs) == 0 {
+		return nil
+	}
+	if len(req.MediaIDs) > model.MaxPostMedia {
+		return fmt.Errorf("%w: a post can attach at most %d images", util.ErrInvalidInput, model.MaxPostMedia)
+	}
+	seen := make(map[string]bool, len(req.MediaIDs))
+	for _, id := range req.MediaIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: image %s is attached twice", util.ErrInvalidInput, id)
+		}
+		seen[id] = true
+	}
+
+	media, err := s.media.ReadMediaByIDs(req.MediaIDs)
+	if err != nil {
+		return err
+	}
+	if len(media) != len(req.MediaIDs) {
+		return fmt.Errorf("%w: unknown image", util.ErrInvalidInput)
+	}
+	for _, m := range media {
+		if m.OwnerID != req.PostedBy {
+			return fmt.Errorf("%w: you can only attach images you uploaded", util.ErrForbidden)
+		}
+	}
+	return nil
+}
+
+// attachMedia loads every post's images in one query
+func (s *PostService) attachMedia(posts []*model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	media, err := s.repo.ReadPostMedia(ids)
+	if err != nil {
+		return err
+	}
+
+	byPost := make(map[int64][]*model.Media, len(posts))
+	for _, m := range media {
+		byPost[m.PostID] = append(byPost[m.PostID], m)
+	}
+	for _, post := range posts {
+		post.Media = byPost[post.ID]
+	}
+	return nil
+}
+
+// attachWorkouts summarizes what each post shares in one round of queries
+func (s *PostService) attachWorkouts(posts []*model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	summaries, err := s.repo.ReadPostWorkouts(ids)
+	if err != nil {
+		return err
+	}
+
+	byPost := make(map[int64]*model.WorkoutSummary, len(summaries))
+	for _, summary := range summaries {
+		byPost[summary.PostID] = summary
+	}
+	for _, post := range posts {
+		post.Workout = byPost[post.ID]
+	}
+	return nil
+}
+
+// CopyRoutine saves the routine a post shares as one of the viewer's own
+func (s *PostService) CopyRoutine(actorID, postID int64) (*model.ExerciseRoutine, error) {
+	if err := s.checkCanSeePost(actorID, postID); err != nil {
+		return nil, err
+	}
+	summaries, err := s.repo.ReadPostWorkouts([]int64{postID})
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 || summaries[0].RoutineID == 0 {
+		return nil, fmt.Errorf("%w: the post doesn't share a routine", util.ErrInvalidInput)
+	}
+	return s.routines.CopyRoutine(actorID, summaries[0].RoutineID)
+}
+
+// UpdatePost is for the author only; staff take posts down instead of editing them
+func (s *PostService) UpdatePost(actorID int64, req model.UpdatePostRequest) (*model.Post, error) {
+	if req.Title == nil && req.Caption == nil && req.Body == nil && req.Status == nil {
+		return nil, fmt.Errorf("%w: nothing to update", util.ErrInvalidInput)
+	}
+	if err := s.checkAuthor(actorID, req.ID); err != nil {
+		return nil, err
+	}
+
 	post, err := s.repo.UpdatePost(req)
 	if err != nil {
 		return nil, err
@@ -80,22 +260,339 @@ func (s *PostService) UpdatePost(req model.UpdatePostRequest) (*model.Post, erro
 	return post, nil
 }
 
-func (s *PostService) DeletePost(id int64) error {
+func (s *PostService) ReadPostRevisions(actorID, postID int64) ([]*model.PostRevision, error) {
+	if err := s.checkAuthor(actorID, postID); err != nil {
+		return nil, err
+	}
+	return s.repo.ReadPostRevisions(postID)
+}
+
+func (s *PostService) checkAuthor(actorID, postID int64) error {
+	ownerID, err := s.repo.ReadPostOwnerID(postID)
+	if err != nil {
+		return err
+	}
+	if ownerID != actorID {
+		return fmt.Errorf("%w: only the author can edit this post", util.ErrForbidden)
+	}
+	return nil
+}
+
+func (s *PostService) DeletePost(actorID, id int64) error {
+	ownerID, err := s.repo.ReadPostOwnerID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.CanModify(actorID, ownerID); err != nil {
+		return err
+	}
 	return s.repo.DeletePost(id)
 }
 
 func (s *PostService) CommentOnPost(req model.CommentOnPostRequest) error {
+	if err := s.checkCanSeePost(req.UserID, req.PostID); err != nil {
+		return err
+	}
 	return s.repo.CommentOnPost(req)
 }
 
 func (s *PostService) CommentOnComment(req model.CommentOnCommentRequest) error {
+	parent, err := s.repo.ReadCommentRef(req.CommentID)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.CanView(req.UserID, parent.PostAuthorID); err != nil {
+		return err
+	}
+
+	switch {
+	case parent.PostID != req.PostID:
+		return fmt.Errorf("%w: comment %d is not on post %d", util.ErrInvalidInput, req.CommentID, req.PostID)
+	case parent.Deleted:
+		return fmt.Errorf("%w: the comment was deleted", util.ErrInvalidInput)
+	case parent.Depth+1 >= model.MaxCommentDepth:
+		return fmt.Errorf("%w: replies can nest at most %d levels deep", util.ErrInvalidInput, model.MaxCommentDepth)
+	}
 	return s.repo.CommentOnComment(req)
 }
 
+// ReadComments pages through the top-level comments of a post, or the
+// replies to a comment, each with the first few levels of its own replies
+func (s *PostService) ReadComments(req model.ReadCommentsRequest) (*model.CommentPage, error) {
+	limit, err := commentPageSize(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	postID := req.PostID
+	if req.ParentID != 0 {
+		parent, err := s.repo.ReadCommentRef(req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.policy.CanView(req.ViewerID, parent.PostAuthorID); err != nil {
+			return nil, err
+		}
+		postID = parent.PostID
+	} else if err := s.checkCanSeePost(req.ViewerID, postID); err != nil {
+		return nil, err
+	}
+
+	filter := model.CommentFilter{
+		PostIDs:  []int64{postID},
+		ParentID: req.ParentID,
+		Limit:    limit + 1,
+		Depth:    model.ThreadDepth,
+		Replies:  model.ThreadReplies,
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeCommentCursor(req.Cursor)
+		if err != nil || cursor.PostID != postID || cursor.ParentID != req.ParentID {
+			return nil, fmt.Errorf("%w: cursor is invalid for this thread", util.ErrInvalidInput)
+		}
+		filter.After = cursor
+	}
+
+	comments, err := s.repo.ReadComments(filter)
+	if err != nil {
+		return nil, err
+	}
+	return pageComments(buildThreads(comments), limit, postID, req.ParentID), nil
+}
+
+// attachComments loads the first page of comments of every post in one query
+func (s *PostService) attachComments(posts []*model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	comments, err := s.repo.ReadComments(model.CommentFilter{
+		PostIDs: ids,
+		Limit:   model.DefaultCommentPageSize + 1,
+		Depth:   model.ThreadDepth,
+		Replies: model.ThreadReplies,
+	})
+	if err != nil {
+		return err
+	}
+
+	byPost := make(map[int64][]*model.Comment, len(posts))
+	for _, c := range buildThreads(comments) {
+		byPost[c.PostID] = append(byPost[c.PostID], c)
+	}
+	for _, post := range posts {
+		page := pageComments(byPost[post.ID], model.DefaultCommentPageSize, post.ID, 0)
+		post.Comments = page.Comments
+		if post.Comments == nil {
+			post.Comments = make([]*model.Comment, 0)
+		}
+		post.CommentsCursor = page.NextCursor
+	}
+	return nil
+}
+
+// UpdateComment is for the comment's author only
+func (s *PostService) UpdateComment(actorID int64, req model.UpdateCommentRequest) (*model.Comment, error) {
+	if strings.TrimSpace(req.Comment) == "" {
+		return nil, fmt.Errorf("%w: comment is required", util.ErrInvalidInput)
+	}
+	ref, err := s.repo.ReadCommentRef(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if ref.AuthorID != actorID {
+		return nil, fmt.Errorf("%w: only the author can edit this comment", util.ErrForbidden)
+	}
+	return s.repo.UpdateComment(req)
+}
+
+// DeleteComment is open to the comment's author, the author of the post it is
+// on, and admins
+func (s *PostService) DeleteComment(actorID, id int64) error {
+	ref, err := s.repo.ReadCommentRef(id)
+	if err != nil {
+		return err
+	}
+	if actorID != ref.PostAuthorID {
+		if err := s.policy.CanModify(actorID, ref.AuthorID); err != nil {
+			return err
+		}
+	}
+	return s.repo.DeleteComment(id)
+}
+
+// LikePost reacts to a post, or changes the user's reaction if they already
+// reacted
 func (s *PostService) LikePost(req model.LikePostRequest) (*model.Post, error) {
-	return s.repo.LikePost(req)
+	if req.Reaction == "" {
+		req.Reaction = model.ReactionLike
+	}
+	if !isReactionType(req.Reaction) {
+		return nil, fmt.Errorf("%w: reaction must be one of %s", util.ErrInvalidInput, strings.Join(model.ReactionTypes, ", "))
+	}
+	if err := s.checkCanSeePost(req.UserID, req.PostID); err != nil {
+		return nil, err
+	}
+	post, err := s.repo.LikePost(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions([]*model.Post{post}, req.UserID); err != nil {
+		return nil, err
+	}
+
+	evaluateAchievements(s.achievements, req.UserID, model.CriteriaLikesGiven)
+	return post, nil
 }
 
 func (s *PostService) UnlikePost(req model.UnikePostRequest) (*model.Post, error) {
-	return s.repo.UnlikePost(req)
+	post, err := s.repo.UnlikePost(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions([]*model.Post{post}, req.UserID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// ReadReactions pages through who reacted to a post the viewer can see
+func (s *PostService) ReadReactions(req model.ReadReactionsRequest) (*model.ReactionPage, error) {
+	limit, err := reactionPageSize(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != "" && !isReactionType(req.Type) {
+		return nil, fmt.Errorf("%w: type must be one of %s", util.ErrInvalidInput, strings.Join(model.ReactionTypes, ", "))
+	}
+	if err := s.checkCanSeePost(req.ViewerID, req.PostID); err != nil {
+		return nil, err
+	}
+
+	filter := model.ReactionFilter{PostID: req.PostID, Type: req.Type, Limit: limit + 1}
+	if req.Cursor != "" {
+		cursor, err := decodeReactionCursor(req.Cursor)
+		if err != nil || cursor.PostID != req.PostID || cursor.Type != req.Type {
+			return nil, fmt.Errorf("%w: cursor is invalid for this list", util.ErrInvalidInput)
+		}
+		filter.After = cursor
+	}
+
+	reactions, err := s.repo.ReadReactions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ReactionPage{Reactions: reactions}
+	if len(reactions) > limit {
+		page.Reactions = reactions[:limit]
+		last := page.Reactions[limit-1]
+		page.NextCursor = encodeReactionCursor(model.ReactionCursor{PostID: req.PostID, Type: req.Type, CreatedAt: last.CreatedAt, UserID: last.UserID})
+	}
+	return page, nil
+}
+
+// attachReactions counts every post's reactions by type in one query
+func (s *PostService) attachReactions(posts []*model.Post, viewerID int64) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(posts))
+	byID := make(map[int64]*model.Post, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+		byID[post.ID] = post
+		post.Reactions = make(map[string]int)
+		post.MyReaction = ""
+	}
+	counts, err := s.repo.ReadPostReactions(ids, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		post := byID[count.PostID]
+		if post == nil {
+			continue
+		}
+		post.Reactions[count.Reaction] = count.Count
+		if count.Mine {
+			post.MyReaction = count.Reaction
+		}
+	}
+	return nil
+}
+
+// checkCanSeePost keeps posts by private profiles out of reach of non-followers
+func (s *PostService) checkCanSeePost(userID, postID int64) error {
+	ownerID, err := s.repo.ReadPostOwnerID(postID)
+	if err != nil {
+		return err
+	}
+	return s.policy.CanView(userID, ownerID)
+}
+
+func newPostFeedFilter(req model.ReadPostRequest, now time.Time) (model.PostFeedFilter, error) {
+	filter := model.PostFeedFilter{ViewerID: req.ViewerID, Scope: req.Scope, Sort: req.Sort, Limit: req.Limit}
+
+	switch filter.Scope {
+	case "":
+		filter.Scope = model.FeedScopeFollowing
+	case model.FeedScopeFollowing, model.FeedScopeDiscover:
+	default:
+		return filter, fmt.Errorf("%w: scope must be following or discover", util.ErrInvalidInput)
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = model.FeedSortRecent
+	case model.FeedSortRecent, model.FeedSortRanked:
+	default:
+		return filter, fmt.Errorf("%w: sort must be recent or ranked", util.ErrInvalidInput)
+	}
+
+	switch {
+	case filter.Limit < 0:
+		return filter, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case filter.Limit == 0:
+		filter.Limit = model.DefaultFeedPageSize
+	case filter.Limit > model.MaxFeedPageSize:
+		filter.Limit = model.MaxFeedPageSize
+	}
+
+	filter.AsOf = now.UTC()
+	if req.Cursor != "" {
+		cursor, err := decodePostFeedCursor(req.Cursor)
+		if err != nil || cursor.Scope != filter.Scope || cursor.Sort != filter.Sort {
+			return filter, fmt.Errorf("%w: cursor is invalid for this scope and sort order", util.ErrInvalidInput)
+		}
+		filter.After = cursor
+		if filter.Sort == model.FeedSortRanked {
+			filter.AsOf = cursor.AsOf
+		}
+	}
+	return filter, nil
+}
+
+// feed cursors are opaque to clients, like exercise cursors
+func encodePostFeedCursor(c model.PostFeedCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePostFeedCursor(s string) (*model.PostFeedCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c model.PostFeedCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
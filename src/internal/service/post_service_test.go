@@ -2,10 +2,14 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"workoutpal/src/internal/model"
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 
 	"github.com/golang/mock/gomock"
 )
@@ -15,7 +19,8 @@ func TestPostService_CreatePost_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.CreatePostRequest{Title: "Test", Body: "Body"}
 	want := &model.Post{ID: 1, Title: "Test", Body: "Body"}
@@ -32,20 +37,215 @@ func TestPostService_CreatePost_OK(t *testing.T) {
 	}
 }
 
+func TestPostService_CreatePost_EvaluatesAchievements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	achievements := mock_service.NewMockAchievementService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, achievements, policy)
+
+	req := model.CreatePostRequest{Title: "Test", PostedBy: 4}
+	repo.EXPECT().CreatePost(req).Return(&model.Post{ID: 1}, nil)
+	achievements.EXPECT().Evaluate(int64(4), model.CriteriaPosts).Return(nil, errors.New("ignored"))
+
+	if _, err := svc.CreatePost(req); err != nil {
+		t.Fatalf("achievement failures must not fail the post: %v", err)
+	}
+}
+
+func TestPostService_CreatePost_SharesSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	sessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, sessions, nil, policy)
+
+	req := model.CreatePostRequest{Title: "Leg day", PostedBy: 4, SessionID: 7}
+	sessions.EXPECT().ReadSessionByID(int64(7)).Return(&model.WorkoutSession{ID: 7, UserID: 4, Status: "finished"}, nil)
+	repo.EXPECT().CreatePost(req).Return(&model.Post{ID: 1}, nil)
+	repo.EXPECT().ReadPostWorkouts([]int64{1}).Return([]*model.WorkoutSummary{{PostID: 1, SessionID: 7, TotalVolume: 1200}}, nil)
+
+	got, err := svc.CreatePost(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Workout == nil || got.Workout.TotalVolume != 1200 {
+		t.Fatalf("expected the session summary, got %+v", got.Workout)
+	}
+}
+
+func TestPostService_CreatePost_InvalidWorkout(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     model.CreatePostRequest
+		routine *model.ExerciseRoutine
+		session *model.WorkoutSession
+		wantErr error
+	}{
+		{name: "both", req: model.CreatePostRequest{PostedBy: 4, RoutineID: 9, SessionID: 7}, wantErr: util.ErrInvalidInput},
+		{name: "someone else's routine", req: model.CreatePostRequest{PostedBy: 4, RoutineID: 9}, routine: &model.ExerciseRoutine{ID: 9, UserID: 5}, wantErr: util.ErrForbidden},
+		{name: "someone else's session", req: model.CreatePostRequest{PostedBy: 4, SessionID: 7}, session: &model.WorkoutSession{ID: 7, UserID: 5, Status: "finished"}, wantErr: util.ErrForbidden},
+		{name: "session in progress", req: model.CreatePostRequest{PostedBy: 4, SessionID: 7}, session: &model.WorkoutSession{ID: 7, UserID: 4, Status: "active"}, wantErr: util.ErrInvalidInput},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mock_repository.NewMockPostRepository(ctrl)
+			routines := mock_service.NewMockRoutineService(ctrl)
+			sessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewPostService(repo, nil, routines, sessions, nil, policy)
+
+			if tc.routine != nil {
+				routines.EXPECT().ReadRoutineWithExercises(tc.req.PostedBy, tc.req.RoutineID).Return(tc.routine, nil)
+			}
+			if tc.session != nil {
+				sessions.EXPECT().ReadSessionByID(tc.req.SessionID).Return(tc.session, nil)
+			}
+
+			if _, err := svc.CreatePost(tc.req); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPostService_CreatePost_AttachesMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	media := mock_repository.NewMockMediaRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, media, nil, nil, nil, policy)
+
+	req := model.CreatePostRequest{Title: "PR day", PostedBy: 4, MediaIDs: []string{"b", "a"}}
+	media.EXPECT().ReadMediaByIDs([]string{"b", "a"}).Return([]*model.Media{{ID: "a", OwnerID: 4}, {ID: "b", OwnerID: 4}}, nil)
+	repo.EXPECT().CreatePost(req).Return(&model.Post{ID: 1}, nil)
+	repo.EXPECT().ReadPostMedia([]int64{1}).Return([]*model.Media{{ID: "b", PostID: 1}, {ID: "a", PostID: 1}}, nil)
+
+	got, err := svc.CreatePost(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got.Media) != 2 || got.Media[0].ID != "b" {
+		t.Fatalf("expected the images in the order attached, got %+v", got.Media)
+	}
+}
+
+func TestPostService_CreatePost_InvalidMedia(t *testing.T) {
+	cases := []struct {
+		name    string
+		ids     []string
+		found   []*model.Media
+		wantErr error
+	}{
+		{name: "too many", ids: []string{"a", "b", "c", "d", "e"}, wantErr: util.ErrInvalidInput},
+		{name: "twice", ids: []string{"a", "a"}, wantErr: util.ErrInvalidInput},
+		{name: "unknown", ids: []string{"a", "b"}, found: []*model.Media{{ID: "a", OwnerID: 4}}, wantErr: util.ErrInvalidInput},
+		{name: "someone else's", ids: []string{"a"}, found: []*model.Media{{ID: "a", OwnerID: 5}}, wantErr: util.ErrForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mock_repository.NewMockPostRepository(ctrl)
+			media := mock_repository.NewMockMediaRepository(ctrl)
+			svc := NewPostService(repo, media, nil, nil, nil, mock_service.NewMockAccessPolicy(ctrl))
+			if tc.found != nil {
+				media.EXPECT().ReadMediaByIDs(tc.ids).Return(tc.found, nil)
+			}
+
+			_, err := svc.CreatePost(model.CreatePostRequest{PostedBy: 4, MediaIDs: tc.ids})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPostService_CopyRoutine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	routines := mock_service.NewMockRoutineService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, routines, nil, nil, policy)
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(5), nil)
+	policy.EXPECT().CanView(int64(4), int64(5)).Return(nil)
+	repo.EXPECT().ReadPostWorkouts([]int64{1}).Return([]*model.WorkoutSummary{{PostID: 1, SessionID: 7, RoutineID: 9}}, nil)
+	routines.EXPECT().CopyRoutine(int64(4), int64(9)).Return(&model.ExerciseRoutine{ID: 12, UserID: 4}, nil)
+
+	got, err := svc.CopyRoutine(4, 1)
+	if err != nil || got.ID != 12 {
+		t.Fatalf("unexpected: %+v err=%v", got, err)
+	}
+}
+
+func TestPostService_CopyRoutine_NothingShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(5), nil)
+	policy.EXPECT().CanView(int64(4), int64(5)).Return(nil)
+	// a session logged without a routine has nothing to copy
+	repo.EXPECT().ReadPostWorkouts([]int64{1}).Return([]*model.WorkoutSummary{{PostID: 1, SessionID: 7}}, nil)
+
+	if _, err := svc.CopyRoutine(4, 1); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPostService_LikePost_EvaluatesAchievements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	achievements := mock_service.NewMockAchievementService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, achievements, policy)
+
+	req := model.LikePostRequest{PostID: 1, UserID: 4, Reaction: model.ReactionClap}
+	repo.EXPECT().ReadPostOwnerID(req.PostID).Return(int64(9), nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
+	repo.EXPECT().LikePost(req).Return(&model.Post{ID: 1}, nil)
+	repo.EXPECT().ReadPostReactions([]int64{1}, req.UserID).Return(nil, nil)
+	achievements.EXPECT().Evaluate(int64(4), model.CriteriaLikesGiven).Return(nil, nil)
+
+	if _, err := svc.LikePost(req); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
 func TestPostService_UpdatePost_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
-	req := model.UpdatePostRequest{ID: 1, Title: "Updated"}
-	want := &model.Post{ID: 1, Title: "Updated"}
+	title := "Updated"
+	req := model.UpdatePostRequest{ID: 1, Title: &title}
+	want := &model.Post{ID: 1, Title: "Updated", Edited: true}
 
-	// Service only calls UpdatePost, nothing else.
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
 	repo.EXPECT().UpdatePost(req).Return(want, nil)
 
-	got, err := svc.UpdatePost(req)
+	got, err := svc.UpdatePost(3, req)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -58,7 +258,8 @@ func TestPostService_CreatePost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.CreatePostRequest{Title: "Fail"}
 	repo.EXPECT().CreatePost(req).Return((*model.Post)(nil), errors.New("db error"))
@@ -77,7 +278,8 @@ func TestPostService_ReadPosts_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	userID := int64(42)
 	post1 := &model.Post{ID: 1}
@@ -85,36 +287,39 @@ func TestPostService_ReadPosts_OK(t *testing.T) {
 	posts := []*model.Post{post1, post2}
 
 	repo.EXPECT().
-		ReadPosts(userID).
+		ReadPosts(gomock.Any()).
 		Return(posts, nil)
 
-	c1p1 := &model.Comment{ID: 10}
-	c2p1 := &model.Comment{ID: 11}
-	repo.EXPECT().
-		ReadCommentsByPost(int64(1)).
-		Return([]*model.Comment{c1p1, c2p1}, nil)
-
-	r1c1p1 := &model.Comment{ID: 100}
+	// one query for the comments of the whole page, flat and ordered by level
 	repo.EXPECT().
-		ReadCommentsByComment(int64(10)).
-		Return([]*model.Comment{r1c1p1}, nil)
+		ReadComments(model.CommentFilter{PostIDs: []int64{1, 2}, Limit: model.DefaultCommentPageSize + 1, Depth: model.ThreadDepth, Replies: model.ThreadReplies}).
+		Return([]*model.Comment{
+			{ID: 10, PostID: 1},
+			{ID: 11, PostID: 1},
+			{ID: 20, PostID: 2},
+			{ID: 100, PostID: 1, ParentID: 10, Level: 1, ReplyCount: 0},
+		}, nil)
 	repo.EXPECT().
-		ReadCommentsByComment(int64(11)).
-		Return([]*model.Comment{}, nil)
-
-	c1p2 := &model.Comment{ID: 20}
+		ReadPostWorkouts([]int64{1, 2}).
+		Return([]*model.WorkoutSummary{{PostID: 2, SessionID: 7, Name: "Leg day"}}, nil)
 	repo.EXPECT().
-		ReadCommentsByPost(int64(2)).
-		Return([]*model.Comment{c1p2}, nil)
-
+		ReadPostMedia([]int64{1, 2}).
+		Return([]*model.Media{{ID: "a", PostID: 1}, {ID: "b", PostID: 1}}, nil)
 	repo.EXPECT().
-		ReadCommentsByComment(int64(20)).
-		Return([]*model.Comment{}, nil)
+		ReadPostReactions([]int64{1, 2}, userID).
+		Return([]*model.ReactionCount{
+			{PostID: 1, Reaction: model.ReactionLike, Count: 3},
+			{PostID: 1, Reaction: model.ReactionFire, Count: 1, Mine: true},
+		}, nil)
 
-	got, err := svc.ReadPosts(userID)
+	page, err := svc.ReadPosts(model.ReadPostRequest{ViewerID: userID})
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
+	got := page.Posts
+	if page.NextCursor != "" {
+		t.Fatalf("expected the only page, got cursor %q", page.NextCursor)
+	}
 	if len(got) != 2 {
 		t.Fatalf("expected 2 posts, got %d", len(got))
 	}
@@ -135,6 +340,19 @@ func TestPostService_ReadPosts_OK(t *testing.T) {
 	if len(got[1].Comments[0].Replies) != 0 {
 		t.Fatalf("expected 0 replies on post 2 comment, got %d", len(got[1].Comments[0].Replies))
 	}
+
+	if got[0].Workout != nil || got[1].Workout == nil || got[1].Workout.SessionID != 7 {
+		t.Fatalf("only post 2 shares a workout, got %+v and %+v", got[0].Workout, got[1].Workout)
+	}
+	if len(got[0].Media) != 2 || got[0].Media[1].ID != "b" || got[1].Media != nil {
+		t.Fatalf("only post 1 has images, got %+v and %+v", got[0].Media, got[1].Media)
+	}
+	if got[0].Reactions[model.ReactionLike] != 3 || got[0].Reactions[model.ReactionFire] != 1 || got[0].MyReaction != model.ReactionFire {
+		t.Fatalf("unexpected reactions on post 1: %v, mine %q", got[0].Reactions, got[0].MyReaction)
+	}
+	if got[1].Reactions == nil || len(got[1].Reactions) != 0 || got[1].MyReaction != "" {
+		t.Fatalf("expected no reactions on post 2, got %v", got[1].Reactions)
+	}
 }
 
 func TestPostService_ReadPosts_Error(t *testing.T) {
@@ -142,12 +360,13 @@ func TestPostService_ReadPosts_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	userID := int64(42)
 
-	repo.EXPECT().ReadPosts(userID).Return(nil, errors.New("failed"))
-	got, err := svc.ReadPosts(userID)
+	repo.EXPECT().ReadPosts(gomock.Any()).Return(nil, errors.New("failed"))
+	got, err := svc.ReadPosts(model.ReadPostRequest{ViewerID: userID})
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
@@ -161,7 +380,8 @@ func TestPostService_ReadPostsByUserID_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	targetUserID := int64(100)
 	userID := int64(42)
@@ -173,19 +393,16 @@ func TestPostService_ReadPostsByUserID_OK(t *testing.T) {
 		ReadPostsByUserID(targetUserID, userID).
 		Return(posts, nil)
 
-	c1 := &model.Comment{ID: 10}
-	c2 := &model.Comment{ID: 11}
-	repo.EXPECT().
-		ReadCommentsByPost(int64(1)).
-		Return([]*model.Comment{c1, c2}, nil)
-
-	r1 := &model.Comment{ID: 100}
-	repo.EXPECT().
-		ReadCommentsByComment(int64(10)).
-		Return([]*model.Comment{r1}, nil)
 	repo.EXPECT().
-		ReadCommentsByComment(int64(11)).
-		Return([]*model.Comment{}, nil)
+		ReadComments(gomock.Any()).
+		Return([]*model.Comment{
+			{ID: 10, PostID: 1},
+			{ID: 11, PostID: 1},
+			{ID: 100, PostID: 1, ParentID: 10, Level: 1},
+		}, nil)
+	repo.EXPECT().ReadPostWorkouts([]int64{1}).Return([]*model.WorkoutSummary{}, nil)
+	repo.EXPECT().ReadPostMedia([]int64{1}).Return([]*model.Media{}, nil)
+	repo.EXPECT().ReadPostReactions([]int64{1}, userID).Return([]*model.ReactionCount{}, nil)
 
 	got, err := svc.ReadPostsByUserID(targetUserID, userID)
 	if err != nil {
@@ -211,7 +428,8 @@ func TestPostService_ReadPostsByUserID_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	targetUserID := int64(100)
 	userID := int64(42)
@@ -234,12 +452,15 @@ func TestPostService_UpdatePost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
-	req := model.UpdatePostRequest{ID: 1}
+	status := "archived"
+	req := model.UpdatePostRequest{ID: 1, Status: &status}
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
 	repo.EXPECT().UpdatePost(req).Return((*model.Post)(nil), errors.New("no post"))
 
-	got, err := svc.UpdatePost(req)
+	got, err := svc.UpdatePost(3, req)
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
@@ -248,16 +469,74 @@ func TestPostService_UpdatePost_Error(t *testing.T) {
 	}
 }
 
+func TestPostService_UpdatePost_NotAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	title := "Updated"
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
+
+	// staff can't edit someone else's post either, so the policy isn't asked
+	if _, err := svc.UpdatePost(4, model.UpdatePostRequest{ID: 1, Title: &title}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestPostService_UpdatePost_NothingToUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	if _, err := svc.UpdatePost(3, model.UpdatePostRequest{ID: 1}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPostService_ReadPostRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	want := []*model.PostRevision{{ID: 2, PostID: 1, Title: "Before"}}
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil).Times(2)
+	repo.EXPECT().ReadPostRevisions(int64(1)).Return(want, nil)
+
+	got, err := svc.ReadPostRevisions(3, 1)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Before" {
+		t.Fatalf("unexpected revisions: %#v", got)
+	}
+
+	if _, err := svc.ReadPostRevisions(4, 1); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestPostService_DeletePost_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
+	policy.EXPECT().CanModify(int64(3), int64(3)).Return(nil)
 	repo.EXPECT().DeletePost(int64(1)).Return(nil)
 
-	if err := svc.DeletePost(1); err != nil {
+	if err := svc.DeletePost(3, 1); err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
@@ -267,23 +546,45 @@ func TestPostService_DeletePost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
+	policy.EXPECT().CanModify(int64(3), int64(3)).Return(nil)
 	repo.EXPECT().DeletePost(int64(1)).Return(errors.New("not found"))
 
-	if err := svc.DeletePost(1); err == nil || err.Error() != "not found" {
+	if err := svc.DeletePost(3, 1); err == nil || err.Error() != "not found" {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
 
+func TestPostService_DeletePost_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(3), nil)
+	policy.EXPECT().CanModify(int64(4), int64(3)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	if err := svc.DeletePost(4, 1); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestPostService_CommentOnPost_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.CommentOnPostRequest{Comment: "Nice"}
+	repo.EXPECT().ReadPostOwnerID(req.PostID).Return(int64(9), nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
 	repo.EXPECT().CommentOnPost(req).Return(nil)
 
 	if err := svc.CommentOnPost(req); err != nil {
@@ -296,9 +597,12 @@ func TestPostService_CommentOnPost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.CommentOnPostRequest{Comment: "Bad"}
+	repo.EXPECT().ReadPostOwnerID(req.PostID).Return(int64(9), nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
 	repo.EXPECT().CommentOnPost(req).Return(errors.New("fail"))
 
 	if err := svc.CommentOnPost(req); err == nil || err.Error() != "fail" {
@@ -311,9 +615,12 @@ func TestPostService_CommentOnComment_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
-	req := model.CommentOnCommentRequest{Comment: "Reply"}
+	req := model.CommentOnCommentRequest{CommentID: 5, PostID: 1, UserID: 2, Comment: "Reply"}
+	repo.EXPECT().ReadCommentRef(int64(5)).Return(&model.CommentRef{ID: 5, PostID: 1, PostAuthorID: 9, Depth: model.MaxCommentDepth - 2}, nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
 	repo.EXPECT().CommentOnComment(req).Return(nil)
 
 	if err := svc.CommentOnComment(req); err != nil {
@@ -326,9 +633,12 @@ func TestPostService_CommentOnComment_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
-	req := model.CommentOnCommentRequest{Comment: "Reply"}
+	req := model.CommentOnCommentRequest{CommentID: 5, PostID: 1, UserID: 2, Comment: "Reply"}
+	repo.EXPECT().ReadCommentRef(int64(5)).Return(&model.CommentRef{ID: 5, PostID: 1, PostAuthorID: 9}, nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
 	repo.EXPECT().CommentOnComment(req).Return(errors.New("bad"))
 
 	if err := svc.CommentOnComment(req); err == nil || err.Error() != "bad" {
@@ -336,6 +646,180 @@ func TestPostService_CommentOnComment_Error(t *testing.T) {
 	}
 }
 
+func TestPostService_CommentOnComment_Invalid(t *testing.T) {
+	cases := map[string]*model.CommentRef{
+		"on another post": {ID: 5, PostID: 7, PostAuthorID: 9},
+		"deleted":         {ID: 5, PostID: 1, PostAuthorID: 9, Deleted: true},
+		"too deep":        {ID: 5, PostID: 1, PostAuthorID: 9, Depth: model.MaxCommentDepth - 1},
+	}
+	for name, parent := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mock_repository.NewMockPostRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+			repo.EXPECT().ReadCommentRef(int64(5)).Return(parent, nil)
+			policy.EXPECT().CanView(int64(2), int64(9)).Return(nil)
+
+			err := svc.CommentOnComment(model.CommentOnCommentRequest{CommentID: 5, PostID: 1, UserID: 2, Comment: "Reply"})
+			if !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPostService_ReadComments_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	created := time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(nil)
+	repo.EXPECT().ReadComments(model.CommentFilter{PostIDs: []int64{1}, Limit: 3, Depth: model.ThreadDepth, Replies: model.ThreadReplies}).
+		Return([]*model.Comment{
+			{ID: 10, PostID: 1, CreatedAt: created},
+			{ID: 11, PostID: 1, CreatedAt: created.Add(time.Minute)},
+			{ID: 12, PostID: 1, CreatedAt: created.Add(2 * time.Minute)},
+		}, nil)
+
+	page, err := svc.ReadComments(model.ReadCommentsRequest{ViewerID: 2, PostID: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Comments) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected a full page and a cursor, got %d comments and %q", len(page.Comments), page.NextCursor)
+	}
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(nil)
+	repo.EXPECT().ReadComments(model.CommentFilter{
+		PostIDs: []int64{1}, Limit: 3, Depth: model.ThreadDepth, Replies: model.ThreadReplies,
+		After: &model.CommentCursor{PostID: 1, CreatedAt: created.Add(time.Minute), ID: 11},
+	}).Return([]*model.Comment{{ID: 12, PostID: 1}}, nil)
+
+	page, err = svc.ReadComments(model.ReadCommentsRequest{ViewerID: 2, PostID: 1, Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Comments) != 1 || page.NextCursor != "" {
+		t.Fatalf("expected the last page, got %d comments and %q", len(page.Comments), page.NextCursor)
+	}
+}
+
+func TestPostService_ReadComments_Replies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	repo.EXPECT().ReadCommentRef(int64(10)).Return(&model.CommentRef{ID: 10, PostID: 1, PostAuthorID: 9}, nil).Times(2)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(nil).Times(2)
+	repo.EXPECT().ReadComments(model.CommentFilter{PostIDs: []int64{1}, ParentID: 10, Limit: model.DefaultCommentPageSize + 1, Depth: model.ThreadDepth, Replies: model.ThreadReplies}).
+		Return([]*model.Comment{{ID: 100, PostID: 1, ParentID: 10}}, nil)
+
+	page, err := svc.ReadComments(model.ReadCommentsRequest{ViewerID: 2, ParentID: 10})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Comments) != 1 || page.Comments[0].ID != 100 {
+		t.Fatalf("unexpected replies %+v", page.Comments)
+	}
+
+	// a cursor from another thread is refused
+	other := encodeCommentCursor(model.CommentCursor{PostID: 1, ParentID: 11, ID: 3})
+	if _, err := svc.ReadComments(model.ReadCommentsRequest{ViewerID: 2, ParentID: 10, Cursor: other}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPostService_ReadComments_PrivateAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	if _, err := svc.ReadComments(model.ReadCommentsRequest{ViewerID: 2, PostID: 1}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestPostService_UpdateComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	req := model.UpdateCommentRequest{ID: 5, Comment: "Fixed"}
+	repo.EXPECT().ReadCommentRef(int64(5)).Return(&model.CommentRef{ID: 5, AuthorID: 2, PostAuthorID: 9}, nil).Times(2)
+	repo.EXPECT().UpdateComment(req).Return(&model.Comment{ID: 5, Comment: "Fixed", Edited: true}, nil)
+
+	got, err := svc.UpdateComment(2, req)
+	if err != nil || !got.Edited {
+		t.Fatalf("unexpected: %+v err=%v", got, err)
+	}
+
+	// not even the post's author can put words in a commenter's mouth
+	if _, err := svc.UpdateComment(9, req); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.UpdateComment(2, model.UpdateCommentRequest{ID: 5, Comment: "  "}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPostService_DeleteComment(t *testing.T) {
+	ref := &model.CommentRef{ID: 5, AuthorID: 2, PostAuthorID: 9}
+	cases := []struct {
+		name    string
+		actorID int64
+		policy  error
+		wantErr error
+	}{
+		{name: "post author", actorID: 9},
+		{name: "comment author", actorID: 2},
+		{name: "someone else", actorID: 4, policy: fmt.Errorf("%w: this belongs to another user", util.ErrForbidden), wantErr: util.ErrForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mock_repository.NewMockPostRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+			repo.EXPECT().ReadCommentRef(int64(5)).Return(ref, nil)
+			if tc.actorID != ref.PostAuthorID {
+				policy.EXPECT().CanModify(tc.actorID, ref.AuthorID).Return(tc.policy)
+			}
+			if tc.wantErr == nil {
+				repo.EXPECT().DeleteComment(int64(5)).Return(nil)
+			}
+
+			if err := svc.DeleteComment(tc.actorID, 5); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 // ===== Like / Unlike tests =====
 
 func TestPostService_LikePost_OK(t *testing.T) {
@@ -343,12 +827,20 @@ func TestPostService_LikePost_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.LikePostRequest{UserID: 2, PostID: 1}
 	want := &model.Post{ID: 1, IsLiked: true}
 
-	repo.EXPECT().LikePost(req).Return(want, nil)
+	// a reaction is a like unless it says otherwise
+	liked := req
+	liked.Reaction = model.ReactionLike
+	repo.EXPECT().ReadPostOwnerID(req.PostID).Return(int64(9), nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
+	repo.EXPECT().LikePost(liked).Return(want, nil)
+	repo.EXPECT().ReadPostReactions([]int64{1}, req.UserID).
+		Return([]*model.ReactionCount{{PostID: 1, Reaction: model.ReactionLike, Count: 1, Mine: true}}, nil)
 
 	got, err := svc.LikePost(req)
 	if err != nil {
@@ -357,6 +849,21 @@ func TestPostService_LikePost_OK(t *testing.T) {
 	if got == nil || got.ID != want.ID || !got.IsLiked {
 		t.Fatalf("unexpected post: %#v", got)
 	}
+	if got.MyReaction != model.ReactionLike || got.Reactions[model.ReactionLike] != 1 {
+		t.Fatalf("unexpected reactions: %v, mine %q", got.Reactions, got.MyReaction)
+	}
+}
+
+func TestPostService_LikePost_UnknownReaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := NewPostService(mock_repository.NewMockPostRepository(ctrl), nil, nil, nil, nil, nil)
+
+	_, err := svc.LikePost(model.LikePostRequest{UserID: 2, PostID: 1, Reaction: "love"})
+	if !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
 }
 
 func TestPostService_LikePost_Error(t *testing.T) {
@@ -364,9 +871,12 @@ func TestPostService_LikePost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
-	req := model.LikePostRequest{UserID: 2, PostID: 1}
+	req := model.LikePostRequest{UserID: 2, PostID: 1, Reaction: model.ReactionStrong}
+	repo.EXPECT().ReadPostOwnerID(req.PostID).Return(int64(9), nil)
+	policy.EXPECT().CanView(req.UserID, int64(9)).Return(nil)
 	repo.EXPECT().LikePost(req).Return((*model.Post)(nil), errors.New("like fail"))
 
 	got, err := svc.LikePost(req)
@@ -378,17 +888,36 @@ func TestPostService_LikePost_Error(t *testing.T) {
 	}
 }
 
+func TestPostService_LikePost_PrivateAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	req := model.LikePostRequest{UserID: 2, PostID: 1}
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	if _, err := svc.LikePost(req); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestPostService_UnlikePost_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.UnikePostRequest{UserID: 2, PostID: 1}
 	want := &model.Post{ID: 1, IsLiked: false}
 
 	repo.EXPECT().UnlikePost(req).Return(want, nil)
+	repo.EXPECT().ReadPostReactions([]int64{1}, req.UserID).Return(nil, nil)
 
 	got, err := svc.UnlikePost(req)
 	if err != nil {
@@ -404,7 +933,8 @@ func TestPostService_UnlikePost_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockPostRepository(ctrl)
-	svc := NewPostService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
 
 	req := model.UnikePostRequest{UserID: 2, PostID: 1}
 	repo.EXPECT().UnlikePost(req).Return((*model.Post)(nil), errors.New("unlike fail"))
@@ -417,3 +947,147 @@ func TestPostService_UnlikePost_Error(t *testing.T) {
 		t.Fatalf("expected unlike fail, got %v", err)
 	}
 }
+
+func TestPostService_ReadPosts_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	svc := &PostService{repo: repo, now: func() time.Time { return time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC) }}
+	asOf := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().ReadPosts(model.PostFeedFilter{ViewerID: 42, Scope: "discover", Sort: "ranked", AsOf: asOf, Limit: 3}).
+		Return([]*model.Post{{ID: 9, Score: 0.9}, {ID: 4, Score: 0.5}, {ID: 8, Score: 0.1}}, nil)
+	repo.EXPECT().ReadComments(gomock.Any()).Return(nil, nil)
+	repo.EXPECT().ReadPostWorkouts([]int64{9, 4}).Return(nil, nil)
+	repo.EXPECT().ReadPostMedia([]int64{9, 4}).Return(nil, nil)
+	repo.EXPECT().ReadPostReactions([]int64{9, 4}, int64(42)).Return(nil, nil)
+
+	page, err := svc.ReadPosts(model.ReadPostRequest{ViewerID: 42, Scope: "discover", Sort: "ranked", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Posts) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected a full page and a cursor, got %d posts and %q", len(page.Posts), page.NextCursor)
+	}
+
+	// the next page keeps scoring as of the first one even though time has moved on
+	svc.now = func() time.Time { return asOf.Add(time.Hour) }
+	repo.EXPECT().ReadPosts(model.PostFeedFilter{
+		ViewerID: 42, Scope: "discover", Sort: "ranked", AsOf: asOf, Limit: 3,
+		After: &model.PostFeedCursor{Scope: "discover", Sort: "ranked", AsOf: asOf, Score: 0.5, ID: 4},
+	}).Return([]*model.Post{}, nil)
+
+	page, err = svc.ReadPosts(model.ReadPostRequest{ViewerID: 42, Scope: "discover", Sort: "ranked", Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Posts) != 0 || page.NextCursor != "" {
+		t.Fatalf("expected an empty last page, got %+v", page)
+	}
+}
+
+func TestPostService_ReadPosts_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := &PostService{repo: mock_repository.NewMockPostRepository(ctrl), now: time.Now}
+	recent := encodePostFeedCursor(model.PostFeedCursor{Scope: "following", Sort: "recent", ID: 3})
+
+	for _, req := range []model.ReadPostRequest{
+		{Scope: "everyone"},
+		{Sort: "popular"},
+		{Limit: -1},
+		{Cursor: "not a cursor"},
+		// cursors only continue the feed they came from
+		{Sort: "ranked", Cursor: recent},
+		{Scope: "discover", Cursor: recent},
+	} {
+		if _, err := svc.ReadPosts(req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", req, err)
+		}
+	}
+}
+
+func TestPostService_ReadReactions_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	at := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil).Times(2)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(nil).Times(2)
+	repo.EXPECT().ReadReactions(model.ReactionFilter{PostID: 1, Type: "fire", Limit: 3}).
+		Return([]*model.Reaction{
+			{UserID: 5, Reaction: "fire", CreatedAt: at},
+			{UserID: 4, Reaction: "fire", CreatedAt: at.Add(-time.Minute)},
+			{UserID: 3, Reaction: "fire", CreatedAt: at.Add(-time.Hour)},
+		}, nil)
+
+	page, err := svc.ReadReactions(model.ReadReactionsRequest{ViewerID: 2, PostID: 1, Type: "fire", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Reactions) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected a full page and a cursor, got %d reactions and %q", len(page.Reactions), page.NextCursor)
+	}
+
+	repo.EXPECT().ReadReactions(model.ReactionFilter{
+		PostID: 1, Type: "fire", Limit: 3,
+		After: &model.ReactionCursor{PostID: 1, Type: "fire", CreatedAt: at.Add(-time.Minute), UserID: 4},
+	}).Return([]*model.Reaction{{UserID: 3, Reaction: "fire", CreatedAt: at.Add(-time.Hour)}}, nil)
+
+	page, err = svc.ReadReactions(model.ReadReactionsRequest{ViewerID: 2, PostID: 1, Type: "fire", Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Reactions) != 1 || page.NextCursor != "" {
+		t.Fatalf("expected the last page, got %d reactions and %q", len(page.Reactions), page.NextCursor)
+	}
+}
+
+func TestPostService_ReadReactions_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+	repo.EXPECT().ReadPostOwnerID(gomock.Any()).Return(int64(9), nil).AnyTimes()
+	policy.EXPECT().CanView(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// cursors only continue the list they came from
+	otherType := encodeReactionCursor(model.ReactionCursor{PostID: 1, Type: "clap", UserID: 3})
+	otherPost := encodeReactionCursor(model.ReactionCursor{PostID: 2, UserID: 3})
+
+	for _, req := range []model.ReadReactionsRequest{
+		{PostID: 1, Type: "love"},
+		{PostID: 1, Limit: -1},
+		{PostID: 1, Cursor: "not a cursor"},
+		{PostID: 1, Type: "fire", Cursor: otherType},
+		{PostID: 1, Cursor: otherPost},
+	} {
+		if _, err := svc.ReadReactions(req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", req, err)
+		}
+	}
+}
+
+func TestPostService_ReadReactions_PrivateAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockPostRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewPostService(repo, nil, nil, nil, nil, policy)
+
+	repo.EXPECT().ReadPostOwnerID(int64(1)).Return(int64(9), nil)
+	policy.EXPECT().CanView(int64(2), int64(9)).Return(fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	if _, err := svc.ReadReactions(model.ReadReactionsRequest{ViewerID: 2, PostID: 1}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
@@ -0,0 +1,530 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+type programService struct {
+	programRepository repository.ProgramRepository
+	routineRepository repository.RoutineRepository
+	recordRepository  repository.PersonalRecordRepository
+	routines          service.RoutineService
+	schedules         service.ScheduleService
+	policy            service.AccessPolicy
+	now               func() time.Time
+}
+
+func NewProgramService(programRepository repository.ProgramRepository, routineRepository repository.RoutineRepository, recordRepository repository.PersonalRecordRepository, routines service.RoutineService, schedules service.ScheduleService, policy service.AccessPolicy) service.ProgramService {
+	return &programService{
+		programRepository: programRepository,
+		routineRepository: routineRepository,
+		recordRepository:  recordRepository,
+		routines:          routines,
+		schedules:         schedules,
+		policy:            policy,
+		now:               time.Now,
+	}
+}
+
+// CreateProgram builds a program on the actor's own routines
+func (s *programService) CreateProgram(actorID int64, request model.CreateProgramRequest) (*model.Program, error) {
+	request.UserID = actorID
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", util.ErrInvalidInput)
+	}
+	if request.Visibility == "" {
+		request.Visibility = model.RoutineVisibilityPrivate
+	}
+	if err := checkRoutineVisibility(request.Visibility); err != nil {
+		return nil, err
+	}
+	if err := checkProgramWeeks(request.Weeks); err != nil {
+		return nil, err
+	}
+
+	routines, err := s.routineRepository.ReadUserRoutines(actorID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int64]bool, len(routines))
+	for _, routine := range routines {
+		owned[routine.ID] = true
+	}
+	for _, week := range request.Weeks {
+		for _, day := range week.Days {
+			if !owned[day.RoutineID] {
+				return nil, fmt.Errorf("%w: routine %d is not one of your routines", util.ErrForbidden, day.RoutineID)
+			}
+		}
+	}
+
+	return s.programRepository.CreateProgram(request)
+}
+
+func (s *programService) ReadProgram(actorID, programID int64) (*model.Program, error) {
+	program, err := s.programRepository.ReadProgramByID(programID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.canViewProgram(actorID, program); err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+// DeleteProgram cancels its active enrollments, which enrollees keep; the
+// schedules already generated for them stay on their calendars
+func (s *programService) DeleteProgram(actorID, programID int64) error {
+	program, err := s.programRepository.ReadProgramByID(programID)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.CanModify(actorID, program.UserID); err != nil {
+		return err
+	}
+	return s.programRepository.DeleteProgram(programID)
+}
+
+// Enroll undoes what it has created when week 1 can't be scheduled, so a
+// clash with another schedule leaves nothing behind
+func (s *programService) Enroll(actorID int64, request model.EnrollRequest) (*model.ProgramEnrollment, error) {
+	request.UserID = actorID
+	program, err := s.ReadProgram(actorID, request.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	timezone, err := normalizeScheduleWindow(request.Timezone, request.StartsOn, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseTimeSlot(request.TimeSlot); err != nil {
+		return nil, fmt.Errorf("%w: timeSlot must be HH:MM or HH:MM:SS", util.ErrInvalidInput)
+	}
+	if request.RoutineLengthMinutes < 1 || request.RoutineLengthMinutes > maxRoutineLengthMinutes {
+		return nil, fmt.Errorf("%w: routineLengthMinutes must be between 1 and %d", util.ErrInvalidInput, maxRoutineLengthMinutes)
+	}
+	location, _ := time.LoadLocation(timezone)
+	today := localDate(s.now(), location)
+	startsOn := today
+	if request.StartsOn != "" {
+		startsOn, _ = time.Parse(time.DateOnly, request.StartsOn)
+		if startsOn.Before(today) {
+			return nil, fmt.Errorf("%w: startsOn can't be in the past", util.ErrInvalidInput)
+		}
+	}
+
+	enrollments, err := s.programRepository.ReadUserEnrollments(actorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, enrollment := range enrollments {
+		if enrollment.ProgramID == program.ID && enrollment.Status == model.EnrollmentActive {
+			return nil, fmt.Errorf("%w: you are already enrolled in this program", util.ErrConflict)
+		}
+	}
+
+	// the enrollee runs their own copy of each routine, so it can be scheduled,
+	// started and edited like any of theirs
+	routines := map[int64]int64{}
+	var copies []int64
+	undo := func() {
+		for _, routineID := range copies {
+			_ = s.routines.DeleteRoutine(actorID, routineID)
+		}
+	}
+	for _, week := range program.Weeks {
+		for _, day := range week.Days {
+			if _, ok := routines[day.RoutineID]; ok {
+				continue
+			}
+			if program.UserID == actorID {
+				routines[day.RoutineID] = day.RoutineID
+				continue
+			}
+			routine, err := s.routines.CopyProgramRoutine(actorID, day.RoutineID)
+			if err != nil {
+				undo()
+				return nil, err
+			}
+			copies = append(copies, routine.ID)
+			routines[day.RoutineID] = routine.ID
+		}
+	}
+
+	enrollment, err := s.programRepository.CreateEnrollment(&model.ProgramEnrollment{
+		UserID:               actorID,
+		ProgramID:            program.ID,
+		Status:               model.EnrollmentActive,
+		StartedOn:            startsOn.Format(time.DateOnly),
+		TimeSlot:             request.TimeSlot,
+		RoutineLengthMinutes: request.RoutineLengthMinutes,
+		Timezone:             timezone,
+		Routines:             routines,
+	})
+	if err != nil {
+		undo()
+		return nil, err
+	}
+	enrollment.ProgramName = program.Name
+	enrollment.TotalWeeks = len(program.Weeks)
+
+	if err := s.advance(enrollment, program, true); err != nil {
+		_ = s.programRepository.DeleteEnrollment(enrollment.ID)
+		undo()
+		return nil, err
+	}
+	if err := s.fillWeek(enrollment, program); err != nil {
+		s.discardSchedules(enrollment)
+		_ = s.programRepository.DeleteEnrollment(enrollment.ID)
+		undo()
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+// discardSchedules deletes the schedules generated for an enrollment's
+// current week, which outlive the enrollment itself
+func (s *programService) discardSchedules(enrollment *model.ProgramEnrollment) {
+	schedules, err := s.programRepository.ReadEnrollmentSchedules(enrollment.ID, enrollment.CurrentWeek)
+	if err != nil {
+		return
+	}
+	for _, schedule := range schedules {
+		_ = s.schedules.DeleteSchedule(enrollment.UserID, model.DeleteScheduleRequest{ID: schedule.ScheduleID})
+	}
+}
+
+// ReadEnrollments only reads; AdvanceEnrollments moves active enrollments on
+// to the weeks that have started
+func (s *programService) ReadEnrollments(actorID int64) ([]*model.ProgramEnrollment, error) {
+	enrollments, err := s.programRepository.ReadUserEnrollments(actorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, enrollment := range enrollments {
+		if enrollment.Status != model.EnrollmentActive {
+			continue
+		}
+		program, err := s.programRepository.ReadProgramByID(enrollment.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.fillWeek(enrollment, program); err != nil {
+			return nil, err
+		}
+	}
+	return enrollments, nil
+}
+
+// AdvanceEnrollments runs periodically. One enrollment failing is logged and
+// doesn't hold up the others.
+func (s *programService) AdvanceEnrollments() error {
+	enrollments, err := s.programRepository.ReadActiveEnrollments()
+	if err != nil {
+		return err
+	}
+	programs := map[int64]*model.Program{}
+	for _, enrollment := range enrollments {
+		program, ok := programs[enrollment.ProgramID]
+		if !ok {
+			if program, err = s.programRepository.ReadProgramByID(enrollment.ProgramID); err != nil {
+				log.Printf("advancing enrollment %d: %v", enrollment.ID, err)
+				continue
+			}
+			programs[enrollment.ProgramID] = program
+		}
+		if err := s.advance(enrollment, program, false); err != nil {
+			log.Printf("advancing enrollment %d: %v", enrollment.ID, err)
+		}
+	}
+	return nil
+}
+
+// CancelEnrollment removes the schedules of the current week from today on;
+// those of earlier days stay, so adherence still counts them
+func (s *programService) CancelEnrollment(actorID, enrollmentID int64) error {
+	enrollment, err := s.programRepository.ReadEnrollmentByID(enrollmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.CanModify(actorID, enrollment.UserID); err != nil {
+		return err
+	}
+	if enrollment.Status != model.EnrollmentActive {
+		return fmt.Errorf("%w: the enrollment is already %s", util.ErrConflict, enrollment.Status)
+	}
+
+	location, err := time.LoadLocation(enrollment.Timezone)
+	if err != nil {
+		return err
+	}
+	today := localDate(s.now(), location)
+	weekStart := enrollmentWeekStart(enrollment, enrollment.CurrentWeek)
+	schedules, err := s.programRepository.ReadEnrollmentSchedules(enrollment.ID, enrollment.CurrentWeek)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if programDate(weekStart, schedule.DayOfWeek).Before(today) {
+			continue
+		}
+		err := s.schedules.DeleteSchedule(actorID, model.DeleteScheduleRequest{ID: schedule.ScheduleID})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+	return s.programRepository.UpdateEnrollmentProgress(enrollment.ID, enrollment.CurrentWeek, model.EnrollmentCancelled)
+}
+
+// advance schedules every week up to the one today falls in, and completes
+// the enrollment once its last week is over. Weeks missed while the job wasn't
+// running are still scheduled, so adherence reports them. Only strict mode
+// fails on days that clash with another schedule; otherwise they are left
+// unscheduled.
+func (s *programService) advance(enrollment *model.ProgramEnrollment, program *model.Program, strict bool) error {
+	location, err := time.LoadLocation(enrollment.Timezone)
+	if err != nil {
+		return err
+	}
+	target := int(localDate(s.now(), location).Sub(enrollmentWeekStart(enrollment, 1)).Hours()/24)/7 + 1
+	if target < 1 {
+		target = 1
+	}
+
+	for week := enrollment.CurrentWeek + 1; week <= min(target, len(program.Weeks)); week++ {
+		// claiming the week first keeps another run, or a cancellation, from
+		// racing the schedules it creates
+		claimed, err := s.programRepository.ClaimEnrollmentWeek(enrollment.ID, week)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return nil
+		}
+		if err := s.scheduleWeek(enrollment, program, week, strict); err != nil {
+			_ = s.programRepository.UpdateEnrollmentProgress(enrollment.ID, week-1, model.EnrollmentActive)
+			return err
+		}
+		enrollment.CurrentWeek = week
+	}
+	if target <= len(program.Weeks) {
+		return nil
+	}
+	enrollment.Status = model.EnrollmentCompleted
+	return s.programRepository.UpdateEnrollmentProgress(enrollment.ID, enrollment.CurrentWeek, enrollment.Status)
+}
+
+// scheduleWeek gives each day of the week a schedule that occurs only on its date
+func (s *programService) scheduleWeek(enrollment *model.ProgramEnrollment, program *model.Program, week int, strict bool) error {
+	weekStart := enrollmentWeekStart(enrollment, week)
+	generated := make([]*model.EnrollmentSchedule, 0, len(program.Weeks[week-1].Days))
+	for _, day := range program.Weeks[week-1].Days {
+		// the enrollee may have deleted their copy of the routine
+		routineID, ok := enrollment.Routines[day.RoutineID]
+		if !ok {
+			continue
+		}
+		date := programDate(weekStart, day.DayOfWeek).Format(time.DateOnly)
+		schedule, err := s.schedules.CreateSchedule(model.CreateScheduleRequest{
+			Name:                 fmt.Sprintf("%s: week %d", program.Name, week),
+			UserID:               enrollment.UserID,
+			DayOfWeek:            day.DayOfWeek,
+			RoutineIDs:           []int64{routineID},
+			TimeSlot:             enrollment.TimeSlot,
+			RoutineLengthMinutes: enrollment.RoutineLengthMinutes,
+			Timezone:             enrollment.Timezone,
+			StartsOn:             date,
+			EndsOn:               date,
+		})
+		if err != nil {
+			if !strict && errors.Is(err, util.ErrConflict) {
+				continue
+			}
+			for _, created := range generated {
+				_ = s.schedules.DeleteSchedule(enrollment.UserID, model.DeleteScheduleRequest{ID: created.ScheduleID})
+			}
+			return err
+		}
+		generated = append(generated, &model.EnrollmentSchedule{
+			EnrollmentID: enrollment.ID,
+			ScheduleID:   schedule.ID,
+			Week:         week,
+			DayOfWeek:    day.DayOfWeek,
+		})
+	}
+	return s.programRepository.CreateEnrollmentSchedules(generated)
+}
+
+// fillWeek lists the current week's days of an active enrollment by date,
+// turning percentage loads into weights from the enrollee's estimated 1RMs
+func (s *programService) fillWeek(enrollment *model.ProgramEnrollment, program *model.Program) error {
+	if enrollment.Status != model.EnrollmentActive || enrollment.CurrentWeek < 1 {
+		return nil
+	}
+	schedules, err := s.programRepository.ReadEnrollmentSchedules(enrollment.ID, enrollment.CurrentWeek)
+	if err != nil {
+		return err
+	}
+	scheduled := make(map[int64]int64, len(schedules))
+	for _, schedule := range schedules {
+		scheduled[schedule.DayOfWeek] = schedule.ScheduleID
+	}
+
+	var oneRepMaxes map[int64]float64
+	weekStart := enrollmentWeekStart(enrollment, enrollment.CurrentWeek)
+	enrollment.Days = []*model.EnrolledDay{}
+	for _, day := range program.Weeks[enrollment.CurrentWeek-1].Days {
+		routineID, ok := enrollment.Routines[day.RoutineID]
+		if !ok {
+			continue
+		}
+		enrolled := &model.EnrolledDay{
+			Date:       programDate(weekStart, day.DayOfWeek).Format(time.DateOnly),
+			DayOfWeek:  day.DayOfWeek,
+			RoutineID:  routineID,
+			ScheduleID: scheduled[day.DayOfWeek],
+			LoadType:   day.LoadType,
+			Load:       day.Load,
+		}
+		if day.LoadType == model.LoadTypePercent {
+			if oneRepMaxes == nil {
+				if oneRepMaxes, err = s.readOneRepMaxes(enrollment.UserID); err != nil {
+					return err
+				}
+			}
+			routine, err := s.routineRepository.ReadRoutineWithExercises(routineID)
+			if err != nil {
+				return err
+			}
+			enrolled.Targets = loadTargets(routine.ExerciseIDs, oneRepMaxes, day.Load)
+		}
+		enrollment.Days = append(enrollment.Days, enrolled)
+	}
+	// weeks start on any weekday, so the program's order isn't the calendar's
+	sort.Slice(enrollment.Days, func(i, j int) bool { return enrollment.Days[i].Date < enrollment.Days[j].Date })
+	return nil
+}
+
+// readOneRepMaxes reads the user's best Epley estimate for each exercise
+func (s *programService) readOneRepMaxes(userID int64) (map[int64]float64, error) {
+	records, err := s.recordRepository.ReadBestRecords(userID)
+	if err != nil {
+		return nil, err
+	}
+	oneRepMaxes := map[int64]float64{}
+	for _, record := range filterFormula(records, model.FormulaEpley) {
+		if record.Type == model.RecordTypeEstimated1RM && record.Value > oneRepMaxes[record.ExerciseID] {
+			oneRepMaxes[record.ExerciseID] = record.Value
+		}
+	}
+	return oneRepMaxes, nil
+}
+
+// canViewProgram shares programs the same way as routines
+func (s *programService) canViewProgram(actorID int64, program *model.Program) error {
+	switch program.Visibility {
+	case model.RoutineVisibilityPublic:
+		return s.policy.CanView(actorID, program.UserID)
+	case model.RoutineVisibilityFollowers:
+		return s.policy.CanViewAsFollower(actorID, program.UserID)
+	default:
+		if err := s.policy.CanModify(actorID, program.UserID); err != nil {
+			if errors.Is(err, util.ErrForbidden) {
+				return fmt.Errorf("%w: this program is private", util.ErrForbidden)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// checkProgramWeeks allows weeks without days, e.g. for rest, but not a
+// program without any
+func checkProgramWeeks(weeks []*model.ProgramWeek) error {
+	if len(weeks) == 0 || len(weeks) > model.MaxProgramWeeks {
+		return fmt.Errorf("%w: a program has between 1 and %d weeks", util.ErrInvalidInput, model.MaxProgramWeeks)
+	}
+	days := 0
+	for i, week := range weeks {
+		if week == nil {
+			return fmt.Errorf("%w: week %d is empty", util.ErrInvalidInput, i+1)
+		}
+		seen := map[int64]bool{}
+		for _, day := range week.Days {
+			if day == nil {
+				return fmt.Errorf("%w: week %d has an empty day", util.ErrInvalidInput, i+1)
+			}
+			if day.DayOfWeek < 0 || day.DayOfWeek > 6 {
+				return fmt.Errorf("%w: dayOfWeek must be between 0 (Sunday) and 6 (Saturday)", util.ErrInvalidInput)
+			}
+			if seen[day.DayOfWeek] {
+				return fmt.Errorf("%w: week %d plans day %d twice", util.ErrInvalidInput, i+1, day.DayOfWeek)
+			}
+			seen[day.DayOfWeek] = true
+			switch day.LoadType {
+			case model.LoadTypePercent:
+				if day.Load <= 0 || day.Load > 100 {
+					return fmt.Errorf("%w: a percentage load must be above 0 and at most 100", util.ErrInvalidInput)
+				}
+			case model.LoadTypeRPE:
+				if day.Load < 1 || day.Load > 10 {
+					return fmt.Errorf("%w: an RPE load must be between 1 and 10", util.ErrInvalidInput)
+				}
+			default:
+				return fmt.Errorf("%w: loadType must be one of %s", util.ErrInvalidInput, strings.Join(model.LoadTypes, ", "))
+			}
+			days++
+		}
+	}
+	if days == 0 {
+		return fmt.Errorf("%w: a program needs at least one day", util.ErrInvalidInput)
+	}
+	return nil
+}
+
+// loadTargets takes the percentage of each exercise's estimated 1RM, rounded
+// to the nearest half, in the order of the routine
+func loadTargets(exerciseIDs []int64, oneRepMaxes map[int64]float64, percent float64) []*model.LoadTarget {
+	targets := []*model.LoadTarget{}
+	for _, exerciseID := range exerciseIDs {
+		oneRepMax, ok := oneRepMaxes[exerciseID]
+		if !ok {
+			continue
+		}
+		targets = append(targets, &model.LoadTarget{
+			ExerciseID:         exerciseID,
+			EstimatedOneRepMax: oneRepMax,
+			Weight:             math.Round(oneRepMax*percent/100*2) / 2,
+		})
+	}
+	return targets
+}
+
+func localDate(now time.Time, location *time.Location) time.Time {
+	date, _ := time.Parse(time.DateOnly, now.In(location).Format(time.DateOnly))
+	return date
+}
+
+// enrollmentWeekStart is the first date of a week of the enrollment; weeks
+// start on the weekday the enrollment started on
+func enrollmentWeekStart(enrollment *model.ProgramEnrollment, week int) time.Time {
+	startedOn, _ := time.Parse(time.DateOnly, enrollment.StartedOn)
+	return startedOn.AddDate(0, 0, 7*(week-1))
+}
+
+// programDate is the date within the 7 days from weekStart that falls on dayOfWeek
+func programDate(weekStart time.Time, dayOfWeek int64) time.Time {
+	return weekStart.AddDate(0, 0, (int(dayOfWeek)-int(weekStart.Weekday())+7)%7)
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+
+	"github.com/golang/mock/gomock"
+)
+
+var programNow = time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC) // a Wednesday
+
+type programMocks struct {
+	programs  *mock_repository.MockProgramRepository
+	routines  *mock_repository.MockRoutineRepository
+	records   *mock_repository.MockPersonalRecordRepository
+	copier    *mock_service.MockRoutineService
+	schedules *mock_service.MockScheduleService
+	policy    *mock_service.MockAccessPolicy
+}
+
+func newProgramMocks(t *testing.T) (programMocks, *programService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := programMocks{
+		programs:  mock_repository.NewMockProgramRepository(ctrl),
+		routines:  mock_repository.NewMockRoutineRepository(ctrl),
+		records:   mock_repository.NewMockPersonalRecordRepository(ctrl),
+		copier:    mock_service.NewMockRoutineService(ctrl),
+		schedules: mock_service.NewMockScheduleService(ctrl),
+		policy:    mock_service.NewMockAccessPolicy(ctrl),
+	}
+	svc := NewProgramService(m.programs, m.routines, m.records, m.copier, m.schedules, m.policy).(*programService)
+	svc.now = func() time.Time { return programNow }
+	return m, svc
+}
+
+// two weeks by user 1: Monday and Friday, then a lighter Monday
+func testProgram() *model.Program {
+	return &model.Program{ID: 7, UserID: 1, Name: "Strength", Visibility: model.RoutineVisibilityPublic, Weeks: []*model.ProgramWeek{
+		{Number: 1, Days: []*model.ProgramDay{
+			{DayOfWeek: 1, RoutineID: 10, LoadType: model.LoadTypePercent, Load: 70},
+			{DayOfWeek: 5, RoutineID: 11, LoadType: model.LoadTypeRPE, Load: 8},
+		}},
+		{Number: 2, Days: []*model.ProgramDay{
+			{DayOfWeek: 1, RoutineID: 10, LoadType: model.LoadTypePercent, Load: 60},
+		}},
+	}}
+}
+
+func TestProgramService_CreateProgram(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.routines.EXPECT().ReadUserRoutines(int64(1)).Return([]*model.ExerciseRoutine{{ID: 10}, {ID: 11}}, nil)
+	m.programs.EXPECT().CreateProgram(gomock.Any()).DoAndReturn(func(req model.CreateProgramRequest) (*model.Program, error) {
+		if req.UserID != 1 || req.Name != "Strength" || req.Visibility != model.RoutineVisibilityPrivate {
+			t.Fatalf("unexpected request: %#v", req)
+		}
+		return &model.Program{ID: 7, UserID: 1}, nil
+	})
+
+	program := testProgram()
+	got, err := svc.CreateProgram(1, model.CreateProgramRequest{UserID: 2, Name: " Strength ", Weeks: program.Weeks})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 7 {
+		t.Fatalf("unexpected program: %#v", got)
+	}
+}
+
+func TestProgramService_CreateProgram_Invalid(t *testing.T) {
+	day := func(dayOfWeek int64, loadType string, load float64) *model.ProgramDay {
+		return &model.ProgramDay{DayOfWeek: dayOfWeek, RoutineID: 10, LoadType: loadType, Load: load}
+	}
+	tests := map[string]model.CreateProgramRequest{
+		"no name":         {Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, model.LoadTypeRPE, 8)}}}},
+		"bad visibility":  {Name: "x", Visibility: "friends", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, model.LoadTypeRPE, 8)}}}},
+		"no weeks":        {Name: "x"},
+		"too many weeks":  {Name: "x", Weeks: make([]*model.ProgramWeek, model.MaxProgramWeeks+1)},
+		"no days":         {Name: "x", Weeks: []*model.ProgramWeek{{}}},
+		"bad day":         {Name: "x", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(7, model.LoadTypeRPE, 8)}}}},
+		"day twice":       {Name: "x", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, model.LoadTypeRPE, 8), day(1, model.LoadTypeRPE, 9)}}}},
+		"bad load type":   {Name: "x", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, "kg", 100)}}}},
+		"percent above":   {Name: "x", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, model.LoadTypePercent, 105)}}}},
+		"rpe out of band": {Name: "x", Weeks: []*model.ProgramWeek{{Days: []*model.ProgramDay{day(1, model.LoadTypeRPE, 11)}}}},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, svc := newProgramMocks(t)
+
+			if _, err := svc.CreateProgram(1, req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("want ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProgramService_CreateProgram_ForeignRoutine(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.routines.EXPECT().ReadUserRoutines(int64(1)).Return([]*model.ExerciseRoutine{{ID: 10}}, nil)
+
+	_, err := svc.CreateProgram(1, model.CreateProgramRequest{Name: "x", Weeks: testProgram().Weeks})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("want ErrForbidden, got %v", err)
+	}
+}
+
+func TestProgramService_ReadProgram_Private(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(&model.Program{ID: 7, UserID: 1, Visibility: model.RoutineVisibilityPrivate}, nil)
+	m.policy.EXPECT().CanModify(int64(2), int64(1)).Return(fmt.Errorf("%w: not your account", util.ErrForbidden))
+
+	_, err := svc.ReadProgram(2, 7)
+	if !errors.Is(err, util.ErrForbidden) || err.Error() != "forbidden: this program is private" {
+		t.Fatalf("want the program reported private, got %v", err)
+	}
+}
+
+func TestProgramService_Enroll(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.policy.EXPECT().CanView(int64(2), int64(1)).Return(nil)
+	m.programs.EXPECT().ReadUserEnrollments(int64(2)).Return([]*model.ProgramEnrollment{
+		{ProgramID: 7, Status: model.EnrollmentCompleted},
+	}, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(10)).Return(&model.ExerciseRoutine{ID: 100}, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(11)).Return(&model.ExerciseRoutine{ID: 101}, nil)
+	m.programs.EXPECT().CreateEnrollment(gomock.Any()).DoAndReturn(func(e *model.ProgramEnrollment) (*model.ProgramEnrollment, error) {
+		if e.StartedOn != "2025-03-05" || e.Timezone != "UTC" || e.CurrentWeek != 0 || e.Routines[10] != 100 || e.Routines[11] != 101 {
+			t.Fatalf("unexpected enrollment: %#v", e)
+		}
+		created := *e
+		created.ID = 90
+		return &created, nil
+	})
+	// week 1 runs from Wednesday, so Friday comes before Monday
+	m.schedules.EXPECT().CreateSchedule(model.CreateScheduleRequest{
+		Name: "Strength: week 1", UserID: 2, DayOfWeek: 1, RoutineIDs: []int64{100}, TimeSlot: "07:00",
+		RoutineLengthMinutes: 60, Timezone: "UTC", StartsOn: "2025-03-10", EndsOn: "2025-03-10",
+	}).Return(&model.Schedule{ID: 30}, nil)
+	m.schedules.EXPECT().CreateSchedule(model.CreateScheduleRequest{
+		Name: "Strength: week 1", UserID: 2, DayOfWeek: 5, RoutineIDs: []int64{101}, TimeSlot: "07:00",
+		RoutineLengthMinutes: 60, Timezone: "UTC", StartsOn: "2025-03-07", EndsOn: "2025-03-07",
+	}).Return(&model.Schedule{ID: 31}, nil)
+	m.programs.EXPECT().CreateEnrollmentSchedules([]*model.EnrollmentSchedule{
+		{EnrollmentID: 90, ScheduleID: 30, Week: 1, DayOfWeek: 1},
+		{EnrollmentID: 90, ScheduleID: 31, Week: 1, DayOfWeek: 5},
+	}).Return(nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 1).Return(true, nil)
+	m.programs.EXPECT().ReadEnrollmentSchedules(int64(90), 1).Return([]*model.EnrollmentSchedule{
+		{ScheduleID: 30, DayOfWeek: 1}, {ScheduleID: 31, DayOfWeek: 5},
+	}, nil)
+	m.records.EXPECT().ReadBestRecords(int64(2)).Return([]*model.PersonalRecord{
+		{ExerciseID: 50, Type: model.RecordTypeEstimated1RM, Formula: model.FormulaEpley, Value: 101},
+		{ExerciseID: 50, Type: model.RecordTypeEstimated1RM, Formula: model.FormulaBrzycki, Value: 120},
+		{ExerciseID: 51, Type: model.RecordTypeMaxWeight, Value: 80},
+	}, nil)
+	m.routines.EXPECT().ReadRoutineWithExercises(int64(100)).Return(&model.ExerciseRoutine{ID: 100, ExerciseIDs: []int64{50, 51}}, nil)
+
+	got, err := svc.Enroll(2, model.EnrollRequest{ProgramID: 7, TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 90 || got.CurrentWeek != 1 || got.TotalWeeks != 2 || got.ProgramName != "Strength" || len(got.Days) != 2 {
+		t.Fatalf("unexpected enrollment: %#v", got)
+	}
+	friday, monday := got.Days[0], got.Days[1]
+	if monday.Date != "2025-03-10" || monday.ScheduleID != 30 || monday.RoutineID != 100 || len(monday.Targets) != 1 {
+		t.Fatalf("unexpected Monday: %#v", monday)
+	}
+	// 70% of 101 is 70.7, rounded to the nearest half
+	if target := monday.Targets[0]; target.ExerciseID != 50 || target.EstimatedOneRepMax != 101 || target.Weight != 70.5 {
+		t.Fatalf("unexpected target: %#v", target)
+	}
+	if friday.Date != "2025-03-07" || friday.LoadType != model.LoadTypeRPE || friday.Load != 8 || len(friday.Targets) != 0 {
+		t.Fatalf("unexpected Friday: %#v", friday)
+	}
+}
+
+func TestProgramService_Enroll_OwnProgramKeepsRoutines(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	program := testProgram()
+	program.Weeks = program.Weeks[:1]
+	program.Weeks[0].Days = program.Weeks[0].Days[1:]
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(program, nil)
+	m.policy.EXPECT().CanView(int64(1), int64(1)).Return(nil)
+	m.programs.EXPECT().ReadUserEnrollments(int64(1)).Return(nil, nil)
+	m.programs.EXPECT().CreateEnrollment(gomock.Any()).DoAndReturn(func(e *model.ProgramEnrollment) (*model.ProgramEnrollment, error) {
+		if e.Routines[11] != 11 || e.StartedOn != "2025-03-10" {
+			t.Fatalf("unexpected enrollment: %#v", e)
+		}
+		created := *e
+		created.ID = 90
+		return &created, nil
+	})
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(&model.Schedule{ID: 30}, nil)
+	m.programs.EXPECT().CreateEnrollmentSchedules(gomock.Any()).Return(nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 1).Return(true, nil)
+	m.programs.EXPECT().ReadEnrollmentSchedules(int64(90), 1).Return(nil, nil)
+
+	got, err := svc.Enroll(1, model.EnrollRequest{ProgramID: 7, StartsOn: "2025-03-10", TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Days) != 1 || got.Days[0].Date != "2025-03-14" {
+		t.Fatalf("unexpected days: %#v", got.Days)
+	}
+}
+
+func TestProgramService_Enroll_UndoesOnClash(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	clash := util.WithFields(fmt.Errorf("%w: the schedule overlaps 1 of your other schedules", util.ErrConflict), nil)
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.policy.EXPECT().CanView(int64(2), int64(1)).Return(nil)
+	m.programs.EXPECT().ReadUserEnrollments(int64(2)).Return(nil, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(10)).Return(&model.ExerciseRoutine{ID: 100}, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(11)).Return(&model.ExerciseRoutine{ID: 101}, nil)
+	m.programs.EXPECT().CreateEnrollment(gomock.Any()).Return(&model.ProgramEnrollment{ID: 90, UserID: 2, StartedOn: "2025-03-05",
+		TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", Routines: map[int64]int64{10: 100, 11: 101}}, nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 1).Return(true, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(&model.Schedule{ID: 30}, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(nil, clash)
+	m.schedules.EXPECT().DeleteSchedule(int64(2), model.DeleteScheduleRequest{ID: 30}).Return(nil)
+	m.programs.EXPECT().UpdateEnrollmentProgress(int64(90), 0, model.EnrollmentActive).Return(nil)
+	m.programs.EXPECT().DeleteEnrollment(int64(90)).Return(nil)
+	m.copier.EXPECT().DeleteRoutine(int64(2), int64(100)).Return(nil)
+	m.copier.EXPECT().DeleteRoutine(int64(2), int64(101)).Return(nil)
+
+	_, err := svc.Enroll(2, model.EnrollRequest{ProgramID: 7, TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("want ErrConflict, got %v", err)
+	}
+}
+
+func TestProgramService_Enroll_UndoesWhenLoadsFail(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.policy.EXPECT().CanView(int64(2), int64(1)).Return(nil)
+	m.programs.EXPECT().ReadUserEnrollments(int64(2)).Return(nil, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(10)).Return(&model.ExerciseRoutine{ID: 100}, nil)
+	m.copier.EXPECT().CopyProgramRoutine(int64(2), int64(11)).Return(&model.ExerciseRoutine{ID: 101}, nil)
+	m.programs.EXPECT().CreateEnrollment(gomock.Any()).Return(&model.ProgramEnrollment{ID: 90, UserID: 2, Status: model.EnrollmentActive,
+		StartedOn: "2025-03-05", TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", Routines: map[int64]int64{10: 100, 11: 101}}, nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 1).Return(true, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(&model.Schedule{ID: 30}, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(&model.Schedule{ID: 31}, nil)
+	m.programs.EXPECT().CreateEnrollmentSchedules(gomock.Any()).Return(nil)
+	m.programs.EXPECT().ReadEnrollmentSchedules(int64(90), 1).Return([]*model.EnrollmentSchedule{
+		{ScheduleID: 30, DayOfWeek: 1}, {ScheduleID: 31, DayOfWeek: 5},
+	}, nil).Times(2)
+	m.records.EXPECT().ReadBestRecords(int64(2)).Return(nil, errors.New("db down"))
+	m.schedules.EXPECT().DeleteSchedule(int64(2), model.DeleteScheduleRequest{ID: 30}).Return(nil)
+	m.schedules.EXPECT().DeleteSchedule(int64(2), model.DeleteScheduleRequest{ID: 31}).Return(nil)
+	m.programs.EXPECT().DeleteEnrollment(int64(90)).Return(nil)
+	m.copier.EXPECT().DeleteRoutine(int64(2), int64(100)).Return(nil)
+	m.copier.EXPECT().DeleteRoutine(int64(2), int64(101)).Return(nil)
+
+	_, err := svc.Enroll(2, model.EnrollRequest{ProgramID: 7, TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if err == nil || err.Error() != "db down" {
+		t.Fatalf("want the read error, got %v", err)
+	}
+}
+
+func TestProgramService_Enroll_Invalid(t *testing.T) {
+	tests := map[string]model.EnrollRequest{
+		"bad time slot":   {TimeSlot: "7am", RoutineLengthMinutes: 60},
+		"bad length":      {TimeSlot: "07:00"},
+		"bad time zone":   {TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "Mars/Olympus"},
+		"bad start":       {TimeSlot: "07:00", RoutineLengthMinutes: 60, StartsOn: "next monday"},
+		"start in past":   {TimeSlot: "07:00", RoutineLengthMinutes: 60, StartsOn: "2025-03-04"},
+		"start yesterday": {TimeSlot: "07:00", RoutineLengthMinutes: 60, StartsOn: "2025-03-05", Timezone: "Pacific/Kiritimati"},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, svc := newProgramMocks(t)
+			m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+			m.policy.EXPECT().CanView(int64(2), int64(1)).Return(nil)
+
+			req.ProgramID = 7
+			if _, err := svc.Enroll(2, req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("want ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProgramService_Enroll_AlreadyEnrolled(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.policy.EXPECT().CanView(int64(2), int64(1)).Return(nil)
+	m.programs.EXPECT().ReadUserEnrollments(int64(2)).Return([]*model.ProgramEnrollment{{ProgramID: 7, Status: model.EnrollmentActive}}, nil)
+
+	_, err := svc.Enroll(2, model.EnrollRequest{ProgramID: 7, TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("want ErrConflict, got %v", err)
+	}
+}
+
+func TestProgramService_ReadEnrollments_OnlyReads(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	// week 3 has started but the job hasn't advanced the enrollment yet; no
+	// schedules are created and no progress is written
+	m.programs.EXPECT().ReadUserEnrollments(int64(2)).Return([]*model.ProgramEnrollment{
+		{ID: 90, UserID: 2, ProgramID: 7, Status: model.EnrollmentActive, StartedOn: "2025-02-19", CurrentWeek: 1, TotalWeeks: 2,
+			TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", Routines: map[int64]int64{10: 100, 11: 101}},
+		{ID: 80, UserID: 2, Status: model.EnrollmentCancelled},
+	}, nil)
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.programs.EXPECT().ReadEnrollmentSchedules(int64(90), 1).Return([]*model.EnrollmentSchedule{{ScheduleID: 30, DayOfWeek: 1}}, nil)
+	m.records.EXPECT().ReadBestRecords(int64(2)).Return(nil, nil)
+	m.routines.EXPECT().ReadRoutineWithExercises(int64(100)).Return(&model.ExerciseRoutine{ID: 100}, nil)
+
+	got, err := svc.ReadEnrollments(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].CurrentWeek != 1 || len(got[0].Days) != 2 || got[1].Days != nil {
+		t.Fatalf("unexpected enrollments: %#v", got)
+	}
+	if got[0].Days[0].Date != "2025-02-21" || got[0].Days[1].ScheduleID != 30 {
+		t.Fatalf("unexpected days: %#v %#v", got[0].Days[0], got[0].Days[1])
+	}
+}
+
+func TestProgramService_AdvanceEnrollments_AdvancesWeeks(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	program := testProgram()
+	program.Weeks = append(program.Weeks, &model.ProgramWeek{Number: 3, Days: []*model.ProgramDay{
+		{DayOfWeek: 3, RoutineID: 11, LoadType: model.LoadTypeRPE, Load: 9},
+		{DayOfWeek: 5, RoutineID: 11, LoadType: model.LoadTypeRPE, Load: 9},
+	}})
+	// week 3 starts today; the enrollment was last advanced in week 1
+	m.programs.EXPECT().ReadActiveEnrollments().Return([]*model.ProgramEnrollment{
+		{ID: 90, UserID: 2, ProgramID: 7, Status: model.EnrollmentActive, StartedOn: "2025-02-19", CurrentWeek: 1, TotalWeeks: 3,
+			TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", Routines: map[int64]int64{10: 100, 11: 101}},
+	}, nil)
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(program, nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 2).Return(true, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).DoAndReturn(func(req model.CreateScheduleRequest) (*model.Schedule, error) {
+		if req.Name != "Strength: week 2" || req.StartsOn != "2025-03-03" {
+			t.Fatalf("unexpected schedule: %#v", req)
+		}
+		return &model.Schedule{ID: 32}, nil
+	})
+	m.programs.EXPECT().CreateEnrollmentSchedules([]*model.EnrollmentSchedule{{EnrollmentID: 90, ScheduleID: 32, Week: 2, DayOfWeek: 1}}).Return(nil)
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 3).Return(true, nil)
+	// a clash in a later week leaves that day off the calendar
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(nil, fmt.Errorf("%w: overlap", util.ErrConflict))
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).DoAndReturn(func(req model.CreateScheduleRequest) (*model.Schedule, error) {
+		if req.StartsOn != "2025-03-07" {
+			t.Fatalf("unexpected schedule: %#v", req)
+		}
+		return &model.Schedule{ID: 34}, nil
+	})
+	m.programs.EXPECT().CreateEnrollmentSchedules([]*model.EnrollmentSchedule{{EnrollmentID: 90, ScheduleID: 34, Week: 3, DayOfWeek: 5}}).Return(nil)
+
+	if err := svc.AdvanceEnrollments(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProgramService_AdvanceEnrollments_CompletesAfterLastWeek(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadActiveEnrollments().Return([]*model.ProgramEnrollment{
+		{ID: 90, UserID: 2, ProgramID: 7, Status: model.EnrollmentActive, StartedOn: "2025-02-19", CurrentWeek: 2, TotalWeeks: 2,
+			TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", Routines: map[int64]int64{10: 100, 11: 101}},
+	}, nil)
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	m.programs.EXPECT().UpdateEnrollmentProgress(int64(90), 2, model.EnrollmentCompleted).Return(nil)
+
+	if err := svc.AdvanceEnrollments(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProgramService_AdvanceEnrollments_SkipsClaimedWeeksAndFailures(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadActiveEnrollments().Return([]*model.ProgramEnrollment{
+		{ID: 90, UserID: 2, ProgramID: 7, Status: model.EnrollmentActive, StartedOn: "2025-02-26", CurrentWeek: 1, Timezone: "UTC"},
+		{ID: 91, UserID: 3, ProgramID: 7, Status: model.EnrollmentActive, StartedOn: "2025-02-26", CurrentWeek: 1, Timezone: "UTC",
+			Routines: map[int64]int64{10: 110}},
+		{ID: 92, UserID: 4, ProgramID: 8, Status: model.EnrollmentActive, StartedOn: "2025-02-26", CurrentWeek: 1, Timezone: "UTC"},
+	}, nil)
+	// the program is read once for both of its enrollments
+	m.programs.EXPECT().ReadProgramByID(int64(7)).Return(testProgram(), nil)
+	// another run has already moved enrollment 90 on, so nothing is scheduled
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(90), 2).Return(false, nil)
+	// enrollment 91 fails and gives its week back, and 92's program can't be
+	// read; neither stops the run
+	m.programs.EXPECT().ClaimEnrollmentWeek(int64(91), 2).Return(true, nil)
+	m.schedules.EXPECT().CreateSchedule(gomock.Any()).Return(nil, errors.New("db down"))
+	m.programs.EXPECT().UpdateEnrollmentProgress(int64(91), 1, model.EnrollmentActive).Return(nil)
+	m.programs.EXPECT().ReadProgramByID(int64(8)).Return(nil, errors.New("db down"))
+
+	if err := svc.AdvanceEnrollments(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProgramService_CancelEnrollment(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	// week 2 runs Monday to Sunday; Monday is over, today and Friday are not
+	m.programs.EXPECT().ReadEnrollmentByID(int64(90)).Return(&model.ProgramEnrollment{
+		ID: 90, UserID: 2, Status: model.EnrollmentActive, StartedOn: "2025-02-24", CurrentWeek: 2, Timezone: "UTC",
+	}, nil)
+	m.policy.EXPECT().CanModify(int64(2), int64(2)).Return(nil)
+	m.programs.EXPECT().ReadEnrollmentSchedules(int64(90), 2).Return([]*model.EnrollmentSchedule{
+		{ScheduleID: 30, DayOfWeek: 1}, {ScheduleID: 31, DayOfWeek: 3}, {ScheduleID: 32, DayOfWeek: 5},
+	}, nil)
+	m.schedules.EXPECT().DeleteSchedule(int64(2), model.DeleteScheduleRequest{ID: 31}).Return(nil)
+	m.schedules.EXPECT().DeleteSchedule(int64(2), model.DeleteScheduleRequest{ID: 32}).Return(nil)
+	m.programs.EXPECT().UpdateEnrollmentProgress(int64(90), 2, model.EnrollmentCancelled).Return(nil)
+
+	if err := svc.CancelEnrollment(2, 90); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestProgramService_CancelEnrollment_NotActive(t *testing.T) {
+	m, svc := newProgramMocks(t)
+
+	m.programs.EXPECT().ReadEnrollmentByID(int64(90)).Return(&model.ProgramEnrollment{ID: 90, UserID: 2, Status: model.EnrollmentCompleted}, nil)
+	m.policy.EXPECT().CanModify(int64(2), int64(2)).Return(nil)
+
+	if err := svc.CancelEnrollment(2, 90); !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("want ErrConflict, got %v", err)
+	}
+}
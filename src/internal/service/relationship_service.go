@@ -9,17 +9,30 @@ import (
 type relationshipService struct {
 	relationshipRepository repository.RelationshipRepository
 	userRepository         repository.UserRepository
+	achievements           service.AchievementEvaluator
+	policy                 service.AccessPolicy
 }
 
-func NewRelationshipService(relationshipRepository repository.RelationshipRepository, userRepository repository.UserRepository) service.RelationshipService {
+func NewRelationshipService(relationshipRepository repository.RelationshipRepository, userRepository repository.UserRepository, achievements service.AchievementEvaluator, policy service.AccessPolicy) service.RelationshipService {
 	return &relationshipService{
 		relationshipRepository: relationshipRepository,
 		userRepository:         userRepository,
+		achievements:           achievements,
+		policy:                 policy,
 	}
 }
 
+// FollowUser is refused for private profiles, which have to accept a follow request instead
 func (u *relationshipService) FollowUser(followerID, followeeID int64) error {
-	return u.relationshipRepository.FollowUser(followerID, followeeID)
+	if err := u.policy.CanView(followerID, followeeID); err != nil {
+		return err
+	}
+	if err := u.relationshipRepository.FollowUser(followerID, followeeID); err != nil {
+		return err
+	}
+
+	u.evaluateFollow(followerID, followeeID)
+	return nil
 }
 
 func (u *relationshipService) UnfollowUser(followerID, followeeID int64) error {
@@ -72,30 +85,44 @@ func (u *relationshipService) GetPendingFollowRequests(userID int64) ([]*model.F
 	return u.relationshipRepository.GetPendingFollowRequests(userID)
 }
 
-func (u *relationshipService) AcceptFollowRequest(requestID int64) error {
-	// Get the request details
-	req, err := u.relationshipRepository.GetFollowRequestByID(requestID)
-	if err != nil {
+func (u *relationshipService) AcceptFollowRequest(actorID, requestID int64) error {
+	req, err := u.readReceivedFollowRequest(actorID, requestID)
+	if err != nil || req == nil {
 		return err
 	}
-	if req == nil {
-		return nil // Request not found
-	}
 	
 	// Create the follow relationship
 	err = u.relationshipRepository.FollowUser(req.RequesterID, req.RequestedID)
 	if err != nil {
 		return err
 	}
+	u.evaluateFollow(req.RequesterID, req.RequestedID)
 	
 	// Update status to accepted
 	return u.relationshipRepository.UpdateFollowRequestStatus(requestID, "accepted")
 }
 
-func (u *relationshipService) RejectFollowRequest(requestID int64) error {
+func (u *relationshipService) RejectFollowRequest(actorID, requestID int64) error {
+	req, err := u.readReceivedFollowRequest(actorID, requestID)
+	if err != nil || req == nil {
+		return err
+	}
 	return u.relationshipRepository.UpdateFollowRequestStatus(requestID, "rejected")
 }
 
+// readReceivedFollowRequest only lets the requested user answer a request; a
+// missing request is returned as nil
+func (u *relationshipService) readReceivedFollowRequest(actorID, requestID int64) (*model.FollowRequestModel, error) {
+	req, err := u.relationshipRepository.GetFollowRequestByID(requestID)
+	if err != nil || req == nil {
+		return nil, err
+	}
+	if err := u.policy.CanModify(actorID, req.RequestedID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 func (u *relationshipService) CancelFollowRequest(requesterID, requestedID int64) error {
 	req, err := u.relationshipRepository.GetFollowRequest(requesterID, requestedID)
 	if err != nil {
@@ -106,3 +133,9 @@ func (u *relationshipService) CancelFollowRequest(requesterID, requestedID int64
 	}
 	return u.relationshipRepository.DeleteFollowRequest(req.ID)
 }
+
+// evaluateFollow notifies achievements for both sides of a new follow
+func (u *relationshipService) evaluateFollow(followerID, followeeID int64) {
+	evaluateAchievements(u.achievements, followerID, model.CriteriaFollowing)
+	evaluateAchievements(u.achievements, followeeID, model.CriteriaFollowers)
+}
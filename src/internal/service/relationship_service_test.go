@@ -2,10 +2,13 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
 
 	"github.com/golang/mock/gomock"
 )
@@ -16,8 +19,10 @@ func TestRelationshipService_FollowUser_OK(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
+	policy.EXPECT().CanView(int64(1), int64(2)).Return(nil)
 	repo.EXPECT().FollowUser(int64(1), int64(2)).Return(nil)
 
 	if err := svc.FollowUser(1, 2); err != nil {
@@ -25,14 +30,36 @@ func TestRelationshipService_FollowUser_OK(t *testing.T) {
 	}
 }
 
+func TestRelationshipService_FollowUser_EvaluatesBothSides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRelationshipRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	achievements := mock_service.NewMockAchievementService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, achievements, policy)
+
+	policy.EXPECT().CanView(int64(1), int64(2)).Return(nil)
+	repo.EXPECT().FollowUser(int64(1), int64(2)).Return(nil)
+	achievements.EXPECT().Evaluate(int64(1), model.CriteriaFollowing).Return(nil, nil)
+	achievements.EXPECT().Evaluate(int64(2), model.CriteriaFollowers).Return(nil, nil)
+
+	if err := svc.FollowUser(1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestRelationshipService_FollowUser_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
+	policy.EXPECT().CanView(int64(1), int64(2)).Return(nil)
 	repo.EXPECT().FollowUser(int64(1), int64(2)).Return(errors.New("already following"))
 
 	if err := svc.FollowUser(1, 2); err == nil || err.Error() != "already following" {
@@ -40,13 +67,30 @@ func TestRelationshipService_FollowUser_Error(t *testing.T) {
 	}
 }
 
+func TestRelationshipService_FollowUser_PrivateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRelationshipRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
+
+	policy.EXPECT().CanView(int64(1), int64(2)).Return(fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	if err := svc.FollowUser(1, 2); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestRelationshipService_UnfollowUser_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	repo.EXPECT().UnfollowUser(int64(3), int64(5)).Return(nil)
 
@@ -61,7 +105,8 @@ func TestRelationshipService_UnfollowUser_Error(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	repo.EXPECT().UnfollowUser(int64(3), int64(5)).Return(errors.New("not following"))
 
@@ -76,7 +121,8 @@ func TestRelationshipService_ReadUserFollowers_OK(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	followerIds := []int64{10, 11, 12}
 	want := []model.User{
@@ -104,7 +150,8 @@ func TestRelationshipService_ReadUserFollowers_Error(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	repo.EXPECT().ReadUserFollowers(int64(7)).Return(nil, errors.New("user not found"))
 
@@ -123,7 +170,8 @@ func TestRelationshipService_ReadUserFollowing_OK(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	followingIds := []int64{20, 21}
 	want := []model.User{
@@ -149,7 +197,8 @@ func TestRelationshipService_ReadUserFollowing_Error(t *testing.T) {
 
 	repo := mock_repository.NewMockRelationshipRepository(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewRelationshipService(repo, userRepo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
 
 	repo.EXPECT().ReadUserFollowing(int64(8)).Return(nil, errors.New("user not found"))
 
@@ -161,3 +210,57 @@ func TestRelationshipService_ReadUserFollowing_Error(t *testing.T) {
 		t.Fatalf("expected user not found, got %v", err)
 	}
 }
+
+func TestRelationshipService_AcceptFollowRequest_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRelationshipRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
+
+	repo.EXPECT().GetFollowRequestByID(int64(7)).Return(&model.FollowRequestModel{ID: 7, RequesterID: 1, RequestedID: 2}, nil)
+	policy.EXPECT().CanModify(int64(2), int64(2)).Return(nil)
+	repo.EXPECT().FollowUser(int64(1), int64(2)).Return(nil)
+	repo.EXPECT().UpdateFollowRequestStatus(int64(7), "accepted").Return(nil)
+
+	if err := svc.AcceptFollowRequest(2, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRelationshipService_AcceptFollowRequest_SentToSomeoneElse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRelationshipRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
+
+	repo.EXPECT().GetFollowRequestByID(int64(7)).Return(&model.FollowRequestModel{ID: 7, RequesterID: 1, RequestedID: 2}, nil)
+	policy.EXPECT().CanModify(int64(1), int64(2)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	// the requester cannot accept their own request
+	if err := svc.AcceptFollowRequest(1, 7); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRelationshipService_RejectFollowRequest_SentToSomeoneElse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRelationshipRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRelationshipService(repo, userRepo, nil, policy)
+
+	repo.EXPECT().GetFollowRequestByID(int64(7)).Return(&model.FollowRequestModel{ID: 7, RequesterID: 1, RequestedID: 2}, nil)
+	policy.EXPECT().CanModify(int64(3), int64(2)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	if err := svc.RejectFollowRequest(3, 7); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
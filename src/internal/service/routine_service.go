@@ -1,39 +1,407 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 )
 
 type routineService struct {
-	routineRepository repository.RoutineRepository
+	routineRepository  repository.RoutineRepository
+	exerciseRepository repository.ExerciseRepository
+	achievements       service.AchievementEvaluator
+	policy             service.AccessPolicy
 }
 
-func NewRoutineService(routineRepository repository.RoutineRepository) service.RoutineService {
-	return &routineService{routineRepository: routineRepository}
+func NewRoutineService(routineRepository repository.RoutineRepository, exerciseRepository repository.ExerciseRepository, achievements service.AchievementEvaluator, policy service.AccessPolicy) service.RoutineService {
+	return &routineService{routineRepository: routineRepository, exerciseRepository: exerciseRepository, achievements: achievements, policy: policy}
 }
 
-func (u *routineService) CreateRoutine(userID int64, request model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
-	return u.routineRepository.CreateRoutine(userID, request)
+func (u *routineService) CreateRoutine(actorID, userID int64, request model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
+	if err := u.policy.CanModify(actorID, userID); err != nil {
+		return nil, err
+	}
+	if request.Visibility == "" {
+		request.Visibility = model.RoutineVisibilityPrivate
+	}
+	if err := checkRoutineVisibility(request.Visibility); err != nil {
+		return nil, err
+	}
+	if len(request.Exercises) == 0 {
+		request.Exercises = make([]model.RoutineExercise, 0, len(request.ExerciseIDs))
+		for _, exerciseID := range request.ExerciseIDs {
+			request.Exercises = append(request.Exercises, model.RoutineExercise{ExerciseID: exerciseID})
+		}
+	}
+	if err := checkRoutineExercises(request.Exercises); err != nil {
+		return nil, err
+	}
+	for _, entry := range request.Exercises {
+		if err := u.checkExerciseUsable(userID, entry.ExerciseID); err != nil {
+			return nil, err
+		}
+	}
+
+	routine, err := u.routineRepository.CreateRoutine(userID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluateAchievements(u.achievements, userID, model.CriteriaRoutines)
+	return routine, nil
+}
+
+// ReadUserRoutines lists the routines of the user that are shared with the actor
+func (u *routineService) ReadUserRoutines(actorID, userID int64) ([]*model.ExerciseRoutine, error) {
+	if err := u.policy.CanView(actorID, userID); err != nil {
+		return nil, err
+	}
+	routines, err := u.routineRepository.ReadUserRoutines(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// each visibility is checked once; public ones passed CanView above
+	shared := map[string]bool{model.RoutineVisibilityPublic: true}
+	visible := make([]*model.ExerciseRoutine, 0, len(routines))
+	for _, routine := range routines {
+		ok, checked := shared[routine.Visibility]
+		if !checked {
+			err := u.canViewRoutine(actorID, routine)
+			if err != nil && !errors.Is(err, util.ErrForbidden) {
+				return nil, err
+			}
+			ok = err == nil
+			shared[routine.Visibility] = ok
+		}
+		if ok {
+			visible = append(visible, routine)
+		}
+	}
+	return visible, nil
 }
 
-func (u *routineService) ReadUserRoutines(userID int64) ([]*model.ExerciseRoutine, error) {
-	return u.routineRepository.ReadUserRoutines(userID)
+// ReadPublicRoutines pages through the routines everyone can see
+func (u *routineService) ReadPublicRoutines(request model.ReadPublicRoutinesRequest) (*model.RoutinePage, error) {
+	filter, err := newPublicRoutineFilter(request)
+	if err != nil {
+		return nil, err
+	}
+	limit := filter.Limit
+	filter.Limit++
+
+	routines, err := u.routineRepository.ReadPublicRoutines(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.RoutinePage{Routines: routines}
+	if len(routines) > limit {
+		page.Routines = routines[:limit]
+		last := page.Routines[limit-1]
+		page.NextCursor = encodeRoutineCursor(model.RoutineCursor{Sort: filter.Sort, ForkCount: last.ForkCount, ID: last.ID})
+	}
+	return page, nil
 }
 
-func (u *routineService) DeleteRoutine(routineID int64) error {
+func (u *routineService) DeleteRoutine(actorID, routineID int64) error {
+	if _, err := u.readModifiableRoutine(actorID, routineID); err != nil {
+		return err
+	}
 	return u.routineRepository.DeleteRoutine(routineID)
 }
 
-func (u *routineService) ReadRoutineWithExercises(routineID int64) (*model.ExerciseRoutine, error) {
-	return u.routineRepository.ReadRoutineWithExercises(routineID)
+func (u *routineService) ReadRoutineWithExercises(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	routine, err := u.routineRepository.ReadRoutineWithExercises(routineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.canViewRoutine(actorID, routine); err != nil {
+		return nil, err
+	}
+	return routine, nil
+}
+
+// UpdateRoutine only checks exercises that are new to the routine, so one
+// whose owner has since made it private can stay
+func (u *routineService) UpdateRoutine(actorID int64, request model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+	routine, err := u.readModifiableRoutine(actorID, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name == nil && request.Description == nil && request.Exercises == nil && request.Visibility == nil {
+		return nil, fmt.Errorf("%w: nothing to update", util.ErrInvalidInput)
+	}
+	if request.Name != nil && strings.TrimSpace(*request.Name) == "" {
+		return nil, fmt.Errorf("%w: name can't be empty", util.ErrInvalidInput)
+	}
+	if request.Visibility != nil {
+		if err := checkRoutineVisibility(*request.Visibility); err != nil {
+			return nil, err
+		}
+	}
+	if request.Exercises != nil {
+		if err := checkRoutineExercises(*request.Exercises); err != nil {
+			return nil, err
+		}
+		for _, entry := range *request.Exercises {
+			if slices.Contains(routine.ExerciseIDs, entry.ExerciseID) {
+				continue
+			}
+			if err := u.checkExerciseUsable(routine.UserID, entry.ExerciseID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return u.routineRepository.UpdateRoutine(request)
 }
 
-func (u *routineService) AddExerciseToRoutine(routineID, exerciseID int64) error {
+func (u *routineService) AddExerciseToRoutine(actorID, routineID, exerciseID int64) error {
+	routine, err := u.readModifiableRoutine(actorID, routineID)
+	if err != nil {
+		return err
+	}
+	if err := u.checkExerciseUsable(routine.UserID, exerciseID); err != nil {
+		return err
+	}
 	return u.routineRepository.AddExerciseToRoutine(routineID, exerciseID)
 }
 
-func (u *routineService) RemoveExerciseFromRoutine(routineID, exerciseID int64) error {
+func (u *routineService) RemoveExerciseFromRoutine(actorID, routineID, exerciseID int64) error {
+	if _, err := u.readModifiableRoutine(actorID, routineID); err != nil {
+		return err
+	}
 	return u.routineRepository.RemoveExerciseFromRoutine(routineID, exerciseID)
 }
+
+// ReorderRoutineExercises takes every exercise in the routine, each once, in
+// the new order. Targets move with their exercise.
+func (u *routineService) ReorderRoutineExercises(actorID, routineID int64, exerciseIDs []int64) (*model.ExerciseRoutine, error) {
+	routine, err := u.readModifiableRoutine(actorID, routineID)
+	if err != nil {
+		return nil, err
+	}
+
+	byExercise := make(map[int64]model.RoutineExercise, len(routine.Entries))
+	for _, entry := range routine.Entries {
+		byExercise[entry.ExerciseID] = entry
+	}
+	reordered := make([]model.RoutineExercise, 0, len(exerciseIDs))
+	for _, exerciseID := range exerciseIDs {
+		entry, ok := byExercise[exerciseID]
+		if !ok {
+			return nil, fmt.Errorf("%w: exercise %d is not in the routine or is listed twice", util.ErrInvalidInput, exerciseID)
+		}
+		delete(byExercise, exerciseID)
+		reordered = append(reordered, entry)
+	}
+	if len(byExercise) > 0 {
+		return nil, fmt.Errorf("%w: every exercise in the routine must be listed", util.ErrInvalidInput)
+	}
+	if err := checkSupersets(reordered); err != nil {
+		return nil, err
+	}
+
+	if err := u.routineRepository.ReorderRoutineExercises(routineID, exerciseIDs); err != nil {
+		return nil, err
+	}
+	routine.ExerciseIDs = exerciseIDs
+	routine.Entries = reordered
+	for i := range routine.Entries {
+		routine.Entries[i].Position = i
+	}
+	return routine, nil
+}
+
+func (u *routineService) ForkRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	original, err := u.ReadRoutineWithExercises(actorID, routineID)
+	if err != nil {
+		return nil, err
+	}
+	return u.forkRoutine(actorID, original)
+}
+
+func (u *routineService) CopyRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	original, err := u.readSharedRoutine(actorID, routineID)
+	if err != nil {
+		return nil, err
+	}
+	return u.forkRoutine(actorID, original)
+}
+
+func (u *routineService) CopyProgramRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	original, err := u.readSharedRoutine(actorID, routineID)
+	if err != nil {
+		return nil, err
+	}
+	return u.copyRoutine(actorID, original, nil)
+}
+
+// readSharedRoutine reads a routine shared through a post or program, which
+// only needs its owner's profile to be visible
+func (u *routineService) readSharedRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	original, err := u.routineRepository.ReadRoutineWithExercises(routineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.policy.CanView(actorID, original.UserID); err != nil {
+		return nil, err
+	}
+	return original, nil
+}
+
+// forkRoutine copies the routine along with its exercise settings, crediting
+// the original
+func (u *routineService) forkRoutine(actorID int64, original *model.ExerciseRoutine) (*model.ExerciseRoutine, error) {
+	return u.copyRoutine(actorID, original, &model.RoutineSource{RoutineID: original.ID, UserID: original.UserID})
+}
+
+// copyRoutine gives the actor their own private routine with the same name,
+// exercises and targets as the original. Private custom exercises of the
+// original's owner are left out.
+func (u *routineService) copyRoutine(actorID int64, original *model.ExerciseRoutine, source *model.RoutineSource) (*model.ExerciseRoutine, error) {
+	request := model.CreateRoutineRequest{
+		Name:        original.Name,
+		Description: original.Description,
+		ExerciseIDs: make([]int64, 0, len(original.ExerciseIDs)),
+		Exercises:   make([]model.RoutineExercise, 0, len(original.ExerciseIDs)),
+		Visibility:  model.RoutineVisibilityPrivate,
+		Source:      source,
+	}
+	targets := make(map[int64]model.RoutineExercise, len(original.Entries))
+	for _, entry := range original.Entries {
+		targets[entry.ExerciseID] = entry
+	}
+	for _, exerciseID := range original.ExerciseIDs {
+		err := u.checkExerciseUsable(actorID, exerciseID)
+		if errors.Is(err, util.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry, ok := targets[exerciseID]
+		if !ok {
+			entry = model.RoutineExercise{ExerciseID: exerciseID}
+		}
+		entry.Position = len(request.Exercises)
+		request.ExerciseIDs = append(request.ExerciseIDs, exerciseID)
+		request.Exercises = append(request.Exercises, entry)
+	}
+
+	routine, err := u.routineRepository.CreateRoutine(actorID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluateAchievements(u.achievements, actorID, model.CriteriaRoutines)
+	return routine, nil
+}
+
+func (u *routineService) readModifiableRoutine(actorID, routineID int64) (*model.ExerciseRoutine, error) {
+	routine, err := u.routineRepository.ReadRoutineWithExercises(routineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.policy.CanModify(actorID, routine.UserID); err != nil {
+		return nil, err
+	}
+	return routine, nil
+}
+
+// canViewRoutine lets the owner and admins see every routine, followers see
+// routines shared with followers, and anyone who can see the profile see
+// public ones
+func (u *routineService) canViewRoutine(actorID int64, routine *model.ExerciseRoutine) error {
+	switch routine.Visibility {
+	case model.RoutineVisibilityPublic:
+		return u.policy.CanView(actorID, routine.UserID)
+	case model.RoutineVisibilityFollowers:
+		return u.policy.CanViewAsFollower(actorID, routine.UserID)
+	default:
+		if err := u.policy.CanModify(actorID, routine.UserID); err != nil {
+			if errors.Is(err, util.ErrForbidden) {
+				return fmt.Errorf("%w: this routine is private", util.ErrForbidden)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// checkExerciseUsable keeps other users' private custom exercises out of a routine
+func (u *routineService) checkExerciseUsable(userID, exerciseID int64) error {
+	exercise, err := u.exerciseRepository.ReadExerciseByID(exerciseID)
+	if err != nil {
+		return err
+	}
+	if !exerciseVisibleTo(exercise, userID) {
+		return fmt.Errorf("%w: exercise %d is private", util.ErrForbidden, exerciseID)
+	}
+	if exercise.Deleted {
+		return fmt.Errorf("%w: exercise %d was deleted", util.ErrConflict, exerciseID)
+	}
+	return nil
+}
+
+// checkRoutineExercises validates an exercise list before it replaces a
+// routine's. Positions come from the order of the list.
+func checkRoutineExercises(entries []model.RoutineExercise) error {
+	seen := make(map[int64]bool, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		if entry.ExerciseID <= 0 {
+			return fmt.Errorf("%w: exerciseId is required", util.ErrInvalidInput)
+		}
+		if seen[entry.ExerciseID] {
+			return fmt.Errorf("%w: exercise %d is listed twice", util.ErrInvalidInput, entry.ExerciseID)
+		}
+		seen[entry.ExerciseID] = true
+		entry.Position = i
+
+		switch {
+		case entry.TargetSets != nil && *entry.TargetSets <= 0:
+			return fmt.Errorf("%w: targetSets must be positive", util.ErrInvalidInput)
+		case entry.RepsMin != nil && *entry.RepsMin <= 0:
+			return fmt.Errorf("%w: repsMin must be positive", util.ErrInvalidInput)
+		case entry.RepsMax != nil && *entry.RepsMax <= 0:
+			return fmt.Errorf("%w: repsMax must be positive", util.ErrInvalidInput)
+		case entry.RepsMin != nil && entry.RepsMax != nil && *entry.RepsMax < *entry.RepsMin:
+			return fmt.Errorf("%w: repsMax is below repsMin", util.ErrInvalidInput)
+		case entry.RestSeconds != nil && *entry.RestSeconds < 0:
+			return fmt.Errorf("%w: restSeconds can't be negative", util.ErrInvalidInput)
+		case entry.SupersetGroup != nil && *entry.SupersetGroup <= 0:
+			return fmt.Errorf("%w: supersetGroup must be positive", util.ErrInvalidInput)
+		}
+	}
+	return checkSupersets(entries)
+}
+
+// checkSupersets keeps the exercises of each superset next to each other
+func checkSupersets(entries []model.RoutineExercise) error {
+	closed := map[int]bool{}
+	current := 0
+	for _, entry := range entries {
+		group := 0
+		if entry.SupersetGroup != nil {
+			group = *entry.SupersetGroup
+		}
+		if group == current {
+			continue
+		}
+		if current != 0 {
+			closed[current] = true
+		}
+		if closed[group] {
+			return fmt.Errorf("%w: the exercises in superset %d must be next to each other", util.ErrInvalidInput, group)
+		}
+		current = group
+	}
+	return nil
+}
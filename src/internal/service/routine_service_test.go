@@ -2,10 +2,13 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"workoutpal/src/internal/model"
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 
 	"github.com/golang/mock/gomock"
 )
@@ -15,17 +18,19 @@ func TestRoutineService_CreateRoutine_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
 	const userID int64 = 1
 	req := model.CreateRoutineRequest{Name: "Push Day"}
 	want := &model.ExerciseRoutine{ID: 10, UserID: userID, Name: req.Name}
 
+	policy.EXPECT().CanModify(userID, userID).Return(nil)
 	repo.EXPECT().
 		CreateRoutine(userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
 		Return(want, nil)
 
-	got, err := svc.CreateRoutine(userID, req)
+	got, err := svc.CreateRoutine(userID, userID, req)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -39,16 +44,18 @@ func TestRoutineService_CreateRoutine_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
 	const userID int64 = 2
 	req := model.CreateRoutineRequest{Name: "Leg Day"}
 
+	policy.EXPECT().CanModify(userID, userID).Return(nil)
 	repo.EXPECT().
 		CreateRoutine(userID, gomock.AssignableToTypeOf(model.CreateRoutineRequest{})).
 		Return((*model.ExerciseRoutine)(nil), errors.New("validation failed"))
 
-	got, err := svc.CreateRoutine(userID, req)
+	got, err := svc.CreateRoutine(userID, userID, req)
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
@@ -62,17 +69,19 @@ func TestRoutineService_ReadUserRoutines_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
 	const userID int64 = 3
 	want := []*model.ExerciseRoutine{
-		{ID: 1, UserID: userID, Name: "A"},
-		{ID: 2, UserID: userID, Name: "B"},
+		{ID: 1, UserID: userID, Name: "A", Visibility: model.RoutineVisibilityPublic},
+		{ID: 2, UserID: userID, Name: "B", Visibility: model.RoutineVisibilityPublic},
 	}
 
+	policy.EXPECT().CanView(userID, userID).Return(nil)
 	repo.EXPECT().ReadUserRoutines(userID).Return(want, nil)
 
-	got, err := svc.ReadUserRoutines(userID)
+	got, err := svc.ReadUserRoutines(userID, userID)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -86,12 +95,14 @@ func TestRoutineService_ReadUserRoutines_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
 	const userID int64 = 4
+	policy.EXPECT().CanView(userID, userID).Return(nil)
 	repo.EXPECT().ReadUserRoutines(userID).Return(nil, errors.New("user not found"))
 
-	got, err := svc.ReadUserRoutines(userID)
+	got, err := svc.ReadUserRoutines(userID, userID)
 	if got != nil {
 		t.Fatalf("expected nil slice, got %#v", got)
 	}
@@ -105,11 +116,14 @@ func TestRoutineService_DeleteRoutine_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(5)).Return(&model.ExerciseRoutine{ID: 5, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
 	repo.EXPECT().DeleteRoutine(int64(5)).Return(nil)
 
-	if err := svc.DeleteRoutine(5); err != nil {
+	if err := svc.DeleteRoutine(4, 5); err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
@@ -119,26 +133,47 @@ func TestRoutineService_DeleteRoutine_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(6)).Return(&model.ExerciseRoutine{ID: 6, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
 	repo.EXPECT().DeleteRoutine(int64(6)).Return(errors.New("not found"))
 
-	if err := svc.DeleteRoutine(6); err == nil || err.Error() != "not found" {
+	if err := svc.DeleteRoutine(4, 6); err == nil || err.Error() != "not found" {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
 
+func TestRoutineService_DeleteRoutine_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(5)).Return(&model.ExerciseRoutine{ID: 5, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(2), int64(4)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	if err := svc.DeleteRoutine(2, 5); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestRoutineService_ReadRoutineWithExercises_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
-	want := &model.ExerciseRoutine{ID: 7, Name: "Pull Day"}
+	want := &model.ExerciseRoutine{ID: 7, UserID: 4, Name: "Pull Day", Visibility: model.RoutineVisibilityPublic}
 	repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(want, nil)
+	policy.EXPECT().CanView(int64(3), int64(4)).Return(nil)
 
-	got, err := svc.ReadRoutineWithExercises(7)
+	got, err := svc.ReadRoutineWithExercises(3, 7)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -152,12 +187,13 @@ func TestRoutineService_ReadRoutineWithExercises_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
 	repo.EXPECT().ReadRoutineWithExercises(int64(8)).
 		Return((*model.ExerciseRoutine)(nil), errors.New("not found"))
 
-	got, err := svc.ReadRoutineWithExercises(8)
+	got, err := svc.ReadRoutineWithExercises(4, 8)
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
@@ -171,11 +207,16 @@ func TestRoutineService_AddExerciseToRoutine_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100, Custom: true, OwnerID: 4}, nil)
 	repo.EXPECT().AddExerciseToRoutine(int64(9), int64(100)).Return(nil)
 
-	if err := svc.AddExerciseToRoutine(9, 100); err != nil {
+	if err := svc.AddExerciseToRoutine(4, 9, 100); err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
@@ -185,26 +226,71 @@ func TestRoutineService_AddExerciseToRoutine_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100}, nil)
 	repo.EXPECT().AddExerciseToRoutine(int64(9), int64(100)).
 		Return(errors.New("already added"))
 
-	if err := svc.AddExerciseToRoutine(9, 100); err == nil || err.Error() != "already added" {
+	if err := svc.AddExerciseToRoutine(4, 9, 100); err == nil || err.Error() != "already added" {
 		t.Fatalf("expected already added, got %v", err)
 	}
 }
 
+func TestRoutineService_AddExerciseToRoutine_OthersPrivateExercise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100, Custom: true, OwnerID: 5}, nil)
+
+	if err := svc.AddExerciseToRoutine(4, 9, 100); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRoutineService_CreateRoutine_OthersPrivateExercise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(1)).Return(&model.Exercise{ID: 1}, nil)
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100, Custom: true, OwnerID: 5}, nil)
+
+	_, err := svc.CreateRoutine(4, 4, model.CreateRoutineRequest{Name: "Legs", ExerciseIDs: []int64{1, 100}})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestRoutineService_RemoveExerciseFromRoutine_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(10)).Return(&model.ExerciseRoutine{ID: 10, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
 	repo.EXPECT().RemoveExerciseFromRoutine(int64(10), int64(101)).Return(nil)
 
-	if err := svc.RemoveExerciseFromRoutine(10, 101); err != nil {
+	if err := svc.RemoveExerciseFromRoutine(4, 10, 101); err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
@@ -214,12 +300,412 @@ func TestRoutineService_RemoveExerciseFromRoutine_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockRoutineRepository(ctrl)
-	svc := NewRoutineService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
 
+	repo.EXPECT().ReadRoutineWithExercises(int64(10)).Return(&model.ExerciseRoutine{ID: 10, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
 	repo.EXPECT().RemoveExerciseFromRoutine(int64(10), int64(101)).
 		Return(errors.New("not in routine"))
 
-	if err := svc.RemoveExerciseFromRoutine(10, 101); err == nil || err.Error() != "not in routine" {
+	if err := svc.RemoveExerciseFromRoutine(4, 10, 101); err == nil || err.Error() != "not in routine" {
 		t.Fatalf("expected not in routine, got %v", err)
 	}
 }
+
+func TestRoutineService_CopyRoutine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	achievements := mock_service.NewMockAchievementService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, achievements, policy)
+
+	sets, rest := 4, 90
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 5, Name: "Legs", ExerciseIDs: []int64{1, 100, 101},
+		Entries: []model.RoutineExercise{{ExerciseID: 1}, {ExerciseID: 100, Position: 1}, {ExerciseID: 101, Position: 2, TargetSets: &sets, RestSeconds: &rest}},
+	}, nil)
+	policy.EXPECT().CanView(int64(4), int64(5)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(1)).Return(&model.Exercise{ID: 1}, nil)
+	// the owner's private custom exercise stays behind, their public one comes along
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100, Custom: true, OwnerID: 5}, nil)
+	exercises.EXPECT().ReadExerciseByID(int64(101)).Return(&model.Exercise{ID: 101, Custom: true, OwnerID: 5, IsPublic: true}, nil)
+	// targets come along and positions close up behind the one left out
+	repo.EXPECT().CreateRoutine(int64(4), model.CreateRoutineRequest{Name: "Legs", ExerciseIDs: []int64{1, 101},
+		Exercises:  []model.RoutineExercise{{ExerciseID: 1}, {ExerciseID: 101, Position: 1, TargetSets: &sets, RestSeconds: &rest}},
+		Visibility: model.RoutineVisibilityPrivate,
+		Source:     &model.RoutineSource{RoutineID: 9, UserID: 5},
+	}).
+		Return(&model.ExerciseRoutine{ID: 12, UserID: 4, Name: "Legs", ExerciseIDs: []int64{1, 101}}, nil)
+	achievements.EXPECT().Evaluate(int64(4), model.CriteriaRoutines).Return(nil, nil)
+
+	got, err := svc.CopyRoutine(4, 9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 12 || got.UserID != 4 {
+		t.Fatalf("unexpected copy %+v", got)
+	}
+}
+
+func TestRoutineService_CopyProgramRoutine_IsNotAFork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 5, Name: "Legs", ExerciseIDs: []int64{1},
+		Entries: []model.RoutineExercise{{ExerciseID: 1}},
+	}, nil)
+	policy.EXPECT().CanView(int64(4), int64(5)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(1)).Return(&model.Exercise{ID: 1}, nil)
+	// no Source, so the original isn't credited and its fork count stays
+	repo.EXPECT().CreateRoutine(int64(4), model.CreateRoutineRequest{Name: "Legs", ExerciseIDs: []int64{1},
+		Exercises:  []model.RoutineExercise{{ExerciseID: 1}},
+		Visibility: model.RoutineVisibilityPrivate,
+	}).
+		Return(&model.ExerciseRoutine{ID: 12, UserID: 4, Name: "Legs", ExerciseIDs: []int64{1}}, nil)
+
+	got, err := svc.CopyProgramRoutine(4, 9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 12 || got.ForkedFrom != nil {
+		t.Fatalf("unexpected copy %+v", got)
+	}
+}
+
+func TestRoutineService_CopyRoutine_PrivateOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 5}, nil)
+	policy.EXPECT().CanView(int64(4), int64(5)).Return(fmt.Errorf("%w: this profile is private", util.ErrForbidden))
+
+	if _, err := svc.CopyRoutine(4, 9); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRoutineService_UpdateRoutine_ChecksOnlyNewExercises(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	name := "Pull B"
+	group := 1
+	list := []model.RoutineExercise{{ExerciseID: 12, SupersetGroup: &group}, {ExerciseID: 13, SupersetGroup: &group}, {ExerciseID: 11}}
+	repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4, ExerciseIDs: []int64{11, 12}}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(13)).Return(&model.Exercise{ID: 13}, nil)
+	repo.EXPECT().UpdateRoutine(gomock.Any()).DoAndReturn(func(req model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+		if req.ID != 7 || *req.Name != name || (*req.Exercises)[2].Position != 2 {
+			t.Fatalf("unexpected request %+v", req)
+		}
+		return &model.ExerciseRoutine{ID: 7, UserID: 4, Name: name, ExerciseIDs: []int64{12, 13, 11}}, nil
+	})
+
+	got, err := svc.UpdateRoutine(4, model.UpdateRoutineRequest{ID: 7, Name: &name, Exercises: &list})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Name != name {
+		t.Fatalf("unexpected routine %+v", got)
+	}
+}
+
+func TestRoutineService_UpdateRoutine_Invalid(t *testing.T) {
+	sets, zero, low, high := 3, 0, 8, 6
+	group, other := 1, 2
+	blank := " "
+	tests := []struct {
+		name string
+		req  model.UpdateRoutineRequest
+	}{
+		{"nothing to update", model.UpdateRoutineRequest{ID: 7}},
+		{"blank name", model.UpdateRoutineRequest{ID: 7, Name: &blank}},
+		{"duplicate exercise", model.UpdateRoutineRequest{ID: 7, Exercises: &[]model.RoutineExercise{{ExerciseID: 1}, {ExerciseID: 1}}}},
+		{"zero sets", model.UpdateRoutineRequest{ID: 7, Exercises: &[]model.RoutineExercise{{ExerciseID: 1, TargetSets: &zero}}}},
+		{"reps range upside down", model.UpdateRoutineRequest{ID: 7, Exercises: &[]model.RoutineExercise{{ExerciseID: 1, TargetSets: &sets, RepsMin: &low, RepsMax: &high}}}},
+		{"superset split up", model.UpdateRoutineRequest{ID: 7, Exercises: &[]model.RoutineExercise{
+			{ExerciseID: 1, SupersetGroup: &group}, {ExerciseID: 2, SupersetGroup: &other}, {ExerciseID: 3, SupersetGroup: &group},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockRoutineRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewRoutineService(repo, nil, nil, policy)
+
+			repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4, ExerciseIDs: []int64{1, 2, 3}}, nil)
+			policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+
+			if _, err := svc.UpdateRoutine(4, tt.req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRoutineService_UpdateRoutine_NewPrivateExercise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, nil, policy)
+
+	list := []model.RoutineExercise{{ExerciseID: 100}}
+	repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(100)).Return(&model.Exercise{ID: 100, Custom: true, OwnerID: 5}, nil)
+
+	if _, err := svc.UpdateRoutine(4, model.UpdateRoutineRequest{ID: 7, Exercises: &list}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRoutineService_ReorderRoutineExercises_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
+
+	sets := 5
+	repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4, ExerciseIDs: []int64{11, 12, 13},
+		Entries: []model.RoutineExercise{{ExerciseID: 11, TargetSets: &sets}, {ExerciseID: 12, Position: 1}, {ExerciseID: 13, Position: 2}},
+	}, nil)
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+	repo.EXPECT().ReorderRoutineExercises(int64(7), []int64{13, 11, 12}).Return(nil)
+
+	got, err := svc.ReorderRoutineExercises(4, 7, []int64{13, 11, 12})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	// the targets move with their exercise
+	if got.ExerciseIDs[0] != 13 || got.Entries[1].ExerciseID != 11 || got.Entries[1].Position != 1 || *got.Entries[1].TargetSets != 5 {
+		t.Fatalf("unexpected routine %+v", got)
+	}
+}
+
+func TestRoutineService_ReorderRoutineExercises_NotAPermutation(t *testing.T) {
+	group := 1
+	tests := map[string][]int64{
+		"missing one":     {12, 11},
+		"listed twice":    {11, 11, 12, 13},
+		"not in it":       {11, 12, 13, 14},
+		"splits superset": {11, 13, 12},
+	}
+	for name, order := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockRoutineRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewRoutineService(repo, nil, nil, policy)
+
+			repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4, ExerciseIDs: []int64{11, 12, 13},
+				Entries: []model.RoutineExercise{{ExerciseID: 11, SupersetGroup: &group}, {ExerciseID: 12, Position: 1, SupersetGroup: &group}, {ExerciseID: 13, Position: 2}},
+			}, nil)
+			policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil)
+
+			if _, err := svc.ReorderRoutineExercises(4, 7, order); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRoutineService_CreateRoutine_Visibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
+
+	policy.EXPECT().CanModify(int64(4), int64(4)).Return(nil).Times(2)
+	repo.EXPECT().CreateRoutine(int64(4), gomock.Any()).DoAndReturn(func(_ int64, req model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
+		if req.Visibility != model.RoutineVisibilityPrivate {
+			t.Fatalf("want private by default, got %q", req.Visibility)
+		}
+		return &model.ExerciseRoutine{ID: 1, UserID: 4, Visibility: req.Visibility}, nil
+	})
+
+	if _, err := svc.CreateRoutine(4, 4, model.CreateRoutineRequest{Name: "Push"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, err := svc.CreateRoutine(4, 4, model.CreateRoutineRequest{Name: "Push", Visibility: "friends"}); !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestRoutineService_ReadUserRoutines_OnlyShared(t *testing.T) {
+	tests := []struct {
+		name     string
+		follower bool
+		want     []int64
+	}{
+		{name: "follower", follower: true, want: []int64{2, 3}},
+		{name: "stranger", want: []int64{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockRoutineRepository(ctrl)
+			policy := mock_service.NewMockAccessPolicy(ctrl)
+			svc := NewRoutineService(repo, nil, nil, policy)
+
+			policy.EXPECT().CanView(int64(2), int64(4)).Return(nil)
+			repo.EXPECT().ReadUserRoutines(int64(4)).Return([]*model.ExerciseRoutine{
+				{ID: 1, UserID: 4, Visibility: model.RoutineVisibilityPrivate},
+				{ID: 2, UserID: 4, Visibility: model.RoutineVisibilityFollowers},
+				{ID: 3, UserID: 4, Visibility: model.RoutineVisibilityPublic},
+				{ID: 4, UserID: 4, Visibility: model.RoutineVisibilityPrivate},
+			}, nil)
+			// each visibility is asked about once
+			policy.EXPECT().CanModify(int64(2), int64(4)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+			var followerErr error
+			if !tt.follower {
+				followerErr = fmt.Errorf("%w: this is only shared with followers", util.ErrForbidden)
+			}
+			policy.EXPECT().CanViewAsFollower(int64(2), int64(4)).Return(followerErr)
+
+			got, err := svc.ReadUserRoutines(2, 4)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			ids := make([]int64, len(got))
+			for i, routine := range got {
+				ids[i] = routine.ID
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Fatalf("got routines %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutineService_ReadRoutineWithExercises_Private(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(7)).Return(&model.ExerciseRoutine{ID: 7, UserID: 4, Visibility: model.RoutineVisibilityPrivate}, nil)
+	policy.EXPECT().CanModify(int64(3), int64(4)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	if _, err := svc.ReadRoutineWithExercises(3, 7); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRoutineService_ForkRoutine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	exercises := mock_repository.NewMockExerciseRepository(ctrl)
+	achievements := mock_service.NewMockAchievementService(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, exercises, achievements, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 5, Name: "5x5", Description: "Strength",
+		Visibility: model.RoutineVisibilityFollowers, ExerciseIDs: []int64{1}, Entries: []model.RoutineExercise{{ExerciseID: 1}},
+	}, nil)
+	policy.EXPECT().CanViewAsFollower(int64(4), int64(5)).Return(nil)
+	exercises.EXPECT().ReadExerciseByID(int64(1)).Return(&model.Exercise{ID: 1}, nil)
+	repo.EXPECT().CreateRoutine(int64(4), model.CreateRoutineRequest{Name: "5x5", Description: "Strength", ExerciseIDs: []int64{1},
+		Exercises:  []model.RoutineExercise{{ExerciseID: 1}},
+		Visibility: model.RoutineVisibilityPrivate,
+		Source:     &model.RoutineSource{RoutineID: 9, UserID: 5},
+	}).Return(&model.ExerciseRoutine{ID: 12, UserID: 4, ForkedFrom: &model.RoutineSource{RoutineID: 9, UserID: 5}}, nil)
+	achievements.EXPECT().Evaluate(int64(4), model.CriteriaRoutines).Return(nil, nil)
+
+	got, err := svc.ForkRoutine(4, 9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 12 || got.ForkedFrom.RoutineID != 9 {
+		t.Fatalf("unexpected fork %+v", got)
+	}
+}
+
+func TestRoutineService_ForkRoutine_NotShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewRoutineService(repo, nil, nil, policy)
+
+	repo.EXPECT().ReadRoutineWithExercises(int64(9)).Return(&model.ExerciseRoutine{ID: 9, UserID: 5, Visibility: model.RoutineVisibilityFollowers}, nil)
+	policy.EXPECT().CanViewAsFollower(int64(4), int64(5)).Return(fmt.Errorf("%w: this is only shared with followers", util.ErrForbidden))
+
+	if _, err := svc.ForkRoutine(4, 9); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestRoutineService_ReadPublicRoutines_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockRoutineRepository(ctrl)
+	svc := NewRoutineService(repo, nil, nil, nil)
+
+	repo.EXPECT().ReadPublicRoutines(model.PublicRoutineFilter{Search: "legs", Sort: model.RoutineSortPopular, Limit: 3}).
+		Return([]*model.ExerciseRoutine{{ID: 5, ForkCount: 9}, {ID: 8, ForkCount: 4}, {ID: 2, ForkCount: 4}}, nil)
+
+	page, err := svc.ReadPublicRoutines(model.ReadPublicRoutinesRequest{Search: " legs ", Sort: model.RoutineSortPopular, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(page.Routines) != 2 || page.NextCursor == "" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	repo.EXPECT().ReadPublicRoutines(model.PublicRoutineFilter{Sort: model.RoutineSortPopular, Limit: 3,
+		After: &model.RoutineCursor{Sort: model.RoutineSortPopular, ForkCount: 4, ID: 8}}).
+		Return([]*model.ExerciseRoutine{{ID: 2, ForkCount: 4}}, nil)
+
+	page, err = svc.ReadPublicRoutines(model.ReadPublicRoutinesRequest{Sort: model.RoutineSortPopular, Cursor: page.NextCursor, Limit: 2})
+	if err != nil || len(page.Routines) != 1 || page.NextCursor != "" {
+		t.Fatalf("unexpected last page %+v err=%v", page, err)
+	}
+}
+
+func TestRoutineService_ReadPublicRoutines_Invalid(t *testing.T) {
+	svc := NewRoutineService(nil, nil, nil, nil)
+	cursor := encodeRoutineCursor(model.RoutineCursor{Sort: model.RoutineSortNewest, ID: 3})
+
+	for name, req := range map[string]model.ReadPublicRoutinesRequest{
+		"unknown sort":         {Sort: "oldest"},
+		"negative limit":       {Limit: -1},
+		"cursor of other sort": {Sort: model.RoutineSortPopular, Cursor: cursor},
+		"garbled cursor":       {Cursor: "!!"},
+	} {
+		if _, err := svc.ReadPublicRoutines(req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
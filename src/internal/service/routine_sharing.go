@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+func checkRoutineVisibility(visibility string) error {
+	if !slices.Contains(model.RoutineVisibilities, visibility) {
+		return fmt.Errorf("%w: visibility must be one of %s", util.ErrInvalidInput, strings.Join(model.RoutineVisibilities, ", "))
+	}
+	return nil
+}
+
+func newPublicRoutineFilter(req model.ReadPublicRoutinesRequest) (model.PublicRoutineFilter, error) {
+	filter := model.PublicRoutineFilter{
+		Search: strings.TrimSpace(req.Search),
+		Sort:   req.Sort,
+		Limit:  req.Limit,
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = model.RoutineSortNewest
+	case model.RoutineSortNewest, model.RoutineSortPopular:
+	default:
+		return filter, fmt.Errorf("%w: sort must be newest or popular", util.ErrInvalidInput)
+	}
+
+	switch {
+	case filter.Limit < 0:
+		return filter, fmt.Errorf("%w: limit must be positive", util.ErrInvalidInput)
+	case filter.Limit == 0:
+		filter.Limit = model.DefaultRoutinePageSize
+	case filter.Limit > model.MaxRoutinePageSize:
+		filter.Limit = model.MaxRoutinePageSize
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeRoutineCursor(req.Cursor)
+		if err != nil || cursor.Sort != filter.Sort {
+			return filter, fmt.Errorf("%w: cursor is invalid for this sort order", util.ErrInvalidInput)
+		}
+		filter.After = cursor
+	}
+	return filter, nil
+}
+
+// routine cursors are opaque to clients, like exercise cursors
+func encodeRoutineCursor(c model.RoutineCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeRoutineCursor(s string) (*model.RoutineCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c model.RoutineCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
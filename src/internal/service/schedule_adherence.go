@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+// a workout completes an occurrence when it starts no more than this long
+// before the occurrence starts or after it ends
+const adherenceGrace = 6 * time.Hour
+
+// length of the report when no range is asked for: the last 12 weeks
+const defaultAdherenceDays = 12 * 7
+
+// adherenceRange reads the report's local dates, defaulting to the 12 weeks
+// ending today in UTC
+func adherenceRange(rawFrom, rawTo string, now time.Time) (time.Time, time.Time, error) {
+	return dateRange(rawFrom, rawTo, defaultAdherenceDays, now)
+}
+
+// dateRange reads inclusive local dates, defaulting to the given number of
+// days ending today in UTC
+func dateRange(rawFrom, rawTo string, days int, now time.Time) (time.Time, time.Time, error) {
+	to := now.UTC().Truncate(24 * time.Hour)
+	if rawTo != "" {
+		parsed, err := time.Parse(time.DateOnly, rawTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, 1-days)
+	if rawFrom != "" {
+		parsed, err := time.Parse(time.DateOnly, rawFrom)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to is before from", util.ErrInvalidInput)
+	}
+	if to.Sub(from) >= maxOccurrenceRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: the range can span at most 366 days", util.ErrInvalidInput)
+	}
+	return from, to, nil
+}
+
+func containsRoutine(routineIDs []int64, routineID int64) bool {
+	for _, id := range routineIDs {
+		if id == routineID {
+			return true
+		}
+	}
+	return false
+}
+
+// trackOccurrences settles how each occurrence went. A mark the user set wins.
+// Otherwise the occurrence claims a finished workout started within its window
+// that no earlier occurrence has claimed, preferring one of its own routines.
+// Once the window has closed without one it is missed, until then it is upcoming.
+// occurrences and workouts must be ordered by start.
+func trackOccurrences(occurrences []*model.ScheduleOccurrence, marks []*model.OccurrenceMark, workouts []*model.CompletedWorkout, now time.Time) []*model.TrackedOccurrence {
+	type occurrenceKey struct {
+		scheduleID int64
+		date       string
+	}
+	marked := make(map[occurrenceKey]*model.OccurrenceMark, len(marks))
+	claimed := make(map[int64]bool)
+	for _, mark := range marks {
+		marked[occurrenceKey{mark.ScheduleID, mark.Date}] = mark
+		if mark.SessionID != 0 {
+			claimed[mark.SessionID] = true
+		}
+	}
+
+	tracked := make([]*model.TrackedOccurrence, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		entry := &model.TrackedOccurrence{ScheduleOccurrence: *occurrence}
+		tracked = append(tracked, entry)
+
+		if mark := marked[occurrenceKey{occurrence.ScheduleID, occurrence.Date}]; mark != nil {
+			entry.Status = mark.Status
+			entry.SessionID = mark.SessionID
+			entry.Manual = true
+			continue
+		}
+
+		opens := occurrence.StartsAt.Add(-adherenceGrace)
+		closes := occurrence.EndsAt.Add(adherenceGrace)
+		var match *model.CompletedWorkout
+		first := sort.Search(len(workouts), func(i int) bool { return !workouts[i].StartedAt.Before(opens) })
+		for _, workout := range workouts[first:] {
+			if !workout.StartedAt.Before(closes) {
+				break
+			}
+			if claimed[workout.SessionID] {
+				continue
+			}
+			if containsRoutine(occurrence.RoutineIDs, workout.RoutineID) {
+				match = workout
+				break
+			}
+			if match == nil {
+				match = workout
+			}
+		}
+
+		switch {
+		case match != nil:
+			claimed[match.SessionID] = true
+			entry.Status = model.OccurrenceCompleted
+			entry.SessionID = match.SessionID
+		case now.Before(closes):
+			entry.Status = model.OccurrenceUpcoming
+		default:
+			entry.Status = model.OccurrenceMissed
+		}
+	}
+	return tracked
+}
+
+func countOccurrence(period *model.AdherencePeriod, status string) {
+	period.Planned++
+	switch status {
+	case model.OccurrenceCompleted:
+		period.Completed++
+	case model.OccurrenceMissed:
+		period.Missed++
+	case model.OccurrenceSkipped:
+		period.Skipped++
+	}
+}
+
+// settlePercent rounds to one decimal
+func settlePercent(period *model.AdherencePeriod) {
+	if settled := period.Completed + period.Missed; settled > 0 {
+		percent := math.Round(float64(period.Completed)*1000/float64(settled)) / 10
+		period.Percent = &percent
+	}
+}
+
+// adherencePeriods lists every period between from and to, empty ones
+// included, and counts each occurrence in the period its local date falls in.
+// start maps a date to the first day of its period and next steps to the
+// following period.
+func adherencePeriods(tracked []*model.TrackedOccurrence, from, to time.Time, start func(time.Time) time.Time, next func(time.Time) time.Time) []*model.AdherencePeriod {
+	periods := make([]*model.AdherencePeriod, 0)
+	byStart := make(map[string]*model.AdherencePeriod)
+	for day := start(from); !day.After(to); day = next(day) {
+		period := &model.AdherencePeriod{Start: day.Format(time.DateOnly)}
+		periods = append(periods, period)
+		byStart[period.Start] = period
+	}
+
+	for _, occurrence := range tracked {
+		date, err := time.Parse(time.DateOnly, occurrence.Date)
+		if err != nil {
+			continue
+		}
+		if period := byStart[start(date).Format(time.DateOnly)]; period != nil {
+			countOccurrence(period, occurrence.Status)
+		}
+	}
+	for _, period := range periods {
+		settlePercent(period)
+	}
+	return periods
+}
+
+func weekStart(day time.Time) time.Time {
+	// Monday starts the week
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func monthStart(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// adherenceStreaks counts completed occurrences in a row, in start order.
+// Skipped and upcoming occurrences neither extend nor break a streak.
+func adherenceStreaks(tracked []*model.TrackedOccurrence) (current int, longest int) {
+	for _, occurrence := range tracked {
+		switch occurrence.Status {
+		case model.OccurrenceCompleted:
+			current++
+			if current > longest {
+				longest = current
+			}
+		case model.OccurrenceMissed:
+			current = 0
+		}
+	}
+	return current, longest
+}
+
+// summarizeAdherence builds the report for the occurrences planned on local
+// dates in [from, to]
+func summarizeAdherence(userID int64, tracked []*model.TrackedOccurrence, from, to time.Time) *model.Adherence {
+	current, longest := adherenceStreaks(tracked)
+	return &model.Adherence{
+		UserID: userID,
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Weekly: adherencePeriods(tracked, from, to, weekStart, func(day time.Time) time.Time {
+			return day.AddDate(0, 0, 7)
+		}),
+		Monthly: adherencePeriods(tracked, from, to, monthStart, func(day time.Time) time.Time {
+			return day.AddDate(0, 1, 0)
+		}),
+		CurrentStreak: current,
+		LongestStreak: longest,
+		Occurrences:   tracked,
+	}
+}
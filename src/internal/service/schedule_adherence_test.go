@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+func occurrenceAt(t *testing.T, scheduleID int64, startsAt string, routineIDs ...int64) *model.ScheduleOccurrence {
+	t.Helper()
+	start := mustTime(t, startsAt)
+	return &model.ScheduleOccurrence{
+		ScheduleID: scheduleID,
+		RoutineIDs: routineIDs,
+		Date:       start.Format(time.DateOnly),
+		StartsAt:   start,
+		EndsAt:     start.Add(time.Hour),
+	}
+}
+
+func workoutAt(t *testing.T, sessionID, routineID int64, startedAt string) *model.CompletedWorkout {
+	t.Helper()
+	start := mustTime(t, startedAt)
+	return &model.CompletedWorkout{SessionID: sessionID, RoutineID: routineID, StartedAt: start, FinishedAt: start.Add(time.Hour)}
+}
+
+func TestTrackOccurrences(t *testing.T) {
+	occurrences := []*model.ScheduleOccurrence{
+		occurrenceAt(t, 1, "2025-03-03T07:00:00Z", 10),
+		occurrenceAt(t, 2, "2025-03-03T09:00:00Z", 20),
+		occurrenceAt(t, 1, "2025-03-10T07:00:00Z", 10),
+		occurrenceAt(t, 1, "2025-03-17T07:00:00Z", 10),
+		occurrenceAt(t, 1, "2025-03-24T07:00:00Z", 10),
+	}
+	workouts := []*model.CompletedWorkout{
+		// both fall in the first two windows; schedule 2 gets its own routine
+		workoutAt(t, 100, 20, "2025-03-03T06:30:00Z"),
+		workoutAt(t, 101, 10, "2025-03-03T07:10:00Z"),
+		// too late for the 10th
+		workoutAt(t, 102, 10, "2025-03-10T15:00:00Z"),
+		// named by the mark, so it can't complete another occurrence
+		workoutAt(t, 103, 10, "2025-03-17T07:00:00Z"),
+	}
+	marks := []*model.OccurrenceMark{{ScheduleID: 1, Date: "2025-03-17", Status: model.OccurrenceSkipped, SessionID: 103}}
+	now := mustTime(t, "2025-03-24T10:00:00Z")
+
+	got := trackOccurrences(occurrences, marks, workouts, now)
+
+	want := []struct {
+		status    string
+		sessionID int64
+		manual    bool
+	}{
+		{model.OccurrenceCompleted, 101, false},
+		{model.OccurrenceCompleted, 100, false},
+		{model.OccurrenceMissed, 0, false},
+		{model.OccurrenceSkipped, 103, true},
+		// the window stays open until 6 hours after it ends
+		{model.OccurrenceUpcoming, 0, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Status != w.status || got[i].SessionID != w.sessionID || got[i].Manual != w.manual {
+			t.Errorf("occurrence %d = %s/%d/%v, want %s/%d/%v", i, got[i].Status, got[i].SessionID, got[i].Manual, w.status, w.sessionID, w.manual)
+		}
+	}
+}
+
+func TestSummarizeAdherence(t *testing.T) {
+	tracked := []*model.TrackedOccurrence{
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-02-24"}, Status: model.OccurrenceCompleted},
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-03-01"}, Status: model.OccurrenceMissed},
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-03-03"}, Status: model.OccurrenceCompleted},
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-03-05"}, Status: model.OccurrenceSkipped},
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-03-07"}, Status: model.OccurrenceCompleted},
+		{ScheduleOccurrence: model.ScheduleOccurrence{Date: "2025-03-14"}, Status: model.OccurrenceUpcoming},
+	}
+	from := mustTime(t, "2025-02-24T00:00:00Z")
+	to := mustTime(t, "2025-03-16T00:00:00Z")
+
+	got := summarizeAdherence(7, tracked, from, to)
+
+	if got.From != "2025-02-24" || got.To != "2025-03-16" {
+		t.Fatalf("range = %s..%s", got.From, got.To)
+	}
+	if got.CurrentStreak != 2 || got.LongestStreak != 2 {
+		t.Fatalf("streaks = %d/%d, want 2/2", got.CurrentStreak, got.LongestStreak)
+	}
+
+	if len(got.Weekly) != 3 {
+		t.Fatalf("got %d weeks, want 3", len(got.Weekly))
+	}
+	first, second, third := got.Weekly[0], got.Weekly[1], got.Weekly[2]
+	if first.Start != "2025-02-24" || first.Planned != 2 || first.Percent == nil || *first.Percent != 50 {
+		t.Fatalf("first week = %+v", first)
+	}
+	if second.Start != "2025-03-03" || second.Planned != 3 || second.Skipped != 1 || *second.Percent != 100 {
+		t.Fatalf("second week = %+v", second)
+	}
+	if third.Planned != 1 || third.Percent != nil {
+		t.Fatalf("an upcoming occurrence has no percentage yet, got %+v", third)
+	}
+
+	if len(got.Monthly) != 2 || got.Monthly[0].Start != "2025-02-01" || got.Monthly[1].Start != "2025-03-01" {
+		t.Fatalf("months = %+v", got.Monthly)
+	}
+	if *got.Monthly[1].Percent != 66.7 {
+		t.Fatalf("march = %v, want 66.7", *got.Monthly[1].Percent)
+	}
+}
+
+func TestAdherenceRange(t *testing.T) {
+	now := mustTime(t, "2025-03-20T15:00:00Z")
+
+	from, to, err := adherenceRange("", "", now)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if to.Format(time.DateOnly) != "2025-03-20" || from.Format(time.DateOnly) != "2024-12-27" {
+		t.Fatalf("default range = %s..%s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	for _, bounds := range [][2]string{{"2025-03-10", "2025-03-01"}, {"2024-01-01", "2025-03-01"}, {"March", ""}, {"", "2025-3-1"}} {
+		if _, _, err := adherenceRange(bounds[0], bounds[1], now); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%v: expected ErrInvalidInput, got %v", bounds, err)
+		}
+	}
+}
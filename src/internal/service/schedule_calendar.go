@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"workoutpal/src/internal/model"
+)
+
+const (
+	icalDateTime    = "20060102T150405"
+	icalMaxLineSize = 75
+)
+
+// schedules store a day of week as 0 (Sunday) to 6 (Saturday), like time.Weekday
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// renderCalendar writes the entries as an RFC 5545 calendar with one weekly
+// recurring event per schedule. Start times carry the schedule's IANA time zone
+// as TZID, which calendar apps resolve without a VTIMEZONE component. Skipped
+// occurrences are listed as EXDATE and each rescheduled one gets an event of
+// its own that overrides it through RECURRENCE-ID.
+func renderCalendar(entries []*model.CalendarEntry, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeICalLine(&buf, name+":"+value)
+	}
+	timeLine := func(name string, t time.Time) {
+		if t.Location() == time.UTC {
+			line(name, t.Format(icalDateTime)+"Z")
+		} else {
+			line(name+";TZID="+t.Location().String(), t.Format(icalDateTime))
+		}
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//WorkoutPal//Schedules//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "WorkoutPal")
+
+	stamp := now.UTC().Format(icalDateTime) + "Z"
+	for _, entry := range entries {
+		if entry.DayOfWeek < 0 || entry.DayOfWeek >= int64(len(icalWeekdays)) {
+			continue
+		}
+		start, err := firstOccurrence(entry)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %d: %w", entry.ID, err)
+		}
+
+		uid := fmt.Sprintf("schedule-%d@workoutpal", entry.ID)
+		details := func() {
+			if entry.RoutineLengthMinutes > 0 {
+				line("DURATION", fmt.Sprintf("PT%dM", entry.RoutineLengthMinutes))
+			}
+			line("SUMMARY", escapeICalText(entry.Name))
+			if len(entry.RoutineNames) > 0 {
+				line("DESCRIPTION", escapeICalText("Routines: "+strings.Join(entry.RoutineNames, ", ")))
+			}
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", uid)
+		line("DTSTAMP", stamp)
+		timeLine("DTSTART", start)
+		rule := "FREQ=WEEKLY;BYDAY=" + icalWeekdays[entry.DayOfWeek]
+		if entry.EndsOn != "" {
+			until, err := lastInstant(entry.EndsOn, start.Location())
+			if err != nil {
+				return nil, fmt.Errorf("schedule %d: %w", entry.ID, err)
+			}
+			rule += ";UNTIL=" + until.Format(icalDateTime) + "Z"
+		}
+		line("RRULE", rule)
+		// skips drop their occurrence; reschedules override it with an event of their own
+		var overrides []*model.ScheduleException
+		for _, exception := range entry.Exceptions {
+			if exception.RescheduledTo != nil {
+				overrides = append(overrides, exception)
+				continue
+			}
+			planned, err := plannedOccurrence(exception.Date, start)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %d: %w", entry.ID, err)
+			}
+			timeLine("EXDATE", planned)
+		}
+		details()
+		line("END", "VEVENT")
+
+		for _, exception := range overrides {
+			planned, err := plannedOccurrence(exception.Date, start)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %d: %w", entry.ID, err)
+			}
+			line("BEGIN", "VEVENT")
+			line("UID", uid)
+			line("DTSTAMP", stamp)
+			timeLine("RECURRENCE-ID", planned)
+			timeLine("DTSTART", exception.RescheduledTo.In(start.Location()))
+			details()
+			line("END", "VEVENT")
+		}
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes(), nil
+}
+
+// firstOccurrence is the schedule's time slot on the first matching weekday on
+// or after the day it starts, or the day it was created when it has no start date
+func firstOccurrence(entry *model.CalendarEntry) (time.Time, error) {
+	slot, err := parseTimeSlot(entry.TimeSlot)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc := scheduleLocation(&entry.Schedule)
+	from := entry.CreatedAt.In(loc)
+	if entry.StartsOn != "" {
+		if from, err = time.ParseInLocation(time.DateOnly, entry.StartsOn, loc); err != nil {
+			return time.Time{}, err
+		}
+	}
+	day := time.Date(from.Year(), from.Month(), from.Day(), slot.Hour(), slot.Minute(), slot.Second(), 0, loc)
+	offset := (int(entry.DayOfWeek) - int(day.Weekday()) + 7) % 7
+	return day.AddDate(0, 0, offset), nil
+}
+
+// plannedOccurrence is the schedule's time slot on the local date an
+// exception was made for, in the zone of the first occurrence
+func plannedOccurrence(date string, first time.Time) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, first.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), first.Hour(), first.Minute(), first.Second(), 0, first.Location()), nil
+}
+
+// lastInstant is the end of the local date in UTC, as RRULE UNTIL requires
+// whenever DTSTART has a time zone
+func lastInstant(date string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Second).UTC(), nil
+}
+
+// parseTimeSlot accepts the TIME column as postgres renders it, with or without seconds
+func parseTimeSlot(slot string) (time.Time, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, slot); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time slot %q", slot)
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
+
+// writeICalLine folds content lines longer than 75 octets without splitting a
+// UTF-8 sequence, and ends every line with CRLF
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalMaxLineSize
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = icalMaxLineSize - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
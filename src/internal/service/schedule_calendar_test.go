@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"workoutpal/src/internal/model"
+)
+
+var calendarNow = time.Date(2025, 3, 5, 9, 30, 0, 0, time.UTC)
+
+func TestRenderCalendar_WeeklyEvent(t *testing.T) {
+	entries := []*model.CalendarEntry{{
+		Schedule: model.Schedule{ID: 7, Name: "Leg day", DayOfWeek: 1, TimeSlot: "07:30:00", RoutineLengthMinutes: 45},
+		// a Wednesday, so the first Monday is the 10th
+		CreatedAt:    time.Date(2025, 3, 5, 20, 0, 0, 0, time.UTC),
+		RoutineNames: []string{"Squats", "Lunges"},
+	}}
+
+	got, err := renderCalendar(entries, calendarNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:schedule-7@workoutpal\r\n",
+		"DTSTAMP:20250305T093000Z\r\n",
+		"DTSTART:20250310T073000Z\r\n",
+		"DURATION:PT45M\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n",
+		"SUMMARY:Leg day\r\n",
+		"DESCRIPTION:Routines: Squats\\, Lunges\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("calendar is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderCalendar_SameDayAndEdgeCases(t *testing.T) {
+	entries := []*model.CalendarEntry{
+		// created on a Sunday, so it starts the same day
+		{Schedule: model.Schedule{ID: 1, Name: "Run", DayOfWeek: 0, TimeSlot: "18:00"}, CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+		// not a day of the week, left out
+		{Schedule: model.Schedule{ID: 2, Name: "Never", DayOfWeek: 9, TimeSlot: "18:00"}, CreatedAt: calendarNow},
+	}
+
+	got, err := renderCalendar(entries, calendarNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	cal := string(got)
+	if !strings.Contains(cal, "DTSTART:20250302T180000Z\r\n") {
+		t.Errorf("expected the first occurrence on the day of creation:\n%s", cal)
+	}
+	if strings.Contains(cal, "DURATION") || strings.Contains(cal, "DESCRIPTION") {
+		t.Errorf("expected no duration or description without a length or routines:\n%s", cal)
+	}
+	if strings.Count(cal, "BEGIN:VEVENT") != 1 {
+		t.Errorf("expected the schedule with an invalid day to be skipped:\n%s", cal)
+	}
+}
+
+func TestRenderCalendar_TimeZoneAndWindow(t *testing.T) {
+	entries := []*model.CalendarEntry{{
+		Schedule: model.Schedule{
+			ID: 3, Name: "Swim", DayOfWeek: 2, TimeSlot: "06:00", Timezone: "America/New_York",
+			StartsOn: "2025-04-01", EndsOn: "2025-06-30",
+		},
+		CreatedAt: calendarNow,
+	}}
+
+	got, err := renderCalendar(entries, calendarNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for _, want := range []string{
+		"DTSTART;TZID=America/New_York:20250401T060000\r\n",
+		// end of June 30th in New York, in UTC
+		"RRULE:FREQ=WEEKLY;BYDAY=TU;UNTIL=20250701T035959Z\r\n",
+	} {
+		if !bytes.Contains(got, []byte(want)) {
+			t.Errorf("calendar is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderCalendar_Exceptions(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// moved from Thursday evening to Friday morning
+	movedTo := time.Date(2025, 3, 21, 6, 30, 0, 0, berlin).UTC()
+	entries := []*model.CalendarEntry{{
+		Schedule:  model.Schedule{ID: 4, Name: "Push", DayOfWeek: 4, TimeSlot: "18:00", RoutineLengthMinutes: 60, Timezone: "Europe/Berlin", StartsOn: "2025-03-06"},
+		CreatedAt: calendarNow,
+		Exceptions: []*model.ScheduleException{
+			{ScheduleID: 4, Date: "2025-03-13"},
+			{ScheduleID: 4, Date: "2025-03-20", RescheduledTo: &movedTo},
+		},
+	}}
+
+	got, err := renderCalendar(entries, calendarNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	cal := string(got)
+
+	master, override, found := strings.Cut(cal, "END:VEVENT\r\n")
+	if !found {
+		t.Fatalf("expected events:\n%s", cal)
+	}
+	if !strings.Contains(master, "EXDATE;TZID=Europe/Berlin:20250313T180000\r\n") {
+		t.Errorf("expected the skipped occurrence excluded from the series:\n%s", master)
+	}
+	if strings.Contains(master, "20250320") {
+		t.Errorf("expected the rescheduled occurrence left to its override:\n%s", master)
+	}
+	for _, want := range []string{
+		"BEGIN:VEVENT\r\nUID:schedule-4@workoutpal\r\n",
+		"RECURRENCE-ID;TZID=Europe/Berlin:20250320T180000\r\n",
+		"DTSTART;TZID=Europe/Berlin:20250321T063000\r\n",
+		"DURATION:PT60M\r\n",
+		"SUMMARY:Push\r\n",
+	} {
+		if !strings.Contains(override, want) {
+			t.Errorf("override is missing %q:\n%s", want, override)
+		}
+	}
+	if strings.Contains(override, "RRULE") || strings.Count(cal, "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected a single one-off override:\n%s", cal)
+	}
+}
+
+func TestRenderCalendar_UTCExceptions(t *testing.T) {
+	entries := []*model.CalendarEntry{{
+		Schedule:   model.Schedule{ID: 7, Name: "Leg day", DayOfWeek: 1, TimeSlot: "07:30:00"},
+		CreatedAt:  calendarNow,
+		Exceptions: []*model.ScheduleException{{ScheduleID: 7, Date: "2025-03-17"}},
+	}}
+
+	got, err := renderCalendar(entries, calendarNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !bytes.Contains(got, []byte("EXDATE:20250317T073000Z\r\n")) {
+		t.Errorf("expected a UTC exclusion:\n%s", got)
+	}
+}
+
+func TestRenderCalendar_InvalidTimeSlot(t *testing.T) {
+	entries := []*model.CalendarEntry{{Schedule: model.Schedule{ID: 1, TimeSlot: "noon"}, CreatedAt: calendarNow}}
+
+	if _, err := renderCalendar(entries, calendarNow); err == nil {
+		t.Fatal("expected an error for an unparseable time slot")
+	}
+}
+
+func TestEscapeICalText(t *testing.T) {
+	got := escapeICalText("a\\b;c,d\ne")
+	if want := `a\\b\;c\,d\ne`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriteICalLine_Folds(t *testing.T) {
+	var buf bytes.Buffer
+	writeICalLine(&buf, "SUMMARY:"+strings.Repeat("é", 80))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected the line to be folded, got %q", buf.String())
+	}
+	var unfolded string
+	for i, l := range lines {
+		if len(l) > icalMaxLineSize {
+			t.Errorf("line %d is %d octets", i, len(l))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Errorf("continuation line %d must start with a space", i)
+			}
+			l = l[1:]
+		}
+		unfolded += l
+	}
+	if unfolded != "SUMMARY:"+strings.Repeat("é", 80) {
+		t.Fatalf("unfolding does not restore the line: %q", unfolded)
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+// longest range /schedules/occurrences expands at once
+const maxOccurrenceRange = 366 * 24 * time.Hour
+
+// scheduleLocation is the time zone the schedule's time slot is read in.
+// Time zones are checked when schedules are written, so an unknown one can
+// only come from a tzdata change and falls back to UTC.
+func scheduleLocation(schedule *model.Schedule) *time.Location {
+	if schedule.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// normalizeScheduleWindow defaults the time zone to UTC and checks the time
+// zone and validity window of a schedule that is about to be written
+func normalizeScheduleWindow(timezone, startsOn, endsOn string) (string, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", fmt.Errorf("%w: unknown time zone %q", util.ErrInvalidInput, timezone)
+	}
+
+	var start, end time.Time
+	var err error
+	if startsOn != "" {
+		if start, err = time.Parse(time.DateOnly, startsOn); err != nil {
+			return "", fmt.Errorf("%w: startsOn must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+		}
+	}
+	if endsOn != "" {
+		if end, err = time.Parse(time.DateOnly, endsOn); err != nil {
+			return "", fmt.Errorf("%w: endsOn must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+		}
+	}
+	if startsOn != "" && endsOn != "" && end.Before(start) {
+		return "", fmt.Errorf("%w: endsOn is before startsOn", util.ErrInvalidInput)
+	}
+	return timezone, nil
+}
+
+// occursOn reports whether the schedule plans a workout on the local date
+func occursOn(schedule *model.Schedule, date time.Time) bool {
+	if int64(date.Weekday()) != schedule.DayOfWeek {
+		return false
+	}
+	day := date.Format(time.DateOnly)
+	if schedule.StartsOn != "" && day < schedule.StartsOn {
+		return false
+	}
+	if schedule.EndsOn != "" && day > schedule.EndsOn {
+		return false
+	}
+	return true
+}
+
+func newOccurrence(schedule *model.Schedule, date string, startsAt time.Time) *model.ScheduleOccurrence {
+	startsAt = startsAt.UTC()
+	return &model.ScheduleOccurrence{
+		ScheduleID: schedule.ID,
+		Name:       schedule.Name,
+		RoutineIDs: schedule.RoutineIDs,
+		Date:       date,
+		StartsAt:   startsAt,
+		EndsAt:     startsAt.Add(time.Duration(schedule.RoutineLengthMinutes) * time.Minute),
+	}
+}
+
+// expandOccurrences turns weekly schedules into the occurrences that start in
+// [from, to), ordered by start. Each time slot is read in its schedule's time
+// zone on every date, so occurrences keep their wall clock time across DST changes.
+func expandOccurrences(schedules []*model.Schedule, exceptions []*model.ScheduleException, from, to time.Time) ([]*model.ScheduleOccurrence, error) {
+	type occurrenceKey struct {
+		scheduleID int64
+		date       string
+	}
+	changed := make(map[occurrenceKey]bool, len(exceptions))
+	for _, exception := range exceptions {
+		changed[occurrenceKey{exception.ScheduleID, exception.Date}] = true
+	}
+
+	occurrences := make([]*model.ScheduleOccurrence, 0)
+	byID := make(map[int64]*model.Schedule, len(schedules))
+	for _, schedule := range schedules {
+		byID[schedule.ID] = schedule
+		slot, err := parseTimeSlot(schedule.TimeSlot)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %d: %w", schedule.ID, err)
+		}
+
+		loc := scheduleLocation(schedule)
+		// a day of margin on both sides covers every UTC offset
+		first := from.In(loc).AddDate(0, 0, -1)
+		last := to.In(loc).AddDate(0, 0, 1)
+		for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+			if !occursOn(schedule, day) {
+				continue
+			}
+			date := day.Format(time.DateOnly)
+			if changed[occurrenceKey{schedule.ID, date}] {
+				continue
+			}
+			startsAt := time.Date(day.Year(), day.Month(), day.Day(), slot.Hour(), slot.Minute(), slot.Second(), 0, loc)
+			if startsAt.Before(from) || !startsAt.Before(to) {
+				continue
+			}
+			occurrences = append(occurrences, newOccurrence(schedule, date, startsAt))
+		}
+	}
+
+	for _, exception := range exceptions {
+		schedule := byID[exception.ScheduleID]
+		if schedule == nil || exception.RescheduledTo == nil {
+			continue
+		}
+		startsAt := *exception.RescheduledTo
+		if startsAt.Before(from) || !startsAt.Before(to) {
+			continue
+		}
+		occurrence := newOccurrence(schedule, exception.Date, startsAt)
+		occurrence.Rescheduled = true
+		occurrences = append(occurrences, occurrence)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].StartsAt.Equal(occurrences[j].StartsAt) {
+			return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+		}
+		return occurrences[i].ScheduleID < occurrences[j].ScheduleID
+	})
+	return occurrences, nil
+}
+
+// a routine can't outlast the day it is planned on
+const maxRoutineLengthMinutes = 24 * 60
+
+// findConflicts lists the other schedules with an occurrence overlapping one of
+// the candidate's. Expanding both over the coming year accounts for time zones,
+// DST and validity windows; one-off exceptions are not considered.
+func findConflicts(candidate *model.Schedule, others []*model.Schedule, now time.Time) ([]int64, error) {
+	from := now
+	if candidate.StartsOn != "" {
+		// the day before covers every UTC offset
+		if startsOn, err := time.Parse(time.DateOnly, candidate.StartsOn); err == nil && startsOn.AddDate(0, 0, -1).After(from) {
+			from = startsOn.AddDate(0, 0, -1)
+		}
+	}
+	to := from.Add(maxOccurrenceRange)
+
+	planned, err := expandOccurrences([]*model.Schedule{candidate}, nil, from, to)
+	if err != nil || len(planned) == 0 {
+		return nil, err
+	}
+	existing, err := expandOccurrences(others, nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]int64, 0)
+	seen := make(map[int64]bool)
+	// both lists are ordered by start, so a single sweep finds every overlap
+	i := 0
+	for _, other := range existing {
+		for i < len(planned) && !planned[i].EndsAt.After(other.StartsAt) {
+			i++
+		}
+		for j := i; j < len(planned) && planned[j].StartsAt.Before(other.EndsAt); j++ {
+			if planned[j].EndsAt.After(other.StartsAt) && !seen[other.ScheduleID] {
+				seen[other.ScheduleID] = true
+				conflicts = append(conflicts, other.ScheduleID)
+			}
+		}
+	}
+	sort.Slice(conflicts, func(a, b int) bool { return conflicts[a] < conflicts[b] })
+	return conflicts, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return v
+}
+
+func TestExpandOccurrences_KeepsWallClockAcrossDST(t *testing.T) {
+	// New York moves to daylight saving time on Sunday 2025-03-09
+	schedules := []*model.Schedule{{ID: 1, Name: "Lift", DayOfWeek: 1, TimeSlot: "07:00:00", RoutineLengthMinutes: 60, Timezone: "America/New_York"}}
+
+	got, err := expandOccurrences(schedules, nil, mustTime(t, "2025-03-01T00:00:00Z"), mustTime(t, "2025-03-15T00:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d", len(got))
+	}
+	if want := mustTime(t, "2025-03-03T12:00:00Z"); !got[0].StartsAt.Equal(want) || got[0].Date != "2025-03-03" {
+		t.Fatalf("first occurrence %+v, want start %v", got[0], want)
+	}
+	if want := mustTime(t, "2025-03-10T11:00:00Z"); !got[1].StartsAt.Equal(want) {
+		t.Fatalf("second occurrence starts %v, want %v", got[1].StartsAt, want)
+	}
+	if got[1].EndsAt.Sub(got[1].StartsAt) != time.Hour {
+		t.Fatalf("expected a one hour occurrence, got %v", got[1].EndsAt.Sub(got[1].StartsAt))
+	}
+}
+
+func TestExpandOccurrences_LocalDateDiffersFromUTC(t *testing.T) {
+	// Monday 06:00 in Auckland is still Sunday in UTC
+	schedules := []*model.Schedule{{ID: 1, DayOfWeek: 1, TimeSlot: "06:00", Timezone: "Pacific/Auckland"}}
+
+	got, err := expandOccurrences(schedules, nil, mustTime(t, "2025-06-01T00:00:00Z"), mustTime(t, "2025-06-02T00:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].Date != "2025-06-02" || !got[0].StartsAt.Equal(mustTime(t, "2025-06-01T18:00:00Z")) {
+		t.Fatalf("unexpected occurrences: %+v", got)
+	}
+}
+
+func TestExpandOccurrences_WindowAndExceptions(t *testing.T) {
+	moved := mustTime(t, "2025-03-19T17:00:00Z")
+	schedules := []*model.Schedule{
+		{ID: 1, DayOfWeek: 1, TimeSlot: "07:00", StartsOn: "2025-03-10", EndsOn: "2025-03-24"},
+		{ID: 2, DayOfWeek: 3, TimeSlot: "18:00"},
+	}
+	exceptions := []*model.ScheduleException{
+		{ScheduleID: 1, Date: "2025-03-17"},
+		{ScheduleID: 2, Date: "2025-03-12", RescheduledTo: &moved},
+		// stale: schedule 2 has no occurrence on a Tuesday
+		{ScheduleID: 2, Date: "2025-03-18"},
+	}
+
+	got, err := expandOccurrences(schedules, exceptions, mustTime(t, "2025-03-01T00:00:00Z"), mustTime(t, "2025-04-01T00:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	var plan []string
+	for _, o := range got {
+		plan = append(plan, o.StartsAt.Format(time.RFC3339))
+	}
+	want := []string{
+		"2025-03-05T18:00:00Z",
+		"2025-03-10T07:00:00Z",
+		// 03-12 moved to 03-19, 03-17 skipped
+		"2025-03-19T17:00:00Z",
+		"2025-03-19T18:00:00Z",
+		"2025-03-24T07:00:00Z",
+		"2025-03-26T18:00:00Z",
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("got %v, want %v", plan, want)
+	}
+	for i := range want {
+		if plan[i] != want[i] {
+			t.Fatalf("got %v, want %v", plan, want)
+		}
+	}
+	if !got[2].Rescheduled || got[2].Date != "2025-03-12" {
+		t.Fatalf("expected the moved occurrence to keep its planned date, got %+v", got[2])
+	}
+}
+
+func TestNormalizeScheduleWindow(t *testing.T) {
+	if tz, err := normalizeScheduleWindow("", "", ""); err != nil || tz != "UTC" {
+		t.Fatalf("expected UTC by default, got %q, %v", tz, err)
+	}
+	if tz, err := normalizeScheduleWindow("Europe/Berlin", "2025-01-01", "2025-01-01"); err != nil || tz != "Europe/Berlin" {
+		t.Fatalf("unexpected result %q, %v", tz, err)
+	}
+
+	for _, tc := range [][3]string{
+		{"Mars/Olympus", "", ""},
+		{"UTC", "01/02/2025", ""},
+		{"UTC", "", "tomorrow"},
+		{"UTC", "2025-02-01", "2025-01-01"},
+	} {
+		if _, err := normalizeScheduleWindow(tc[0], tc[1], tc[2]); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%v: expected ErrInvalidInput, got %v", tc, err)
+		}
+	}
+}
+
+func TestFindConflicts_RespectsValidityWindows(t *testing.T) {
+	now := mustTime(t, "2025-03-01T00:00:00Z")
+	candidate := &model.Schedule{ID: 1, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", StartsOn: "2025-06-01"}
+	others := []*model.Schedule{
+		// ended before the candidate starts
+		{ID: 2, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", EndsOn: "2025-05-31"},
+		// still running when the candidate starts
+		{ID: 3, DayOfWeek: 1, TimeSlot: "07:30", RoutineLengthMinutes: 60, Timezone: "UTC", EndsOn: "2025-06-30"},
+		// starts after a year of the candidate has been checked
+		{ID: 4, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC", StartsOn: "2026-09-01"},
+	}
+
+	got, err := findConflicts(candidate, others, now)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0] != 3 {
+		t.Fatalf("conflicts = %v, want [3]", got)
+	}
+}
@@ -1,17 +1,26 @@
 package service
 
 import (
+	"database/sql"
+	"fmt"
+	"time"
 	"workoutpal/src/internal/domain/repository"
 	"workoutpal/src/internal/domain/service"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 )
 
 type scheduleService struct {
-	repository repository.ScheduleRepository
+	repository   repository.ScheduleRepository
+	routines     repository.RoutineRepository
+	sessions     repository.WorkoutSessionRepository
+	achievements service.AchievementEvaluator
+	policy       service.AccessPolicy
+	now          func() time.Time
 }
 
-func NewScheduleService(repository repository.ScheduleRepository) service.ScheduleService {
-	return &scheduleService{repository: repository}
+func NewScheduleService(repository repository.ScheduleRepository, routines repository.RoutineRepository, sessions repository.WorkoutSessionRepository, achievements service.AchievementEvaluator, policy service.AccessPolicy) service.ScheduleService {
+	return &scheduleService{repository: repository, routines: routines, sessions: sessions, achievements: achievements, policy: policy, now: time.Now}
 }
 
 func (s *scheduleService) ReadUserSchedules(userId int64) ([]*model.Schedule, error) {
@@ -22,22 +31,333 @@ func (s *scheduleService) ReadUserSchedulesByDay(userId int64, dayOfWeek int64)
 	return s.repository.ReadUserSchedulesByDay(userId, dayOfWeek)
 }
 
-func (s *scheduleService) ReadScheduleByID(id int64) (*model.Schedule, error) {
-	return s.repository.ReadScheduleByID(id)
+func (s *scheduleService) ReadScheduleByID(actorID, id int64) (*model.Schedule, error) {
+	schedule, err := s.repository.ReadScheduleByID(id)
+	if err != nil || schedule == nil {
+		return schedule, err
+	}
+	if err := s.policy.CanView(actorID, schedule.UserID); err != nil {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 func (s *scheduleService) CreateSchedule(request model.CreateScheduleRequest) (*model.Schedule, error) {
+	timezone, err := normalizeScheduleWindow(request.Timezone, request.StartsOn, request.EndsOn)
+	if err != nil {
+		return nil, err
+	}
+	request.Timezone = timezone
+
+	if err := s.checkSchedule(&model.Schedule{
+		UserID:               request.UserID,
+		DayOfWeek:            request.DayOfWeek,
+		RoutineIDs:           request.RoutineIDs,
+		TimeSlot:             request.TimeSlot,
+		RoutineLengthMinutes: request.RoutineLengthMinutes,
+		Timezone:             request.Timezone,
+		StartsOn:             request.StartsOn,
+		EndsOn:               request.EndsOn,
+	}); err != nil {
+		return nil, err
+	}
+
 	schedule, err := s.repository.CreateSchedule(request)
 	if err != nil {
 		return nil, err
 	}
+
+	evaluateAchievements(s.achievements, request.UserID, model.CriteriaSchedules)
 	return schedule, nil
 }
 
 func (s *scheduleService) UpdateSchedule(request model.UpdateScheduleRequest) (*model.Schedule, error) {
+	stored, err := s.readModifiableSchedule(request.UserID, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	// an admin edits the schedule on the owner's behalf
+	request.UserID = stored.UserID
+
+	timezone, err := normalizeScheduleWindow(request.Timezone, request.StartsOn, request.EndsOn)
+	if err != nil {
+		return nil, err
+	}
+	request.Timezone = timezone
+
+	if err := s.checkSchedule(&model.Schedule{
+		ID:                   stored.ID,
+		UserID:               request.UserID,
+		DayOfWeek:            request.DayOfWeek,
+		RoutineIDs:           request.RoutineIDs,
+		TimeSlot:             request.TimeSlot,
+		RoutineLengthMinutes: request.RoutineLengthMinutes,
+		Timezone:             request.Timezone,
+		StartsOn:             request.StartsOn,
+		EndsOn:               request.EndsOn,
+	}); err != nil {
+		return nil, err
+	}
+
 	return s.repository.UpdateSchedule(request)
 }
 
-func (s *scheduleService) DeleteSchedule(request model.DeleteScheduleRequest) error {
+// checkSchedule validates a schedule that is about to be written: its slot,
+// that the caller owns every routine it names, and that it doesn't overlap
+// another of the caller's schedules
+func (s *scheduleService) checkSchedule(candidate *model.Schedule) error {
+	if candidate.DayOfWeek < 0 || candidate.DayOfWeek > 6 {
+		return fmt.Errorf("%w: dayOfWeek must be between 0 (Sunday) and 6 (Saturday)", util.ErrInvalidInput)
+	}
+	if _, err := parseTimeSlot(candidate.TimeSlot); err != nil {
+		return fmt.Errorf("%w: timeSlot must be HH:MM or HH:MM:SS", util.ErrInvalidInput)
+	}
+	if candidate.RoutineLengthMinutes < 1 || candidate.RoutineLengthMinutes > maxRoutineLengthMinutes {
+		return fmt.Errorf("%w: routineLengthMinutes must be between 1 and %d", util.ErrInvalidInput, maxRoutineLengthMinutes)
+	}
+
+	if len(candidate.RoutineIDs) > 0 {
+		routines, err := s.routines.ReadUserRoutines(candidate.UserID)
+		if err != nil {
+			return err
+		}
+		owned := make(map[int64]bool, len(routines))
+		for _, routine := range routines {
+			owned[routine.ID] = true
+		}
+		for _, id := range candidate.RoutineIDs {
+			if !owned[id] {
+				return fmt.Errorf("%w: routine %d is not one of your routines", util.ErrForbidden, id)
+			}
+		}
+	}
+
+	schedules, err := s.repository.ReadUserSchedules(candidate.UserID)
+	if err != nil {
+		return err
+	}
+	others := make([]*model.Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.ID != candidate.ID {
+			others = append(others, schedule)
+		}
+	}
+	conflicts, err := findConflicts(candidate, others, s.now())
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return util.WithFields(
+			fmt.Errorf("%w: the schedule overlaps %d of your other schedules", util.ErrConflict, len(conflicts)),
+			map[string]any{"conflictingScheduleIds": conflicts},
+		)
+	}
+	return nil
+}
+
+func (s *scheduleService) DeleteSchedule(actorID int64, request model.DeleteScheduleRequest) error {
+	if _, err := s.readModifiableSchedule(actorID, request.ID); err != nil {
+		return err
+	}
 	return s.repository.DeleteSchedule(request)
 }
+
+func (s *scheduleService) ExportCalendar(userID int64) ([]byte, error) {
+	entries, err := s.repository.ReadCalendarEntries(userID)
+	if err != nil {
+		return nil, err
+	}
+	return renderCalendar(entries, s.now())
+}
+
+// CreateCalendarFeed issues a new subscription token, replacing any earlier one
+func (s *scheduleService) CreateCalendarFeed(userID int64) (string, error) {
+	token := randomToken(32)
+	if err := s.repository.UpsertCalendarFeed(userID, hashToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *scheduleService) RevokeCalendarFeed(userID int64) error {
+	return s.repository.DeleteCalendarFeed(userID)
+}
+
+func (s *scheduleService) ExportCalendarFeed(token string) ([]byte, error) {
+	userID, err := s.repository.ReadCalendarFeedOwner(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return s.ExportCalendar(userID)
+}
+
+func (s *scheduleService) ReadOccurrences(request model.ReadOccurrencesRequest) ([]*model.ScheduleOccurrence, error) {
+	if !request.To.After(request.From) {
+		return nil, fmt.Errorf("%w: to must be after from", util.ErrInvalidInput)
+	}
+	if request.To.Sub(request.From) > maxOccurrenceRange {
+		return nil, fmt.Errorf("%w: the range can span at most 366 days", util.ErrInvalidInput)
+	}
+
+	schedules, err := s.repository.ReadUserSchedules(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := s.repository.ReadScheduleExceptions(model.ScheduleExceptionFilter{
+		UserID:   request.UserID,
+		FromDate: request.From.UTC().AddDate(0, 0, -1).Format(time.DateOnly),
+		ToDate:   request.To.UTC().AddDate(0, 0, 1).Format(time.DateOnly),
+		From:     request.From,
+		To:       request.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expandOccurrences(schedules, exceptions, request.From, request.To)
+}
+
+// CreateScheduleException skips the occurrence planned for the request's date,
+// or moves it when RescheduledTo is set
+func (s *scheduleService) CreateScheduleException(actorID int64, request model.CreateScheduleExceptionRequest) (*model.ScheduleException, error) {
+	schedule, err := s.readModifiableSchedule(actorID, request.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+	}
+	if !occursOn(schedule, date) {
+		return nil, fmt.Errorf("%w: the schedule has no occurrence on %s", util.ErrInvalidInput, request.Date)
+	}
+
+	return s.repository.CreateScheduleException(request)
+}
+
+func (s *scheduleService) DeleteScheduleException(actorID int64, request model.DeleteScheduleExceptionRequest) error {
+	if _, err := s.readModifiableSchedule(actorID, request.ScheduleID); err != nil {
+		return err
+	}
+	return s.repository.DeleteScheduleException(request)
+}
+
+// ReadAdherence reports how the user's occurrences planned on local dates in
+// [From, To] went
+func (s *scheduleService) ReadAdherence(actorID int64, request model.ReadAdherenceRequest) (*model.Adherence, error) {
+	if err := s.policy.CanView(actorID, request.UserID); err != nil {
+		return nil, err
+	}
+	from, to, err := adherenceRange(request.From, request.To, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repository.ReadUserSchedules(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// local dates in [from, to] start within a day of the same UTC dates
+	start, end := from.AddDate(0, 0, -1), to.AddDate(0, 0, 2)
+	exceptions, err := s.repository.ReadScheduleExceptions(model.ScheduleExceptionFilter{
+		UserID:   request.UserID,
+		FromDate: from.Format(time.DateOnly),
+		ToDate:   to.Format(time.DateOnly),
+		From:     start,
+		To:       end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	expanded, err := expandOccurrences(schedules, exceptions, start, end)
+	if err != nil {
+		return nil, err
+	}
+	occurrences := make([]*model.ScheduleOccurrence, 0, len(expanded))
+	for _, occurrence := range expanded {
+		if occurrence.Date >= from.Format(time.DateOnly) && occurrence.Date <= to.Format(time.DateOnly) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	marks, err := s.repository.ReadOccurrenceMarks(model.OccurrenceMarkFilter{
+		UserID:   request.UserID,
+		FromDate: from.Format(time.DateOnly),
+		ToDate:   to.Format(time.DateOnly),
+	})
+	if err != nil {
+		return nil, err
+	}
+	workouts, err := s.sessions.ReadCompletedWorkouts(request.UserID,
+		start.Add(-adherenceGrace), end.Add(maxRoutineLengthMinutes*time.Minute+adherenceGrace))
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := trackOccurrences(occurrences, marks, workouts, s.now())
+	return summarizeAdherence(request.UserID, tracked, from, to), nil
+}
+
+// MarkOccurrence sets how one occurrence went by hand, overriding whatever
+// workouts it would be matched with
+func (s *scheduleService) MarkOccurrence(actorID int64, request model.MarkOccurrenceRequest) (*model.OccurrenceMark, error) {
+	switch request.Status {
+	case model.OccurrenceCompleted, model.OccurrenceMissed, model.OccurrenceSkipped:
+	default:
+		return nil, fmt.Errorf("%w: status must be completed, missed or skipped", util.ErrInvalidInput)
+	}
+	if request.SessionID != 0 && request.Status != model.OccurrenceCompleted {
+		return nil, fmt.Errorf("%w: only completed occurrences name a workout", util.ErrInvalidInput)
+	}
+
+	schedule, err := s.readModifiableSchedule(actorID, request.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be a date (YYYY-MM-DD)", util.ErrInvalidInput)
+	}
+	if !occursOn(schedule, date) {
+		return nil, fmt.Errorf("%w: the schedule has no occurrence on %s", util.ErrInvalidInput, request.Date)
+	}
+	// a future occurrence can be skipped ahead of time but not done or missed yet
+	today := s.now().In(scheduleLocation(schedule)).Format(time.DateOnly)
+	if request.Status != model.OccurrenceSkipped && request.Date > today {
+		return nil, fmt.Errorf("%w: the occurrence on %s hasn't happened yet", util.ErrInvalidInput, request.Date)
+	}
+
+	if request.SessionID != 0 {
+		session, err := s.sessions.ReadSessionByID(request.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.UserID != schedule.UserID || session.Status != "finished" {
+			return nil, fmt.Errorf("%w: session %d is not a finished workout of the schedule's owner", util.ErrInvalidInput, request.SessionID)
+		}
+	}
+
+	return s.repository.UpsertOccurrenceMark(request)
+}
+
+// DeleteOccurrenceMark goes back to matching the occurrence with workouts
+func (s *scheduleService) DeleteOccurrenceMark(actorID int64, request model.DeleteOccurrenceMarkRequest) error {
+	if _, err := s.readModifiableSchedule(actorID, request.ScheduleID); err != nil {
+		return err
+	}
+	return s.repository.DeleteOccurrenceMark(request)
+}
+
+func (s *scheduleService) readModifiableSchedule(actorID, id int64) (*model.Schedule, error) {
+	schedule, err := s.repository.ReadScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sql.ErrNoRows
+	}
+	if err := s.policy.CanModify(actorID, schedule.UserID); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
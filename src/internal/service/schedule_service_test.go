@@ -1,11 +1,18 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"workoutpal/src/internal/model"
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
 
 	"github.com/golang/mock/gomock"
 )
@@ -89,16 +96,18 @@ func TestScheduleService_ReadScheduleByID_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := &scheduleService{repository: mockRepo, policy: policy}
 
 	id := int64(123)
-	want := &model.Schedule{ID: id, Name: "Pull"}
+	want := &model.Schedule{ID: id, Name: "Pull", UserID: 1}
 
 	mockRepo.EXPECT().
 		ReadScheduleByID(id).
 		Return(want, nil)
+	policy.EXPECT().CanView(int64(1), int64(1)).Return(nil)
 
-	got, err := svc.ReadScheduleByID(id)
+	got, err := svc.ReadScheduleByID(1, id)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -107,12 +116,34 @@ func TestScheduleService_ReadScheduleByID_OK(t *testing.T) {
 	}
 }
 
-func TestScheduleService_CreateSchedule_OK(t *testing.T) {
+// newScheduleWriteMocks returns a service whose caller, user 77 or 44, owns
+// routines 7, 8, 10 and 11 and has no other schedules
+func newScheduleWriteMocks(t *testing.T) (*mock_repository.MockScheduleRepository, *mock_repository.MockRoutineRepository, *scheduleService) {
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	routines := mock_repository.NewMockRoutineRepository(ctrl)
+	svc := &scheduleService{repository: mockRepo, routines: routines, now: func() time.Time { return calendarNow }}
+	return mockRepo, routines, svc
+}
+
+func expectNoOtherSchedules(mockRepo *mock_repository.MockScheduleRepository, routines *mock_repository.MockRoutineRepository, userID int64) {
+	routines.EXPECT().ReadUserRoutines(userID).Return([]*model.ExerciseRoutine{{ID: 7}, {ID: 8}, {ID: 10}, {ID: 11}}, nil).AnyTimes()
+	mockRepo.EXPECT().ReadUserSchedules(userID).Return(nil, nil)
+}
+
+// expectStoredSchedule has schedule id exist, owned by ownerID, and lets actorID modify it
+func expectStoredSchedule(t *testing.T, mockRepo *mock_repository.MockScheduleRepository, svc *scheduleService, id, ownerID, actorID int64) {
+	policy := mock_service.NewMockAccessPolicy(gomock.NewController(t))
+	policy.EXPECT().CanModify(actorID, ownerID).Return(nil)
+	svc.policy = policy
+	mockRepo.EXPECT().ReadScheduleByID(id).Return(&model.Schedule{ID: id, UserID: ownerID}, nil)
+}
+
+func TestScheduleService_CreateSchedule_OK(t *testing.T) {
+	mockRepo, routines, svc := newScheduleWriteMocks(t)
+	expectNoOtherSchedules(mockRepo, routines, 77)
 
 	req := model.CreateScheduleRequest{
 		Name:                 "AM Upper",
@@ -121,6 +152,7 @@ func TestScheduleService_CreateSchedule_OK(t *testing.T) {
 		TimeSlot:             "07:15",
 		RoutineLengthMinutes: 80,
 		RoutineIDs:           []int64{10, 11},
+		Timezone:             "UTC",
 	}
 
 	want := &model.Schedule{
@@ -147,17 +179,16 @@ func TestScheduleService_CreateSchedule_OK(t *testing.T) {
 }
 
 func TestScheduleService_CreateSchedule_Error(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	mockRepo, routines, svc := newScheduleWriteMocks(t)
+	expectNoOtherSchedules(mockRepo, routines, 1)
 
 	req := model.CreateScheduleRequest{
-		Name:      "oops",
-		UserID:    1,
-		DayOfWeek: 2,
-		TimeSlot:  "bad",
+		Name:                 "oops",
+		UserID:               1,
+		DayOfWeek:            2,
+		TimeSlot:             "07:00",
+		RoutineLengthMinutes: 30,
+		Timezone:             "UTC",
 	}
 
 	mockRepo.EXPECT().
@@ -174,11 +205,9 @@ func TestScheduleService_CreateSchedule_Error(t *testing.T) {
 }
 
 func TestScheduleService_UpdateSchedule_OK(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	mockRepo, routines, svc := newScheduleWriteMocks(t)
+	expectStoredSchedule(t, mockRepo, svc, 321, 44, 44)
+	expectNoOtherSchedules(mockRepo, routines, 44)
 
 	req := model.UpdateScheduleRequest{
 		ID:                   321,
@@ -188,6 +217,7 @@ func TestScheduleService_UpdateSchedule_OK(t *testing.T) {
 		TimeSlot:             "20:00",
 		RoutineLengthMinutes: 55,
 		RoutineIDs:           []int64{7, 8},
+		Timezone:             "UTC",
 	}
 	want := &model.Schedule{
 		ID:                   321,
@@ -197,6 +227,7 @@ func TestScheduleService_UpdateSchedule_OK(t *testing.T) {
 		TimeSlot:             "20:00",
 		RoutineLengthMinutes: 55,
 		RoutineIDs:           []int64{7, 8},
+		Timezone:             "UTC",
 	}
 
 	mockRepo.EXPECT().
@@ -213,18 +244,18 @@ func TestScheduleService_UpdateSchedule_OK(t *testing.T) {
 }
 
 func TestScheduleService_UpdateSchedule_Error(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Cleanup(ctrl.Finish)
-
-	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	mockRepo, routines, svc := newScheduleWriteMocks(t)
+	expectStoredSchedule(t, mockRepo, svc, 999, 44, 44)
+	expectNoOtherSchedules(mockRepo, routines, 44)
 
 	req := model.UpdateScheduleRequest{
-		ID:        999,
-		UserID:    44,
-		Name:      "doesn't matter",
-		DayOfWeek: 6,
-		TimeSlot:  "xx",
+		ID:                   999,
+		UserID:               44,
+		Name:                 "doesn't matter",
+		DayOfWeek:            6,
+		TimeSlot:             "07:00",
+		RoutineLengthMinutes: 30,
+		Timezone:             "UTC",
 	}
 
 	mockRepo.EXPECT().
@@ -245,15 +276,18 @@ func TestScheduleService_DeleteSchedule_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := &scheduleService{repository: mockRepo, policy: policy}
 
 	req := model.DeleteScheduleRequest{ID: 123}
 
+	mockRepo.EXPECT().ReadScheduleByID(int64(123)).Return(&model.Schedule{ID: 123, UserID: 1}, nil)
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
 	mockRepo.EXPECT().
 		DeleteSchedule(req).
 		Return(nil)
 
-	if err := svc.DeleteSchedule(req); err != nil {
+	if err := svc.DeleteSchedule(1, req); err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
@@ -263,16 +297,366 @@ func TestScheduleService_DeleteSchedule_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
-	svc := &scheduleService{repository: mockRepo}
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := &scheduleService{repository: mockRepo, policy: policy}
 
 	req := model.DeleteScheduleRequest{ID: 123}
 
+	mockRepo.EXPECT().ReadScheduleByID(int64(123)).Return(&model.Schedule{ID: 123, UserID: 1}, nil)
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil)
 	mockRepo.EXPECT().
 		DeleteSchedule(req).
 		Return(errors.New("delete fail"))
 
-	err := svc.DeleteSchedule(req)
+	err := svc.DeleteSchedule(1, req)
 	if err == nil || err.Error() != "delete fail" {
 		t.Fatalf("expected delete fail, got %v", err)
 	}
 }
+
+func TestScheduleService_DeleteSchedule_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := &scheduleService{repository: mockRepo, policy: policy}
+
+	mockRepo.EXPECT().ReadScheduleByID(int64(123)).Return(&model.Schedule{ID: 123, UserID: 1}, nil)
+	policy.EXPECT().CanModify(int64(2), int64(1)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	err := svc.DeleteSchedule(2, model.DeleteScheduleRequest{ID: 123})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestScheduleService_CreateCalendarFeed_StoresHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	svc := &scheduleService{repository: mockRepo}
+
+	var stored string
+	mockRepo.EXPECT().UpsertCalendarFeed(int64(5), gomock.Any()).
+		DoAndReturn(func(_ int64, hash string) error {
+			stored = hash
+			return nil
+		})
+
+	token, err := svc.CreateCalendarFeed(5)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if token == "" || stored != hashToken(token) {
+		t.Fatalf("expected the hash of token %q to be stored, got %q", token, stored)
+	}
+}
+
+func TestScheduleService_ExportCalendarFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	svc := &scheduleService{repository: mockRepo, now: func() time.Time { return calendarNow }}
+
+	mockRepo.EXPECT().ReadCalendarFeedOwner(hashToken("secret")).Return(int64(5), nil)
+	mockRepo.EXPECT().ReadCalendarEntries(int64(5)).Return([]*model.CalendarEntry{
+		{Schedule: model.Schedule{ID: 1, Name: "Run", TimeSlot: "18:00:00"}, CreatedAt: calendarNow},
+	}, nil)
+
+	got, err := svc.ExportCalendarFeed("secret")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !strings.Contains(string(got), "UID:schedule-1@workoutpal") {
+		t.Fatalf("unexpected calendar:\n%s", got)
+	}
+}
+
+func TestScheduleService_ExportCalendarFeed_UnknownToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	svc := &scheduleService{repository: mockRepo}
+
+	mockRepo.EXPECT().ReadCalendarFeedOwner(gomock.Any()).Return(int64(0), sql.ErrNoRows)
+
+	if _, err := svc.ExportCalendarFeed("revoked"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestScheduleService_ReadOccurrences_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	svc := &scheduleService{repository: mock_repository.NewMockScheduleRepository(ctrl)}
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, to := range []time.Time{from, from.AddDate(0, 0, -1), from.AddDate(2, 0, 0)} {
+		_, err := svc.ReadOccurrences(model.ReadOccurrencesRequest{UserID: 1, From: from, To: to})
+		if !errors.Is(err, util.ErrInvalidInput) {
+			t.Fatalf("to %v: expected ErrInvalidInput, got %v", to, err)
+		}
+	}
+}
+
+func TestScheduleService_ReadOccurrences_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	svc := &scheduleService{repository: mockRepo}
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mockRepo.EXPECT().ReadUserSchedules(int64(1)).
+		Return([]*model.Schedule{{ID: 4, DayOfWeek: 1, TimeSlot: "07:00:00", Timezone: "UTC"}}, nil)
+	mockRepo.EXPECT().ReadScheduleExceptions(model.ScheduleExceptionFilter{
+		UserID: 1, FromDate: "2025-02-28", ToDate: "2025-03-09", From: from, To: to,
+	}).Return(nil, nil)
+
+	got, err := svc.ReadOccurrences(model.ReadOccurrencesRequest{UserID: 1, From: from, To: to})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 || got[0].ScheduleID != 4 || got[0].Date != "2025-03-03" {
+		t.Fatalf("unexpected occurrences: %+v", got)
+	}
+}
+
+func TestScheduleService_CreateScheduleException(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := &scheduleService{repository: mockRepo, policy: policy}
+
+	schedule := &model.Schedule{ID: 30, UserID: 1, DayOfWeek: 1, TimeSlot: "07:00"}
+	mockRepo.EXPECT().ReadScheduleByID(int64(30)).Return(schedule, nil).Times(3)
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil).Times(2)
+	policy.EXPECT().CanModify(int64(2), int64(1)).Return(util.ErrForbidden)
+
+	// 2025-03-04 is a Tuesday
+	_, err := svc.CreateScheduleException(1, model.CreateScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-04"})
+	if !errors.Is(err, util.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for a date without an occurrence, got %v", err)
+	}
+
+	_, err = svc.CreateScheduleException(2, model.CreateScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-03"})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for another user's schedule, got %v", err)
+	}
+
+	req := model.CreateScheduleExceptionRequest{ScheduleID: 30, Date: "2025-03-03"}
+	mockRepo.EXPECT().CreateScheduleException(req).Return(&model.ScheduleException{ID: 1, ScheduleID: 30, Date: "2025-03-03"}, nil)
+	if _, err := svc.CreateScheduleException(1, req); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestScheduleService_CreateSchedule_Invalid(t *testing.T) {
+	base := model.CreateScheduleRequest{UserID: 77, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 30}
+	tests := []struct {
+		name   string
+		modify func(*model.CreateScheduleRequest)
+	}{
+		{"day too large", func(r *model.CreateScheduleRequest) { r.DayOfWeek = 9 }},
+		{"negative day", func(r *model.CreateScheduleRequest) { r.DayOfWeek = -1 }},
+		{"time slot", func(r *model.CreateScheduleRequest) { r.TimeSlot = "7am" }},
+		{"hour out of range", func(r *model.CreateScheduleRequest) { r.TimeSlot = "25:00" }},
+		{"no length", func(r *model.CreateScheduleRequest) { r.RoutineLengthMinutes = 0 }},
+		{"longer than a day", func(r *model.CreateScheduleRequest) { r.RoutineLengthMinutes = 24*60 + 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, svc := newScheduleWriteMocks(t)
+			req := base
+			tt.modify(&req)
+
+			if _, err := svc.CreateSchedule(req); !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestScheduleService_CreateSchedule_ForeignRoutine(t *testing.T) {
+	_, routines, svc := newScheduleWriteMocks(t)
+	routines.EXPECT().ReadUserRoutines(int64(77)).Return([]*model.ExerciseRoutine{{ID: 10}}, nil)
+
+	_, err := svc.CreateSchedule(model.CreateScheduleRequest{
+		UserID: 77, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 30, RoutineIDs: []int64{10, 12},
+	})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestScheduleService_CreateSchedule_Conflict(t *testing.T) {
+	mockRepo, _, svc := newScheduleWriteMocks(t)
+	mockRepo.EXPECT().ReadUserSchedules(int64(77)).Return([]*model.Schedule{
+		// overlaps the last 15 minutes
+		{ID: 3, DayOfWeek: 1, TimeSlot: "07:45", RoutineLengthMinutes: 60, Timezone: "UTC"},
+		// 06:00 in New York is 11:00 UTC for most of the year
+		{ID: 5, DayOfWeek: 1, TimeSlot: "03:00", RoutineLengthMinutes: 300, Timezone: "America/New_York"},
+		// back to back is fine
+		{ID: 6, DayOfWeek: 1, TimeSlot: "06:00", RoutineLengthMinutes: 60, Timezone: "UTC"},
+		// different day
+		{ID: 7, DayOfWeek: 2, TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "UTC"},
+	}, nil)
+
+	_, err := svc.CreateSchedule(model.CreateScheduleRequest{UserID: 77, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 60})
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	responseErr := util.Error(err, "/schedules")
+	if responseErr.Status != http.StatusConflict {
+		t.Fatalf("expected a 409 response, got %d", responseErr.Status)
+	}
+	ids, _ := responseErr.Fields["conflictingScheduleIds"].([]int64)
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 5 {
+		t.Fatalf("expected schedules 3 and 5 to conflict, got %v", responseErr.Fields)
+	}
+}
+
+func TestScheduleService_UpdateSchedule_IgnoresItself(t *testing.T) {
+	mockRepo, _, svc := newScheduleWriteMocks(t)
+	expectStoredSchedule(t, mockRepo, svc, 321, 44, 44)
+	mockRepo.EXPECT().ReadUserSchedules(int64(44)).Return([]*model.Schedule{
+		{ID: 321, DayOfWeek: 5, TimeSlot: "20:00", RoutineLengthMinutes: 55, Timezone: "UTC"},
+	}, nil)
+	req := model.UpdateScheduleRequest{ID: 321, UserID: 44, DayOfWeek: 5, TimeSlot: "20:30", RoutineLengthMinutes: 55, Timezone: "UTC"}
+	mockRepo.EXPECT().UpdateSchedule(req).Return(&model.Schedule{ID: 321}, nil)
+
+	if _, err := svc.UpdateSchedule(req); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestScheduleService_UpdateSchedule_NotFound(t *testing.T) {
+	mockRepo, _, svc := newScheduleWriteMocks(t)
+	mockRepo.EXPECT().ReadScheduleByID(int64(999)).Return(nil, nil)
+
+	// checked before the overlap scan, so no other schedules are read
+	_, err := svc.UpdateSchedule(model.UpdateScheduleRequest{ID: 999, UserID: 44, DayOfWeek: 5, TimeSlot: "20:30", RoutineLengthMinutes: 55})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestScheduleService_UpdateSchedule_NotOwner(t *testing.T) {
+	mockRepo, _, svc := newScheduleWriteMocks(t)
+	policy := mock_service.NewMockAccessPolicy(gomock.NewController(t))
+	svc.policy = policy
+	mockRepo.EXPECT().ReadScheduleByID(int64(321)).Return(&model.Schedule{ID: 321, UserID: 44}, nil)
+	policy.EXPECT().CanModify(int64(45), int64(44)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	_, err := svc.UpdateSchedule(model.UpdateScheduleRequest{ID: 321, UserID: 45, DayOfWeek: 5, TimeSlot: "20:30", RoutineLengthMinutes: 55})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestScheduleService_UpdateSchedule_AsAdmin(t *testing.T) {
+	mockRepo, _, svc := newScheduleWriteMocks(t)
+	expectStoredSchedule(t, mockRepo, svc, 321, 44, 1)
+	// the overlap check and the write both run against the owner's schedules
+	mockRepo.EXPECT().ReadUserSchedules(int64(44)).Return(nil, nil)
+	req := model.UpdateScheduleRequest{ID: 321, UserID: 1, DayOfWeek: 5, TimeSlot: "20:30", RoutineLengthMinutes: 55, Timezone: "UTC"}
+	written := req
+	written.UserID = 44
+	mockRepo.EXPECT().UpdateSchedule(written).Return(&model.Schedule{ID: 321, UserID: 44}, nil)
+
+	if _, err := svc.UpdateSchedule(req); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestScheduleService_ReadAdherence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	sessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	now := time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC)
+	svc := &scheduleService{repository: mockRepo, sessions: sessions, policy: policy, now: func() time.Time { return now }}
+
+	policy.EXPECT().CanView(int64(2), int64(1)).Return(util.ErrForbidden)
+	if _, err := svc.ReadAdherence(2, model.ReadAdherenceRequest{UserID: 1}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	policy.EXPECT().CanView(int64(1), int64(1)).Return(nil)
+	// Mondays at 07:00 in Auckland are Sunday evenings in UTC
+	mockRepo.EXPECT().ReadUserSchedules(int64(1)).
+		Return([]*model.Schedule{{ID: 4, DayOfWeek: 1, TimeSlot: "07:00", RoutineLengthMinutes: 60, Timezone: "Pacific/Auckland"}}, nil)
+	mockRepo.EXPECT().ReadScheduleExceptions(model.ScheduleExceptionFilter{
+		UserID: 1, FromDate: "2025-03-01", ToDate: "2025-03-14", From: from.AddDate(0, 0, -1), To: from.AddDate(0, 0, 15),
+	}).Return(nil, nil)
+	mockRepo.EXPECT().ReadOccurrenceMarks(model.OccurrenceMarkFilter{UserID: 1, FromDate: "2025-03-01", ToDate: "2025-03-14"}).
+		Return([]*model.OccurrenceMark{{ScheduleID: 4, Date: "2025-03-10", Status: model.OccurrenceSkipped}}, nil)
+	sessions.EXPECT().ReadCompletedWorkouts(int64(1), gomock.Any(), gomock.Any()).
+		Return([]*model.CompletedWorkout{{SessionID: 9, StartedAt: time.Date(2025, 3, 2, 18, 5, 0, 0, time.UTC)}}, nil)
+
+	got, err := svc.ReadAdherence(1, model.ReadAdherenceRequest{UserID: 1, From: "2025-03-01", To: "2025-03-14"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got.Occurrences) != 2 || got.Occurrences[0].Date != "2025-03-03" || got.Occurrences[0].SessionID != 9 {
+		t.Fatalf("unexpected occurrences: %+v", got.Occurrences)
+	}
+	if got.Occurrences[1].Status != model.OccurrenceSkipped || got.CurrentStreak != 1 {
+		t.Fatalf("unexpected adherence: %+v", got)
+	}
+}
+
+func TestScheduleService_MarkOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mock_repository.NewMockScheduleRepository(ctrl)
+	sessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	now := time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC)
+	svc := &scheduleService{repository: mockRepo, sessions: sessions, policy: policy, now: func() time.Time { return now }}
+
+	schedule := &model.Schedule{ID: 30, UserID: 1, DayOfWeek: 1, TimeSlot: "07:00", Timezone: "UTC"}
+	mockRepo.EXPECT().ReadScheduleByID(int64(30)).Return(schedule, nil).AnyTimes()
+	policy.EXPECT().CanModify(int64(1), int64(1)).Return(nil).AnyTimes()
+	sessions.EXPECT().ReadSessionByID(int64(8)).Return(&model.WorkoutSession{ID: 8, UserID: 2, Status: "finished"}, nil)
+	sessions.EXPECT().ReadSessionByID(int64(9)).Return(&model.WorkoutSession{ID: 9, UserID: 1, Status: "finished"}, nil)
+
+	invalid := []model.MarkOccurrenceRequest{
+		{ScheduleID: 30, Date: "2025-03-10", Status: "done"},
+		{ScheduleID: 30, Date: "2025-03-10", Status: model.OccurrenceMissed, SessionID: 9},
+		// a Tuesday
+		{ScheduleID: 30, Date: "2025-03-11", Status: model.OccurrenceCompleted},
+		{ScheduleID: 30, Date: "2025-03-17", Status: model.OccurrenceCompleted},
+		{ScheduleID: 30, Date: "2025-03-10", Status: model.OccurrenceCompleted, SessionID: 8},
+	}
+	for _, req := range invalid {
+		if _, err := svc.MarkOccurrence(1, req); !errors.Is(err, util.ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", req, err)
+		}
+	}
+
+	skipAhead := model.MarkOccurrenceRequest{ScheduleID: 30, Date: "2025-03-17", Status: model.OccurrenceSkipped}
+	mockRepo.EXPECT().UpsertOccurrenceMark(skipAhead).Return(&model.OccurrenceMark{ScheduleID: 30, Date: "2025-03-17", Status: "skipped"}, nil)
+	if _, err := svc.MarkOccurrence(1, skipAhead); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	done := model.MarkOccurrenceRequest{ScheduleID: 30, Date: "2025-03-10", Status: model.OccurrenceCompleted, SessionID: 9}
+	mockRepo.EXPECT().UpsertOccurrenceMark(done).Return(&model.OccurrenceMark{ScheduleID: 30, Date: "2025-03-10", Status: "completed", SessionID: 9}, nil)
+	if _, err := svc.MarkOccurrence(1, done); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -8,11 +8,13 @@ import (
 
 type userService struct {
 	userRepository repository.UserRepository
+	policy         service.AccessPolicy
 }
 
-func NewUserService(ur repository.UserRepository) service.UserService {
+func NewUserService(ur repository.UserRepository, policy service.AccessPolicy) service.UserService {
 	return &userService{
 		userRepository: ur,
+		policy:         policy,
 	}
 }
 
@@ -32,10 +34,16 @@ func (u *userService) CreateUser(request model.CreateUserRequest) (*model.User,
 	return u.userRepository.CreateUser(request)
 }
 
-func (u *userService) UpdateUser(request model.UpdateUserRequest) (*model.User, error) {
+func (u *userService) UpdateUser(actorID int64, request model.UpdateUserRequest) (*model.User, error) {
+	if err := u.policy.CanModify(actorID, request.ID); err != nil {
+		return nil, err
+	}
 	return u.userRepository.UpdateUser(request)
 }
 
-func (u *userService) DeleteUser(request model.DeleteUserRequest) error {
+func (u *userService) DeleteUser(actorID int64, request model.DeleteUserRequest) error {
+	if err := u.policy.CanModify(actorID, request.ID); err != nil {
+		return err
+	}
 	return u.userRepository.DeleteUser(request)
 }
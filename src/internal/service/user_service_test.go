@@ -2,10 +2,13 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
 
 	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
 
 	"github.com/golang/mock/gomock"
 )
@@ -15,7 +18,8 @@ func TestUserService_ReadUserByEmail_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	want := &model.User{ID: 1, Email: "a@b.com"}
 	repo.EXPECT().ReadUserByEmail("a@b.com").Return(want, nil)
@@ -34,7 +38,8 @@ func TestUserService_ReadUserByEmail_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	repo.EXPECT().ReadUserByEmail("x@y.com").Return((*model.User)(nil), errors.New("not found"))
 
@@ -52,7 +57,8 @@ func TestUserService_ReadUsers_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	want := []*model.User{
 		{ID: 1, Username: "max"},
@@ -74,7 +80,8 @@ func TestUserService_ReadUsers_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	repo.EXPECT().ReadUsers().Return(nil, errors.New("db down"))
 
@@ -92,7 +99,8 @@ func TestUserService_ReadUserByID_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	want := &model.User{ID: 42, Username: "max"}
 	repo.EXPECT().ReadUserByID(int64(42)).Return(want, nil)
@@ -111,7 +119,8 @@ func TestUserService_ReadUserByID_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	repo.EXPECT().ReadUserByID(int64(7)).Return((*model.User)(nil), errors.New("not found"))
 
@@ -129,7 +138,8 @@ func TestUserService_CreateUser_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.CreateUserRequest{Username: "max", Email: "a@b.com", Name: "Max", Password: "Str0ng!Pass"}
 	want := &model.User{ID: 1, Username: req.Username, Email: req.Email, Name: req.Name}
@@ -152,7 +162,8 @@ func TestUserService_CreateUser_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.CreateUserRequest{Username: "max"}
 	repo.EXPECT().
@@ -173,16 +184,18 @@ func TestUserService_UpdateUser_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.UpdateUserRequest{ID: 9, Username: "newname"}
 	want := &model.User{ID: 9, Username: req.Username}
 
+	policy.EXPECT().CanModify(int64(9), int64(9)).Return(nil)
 	repo.EXPECT().
 		UpdateUser(gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
 		Return(want, nil)
 
-	got, err := svc.UpdateUser(req)
+	got, err := svc.UpdateUser(9, req)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -196,14 +209,16 @@ func TestUserService_UpdateUser_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.UpdateUserRequest{ID: 9}
+	policy.EXPECT().CanModify(int64(9), int64(9)).Return(nil)
 	repo.EXPECT().
 		UpdateUser(gomock.AssignableToTypeOf(model.UpdateUserRequest{})).
 		Return((*model.User)(nil), errors.New("bad update"))
 
-	got, err := svc.UpdateUser(req)
+	got, err := svc.UpdateUser(9, req)
 	if got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
@@ -217,12 +232,14 @@ func TestUserService_DeleteUser_OK(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.DeleteUserRequest{ID: 13}
+	policy.EXPECT().CanModify(int64(13), int64(13)).Return(nil)
 	repo.EXPECT().DeleteUser(req).Return(nil)
 
-	if err := svc.DeleteUser(req); err != nil {
+	if err := svc.DeleteUser(13, req); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -232,12 +249,29 @@ func TestUserService_DeleteUser_Error(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mock_repository.NewMockUserRepository(ctrl)
-	svc := NewUserService(repo)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
 
 	req := model.DeleteUserRequest{ID: 13}
+	policy.EXPECT().CanModify(int64(13), int64(13)).Return(nil)
 	repo.EXPECT().DeleteUser(req).Return(errors.New("not found"))
 
-	if err := svc.DeleteUser(req); err == nil || err.Error() != "not found" {
+	if err := svc.DeleteUser(13, req); err == nil || err.Error() != "not found" {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
+
+func TestUserService_DeleteUser_SomeoneElse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_repository.NewMockUserRepository(ctrl)
+	policy := mock_service.NewMockAccessPolicy(ctrl)
+	svc := NewUserService(repo, policy)
+
+	policy.EXPECT().CanModify(int64(2), int64(13)).Return(fmt.Errorf("%w: this belongs to another user", util.ErrForbidden))
+
+	if err := svc.DeleteUser(2, model.DeleteUserRequest{ID: 13}); !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
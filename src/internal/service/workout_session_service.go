@@ -0,0 +1,142 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"workoutpal/src/internal/domain/repository"
+	"workoutpal/src/internal/domain/service"
+	"workoutpal/src/internal/model"
+	"workoutpal/src/util"
+)
+
+type workoutSessionService struct {
+	sessionRepository repository.WorkoutSessionRepository
+	routineRepository repository.RoutineRepository
+	recordService     service.PersonalRecordService
+	achievements      service.AchievementEvaluator
+	goals             service.GoalSettler
+}
+
+func NewWorkoutSessionService(sessionRepository repository.WorkoutSessionRepository, routineRepository repository.RoutineRepository, recordService service.PersonalRecordService, achievements service.AchievementEvaluator, goals service.GoalSettler) service.WorkoutSessionService {
+	return &workoutSessionService{
+		sessionRepository: sessionRepository,
+		routineRepository: routineRepository,
+		recordService:     recordService,
+		achievements:      achievements,
+		goals:             goals,
+	}
+}
+
+func (s *workoutSessionService) ReadUserSessions(userID int64) ([]*model.WorkoutSession, error) {
+	return s.sessionRepository.ReadUserSessions(userID)
+}
+
+func (s *workoutSessionService) ReadSessionByID(id int64, userID int64) (*model.WorkoutSession, error) {
+	return s.readOwnedSession(id, userID)
+}
+
+func (s *workoutSessionService) ReadActiveSession(userID int64) (*model.WorkoutSession, error) {
+	session, err := s.sessionRepository.ReadActiveSession(userID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, sql.ErrNoRows
+	}
+	return session, nil
+}
+
+func (s *workoutSessionService) StartSession(request model.StartWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	routine, err := s.routineRepository.ReadRoutineWithExercises(request.RoutineID)
+	if err != nil {
+		return nil, err
+	}
+	if routine.UserID != request.UserID {
+		return nil, fmt.Errorf("%w: routine belongs to another user", util.ErrForbidden)
+	}
+
+	active, err := s.sessionRepository.ReadActiveSession(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("%w: session %d is still in progress", util.ErrConflict, active.ID)
+	}
+
+	if request.Name == "" {
+		request.Name = routine.Name
+	}
+	return s.sessionRepository.CreateSession(request)
+}
+
+func (s *workoutSessionService) LogSet(request model.LogWorkoutSetRequest) (*model.WorkoutSet, error) {
+	if request.Reps <= 0 {
+		return nil, fmt.Errorf("%w: reps must be greater than 0", util.ErrInvalidInput)
+	}
+	if request.Weight < 0 {
+		return nil, fmt.Errorf("%w: weight cannot be negative", util.ErrInvalidInput)
+	}
+	if request.RPE != 0 && (request.RPE < 1 || request.RPE > 10) {
+		return nil, fmt.Errorf("%w: rpe must be between 1 and 10", util.ErrInvalidInput)
+	}
+	if request.RestSeconds < 0 {
+		return nil, fmt.Errorf("%w: rest cannot be negative", util.ErrInvalidInput)
+	}
+
+	session, err := s.readOwnedSession(request.SessionID, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == "finished" {
+		return nil, fmt.Errorf("%w: session is already finished", util.ErrConflict)
+	}
+
+	set, err := s.sessionRepository.CreateSet(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// the set is already stored, so a failed record check must not fail the request
+	records, err := s.recordService.RecordSet(request.UserID, set)
+	if err != nil {
+		log.Printf("personal records for set %d: %v", set.ID, err)
+		return set, nil
+	}
+	if len(records) > 0 {
+		set.NewRecords = records
+		notifyGoals(s.goals, request.UserID)
+	}
+	return set, nil
+}
+
+func (s *workoutSessionService) FinishSession(request model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	session, err := s.readOwnedSession(request.ID, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == "finished" {
+		return nil, fmt.Errorf("%w: session is already finished", util.ErrConflict)
+	}
+
+	finished, err := s.sessionRepository.FinishSession(request)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluateAchievements(s.achievements, request.UserID, model.CriteriaScheduledWorkouts, model.CriteriaWorkoutStreak)
+	notifyGoals(s.goals, request.UserID)
+	return finished, nil
+}
+
+// readOwnedSession hides other users' sessions behind a not found error
+func (s *workoutSessionService) readOwnedSession(id int64, userID int64) (*model.WorkoutSession, error) {
+	session, err := s.sessionRepository.ReadSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID != userID {
+		return nil, sql.ErrNoRows
+	}
+	return session, nil
+}
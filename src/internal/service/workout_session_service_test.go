@@ -0,0 +1,220 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"workoutpal/src/internal/model"
+	mock_repository "workoutpal/src/mock_internal/domain/repository"
+	mock_service "workoutpal/src/mock_internal/domain/service"
+	"workoutpal/src/util"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newWorkoutSessionServiceMocks(t *testing.T) (*mock_repository.MockWorkoutSessionRepository, *mock_repository.MockRoutineRepository, *mock_service.MockPersonalRecordService, *workoutSessionService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	sessions := mock_repository.NewMockWorkoutSessionRepository(ctrl)
+	routines := mock_repository.NewMockRoutineRepository(ctrl)
+	records := mock_service.NewMockPersonalRecordService(ctrl)
+	svc := NewWorkoutSessionService(sessions, routines, records, nil, nil).(*workoutSessionService)
+	return sessions, routines, records, svc
+}
+
+func TestWorkoutSessionService_StartSession_OK_DefaultsNameToRoutine(t *testing.T) {
+	sessions, routines, _, svc := newWorkoutSessionServiceMocks(t)
+
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).
+		Return(&model.ExerciseRoutine{ID: 3, UserID: 7, Name: "Push Day"}, nil)
+	sessions.EXPECT().ReadActiveSession(int64(7)).Return(nil, nil)
+	sessions.EXPECT().
+		CreateSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3, Name: "Push Day"}).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, RoutineID: 3, Name: "Push Day", Status: "active"}, nil)
+
+	got, err := svc.StartSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 1 || got.Name != "Push Day" {
+		t.Fatalf("unexpected session: %#v", got)
+	}
+}
+
+func TestWorkoutSessionService_StartSession_ForeignRoutine(t *testing.T) {
+	_, routines, _, svc := newWorkoutSessionServiceMocks(t)
+
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).
+		Return(&model.ExerciseRoutine{ID: 3, UserID: 8}, nil)
+
+	_, err := svc.StartSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3})
+	if !errors.Is(err, util.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_StartSession_AlreadyActive(t *testing.T) {
+	sessions, routines, _, svc := newWorkoutSessionServiceMocks(t)
+
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).
+		Return(&model.ExerciseRoutine{ID: 3, UserID: 7}, nil)
+	sessions.EXPECT().ReadActiveSession(int64(7)).
+		Return(&model.WorkoutSession{ID: 9, UserID: 7, Status: "active"}, nil)
+
+	_, err := svc.StartSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3})
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_StartSession_RoutineError(t *testing.T) {
+	_, routines, _, svc := newWorkoutSessionServiceMocks(t)
+
+	routines.EXPECT().ReadRoutineWithExercises(int64(3)).
+		Return(nil, errors.New("routine not found"))
+
+	_, err := svc.StartSession(model.StartWorkoutSessionRequest{UserID: 7, RoutineID: 3})
+	if err == nil || err.Error() != "routine not found" {
+		t.Fatalf("expected routine not found, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_ReadSessionByID_OtherUserIsNotFound(t *testing.T) {
+	sessions, _, _, svc := newWorkoutSessionServiceMocks(t)
+
+	sessions.EXPECT().ReadSessionByID(int64(1)).
+		Return(&model.WorkoutSession{ID: 1, UserID: 8}, nil)
+
+	_, err := svc.ReadSessionByID(1, 7)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_ReadActiveSession_None(t *testing.T) {
+	sessions, _, _, svc := newWorkoutSessionServiceMocks(t)
+
+	sessions.EXPECT().ReadActiveSession(int64(7)).Return(nil, nil)
+
+	_, err := svc.ReadActiveSession(7)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_LogSet_OK(t *testing.T) {
+	sessions, _, records, svc := newWorkoutSessionServiceMocks(t)
+	goals := mock_service.NewMockGoalService(gomock.NewController(t))
+	svc.goals = goals
+
+	req := model.LogWorkoutSetRequest{SessionID: 1, UserID: 7, ExerciseID: 2, Weight: 80, Reps: 5, RPE: 8}
+	set := &model.WorkoutSet{ID: 3, SessionID: 1, ExerciseID: 2, SetNumber: 1, Weight: 80, Reps: 5, RPE: 8}
+	sessions.EXPECT().ReadSessionByID(int64(1)).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, Status: "active"}, nil)
+	sessions.EXPECT().CreateSet(req).Return(set, nil)
+	records.EXPECT().RecordSet(int64(7), set).
+		Return([]*model.PersonalRecord{{Type: model.RecordTypeMaxWeight, Value: 80}}, nil)
+	// a new record can meet a one_rep_max goal
+	goals.EXPECT().SettleGoals(int64(7)).Return(nil)
+
+	got, err := svc.LogSet(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 3 || got.SetNumber != 1 {
+		t.Fatalf("unexpected set: %#v", got)
+	}
+	if len(got.NewRecords) != 1 || got.NewRecords[0].Type != model.RecordTypeMaxWeight {
+		t.Fatalf("expected the broken record on the set, got %#v", got.NewRecords)
+	}
+}
+
+func TestWorkoutSessionService_LogSet_RecordErrorStillReturnsSet(t *testing.T) {
+	sessions, _, records, svc := newWorkoutSessionServiceMocks(t)
+
+	req := model.LogWorkoutSetRequest{SessionID: 1, UserID: 7, ExerciseID: 2, Weight: 80, Reps: 5}
+	set := &model.WorkoutSet{ID: 3, SessionID: 1, ExerciseID: 2, SetNumber: 1, Weight: 80, Reps: 5}
+	sessions.EXPECT().ReadSessionByID(int64(1)).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, Status: "active"}, nil)
+	sessions.EXPECT().CreateSet(req).Return(set, nil)
+	records.EXPECT().RecordSet(int64(7), set).Return(nil, errors.New("db down"))
+
+	got, err := svc.LogSet(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.ID != 3 || got.NewRecords != nil {
+		t.Fatalf("unexpected set: %#v", got)
+	}
+}
+
+func TestWorkoutSessionService_LogSet_Validation(t *testing.T) {
+	_, _, _, svc := newWorkoutSessionServiceMocks(t)
+
+	tests := []struct {
+		name string
+		req  model.LogWorkoutSetRequest
+	}{
+		{"zero reps", model.LogWorkoutSetRequest{Reps: 0}},
+		{"negative weight", model.LogWorkoutSetRequest{Reps: 5, Weight: -1}},
+		{"rpe too high", model.LogWorkoutSetRequest{Reps: 5, RPE: 11}},
+		{"rpe too low", model.LogWorkoutSetRequest{Reps: 5, RPE: 0.5}},
+		{"negative rest", model.LogWorkoutSetRequest{Reps: 5, RestSeconds: -30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.LogSet(tt.req)
+			if !errors.Is(err, util.ErrInvalidInput) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWorkoutSessionService_LogSet_FinishedSession(t *testing.T) {
+	sessions, _, _, svc := newWorkoutSessionServiceMocks(t)
+
+	sessions.EXPECT().ReadSessionByID(int64(1)).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, Status: "finished"}, nil)
+
+	_, err := svc.LogSet(model.LogWorkoutSetRequest{SessionID: 1, UserID: 7, Reps: 5})
+	if !errors.Is(err, util.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestWorkoutSessionService_FinishSession_OK(t *testing.T) {
+	sessions, _, _, svc := newWorkoutSessionServiceMocks(t)
+	goals := mock_service.NewMockGoalService(gomock.NewController(t))
+	svc.goals = goals
+
+	req := model.FinishWorkoutSessionRequest{ID: 1, UserID: 7}
+	sessions.EXPECT().ReadSessionByID(int64(1)).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, Status: "active"}, nil)
+	sessions.EXPECT().FinishSession(req).
+		Return(&model.WorkoutSession{ID: 1, UserID: 7, Status: "finished"}, nil)
+	// a settle failure is logged, the session stays finished
+	goals.EXPECT().SettleGoals(int64(7)).Return(errors.New("db down"))
+
+	got, err := svc.FinishSession(req)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got.Status != "finished" {
+		t.Fatalf("unexpected session: %#v", got)
+	}
+}
+
+func TestWorkoutSessionService_FinishSession_Missing(t *testing.T) {
+	sessions, _, _, svc := newWorkoutSessionServiceMocks(t)
+
+	sessions.EXPECT().ReadSessionByID(int64(1)).Return(nil, nil)
+
+	_, err := svc.FinishSession(model.FinishWorkoutSessionRequest{ID: 1, UserID: 7})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
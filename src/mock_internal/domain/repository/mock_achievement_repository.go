@@ -34,6 +34,21 @@ func (m *MockAchievementRepository) EXPECT() *MockAchievementRepositoryMockRecor
 	return m.recorder
 }
 
+// AwardAchievement mocks base method.
+func (m *MockAchievementRepository) AwardAchievement(arg0, arg1 int64) (*model.UserAchievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardAchievement", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserAchievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardAchievement indicates an expected call of AwardAchievement.
+func (mr *MockAchievementRepositoryMockRecorder) AwardAchievement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardAchievement", reflect.TypeOf((*MockAchievementRepository)(nil).AwardAchievement), arg0, arg1)
+}
+
 // CreateAchievement mocks base method.
 func (m *MockAchievementRepository) CreateAchievement(arg0 model.CreateAchievementRequest) (*model.UserAchievement, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +64,50 @@ func (mr *MockAchievementRepositoryMockRecorder) CreateAchievement(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAchievement", reflect.TypeOf((*MockAchievementRepository)(nil).CreateAchievement), arg0)
 }
 
+// CreateAchievementDefinition mocks base method.
+func (m *MockAchievementRepository) CreateAchievementDefinition(arg0 model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAchievementDefinition", arg0)
+	ret0, _ := ret[0].(*model.Achievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAchievementDefinition indicates an expected call of CreateAchievementDefinition.
+func (mr *MockAchievementRepositoryMockRecorder) CreateAchievementDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAchievementDefinition", reflect.TypeOf((*MockAchievementRepository)(nil).CreateAchievementDefinition), arg0)
+}
+
+// DeleteAchievementDefinition mocks base method.
+func (m *MockAchievementRepository) DeleteAchievementDefinition(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAchievementDefinition", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAchievementDefinition indicates an expected call of DeleteAchievementDefinition.
+func (mr *MockAchievementRepositoryMockRecorder) DeleteAchievementDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAchievementDefinition", reflect.TypeOf((*MockAchievementRepository)(nil).DeleteAchievementDefinition), arg0)
+}
+
+// ReadAchievementsByCriteria mocks base method.
+func (m *MockAchievementRepository) ReadAchievementsByCriteria(arg0 []string) ([]*model.Achievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAchievementsByCriteria", arg0)
+	ret0, _ := ret[0].([]*model.Achievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAchievementsByCriteria indicates an expected call of ReadAchievementsByCriteria.
+func (mr *MockAchievementRepositoryMockRecorder) ReadAchievementsByCriteria(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAchievementsByCriteria", reflect.TypeOf((*MockAchievementRepository)(nil).ReadAchievementsByCriteria), arg0)
+}
+
 // ReadAchievementsFeed mocks base method.
 func (m *MockAchievementRepository) ReadAchievementsFeed() ([]*model.UserAchievement, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +138,21 @@ func (mr *MockAchievementRepositoryMockRecorder) ReadAllAchievements() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllAchievements", reflect.TypeOf((*MockAchievementRepository)(nil).ReadAllAchievements))
 }
 
+// ReadCriteriaProgress mocks base method.
+func (m *MockAchievementRepository) ReadCriteriaProgress(arg0 int64, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCriteriaProgress", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCriteriaProgress indicates an expected call of ReadCriteriaProgress.
+func (mr *MockAchievementRepositoryMockRecorder) ReadCriteriaProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCriteriaProgress", reflect.TypeOf((*MockAchievementRepository)(nil).ReadCriteriaProgress), arg0, arg1)
+}
+
 // ReadUnlockedAchievementByAchievementID mocks base method.
 func (m *MockAchievementRepository) ReadUnlockedAchievementByAchievementID(arg0 int64) (*model.UserAchievement, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +182,18 @@ func (mr *MockAchievementRepositoryMockRecorder) ReadUnlockedAchievements(arg0 i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUnlockedAchievements", reflect.TypeOf((*MockAchievementRepository)(nil).ReadUnlockedAchievements), arg0)
 }
+
+// UpdateAchievementDefinition mocks base method.
+func (m *MockAchievementRepository) UpdateAchievementDefinition(arg0 model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAchievementDefinition", arg0)
+	ret0, _ := ret[0].(*model.Achievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAchievementDefinition indicates an expected call of UpdateAchievementDefinition.
+func (mr *MockAchievementRepositoryMockRecorder) UpdateAchievementDefinition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAchievementDefinition", reflect.TypeOf((*MockAchievementRepository)(nil).UpdateAchievementDefinition), arg0)
+}
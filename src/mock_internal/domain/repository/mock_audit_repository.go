@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: AuditRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditRecord mocks base method.
+func (m *MockAuditRepository) CreateAuditRecord(arg0 model.CreateAuditRecordRequest) (*model.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditRecord", arg0)
+	ret0, _ := ret[0].(*model.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditRecord indicates an expected call of CreateAuditRecord.
+func (mr *MockAuditRepositoryMockRecorder) CreateAuditRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditRecord", reflect.TypeOf((*MockAuditRepository)(nil).CreateAuditRecord), arg0)
+}
+
+// ReadAuditRecords mocks base method.
+func (m *MockAuditRepository) ReadAuditRecords(arg0 model.AuditFilter) ([]*model.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAuditRecords", arg0)
+	ret0, _ := ret[0].([]*model.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAuditRecords indicates an expected call of ReadAuditRecords.
+func (mr *MockAuditRepositoryMockRecorder) ReadAuditRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAuditRecords", reflect.TypeOf((*MockAuditRepository)(nil).ReadAuditRecords), arg0)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: BodyMeasurementRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBodyMeasurementRepository is a mock of BodyMeasurementRepository interface.
+type MockBodyMeasurementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBodyMeasurementRepositoryMockRecorder
+}
+
+// MockBodyMeasurementRepositoryMockRecorder is the mock recorder for MockBodyMeasurementRepository.
+type MockBodyMeasurementRepositoryMockRecorder struct {
+	mock *MockBodyMeasurementRepository
+}
+
+// NewMockBodyMeasurementRepository creates a new mock instance.
+func NewMockBodyMeasurementRepository(ctrl *gomock.Controller) *MockBodyMeasurementRepository {
+	mock := &MockBodyMeasurementRepository{ctrl: ctrl}
+	mock.recorder = &MockBodyMeasurementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBodyMeasurementRepository) EXPECT() *MockBodyMeasurementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMeasurement mocks base method.
+func (m *MockBodyMeasurementRepository) CreateMeasurement(arg0 *model.BodyMeasurement) (*model.BodyMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMeasurement", arg0)
+	ret0, _ := ret[0].(*model.BodyMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMeasurement indicates an expected call of CreateMeasurement.
+func (mr *MockBodyMeasurementRepositoryMockRecorder) CreateMeasurement(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeasurement", reflect.TypeOf((*MockBodyMeasurementRepository)(nil).CreateMeasurement), arg0)
+}
+
+// DeleteMeasurement mocks base method.
+func (m *MockBodyMeasurementRepository) DeleteMeasurement(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMeasurement", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMeasurement indicates an expected call of DeleteMeasurement.
+func (mr *MockBodyMeasurementRepositoryMockRecorder) DeleteMeasurement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeasurement", reflect.TypeOf((*MockBodyMeasurementRepository)(nil).DeleteMeasurement), arg0, arg1)
+}
+
+// ReadMeasurements mocks base method.
+func (m *MockBodyMeasurementRepository) ReadMeasurements(arg0 model.MeasurementFilter) ([]*model.BodyMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMeasurements", arg0)
+	ret0, _ := ret[0].([]*model.BodyMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMeasurements indicates an expected call of ReadMeasurements.
+func (mr *MockBodyMeasurementRepositoryMockRecorder) ReadMeasurements(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMeasurements", reflect.TypeOf((*MockBodyMeasurementRepository)(nil).ReadMeasurements), arg0)
+}
@@ -34,19 +34,62 @@ func (m *MockExerciseRepository) EXPECT() *MockExerciseRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ReadAllExercises mocks base method.
-func (m *MockExerciseRepository) ReadAllExercises() ([]*model.Exercise, error) {
+// CreateCatalogueExercise mocks base method.
+func (m *MockExerciseRepository) CreateCatalogueExercise(arg0 model.CreateExerciseRequest) (*model.Exercise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadAllExercises")
-	ret0, _ := ret[0].([]*model.Exercise)
+	ret := m.ctrl.Call(m, "CreateCatalogueExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ReadAllExercises indicates an expected call of ReadAllExercises.
-func (mr *MockExerciseRepositoryMockRecorder) ReadAllExercises() *gomock.Call {
+// CreateCatalogueExercise indicates an expected call of CreateCatalogueExercise.
+func (mr *MockExerciseRepositoryMockRecorder) CreateCatalogueExercise(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllExercises", reflect.TypeOf((*MockExerciseRepository)(nil).ReadAllExercises))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogueExercise", reflect.TypeOf((*MockExerciseRepository)(nil).CreateCatalogueExercise), arg0)
+}
+
+// CreateExercise mocks base method.
+func (m *MockExerciseRepository) CreateExercise(arg0 model.CreateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExercise indicates an expected call of CreateExercise.
+func (mr *MockExerciseRepositoryMockRecorder) CreateExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExercise", reflect.TypeOf((*MockExerciseRepository)(nil).CreateExercise), arg0)
+}
+
+// DeleteCatalogueExercise mocks base method.
+func (m *MockExerciseRepository) DeleteCatalogueExercise(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogueExercise", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCatalogueExercise indicates an expected call of DeleteCatalogueExercise.
+func (mr *MockExerciseRepositoryMockRecorder) DeleteCatalogueExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogueExercise", reflect.TypeOf((*MockExerciseRepository)(nil).DeleteCatalogueExercise), arg0)
+}
+
+// DeleteExercise mocks base method.
+func (m *MockExerciseRepository) DeleteExercise(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExercise", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExercise indicates an expected call of DeleteExercise.
+func (mr *MockExerciseRepositoryMockRecorder) DeleteExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExercise", reflect.TypeOf((*MockExerciseRepository)(nil).DeleteExercise), arg0)
 }
 
 // ReadExerciseByID mocks base method.
@@ -63,3 +106,48 @@ func (mr *MockExerciseRepositoryMockRecorder) ReadExerciseByID(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseByID", reflect.TypeOf((*MockExerciseRepository)(nil).ReadExerciseByID), arg0)
 }
+
+// ReadExercises mocks base method.
+func (m *MockExerciseRepository) ReadExercises(arg0 model.ExerciseFilter) ([]*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExercises", arg0)
+	ret0, _ := ret[0].([]*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExercises indicates an expected call of ReadExercises.
+func (mr *MockExerciseRepositoryMockRecorder) ReadExercises(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExercises", reflect.TypeOf((*MockExerciseRepository)(nil).ReadExercises), arg0)
+}
+
+// UpdateCatalogueExercise mocks base method.
+func (m *MockExerciseRepository) UpdateCatalogueExercise(arg0 model.UpdateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogueExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogueExercise indicates an expected call of UpdateCatalogueExercise.
+func (mr *MockExerciseRepositoryMockRecorder) UpdateCatalogueExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogueExercise", reflect.TypeOf((*MockExerciseRepository)(nil).UpdateCatalogueExercise), arg0)
+}
+
+// UpdateExercise mocks base method.
+func (m *MockExerciseRepository) UpdateExercise(arg0 model.UpdateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExercise indicates an expected call of UpdateExercise.
+func (mr *MockExerciseRepositoryMockRecorder) UpdateExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExercise", reflect.TypeOf((*MockExerciseRepository)(nil).UpdateExercise), arg0)
+}
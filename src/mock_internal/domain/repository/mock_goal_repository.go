@@ -34,6 +34,20 @@ func (m *MockGoalRepository) EXPECT() *MockGoalRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CompleteGoals mocks base method.
+func (m *MockGoalRepository) CompleteGoals(arg0 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteGoals", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteGoals indicates an expected call of CompleteGoals.
+func (mr *MockGoalRepositoryMockRecorder) CompleteGoals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteGoals", reflect.TypeOf((*MockGoalRepository)(nil).CompleteGoals), arg0)
+}
+
 // CreateGoal mocks base method.
 func (m *MockGoalRepository) CreateGoal(arg0 int64, arg1 model.CreateGoalRequest) (*model.Goal, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +64,17 @@ func (mr *MockGoalRepositoryMockRecorder) CreateGoal(arg0, arg1 interface{}) *go
 }
 
 // DeleteGoal mocks base method.
-func (m *MockGoalRepository) DeleteGoal(arg0 int64) error {
+func (m *MockGoalRepository) DeleteGoal(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGoal", arg0)
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteGoal indicates an expected call of DeleteGoal.
-func (mr *MockGoalRepositoryMockRecorder) DeleteGoal(arg0 interface{}) *gomock.Call {
+func (mr *MockGoalRepositoryMockRecorder) DeleteGoal(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalRepository)(nil).DeleteGoal), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalRepository)(nil).DeleteGoal), arg0, arg1)
 }
 
 // ReadUserGoals mocks base method.
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: MediaRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaRepository is a mock of MediaRepository interface.
+type MockMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryMockRecorder
+}
+
+// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
+type MockMediaRepositoryMockRecorder struct {
+	mock *MockMediaRepository
+}
+
+// NewMockMediaRepository creates a new mock instance.
+func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
+	mock := &MockMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMedia mocks base method.
+func (m *MockMediaRepository) CreateMedia(arg0 *model.Media) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedia", arg0)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockMediaRepositoryMockRecorder) CreateMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockMediaRepository)(nil).CreateMedia), arg0)
+}
+
+// ReadMediaByID mocks base method.
+func (m *MockMediaRepository) ReadMediaByID(arg0 string) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMediaByID", arg0)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMediaByID indicates an expected call of ReadMediaByID.
+func (mr *MockMediaRepositoryMockRecorder) ReadMediaByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMediaByID", reflect.TypeOf((*MockMediaRepository)(nil).ReadMediaByID), arg0)
+}
+
+// ReadMediaByIDs mocks base method.
+func (m *MockMediaRepository) ReadMediaByIDs(arg0 []string) ([]*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMediaByIDs", arg0)
+	ret0, _ := ret[0].([]*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMediaByIDs indicates an expected call of ReadMediaByIDs.
+func (mr *MockMediaRepositoryMockRecorder) ReadMediaByIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMediaByIDs", reflect.TypeOf((*MockMediaRepository)(nil).ReadMediaByIDs), arg0)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: PersonalRecordRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonalRecordRepository is a mock of PersonalRecordRepository interface.
+type MockPersonalRecordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalRecordRepositoryMockRecorder
+}
+
+// MockPersonalRecordRepositoryMockRecorder is the mock recorder for MockPersonalRecordRepository.
+type MockPersonalRecordRepositoryMockRecorder struct {
+	mock *MockPersonalRecordRepository
+}
+
+// NewMockPersonalRecordRepository creates a new mock instance.
+func NewMockPersonalRecordRepository(ctrl *gomock.Controller) *MockPersonalRecordRepository {
+	mock := &MockPersonalRecordRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonalRecordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalRecordRepository) EXPECT() *MockPersonalRecordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRecords mocks base method.
+func (m *MockPersonalRecordRepository) CreateRecords(arg0 []*model.PersonalRecord) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecords", arg0)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecords indicates an expected call of CreateRecords.
+func (mr *MockPersonalRecordRepositoryMockRecorder) CreateRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecords", reflect.TypeOf((*MockPersonalRecordRepository)(nil).CreateRecords), arg0)
+}
+
+// ReadBestRecords mocks base method.
+func (m *MockPersonalRecordRepository) ReadBestRecords(arg0 int64) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBestRecords", arg0)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBestRecords indicates an expected call of ReadBestRecords.
+func (mr *MockPersonalRecordRepositoryMockRecorder) ReadBestRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBestRecords", reflect.TypeOf((*MockPersonalRecordRepository)(nil).ReadBestRecords), arg0)
+}
+
+// ReadExerciseBestRecords mocks base method.
+func (m *MockPersonalRecordRepository) ReadExerciseBestRecords(arg0, arg1 int64) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExerciseBestRecords", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExerciseBestRecords indicates an expected call of ReadExerciseBestRecords.
+func (mr *MockPersonalRecordRepositoryMockRecorder) ReadExerciseBestRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseBestRecords", reflect.TypeOf((*MockPersonalRecordRepository)(nil).ReadExerciseBestRecords), arg0, arg1)
+}
+
+// ReadExerciseRecordHistory mocks base method.
+func (m *MockPersonalRecordRepository) ReadExerciseRecordHistory(arg0, arg1 int64) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExerciseRecordHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExerciseRecordHistory indicates an expected call of ReadExerciseRecordHistory.
+func (mr *MockPersonalRecordRepositoryMockRecorder) ReadExerciseRecordHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseRecordHistory", reflect.TypeOf((*MockPersonalRecordRepository)(nil).ReadExerciseRecordHistory), arg0, arg1)
+}
+
+// ReadSessionExerciseVolume mocks base method.
+func (m *MockPersonalRecordRepository) ReadSessionExerciseVolume(arg0, arg1 int64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSessionExerciseVolume", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSessionExerciseVolume indicates an expected call of ReadSessionExerciseVolume.
+func (mr *MockPersonalRecordRepositoryMockRecorder) ReadSessionExerciseVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSessionExerciseVolume", reflect.TypeOf((*MockPersonalRecordRepository)(nil).ReadSessionExerciseVolume), arg0, arg1)
+}
@@ -77,6 +77,20 @@ func (mr *MockPostRepositoryMockRecorder) CreatePost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockPostRepository)(nil).CreatePost), arg0)
 }
 
+// DeleteComment mocks base method.
+func (m *MockPostRepository) DeleteComment(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockPostRepositoryMockRecorder) DeleteComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockPostRepository)(nil).DeleteComment), arg0)
+}
+
 // DeletePost mocks base method.
 func (m *MockPostRepository) DeletePost(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -106,34 +120,34 @@ func (mr *MockPostRepositoryMockRecorder) LikePost(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikePost", reflect.TypeOf((*MockPostRepository)(nil).LikePost), arg0)
 }
 
-// ReadCommentsByComment mocks base method.
-func (m *MockPostRepository) ReadCommentsByComment(arg0 int64) ([]*model.Comment, error) {
+// ReadCommentRef mocks base method.
+func (m *MockPostRepository) ReadCommentRef(arg0 int64) (*model.CommentRef, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadCommentsByComment", arg0)
-	ret0, _ := ret[0].([]*model.Comment)
+	ret := m.ctrl.Call(m, "ReadCommentRef", arg0)
+	ret0, _ := ret[0].(*model.CommentRef)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ReadCommentsByComment indicates an expected call of ReadCommentsByComment.
-func (mr *MockPostRepositoryMockRecorder) ReadCommentsByComment(arg0 interface{}) *gomock.Call {
+// ReadCommentRef indicates an expected call of ReadCommentRef.
+func (mr *MockPostRepositoryMockRecorder) ReadCommentRef(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCommentsByComment", reflect.TypeOf((*MockPostRepository)(nil).ReadCommentsByComment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCommentRef", reflect.TypeOf((*MockPostRepository)(nil).ReadCommentRef), arg0)
 }
 
-// ReadCommentsByPost mocks base method.
-func (m *MockPostRepository) ReadCommentsByPost(arg0 int64) ([]*model.Comment, error) {
+// ReadComments mocks base method.
+func (m *MockPostRepository) ReadComments(arg0 model.CommentFilter) ([]*model.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadCommentsByPost", arg0)
+	ret := m.ctrl.Call(m, "ReadComments", arg0)
 	ret0, _ := ret[0].([]*model.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ReadCommentsByPost indicates an expected call of ReadCommentsByPost.
-func (mr *MockPostRepositoryMockRecorder) ReadCommentsByPost(arg0 interface{}) *gomock.Call {
+// ReadComments indicates an expected call of ReadComments.
+func (mr *MockPostRepositoryMockRecorder) ReadComments(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCommentsByPost", reflect.TypeOf((*MockPostRepository)(nil).ReadCommentsByPost), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadComments", reflect.TypeOf((*MockPostRepository)(nil).ReadComments), arg0)
 }
 
 // ReadPost mocks base method.
@@ -151,8 +165,83 @@ func (mr *MockPostRepositoryMockRecorder) ReadPost(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPost", reflect.TypeOf((*MockPostRepository)(nil).ReadPost), arg0, arg1)
 }
 
+// ReadPostMedia mocks base method.
+func (m *MockPostRepository) ReadPostMedia(arg0 []int64) ([]*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostMedia", arg0)
+	ret0, _ := ret[0].([]*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostMedia indicates an expected call of ReadPostMedia.
+func (mr *MockPostRepositoryMockRecorder) ReadPostMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostMedia", reflect.TypeOf((*MockPostRepository)(nil).ReadPostMedia), arg0)
+}
+
+// ReadPostOwnerID mocks base method.
+func (m *MockPostRepository) ReadPostOwnerID(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostOwnerID", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostOwnerID indicates an expected call of ReadPostOwnerID.
+func (mr *MockPostRepositoryMockRecorder) ReadPostOwnerID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostOwnerID", reflect.TypeOf((*MockPostRepository)(nil).ReadPostOwnerID), arg0)
+}
+
+// ReadPostReactions mocks base method.
+func (m *MockPostRepository) ReadPostReactions(arg0 []int64, arg1 int64) ([]*model.ReactionCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostReactions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ReactionCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostReactions indicates an expected call of ReadPostReactions.
+func (mr *MockPostRepositoryMockRecorder) ReadPostReactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostReactions", reflect.TypeOf((*MockPostRepository)(nil).ReadPostReactions), arg0, arg1)
+}
+
+// ReadPostRevisions mocks base method.
+func (m *MockPostRepository) ReadPostRevisions(arg0 int64) ([]*model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostRevisions", arg0)
+	ret0, _ := ret[0].([]*model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostRevisions indicates an expected call of ReadPostRevisions.
+func (mr *MockPostRepositoryMockRecorder) ReadPostRevisions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostRevisions", reflect.TypeOf((*MockPostRepository)(nil).ReadPostRevisions), arg0)
+}
+
+// ReadPostWorkouts mocks base method.
+func (m *MockPostRepository) ReadPostWorkouts(arg0 []int64) ([]*model.WorkoutSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostWorkouts", arg0)
+	ret0, _ := ret[0].([]*model.WorkoutSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostWorkouts indicates an expected call of ReadPostWorkouts.
+func (mr *MockPostRepositoryMockRecorder) ReadPostWorkouts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostWorkouts", reflect.TypeOf((*MockPostRepository)(nil).ReadPostWorkouts), arg0)
+}
+
 // ReadPosts mocks base method.
-func (m *MockPostRepository) ReadPosts(arg0 int64) ([]*model.Post, error) {
+func (m *MockPostRepository) ReadPosts(arg0 model.PostFeedFilter) ([]*model.Post, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReadPosts", arg0)
 	ret0, _ := ret[0].([]*model.Post)
@@ -181,6 +270,21 @@ func (mr *MockPostRepositoryMockRecorder) ReadPostsByUserID(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostsByUserID", reflect.TypeOf((*MockPostRepository)(nil).ReadPostsByUserID), arg0, arg1)
 }
 
+// ReadReactions mocks base method.
+func (m *MockPostRepository) ReadReactions(arg0 model.ReactionFilter) ([]*model.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadReactions", arg0)
+	ret0, _ := ret[0].([]*model.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadReactions indicates an expected call of ReadReactions.
+func (mr *MockPostRepositoryMockRecorder) ReadReactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadReactions", reflect.TypeOf((*MockPostRepository)(nil).ReadReactions), arg0)
+}
+
 // UnlikePost mocks base method.
 func (m *MockPostRepository) UnlikePost(arg0 model.UnikePostRequest) (*model.Post, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +300,21 @@ func (mr *MockPostRepositoryMockRecorder) UnlikePost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlikePost", reflect.TypeOf((*MockPostRepository)(nil).UnlikePost), arg0)
 }
 
+// UpdateComment mocks base method.
+func (m *MockPostRepository) UpdateComment(arg0 model.UpdateCommentRequest) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", arg0)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockPostRepositoryMockRecorder) UpdateComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockPostRepository)(nil).UpdateComment), arg0)
+}
+
 // UpdatePost mocks base method.
 func (m *MockPostRepository) UpdatePost(arg0 model.UpdatePostRequest) (*model.Post, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: ProgramRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProgramRepository is a mock of ProgramRepository interface.
+type MockProgramRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgramRepositoryMockRecorder
+}
+
+// MockProgramRepositoryMockRecorder is the mock recorder for MockProgramRepository.
+type MockProgramRepositoryMockRecorder struct {
+	mock *MockProgramRepository
+}
+
+// NewMockProgramRepository creates a new mock instance.
+func NewMockProgramRepository(ctrl *gomock.Controller) *MockProgramRepository {
+	mock := &MockProgramRepository{ctrl: ctrl}
+	mock.recorder = &MockProgramRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProgramRepository) EXPECT() *MockProgramRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimEnrollmentWeek mocks base method.
+func (m *MockProgramRepository) ClaimEnrollmentWeek(arg0 int64, arg1 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEnrollmentWeek", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEnrollmentWeek indicates an expected call of ClaimEnrollmentWeek.
+func (mr *MockProgramRepositoryMockRecorder) ClaimEnrollmentWeek(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEnrollmentWeek", reflect.TypeOf((*MockProgramRepository)(nil).ClaimEnrollmentWeek), arg0, arg1)
+}
+
+// CreateEnrollment mocks base method.
+func (m *MockProgramRepository) CreateEnrollment(arg0 *model.ProgramEnrollment) (*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEnrollment", arg0)
+	ret0, _ := ret[0].(*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEnrollment indicates an expected call of CreateEnrollment.
+func (mr *MockProgramRepositoryMockRecorder) CreateEnrollment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnrollment", reflect.TypeOf((*MockProgramRepository)(nil).CreateEnrollment), arg0)
+}
+
+// CreateEnrollmentSchedules mocks base method.
+func (m *MockProgramRepository) CreateEnrollmentSchedules(arg0 []*model.EnrollmentSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEnrollmentSchedules", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEnrollmentSchedules indicates an expected call of CreateEnrollmentSchedules.
+func (mr *MockProgramRepositoryMockRecorder) CreateEnrollmentSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnrollmentSchedules", reflect.TypeOf((*MockProgramRepository)(nil).CreateEnrollmentSchedules), arg0)
+}
+
+// CreateProgram mocks base method.
+func (m *MockProgramRepository) CreateProgram(arg0 model.CreateProgramRequest) (*model.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProgram", arg0)
+	ret0, _ := ret[0].(*model.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProgram indicates an expected call of CreateProgram.
+func (mr *MockProgramRepositoryMockRecorder) CreateProgram(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProgram", reflect.TypeOf((*MockProgramRepository)(nil).CreateProgram), arg0)
+}
+
+// DeleteEnrollment mocks base method.
+func (m *MockProgramRepository) DeleteEnrollment(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEnrollment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEnrollment indicates an expected call of DeleteEnrollment.
+func (mr *MockProgramRepositoryMockRecorder) DeleteEnrollment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnrollment", reflect.TypeOf((*MockProgramRepository)(nil).DeleteEnrollment), arg0)
+}
+
+// DeleteProgram mocks base method.
+func (m *MockProgramRepository) DeleteProgram(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProgram", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProgram indicates an expected call of DeleteProgram.
+func (mr *MockProgramRepositoryMockRecorder) DeleteProgram(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProgram", reflect.TypeOf((*MockProgramRepository)(nil).DeleteProgram), arg0)
+}
+
+// ReadActiveEnrollments mocks base method.
+func (m *MockProgramRepository) ReadActiveEnrollments() ([]*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActiveEnrollments")
+	ret0, _ := ret[0].([]*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadActiveEnrollments indicates an expected call of ReadActiveEnrollments.
+func (mr *MockProgramRepositoryMockRecorder) ReadActiveEnrollments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActiveEnrollments", reflect.TypeOf((*MockProgramRepository)(nil).ReadActiveEnrollments))
+}
+
+// ReadEnrollmentByID mocks base method.
+func (m *MockProgramRepository) ReadEnrollmentByID(arg0 int64) (*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEnrollmentByID", arg0)
+	ret0, _ := ret[0].(*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEnrollmentByID indicates an expected call of ReadEnrollmentByID.
+func (mr *MockProgramRepositoryMockRecorder) ReadEnrollmentByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEnrollmentByID", reflect.TypeOf((*MockProgramRepository)(nil).ReadEnrollmentByID), arg0)
+}
+
+// ReadEnrollmentSchedules mocks base method.
+func (m *MockProgramRepository) ReadEnrollmentSchedules(arg0 int64, arg1 int) ([]*model.EnrollmentSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEnrollmentSchedules", arg0, arg1)
+	ret0, _ := ret[0].([]*model.EnrollmentSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEnrollmentSchedules indicates an expected call of ReadEnrollmentSchedules.
+func (mr *MockProgramRepositoryMockRecorder) ReadEnrollmentSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEnrollmentSchedules", reflect.TypeOf((*MockProgramRepository)(nil).ReadEnrollmentSchedules), arg0, arg1)
+}
+
+// ReadProgramByID mocks base method.
+func (m *MockProgramRepository) ReadProgramByID(arg0 int64) (*model.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadProgramByID", arg0)
+	ret0, _ := ret[0].(*model.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadProgramByID indicates an expected call of ReadProgramByID.
+func (mr *MockProgramRepositoryMockRecorder) ReadProgramByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadProgramByID", reflect.TypeOf((*MockProgramRepository)(nil).ReadProgramByID), arg0)
+}
+
+// ReadUserEnrollments mocks base method.
+func (m *MockProgramRepository) ReadUserEnrollments(arg0 int64) ([]*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserEnrollments", arg0)
+	ret0, _ := ret[0].([]*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserEnrollments indicates an expected call of ReadUserEnrollments.
+func (mr *MockProgramRepositoryMockRecorder) ReadUserEnrollments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserEnrollments", reflect.TypeOf((*MockProgramRepository)(nil).ReadUserEnrollments), arg0)
+}
+
+// UpdateEnrollmentProgress mocks base method.
+func (m *MockProgramRepository) UpdateEnrollmentProgress(arg0 int64, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnrollmentProgress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnrollmentProgress indicates an expected call of UpdateEnrollmentProgress.
+func (mr *MockProgramRepositoryMockRecorder) UpdateEnrollmentProgress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnrollmentProgress", reflect.TypeOf((*MockProgramRepository)(nil).UpdateEnrollmentProgress), arg0, arg1, arg2)
+}
@@ -77,6 +77,21 @@ func (mr *MockRoutineRepositoryMockRecorder) DeleteRoutine(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoutine", reflect.TypeOf((*MockRoutineRepository)(nil).DeleteRoutine), arg0)
 }
 
+// ReadPublicRoutines mocks base method.
+func (m *MockRoutineRepository) ReadPublicRoutines(arg0 model.PublicRoutineFilter) ([]*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPublicRoutines", arg0)
+	ret0, _ := ret[0].([]*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPublicRoutines indicates an expected call of ReadPublicRoutines.
+func (mr *MockRoutineRepositoryMockRecorder) ReadPublicRoutines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPublicRoutines", reflect.TypeOf((*MockRoutineRepository)(nil).ReadPublicRoutines), arg0)
+}
+
 // ReadRoutineWithExercises mocks base method.
 func (m *MockRoutineRepository) ReadRoutineWithExercises(arg0 int64) (*model.ExerciseRoutine, error) {
 	m.ctrl.T.Helper()
@@ -120,3 +135,32 @@ func (mr *MockRoutineRepositoryMockRecorder) RemoveExerciseFromRoutine(arg0, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExerciseFromRoutine", reflect.TypeOf((*MockRoutineRepository)(nil).RemoveExerciseFromRoutine), arg0, arg1)
 }
+
+// ReorderRoutineExercises mocks base method.
+func (m *MockRoutineRepository) ReorderRoutineExercises(arg0 int64, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRoutineExercises", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderRoutineExercises indicates an expected call of ReorderRoutineExercises.
+func (mr *MockRoutineRepositoryMockRecorder) ReorderRoutineExercises(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRoutineExercises", reflect.TypeOf((*MockRoutineRepository)(nil).ReorderRoutineExercises), arg0, arg1)
+}
+
+// UpdateRoutine mocks base method.
+func (m *MockRoutineRepository) UpdateRoutine(arg0 model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoutine", arg0)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRoutine indicates an expected call of UpdateRoutine.
+func (mr *MockRoutineRepositoryMockRecorder) UpdateRoutine(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoutine", reflect.TypeOf((*MockRoutineRepository)(nil).UpdateRoutine), arg0)
+}
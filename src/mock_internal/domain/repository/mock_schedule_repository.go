@@ -49,6 +49,49 @@ func (mr *MockScheduleRepositoryMockRecorder) CreateSchedule(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).CreateSchedule), arg0)
 }
 
+// CreateScheduleException mocks base method.
+func (m *MockScheduleRepository) CreateScheduleException(arg0 model.CreateScheduleExceptionRequest) (*model.ScheduleException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduleException", arg0)
+	ret0, _ := ret[0].(*model.ScheduleException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduleException indicates an expected call of CreateScheduleException.
+func (mr *MockScheduleRepositoryMockRecorder) CreateScheduleException(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduleException", reflect.TypeOf((*MockScheduleRepository)(nil).CreateScheduleException), arg0)
+}
+
+// DeleteCalendarFeed mocks base method.
+func (m *MockScheduleRepository) DeleteCalendarFeed(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarFeed", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarFeed indicates an expected call of DeleteCalendarFeed.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteCalendarFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarFeed", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteCalendarFeed), arg0)
+}
+
+// DeleteOccurrenceMark mocks base method.
+func (m *MockScheduleRepository) DeleteOccurrenceMark(arg0 model.DeleteOccurrenceMarkRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOccurrenceMark", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOccurrenceMark indicates an expected call of DeleteOccurrenceMark.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteOccurrenceMark(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOccurrenceMark", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteOccurrenceMark), arg0)
+}
+
 // DeleteSchedule mocks base method.
 func (m *MockScheduleRepository) DeleteSchedule(arg0 model.DeleteScheduleRequest) error {
 	m.ctrl.T.Helper()
@@ -63,6 +106,65 @@ func (mr *MockScheduleRepositoryMockRecorder) DeleteSchedule(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteSchedule), arg0)
 }
 
+// DeleteScheduleException mocks base method.
+func (m *MockScheduleRepository) DeleteScheduleException(arg0 model.DeleteScheduleExceptionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduleException", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduleException indicates an expected call of DeleteScheduleException.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteScheduleException(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduleException", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteScheduleException), arg0)
+}
+
+// ReadCalendarEntries mocks base method.
+func (m *MockScheduleRepository) ReadCalendarEntries(arg0 int64) ([]*model.CalendarEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCalendarEntries", arg0)
+	ret0, _ := ret[0].([]*model.CalendarEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCalendarEntries indicates an expected call of ReadCalendarEntries.
+func (mr *MockScheduleRepositoryMockRecorder) ReadCalendarEntries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCalendarEntries", reflect.TypeOf((*MockScheduleRepository)(nil).ReadCalendarEntries), arg0)
+}
+
+// ReadCalendarFeedOwner mocks base method.
+func (m *MockScheduleRepository) ReadCalendarFeedOwner(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCalendarFeedOwner", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCalendarFeedOwner indicates an expected call of ReadCalendarFeedOwner.
+func (mr *MockScheduleRepositoryMockRecorder) ReadCalendarFeedOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCalendarFeedOwner", reflect.TypeOf((*MockScheduleRepository)(nil).ReadCalendarFeedOwner), arg0)
+}
+
+// ReadOccurrenceMarks mocks base method.
+func (m *MockScheduleRepository) ReadOccurrenceMarks(arg0 model.OccurrenceMarkFilter) ([]*model.OccurrenceMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOccurrenceMarks", arg0)
+	ret0, _ := ret[0].([]*model.OccurrenceMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOccurrenceMarks indicates an expected call of ReadOccurrenceMarks.
+func (mr *MockScheduleRepositoryMockRecorder) ReadOccurrenceMarks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOccurrenceMarks", reflect.TypeOf((*MockScheduleRepository)(nil).ReadOccurrenceMarks), arg0)
+}
+
 // ReadScheduleByID mocks base method.
 func (m *MockScheduleRepository) ReadScheduleByID(arg0 int64) (*model.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +180,21 @@ func (mr *MockScheduleRepositoryMockRecorder) ReadScheduleByID(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScheduleByID", reflect.TypeOf((*MockScheduleRepository)(nil).ReadScheduleByID), arg0)
 }
 
+// ReadScheduleExceptions mocks base method.
+func (m *MockScheduleRepository) ReadScheduleExceptions(arg0 model.ScheduleExceptionFilter) ([]*model.ScheduleException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadScheduleExceptions", arg0)
+	ret0, _ := ret[0].([]*model.ScheduleException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadScheduleExceptions indicates an expected call of ReadScheduleExceptions.
+func (mr *MockScheduleRepositoryMockRecorder) ReadScheduleExceptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScheduleExceptions", reflect.TypeOf((*MockScheduleRepository)(nil).ReadScheduleExceptions), arg0)
+}
+
 // ReadUserSchedules mocks base method.
 func (m *MockScheduleRepository) ReadUserSchedules(arg0 int64) ([]*model.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +239,32 @@ func (mr *MockScheduleRepositoryMockRecorder) UpdateSchedule(arg0 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).UpdateSchedule), arg0)
 }
+
+// UpsertCalendarFeed mocks base method.
+func (m *MockScheduleRepository) UpsertCalendarFeed(arg0 int64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCalendarFeed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCalendarFeed indicates an expected call of UpsertCalendarFeed.
+func (mr *MockScheduleRepositoryMockRecorder) UpsertCalendarFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCalendarFeed", reflect.TypeOf((*MockScheduleRepository)(nil).UpsertCalendarFeed), arg0, arg1)
+}
+
+// UpsertOccurrenceMark mocks base method.
+func (m *MockScheduleRepository) UpsertOccurrenceMark(arg0 model.MarkOccurrenceRequest) (*model.OccurrenceMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOccurrenceMark", arg0)
+	ret0, _ := ret[0].(*model.OccurrenceMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOccurrenceMark indicates an expected call of UpsertOccurrenceMark.
+func (mr *MockScheduleRepositoryMockRecorder) UpsertOccurrenceMark(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOccurrenceMark", reflect.TypeOf((*MockScheduleRepository)(nil).UpsertOccurrenceMark), arg0)
+}
@@ -34,6 +34,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateGoogleUser mocks base method.
+func (m *MockUserRepository) CreateGoogleUser(arg0 model.CreateGoogleUserRequest) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoogleUser", arg0)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoogleUser indicates an expected call of CreateGoogleUser.
+func (mr *MockUserRepositoryMockRecorder) CreateGoogleUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoogleUser", reflect.TypeOf((*MockUserRepository)(nil).CreateGoogleUser), arg0)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(arg0 model.CreateUserRequest) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +78,21 @@ func (mr *MockUserRepositoryMockRecorder) DeleteUser(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), arg0)
 }
 
+// LinkGoogleAccount mocks base method.
+func (m *MockUserRepository) LinkGoogleAccount(arg0, arg1 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkGoogleAccount", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkGoogleAccount indicates an expected call of LinkGoogleAccount.
+func (mr *MockUserRepositoryMockRecorder) LinkGoogleAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkGoogleAccount", reflect.TypeOf((*MockUserRepository)(nil).LinkGoogleAccount), arg0, arg1)
+}
+
 // ReadUserByEmail mocks base method.
 func (m *MockUserRepository) ReadUserByEmail(arg0 string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +108,21 @@ func (mr *MockUserRepositoryMockRecorder) ReadUserByEmail(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).ReadUserByEmail), arg0)
 }
 
+// ReadUserByGoogleID mocks base method.
+func (m *MockUserRepository) ReadUserByGoogleID(arg0 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserByGoogleID", arg0)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserByGoogleID indicates an expected call of ReadUserByGoogleID.
+func (mr *MockUserRepositoryMockRecorder) ReadUserByGoogleID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByGoogleID", reflect.TypeOf((*MockUserRepository)(nil).ReadUserByGoogleID), arg0)
+}
+
 // ReadUserByID mocks base method.
 func (m *MockUserRepository) ReadUserByID(arg0 int64) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +138,36 @@ func (mr *MockUserRepositoryMockRecorder) ReadUserByID(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserByID", reflect.TypeOf((*MockUserRepository)(nil).ReadUserByID), arg0)
 }
 
+// ReadUserRole mocks base method.
+func (m *MockUserRepository) ReadUserRole(arg0 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserRole", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserRole indicates an expected call of ReadUserRole.
+func (mr *MockUserRepositoryMockRecorder) ReadUserRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserRole", reflect.TypeOf((*MockUserRepository)(nil).ReadUserRole), arg0)
+}
+
+// ReadUserStanding mocks base method.
+func (m *MockUserRepository) ReadUserStanding(arg0 int64) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserStanding", arg0)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserStanding indicates an expected call of ReadUserStanding.
+func (mr *MockUserRepositoryMockRecorder) ReadUserStanding(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserStanding", reflect.TypeOf((*MockUserRepository)(nil).ReadUserStanding), arg0)
+}
+
 // ReadUsers mocks base method.
 func (m *MockUserRepository) ReadUsers() ([]*model.User, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +183,20 @@ func (mr *MockUserRepositoryMockRecorder) ReadUsers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUsers", reflect.TypeOf((*MockUserRepository)(nil).ReadUsers))
 }
 
+// UpdateAvatarMedia mocks base method.
+func (m *MockUserRepository) UpdateAvatarMedia(arg0 int64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatarMedia", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAvatarMedia indicates an expected call of UpdateAvatarMedia.
+func (mr *MockUserRepositoryMockRecorder) UpdateAvatarMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatarMedia", reflect.TypeOf((*MockUserRepository)(nil).UpdateAvatarMedia), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(arg0 model.UpdateUserRequest) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -122,3 +211,33 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUser(arg0 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), arg0)
 }
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRepository) UpdateUserRole(arg0 int64, arg1 string) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserRole), arg0, arg1)
+}
+
+// UpdateUserStanding mocks base method.
+func (m *MockUserRepository) UpdateUserStanding(arg0 model.UpdateUserStandingRequest) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStanding", arg0)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserStanding indicates an expected call of UpdateUserStanding.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserStanding(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStanding", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserStanding), arg0)
+}
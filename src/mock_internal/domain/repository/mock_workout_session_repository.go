@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/repository (interfaces: WorkoutSessionRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkoutSessionRepository is a mock of WorkoutSessionRepository interface.
+type MockWorkoutSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkoutSessionRepositoryMockRecorder
+}
+
+// MockWorkoutSessionRepositoryMockRecorder is the mock recorder for MockWorkoutSessionRepository.
+type MockWorkoutSessionRepositoryMockRecorder struct {
+	mock *MockWorkoutSessionRepository
+}
+
+// NewMockWorkoutSessionRepository creates a new mock instance.
+func NewMockWorkoutSessionRepository(ctrl *gomock.Controller) *MockWorkoutSessionRepository {
+	mock := &MockWorkoutSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkoutSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkoutSessionRepository) EXPECT() *MockWorkoutSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockWorkoutSessionRepository) CreateSession(arg0 model.StartWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) CreateSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).CreateSession), arg0)
+}
+
+// CreateSet mocks base method.
+func (m *MockWorkoutSessionRepository) CreateSet(arg0 model.LogWorkoutSetRequest) (*model.WorkoutSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSet", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSet indicates an expected call of CreateSet.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) CreateSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSet", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).CreateSet), arg0)
+}
+
+// FinishSession mocks base method.
+func (m *MockWorkoutSessionRepository) FinishSession(arg0 model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishSession indicates an expected call of FinishSession.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) FinishSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSession", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).FinishSession), arg0)
+}
+
+// ReadActiveSession mocks base method.
+func (m *MockWorkoutSessionRepository) ReadActiveSession(arg0 int64) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActiveSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadActiveSession indicates an expected call of ReadActiveSession.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) ReadActiveSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActiveSession", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).ReadActiveSession), arg0)
+}
+
+// ReadCompletedWorkouts mocks base method.
+func (m *MockWorkoutSessionRepository) ReadCompletedWorkouts(arg0 int64, arg1, arg2 time.Time) ([]*model.CompletedWorkout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCompletedWorkouts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.CompletedWorkout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCompletedWorkouts indicates an expected call of ReadCompletedWorkouts.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) ReadCompletedWorkouts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCompletedWorkouts", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).ReadCompletedWorkouts), arg0, arg1, arg2)
+}
+
+// ReadSessionByID mocks base method.
+func (m *MockWorkoutSessionRepository) ReadSessionByID(arg0 int64) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSessionByID", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSessionByID indicates an expected call of ReadSessionByID.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) ReadSessionByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSessionByID", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).ReadSessionByID), arg0)
+}
+
+// ReadUserSessions mocks base method.
+func (m *MockWorkoutSessionRepository) ReadUserSessions(arg0 int64) ([]*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserSessions", arg0)
+	ret0, _ := ret[0].([]*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserSessions indicates an expected call of ReadUserSessions.
+func (mr *MockWorkoutSessionRepositoryMockRecorder) ReadUserSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserSessions", reflect.TypeOf((*MockWorkoutSessionRepository)(nil).ReadUserSessions), arg0)
+}
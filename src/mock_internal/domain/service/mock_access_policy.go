@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: AccessPolicy)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccessPolicy is a mock of AccessPolicy interface.
+type MockAccessPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessPolicyMockRecorder
+}
+
+// MockAccessPolicyMockRecorder is the mock recorder for MockAccessPolicy.
+type MockAccessPolicyMockRecorder struct {
+	mock *MockAccessPolicy
+}
+
+// NewMockAccessPolicy creates a new mock instance.
+func NewMockAccessPolicy(ctrl *gomock.Controller) *MockAccessPolicy {
+	mock := &MockAccessPolicy{ctrl: ctrl}
+	mock.recorder = &MockAccessPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessPolicy) EXPECT() *MockAccessPolicyMockRecorder {
+	return m.recorder
+}
+
+// CanModify mocks base method.
+func (m *MockAccessPolicy) CanModify(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanModify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanModify indicates an expected call of CanModify.
+func (mr *MockAccessPolicyMockRecorder) CanModify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanModify", reflect.TypeOf((*MockAccessPolicy)(nil).CanModify), arg0, arg1)
+}
+
+// CanView mocks base method.
+func (m *MockAccessPolicy) CanView(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanView", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanView indicates an expected call of CanView.
+func (mr *MockAccessPolicyMockRecorder) CanView(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanView", reflect.TypeOf((*MockAccessPolicy)(nil).CanView), arg0, arg1)
+}
+
+// CanViewAsFollower mocks base method.
+func (m *MockAccessPolicy) CanViewAsFollower(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewAsFollower", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanViewAsFollower indicates an expected call of CanViewAsFollower.
+func (mr *MockAccessPolicyMockRecorder) CanViewAsFollower(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewAsFollower", reflect.TypeOf((*MockAccessPolicy)(nil).CanViewAsFollower), arg0, arg1)
+}
+
+// CanViewMetrics mocks base method.
+func (m *MockAccessPolicy) CanViewMetrics(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewMetrics", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanViewMetrics indicates an expected call of CanViewMetrics.
+func (mr *MockAccessPolicyMockRecorder) CanViewMetrics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewMetrics", reflect.TypeOf((*MockAccessPolicy)(nil).CanViewMetrics), arg0, arg1)
+}
@@ -35,18 +35,38 @@ func (m *MockAchievementService) EXPECT() *MockAchievementServiceMockRecorder {
 }
 
 // CreateAchievement mocks base method.
-func (m *MockAchievementService) CreateAchievement(arg0 model.CreateAchievementRequest) (*model.UserAchievement, error) {
+func (m *MockAchievementService) CreateAchievement(arg0 int64, arg1 model.CreateAchievementRequest) (*model.UserAchievement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAchievement", arg0)
+	ret := m.ctrl.Call(m, "CreateAchievement", arg0, arg1)
 	ret0, _ := ret[0].(*model.UserAchievement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAchievement indicates an expected call of CreateAchievement.
-func (mr *MockAchievementServiceMockRecorder) CreateAchievement(arg0 interface{}) *gomock.Call {
+func (mr *MockAchievementServiceMockRecorder) CreateAchievement(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAchievement", reflect.TypeOf((*MockAchievementService)(nil).CreateAchievement), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAchievement", reflect.TypeOf((*MockAchievementService)(nil).CreateAchievement), arg0, arg1)
+}
+
+// Evaluate mocks base method.
+func (m *MockAchievementService) Evaluate(arg0 int64, arg1 ...string) ([]*model.UserAchievement, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Evaluate", varargs...)
+	ret0, _ := ret[0].([]*model.UserAchievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockAchievementServiceMockRecorder) Evaluate(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockAchievementService)(nil).Evaluate), varargs...)
 }
 
 // ReadAchievementsFeed mocks base method.
@@ -0,0 +1,212 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: AdminService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminService is a mock of AdminService interface.
+type MockAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceMockRecorder
+}
+
+// MockAdminServiceMockRecorder is the mock recorder for MockAdminService.
+type MockAdminServiceMockRecorder struct {
+	mock *MockAdminService
+}
+
+// NewMockAdminService creates a new mock instance.
+func NewMockAdminService(ctrl *gomock.Controller) *MockAdminService {
+	mock := &MockAdminService{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminService) EXPECT() *MockAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockAdminService) BanUser(arg0 int64, arg1 model.BanUserRequest) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockAdminServiceMockRecorder) BanUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockAdminService)(nil).BanUser), arg0, arg1)
+}
+
+// CreateAchievement mocks base method.
+func (m *MockAdminService) CreateAchievement(arg0 int64, arg1 model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAchievement", arg0, arg1)
+	ret0, _ := ret[0].(*model.Achievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAchievement indicates an expected call of CreateAchievement.
+func (mr *MockAdminServiceMockRecorder) CreateAchievement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAchievement", reflect.TypeOf((*MockAdminService)(nil).CreateAchievement), arg0, arg1)
+}
+
+// CreateCatalogueExercise mocks base method.
+func (m *MockAdminService) CreateCatalogueExercise(arg0 int64, arg1 model.CreateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogueExercise", arg0, arg1)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCatalogueExercise indicates an expected call of CreateCatalogueExercise.
+func (mr *MockAdminServiceMockRecorder) CreateCatalogueExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogueExercise", reflect.TypeOf((*MockAdminService)(nil).CreateCatalogueExercise), arg0, arg1)
+}
+
+// DeleteAchievement mocks base method.
+func (m *MockAdminService) DeleteAchievement(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAchievement", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAchievement indicates an expected call of DeleteAchievement.
+func (mr *MockAdminServiceMockRecorder) DeleteAchievement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAchievement", reflect.TypeOf((*MockAdminService)(nil).DeleteAchievement), arg0, arg1)
+}
+
+// DeleteCatalogueExercise mocks base method.
+func (m *MockAdminService) DeleteCatalogueExercise(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogueExercise", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCatalogueExercise indicates an expected call of DeleteCatalogueExercise.
+func (mr *MockAdminServiceMockRecorder) DeleteCatalogueExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogueExercise", reflect.TypeOf((*MockAdminService)(nil).DeleteCatalogueExercise), arg0, arg1)
+}
+
+// ReadAuditLog mocks base method.
+func (m *MockAdminService) ReadAuditLog(arg0 model.ReadAuditLogRequest) (*model.AuditLogPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAuditLog", arg0)
+	ret0, _ := ret[0].(*model.AuditLogPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAuditLog indicates an expected call of ReadAuditLog.
+func (mr *MockAdminServiceMockRecorder) ReadAuditLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAuditLog", reflect.TypeOf((*MockAdminService)(nil).ReadAuditLog), arg0)
+}
+
+// ReinstateUser mocks base method.
+func (m *MockAdminService) ReinstateUser(arg0, arg1 int64) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReinstateUser", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReinstateUser indicates an expected call of ReinstateUser.
+func (mr *MockAdminServiceMockRecorder) ReinstateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReinstateUser", reflect.TypeOf((*MockAdminService)(nil).ReinstateUser), arg0, arg1)
+}
+
+// SuspendUser mocks base method.
+func (m *MockAdminService) SuspendUser(arg0 int64, arg1 model.SuspendUserRequest) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockAdminServiceMockRecorder) SuspendUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockAdminService)(nil).SuspendUser), arg0, arg1)
+}
+
+// TakeDownPost mocks base method.
+func (m *MockAdminService) TakeDownPost(arg0 int64, arg1 model.TakeDownPostRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDownPost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeDownPost indicates an expected call of TakeDownPost.
+func (mr *MockAdminServiceMockRecorder) TakeDownPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDownPost", reflect.TypeOf((*MockAdminService)(nil).TakeDownPost), arg0, arg1)
+}
+
+// UpdateAchievement mocks base method.
+func (m *MockAdminService) UpdateAchievement(arg0 int64, arg1 model.AchievementDefinitionRequest) (*model.Achievement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAchievement", arg0, arg1)
+	ret0, _ := ret[0].(*model.Achievement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAchievement indicates an expected call of UpdateAchievement.
+func (mr *MockAdminServiceMockRecorder) UpdateAchievement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAchievement", reflect.TypeOf((*MockAdminService)(nil).UpdateAchievement), arg0, arg1)
+}
+
+// UpdateCatalogueExercise mocks base method.
+func (m *MockAdminService) UpdateCatalogueExercise(arg0 int64, arg1 model.UpdateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogueExercise", arg0, arg1)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogueExercise indicates an expected call of UpdateCatalogueExercise.
+func (mr *MockAdminServiceMockRecorder) UpdateCatalogueExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogueExercise", reflect.TypeOf((*MockAdminService)(nil).UpdateCatalogueExercise), arg0, arg1)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockAdminService) UpdateUserRole(arg0 int64, arg1 model.UpdateUserRoleRequest) (*model.UserStanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockAdminServiceMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockAdminService)(nil).UpdateUserRole), arg0, arg1)
+}
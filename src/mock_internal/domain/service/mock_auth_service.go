@@ -49,3 +49,105 @@ func (mr *MockAuthServiceMockRecorder) Authenticate(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthService)(nil).Authenticate), arg0, arg1)
 }
+
+// AuthenticateGoogle mocks base method.
+func (m *MockAuthService) AuthenticateGoogle(arg0 context.Context, arg1 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateGoogle", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateGoogle indicates an expected call of AuthenticateGoogle.
+func (mr *MockAuthServiceMockRecorder) AuthenticateGoogle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateGoogle", reflect.TypeOf((*MockAuthService)(nil).AuthenticateGoogle), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthService) CreateSession(arg0 context.Context, arg1 int64) (*model.AuthSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(*model.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAuthServiceMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthService)(nil).CreateSession), arg0, arg1)
+}
+
+// EndSession mocks base method.
+func (m *MockAuthService) EndSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockAuthServiceMockRecorder) EndSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockAuthService)(nil).EndSession), arg0, arg1)
+}
+
+// IsSessionActive mocks base method.
+func (m *MockAuthService) IsSessionActive(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionActive", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionActive indicates an expected call of IsSessionActive.
+func (mr *MockAuthServiceMockRecorder) IsSessionActive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionActive", reflect.TypeOf((*MockAuthService)(nil).IsSessionActive), arg0, arg1)
+}
+
+// RefreshSession mocks base method.
+func (m *MockAuthService) RefreshSession(arg0 context.Context, arg1 string) (*model.AuthSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", arg0, arg1)
+	ret0, _ := ret[0].(*model.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockAuthServiceMockRecorder) RefreshSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockAuthService)(nil).RefreshSession), arg0, arg1)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockAuthService) RevokeAllSessions(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockAuthServiceMockRecorder) RevokeAllSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockAuthService)(nil).RevokeAllSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthService) RevokeSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthService)(nil).RevokeSession), arg0, arg1)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: BodyMeasurementService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBodyMeasurementService is a mock of BodyMeasurementService interface.
+type MockBodyMeasurementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBodyMeasurementServiceMockRecorder
+}
+
+// MockBodyMeasurementServiceMockRecorder is the mock recorder for MockBodyMeasurementService.
+type MockBodyMeasurementServiceMockRecorder struct {
+	mock *MockBodyMeasurementService
+}
+
+// NewMockBodyMeasurementService creates a new mock instance.
+func NewMockBodyMeasurementService(ctrl *gomock.Controller) *MockBodyMeasurementService {
+	mock := &MockBodyMeasurementService{ctrl: ctrl}
+	mock.recorder = &MockBodyMeasurementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBodyMeasurementService) EXPECT() *MockBodyMeasurementServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateMeasurement mocks base method.
+func (m *MockBodyMeasurementService) CreateMeasurement(arg0 int64, arg1 model.CreateMeasurementRequest) (*model.BodyMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMeasurement", arg0, arg1)
+	ret0, _ := ret[0].(*model.BodyMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMeasurement indicates an expected call of CreateMeasurement.
+func (mr *MockBodyMeasurementServiceMockRecorder) CreateMeasurement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeasurement", reflect.TypeOf((*MockBodyMeasurementService)(nil).CreateMeasurement), arg0, arg1)
+}
+
+// DeleteMeasurement mocks base method.
+func (m *MockBodyMeasurementService) DeleteMeasurement(arg0, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMeasurement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMeasurement indicates an expected call of DeleteMeasurement.
+func (mr *MockBodyMeasurementServiceMockRecorder) DeleteMeasurement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeasurement", reflect.TypeOf((*MockBodyMeasurementService)(nil).DeleteMeasurement), arg0, arg1, arg2)
+}
+
+// ReadMeasurements mocks base method.
+func (m *MockBodyMeasurementService) ReadMeasurements(arg0 int64, arg1 model.ReadMeasurementsRequest) ([]*model.BodyMeasurement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMeasurements", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BodyMeasurement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMeasurements indicates an expected call of ReadMeasurements.
+func (mr *MockBodyMeasurementServiceMockRecorder) ReadMeasurements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMeasurements", reflect.TypeOf((*MockBodyMeasurementService)(nil).ReadMeasurements), arg0, arg1)
+}
+
+// ReadTrend mocks base method.
+func (m *MockBodyMeasurementService) ReadTrend(arg0 int64, arg1 model.ReadMeasurementsRequest) (*model.MeasurementTrend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTrend", arg0, arg1)
+	ret0, _ := ret[0].(*model.MeasurementTrend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadTrend indicates an expected call of ReadTrend.
+func (mr *MockBodyMeasurementServiceMockRecorder) ReadTrend(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTrend", reflect.TypeOf((*MockBodyMeasurementService)(nil).ReadTrend), arg0, arg1)
+}
@@ -34,32 +34,76 @@ func (m *MockExerciseService) EXPECT() *MockExerciseServiceMockRecorder {
 	return m.recorder
 }
 
-// ReadAllExercises mocks base method.
-func (m *MockExerciseService) ReadAllExercises() ([]*model.Exercise, error) {
+// CreateExercise mocks base method.
+func (m *MockExerciseService) CreateExercise(arg0 model.CreateExerciseRequest) (*model.Exercise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadAllExercises")
-	ret0, _ := ret[0].([]*model.Exercise)
+	ret := m.ctrl.Call(m, "CreateExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ReadAllExercises indicates an expected call of ReadAllExercises.
-func (mr *MockExerciseServiceMockRecorder) ReadAllExercises() *gomock.Call {
+// CreateExercise indicates an expected call of CreateExercise.
+func (mr *MockExerciseServiceMockRecorder) CreateExercise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExercise", reflect.TypeOf((*MockExerciseService)(nil).CreateExercise), arg0)
+}
+
+// DeleteExercise mocks base method.
+func (m *MockExerciseService) DeleteExercise(arg0 model.DeleteExerciseRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExercise", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExercise indicates an expected call of DeleteExercise.
+func (mr *MockExerciseServiceMockRecorder) DeleteExercise(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllExercises", reflect.TypeOf((*MockExerciseService)(nil).ReadAllExercises))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExercise", reflect.TypeOf((*MockExerciseService)(nil).DeleteExercise), arg0)
 }
 
 // ReadExerciseByID mocks base method.
-func (m *MockExerciseService) ReadExerciseByID(arg0 int64) (*model.Exercise, error) {
+func (m *MockExerciseService) ReadExerciseByID(arg0, arg1 int64) (*model.Exercise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadExerciseByID", arg0)
+	ret := m.ctrl.Call(m, "ReadExerciseByID", arg0, arg1)
 	ret0, _ := ret[0].(*model.Exercise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadExerciseByID indicates an expected call of ReadExerciseByID.
-func (mr *MockExerciseServiceMockRecorder) ReadExerciseByID(arg0 interface{}) *gomock.Call {
+func (mr *MockExerciseServiceMockRecorder) ReadExerciseByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseByID", reflect.TypeOf((*MockExerciseService)(nil).ReadExerciseByID), arg0, arg1)
+}
+
+// ReadExercises mocks base method.
+func (m *MockExerciseService) ReadExercises(arg0 model.ReadExerciseRequest) (*model.ExercisePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExercises", arg0)
+	ret0, _ := ret[0].(*model.ExercisePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExercises indicates an expected call of ReadExercises.
+func (mr *MockExerciseServiceMockRecorder) ReadExercises(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExercises", reflect.TypeOf((*MockExerciseService)(nil).ReadExercises), arg0)
+}
+
+// UpdateExercise mocks base method.
+func (m *MockExerciseService) UpdateExercise(arg0 model.UpdateExerciseRequest) (*model.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExercise", arg0)
+	ret0, _ := ret[0].(*model.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExercise indicates an expected call of UpdateExercise.
+func (mr *MockExerciseServiceMockRecorder) UpdateExercise(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseByID", reflect.TypeOf((*MockExerciseService)(nil).ReadExerciseByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExercise", reflect.TypeOf((*MockExerciseService)(nil).UpdateExercise), arg0)
 }
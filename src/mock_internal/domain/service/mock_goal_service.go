@@ -35,31 +35,74 @@ func (m *MockGoalService) EXPECT() *MockGoalServiceMockRecorder {
 }
 
 // CreateGoal mocks base method.
-func (m *MockGoalService) CreateGoal(arg0 int64, arg1 model.CreateGoalRequest) (*model.Goal, error) {
+func (m *MockGoalService) CreateGoal(arg0, arg1 int64, arg2 model.CreateGoalRequest) (*model.Goal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.Goal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateGoal indicates an expected call of CreateGoal.
-func (mr *MockGoalServiceMockRecorder) CreateGoal(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockGoalServiceMockRecorder) CreateGoal(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockGoalService)(nil).CreateGoal), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockGoalService)(nil).CreateGoal), arg0, arg1, arg2)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockGoalService) DeleteGoal(arg0, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockGoalServiceMockRecorder) DeleteGoal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockGoalService)(nil).DeleteGoal), arg0, arg1, arg2)
 }
 
 // ReadUserGoals mocks base method.
-func (m *MockGoalService) ReadUserGoals(arg0 int64) ([]*model.Goal, error) {
+func (m *MockGoalService) ReadUserGoals(arg0, arg1 int64) ([]*model.Goal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserGoals", arg0)
+	ret := m.ctrl.Call(m, "ReadUserGoals", arg0, arg1)
 	ret0, _ := ret[0].([]*model.Goal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUserGoals indicates an expected call of ReadUserGoals.
-func (mr *MockGoalServiceMockRecorder) ReadUserGoals(arg0 interface{}) *gomock.Call {
+func (mr *MockGoalServiceMockRecorder) ReadUserGoals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserGoals", reflect.TypeOf((*MockGoalService)(nil).ReadUserGoals), arg0, arg1)
+}
+
+// SettleGoals mocks base method.
+func (m *MockGoalService) SettleGoals(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleGoals", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettleGoals indicates an expected call of SettleGoals.
+func (mr *MockGoalServiceMockRecorder) SettleGoals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleGoals", reflect.TypeOf((*MockGoalService)(nil).SettleGoals), arg0)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockGoalService) UpdateGoal(arg0, arg1 int64, arg2 model.UpdateGoalRequest) (*model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockGoalServiceMockRecorder) UpdateGoal(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserGoals", reflect.TypeOf((*MockGoalService)(nil).ReadUserGoals), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockGoalService)(nil).UpdateGoal), arg0, arg1, arg2)
 }
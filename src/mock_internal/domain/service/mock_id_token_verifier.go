@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: IDTokenVerifier)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIDTokenVerifier is a mock of IDTokenVerifier interface.
+type MockIDTokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDTokenVerifierMockRecorder
+}
+
+// MockIDTokenVerifierMockRecorder is the mock recorder for MockIDTokenVerifier.
+type MockIDTokenVerifierMockRecorder struct {
+	mock *MockIDTokenVerifier
+}
+
+// NewMockIDTokenVerifier creates a new mock instance.
+func NewMockIDTokenVerifier(ctrl *gomock.Controller) *MockIDTokenVerifier {
+	mock := &MockIDTokenVerifier{ctrl: ctrl}
+	mock.recorder = &MockIDTokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDTokenVerifier) EXPECT() *MockIDTokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockIDTokenVerifier) Verify(arg0 context.Context, arg1 string) (*model.GoogleIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0, arg1)
+	ret0, _ := ret[0].(*model.GoogleIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIDTokenVerifierMockRecorder) Verify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIDTokenVerifier)(nil).Verify), arg0, arg1)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: MediaService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaService is a mock of MediaService interface.
+type MockMediaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaServiceMockRecorder
+}
+
+// MockMediaServiceMockRecorder is the mock recorder for MockMediaService.
+type MockMediaServiceMockRecorder struct {
+	mock *MockMediaService
+}
+
+// NewMockMediaService creates a new mock instance.
+func NewMockMediaService(ctrl *gomock.Controller) *MockMediaService {
+	mock := &MockMediaService{ctrl: ctrl}
+	mock.recorder = &MockMediaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaService) EXPECT() *MockMediaServiceMockRecorder {
+	return m.recorder
+}
+
+// ReadMedia mocks base method.
+func (m *MockMediaService) ReadMedia(arg0 context.Context, arg1 string, arg2 bool) (*model.MediaContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.MediaContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMedia indicates an expected call of ReadMedia.
+func (mr *MockMediaServiceMockRecorder) ReadMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMedia", reflect.TypeOf((*MockMediaService)(nil).ReadMedia), arg0, arg1, arg2)
+}
+
+// SetAvatar mocks base method.
+func (m *MockMediaService) SetAvatar(arg0, arg1 int64, arg2 string) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvatar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAvatar indicates an expected call of SetAvatar.
+func (mr *MockMediaServiceMockRecorder) SetAvatar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvatar", reflect.TypeOf((*MockMediaService)(nil).SetAvatar), arg0, arg1, arg2)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaService) UploadMedia(arg0 context.Context, arg1 int64, arg2 io.Reader) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaServiceMockRecorder) UploadMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaService)(nil).UploadMedia), arg0, arg1, arg2)
+}
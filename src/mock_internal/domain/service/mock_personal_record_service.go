@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: PersonalRecordService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonalRecordService is a mock of PersonalRecordService interface.
+type MockPersonalRecordService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalRecordServiceMockRecorder
+}
+
+// MockPersonalRecordServiceMockRecorder is the mock recorder for MockPersonalRecordService.
+type MockPersonalRecordServiceMockRecorder struct {
+	mock *MockPersonalRecordService
+}
+
+// NewMockPersonalRecordService creates a new mock instance.
+func NewMockPersonalRecordService(ctrl *gomock.Controller) *MockPersonalRecordService {
+	mock := &MockPersonalRecordService{ctrl: ctrl}
+	mock.recorder = &MockPersonalRecordServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalRecordService) EXPECT() *MockPersonalRecordServiceMockRecorder {
+	return m.recorder
+}
+
+// ReadExerciseRecords mocks base method.
+func (m *MockPersonalRecordService) ReadExerciseRecords(arg0 model.ReadRecordsRequest) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExerciseRecords", arg0)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExerciseRecords indicates an expected call of ReadExerciseRecords.
+func (mr *MockPersonalRecordServiceMockRecorder) ReadExerciseRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExerciseRecords", reflect.TypeOf((*MockPersonalRecordService)(nil).ReadExerciseRecords), arg0)
+}
+
+// ReadUserRecords mocks base method.
+func (m *MockPersonalRecordService) ReadUserRecords(arg0 model.ReadRecordsRequest) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserRecords", arg0)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserRecords indicates an expected call of ReadUserRecords.
+func (mr *MockPersonalRecordServiceMockRecorder) ReadUserRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserRecords", reflect.TypeOf((*MockPersonalRecordService)(nil).ReadUserRecords), arg0)
+}
+
+// RecordSet mocks base method.
+func (m *MockPersonalRecordService) RecordSet(arg0 int64, arg1 *model.WorkoutSet) ([]*model.PersonalRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSet", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PersonalRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSet indicates an expected call of RecordSet.
+func (mr *MockPersonalRecordServiceMockRecorder) RecordSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSet", reflect.TypeOf((*MockPersonalRecordService)(nil).RecordSet), arg0, arg1)
+}
@@ -62,6 +62,21 @@ func (mr *MockPostServiceMockRecorder) CommentOnPost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentOnPost", reflect.TypeOf((*MockPostService)(nil).CommentOnPost), arg0)
 }
 
+// CopyRoutine mocks base method.
+func (m *MockPostService) CopyRoutine(arg0, arg1 int64) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyRoutine", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyRoutine indicates an expected call of CopyRoutine.
+func (mr *MockPostServiceMockRecorder) CopyRoutine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyRoutine", reflect.TypeOf((*MockPostService)(nil).CopyRoutine), arg0, arg1)
+}
+
 // CreatePost mocks base method.
 func (m *MockPostService) CreatePost(arg0 model.CreatePostRequest) (*model.Post, error) {
 	m.ctrl.T.Helper()
@@ -77,18 +92,32 @@ func (mr *MockPostServiceMockRecorder) CreatePost(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockPostService)(nil).CreatePost), arg0)
 }
 
+// DeleteComment mocks base method.
+func (m *MockPostService) DeleteComment(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockPostServiceMockRecorder) DeleteComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockPostService)(nil).DeleteComment), arg0, arg1)
+}
+
 // DeletePost mocks base method.
-func (m *MockPostService) DeletePost(arg0 int64) error {
+func (m *MockPostService) DeletePost(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePost", arg0)
+	ret := m.ctrl.Call(m, "DeletePost", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePost indicates an expected call of DeletePost.
-func (mr *MockPostServiceMockRecorder) DeletePost(arg0 interface{}) *gomock.Call {
+func (mr *MockPostServiceMockRecorder) DeletePost(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockPostService)(nil).DeletePost), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockPostService)(nil).DeletePost), arg0, arg1)
 }
 
 // LikePost mocks base method.
@@ -106,11 +135,41 @@ func (mr *MockPostServiceMockRecorder) LikePost(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikePost", reflect.TypeOf((*MockPostService)(nil).LikePost), arg0)
 }
 
+// ReadComments mocks base method.
+func (m *MockPostService) ReadComments(arg0 model.ReadCommentsRequest) (*model.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadComments", arg0)
+	ret0, _ := ret[0].(*model.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadComments indicates an expected call of ReadComments.
+func (mr *MockPostServiceMockRecorder) ReadComments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadComments", reflect.TypeOf((*MockPostService)(nil).ReadComments), arg0)
+}
+
+// ReadPostRevisions mocks base method.
+func (m *MockPostService) ReadPostRevisions(arg0, arg1 int64) ([]*model.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPostRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPostRevisions indicates an expected call of ReadPostRevisions.
+func (mr *MockPostServiceMockRecorder) ReadPostRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostRevisions", reflect.TypeOf((*MockPostService)(nil).ReadPostRevisions), arg0, arg1)
+}
+
 // ReadPosts mocks base method.
-func (m *MockPostService) ReadPosts(arg0 int64) ([]*model.Post, error) {
+func (m *MockPostService) ReadPosts(arg0 model.ReadPostRequest) (*model.PostPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReadPosts", arg0)
-	ret0, _ := ret[0].([]*model.Post)
+	ret0, _ := ret[0].(*model.PostPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -136,6 +195,21 @@ func (mr *MockPostServiceMockRecorder) ReadPostsByUserID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPostsByUserID", reflect.TypeOf((*MockPostService)(nil).ReadPostsByUserID), arg0, arg1)
 }
 
+// ReadReactions mocks base method.
+func (m *MockPostService) ReadReactions(arg0 model.ReadReactionsRequest) (*model.ReactionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadReactions", arg0)
+	ret0, _ := ret[0].(*model.ReactionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadReactions indicates an expected call of ReadReactions.
+func (mr *MockPostServiceMockRecorder) ReadReactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadReactions", reflect.TypeOf((*MockPostService)(nil).ReadReactions), arg0)
+}
+
 // UnlikePost mocks base method.
 func (m *MockPostService) UnlikePost(arg0 model.UnikePostRequest) (*model.Post, error) {
 	m.ctrl.T.Helper()
@@ -151,17 +225,32 @@ func (mr *MockPostServiceMockRecorder) UnlikePost(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlikePost", reflect.TypeOf((*MockPostService)(nil).UnlikePost), arg0)
 }
 
+// UpdateComment mocks base method.
+func (m *MockPostService) UpdateComment(arg0 int64, arg1 model.UpdateCommentRequest) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockPostServiceMockRecorder) UpdateComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockPostService)(nil).UpdateComment), arg0, arg1)
+}
+
 // UpdatePost mocks base method.
-func (m *MockPostService) UpdatePost(arg0 model.UpdatePostRequest) (*model.Post, error) {
+func (m *MockPostService) UpdatePost(arg0 int64, arg1 model.UpdatePostRequest) (*model.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePost", arg0)
+	ret := m.ctrl.Call(m, "UpdatePost", arg0, arg1)
 	ret0, _ := ret[0].(*model.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePost indicates an expected call of UpdatePost.
-func (mr *MockPostServiceMockRecorder) UpdatePost(arg0 interface{}) *gomock.Call {
+func (mr *MockPostServiceMockRecorder) UpdatePost(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockPostService)(nil).UpdatePost), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockPostService)(nil).UpdatePost), arg0, arg1)
 }
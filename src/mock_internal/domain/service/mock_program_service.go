@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: ProgramService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProgramService is a mock of ProgramService interface.
+type MockProgramService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgramServiceMockRecorder
+}
+
+// MockProgramServiceMockRecorder is the mock recorder for MockProgramService.
+type MockProgramServiceMockRecorder struct {
+	mock *MockProgramService
+}
+
+// NewMockProgramService creates a new mock instance.
+func NewMockProgramService(ctrl *gomock.Controller) *MockProgramService {
+	mock := &MockProgramService{ctrl: ctrl}
+	mock.recorder = &MockProgramServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProgramService) EXPECT() *MockProgramServiceMockRecorder {
+	return m.recorder
+}
+
+// AdvanceEnrollments mocks base method.
+func (m *MockProgramService) AdvanceEnrollments() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceEnrollments")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvanceEnrollments indicates an expected call of AdvanceEnrollments.
+func (mr *MockProgramServiceMockRecorder) AdvanceEnrollments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceEnrollments", reflect.TypeOf((*MockProgramService)(nil).AdvanceEnrollments))
+}
+
+// CancelEnrollment mocks base method.
+func (m *MockProgramService) CancelEnrollment(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEnrollment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEnrollment indicates an expected call of CancelEnrollment.
+func (mr *MockProgramServiceMockRecorder) CancelEnrollment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEnrollment", reflect.TypeOf((*MockProgramService)(nil).CancelEnrollment), arg0, arg1)
+}
+
+// CreateProgram mocks base method.
+func (m *MockProgramService) CreateProgram(arg0 int64, arg1 model.CreateProgramRequest) (*model.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProgram", arg0, arg1)
+	ret0, _ := ret[0].(*model.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProgram indicates an expected call of CreateProgram.
+func (mr *MockProgramServiceMockRecorder) CreateProgram(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProgram", reflect.TypeOf((*MockProgramService)(nil).CreateProgram), arg0, arg1)
+}
+
+// DeleteProgram mocks base method.
+func (m *MockProgramService) DeleteProgram(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProgram", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProgram indicates an expected call of DeleteProgram.
+func (mr *MockProgramServiceMockRecorder) DeleteProgram(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProgram", reflect.TypeOf((*MockProgramService)(nil).DeleteProgram), arg0, arg1)
+}
+
+// Enroll mocks base method.
+func (m *MockProgramService) Enroll(arg0 int64, arg1 model.EnrollRequest) (*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockProgramServiceMockRecorder) Enroll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockProgramService)(nil).Enroll), arg0, arg1)
+}
+
+// ReadEnrollments mocks base method.
+func (m *MockProgramService) ReadEnrollments(arg0 int64) ([]*model.ProgramEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadEnrollments", arg0)
+	ret0, _ := ret[0].([]*model.ProgramEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadEnrollments indicates an expected call of ReadEnrollments.
+func (mr *MockProgramServiceMockRecorder) ReadEnrollments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadEnrollments", reflect.TypeOf((*MockProgramService)(nil).ReadEnrollments), arg0)
+}
+
+// ReadProgram mocks base method.
+func (m *MockProgramService) ReadProgram(arg0, arg1 int64) (*model.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadProgram", arg0, arg1)
+	ret0, _ := ret[0].(*model.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadProgram indicates an expected call of ReadProgram.
+func (mr *MockProgramServiceMockRecorder) ReadProgram(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadProgram", reflect.TypeOf((*MockProgramService)(nil).ReadProgram), arg0, arg1)
+}
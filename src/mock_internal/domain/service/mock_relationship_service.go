@@ -34,76 +34,46 @@ func (m *MockRelationshipService) EXPECT() *MockRelationshipServiceMockRecorder
 	return m.recorder
 }
 
-// FollowUser mocks base method.
-func (m *MockRelationshipService) FollowUser(arg0, arg1 int64) error {
+// AcceptFollowRequest mocks base method.
+func (m *MockRelationshipService) AcceptFollowRequest(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FollowUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "AcceptFollowRequest", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// FollowUser indicates an expected call of FollowUser.
-func (mr *MockRelationshipServiceMockRecorder) FollowUser(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockRelationshipService)(nil).FollowUser), arg0, arg1)
-}
-
-// ReadUserFollowers mocks base method.
-func (m *MockRelationshipService) ReadUserFollowers(arg0 int64) ([]model.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserFollowers", arg0)
-	ret0, _ := ret[0].([]model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ReadUserFollowers indicates an expected call of ReadUserFollowers.
-func (mr *MockRelationshipServiceMockRecorder) ReadUserFollowers(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserFollowers", reflect.TypeOf((*MockRelationshipService)(nil).ReadUserFollowers), arg0)
-}
-
-// ReadUserFollowing mocks base method.
-func (m *MockRelationshipService) ReadUserFollowing(arg0 int64) ([]model.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserFollowing", arg0)
-	ret0, _ := ret[0].([]model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ReadUserFollowing indicates an expected call of ReadUserFollowing.
-func (mr *MockRelationshipServiceMockRecorder) ReadUserFollowing(arg0 interface{}) *gomock.Call {
+// AcceptFollowRequest indicates an expected call of AcceptFollowRequest.
+func (mr *MockRelationshipServiceMockRecorder) AcceptFollowRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserFollowing", reflect.TypeOf((*MockRelationshipService)(nil).ReadUserFollowing), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).AcceptFollowRequest), arg0, arg1)
 }
 
-// UnfollowUser mocks base method.
-func (m *MockRelationshipService) UnfollowUser(arg0, arg1 int64) error {
+// CancelFollowRequest mocks base method.
+func (m *MockRelationshipService) CancelFollowRequest(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnfollowUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "CancelFollowRequest", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UnfollowUser indicates an expected call of UnfollowUser.
-func (mr *MockRelationshipServiceMockRecorder) UnfollowUser(arg0, arg1 interface{}) *gomock.Call {
+// CancelFollowRequest indicates an expected call of CancelFollowRequest.
+func (mr *MockRelationshipServiceMockRecorder) CancelFollowRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockRelationshipService)(nil).UnfollowUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).CancelFollowRequest), arg0, arg1)
 }
 
-// SendFollowRequest mocks base method.
-func (m *MockRelationshipService) SendFollowRequest(arg0, arg1 int64) error {
+// FollowUser mocks base method.
+func (m *MockRelationshipService) FollowUser(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendFollowRequest", arg0, arg1)
+	ret := m.ctrl.Call(m, "FollowUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendFollowRequest indicates an expected call of SendFollowRequest.
-func (mr *MockRelationshipServiceMockRecorder) SendFollowRequest(arg0, arg1 interface{}) *gomock.Call {
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockRelationshipServiceMockRecorder) FollowUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).SendFollowRequest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockRelationshipService)(nil).FollowUser), arg0, arg1)
 }
 
 // GetFollowRequest mocks base method.
@@ -136,44 +106,74 @@ func (mr *MockRelationshipServiceMockRecorder) GetPendingFollowRequests(arg0 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingFollowRequests", reflect.TypeOf((*MockRelationshipService)(nil).GetPendingFollowRequests), arg0)
 }
 
-// AcceptFollowRequest mocks base method.
-func (m *MockRelationshipService) AcceptFollowRequest(arg0 int64) error {
+// ReadUserFollowers mocks base method.
+func (m *MockRelationshipService) ReadUserFollowers(arg0 int64) ([]model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AcceptFollowRequest", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ReadUserFollowers", arg0)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AcceptFollowRequest indicates an expected call of AcceptFollowRequest.
-func (mr *MockRelationshipServiceMockRecorder) AcceptFollowRequest(arg0 interface{}) *gomock.Call {
+// ReadUserFollowers indicates an expected call of ReadUserFollowers.
+func (mr *MockRelationshipServiceMockRecorder) ReadUserFollowers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserFollowers", reflect.TypeOf((*MockRelationshipService)(nil).ReadUserFollowers), arg0)
+}
+
+// ReadUserFollowing mocks base method.
+func (m *MockRelationshipService) ReadUserFollowing(arg0 int64) ([]model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserFollowing", arg0)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserFollowing indicates an expected call of ReadUserFollowing.
+func (mr *MockRelationshipServiceMockRecorder) ReadUserFollowing(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).AcceptFollowRequest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserFollowing", reflect.TypeOf((*MockRelationshipService)(nil).ReadUserFollowing), arg0)
 }
 
 // RejectFollowRequest mocks base method.
-func (m *MockRelationshipService) RejectFollowRequest(arg0 int64) error {
+func (m *MockRelationshipService) RejectFollowRequest(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RejectFollowRequest", arg0)
+	ret := m.ctrl.Call(m, "RejectFollowRequest", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RejectFollowRequest indicates an expected call of RejectFollowRequest.
-func (mr *MockRelationshipServiceMockRecorder) RejectFollowRequest(arg0 interface{}) *gomock.Call {
+func (mr *MockRelationshipServiceMockRecorder) RejectFollowRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).RejectFollowRequest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).RejectFollowRequest), arg0, arg1)
 }
 
-// CancelFollowRequest mocks base method.
-func (m *MockRelationshipService) CancelFollowRequest(arg0, arg1 int64) error {
+// SendFollowRequest mocks base method.
+func (m *MockRelationshipService) SendFollowRequest(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelFollowRequest", arg0, arg1)
+	ret := m.ctrl.Call(m, "SendFollowRequest", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CancelFollowRequest indicates an expected call of CancelFollowRequest.
-func (mr *MockRelationshipServiceMockRecorder) CancelFollowRequest(arg0, arg1 interface{}) *gomock.Call {
+// SendFollowRequest indicates an expected call of SendFollowRequest.
+func (mr *MockRelationshipServiceMockRecorder) SendFollowRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).CancelFollowRequest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFollowRequest", reflect.TypeOf((*MockRelationshipService)(nil).SendFollowRequest), arg0, arg1)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockRelationshipService) UnfollowUser(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockRelationshipServiceMockRecorder) UnfollowUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockRelationshipService)(nil).UnfollowUser), arg0, arg1)
 }
@@ -35,88 +35,178 @@ func (m *MockRoutineService) EXPECT() *MockRoutineServiceMockRecorder {
 }
 
 // AddExerciseToRoutine mocks base method.
-func (m *MockRoutineService) AddExerciseToRoutine(arg0, arg1 int64) error {
+func (m *MockRoutineService) AddExerciseToRoutine(arg0, arg1, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddExerciseToRoutine", arg0, arg1)
+	ret := m.ctrl.Call(m, "AddExerciseToRoutine", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddExerciseToRoutine indicates an expected call of AddExerciseToRoutine.
-func (mr *MockRoutineServiceMockRecorder) AddExerciseToRoutine(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) AddExerciseToRoutine(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExerciseToRoutine", reflect.TypeOf((*MockRoutineService)(nil).AddExerciseToRoutine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExerciseToRoutine", reflect.TypeOf((*MockRoutineService)(nil).AddExerciseToRoutine), arg0, arg1, arg2)
+}
+
+// CopyProgramRoutine mocks base method.
+func (m *MockRoutineService) CopyProgramRoutine(arg0, arg1 int64) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyProgramRoutine", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyProgramRoutine indicates an expected call of CopyProgramRoutine.
+func (mr *MockRoutineServiceMockRecorder) CopyProgramRoutine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyProgramRoutine", reflect.TypeOf((*MockRoutineService)(nil).CopyProgramRoutine), arg0, arg1)
+}
+
+// CopyRoutine mocks base method.
+func (m *MockRoutineService) CopyRoutine(arg0, arg1 int64) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyRoutine", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyRoutine indicates an expected call of CopyRoutine.
+func (mr *MockRoutineServiceMockRecorder) CopyRoutine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyRoutine", reflect.TypeOf((*MockRoutineService)(nil).CopyRoutine), arg0, arg1)
 }
 
 // CreateRoutine mocks base method.
-func (m *MockRoutineService) CreateRoutine(arg0 int64, arg1 model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
+func (m *MockRoutineService) CreateRoutine(arg0, arg1 int64, arg2 model.CreateRoutineRequest) (*model.ExerciseRoutine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRoutine", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateRoutine", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.ExerciseRoutine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRoutine indicates an expected call of CreateRoutine.
-func (mr *MockRoutineServiceMockRecorder) CreateRoutine(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) CreateRoutine(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoutine", reflect.TypeOf((*MockRoutineService)(nil).CreateRoutine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoutine", reflect.TypeOf((*MockRoutineService)(nil).CreateRoutine), arg0, arg1, arg2)
 }
 
 // DeleteRoutine mocks base method.
-func (m *MockRoutineService) DeleteRoutine(arg0 int64) error {
+func (m *MockRoutineService) DeleteRoutine(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRoutine", arg0)
+	ret := m.ctrl.Call(m, "DeleteRoutine", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRoutine indicates an expected call of DeleteRoutine.
-func (mr *MockRoutineServiceMockRecorder) DeleteRoutine(arg0 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) DeleteRoutine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoutine", reflect.TypeOf((*MockRoutineService)(nil).DeleteRoutine), arg0, arg1)
+}
+
+// ForkRoutine mocks base method.
+func (m *MockRoutineService) ForkRoutine(arg0, arg1 int64) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkRoutine", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkRoutine indicates an expected call of ForkRoutine.
+func (mr *MockRoutineServiceMockRecorder) ForkRoutine(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoutine", reflect.TypeOf((*MockRoutineService)(nil).DeleteRoutine), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkRoutine", reflect.TypeOf((*MockRoutineService)(nil).ForkRoutine), arg0, arg1)
+}
+
+// ReadPublicRoutines mocks base method.
+func (m *MockRoutineService) ReadPublicRoutines(arg0 model.ReadPublicRoutinesRequest) (*model.RoutinePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPublicRoutines", arg0)
+	ret0, _ := ret[0].(*model.RoutinePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPublicRoutines indicates an expected call of ReadPublicRoutines.
+func (mr *MockRoutineServiceMockRecorder) ReadPublicRoutines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPublicRoutines", reflect.TypeOf((*MockRoutineService)(nil).ReadPublicRoutines), arg0)
 }
 
 // ReadRoutineWithExercises mocks base method.
-func (m *MockRoutineService) ReadRoutineWithExercises(arg0 int64) (*model.ExerciseRoutine, error) {
+func (m *MockRoutineService) ReadRoutineWithExercises(arg0, arg1 int64) (*model.ExerciseRoutine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadRoutineWithExercises", arg0)
+	ret := m.ctrl.Call(m, "ReadRoutineWithExercises", arg0, arg1)
 	ret0, _ := ret[0].(*model.ExerciseRoutine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadRoutineWithExercises indicates an expected call of ReadRoutineWithExercises.
-func (mr *MockRoutineServiceMockRecorder) ReadRoutineWithExercises(arg0 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) ReadRoutineWithExercises(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRoutineWithExercises", reflect.TypeOf((*MockRoutineService)(nil).ReadRoutineWithExercises), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRoutineWithExercises", reflect.TypeOf((*MockRoutineService)(nil).ReadRoutineWithExercises), arg0, arg1)
 }
 
 // ReadUserRoutines mocks base method.
-func (m *MockRoutineService) ReadUserRoutines(arg0 int64) ([]*model.ExerciseRoutine, error) {
+func (m *MockRoutineService) ReadUserRoutines(arg0, arg1 int64) ([]*model.ExerciseRoutine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadUserRoutines", arg0)
+	ret := m.ctrl.Call(m, "ReadUserRoutines", arg0, arg1)
 	ret0, _ := ret[0].([]*model.ExerciseRoutine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadUserRoutines indicates an expected call of ReadUserRoutines.
-func (mr *MockRoutineServiceMockRecorder) ReadUserRoutines(arg0 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) ReadUserRoutines(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserRoutines", reflect.TypeOf((*MockRoutineService)(nil).ReadUserRoutines), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserRoutines", reflect.TypeOf((*MockRoutineService)(nil).ReadUserRoutines), arg0, arg1)
 }
 
 // RemoveExerciseFromRoutine mocks base method.
-func (m *MockRoutineService) RemoveExerciseFromRoutine(arg0, arg1 int64) error {
+func (m *MockRoutineService) RemoveExerciseFromRoutine(arg0, arg1, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveExerciseFromRoutine", arg0, arg1)
+	ret := m.ctrl.Call(m, "RemoveExerciseFromRoutine", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveExerciseFromRoutine indicates an expected call of RemoveExerciseFromRoutine.
-func (mr *MockRoutineServiceMockRecorder) RemoveExerciseFromRoutine(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRoutineServiceMockRecorder) RemoveExerciseFromRoutine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExerciseFromRoutine", reflect.TypeOf((*MockRoutineService)(nil).RemoveExerciseFromRoutine), arg0, arg1, arg2)
+}
+
+// ReorderRoutineExercises mocks base method.
+func (m *MockRoutineService) ReorderRoutineExercises(arg0, arg1 int64, arg2 []int64) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRoutineExercises", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderRoutineExercises indicates an expected call of ReorderRoutineExercises.
+func (mr *MockRoutineServiceMockRecorder) ReorderRoutineExercises(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRoutineExercises", reflect.TypeOf((*MockRoutineService)(nil).ReorderRoutineExercises), arg0, arg1, arg2)
+}
+
+// UpdateRoutine mocks base method.
+func (m *MockRoutineService) UpdateRoutine(arg0 int64, arg1 model.UpdateRoutineRequest) (*model.ExerciseRoutine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoutine", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExerciseRoutine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRoutine indicates an expected call of UpdateRoutine.
+func (mr *MockRoutineServiceMockRecorder) UpdateRoutine(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExerciseFromRoutine", reflect.TypeOf((*MockRoutineService)(nil).RemoveExerciseFromRoutine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoutine", reflect.TypeOf((*MockRoutineService)(nil).UpdateRoutine), arg0, arg1)
 }
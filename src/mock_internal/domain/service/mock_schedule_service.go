@@ -34,6 +34,21 @@ func (m *MockScheduleService) EXPECT() *MockScheduleServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateCalendarFeed mocks base method.
+func (m *MockScheduleService) CreateCalendarFeed(arg0 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCalendarFeed", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCalendarFeed indicates an expected call of CreateCalendarFeed.
+func (mr *MockScheduleServiceMockRecorder) CreateCalendarFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendarFeed", reflect.TypeOf((*MockScheduleService)(nil).CreateCalendarFeed), arg0)
+}
+
 // CreateSchedule mocks base method.
 func (m *MockScheduleService) CreateSchedule(arg0 model.CreateScheduleRequest) (*model.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -49,33 +64,151 @@ func (mr *MockScheduleServiceMockRecorder) CreateSchedule(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockScheduleService)(nil).CreateSchedule), arg0)
 }
 
+// CreateScheduleException mocks base method.
+func (m *MockScheduleService) CreateScheduleException(arg0 int64, arg1 model.CreateScheduleExceptionRequest) (*model.ScheduleException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduleException", arg0, arg1)
+	ret0, _ := ret[0].(*model.ScheduleException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduleException indicates an expected call of CreateScheduleException.
+func (mr *MockScheduleServiceMockRecorder) CreateScheduleException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduleException", reflect.TypeOf((*MockScheduleService)(nil).CreateScheduleException), arg0, arg1)
+}
+
+// DeleteOccurrenceMark mocks base method.
+func (m *MockScheduleService) DeleteOccurrenceMark(arg0 int64, arg1 model.DeleteOccurrenceMarkRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOccurrenceMark", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOccurrenceMark indicates an expected call of DeleteOccurrenceMark.
+func (mr *MockScheduleServiceMockRecorder) DeleteOccurrenceMark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOccurrenceMark", reflect.TypeOf((*MockScheduleService)(nil).DeleteOccurrenceMark), arg0, arg1)
+}
+
 // DeleteSchedule mocks base method.
-func (m *MockScheduleService) DeleteSchedule(arg0 model.DeleteScheduleRequest) error {
+func (m *MockScheduleService) DeleteSchedule(arg0 int64, arg1 model.DeleteScheduleRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSchedule", arg0)
+	ret := m.ctrl.Call(m, "DeleteSchedule", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSchedule indicates an expected call of DeleteSchedule.
-func (mr *MockScheduleServiceMockRecorder) DeleteSchedule(arg0 interface{}) *gomock.Call {
+func (mr *MockScheduleServiceMockRecorder) DeleteSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockScheduleService)(nil).DeleteSchedule), arg0, arg1)
+}
+
+// DeleteScheduleException mocks base method.
+func (m *MockScheduleService) DeleteScheduleException(arg0 int64, arg1 model.DeleteScheduleExceptionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduleException", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduleException indicates an expected call of DeleteScheduleException.
+func (mr *MockScheduleServiceMockRecorder) DeleteScheduleException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduleException", reflect.TypeOf((*MockScheduleService)(nil).DeleteScheduleException), arg0, arg1)
+}
+
+// ExportCalendar mocks base method.
+func (m *MockScheduleService) ExportCalendar(arg0 int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCalendar", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCalendar indicates an expected call of ExportCalendar.
+func (mr *MockScheduleServiceMockRecorder) ExportCalendar(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockScheduleService)(nil).DeleteSchedule), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCalendar", reflect.TypeOf((*MockScheduleService)(nil).ExportCalendar), arg0)
+}
+
+// ExportCalendarFeed mocks base method.
+func (m *MockScheduleService) ExportCalendarFeed(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCalendarFeed", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCalendarFeed indicates an expected call of ExportCalendarFeed.
+func (mr *MockScheduleServiceMockRecorder) ExportCalendarFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCalendarFeed", reflect.TypeOf((*MockScheduleService)(nil).ExportCalendarFeed), arg0)
+}
+
+// MarkOccurrence mocks base method.
+func (m *MockScheduleService) MarkOccurrence(arg0 int64, arg1 model.MarkOccurrenceRequest) (*model.OccurrenceMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(*model.OccurrenceMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOccurrence indicates an expected call of MarkOccurrence.
+func (mr *MockScheduleServiceMockRecorder) MarkOccurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOccurrence", reflect.TypeOf((*MockScheduleService)(nil).MarkOccurrence), arg0, arg1)
+}
+
+// ReadAdherence mocks base method.
+func (m *MockScheduleService) ReadAdherence(arg0 int64, arg1 model.ReadAdherenceRequest) (*model.Adherence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAdherence", arg0, arg1)
+	ret0, _ := ret[0].(*model.Adherence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAdherence indicates an expected call of ReadAdherence.
+func (mr *MockScheduleServiceMockRecorder) ReadAdherence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAdherence", reflect.TypeOf((*MockScheduleService)(nil).ReadAdherence), arg0, arg1)
+}
+
+// ReadOccurrences mocks base method.
+func (m *MockScheduleService) ReadOccurrences(arg0 model.ReadOccurrencesRequest) ([]*model.ScheduleOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOccurrences", arg0)
+	ret0, _ := ret[0].([]*model.ScheduleOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadOccurrences indicates an expected call of ReadOccurrences.
+func (mr *MockScheduleServiceMockRecorder) ReadOccurrences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOccurrences", reflect.TypeOf((*MockScheduleService)(nil).ReadOccurrences), arg0)
 }
 
 // ReadScheduleByID mocks base method.
-func (m *MockScheduleService) ReadScheduleByID(arg0 int64) (*model.Schedule, error) {
+func (m *MockScheduleService) ReadScheduleByID(arg0, arg1 int64) (*model.Schedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadScheduleByID", arg0)
+	ret := m.ctrl.Call(m, "ReadScheduleByID", arg0, arg1)
 	ret0, _ := ret[0].(*model.Schedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadScheduleByID indicates an expected call of ReadScheduleByID.
-func (mr *MockScheduleServiceMockRecorder) ReadScheduleByID(arg0 interface{}) *gomock.Call {
+func (mr *MockScheduleServiceMockRecorder) ReadScheduleByID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScheduleByID", reflect.TypeOf((*MockScheduleService)(nil).ReadScheduleByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadScheduleByID", reflect.TypeOf((*MockScheduleService)(nil).ReadScheduleByID), arg0, arg1)
 }
 
 // ReadUserSchedules mocks base method.
@@ -108,6 +241,20 @@ func (mr *MockScheduleServiceMockRecorder) ReadUserSchedulesByDay(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserSchedulesByDay", reflect.TypeOf((*MockScheduleService)(nil).ReadUserSchedulesByDay), arg0, arg1)
 }
 
+// RevokeCalendarFeed mocks base method.
+func (m *MockScheduleService) RevokeCalendarFeed(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCalendarFeed", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCalendarFeed indicates an expected call of RevokeCalendarFeed.
+func (mr *MockScheduleServiceMockRecorder) RevokeCalendarFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCalendarFeed", reflect.TypeOf((*MockScheduleService)(nil).RevokeCalendarFeed), arg0)
+}
+
 // UpdateSchedule mocks base method.
 func (m *MockScheduleService) UpdateSchedule(arg0 model.UpdateScheduleRequest) (*model.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -50,17 +50,17 @@ func (mr *MockUserServiceMockRecorder) CreateUser(arg0 interface{}) *gomock.Call
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserService) DeleteUser(arg0 model.DeleteUserRequest) error {
+func (m *MockUserService) DeleteUser(arg0 int64, arg1 model.DeleteUserRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0)
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceMockRecorder) DeleteUser(arg0 interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), arg0, arg1)
 }
 
 // ReadUserByEmail mocks base method.
@@ -109,16 +109,16 @@ func (mr *MockUserServiceMockRecorder) ReadUsers() *gomock.Call {
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserService) UpdateUser(arg0 model.UpdateUserRequest) (*model.User, error) {
+func (m *MockUserService) UpdateUser(arg0 int64, arg1 model.UpdateUserRequest) (*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
 	ret0, _ := ret[0].(*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserServiceMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), arg0, arg1)
 }
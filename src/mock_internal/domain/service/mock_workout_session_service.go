@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workoutpal/src/internal/domain/service (interfaces: WorkoutSessionService)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	model "workoutpal/src/internal/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkoutSessionService is a mock of WorkoutSessionService interface.
+type MockWorkoutSessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkoutSessionServiceMockRecorder
+}
+
+// MockWorkoutSessionServiceMockRecorder is the mock recorder for MockWorkoutSessionService.
+type MockWorkoutSessionServiceMockRecorder struct {
+	mock *MockWorkoutSessionService
+}
+
+// NewMockWorkoutSessionService creates a new mock instance.
+func NewMockWorkoutSessionService(ctrl *gomock.Controller) *MockWorkoutSessionService {
+	mock := &MockWorkoutSessionService{ctrl: ctrl}
+	mock.recorder = &MockWorkoutSessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkoutSessionService) EXPECT() *MockWorkoutSessionServiceMockRecorder {
+	return m.recorder
+}
+
+// FinishSession mocks base method.
+func (m *MockWorkoutSessionService) FinishSession(arg0 model.FinishWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishSession indicates an expected call of FinishSession.
+func (mr *MockWorkoutSessionServiceMockRecorder) FinishSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSession", reflect.TypeOf((*MockWorkoutSessionService)(nil).FinishSession), arg0)
+}
+
+// LogSet mocks base method.
+func (m *MockWorkoutSessionService) LogSet(arg0 model.LogWorkoutSetRequest) (*model.WorkoutSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogSet", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogSet indicates an expected call of LogSet.
+func (mr *MockWorkoutSessionServiceMockRecorder) LogSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSet", reflect.TypeOf((*MockWorkoutSessionService)(nil).LogSet), arg0)
+}
+
+// ReadActiveSession mocks base method.
+func (m *MockWorkoutSessionService) ReadActiveSession(arg0 int64) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadActiveSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadActiveSession indicates an expected call of ReadActiveSession.
+func (mr *MockWorkoutSessionServiceMockRecorder) ReadActiveSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadActiveSession", reflect.TypeOf((*MockWorkoutSessionService)(nil).ReadActiveSession), arg0)
+}
+
+// ReadSessionByID mocks base method.
+func (m *MockWorkoutSessionService) ReadSessionByID(arg0, arg1 int64) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSessionByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSessionByID indicates an expected call of ReadSessionByID.
+func (mr *MockWorkoutSessionServiceMockRecorder) ReadSessionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSessionByID", reflect.TypeOf((*MockWorkoutSessionService)(nil).ReadSessionByID), arg0, arg1)
+}
+
+// ReadUserSessions mocks base method.
+func (m *MockWorkoutSessionService) ReadUserSessions(arg0 int64) ([]*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadUserSessions", arg0)
+	ret0, _ := ret[0].([]*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadUserSessions indicates an expected call of ReadUserSessions.
+func (mr *MockWorkoutSessionServiceMockRecorder) ReadUserSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserSessions", reflect.TypeOf((*MockWorkoutSessionService)(nil).ReadUserSessions), arg0)
+}
+
+// StartSession mocks base method.
+func (m *MockWorkoutSessionService) StartSession(arg0 model.StartWorkoutSessionRequest) (*model.WorkoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", arg0)
+	ret0, _ := ret[0].(*model.WorkoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockWorkoutSessionServiceMockRecorder) StartSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockWorkoutSessionService)(nil).StartSession), arg0)
+}
@@ -3,3 +3,6 @@ package constants
 const USER_ID_KEY = "userID"
 const ID_KEY = "id"
 const DAY_OF_WEEK_KEY = "dayOfWeek"
+const CALENDAR_TOKEN_KEY = "token"
+const DATE_KEY = "date"
+const NEXT_CURSOR_HEADER = "X-Next-Cursor"
@@ -28,5 +28,6 @@ const (
 	UNAVAILABLE UserMessage = "The system is temporarily unavailable. Please try again later."
 	CONFLICT    UserMessage = "The resource is currently locked or being modified."
 
-	AUTH UserMessage = "Invalid username or password."
+	AUTH      UserMessage = "Invalid username or password."
+	FORBIDDEN UserMessage = "You do not have permission to perform this action."
 )
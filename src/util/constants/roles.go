@@ -0,0 +1,5 @@
+package constants
+
+const ROLE_ADMIN = "admin"
+const ROLE_MODERATOR = "moderator"
+const ROLE_USER = "user"
@@ -31,12 +31,16 @@ func Error(err error, instance string) *model.Error {
 		Detail:   userMsg,
 		Instance: instance,
 		Error:    err.Error(),
+		Fields:   extractFields(err),
 	}
 }
 
 func extractFields(err error) map[string]any {
-	m := make(map[string]any)
-	return m
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr.Fields
+	}
+	return nil
 }
 
 func msgAndStatus(err error) (constants.UserMessage, int) {
@@ -87,6 +91,19 @@ func msgAndStatus(err error) (constants.UserMessage, int) {
 		return constants.INVALID_FORMAT, http.StatusUnprocessableEntity
 	}
 
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return constants.UserMessage(err.Error()), http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		return constants.FORBIDDEN, http.StatusForbidden
+	case errors.Is(err, ErrConflict):
+		return constants.UserMessage(err.Error()), http.StatusConflict
+	case errors.Is(err, ErrUnauthorized):
+		return constants.UserMessage(err.Error()), http.StatusUnauthorized
+	case errors.Is(err, ErrTooLarge):
+		return constants.UserMessage(err.Error()), http.StatusRequestEntityTooLarge
+	}
+
 	// Check for specific error messages from repository layer
 	if strings.Contains(err.Error(), "user already exists") {
 		return constants.DUPLICATE, http.StatusBadRequest
@@ -0,0 +1,28 @@
+package util
+
+import "errors"
+
+// Sentinel errors services can wrap (fmt.Errorf("%w: ...", ...)) so that
+// Error/msgAndStatus can map them to a proper HTTP status.
+var (
+	ErrInvalidInput = errors.New("invalid input")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrTooLarge     = errors.New("too large")
+)
+
+// FieldError attaches machine readable fields to an error; Error copies them
+// into the response so clients don't have to parse the message.
+type FieldError struct {
+	Err    error
+	Fields map[string]any
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func WithFields(err error, fields map[string]any) error {
+	return &FieldError{Err: err, Fields: fields}
+}